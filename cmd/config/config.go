@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"time"
 )
 
 type Application struct {
@@ -36,15 +37,63 @@ type AWS struct {
 }
 
 type Common struct {
-	Postgres PostgresConfig `mapstructure:"postgres" validate:"required"`
+	Postgres     PostgresConfig     `mapstructure:"postgres" validate:"required"`
+	Currency     CurrencyConfig     `mapstructure:"currency" validate:"required"`
+	PriceAnomaly PriceAnomalyConfig `mapstructure:"price-anomaly" validate:"required"`
+	Retention    RetentionConfig    `mapstructure:"retention" validate:"required"`
 }
 
+// RetentionConfig sets how long soft deleted rows are kept before the purge removes them
+type RetentionConfig struct {
+	SoftDeleted time.Duration `mapstructure:"soft-deleted" validate:"required,gt=0"`
+}
+
+// CurrencyConfig sets the currency prices are converted to when compared across vendors
+type CurrencyConfig struct {
+	BaseCurrency string `mapstructure:"base-currency" validate:"required"`
+}
+
+// PriceAnomalyConfig sets how far a price may move away from its previous value or from
+// the prices other vendors offer for the same product before it is flagged. A zero ratio
+// disables that comparison, the mode decides whether a flagged price is blocked, applied
+// once the requester confirms it or held until someone else approves it
+type PriceAnomalyConfig struct {
+	Mode            string  `mapstructure:"mode" validate:"required,oneof=block confirm approval"`
+	MaxHistoryRatio float64 `mapstructure:"max-history-ratio" validate:"gte=0"`
+	MaxPeerRatio    float64 `mapstructure:"max-peer-ratio" validate:"gte=0"`
+	MinPeers        int     `mapstructure:"min-peers" validate:"gte=0"`
+}
+
+// PostgresConfig sets the database to connect to. QueryTimeout bounds every query of a
+// request on top of the request being cancelled, zero leaves queries unbounded. Read only
+// queries are spread over the replicas, which are pinged every ReplicaCheckInterval.
+// Pool sizes the primary and each replica alike
 type PostgresConfig struct {
-	Host     string `mapstructure:"host" validate:"required"`
-	Name     string `mapstructure:"name" validate:"required"`
-	Username string `mapstructure:"username" validate:"required"`
-	Password string `mapstructure:"password" validate:"required"`
-	Port     string `mapstructure:"port" validate:"required"`
+	Host                 string                  `mapstructure:"host" validate:"required"`
+	Name                 string                  `mapstructure:"name" validate:"required"`
+	Username             string                  `mapstructure:"username" validate:"required"`
+	Password             string                  `mapstructure:"password" validate:"required"`
+	Port                 string                  `mapstructure:"port" validate:"required"`
+	QueryTimeout         time.Duration           `mapstructure:"query-timeout" validate:"gte=0"`
+	Replicas             []PostgresReplicaConfig `mapstructure:"replicas" validate:"dive"`
+	ReplicaCheckInterval time.Duration           `mapstructure:"replica-check-interval" validate:"gte=0"`
+	Pool                 PostgresPoolConfig      `mapstructure:"pool"`
+}
+
+// PostgresPoolConfig sizes a connection pool, zero values keep the database/sql defaults
+// of unlimited open connections, two idle ones and no lifetime
+type PostgresPoolConfig struct {
+	MaxOpenConns    int           `mapstructure:"max-open-conns" validate:"gte=0"`
+	MaxIdleConns    int           `mapstructure:"max-idle-conns" validate:"gte=0"`
+	ConnMaxLifetime time.Duration `mapstructure:"conn-max-lifetime" validate:"gte=0"`
+	ConnMaxIdleTime time.Duration `mapstructure:"conn-max-idle-time" validate:"gte=0"`
+}
+
+// PostgresReplicaConfig is a read replica of the database, it shares the name and
+// credentials of the primary
+type PostgresReplicaConfig struct {
+	Host string `mapstructure:"host" validate:"required"`
+	Port string `mapstructure:"port" validate:"required"`
 }
 
 type Routes struct {
@@ -52,6 +101,13 @@ type Routes struct {
 	Product     ProductRoutes     `mapstructure:"product" validate:"required"`
 	Account     AccountRoutes     `mapstructure:"account" validate:"required"`
 	EmailStatus EmailStatusRoutes `mapstructure:"email-status" validate:"required"`
+	Search      SearchRoutes      `mapstructure:"search" validate:"required"`
+	Analytics   AnalyticsRoutes   `mapstructure:"analytics" validate:"required"`
+	Currency    CurrencyRoutes    `mapstructure:"currency" validate:"required"`
+	Catalogue   CatalogueRoutes   `mapstructure:"catalogue" validate:"required"`
+	UOM         UOMRoutes         `mapstructure:"uom" validate:"required"`
+	Health      HealthRoutes      `mapstructure:"health" validate:"required"`
+	Audit       AuditRoutes       `mapstructure:"audit" validate:"required"`
 }
 
 type VendorRoutes struct {
@@ -63,13 +119,65 @@ type VendorRoutes struct {
 	AutomatedEmailBlast     string `mapstructure:"automated-email-blast" validate:"required"`
 	Evaluation              string `mapstructure:"evaluation" validate:"required"`
 	GetPopulatedEmailStatus string `mapstructure:"get-populated-email-status" validate:"required"`
+	UpdateStatus            string `mapstructure:"update-status" validate:"required"`
+	GetStatusHistory        string `mapstructure:"get-status-history" validate:"required"`
+	ReviewStatus            string `mapstructure:"review-status" validate:"required"`
+	ExportVendors           string `mapstructure:"export-vendors" validate:"required"`
+	ExportEmailStatus       string `mapstructure:"export-email-status" validate:"required"`
+	DeleteVendor            string `mapstructure:"delete-vendor" validate:"required"`
+	RestoreVendor           string `mapstructure:"restore-vendor" validate:"required"`
 }
 
 type ProductRoutes struct {
-	GetProductsByVendor string `mapstructure:"get-products-by-vendor" validate:"required"`
-	GetProductVendors   string `mapstructure:"get-product-vendors" validate:"required"`
-	UpdateProduct       string `mapstructure:"update-product" validate:"required"`
-	UpdatePrice         string `mapstructure:"update-price" validate:"required"`
+	GetProductsByVendor   string `mapstructure:"get-products-by-vendor" validate:"required"`
+	GetProductVendors     string `mapstructure:"get-product-vendors" validate:"required"`
+	UpdateProduct         string `mapstructure:"update-product" validate:"required"`
+	UpdatePrice           string `mapstructure:"update-price" validate:"required"`
+	GetPriceHistory       string `mapstructure:"get-price-history" validate:"required"`
+	ResolvePrice          string `mapstructure:"resolve-price" validate:"required"`
+	ImportPrices          string `mapstructure:"import-prices" validate:"required"`
+	ExportProductVendors  string `mapstructure:"export-product-vendors" validate:"required"`
+	ExportPriceComparison string `mapstructure:"export-price-comparison" validate:"required"`
+	CreateProductVendor   string `mapstructure:"create-product-vendor" validate:"required"`
+	DeleteProductVendor   string `mapstructure:"delete-product-vendor" validate:"required"`
+	RestoreProductVendor  string `mapstructure:"restore-product-vendor" validate:"required"`
+	CreatePrice           string `mapstructure:"create-price" validate:"required"`
+	DeletePrice           string `mapstructure:"delete-price" validate:"required"`
+	RestorePrice          string `mapstructure:"restore-price" validate:"required"`
+	GetPriceAnomalies     string `mapstructure:"get-price-anomalies" validate:"required"`
+	ReviewPriceAnomaly    string `mapstructure:"review-price-anomaly" validate:"required"`
+}
+
+type CatalogueRoutes struct {
+	CreateProduct         string `mapstructure:"create-product" validate:"required"`
+	GetProducts           string `mapstructure:"get-products" validate:"required"`
+	GetProduct            string `mapstructure:"get-product" validate:"required"`
+	DeleteProduct         string `mapstructure:"delete-product" validate:"required"`
+	RestoreProduct        string `mapstructure:"restore-product" validate:"required"`
+	CreateProductCategory string `mapstructure:"create-product-category" validate:"required"`
+	GetProductCategories  string `mapstructure:"get-product-categories" validate:"required"`
+	GetProductCategory    string `mapstructure:"get-product-category" validate:"required"`
+	DeleteProductCategory string `mapstructure:"delete-product-category" validate:"required"`
+	GetCategoryTree       string `mapstructure:"get-category-tree" validate:"required"`
+	GetCategorySubtree    string `mapstructure:"get-category-subtree" validate:"required"`
+	GetCategoryAncestors  string `mapstructure:"get-category-ancestors" validate:"required"`
+	MoveProductCategory   string `mapstructure:"move-product-category" validate:"required"`
+	CreateProductType     string `mapstructure:"create-product-type" validate:"required"`
+	GetProductTypes       string `mapstructure:"get-product-types" validate:"required"`
+	GetProductType        string `mapstructure:"get-product-type" validate:"required"`
+	DeleteProductType     string `mapstructure:"delete-product-type" validate:"required"`
+	CreateUOM             string `mapstructure:"create-uom" validate:"required"`
+	GetUOMs               string `mapstructure:"get-uoms" validate:"required"`
+	GetUOM                string `mapstructure:"get-uom" validate:"required"`
+	DeleteUOM             string `mapstructure:"delete-uom" validate:"required"`
+}
+
+type UOMRoutes struct {
+	Convert                 string `mapstructure:"convert" validate:"required"`
+	GetProductConversions   string `mapstructure:"get-product-conversions" validate:"required"`
+	CreateProductConversion string `mapstructure:"create-product-conversion" validate:"required"`
+	DeleteProductConversion string `mapstructure:"delete-product-conversion" validate:"required"`
+	UpdateBaseFactor        string `mapstructure:"update-base-factor" validate:"required"`
 }
 
 type Token struct {
@@ -80,6 +188,8 @@ type AccountRoutes struct {
 	Register       string `mapstructure:"register" validate:"required"`
 	Login          string `mapstructure:"login" validate:"required"`
 	GetCurrentUser string `mapstructure:"get-current-user" validate:"required"`
+	DeleteAccount  string `mapstructure:"delete-account" validate:"required"`
+	RestoreAccount string `mapstructure:"restore-account" validate:"required"`
 }
 
 type EmailStatusRoutes struct {
@@ -87,6 +197,33 @@ type EmailStatusRoutes struct {
 	UpdateEmailStatus string `mapstructure:"update-email-status" validate:"required"`
 }
 
+type SearchRoutes struct {
+	Search string `mapstructure:"search" validate:"required"`
+}
+
+type AnalyticsRoutes struct {
+	GetVendorPerformances string `mapstructure:"get-vendor-performances" validate:"required"`
+	GetVendorPerformance  string `mapstructure:"get-vendor-performance" validate:"required"`
+}
+
+type AuditRoutes struct {
+	GetAll string `mapstructure:"get-all" validate:"required"`
+}
+
+// HealthRoutes are probed by the orchestrator, Liveness tells the process is up and
+// Readiness that its dependencies are reachable
+type HealthRoutes struct {
+	Liveness  string `mapstructure:"liveness" validate:"required"`
+	Readiness string `mapstructure:"readiness" validate:"required"`
+}
+
+type CurrencyRoutes struct {
+	GetCurrencies       string `mapstructure:"get-currencies" validate:"required"`
+	GetExchangeRates    string `mapstructure:"get-exchange-rates" validate:"required"`
+	CreateExchangeRate  string `mapstructure:"create-exchange-rate" validate:"required"`
+	ImportExchangeRates string `mapstructure:"import-exchange-rates" validate:"required"`
+}
+
 func Load() Application {
 	ctx := context.Background()
 	cfgManager := NewConfigManager()
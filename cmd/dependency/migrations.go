@@ -0,0 +1,21 @@
+package dependency
+
+import (
+	"kg/procurement/cmd/utils"
+	"kg/procurement/internal/common/database"
+	"kg/procurement/migrations"
+)
+
+// NewMigrationRunner runs the embedded migrations on the primary database of conn
+func NewMigrationRunner(conn database.DBConnector) *migrations.Runner {
+	db := database.StdDB(conn)
+	if db == nil {
+		utils.Logger.Fatalf("failed to create migration runner, %T has no database pool", conn)
+	}
+
+	runner, err := migrations.NewRunner(db)
+	if err != nil {
+		utils.Logger.Fatalf("failed to create migration runner, err: %v", err)
+	}
+	return runner
+}
@@ -2,17 +2,47 @@ package dependency
 
 import (
 	"kg/procurement/cmd/config"
+	"kg/procurement/cmd/utils"
 	"kg/procurement/internal/common/database"
 )
 
 func NewPostgreSQL(
 	config config.PostgresConfig,
 ) database.DBConnector {
-	return database.NewConn(
+	primary := database.NewConn(
 		config.Host,
 		config.Username,
 		config.Password,
 		config.Name,
 		config.Port,
+		config.QueryTimeout,
 	)
+	pool := database.PoolOptions{
+		MaxOpenConns:    config.Pool.MaxOpenConns,
+		MaxIdleConns:    config.Pool.MaxIdleConns,
+		ConnMaxLifetime: config.Pool.ConnMaxLifetime,
+		ConnMaxIdleTime: config.Pool.ConnMaxIdleTime,
+	}
+	primary.ConfigurePool(pool)
+	if len(config.Replicas) == 0 {
+		return primary
+	}
+
+	replicas := make([]database.DBConnector, 0, len(config.Replicas))
+	for _, replica := range config.Replicas {
+		conn, err := database.OpenConn(
+			replica.Host,
+			config.Username,
+			config.Password,
+			config.Name,
+			replica.Port,
+			config.QueryTimeout,
+		)
+		if err != nil {
+			utils.Logger.Fatalf("failed to open replica %s:%s, err: %v", replica.Host, replica.Port, err)
+		}
+		conn.ConfigurePool(pool)
+		replicas = append(replicas, conn)
+	}
+	return database.NewRoutedConn(primary, replicas, config.ReplicaCheckInterval)
 }
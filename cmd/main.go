@@ -1,14 +1,23 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"kg/procurement/cmd/config"
 	"kg/procurement/cmd/dependency"
 	"kg/procurement/cmd/utils"
 	"kg/procurement/internal/account"
+	"kg/procurement/internal/analytics"
+	"kg/procurement/internal/audit"
+	"kg/procurement/internal/common/middleware"
+	"kg/procurement/internal/currency"
+	"kg/procurement/internal/health"
 	"kg/procurement/internal/mailer"
 	"kg/procurement/internal/product"
+	"kg/procurement/internal/retention"
+	"kg/procurement/internal/search"
 	"kg/procurement/internal/token"
+	"kg/procurement/internal/uom"
 	"kg/procurement/internal/vendors"
 	"kg/procurement/router"
 	"os"
@@ -24,6 +33,31 @@ import (
 func main() {
 	cfg := config.Load()
 
+	// migrate runs the embedded migrations instead of serving, see runMigrate
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		conn := dependency.NewPostgreSQL(cfg.Common.Postgres)
+		err := runMigrate(context.Background(), dependency.NewMigrationRunner(conn), os.Args[2:])
+		_ = conn.Close()
+		if err != nil {
+			utils.Logger.Fatalf("failed to migrate, err: %v", err)
+		}
+		return
+	}
+
+	// purge removes the rows soft deleted past their retention instead of serving, see runPurge
+	if len(os.Args) > 1 && os.Args[1] == "purge" {
+		conn := dependency.NewPostgreSQL(cfg.Common.Postgres)
+		err := dependency.NewMigrationRunner(conn).CheckSchema(context.Background())
+		if err == nil {
+			err = runPurge(context.Background(), retention.NewRetentionService(conn, clock.New(), cfg.Common.Retention), os.Args[2:])
+		}
+		_ = conn.Close()
+		if err != nil {
+			utils.Logger.Fatalf("failed to purge, err: %v", err)
+		}
+		return
+	}
+
 	var nrApp *newrelic.Application
 	if cfg.NewRelic.Enabled {
 		app, err := newrelic.NewApplication(
@@ -48,6 +82,11 @@ func main() {
 		_ = os.Stdout.Sync()
 	}()
 
+	// serving on an outdated schema fails in confusing ways, run migrate up first
+	if err := dependency.NewMigrationRunner(conn).CheckSchema(context.Background()); err != nil {
+		utils.Logger.Fatalf("refusing to serve, err: %v", err)
+	}
+
 	clock := clock.New()
 	awsCfg := dependency.NewAWSConfig(cfg.AWS)
 
@@ -58,19 +97,37 @@ func main() {
 
 	mailerSvc := mailer.NewEmailStatusService(conn, clock)
 	vendorSvc := vendors.NewVendorService(cfg, conn, clock, gomailSMTP, mailerSvc)
-	productSvc := product.NewProductService(conn, clock)
+	currencySvc := currency.NewCurrencyService(conn, clock, cfg.Common.Currency.BaseCurrency)
+	uomSvc := uom.NewUOMService(conn, clock)
+	productSvc := product.NewProductService(conn, clock, currencySvc, uomSvc, cfg.Common.PriceAnomaly)
 	tokenSvc := token.NewTokenService(cfg.Token, clock)
 	accountSvc := account.NewAccountService(conn, clock, tokenSvc)
+	searchSvc := search.NewSearchService(conn)
+	analyticsSvc := analytics.NewAnalyticsService(conn, clock, cfg.Common.Currency.BaseCurrency)
+	healthSvc := health.NewHealthService(conn, clock, gomailSMTP)
+	auditSvc := audit.NewAuditService(conn, clock)
 
 	r := gin.Default()
+	// handlers pass their gin context down to the accessors, falling back to the request
+	// context lets a client hanging up cancel the queries of its request
+	r.ContextWithFallback = true
 
 	r.Use(cors.Default())
 	r.Use(nrgin.Middleware(nrApp))
+	r.Use(middleware.ReadYourWrites())
+	r.Use(middleware.RequestID())
 
 	router.NewVendorEngine(r, cfg.Routes.Vendor, vendorSvc)
 	router.NewProductEngine(r, cfg.Routes.Product, productSvc)
 	router.NewAccountEngine(r, cfg.Routes.Account, accountSvc)
 	router.NewEmailStatusEngine(r, cfg.Routes.EmailStatus, mailerSvc)
+	router.NewSearchEngine(r, cfg.Routes.Search, searchSvc)
+	router.NewAnalyticsEngine(r, cfg.Routes.Analytics, analyticsSvc)
+	router.NewCurrencyEngine(r, cfg.Routes.Currency, currencySvc)
+	router.NewCatalogueEngine(r, cfg.Routes.Catalogue, productSvc)
+	router.NewUOMEngine(r, cfg.Routes.UOM, uomSvc)
+	router.NewHealthEngine(r, cfg.Routes.Health, healthSvc)
+	router.NewAuditEngine(r, cfg.Routes.Audit, auditSvc)
 
 	if err := r.Run(":8080"); err != nil {
 		utils.Logger.Fatalf("failed to run server, err: %v", err)
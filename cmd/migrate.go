@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"kg/procurement/migrations"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/pressly/goose/v3"
+)
+
+const migrateUsage = "usage: migrate up | down | status | to-version <version>"
+
+var errMigrateUsage = errors.New(migrateUsage)
+
+// runMigrate runs the migrate subcommand against the database of the app config
+func runMigrate(ctx context.Context, runner *migrations.Runner, args []string) error {
+	if len(args) == 0 {
+		return errMigrateUsage
+	}
+
+	var (
+		results []*goose.MigrationResult
+		err     error
+	)
+	switch args[0] {
+	case "up":
+		results, err = runner.Up(ctx)
+	case "down":
+		results, err = runner.Down(ctx)
+	case "to-version":
+		if len(args) != 2 {
+			return errMigrateUsage
+		}
+		version, parseErr := strconv.ParseInt(args[1], 10, 64)
+		if parseErr != nil {
+			return fmt.Errorf("invalid version %q: %w", args[1], parseErr)
+		}
+		results, err = runner.To(ctx, version)
+	case "status":
+		return printMigrationStatus(ctx, runner)
+	default:
+		return errMigrateUsage
+	}
+
+	for _, res := range results {
+		fmt.Println(res)
+	}
+	if err != nil {
+		return err
+	}
+	if len(results) == 0 {
+		fmt.Println("no migrations to run")
+	}
+	return nil
+}
+
+func printMigrationStatus(ctx context.Context, runner *migrations.Runner) error {
+	statuses, err := runner.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tSTATE\tAPPLIED AT\tMIGRATION")
+	for _, status := range statuses {
+		appliedAt := "-"
+		if !status.AppliedAt.IsZero() {
+			appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05")
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", status.Source.Version, status.State, appliedAt, status.Source.Path)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"kg/procurement/internal/retention"
+	"os"
+	"text/tabwriter"
+)
+
+const purgeUsage = "usage: purge [--dry-run]"
+
+var errPurgeUsage = errors.New(purgeUsage)
+
+// runPurge runs the purge subcommand, removing the rows soft deleted past their retention
+func runPurge(ctx context.Context, svc *retention.RetentionService, args []string) error {
+	flags := flag.NewFlagSet("purge", flag.ContinueOnError)
+	dryRun := flags.Bool("dry-run", false, "purge in a transaction that is rolled back, reporting what would be removed")
+	if err := flags.Parse(args); err != nil {
+		return errPurgeUsage
+	}
+	if flags.NArg() > 0 {
+		return errPurgeUsage
+	}
+
+	purged, err := svc.Purge(ctx, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TABLE\tPURGED")
+	for _, table := range purged {
+		fmt.Fprintf(w, "%s\t%d\n", table.Table, table.Rows)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if *dryRun {
+		fmt.Println("Dry run, nothing was purged")
+	}
+	return nil
+}
@@ -25,14 +25,34 @@ const (
 	findAccountByEmailQuery = `
 		SELECT id, email, password, modified_date, created_at
 		FROM account
-		WHERE email = $1
+		WHERE email = $1 AND deleted_at IS NULL
 	`
 
 	findAccountByIDQuery = `
 		SELECT id, email, password, modified_date, created_at
 		FROM account
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+
+	softDeleteAccountQuery = `
+		UPDATE account SET
+			deleted_at = $2,
+			deleted_by = $3,
+			modified_date = $2
+		WHERE id = $1
+			AND deleted_at IS NULL
+	`
+	// a deleted account frees its email, it can only be restored while no other account took it
+	restoreAccountQuery = `
+		UPDATE account SET
+			deleted_at = NULL,
+			deleted_by = NULL,
+			modified_date = $2
 		WHERE id = $1
+			AND deleted_at IS NOT NULL
+			AND NOT EXISTS (SELECT 1 FROM account taken WHERE taken.email = account.email AND taken.deleted_at IS NULL)
 	`
+	isAccountDeletedQuery = `SELECT EXISTS (SELECT 1 FROM account WHERE id = $1 AND deleted_at IS NOT NULL)`
 )
 
 type postgresAccountAccessor struct {
@@ -42,7 +62,7 @@ type postgresAccountAccessor struct {
 
 func (r *postgresAccountAccessor) RegisterAccount(ctx context.Context, account Account) error {
 	account.ModifiedDate = r.clock.Now()
-	_, err := r.db.NamedExec(insertAccountQuery, &account)
+	_, err := r.db.NamedExecContext(ctx, insertAccountQuery, &account)
 	if err != nil {
 		utils.Logger.Error(err.Error())
 		return err
@@ -52,7 +72,7 @@ func (r *postgresAccountAccessor) RegisterAccount(ctx context.Context, account A
 
 func (r *postgresAccountAccessor) FindAccountByEmail(ctx context.Context, email string) (*Account, error) {
 	account := &Account{}
-	err := r.db.Get(account, findAccountByEmailQuery, email)
+	err := r.db.GetContext(ctx, account, findAccountByEmailQuery, email)
 	if err != nil {
 		utils.Logger.Error(err.Error())
 		return nil, err
@@ -62,7 +82,7 @@ func (r *postgresAccountAccessor) FindAccountByEmail(ctx context.Context, email
 
 func (r *postgresAccountAccessor) FindAccountByID(ctx context.Context, id string) (*Account, error) {
 	account := &Account{}
-	err := r.db.Get(account, findAccountByIDQuery, id)
+	err := r.db.GetContext(ctx, account, findAccountByIDQuery, id)
 	if err != nil {
 		utils.Logger.Error(err.Error())
 		return nil, err
@@ -70,6 +90,51 @@ func (r *postgresAccountAccessor) FindAccountByID(ctx context.Context, id string
 	return account, nil
 }
 
+func (r *postgresAccountAccessor) SoftDelete(ctx context.Context, id string, deletedBy string) error {
+	result, err := r.db.ExecContext(ctx, softDeleteAccountQuery, id, r.clock.Now(), deletedBy)
+	if err != nil {
+		utils.Logger.Error(err.Error())
+		return err
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		utils.Logger.Error(err.Error())
+		return err
+	}
+	if deleted == 0 {
+		return ErrAccountNotFound
+	}
+	return nil
+}
+
+// Restore clears the deletion of an account, when nothing was restored the account is
+// looked up to tell a missing one from one whose email was taken in the meantime
+func (r *postgresAccountAccessor) Restore(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, restoreAccountQuery, id, r.clock.Now())
+	if err != nil {
+		utils.Logger.Error(err.Error())
+		return err
+	}
+	restored, err := result.RowsAffected()
+	if err != nil {
+		utils.Logger.Error(err.Error())
+		return err
+	}
+	if restored > 0 {
+		return nil
+	}
+
+	var deleted bool
+	if err := r.db.QueryRowContext(ctx, isAccountDeletedQuery, id).Scan(&deleted); err != nil {
+		utils.Logger.Error(err.Error())
+		return err
+	}
+	if !deleted {
+		return ErrAccountNotFound
+	}
+	return ErrAccountEmailTaken
+}
+
 // newPostgresAccountAccessor is only accessible by the Product package
 // entrypoint for other verticals should refer to the interface declared on service
 func newPostgresAccountAccessor(db database.DBConnector, clock clock.Clock) *postgresAccountAccessor {
@@ -237,6 +237,100 @@ func Test_FindAccountByID(t *testing.T) {
 }
 
 
+func Test_SoftDelete(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		var (
+			ctx = context.Background()
+			c   = setupAccountAccessorTestComponent(t)
+		)
+		defer c.db.Close()
+
+		c.mock.ExpectExec(softDeleteAccountQuery).
+			WithArgs("ID", c.cmock.Now(), "admin").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := c.accessor.SoftDelete(ctx, "ID", "admin")
+
+		c.g.Expect(err).To(gomega.BeNil())
+	})
+
+	t.Run("error - account not found", func(t *testing.T) {
+		var (
+			ctx = context.Background()
+			c   = setupAccountAccessorTestComponent(t)
+		)
+		defer c.db.Close()
+
+		c.mock.ExpectExec(softDeleteAccountQuery).
+			WithArgs("ID", c.cmock.Now(), "admin").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := c.accessor.SoftDelete(ctx, "ID", "admin")
+
+		c.g.Expect(err).To(gomega.Equal(ErrAccountNotFound))
+	})
+}
+
+func Test_Restore(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		var (
+			ctx = context.Background()
+			c   = setupAccountAccessorTestComponent(t)
+		)
+		defer c.db.Close()
+
+		c.mock.ExpectExec(restoreAccountQuery).
+			WithArgs("ID", c.cmock.Now()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := c.accessor.Restore(ctx, "ID")
+
+		c.g.Expect(err).To(gomega.BeNil())
+	})
+
+	t.Run("error - account not deleted", func(t *testing.T) {
+		var (
+			ctx = context.Background()
+			c   = setupAccountAccessorTestComponent(t)
+		)
+		defer c.db.Close()
+
+		c.mock.ExpectExec(restoreAccountQuery).
+			WithArgs("ID", c.cmock.Now()).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		c.mock.ExpectQuery(isAccountDeletedQuery).
+			WithArgs("ID").
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+		err := c.accessor.Restore(ctx, "ID")
+
+		c.g.Expect(err).To(gomega.Equal(ErrAccountNotFound))
+	})
+
+	t.Run("error - email taken by another account", func(t *testing.T) {
+		var (
+			ctx = context.Background()
+			c   = setupAccountAccessorTestComponent(t)
+		)
+		defer c.db.Close()
+
+		c.mock.ExpectExec(restoreAccountQuery).
+			WithArgs("ID", c.cmock.Now()).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		c.mock.ExpectQuery(isAccountDeletedQuery).
+			WithArgs("ID").
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+		err := c.accessor.Restore(ctx, "ID")
+
+		c.g.Expect(err).To(gomega.Equal(ErrAccountEmailTaken))
+	})
+}
+
 type accountAccessorTestComponent struct {
 	g        *gomega.WithT
 	mock     sqlmock.Sqlmock
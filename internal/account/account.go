@@ -7,11 +7,13 @@ import (
 )
 
 type Account struct {
-	ID           string    `json:"id" db:"id"`
-	Email        string    `json:"email" db:"email"`
-	Password     string    `json:"-" db:"password"`
-	ModifiedDate time.Time `json:"modified_date" db:"modified_date"`
-	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	ID           string     `json:"id" db:"id"`
+	Email        string     `json:"email" db:"email"`
+	Password     string     `json:"-" db:"password"`
+	ModifiedDate time.Time  `json:"modified_date" db:"modified_date"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+	DeletedAt    *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+	DeletedBy    *string    `json:"deleted_by,omitempty" db:"deleted_by"`
 }
 
 func (a *Account) VerifyPassword(password string) error {
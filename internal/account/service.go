@@ -15,12 +15,18 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
-var ErrLoginFailed = errors.New("login failed")
+var (
+	ErrLoginFailed       = errors.New("login failed")
+	ErrAccountNotFound   = errors.New("account not found")
+	ErrAccountEmailTaken = errors.New("email is taken by another account")
+)
 
 type accountDBAccessor interface {
 	RegisterAccount(ctx context.Context, account Account) error
 	FindAccountByEmail(ctx context.Context, email string) (*Account, error)
 	FindAccountByID(ctx context.Context, id string) (*Account, error)
+	SoftDelete(ctx context.Context, id string, deletedBy string) error
+	Restore(ctx context.Context, id string) error
 }
 
 type tokenService interface {
@@ -107,6 +113,17 @@ func (a *AccountService) GetCurrentUser(ctx context.Context, tokenString string)
 	return account, nil
 }
 
+// DeleteAccount soft deletes an account, its email can be registered again
+func (a *AccountService) DeleteAccount(ctx context.Context, id string, deletedBy string) error {
+	return a.accountDBAccessor.SoftDelete(ctx, id, deletedBy)
+}
+
+// RestoreAccount restores a deleted account, ErrAccountEmailTaken is returned while
+// another account holds its email
+func (a *AccountService) RestoreAccount(ctx context.Context, id string) error {
+	return a.accountDBAccessor.Restore(ctx, id)
+}
+
 func NewAccountService(
 	conn database.DBConnector,
 	clock clock.Clock,
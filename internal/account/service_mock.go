@@ -156,6 +156,82 @@ func (c *MockaccountDBAccessorRegisterAccountCall) DoAndReturn(f func(context.Co
 	return c
 }
 
+// Restore mocks base method.
+func (m *MockaccountDBAccessor) Restore(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockaccountDBAccessorMockRecorder) Restore(ctx, id any) *MockaccountDBAccessorRestoreCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockaccountDBAccessor)(nil).Restore), ctx, id)
+	return &MockaccountDBAccessorRestoreCall{Call: call}
+}
+
+// MockaccountDBAccessorRestoreCall wrap *gomock.Call
+type MockaccountDBAccessorRestoreCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockaccountDBAccessorRestoreCall) Return(arg0 error) *MockaccountDBAccessorRestoreCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockaccountDBAccessorRestoreCall) Do(f func(context.Context, string) error) *MockaccountDBAccessorRestoreCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockaccountDBAccessorRestoreCall) DoAndReturn(f func(context.Context, string) error) *MockaccountDBAccessorRestoreCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// SoftDelete mocks base method.
+func (m *MockaccountDBAccessor) SoftDelete(ctx context.Context, id, deletedBy string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SoftDelete", ctx, id, deletedBy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SoftDelete indicates an expected call of SoftDelete.
+func (mr *MockaccountDBAccessorMockRecorder) SoftDelete(ctx, id, deletedBy any) *MockaccountDBAccessorSoftDeleteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SoftDelete", reflect.TypeOf((*MockaccountDBAccessor)(nil).SoftDelete), ctx, id, deletedBy)
+	return &MockaccountDBAccessorSoftDeleteCall{Call: call}
+}
+
+// MockaccountDBAccessorSoftDeleteCall wrap *gomock.Call
+type MockaccountDBAccessorSoftDeleteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockaccountDBAccessorSoftDeleteCall) Return(arg0 error) *MockaccountDBAccessorSoftDeleteCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockaccountDBAccessorSoftDeleteCall) Do(f func(context.Context, string, string) error) *MockaccountDBAccessorSoftDeleteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockaccountDBAccessorSoftDeleteCall) DoAndReturn(f func(context.Context, string, string) error) *MockaccountDBAccessorSoftDeleteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // MocktokenService is a mock of tokenService interface.
 type MocktokenService struct {
 	ctrl     *gomock.Controller
@@ -442,3 +442,20 @@ func TestAccountService_GetCurrentUser(t *testing.T) {
 	}
 }
 
+func TestAccountService_RestoreAccount(t *testing.T) {
+	t.Parallel()
+
+	var (
+		g        = gomega.NewWithT(t)
+		ctx      = context.Background()
+		ctrl     = gomock.NewController(t)
+		accessor = NewMockaccountDBAccessor(ctrl)
+	)
+
+	svc := &AccountService{accountDBAccessor: accessor}
+
+	accessor.EXPECT().Restore(ctx, "ID").Return(ErrAccountEmailTaken)
+
+	err := svc.RestoreAccount(ctx, "ID")
+	g.Expect(errors.Is(err, ErrAccountEmailTaken)).To(gomega.BeTrue())
+}
@@ -0,0 +1,253 @@
+package analytics
+
+import (
+	"context"
+	"fmt"
+	"kg/procurement/cmd/utils"
+	"kg/procurement/internal/common/database"
+	"strings"
+	"time"
+
+	"github.com/benbjohnson/clock"
+)
+
+// vendorPerformanceQuery aggregates every metric in its own CTE so each source
+// table is scanned once. $1 and $2 are the optional period bounds and $3 is the
+// current time, used to find the active prices when there is no period.
+// $4 is the base currency unit prices are converted to before being compared, at the
+// most recent rate effective at the end of the period (or now without one), a rate
+// recorded the other way around is inverted. Prices without a known rate still count
+// as active but are left out of the price index.
+// Email statuses other than failed count as sent, completed ones as answered
+const vendorPerformanceQuery = `
+	WITH emails AS (
+		SELECT
+			es.vendor_id,
+			COUNT(*) FILTER (WHERE es.status <> 'failed') AS emails_sent,
+			COUNT(*) FILTER (WHERE es.status = 'failed') AS emails_failed,
+			COUNT(*) FILTER (WHERE es.status = 'completed') AS emails_answered
+		FROM email_status es
+		WHERE es.vendor_id IS NOT NULL
+			AND ($1::timestamp IS NULL OR es.date_sent >= $1)
+			AND ($2::timestamp IS NULL OR es.date_sent <= $2)
+		GROUP BY es.vendor_id
+	),
+	active_prices AS (
+		SELECT
+			pr.vendor_id,
+			pv.product_id,
+			pr.lead_time_min,
+			pr.lead_time_max,
+			pr.price / NULLIF(pr.price_quantity, 0) * r.rate AS unit_price
+		FROM price pr
+		JOIN product_vendor pv ON pv.id = pr.product_vendor_id
+		LEFT JOIN LATERAL (
+			SELECT 1::numeric AS rate
+			WHERE pr.currency_code = $4::varchar
+			UNION ALL
+			(
+				SELECT rates.rate
+				FROM (
+					SELECT er.rate, er.rate_date, 0 AS inverted
+					FROM exchange_rate er
+					WHERE er.from_currency = pr.currency_code
+						AND er.to_currency = $4
+						AND er.rate_date <= COALESCE($2, $3)::date
+					UNION ALL
+					SELECT 1 / er.rate, er.rate_date, 1 AS inverted
+					FROM exchange_rate er
+					WHERE er.from_currency = $4
+						AND er.to_currency = pr.currency_code
+						AND er.rate_date <= COALESCE($2, $3)::date
+				) rates
+				ORDER BY rates.rate_date DESC, rates.inverted
+				LIMIT 1
+			)
+		) r ON true
+		WHERE pr.deleted_at IS NULL
+			AND pv.deleted_at IS NULL
+			AND pr.valid_from <= COALESCE($2, $3)
+			AND (pr.valid_to IS NULL OR pr.valid_to >= COALESCE($1, $2, $3))
+	),
+	prices AS (
+		SELECT
+			ap.vendor_id,
+			COUNT(*) AS active_prices,
+			AVG(ap.lead_time_min) AS avg_lead_time_min,
+			AVG(ap.lead_time_max) AS avg_lead_time_max
+		FROM active_prices ap
+		GROUP BY ap.vendor_id
+	),
+	market AS (
+		SELECT
+			ap.product_id,
+			AVG(ap.unit_price) AS avg_unit_price
+		FROM active_prices ap
+		WHERE ap.unit_price IS NOT NULL
+		GROUP BY ap.product_id
+		HAVING COUNT(DISTINCT ap.vendor_id) > 1
+	),
+	competitiveness AS (
+		SELECT
+			ap.vendor_id,
+			AVG(ap.unit_price / NULLIF(m.avg_unit_price, 0)) AS price_index
+		FROM active_prices ap
+		JOIN market m ON m.product_id = ap.product_id
+		WHERE ap.unit_price IS NOT NULL
+		GROUP BY ap.vendor_id
+	),
+	evaluations AS (
+		SELECT
+			ve.vendor_id,
+			COUNT(*) AS evaluation_count,
+			AVG((
+				ve.kesesuaian_produk + ve.kualitas_produk + ve.ketepatan_waktu_pengiriman +
+				ve.kompetitifitas_harga + ve.responsivitas_kemampuan_komunikasi +
+				ve.kemampuan_dalam_menangani_masalah + ve.kelengkapan_barang + ve.harga +
+				ve.term_of_payment + ve.reputasi + ve.ketersediaan_barang +
+				ve.kualitas_layanan_after_services
+			) / 12.0) AS evaluation_average
+		FROM vendor_evaluation ve
+		WHERE ($1::timestamp IS NULL OR ve.modified_date >= $1)
+			AND ($2::timestamp IS NULL OR ve.modified_date <= $2)
+		GROUP BY ve.vendor_id
+	)
+	SELECT
+		v.id AS vendor_id,
+		v.name AS vendor_name,
+		v.area_group_name,
+		COALESCE(e.emails_sent, 0) AS emails_sent,
+		COALESCE(e.emails_failed, 0) AS emails_failed,
+		COALESCE(e.emails_answered, 0) AS emails_answered,
+		COALESCE(e.emails_answered::float / NULLIF(e.emails_sent, 0), 0) AS response_rate,
+		COALESCE(p.active_prices, 0) AS active_prices,
+		p.avg_lead_time_min,
+		p.avg_lead_time_max,
+		c.price_index,
+		COALESCE(ev.evaluation_count, 0) AS evaluation_count,
+		ev.evaluation_average,
+		COUNT(*) OVER () AS total_entries
+	FROM vendor v
+	LEFT JOIN emails e ON e.vendor_id = v.id
+	LEFT JOIN prices p ON p.vendor_id = v.id
+	LEFT JOIN competitiveness c ON c.vendor_id = v.id
+	LEFT JOIN evaluations ev ON ev.vendor_id = v.id
+`
+
+// vendorPerformanceSortableColumns maps the accepted order_by fields to their column
+var vendorPerformanceSortableColumns = map[string]string{
+	"vendor_name":        "vendor_name",
+	"emails_sent":        "emails_sent",
+	"emails_failed":      "emails_failed",
+	"response_rate":      "response_rate",
+	"active_prices":      "active_prices",
+	"avg_lead_time_min":  "avg_lead_time_min",
+	"avg_lead_time_max":  "avg_lead_time_max",
+	"price_index":        "price_index",
+	"evaluation_average": "evaluation_average",
+}
+
+type postgresAnalyticsAccessor struct {
+	db           database.DBConnector
+	clock        clock.Clock
+	baseCurrency string
+}
+
+type vendorPerformanceRow struct {
+	VendorPerformance
+	TotalEntries int `db:"total_entries"`
+}
+
+// periodArg leaves an unset bound as NULL so the query skips it
+func periodArg(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t
+}
+
+func (p *postgresAnalyticsAccessor) GetVendorPerformances(
+	ctx context.Context,
+	vendorID string,
+	spec VendorPerformanceSpec,
+) (*AccessorVendorPerformancePaginationData, error) {
+	paginationArgs := database.BuildPaginationArgs(spec.PaginationSpec)
+
+	var (
+		whereClauses = []string{"v.deleted_at IS NULL"}
+		args         = []interface{}{periodArg(spec.From), periodArg(spec.To), p.clock.Now(), p.baseCurrency}
+		argsIndex    = 5
+	)
+
+	if vendorID != "" {
+		whereClauses = append(whereClauses, fmt.Sprintf("v.id = $%d", argsIndex))
+		args = append(args, vendorID)
+		argsIndex++
+	}
+	if spec.AreaGroupID != "" {
+		whereClauses = append(whereClauses, fmt.Sprintf("v.area_group_id = $%d", argsIndex))
+		args = append(args, spec.AreaGroupID)
+		argsIndex++
+	}
+
+	orderBy := paginationArgs.OrderBy
+	if orderBy == "" {
+		orderBy = "vendor_name"
+	}
+	orderByClause, err := database.BuildOrderByClause(orderBy, paginationArgs.Order, vendorPerformanceSortableColumns)
+	if err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	whereClause := ""
+	if len(whereClauses) > 0 {
+		whereClause = "WHERE " + strings.Join(whereClauses, " AND ")
+	}
+
+	query := fmt.Sprintf(`%s
+		%s
+		%s, vendor_id
+		LIMIT $%d
+		OFFSET $%d
+	`, vendorPerformanceQuery, whereClause, orderByClause, argsIndex, argsIndex+1)
+	args = append(args, paginationArgs.Limit, paginationArgs.Offset)
+
+	rows, err := p.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	totalEntries := 0
+	vendors := []VendorPerformance{}
+	for rows.Next() {
+		var row vendorPerformanceRow
+		if err := rows.StructScan(&row); err != nil {
+			utils.Logger.Error(err.Error())
+			return nil, err
+		}
+		totalEntries = row.TotalEntries
+		vendors = append(vendors, row.VendorPerformance)
+	}
+	if err := rows.Err(); err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	return &AccessorVendorPerformancePaginationData{
+		Vendors:  vendors,
+		Metadata: database.GeneratePaginationMetadata(spec.PaginationSpec, totalEntries),
+	}, nil
+}
+
+// newPostgresAnalyticsAccessor is only accessible by the analytics package
+// entrypoint for other verticals should refer to the interface declared on service
+func newPostgresAnalyticsAccessor(db database.DBConnector, clock clock.Clock, baseCurrency string) *postgresAnalyticsAccessor {
+	return &postgresAnalyticsAccessor{
+		db:           db,
+		clock:        clock,
+		baseCurrency: baseCurrency,
+	}
+}
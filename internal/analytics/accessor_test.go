@@ -0,0 +1,212 @@
+package analytics
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"kg/procurement/internal/common/database"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/benbjohnson/clock"
+	"github.com/jmoiron/sqlx"
+	"github.com/onsi/gomega"
+)
+
+func Test_newPostgresAnalyticsAccessor(t *testing.T) {
+	_ = newPostgresAnalyticsAccessor(nil, nil, "IDR")
+}
+
+func Test_GetVendorPerformances(t *testing.T) {
+	t.Parallel()
+
+	var (
+		now     = time.Date(2024, time.December, 4, 0, 0, 0, 0, time.UTC)
+		from    = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+		to      = time.Date(2024, time.June, 30, 0, 0, 0, 0, time.UTC)
+		columns = []string{
+			"vendor_id", "vendor_name", "area_group_name",
+			"emails_sent", "emails_failed", "emails_answered", "response_rate",
+			"active_prices", "avg_lead_time_min", "avg_lead_time_max", "price_index",
+			"evaluation_count", "evaluation_average", "total_entries",
+		}
+		leadTimeMin       = 2.5
+		leadTimeMax       = 7.0
+		priceIndex        = 0.92
+		evaluationAverage = 4.25
+	)
+
+	t.Run("success", func(t *testing.T) {
+		c := setupAnalyticsAccessorTestComponent(t)
+		defer c.db.Close()
+		c.cmock.Set(now)
+
+		rows := sqlmock.NewRows(columns).
+			AddRow("1", "Vendor 1", "Jakarta", 4, 1, 2, 0.5, 3, leadTimeMin, leadTimeMax, priceIndex, 2, evaluationAverage, 11).
+			AddRow("2", "Vendor 2", "Jakarta", 0, 0, 0, 0, 0, nil, nil, nil, 0, nil, 11)
+		c.mock.ExpectQuery(regexp.QuoteMeta(vendorPerformanceQuery)+
+			`\s*WHERE v\.deleted_at IS NULL AND v\.area_group_id = \$5\s+ORDER BY response_rate DESC, vendor_id\s+LIMIT \$6\s+OFFSET \$7`).
+			WithArgs(from, to, now, "IDR", "AG1", 10, 0).
+			WillReturnRows(rows)
+
+		res, err := c.accessor.GetVendorPerformances(context.Background(), "", VendorPerformanceSpec{
+			AreaGroupID: "AG1",
+			From:        from,
+			To:          to,
+			PaginationSpec: database.PaginationSpec{
+				Limit:   10,
+				Page:    1,
+				OrderBy: "response_rate",
+				Order:   "DESC",
+			},
+		})
+
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(res.Vendors).To(gomega.Equal([]VendorPerformance{
+			{
+				VendorID:          "1",
+				VendorName:        "Vendor 1",
+				AreaGroupName:     "Jakarta",
+				EmailsSent:        4,
+				EmailsFailed:      1,
+				EmailsAnswered:    2,
+				ResponseRate:      0.5,
+				ActivePrices:      3,
+				AvgLeadTimeMin:    &leadTimeMin,
+				AvgLeadTimeMax:    &leadTimeMax,
+				PriceIndex:        &priceIndex,
+				EvaluationCount:   2,
+				EvaluationAverage: &evaluationAverage,
+			},
+			{
+				VendorID:      "2",
+				VendorName:    "Vendor 2",
+				AreaGroupName: "Jakarta",
+			},
+		}))
+		c.g.Expect(res.Metadata).To(gomega.Equal(database.PaginationMetadata{
+			TotalPage:    2,
+			CurrentPage:  1,
+			TotalEntries: 11,
+		}))
+	})
+
+	t.Run("success for a single vendor without period", func(t *testing.T) {
+		c := setupAnalyticsAccessorTestComponent(t)
+		defer c.db.Close()
+		c.cmock.Set(now)
+
+		c.mock.ExpectQuery(regexp.QuoteMeta(vendorPerformanceQuery)+
+			`\s*WHERE v\.deleted_at IS NULL AND v\.id = \$5\s+ORDER BY vendor_name ASC, vendor_id\s+LIMIT \$6\s+OFFSET \$7`).
+			WithArgs(nil, nil, now, "IDR", "1", 1, 0).
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		res, err := c.accessor.GetVendorPerformances(context.Background(), "1", VendorPerformanceSpec{
+			PaginationSpec: database.PaginationSpec{Limit: 1, Page: 1},
+		})
+
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(res.Vendors).To(gomega.BeEmpty())
+	})
+
+	t.Run("converts unit prices to the base currency", func(t *testing.T) {
+		c := setupAnalyticsAccessorTestComponent(t)
+		defer c.db.Close()
+		c.cmock.Set(now)
+		c.accessor.baseCurrency = "USD"
+
+		// unit prices are multiplied by the rate into $4, direct or inverted, effective at
+		// the end of the period, and only priced ones make up the market and the index
+		c.mock.ExpectQuery(
+			`pr\.price / NULLIF\(pr\.price_quantity, 0\) \* r\.rate AS unit_price`+
+				`(?s:.*)LEFT JOIN LATERAL \(\s*SELECT 1::numeric AS rate\s+WHERE pr\.currency_code = \$4::varchar`+
+				`(?s:.*)WHERE er\.from_currency = pr\.currency_code\s+AND er\.to_currency = \$4\s+AND er\.rate_date <= COALESCE\(\$2, \$3\)::date`+
+				`(?s:.*)SELECT 1 / er\.rate, er\.rate_date, 1 AS inverted\s+FROM exchange_rate er\s+WHERE er\.from_currency = \$4\s+AND er\.to_currency = pr\.currency_code`+
+				`(?s:.*)ORDER BY rates\.rate_date DESC, rates\.inverted\s+LIMIT 1`+
+				`(?s:.*)market AS \((?s:.*)WHERE ap\.unit_price IS NOT NULL\s+GROUP BY ap\.product_id`+
+				`(?s:.*)competitiveness AS \((?s:.*)WHERE ap\.unit_price IS NOT NULL\s+GROUP BY ap\.vendor_id`,
+		).
+			WithArgs(from, to, now, "USD", 10, 0).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow("1", "Vendor 1", "Jakarta", 0, 0, 0, 0, 1, nil, nil, priceIndex, 0, nil, 1))
+
+		res, err := c.accessor.GetVendorPerformances(context.Background(), "", VendorPerformanceSpec{
+			From:           from,
+			To:             to,
+			PaginationSpec: database.PaginationSpec{Limit: 10, Page: 1},
+		})
+
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(res.Vendors).To(gomega.HaveLen(1))
+		c.g.Expect(res.Vendors[0].PriceIndex).To(gomega.Equal(&priceIndex))
+		c.g.Expect(c.mock.ExpectationsWereMet()).To(gomega.BeNil())
+	})
+
+	t.Run("error on unknown sort column", func(t *testing.T) {
+		c := setupAnalyticsAccessorTestComponent(t)
+		defer c.db.Close()
+
+		res, err := c.accessor.GetVendorPerformances(context.Background(), "", VendorPerformanceSpec{
+			PaginationSpec: database.PaginationSpec{Limit: 10, Page: 1, OrderBy: "v.password"},
+		})
+
+		c.g.Expect(errors.Is(err, database.ErrInvalidSortColumn)).To(gomega.BeTrue())
+		c.g.Expect(res).To(gomega.BeNil())
+	})
+
+	t.Run("error on query", func(t *testing.T) {
+		c := setupAnalyticsAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectQuery(regexp.QuoteMeta(vendorPerformanceQuery)).
+			WillReturnError(sql.ErrConnDone)
+
+		res, err := c.accessor.GetVendorPerformances(context.Background(), "", VendorPerformanceSpec{
+			PaginationSpec: database.PaginationSpec{Limit: 10, Page: 1},
+		})
+
+		c.g.Expect(err).To(gomega.Equal(sql.ErrConnDone))
+		c.g.Expect(res).To(gomega.BeNil())
+	})
+
+	t.Run("error on scan", func(t *testing.T) {
+		c := setupAnalyticsAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectQuery(regexp.QuoteMeta(vendorPerformanceQuery)).
+			WillReturnRows(sqlmock.NewRows([]string{"unknown"}).AddRow("1"))
+
+		res, err := c.accessor.GetVendorPerformances(context.Background(), "", VendorPerformanceSpec{
+			PaginationSpec: database.PaginationSpec{Limit: 10, Page: 1},
+		})
+
+		c.g.Expect(err).ToNot(gomega.BeNil())
+		c.g.Expect(res).To(gomega.BeNil())
+	})
+}
+
+type analyticsAccessorTestComponent struct {
+	g        *gomega.WithT
+	mock     sqlmock.Sqlmock
+	db       *sql.DB
+	accessor *postgresAnalyticsAccessor
+	cmock    *clock.Mock
+}
+
+func setupAnalyticsAccessorTestComponent(t *testing.T) analyticsAccessorTestComponent {
+	g := gomega.NewWithT(t)
+	db, sqlMock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	clockMock := clock.NewMock()
+
+	return analyticsAccessorTestComponent{
+		g:        g,
+		mock:     sqlMock,
+		db:       db,
+		accessor: newPostgresAnalyticsAccessor(sqlxDB, clockMock, "IDR"),
+		cmock:    clockMock,
+	}
+}
@@ -0,0 +1,47 @@
+package analytics
+
+import (
+	"errors"
+	"kg/procurement/internal/common/database"
+	"time"
+)
+
+var (
+	ErrVendorNotFound = errors.New("vendor not found")
+	ErrInvalidPeriod  = errors.New("period start must be before period end")
+)
+
+// VendorPerformanceSpec filters the aggregation, emails and evaluations are
+// counted within the period while prices are those valid at some point of it.
+// Without a period every email and evaluation is counted and only prices valid
+// right now are considered active
+type VendorPerformanceSpec struct {
+	AreaGroupID string    `json:"area_group_id"`
+	From        time.Time `json:"from"`
+	To          time.Time `json:"to"`
+	database.PaginationSpec
+}
+
+// VendorPerformance aggregates the RFQ, price and evaluation history of a vendor.
+// PriceIndex is the average ratio of the vendor unit price to the market average
+// of the same product, below 1 means the vendor is cheaper than its competitors
+type VendorPerformance struct {
+	VendorID          string   `db:"vendor_id" json:"vendor_id"`
+	VendorName        string   `db:"vendor_name" json:"vendor_name"`
+	AreaGroupName     string   `db:"area_group_name" json:"area_group_name"`
+	EmailsSent        int      `db:"emails_sent" json:"emails_sent"`
+	EmailsFailed      int      `db:"emails_failed" json:"emails_failed"`
+	EmailsAnswered    int      `db:"emails_answered" json:"emails_answered"`
+	ResponseRate      float64  `db:"response_rate" json:"response_rate"`
+	ActivePrices      int      `db:"active_prices" json:"active_prices"`
+	AvgLeadTimeMin    *float64 `db:"avg_lead_time_min" json:"avg_lead_time_min"`
+	AvgLeadTimeMax    *float64 `db:"avg_lead_time_max" json:"avg_lead_time_max"`
+	PriceIndex        *float64 `db:"price_index" json:"price_index"`
+	EvaluationCount   int      `db:"evaluation_count" json:"evaluation_count"`
+	EvaluationAverage *float64 `db:"evaluation_average" json:"evaluation_average"`
+}
+
+type AccessorVendorPerformancePaginationData struct {
+	Vendors  []VendorPerformance         `json:"vendors"`
+	Metadata database.PaginationMetadata `json:"metadata"`
+}
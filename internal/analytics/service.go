@@ -0,0 +1,58 @@
+//go:generate mockgen -typed -source=service.go -destination=service_mock.go -package=analytics
+package analytics
+
+import (
+	"context"
+	"kg/procurement/internal/common/database"
+
+	"github.com/benbjohnson/clock"
+)
+
+type analyticsDBAccessor interface {
+	GetVendorPerformances(ctx context.Context, vendorID string, spec VendorPerformanceSpec) (*AccessorVendorPerformancePaginationData, error)
+}
+
+type AnalyticsService struct {
+	analyticsDBAccessor
+}
+
+// GetVendorPerformances lists the performance of every vendor matching the spec
+func (s *AnalyticsService) GetVendorPerformances(ctx context.Context, spec VendorPerformanceSpec) (*AccessorVendorPerformancePaginationData, error) {
+	if !validPeriod(spec) {
+		return nil, ErrInvalidPeriod
+	}
+
+	return s.analyticsDBAccessor.GetVendorPerformances(ctx, "", spec)
+}
+
+// GetVendorPerformance returns the performance of a single vendor
+func (s *AnalyticsService) GetVendorPerformance(ctx context.Context, vendorID string, spec VendorPerformanceSpec) (*VendorPerformance, error) {
+	if !validPeriod(spec) {
+		return nil, ErrInvalidPeriod
+	}
+
+	spec.PaginationSpec = database.PaginationSpec{Limit: 1, Page: 1}
+	res, err := s.analyticsDBAccessor.GetVendorPerformances(ctx, vendorID, spec)
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Vendors) == 0 {
+		return nil, ErrVendorNotFound
+	}
+
+	return &res.Vendors[0], nil
+}
+
+func validPeriod(spec VendorPerformanceSpec) bool {
+	return spec.From.IsZero() || spec.To.IsZero() || !spec.From.After(spec.To)
+}
+
+func NewAnalyticsService(
+	conn database.DBConnector,
+	clock clock.Clock,
+	baseCurrency string,
+) *AnalyticsService {
+	return &AnalyticsService{
+		analyticsDBAccessor: newPostgresAnalyticsAccessor(conn, clock, baseCurrency),
+	}
+}
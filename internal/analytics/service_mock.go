@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+//
+// Generated by this command:
+//
+//	mockgen -typed -source=service.go -destination=service_mock.go -package=analytics
+//
+
+// Package analytics is a generated GoMock package.
+package analytics
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockanalyticsDBAccessor is a mock of analyticsDBAccessor interface.
+type MockanalyticsDBAccessor struct {
+	ctrl     *gomock.Controller
+	recorder *MockanalyticsDBAccessorMockRecorder
+}
+
+// MockanalyticsDBAccessorMockRecorder is the mock recorder for MockanalyticsDBAccessor.
+type MockanalyticsDBAccessorMockRecorder struct {
+	mock *MockanalyticsDBAccessor
+}
+
+// NewMockanalyticsDBAccessor creates a new mock instance.
+func NewMockanalyticsDBAccessor(ctrl *gomock.Controller) *MockanalyticsDBAccessor {
+	mock := &MockanalyticsDBAccessor{ctrl: ctrl}
+	mock.recorder = &MockanalyticsDBAccessorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockanalyticsDBAccessor) EXPECT() *MockanalyticsDBAccessorMockRecorder {
+	return m.recorder
+}
+
+// GetVendorPerformances mocks base method.
+func (m *MockanalyticsDBAccessor) GetVendorPerformances(ctx context.Context, vendorID string, spec VendorPerformanceSpec) (*AccessorVendorPerformancePaginationData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVendorPerformances", ctx, vendorID, spec)
+	ret0, _ := ret[0].(*AccessorVendorPerformancePaginationData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVendorPerformances indicates an expected call of GetVendorPerformances.
+func (mr *MockanalyticsDBAccessorMockRecorder) GetVendorPerformances(ctx, vendorID, spec any) *MockanalyticsDBAccessorGetVendorPerformancesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVendorPerformances", reflect.TypeOf((*MockanalyticsDBAccessor)(nil).GetVendorPerformances), ctx, vendorID, spec)
+	return &MockanalyticsDBAccessorGetVendorPerformancesCall{Call: call}
+}
+
+// MockanalyticsDBAccessorGetVendorPerformancesCall wrap *gomock.Call
+type MockanalyticsDBAccessorGetVendorPerformancesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockanalyticsDBAccessorGetVendorPerformancesCall) Return(arg0 *AccessorVendorPerformancePaginationData, arg1 error) *MockanalyticsDBAccessorGetVendorPerformancesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockanalyticsDBAccessorGetVendorPerformancesCall) Do(f func(context.Context, string, VendorPerformanceSpec) (*AccessorVendorPerformancePaginationData, error)) *MockanalyticsDBAccessorGetVendorPerformancesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockanalyticsDBAccessorGetVendorPerformancesCall) DoAndReturn(f func(context.Context, string, VendorPerformanceSpec) (*AccessorVendorPerformancePaginationData, error)) *MockanalyticsDBAccessorGetVendorPerformancesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
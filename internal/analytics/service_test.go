@@ -0,0 +1,133 @@
+package analytics
+
+import (
+	"context"
+	"errors"
+	"kg/procurement/internal/common/database"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_NewAnalyticsService(t *testing.T) {
+	_ = NewAnalyticsService(nil, nil, "IDR")
+}
+
+func TestAnalyticsService_GetVendorPerformances(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mockAnalyticsAccessor *MockanalyticsDBAccessor
+		subject               *AnalyticsService
+	)
+
+	setup := func(t *testing.T) *gomega.GomegaWithT {
+		ctrl := gomock.NewController(t)
+		mockAnalyticsAccessor = NewMockanalyticsDBAccessor(ctrl)
+		subject = &AnalyticsService{
+			analyticsDBAccessor: mockAnalyticsAccessor,
+		}
+		return gomega.NewWithT(t)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		g := setup(t)
+		ctx := context.Background()
+
+		spec := VendorPerformanceSpec{
+			AreaGroupID:    "AG1",
+			From:           time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+			PaginationSpec: database.PaginationSpec{Limit: 10, Page: 1},
+		}
+		expected := &AccessorVendorPerformancePaginationData{
+			Vendors: []VendorPerformance{{VendorID: "1"}},
+		}
+		mockAnalyticsAccessor.EXPECT().
+			GetVendorPerformances(ctx, "", spec).
+			Return(expected, nil)
+
+		res, err := subject.GetVendorPerformances(ctx, spec)
+
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(res).To(gomega.Equal(expected))
+	})
+
+	t.Run("error on inverted period", func(t *testing.T) {
+		g := setup(t)
+
+		res, err := subject.GetVendorPerformances(context.Background(), VendorPerformanceSpec{
+			From: time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+		})
+
+		g.Expect(err).To(gomega.Equal(ErrInvalidPeriod))
+		g.Expect(res).To(gomega.BeNil())
+	})
+}
+
+func TestAnalyticsService_GetVendorPerformance(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mockAnalyticsAccessor *MockanalyticsDBAccessor
+		subject               *AnalyticsService
+	)
+
+	setup := func(t *testing.T) *gomega.GomegaWithT {
+		ctrl := gomock.NewController(t)
+		mockAnalyticsAccessor = NewMockanalyticsDBAccessor(ctrl)
+		subject = &AnalyticsService{
+			analyticsDBAccessor: mockAnalyticsAccessor,
+		}
+		return gomega.NewWithT(t)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		g := setup(t)
+		ctx := context.Background()
+
+		mockAnalyticsAccessor.EXPECT().
+			GetVendorPerformances(ctx, "1", VendorPerformanceSpec{
+				AreaGroupID:    "AG1",
+				PaginationSpec: database.PaginationSpec{Limit: 1, Page: 1},
+			}).
+			Return(&AccessorVendorPerformancePaginationData{
+				Vendors: []VendorPerformance{{VendorID: "1", EmailsSent: 3}},
+			}, nil)
+
+		res, err := subject.GetVendorPerformance(ctx, "1", VendorPerformanceSpec{AreaGroupID: "AG1"})
+
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(res).To(gomega.Equal(&VendorPerformance{VendorID: "1", EmailsSent: 3}))
+	})
+
+	t.Run("error on unknown vendor", func(t *testing.T) {
+		g := setup(t)
+		ctx := context.Background()
+
+		mockAnalyticsAccessor.EXPECT().
+			GetVendorPerformances(ctx, "1", gomock.Any()).
+			Return(&AccessorVendorPerformancePaginationData{}, nil)
+
+		res, err := subject.GetVendorPerformance(ctx, "1", VendorPerformanceSpec{})
+
+		g.Expect(err).To(gomega.Equal(ErrVendorNotFound))
+		g.Expect(res).To(gomega.BeNil())
+	})
+
+	t.Run("error from accessor", func(t *testing.T) {
+		g := setup(t)
+		ctx := context.Background()
+
+		mockAnalyticsAccessor.EXPECT().
+			GetVendorPerformances(ctx, "1", gomock.Any()).
+			Return(nil, errors.New("db error"))
+
+		res, err := subject.GetVendorPerformance(ctx, "1", VendorPerformanceSpec{})
+
+		g.Expect(err).ToNot(gomega.BeNil())
+		g.Expect(res).To(gomega.BeNil())
+	})
+}
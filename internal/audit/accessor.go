@@ -0,0 +1,92 @@
+package audit
+
+import (
+	"context"
+	"kg/procurement/cmd/utils"
+	"kg/procurement/internal/common/database"
+	"time"
+
+	"github.com/benbjohnson/clock"
+)
+
+const getEntriesQuery = `
+	SELECT
+		id, entity, entity_id, action, actor, request_id, before, after, changes, created_at,
+		COUNT(*) OVER () AS total_entries
+	FROM audit_log
+	WHERE ($1 = '' OR entity = $1)
+		AND ($2 = '' OR entity_id = $2)
+		AND ($3 = '' OR actor = $3)
+		AND ($4 = '' OR request_id = $4)
+		AND ($5::timestamp IS NULL OR created_at >= $5)
+		AND ($6::timestamp IS NULL OR created_at < $6)
+	ORDER BY created_at DESC, id
+	LIMIT $7
+	OFFSET $8
+`
+
+type postgresAuditAccessor struct {
+	db    database.DBConnector
+	clock clock.Clock
+}
+
+type entryRow struct {
+	Entry
+	TotalEntries int `db:"total_entries"`
+}
+
+func (p *postgresAuditAccessor) GetAll(ctx context.Context, spec GetAuditLogSpec) (*AccessorGetAuditLogPaginationData, error) {
+	paginationArgs := database.BuildPaginationArgs(spec.PaginationSpec)
+
+	rows, err := p.db.QueryxContext(ctx, getEntriesQuery,
+		spec.Entity,
+		spec.EntityID,
+		spec.Actor,
+		spec.RequestID,
+		periodArg(spec.From),
+		periodArg(spec.To),
+		paginationArgs.Limit,
+		paginationArgs.Offset,
+	)
+	if err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	totalEntries := 0
+	res := []Entry{}
+	for rows.Next() {
+		var row entryRow
+		if err := rows.StructScan(&row); err != nil {
+			utils.Logger.Error(err.Error())
+			return nil, err
+		}
+		totalEntries = row.TotalEntries
+		res = append(res, row.Entry)
+	}
+	if err := rows.Err(); err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	return &AccessorGetAuditLogPaginationData{
+		Entries:  res,
+		Metadata: database.GeneratePaginationMetadata(spec.PaginationSpec, totalEntries),
+	}, nil
+}
+
+// periodArg leaves an unset bound as NULL so the query skips it
+func periodArg(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t
+}
+
+func newPostgresAuditAccessor(db database.DBConnector, clock clock.Clock) *postgresAuditAccessor {
+	return &postgresAuditAccessor{
+		db:    db,
+		clock: clock,
+	}
+}
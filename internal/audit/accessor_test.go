@@ -0,0 +1,70 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"kg/procurement/internal/common/database"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/onsi/gomega"
+)
+
+func Test_newPostgresAuditAccessor(t *testing.T) {
+	_ = newPostgresAuditAccessor(nil, nil)
+}
+
+func Test_GetAll(t *testing.T) {
+	t.Parallel()
+
+	var (
+		createdAt = time.Date(2024, time.December, 11, 0, 0, 0, 0, time.UTC)
+		from      = time.Date(2024, time.December, 1, 0, 0, 0, 0, time.UTC)
+		columns   = []string{
+			"id", "entity", "entity_id", "action", "actor", "request_id",
+			"before", "after", "changes", "created_at", "total_entries",
+		}
+	)
+
+	t.Run("success", func(t *testing.T) {
+		c := setupTrackTestComponent(t)
+		defer c.db.Close()
+		accessor := newPostgresAuditAccessor(c.db, c.clock)
+
+		rows := sqlmock.NewRows(columns).
+			AddRow("E1", "price", "P1", "update", "admin", "R1",
+				[]byte(`{"price":100}`), []byte(`{"price":120}`), []byte(`{"price":{"old":100,"new":120}}`), createdAt, 3)
+		c.mock.ExpectQuery(regexp.QuoteMeta(getEntriesQuery)).
+			WithArgs(EntityPrice, "P1", "", "", from, nil, 10, 0).
+			WillReturnRows(rows)
+
+		res, err := accessor.GetAll(context.Background(), GetAuditLogSpec{
+			Entity:         EntityPrice,
+			EntityID:       "P1",
+			From:           from,
+			PaginationSpec: database.PaginationSpec{Limit: 10, Page: 1},
+		})
+
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(res.Entries).To(gomega.HaveLen(1))
+		c.g.Expect(res.Entries[0].Action).To(gomega.Equal(ActionUpdate))
+		c.g.Expect(res.Entries[0].Before).To(gomega.HaveKey("price"))
+		c.g.Expect(res.Entries[0].Changes).To(gomega.HaveKey("price"))
+		c.g.Expect(res.Metadata.TotalEntries).To(gomega.Equal(3))
+	})
+
+	t.Run("error on query", func(t *testing.T) {
+		c := setupTrackTestComponent(t)
+		defer c.db.Close()
+		accessor := newPostgresAuditAccessor(c.db, c.clock)
+
+		c.mock.ExpectQuery(regexp.QuoteMeta(getEntriesQuery)).WillReturnError(errors.New("db error"))
+
+		res, err := accessor.GetAll(context.Background(), GetAuditLogSpec{})
+
+		c.g.Expect(err).ToNot(gomega.BeNil())
+		c.g.Expect(res).To(gomega.BeNil())
+	})
+}
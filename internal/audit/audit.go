@@ -0,0 +1,187 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"kg/procurement/internal/common/database"
+	"reflect"
+	"time"
+)
+
+// Entity is an audited table, entries refer to its rows by id
+type Entity string
+
+const (
+	EntityVendor      Entity = "vendor"
+	EntityProduct     Entity = "product"
+	EntityPrice       Entity = "price"
+	EntityEvaluation  Entity = "vendor_evaluation"
+	EntityEmailStatus Entity = "email_status"
+)
+
+var entities = []Entity{EntityVendor, EntityProduct, EntityPrice, EntityEvaluation, EntityEmailStatus}
+
+var (
+	ErrUnknownEntity = errors.New("unknown audit entity")
+	ErrInvalidPeriod = errors.New("period start must be before period end")
+)
+
+// ParseEntity returns the audited entity named s
+func ParseEntity(s string) (Entity, error) {
+	for _, entity := range entities {
+		if string(entity) == s {
+			return entity, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s", ErrUnknownEntity, s)
+}
+
+type Action string
+
+const (
+	ActionCreate  Action = "create"
+	ActionUpdate  Action = "update"
+	ActionDelete  Action = "delete"
+	ActionRestore Action = "restore"
+)
+
+func (a Action) String() string {
+	return string(a)
+}
+
+// Entry records a change made to a row, Before is nil on creation and After on a hard
+// delete. Actor and RequestID are empty when the change was made outside of a request
+type Entry struct {
+	ID        string    `db:"id" json:"id"`
+	Entity    Entity    `db:"entity" json:"entity"`
+	EntityID  string    `db:"entity_id" json:"entity_id"`
+	Action    Action    `db:"action" json:"action"`
+	Actor     string    `db:"actor" json:"actor"`
+	RequestID string    `db:"request_id" json:"request_id"`
+	Before    Snapshot  `db:"before" json:"before"`
+	After     Snapshot  `db:"after" json:"after"`
+	Changes   Changes   `db:"changes" json:"changes"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
+
+// Snapshot is a row as stored, keyed by column
+type Snapshot map[string]any
+
+func (s Snapshot) Value() (driver.Value, error) {
+	if s == nil {
+		return nil, nil
+	}
+	return marshalJSON(s)
+}
+
+func (s *Snapshot) Scan(src any) error {
+	return unmarshalJSON(src, s)
+}
+
+// Change is the value of a column before and after a change
+type Change struct {
+	Old any `json:"old"`
+	New any `json:"new"`
+}
+
+// Changes are the columns that differ between two snapshots
+type Changes map[string]Change
+
+func (c Changes) Value() (driver.Value, error) {
+	if c == nil {
+		c = Changes{}
+	}
+	return marshalJSON(c)
+}
+
+func (c *Changes) Scan(src any) error {
+	return unmarshalJSON(src, c)
+}
+
+// Diff lists the columns whose value differs between before and after, a column
+// missing from either is compared to null
+func Diff(before Snapshot, after Snapshot) Changes {
+	changes := Changes{}
+	for column, old := range before {
+		if value, ok := after[column]; !ok || !reflect.DeepEqual(old, value) {
+			changes[column] = Change{Old: old, New: value}
+		}
+	}
+	for column, value := range after {
+		if _, ok := before[column]; !ok && value != nil {
+			changes[column] = Change{New: value}
+		}
+	}
+	return changes
+}
+
+// marshalJSON stores a document as text, lib/pq would send []byte as bytea
+func marshalJSON(v any) (driver.Value, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return string(raw), nil
+}
+
+// unmarshalJSON keeps numbers as json.Number so that amounts are compared exactly
+func unmarshalJSON(src any, dest any) error {
+	var raw []byte
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		raw = v
+	case string:
+		raw = []byte(v)
+	default:
+		return fmt.Errorf("unsupported audit document type: %T", src)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	return decoder.Decode(dest)
+}
+
+type actorKey struct{}
+
+type requestIDKey struct{}
+
+// WithActor attributes the changes made with ctx to actor
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// WithRequestID ties the changes made with ctx to the request they were made for
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+func actorOf(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
+
+func requestIDOf(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+type GetAuditLogSpec struct {
+	Entity    Entity
+	EntityID  string
+	Actor     string
+	RequestID string
+	From      time.Time
+	To        time.Time
+	database.PaginationSpec
+}
+
+type AccessorGetAuditLogPaginationData struct {
+	Entries  []Entry                     `json:"entries"`
+	Metadata database.PaginationMetadata `json:"metadata"`
+}
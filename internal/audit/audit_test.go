@@ -0,0 +1,129 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func Test_ParseEntity(t *testing.T) {
+	t.Parallel()
+
+	t.Run("known entity", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		entity, err := ParseEntity("price")
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(entity).To(gomega.Equal(EntityPrice))
+	})
+
+	t.Run("unknown entity", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		_, err := ParseEntity("uom")
+		g.Expect(errors.Is(err, ErrUnknownEntity)).To(gomega.BeTrue())
+	})
+}
+
+func Test_Diff(t *testing.T) {
+	t.Parallel()
+
+	t.Run("lists the changed columns", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		changes := Diff(
+			Snapshot{"id": "P1", "price": json.Number("100"), "deleted_at": nil},
+			Snapshot{"id": "P1", "price": json.Number("120"), "deleted_at": "2024-12-01T00:00:00"},
+		)
+		g.Expect(changes).To(gomega.Equal(Changes{
+			"price":      {Old: json.Number("100"), New: json.Number("120")},
+			"deleted_at": {Old: nil, New: "2024-12-01T00:00:00"},
+		}))
+	})
+
+	t.Run("every column of a created row", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		changes := Diff(nil, Snapshot{"id": "P1", "description": nil})
+		g.Expect(changes).To(gomega.Equal(Changes{"id": {New: "P1"}}))
+	})
+
+	t.Run("nothing when unchanged", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		changes := Diff(Snapshot{"id": "P1"}, Snapshot{"id": "P1"})
+		g.Expect(changes).To(gomega.BeEmpty())
+	})
+}
+
+func Test_Snapshot(t *testing.T) {
+	t.Parallel()
+
+	t.Run("round trips through the database", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		value, err := Snapshot{"id": "P1", "price": json.Number("100.5")}.Value()
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(value).To(gomega.BeAssignableToTypeOf(""))
+
+		var res Snapshot
+		g.Expect(res.Scan([]byte(value.(string)))).To(gomega.Succeed())
+		g.Expect(res).To(gomega.Equal(Snapshot{"id": "P1", "price": json.Number("100.5")}))
+	})
+
+	t.Run("nil is stored as null", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		value, err := Snapshot(nil).Value()
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(value).To(gomega.BeNil())
+
+		var res Snapshot
+		g.Expect(res.Scan(nil)).To(gomega.Succeed())
+		g.Expect(res).To(gomega.BeNil())
+	})
+
+	t.Run("error on unsupported type", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		var res Snapshot
+		g.Expect(res.Scan(1)).ToNot(gomega.Succeed())
+	})
+}
+
+func Test_Changes(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil is stored as an empty document", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		value, err := Changes(nil).Value()
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(value).To(gomega.Equal("{}"))
+	})
+
+	t.Run("scan", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		var res Changes
+		g.Expect(res.Scan(`{"name":{"old":"a","new":"b"}}`)).To(gomega.Succeed())
+		g.Expect(res).To(gomega.Equal(Changes{"name": {Old: "a", New: "b"}}))
+	})
+}
+
+func Test_context(t *testing.T) {
+	t.Parallel()
+
+	g := gomega.NewWithT(t)
+
+	ctx := context.Background()
+	g.Expect(actorOf(ctx)).To(gomega.BeEmpty())
+	g.Expect(requestIDOf(ctx)).To(gomega.BeEmpty())
+
+	ctx = WithRequestID(WithActor(ctx, "admin"), "R1")
+	g.Expect(actorOf(ctx)).To(gomega.Equal("admin"))
+	g.Expect(requestIDOf(ctx)).To(gomega.Equal("R1"))
+}
@@ -0,0 +1,35 @@
+//go:generate mockgen -typed -source=service.go -destination=service_mock.go -package=audit
+package audit
+
+import (
+	"context"
+	"kg/procurement/internal/common/database"
+
+	"github.com/benbjohnson/clock"
+)
+
+type auditDBAccessor interface {
+	GetAll(ctx context.Context, spec GetAuditLogSpec) (*AccessorGetAuditLogPaginationData, error)
+}
+
+type AuditService struct {
+	auditDBAccessor
+}
+
+// GetAll lists the entries matching spec, the latest first
+func (a *AuditService) GetAll(ctx context.Context, spec GetAuditLogSpec) (*AccessorGetAuditLogPaginationData, error) {
+	if !spec.From.IsZero() && !spec.To.IsZero() && spec.From.After(spec.To) {
+		return nil, ErrInvalidPeriod
+	}
+
+	return a.auditDBAccessor.GetAll(ctx, spec)
+}
+
+func NewAuditService(
+	conn database.DBConnector,
+	clock clock.Clock,
+) *AuditService {
+	return &AuditService{
+		auditDBAccessor: newPostgresAuditAccessor(conn, clock),
+	}
+}
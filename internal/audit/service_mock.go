@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+//
+// Generated by this command:
+//
+//	mockgen -typed -source=service.go -destination=service_mock.go -package=audit
+//
+
+// Package audit is a generated GoMock package.
+package audit
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockauditDBAccessor is a mock of auditDBAccessor interface.
+type MockauditDBAccessor struct {
+	ctrl     *gomock.Controller
+	recorder *MockauditDBAccessorMockRecorder
+}
+
+// MockauditDBAccessorMockRecorder is the mock recorder for MockauditDBAccessor.
+type MockauditDBAccessorMockRecorder struct {
+	mock *MockauditDBAccessor
+}
+
+// NewMockauditDBAccessor creates a new mock instance.
+func NewMockauditDBAccessor(ctrl *gomock.Controller) *MockauditDBAccessor {
+	mock := &MockauditDBAccessor{ctrl: ctrl}
+	mock.recorder = &MockauditDBAccessorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockauditDBAccessor) EXPECT() *MockauditDBAccessorMockRecorder {
+	return m.recorder
+}
+
+// GetAll mocks base method.
+func (m *MockauditDBAccessor) GetAll(ctx context.Context, spec GetAuditLogSpec) (*AccessorGetAuditLogPaginationData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, spec)
+	ret0, _ := ret[0].(*AccessorGetAuditLogPaginationData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockauditDBAccessorMockRecorder) GetAll(ctx, spec any) *MockauditDBAccessorGetAllCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockauditDBAccessor)(nil).GetAll), ctx, spec)
+	return &MockauditDBAccessorGetAllCall{Call: call}
+}
+
+// MockauditDBAccessorGetAllCall wrap *gomock.Call
+type MockauditDBAccessorGetAllCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockauditDBAccessorGetAllCall) Return(arg0 *AccessorGetAuditLogPaginationData, arg1 error) *MockauditDBAccessorGetAllCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockauditDBAccessorGetAllCall) Do(f func(context.Context, GetAuditLogSpec) (*AccessorGetAuditLogPaginationData, error)) *MockauditDBAccessorGetAllCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockauditDBAccessorGetAllCall) DoAndReturn(f func(context.Context, GetAuditLogSpec) (*AccessorGetAuditLogPaginationData, error)) *MockauditDBAccessorGetAllCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -0,0 +1,78 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"kg/procurement/internal/common/database"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_NewAuditService(t *testing.T) {
+	_ = NewAuditService(nil, nil)
+}
+
+func TestAuditService_GetAll(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mockAuditAccessor *MockauditDBAccessor
+		subject           *AuditService
+	)
+
+	setup := func(t *testing.T) *gomega.GomegaWithT {
+		ctrl := gomock.NewController(t)
+		mockAuditAccessor = NewMockauditDBAccessor(ctrl)
+		subject = &AuditService{
+			auditDBAccessor: mockAuditAccessor,
+		}
+		return gomega.NewWithT(t)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		g := setup(t)
+		ctx := context.Background()
+
+		spec := GetAuditLogSpec{
+			Entity:         EntityPrice,
+			EntityID:       "P1",
+			PaginationSpec: database.PaginationSpec{Limit: 10, Page: 1},
+		}
+		expected := &AccessorGetAuditLogPaginationData{
+			Entries: []Entry{{ID: "E1", Entity: EntityPrice, EntityID: "P1"}},
+		}
+		mockAuditAccessor.EXPECT().GetAll(ctx, spec).Return(expected, nil)
+
+		res, err := subject.GetAll(ctx, spec)
+
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(res).To(gomega.Equal(expected))
+	})
+
+	t.Run("error on inverted period", func(t *testing.T) {
+		g := setup(t)
+
+		res, err := subject.GetAll(context.Background(), GetAuditLogSpec{
+			From: time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+		})
+
+		g.Expect(err).To(gomega.Equal(ErrInvalidPeriod))
+		g.Expect(res).To(gomega.BeNil())
+	})
+
+	t.Run("error on accessor", func(t *testing.T) {
+		g := setup(t)
+		ctx := context.Background()
+
+		mockAuditAccessor.EXPECT().GetAll(ctx, GetAuditLogSpec{}).Return(nil, errors.New("error"))
+
+		res, err := subject.GetAll(ctx, GetAuditLogSpec{})
+
+		g.Expect(err).ToNot(gomega.BeNil())
+		g.Expect(res).To(gomega.BeNil())
+	})
+}
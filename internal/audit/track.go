@@ -0,0 +1,142 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"kg/procurement/cmd/utils"
+	"kg/procurement/internal/common/database"
+	"kg/procurement/internal/common/helper"
+
+	"github.com/benbjohnson/clock"
+	"github.com/lib/pq"
+)
+
+const (
+	// InsertEntryQuery records a batch of entries
+	InsertEntryQuery = `
+		INSERT INTO audit_log
+			(id, entity, entity_id, action, actor, request_id, before, after, changes, created_at)
+		VALUES
+			(:id, :entity, :entity_id, :action, :actor, :request_id, :before, :after, :changes, :created_at)
+	`
+
+	// entryBatchSize keeps a batch of entries below the bind parameters postgres allows
+	entryBatchSize = 1000
+)
+
+// SnapshotQuery reads the rows of entity as json, the entity is one of the audited tables
+func SnapshotQuery(entity Entity) string {
+	return fmt.Sprintf(`SELECT t.id, to_jsonb(t) AS row FROM %s t WHERE t.id = ANY($1)`, entity)
+}
+
+type snapshotRow struct {
+	ID  string   `db:"id"`
+	Row Snapshot `db:"row"`
+}
+
+func snapshot(ctx context.Context, db database.DBConnector, entity Entity, ids []string) (map[string]Snapshot, error) {
+	var rows []snapshotRow
+	if err := db.SelectContext(ctx, &rows, SnapshotQuery(entity), pq.Array(ids)); err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	res := make(map[string]Snapshot, len(rows))
+	for _, row := range rows {
+		res[row.ID] = row.Row
+	}
+	return res, nil
+}
+
+// Track runs write as a unit of work and records an entry for every row of entity among
+// ids it changed. The rows are read before and after write within its transaction, so
+// what is recorded is what was stored whichever statements wrote it. The actor of the
+// context takes precedence over the given one, which is the actor the caller was told
+func Track(
+	ctx context.Context,
+	db database.DBConnector,
+	clock clock.Clock,
+	entity Entity,
+	ids []string,
+	actor string,
+	write func(tx database.DBConnector) error,
+) error {
+	if _, err := ParseEntity(string(entity)); err != nil {
+		return err
+	}
+	if ctxActor := actorOf(ctx); ctxActor != "" {
+		actor = ctxActor
+	}
+
+	return database.RunInTx(ctx, db, func(tx database.DBConnector) error {
+		before, err := snapshot(ctx, tx, entity, ids)
+		if err != nil {
+			return err
+		}
+		if err := write(tx); err != nil {
+			return err
+		}
+		after, err := snapshot(ctx, tx, entity, ids)
+		if err != nil {
+			return err
+		}
+
+		var entries []Entry
+		for _, id := range ids {
+			action, ok := actionOf(before[id], after[id])
+			if !ok {
+				continue
+			}
+			entryID, err := helper.GenerateRandomID()
+			if err != nil {
+				utils.Logger.Error(err.Error())
+				return err
+			}
+			entries = append(entries, Entry{
+				ID:        entryID,
+				Entity:    entity,
+				EntityID:  id,
+				Action:    action,
+				Actor:     actor,
+				RequestID: requestIDOf(ctx),
+				Before:    before[id],
+				After:     after[id],
+				Changes:   Diff(before[id], after[id]),
+				CreatedAt: clock.Now(),
+			})
+		}
+		return writeEntries(ctx, tx, entries)
+	})
+}
+
+// actionOf tells what was done to a row from its snapshots, a soft delete sets its
+// deleted_at. Nothing was done when the row is unchanged
+func actionOf(before Snapshot, after Snapshot) (Action, bool) {
+	switch {
+	case before == nil && after == nil:
+		return "", false
+	case before == nil:
+		return ActionCreate, true
+	case after == nil:
+		return ActionDelete, true
+	case len(Diff(before, after)) == 0:
+		return "", false
+	case before["deleted_at"] == nil && after["deleted_at"] != nil:
+		return ActionDelete, true
+	case before["deleted_at"] != nil && after["deleted_at"] == nil:
+		return ActionRestore, true
+	default:
+		return ActionUpdate, true
+	}
+}
+
+func writeEntries(ctx context.Context, db database.DBConnector, entries []Entry) error {
+	for start := 0; start < len(entries); start += entryBatchSize {
+		batch := entries[start:min(start+entryBatchSize, len(entries))]
+		if _, err := db.NamedExecContext(ctx, InsertEntryQuery, batch); err != nil {
+			utils.Logger.Error(err.Error())
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,209 @@
+package audit
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"kg/procurement/internal/common/database"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/benbjohnson/clock"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/onsi/gomega"
+)
+
+type trackTestComponent struct {
+	g     *gomega.WithT
+	mock  sqlmock.Sqlmock
+	db    *sqlx.DB
+	clock *clock.Mock
+}
+
+func setupTrackTestComponent(t *testing.T) trackTestComponent {
+	db, sqlMock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
+	clockMock := clock.NewMock()
+	clockMock.Set(time.Date(2024, time.December, 11, 0, 0, 0, 0, time.UTC))
+
+	return trackTestComponent{
+		g:     gomega.NewWithT(t),
+		mock:  sqlMock,
+		db:    sqlx.NewDb(db, "sqlmock"),
+		clock: clockMock,
+	}
+}
+
+func (c trackTestComponent) expectSnapshot(rows ...[2]string) {
+	res := sqlmock.NewRows([]string{"id", "row"})
+	for _, row := range rows {
+		res.AddRow(row[0], []byte(row[1]))
+	}
+	c.mock.ExpectQuery(regexp.QuoteMeta(SnapshotQuery(EntityPrice))).
+		WithArgs(pq.Array([]string{"P1"})).
+		WillReturnRows(res)
+}
+
+func (c trackTestComponent) expectEntry(action Action, actor string, requestID string) {
+	c.mock.ExpectExec("INSERT INTO audit_log").
+		WithArgs(
+			sqlmock.AnyArg(),
+			EntityPrice,
+			"P1",
+			action,
+			actor,
+			requestID,
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+			c.clock.Now(),
+		).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
+func (c trackTestComponent) writeTx(err error) func(tx database.DBConnector) error {
+	return func(tx database.DBConnector) error {
+		if _, execErr := tx.ExecContext(context.Background(), "UPDATE price"); execErr != nil {
+			return execErr
+		}
+		return err
+	}
+}
+
+func Test_Track(t *testing.T) {
+	t.Parallel()
+
+	var (
+		price   = `{"id":"P1","price":100,"deleted_at":null}`
+		updated = `{"id":"P1","price":120,"deleted_at":null}`
+		deleted = `{"id":"P1","price":100,"deleted_at":"2024-12-11T00:00:00"}`
+	)
+
+	tests := []struct {
+		name   string
+		before [][2]string
+		after  [][2]string
+		action Action
+	}{
+		{"records a created row", nil, [][2]string{{"P1", price}}, ActionCreate},
+		{"records an updated row", [][2]string{{"P1", price}}, [][2]string{{"P1", updated}}, ActionUpdate},
+		{"records a soft deleted row", [][2]string{{"P1", price}}, [][2]string{{"P1", deleted}}, ActionDelete},
+		{"records a deleted row", [][2]string{{"P1", price}}, nil, ActionDelete},
+		{"records a restored row", [][2]string{{"P1", deleted}}, [][2]string{{"P1", price}}, ActionRestore},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := setupTrackTestComponent(t)
+			defer c.db.Close()
+
+			c.mock.ExpectBegin()
+			c.expectSnapshot(tt.before...)
+			c.mock.ExpectExec("UPDATE price").WillReturnResult(sqlmock.NewResult(0, 1))
+			c.expectSnapshot(tt.after...)
+			c.expectEntry(tt.action, "admin", "")
+			c.mock.ExpectCommit()
+
+			err := Track(context.Background(), c.db, c.clock, EntityPrice, []string{"P1"}, "admin", c.writeTx(nil))
+			c.g.Expect(err).To(gomega.BeNil())
+			c.g.Expect(c.mock.ExpectationsWereMet()).To(gomega.BeNil())
+		})
+	}
+
+	t.Run("skips an unchanged row", func(t *testing.T) {
+		c := setupTrackTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectBegin()
+		c.expectSnapshot([2]string{"P1", price})
+		c.mock.ExpectExec("UPDATE price").WillReturnResult(sqlmock.NewResult(0, 0))
+		c.expectSnapshot([2]string{"P1", price})
+		c.mock.ExpectCommit()
+
+		err := Track(context.Background(), c.db, c.clock, EntityPrice, []string{"P1"}, "admin", c.writeTx(nil))
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(c.mock.ExpectationsWereMet()).To(gomega.BeNil())
+	})
+
+	t.Run("the actor and request of the context take precedence", func(t *testing.T) {
+		c := setupTrackTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectBegin()
+		c.expectSnapshot([2]string{"P1", price})
+		c.mock.ExpectExec("UPDATE price").WillReturnResult(sqlmock.NewResult(0, 1))
+		c.expectSnapshot([2]string{"P1", updated})
+		c.expectEntry(ActionUpdate, "user1", "R1")
+		c.mock.ExpectCommit()
+
+		ctx := WithRequestID(WithActor(context.Background(), "user1"), "R1")
+		err := Track(ctx, c.db, c.clock, EntityPrice, []string{"P1"}, "admin", c.writeTx(nil))
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(c.mock.ExpectationsWereMet()).To(gomega.BeNil())
+	})
+
+	t.Run("rolls back when the write fails", func(t *testing.T) {
+		c := setupTrackTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectBegin()
+		c.expectSnapshot([2]string{"P1", price})
+		c.mock.ExpectExec("UPDATE price").WillReturnResult(sqlmock.NewResult(0, 1))
+		c.mock.ExpectRollback()
+
+		err := Track(context.Background(), c.db, c.clock, EntityPrice, []string{"P1"}, "admin", c.writeTx(errors.New("error")))
+		c.g.Expect(err).ToNot(gomega.BeNil())
+		c.g.Expect(c.mock.ExpectationsWereMet()).To(gomega.BeNil())
+	})
+
+	t.Run("rolls back when the entry can't be written", func(t *testing.T) {
+		c := setupTrackTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectBegin()
+		c.expectSnapshot([2]string{"P1", price})
+		c.mock.ExpectExec("UPDATE price").WillReturnResult(sqlmock.NewResult(0, 1))
+		c.expectSnapshot([2]string{"P1", updated})
+		c.mock.ExpectExec("INSERT INTO audit_log").WillReturnError(errors.New("db error"))
+		c.mock.ExpectRollback()
+
+		err := Track(context.Background(), c.db, c.clock, EntityPrice, []string{"P1"}, "admin", c.writeTx(nil))
+		c.g.Expect(err).ToNot(gomega.BeNil())
+		c.g.Expect(c.mock.ExpectationsWereMet()).To(gomega.BeNil())
+	})
+
+	t.Run("error on an unknown entity", func(t *testing.T) {
+		c := setupTrackTestComponent(t)
+		defer c.db.Close()
+
+		err := Track(context.Background(), c.db, c.clock, Entity("uom"), []string{"P1"}, "admin", c.writeTx(nil))
+		c.g.Expect(errors.Is(err, ErrUnknownEntity)).To(gomega.BeTrue())
+	})
+}
+
+func Test_writeEntries(t *testing.T) {
+	t.Parallel()
+
+	c := setupTrackTestComponent(t)
+	defer c.db.Close()
+
+	entries := make([]Entry, entryBatchSize+1)
+	for i := range entries {
+		entries[i] = Entry{ID: "E", Entity: EntityPrice, EntityID: "P1", Action: ActionUpdate}
+	}
+
+	args := func(n int) []driver.Value {
+		res := make([]driver.Value, 0, n*10)
+		for range n * 10 {
+			res = append(res, sqlmock.AnyArg())
+		}
+		return res
+	}
+	c.mock.ExpectExec("INSERT INTO audit_log").WithArgs(args(entryBatchSize)...).WillReturnResult(sqlmock.NewResult(0, entryBatchSize))
+	c.mock.ExpectExec("INSERT INTO audit_log").WithArgs(args(1)...).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := writeEntries(context.Background(), c.db, entries)
+	c.g.Expect(err).To(gomega.BeNil())
+	c.g.Expect(c.mock.ExpectationsWereMet()).To(gomega.BeNil())
+}
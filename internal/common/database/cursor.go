@@ -0,0 +1,208 @@
+package database
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	PaginationModePage   = "page"
+	PaginationModeCursor = "cursor"
+
+	// CursorValueColumn is the alias listings select their sort column as,
+	// so the value can be read back when building the next and prev cursor
+	CursorValueColumn = "cursor_value"
+)
+
+var (
+	ErrInvalidCursor     = errors.New("invalid cursor")
+	ErrCursorMultiColumn = errors.New("cursor pagination supports a single sort column")
+)
+
+// Cursor is the position of a row on a keyset paginated listing, it is
+// handed to clients as an opaque string via EncodeCursor
+type Cursor struct {
+	Value    interface{} `json:"v"`
+	ID       string      `json:"id"`
+	Backward bool        `json:"b,omitempty"`
+}
+
+func EncodeCursor(c Cursor) string {
+	// text columns scanned into an interface{} come back as bytes
+	if b, ok := c.Value.([]byte); ok {
+		c.Value = string(b)
+	}
+
+	raw, err := json.Marshal(c)
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func DecodeCursor(encoded string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var c Cursor
+	if err := json.Unmarshal(raw, &c); err != nil || c.ID == "" {
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
+
+type KeysetArgs struct {
+	Limit    int
+	Column   string
+	IDColumn string
+	Order    string
+	Cursor   *Cursor
+}
+
+// BuildKeysetArgs resolves the sort column of spec against the allowed map,
+// falling back to defaultOrderBy, and decodes the cursor if one is given.
+// The id column is used as the tie breaker so the ordering is total
+func BuildKeysetArgs(spec PaginationSpec, defaultOrderBy string, idColumn string, allowed map[string]string) (KeysetArgs, error) {
+	limit := 10
+	if spec.Limit > 0 {
+		limit = spec.Limit
+	}
+
+	orderBy := strings.TrimSpace(spec.OrderBy)
+	if orderBy == "" {
+		orderBy = defaultOrderBy
+	}
+	if strings.Contains(orderBy, ",") {
+		return KeysetArgs{}, ErrCursorMultiColumn
+	}
+
+	field, order, found := strings.Cut(orderBy, ":")
+	if !found {
+		order = spec.Order
+	}
+	column, ok := allowed[strings.TrimSpace(field)]
+	if !ok {
+		return KeysetArgs{}, fmt.Errorf("%w: %s", ErrInvalidSortColumn, field)
+	}
+
+	args := KeysetArgs{
+		Limit:    limit,
+		Column:   column,
+		IDColumn: idColumn,
+		Order:    validateOrderString(strings.TrimSpace(order)),
+	}
+
+	if spec.Cursor != "" {
+		cursor, err := DecodeCursor(spec.Cursor)
+		if err != nil {
+			return KeysetArgs{}, err
+		}
+		args.Cursor = cursor
+	}
+
+	return args, nil
+}
+
+// queryOrder is the direction rows are fetched in, walking backward
+// flips it and the rows are reversed again once fetched
+func (k KeysetArgs) queryOrder() string {
+	if k.Cursor == nil || !k.Cursor.Backward {
+		return k.Order
+	}
+	if k.Order == "DESC" {
+		return "ASC"
+	}
+	return "DESC"
+}
+
+// Condition returns the keyset WHERE condition using the given placeholders
+// for the cursor value and id, it is empty on the first page. Sort columns may
+// hold NULL, which ORDER BY puts after every value going ASC and before them
+// going DESC. A row comparison against NULL is never true, so the NULL rows are
+// matched explicitly to keep them on the following pages
+func (k KeysetArgs) Condition(valuePlaceholder string, idPlaceholder string) string {
+	if k.Cursor == nil {
+		return ""
+	}
+
+	if k.queryOrder() == "DESC" {
+		return fmt.Sprintf("(%[1]s < %[3]s OR (%[1]s IS NOT DISTINCT FROM %[3]s AND %[2]s < %[4]s) OR (%[1]s IS NOT NULL AND %[3]s IS NULL))",
+			k.Column, k.IDColumn, valuePlaceholder, idPlaceholder)
+	}
+	return fmt.Sprintf("(%[1]s > %[3]s OR (%[1]s IS NOT DISTINCT FROM %[3]s AND %[2]s > %[4]s) OR (%[1]s IS NULL AND %[3]s IS NOT NULL))",
+		k.Column, k.IDColumn, valuePlaceholder, idPlaceholder)
+}
+
+func (k KeysetArgs) OrderByClause() string {
+	order := k.queryOrder()
+	return fmt.Sprintf("ORDER BY %s %s, %s %s", k.Column, order, k.IDColumn, order)
+}
+
+// FetchLimit fetches one row past the page to know if there is a next one
+func (k KeysetArgs) FetchLimit() int {
+	return k.Limit + 1
+}
+
+// GenerateCursorMetadata trims the extra row fetched by FetchLimit, restores
+// the requested ordering and builds the next and prev cursors of the page
+func GenerateCursorMetadata[T any](k KeysetArgs, rows []T, cursorOf func(T) Cursor) ([]T, PaginationMetadata) {
+	hasMore := len(rows) > k.Limit
+	if hasMore {
+		rows = rows[:k.Limit]
+	}
+
+	backward := k.Cursor != nil && k.Cursor.Backward
+	if backward {
+		slices.Reverse(rows)
+	}
+
+	metadata := PaginationMetadata{}
+	if len(rows) == 0 {
+		return rows, metadata
+	}
+
+	if (!backward && hasMore) || backward {
+		next := cursorOf(rows[len(rows)-1])
+		next.Backward = false
+		metadata.NextCursor = EncodeCursor(next)
+	}
+	if (backward && hasMore) || (!backward && k.Cursor != nil) {
+		prev := cursorOf(rows[0])
+		prev.Backward = true
+		metadata.PrevCursor = EncodeCursor(prev)
+	}
+
+	return rows, metadata
+}
+
+// ExportBatchSize is the page size WalkCursor reads a listing with
+const ExportBatchSize = 500
+
+// WalkCursor reads every row of a keyset paginated listing in batches so
+// exports never hold more than a page in memory. fetch is called with the
+// spec of each page and returns its metadata, the walk stops once there is
+// no next page or fetch fails. The filters and ordering of spec are kept
+func WalkCursor(spec PaginationSpec, fetch func(page PaginationSpec) (PaginationMetadata, error)) error {
+	page := spec
+	page.Mode = PaginationModeCursor
+	page.Limit = ExportBatchSize
+	page.Page = 1
+	page.Cursor = ""
+
+	for {
+		metadata, err := fetch(page)
+		if err != nil {
+			return err
+		}
+		if metadata.NextCursor == "" {
+			return nil
+		}
+		page.Cursor = metadata.NextCursor
+	}
+}
@@ -0,0 +1,231 @@
+package database
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestEncodeDecodeCursor(t *testing.T) {
+	t.Parallel()
+
+	t.Run("round trip", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		encoded := EncodeCursor(Cursor{Value: []byte("acme"), ID: "1", Backward: true})
+		res, err := DecodeCursor(encoded)
+
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(res).To(gomega.Equal(&Cursor{Value: "acme", ID: "1", Backward: true}))
+	})
+
+	t.Run("error on malformed cursor", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		res, err := DecodeCursor("not a cursor!")
+
+		g.Expect(err).To(gomega.Equal(ErrInvalidCursor))
+		g.Expect(res).To(gomega.BeNil())
+	})
+
+	t.Run("error on cursor without id", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		res, err := DecodeCursor(EncodeCursor(Cursor{Value: "acme"}))
+
+		g.Expect(err).To(gomega.Equal(ErrInvalidCursor))
+		g.Expect(res).To(gomega.BeNil())
+	})
+}
+
+func TestBuildKeysetArgs(t *testing.T) {
+	t.Parallel()
+
+	allowed := map[string]string{
+		"name": "v.name",
+		"dt":   "v.dt",
+	}
+
+	t.Run("first page uses the default column", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		res, err := BuildKeysetArgs(PaginationSpec{Mode: PaginationModeCursor, Order: "desc"}, "dt", "v.id", allowed)
+
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(res.Limit).To(gomega.Equal(10))
+		g.Expect(res.FetchLimit()).To(gomega.Equal(11))
+		g.Expect(res.Condition("$1", "$2")).To(gomega.BeEmpty())
+		g.Expect(res.OrderByClause()).To(gomega.Equal("ORDER BY v.dt DESC, v.id DESC"))
+	})
+
+	t.Run("forward cursor", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		spec := PaginationSpec{
+			Limit:   5,
+			OrderBy: "name:asc",
+			Cursor:  EncodeCursor(Cursor{Value: "acme", ID: "1"}),
+		}
+		res, err := BuildKeysetArgs(spec, "dt", "v.id", allowed)
+
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(res.Condition("$1", "$2")).To(gomega.Equal(
+			"(v.name > $1 OR (v.name IS NOT DISTINCT FROM $1 AND v.id > $2) OR (v.name IS NULL AND $1 IS NOT NULL))",
+		))
+		g.Expect(res.OrderByClause()).To(gomega.Equal("ORDER BY v.name ASC, v.id ASC"))
+	})
+
+	t.Run("backward cursor flips the direction", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		spec := PaginationSpec{
+			Order:   "ASC",
+			OrderBy: "name",
+			Cursor:  EncodeCursor(Cursor{Value: "acme", ID: "1", Backward: true}),
+		}
+		res, err := BuildKeysetArgs(spec, "dt", "v.id", allowed)
+
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(res.Condition(":v", ":id")).To(gomega.Equal(
+			"(v.name < :v OR (v.name IS NOT DISTINCT FROM :v AND v.id < :id) OR (v.name IS NOT NULL AND :v IS NULL))",
+		))
+		g.Expect(res.OrderByClause()).To(gomega.Equal("ORDER BY v.name DESC, v.id DESC"))
+	})
+
+	t.Run("cursor on a NULL sort value", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		spec := PaginationSpec{
+			Order:   "DESC",
+			OrderBy: "name",
+			Cursor:  EncodeCursor(Cursor{Value: nil, ID: "3"}),
+		}
+		res, err := BuildKeysetArgs(spec, "dt", "v.id", allowed)
+
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(res.Cursor).To(gomega.Equal(&Cursor{Value: nil, ID: "3"}))
+		g.Expect(res.Condition("$1", "$2")).To(gomega.Equal(
+			"(v.name < $1 OR (v.name IS NOT DISTINCT FROM $1 AND v.id < $2) OR (v.name IS NOT NULL AND $1 IS NULL))",
+		))
+		g.Expect(res.OrderByClause()).To(gomega.Equal("ORDER BY v.name DESC, v.id DESC"))
+	})
+
+	t.Run("error on multiple sort columns", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		_, err := BuildKeysetArgs(PaginationSpec{OrderBy: "name,dt"}, "dt", "v.id", allowed)
+
+		g.Expect(err).To(gomega.Equal(ErrCursorMultiColumn))
+	})
+
+	t.Run("error on unknown sort column", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		_, err := BuildKeysetArgs(PaginationSpec{OrderBy: "password"}, "dt", "v.id", allowed)
+
+		g.Expect(errors.Is(err, ErrInvalidSortColumn)).To(gomega.BeTrue())
+	})
+
+	t.Run("error on invalid cursor", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		_, err := BuildKeysetArgs(PaginationSpec{Cursor: "%%%"}, "dt", "v.id", allowed)
+
+		g.Expect(err).To(gomega.Equal(ErrInvalidCursor))
+	})
+}
+
+func TestGenerateCursorMetadata(t *testing.T) {
+	t.Parallel()
+
+	type row struct {
+		ID   string
+		Name string
+	}
+	cursorOf := func(r row) Cursor {
+		return Cursor{Value: r.Name, ID: r.ID}
+	}
+
+	t.Run("first page with more rows", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		args := KeysetArgs{Limit: 2, Column: "v.name", IDColumn: "v.id", Order: "ASC"}
+		rows := []row{{"1", "a"}, {"2", "b"}, {"3", "c"}}
+
+		res, metadata := GenerateCursorMetadata(args, rows, cursorOf)
+
+		g.Expect(res).To(gomega.Equal([]row{{"1", "a"}, {"2", "b"}}))
+		g.Expect(metadata.NextCursor).To(gomega.Equal(EncodeCursor(Cursor{Value: "b", ID: "2"})))
+		g.Expect(metadata.PrevCursor).To(gomega.BeEmpty())
+	})
+
+	t.Run("last page", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		args := KeysetArgs{Limit: 2, Order: "ASC", Cursor: &Cursor{Value: "b", ID: "2"}}
+		rows := []row{{"3", "c"}}
+
+		res, metadata := GenerateCursorMetadata(args, rows, cursorOf)
+
+		g.Expect(res).To(gomega.Equal([]row{{"3", "c"}}))
+		g.Expect(metadata.NextCursor).To(gomega.BeEmpty())
+		g.Expect(metadata.PrevCursor).To(gomega.Equal(EncodeCursor(Cursor{Value: "c", ID: "3", Backward: true})))
+	})
+
+	t.Run("walking backward restores the order", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		args := KeysetArgs{Limit: 2, Order: "ASC", Cursor: &Cursor{Value: "d", ID: "4", Backward: true}}
+		rows := []row{{"3", "c"}, {"2", "b"}, {"1", "a"}}
+
+		res, metadata := GenerateCursorMetadata(args, rows, cursorOf)
+
+		g.Expect(res).To(gomega.Equal([]row{{"2", "b"}, {"3", "c"}}))
+		g.Expect(metadata.NextCursor).To(gomega.Equal(EncodeCursor(Cursor{Value: "c", ID: "3"})))
+		g.Expect(metadata.PrevCursor).To(gomega.Equal(EncodeCursor(Cursor{Value: "b", ID: "2", Backward: true})))
+	})
+
+	t.Run("empty page", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		res, metadata := GenerateCursorMetadata(KeysetArgs{Limit: 2}, []row{}, cursorOf)
+
+		g.Expect(res).To(gomega.BeEmpty())
+		g.Expect(metadata).To(gomega.Equal(PaginationMetadata{}))
+	})
+}
+
+func TestWalkCursor(t *testing.T) {
+	t.Parallel()
+
+	t.Run("walks every page in cursor mode", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		var pages []PaginationSpec
+		cursors := []string{"next", ""}
+		err := WalkCursor(PaginationSpec{Limit: 10, Page: 3, OrderBy: "name", Order: "DESC"}, func(page PaginationSpec) (PaginationMetadata, error) {
+			pages = append(pages, page)
+			return PaginationMetadata{NextCursor: cursors[len(pages)-1]}, nil
+		})
+
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(pages).To(gomega.Equal([]PaginationSpec{
+			{Limit: ExportBatchSize, Page: 1, OrderBy: "name", Order: "DESC", Mode: PaginationModeCursor},
+			{Limit: ExportBatchSize, Page: 1, OrderBy: "name", Order: "DESC", Mode: PaginationModeCursor, Cursor: "next"},
+		}))
+	})
+
+	t.Run("stops on error", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		calls := 0
+		err := WalkCursor(PaginationSpec{}, func(page PaginationSpec) (PaginationMetadata, error) {
+			calls++
+			return PaginationMetadata{NextCursor: "next"}, errors.New("error")
+		})
+
+		g.Expect(err).ToNot(gomega.BeNil())
+		g.Expect(calls).To(gomega.Equal(1))
+	})
+}
@@ -1,14 +1,26 @@
 package database
 
 import (
+	"errors"
+	"fmt"
 	"strings"
 )
 
+var ErrInvalidSortColumn = errors.New("invalid sort column")
+
 type PaginationSpec struct {
 	Limit   int    `json:"limit"`
 	Page    int    `json:"page"`
 	Order   string `json:"order"`
 	OrderBy string `json:"order_by"`
+	Mode    string `json:"mode"`
+	Cursor  string `json:"cursor"`
+}
+
+// IsCursor reports whether the listing should be keyset paginated
+// instead of using LIMIT/OFFSET, a given cursor implies cursor mode
+func (s PaginationSpec) IsCursor() bool {
+	return s.Mode == PaginationModeCursor || s.Cursor != ""
 }
 
 type PaginationArgs struct {
@@ -19,9 +31,11 @@ type PaginationArgs struct {
 }
 
 type PaginationMetadata struct {
-	TotalPage    int `json:"total_page"`
-	CurrentPage  int `json:"current_page"`
-	TotalEntries int `json:"total_entries"`
+	TotalPage    int    `json:"total_page"`
+	CurrentPage  int    `json:"current_page"`
+	TotalEntries int    `json:"total_entries"`
+	NextCursor   string `json:"next_cursor,omitempty"`
+	PrevCursor   string `json:"prev_cursor,omitempty"`
 }
 
 func validateOrderString(order string) string {
@@ -65,3 +79,32 @@ func GeneratePaginationMetadata(spec PaginationSpec, totalEntries int) Paginatio
 	}
 
 }
+
+// BuildOrderByClause turns a comma separated order_by, i.e. "rating:desc,name",
+// into an ORDER BY clause. Columns are looked up on the allowed map so user input
+// is never concatenated into the query, columns without a direction use order
+func BuildOrderByClause(orderBy string, order string, allowed map[string]string) (string, error) {
+	var columns []string
+	for _, part := range strings.Split(orderBy, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		field, direction, found := strings.Cut(part, ":")
+		if !found {
+			direction = order
+		}
+
+		column, ok := allowed[strings.TrimSpace(field)]
+		if !ok {
+			return "", fmt.Errorf("%w: %s", ErrInvalidSortColumn, field)
+		}
+		columns = append(columns, fmt.Sprintf("%s %s", column, validateOrderString(strings.TrimSpace(direction))))
+	}
+
+	if len(columns) == 0 {
+		return "", nil
+	}
+	return "ORDER BY " + strings.Join(columns, ", "), nil
+}
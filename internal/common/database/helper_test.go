@@ -0,0 +1,76 @@
+package database
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestBuildOrderByClause(t *testing.T) {
+	allowed := map[string]string{
+		"name":   "v.name",
+		"rating": "v.rating",
+	}
+
+	tests := []struct {
+		name    string
+		orderBy string
+		order   string
+		want    string
+		err     error
+	}{
+		{
+			name:    "empty order by",
+			orderBy: "",
+			order:   "DESC",
+			want:    "",
+		},
+		{
+			name:    "single column uses default order",
+			orderBy: "rating",
+			order:   "desc",
+			want:    "ORDER BY v.rating DESC",
+		},
+		{
+			name:    "multiple columns with their own direction",
+			orderBy: "rating:desc, name:asc",
+			order:   "DESC",
+			want:    "ORDER BY v.rating DESC, v.name ASC",
+		},
+		{
+			name:    "invalid direction falls back to ascending",
+			orderBy: "name:sideways",
+			order:   "DESC",
+			want:    "ORDER BY v.name ASC",
+		},
+		{
+			name:    "unknown column",
+			orderBy: "rating,password",
+			order:   "ASC",
+			err:     ErrInvalidSortColumn,
+		},
+		{
+			name:    "injection attempt",
+			orderBy: "rating; DROP TABLE vendor",
+			order:   "ASC",
+			err:     ErrInvalidSortColumn,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			res, err := BuildOrderByClause(tt.orderBy, tt.order, allowed)
+
+			if tt.err != nil {
+				g.Expect(errors.Is(err, tt.err)).To(gomega.BeTrue())
+				g.Expect(res).To(gomega.BeEmpty())
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(res).To(gomega.Equal(tt.want))
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// PoolOptions sizes the connection pool, zero values keep the database/sql defaults
+type PoolOptions struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+}
+
+// ConfigurePool applies opts to the connection pool
+func (c *Conn) ConfigurePool(opts PoolOptions) {
+	if opts.MaxOpenConns > 0 {
+		c.db.SetMaxOpenConns(opts.MaxOpenConns)
+	}
+	if opts.MaxIdleConns > 0 {
+		c.db.SetMaxIdleConns(opts.MaxIdleConns)
+	}
+	if opts.ConnMaxLifetime > 0 {
+		c.db.SetConnMaxLifetime(opts.ConnMaxLifetime)
+	}
+	if opts.ConnMaxIdleTime > 0 {
+		c.db.SetConnMaxIdleTime(opts.ConnMaxIdleTime)
+	}
+}
+
+// Stats reports the usage of the connection pool
+func (c *Conn) Stats() sql.DBStats {
+	return c.db.Stats()
+}
+
+// PingContext checks the primary is reachable, replicas are checked in the background
+func (c *RoutedConn) PingContext(ctx context.Context) error {
+	p, ok := c.primary.(pinger)
+	if !ok {
+		return nil
+	}
+	return p.PingContext(ctx)
+}
+
+// PoolStats is the usage of a connection pool, Healthy is false for a replica left out
+// of the reads
+type PoolStats struct {
+	Name               string        `json:"name"`
+	Healthy            bool          `json:"healthy"`
+	MaxOpenConnections int           `json:"max_open_connections"`
+	OpenConnections    int           `json:"open_connections"`
+	InUse              int           `json:"in_use"`
+	Idle               int           `json:"idle"`
+	WaitCount          int64         `json:"wait_count"`
+	WaitDuration       time.Duration `json:"wait_duration"`
+	MaxIdleClosed      int64         `json:"max_idle_closed"`
+	MaxIdleTimeClosed  int64         `json:"max_idle_time_closed"`
+	MaxLifetimeClosed  int64         `json:"max_lifetime_closed"`
+}
+
+type statser interface {
+	Stats() sql.DBStats
+}
+
+func newPoolStats(name string, healthy bool, stats sql.DBStats) PoolStats {
+	return PoolStats{
+		Name:               name,
+		Healthy:            healthy,
+		MaxOpenConnections: stats.MaxOpenConnections,
+		OpenConnections:    stats.OpenConnections,
+		InUse:              stats.InUse,
+		Idle:               stats.Idle,
+		WaitCount:          stats.WaitCount,
+		WaitDuration:       stats.WaitDuration,
+		MaxIdleClosed:      stats.MaxIdleClosed,
+		MaxIdleTimeClosed:  stats.MaxIdleTimeClosed,
+		MaxLifetimeClosed:  stats.MaxLifetimeClosed,
+	}
+}
+
+// GetPoolStats reports the pools behind db, the primary first followed by its replicas.
+// Transactions borrow a connection and have no pool of their own
+func GetPoolStats(db DBConnector) []PoolStats {
+	switch conn := db.(type) {
+	case *RoutedConn:
+		stats := GetPoolStats(conn.primary)
+		for i, r := range conn.replicas {
+			if s, ok := r.db.(statser); ok {
+				stats = append(stats, newPoolStats(fmt.Sprintf("replica_%d", i), r.healthy.Load(), s.Stats()))
+			}
+		}
+		return stats
+	case statser:
+		return []PoolStats{newPoolStats("primary", true, conn.Stats())}
+	default:
+		return nil
+	}
+}
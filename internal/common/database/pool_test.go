@@ -0,0 +1,56 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+)
+
+func TestConn_ConfigurePool(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+	conn, _ := setupConn(t, 0)
+
+	conn.ConfigurePool(PoolOptions{MaxOpenConns: 5, ConnMaxLifetime: time.Minute})
+	g.Expect(conn.Stats().MaxOpenConnections).To(gomega.Equal(5))
+}
+
+func TestGetPoolStats(t *testing.T) {
+	t.Parallel()
+
+	t.Run("a single connection", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		conn, _ := setupConn(t, 0)
+		conn.ConfigurePool(PoolOptions{MaxOpenConns: 5})
+
+		stats := GetPoolStats(conn)
+		g.Expect(stats).To(gomega.HaveLen(1))
+		g.Expect(stats[0].Name).To(gomega.Equal("primary"))
+		g.Expect(stats[0].Healthy).To(gomega.BeTrue())
+		g.Expect(stats[0].MaxOpenConnections).To(gomega.Equal(5))
+	})
+
+	t.Run("the primary and its replicas", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		primary, _ := setupConn(t, 0)
+		up, _ := setupReplica(t, true)
+		down, _ := setupReplica(t, false)
+		conn := NewRoutedConn(primary, []DBConnector{up, down}, time.Hour)
+		defer conn.Close()
+
+		stats := GetPoolStats(conn)
+		g.Expect(stats).To(gomega.HaveLen(3))
+		g.Expect(stats[1].Name).To(gomega.Equal("replica_0"))
+		g.Expect(stats[1].Healthy).To(gomega.BeTrue())
+		g.Expect(stats[2].Name).To(gomega.Equal("replica_1"))
+		g.Expect(stats[2].Healthy).To(gomega.BeFalse())
+		g.Expect(conn.PingContext(context.Background())).To(gomega.Succeed())
+	})
+
+	t.Run("a transaction has no pool", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		g.Expect(GetPoolStats(&Tx{})).To(gomega.BeNil())
+	})
+}
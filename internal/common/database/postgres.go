@@ -2,9 +2,11 @@
 package database
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"kg/procurement/cmd/utils"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
@@ -23,10 +25,26 @@ type DBConnector interface {
 	Get(dest interface{}, query string, args ...interface{}) error
 	Rebind(query string) string
 	Close() error
+
+	// context aware variants, the query is cancelled along with ctx
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+	QueryxContext(ctx context.Context, query string, args ...interface{}) (*sqlx.Rows, error)
+	QueryRowxContext(ctx context.Context, query string, args ...interface{}) *sqlx.Row
+	NamedQueryContext(ctx context.Context, query string, arg interface{}) (*sqlx.Rows, error)
+	NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error)
+	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+
+	// BeginTxx starts a transaction, see RunInTx
+	BeginTxx(ctx context.Context, opts *sql.TxOptions) (*sqlx.Tx, error)
 }
 
 type Conn struct {
 	db *sqlx.DB
+	// queryTimeout bounds every context aware query, zero leaves them unbounded
+	queryTimeout time.Duration
 }
 
 func (c *Conn) Exec(query string, args ...interface{}) (sql.Result, error) {
@@ -74,16 +92,114 @@ func (c *Conn) Rebind(query string) string {
 	return c.db.Rebind(query)
 }
 
-func NewConn(host, user, password, name, port string) *Conn {
-	connStr := fmt.Sprintf("user=%s port=%s password=%s dbname=%s host=%s sslmode=disable",
+// withTimeout bounds ctx by timeout for queries that are done once they return
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// rowsTimeout bounds ctx by timeout for queries whose rows are read after they return.
+// Cancelling on return would close the rows, so ctx is cancelled once the timeout
+// passes instead
+func rowsTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	if timeout <= 0 {
+		return ctx
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	time.AfterFunc(timeout, cancel)
+	return ctx
+}
+
+func (c *Conn) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	ctx, cancel := withTimeout(ctx, c.queryTimeout)
+	defer cancel()
+	return c.db.ExecContext(ctx, query, args...)
+}
+
+func (c *Conn) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return c.db.QueryContext(rowsTimeout(ctx, c.queryTimeout), query, args...)
+}
+
+func (c *Conn) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return c.db.QueryRowContext(rowsTimeout(ctx, c.queryTimeout), query, args...)
+}
+
+func (c *Conn) QueryxContext(ctx context.Context, query string, args ...interface{}) (*sqlx.Rows, error) {
+	return c.db.QueryxContext(rowsTimeout(ctx, c.queryTimeout), query, args...)
+}
+
+func (c *Conn) QueryRowxContext(ctx context.Context, query string, args ...interface{}) *sqlx.Row {
+	return c.db.QueryRowxContext(rowsTimeout(ctx, c.queryTimeout), query, args...)
+}
+
+func (c *Conn) NamedQueryContext(ctx context.Context, query string, arg interface{}) (*sqlx.Rows, error) {
+	return c.db.NamedQueryContext(rowsTimeout(ctx, c.queryTimeout), query, arg)
+}
+
+func (c *Conn) NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error) {
+	ctx, cancel := withTimeout(ctx, c.queryTimeout)
+	defer cancel()
+	return c.db.NamedExecContext(ctx, query, arg)
+}
+
+func (c *Conn) SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	ctx, cancel := withTimeout(ctx, c.queryTimeout)
+	defer cancel()
+	return c.db.SelectContext(ctx, dest, query, args...)
+}
+
+func (c *Conn) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	ctx, cancel := withTimeout(ctx, c.queryTimeout)
+	defer cancel()
+	return c.db.GetContext(ctx, dest, query, args...)
+}
+
+func (c *Conn) BeginTxx(ctx context.Context, opts *sql.TxOptions) (*sqlx.Tx, error) {
+	return c.db.BeginTxx(ctx, opts)
+}
+
+// PingContext checks the connection is still alive
+func (c *Conn) PingContext(ctx context.Context) error {
+	return c.db.PingContext(ctx)
+}
+
+// StdDB is the database/sql pool of the primary behind db, nil for a transaction
+func StdDB(db DBConnector) *sql.DB {
+	switch conn := db.(type) {
+	case *Conn:
+		return conn.db.DB
+	case *RoutedConn:
+		return StdDB(conn.primary)
+	default:
+		return nil
+	}
+}
+
+func connString(host, user, password, name, port string) string {
+	return fmt.Sprintf("user=%s port=%s password=%s dbname=%s host=%s sslmode=disable",
 		user, port, password, name, host)
+}
 
-	db := sqlx.MustConnect("postgres", connStr)
+// NewConn connects to the database, queryTimeout bounds every context aware query
+func NewConn(host, user, password, name, port string, queryTimeout time.Duration) *Conn {
+	db := sqlx.MustConnect("postgres", connString(host, user, password, name, port))
 
 	if err := db.Ping(); err != nil {
 		utils.Logger.Fatal(err.Error())
 	}
 
 	utils.Logger.Info("\nSuccessfully connected to database")
-	return &Conn{db: db}
+	return &Conn{db: db, queryTimeout: queryTimeout}
+}
+
+// OpenConn opens a connection without reaching the database, which may be down for now.
+// Replicas are opened this way and left out until they answer their pings
+func OpenConn(host, user, password, name, port string, queryTimeout time.Duration) (*Conn, error) {
+	db, err := sqlx.Open("postgres", connString(host, user, password, name, port))
+	if err != nil {
+		return nil, err
+	}
+	return &Conn{db: db, queryTimeout: queryTimeout}, nil
 }
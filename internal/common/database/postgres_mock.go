@@ -10,6 +10,7 @@
 package database
 
 import (
+	context "context"
 	sql "database/sql"
 	reflect "reflect"
 
@@ -40,6 +41,45 @@ func (m *MockDBConnector) EXPECT() *MockDBConnectorMockRecorder {
 	return m.recorder
 }
 
+// BeginTxx mocks base method.
+func (m *MockDBConnector) BeginTxx(ctx context.Context, opts *sql.TxOptions) (*sqlx.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginTxx", ctx, opts)
+	ret0, _ := ret[0].(*sqlx.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginTxx indicates an expected call of BeginTxx.
+func (mr *MockDBConnectorMockRecorder) BeginTxx(ctx, opts any) *MockDBConnectorBeginTxxCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTxx", reflect.TypeOf((*MockDBConnector)(nil).BeginTxx), ctx, opts)
+	return &MockDBConnectorBeginTxxCall{Call: call}
+}
+
+// MockDBConnectorBeginTxxCall wrap *gomock.Call
+type MockDBConnectorBeginTxxCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockDBConnectorBeginTxxCall) Return(arg0 *sqlx.Tx, arg1 error) *MockDBConnectorBeginTxxCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockDBConnectorBeginTxxCall) Do(f func(context.Context, *sql.TxOptions) (*sqlx.Tx, error)) *MockDBConnectorBeginTxxCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockDBConnectorBeginTxxCall) DoAndReturn(f func(context.Context, *sql.TxOptions) (*sqlx.Tx, error)) *MockDBConnectorBeginTxxCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // Close mocks base method.
 func (m *MockDBConnector) Close() error {
 	m.ctrl.T.Helper()
@@ -122,6 +162,50 @@ func (c *MockDBConnectorExecCall) DoAndReturn(f func(string, ...any) (sql.Result
 	return c
 }
 
+// ExecContext mocks base method.
+func (m *MockDBConnector) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, query}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ExecContext", varargs...)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExecContext indicates an expected call of ExecContext.
+func (mr *MockDBConnectorMockRecorder) ExecContext(ctx, query any, args ...any) *MockDBConnectorExecContextCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, query}, args...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecContext", reflect.TypeOf((*MockDBConnector)(nil).ExecContext), varargs...)
+	return &MockDBConnectorExecContextCall{Call: call}
+}
+
+// MockDBConnectorExecContextCall wrap *gomock.Call
+type MockDBConnectorExecContextCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockDBConnectorExecContextCall) Return(arg0 sql.Result, arg1 error) *MockDBConnectorExecContextCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockDBConnectorExecContextCall) Do(f func(context.Context, string, ...any) (sql.Result, error)) *MockDBConnectorExecContextCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockDBConnectorExecContextCall) DoAndReturn(f func(context.Context, string, ...any) (sql.Result, error)) *MockDBConnectorExecContextCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // Get mocks base method.
 func (m *MockDBConnector) Get(dest any, query string, args ...any) error {
 	m.ctrl.T.Helper()
@@ -165,6 +249,49 @@ func (c *MockDBConnectorGetCall) DoAndReturn(f func(any, string, ...any) error)
 	return c
 }
 
+// GetContext mocks base method.
+func (m *MockDBConnector) GetContext(ctx context.Context, dest any, query string, args ...any) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, dest, query}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetContext", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetContext indicates an expected call of GetContext.
+func (mr *MockDBConnectorMockRecorder) GetContext(ctx, dest, query any, args ...any) *MockDBConnectorGetContextCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, dest, query}, args...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContext", reflect.TypeOf((*MockDBConnector)(nil).GetContext), varargs...)
+	return &MockDBConnectorGetContextCall{Call: call}
+}
+
+// MockDBConnectorGetContextCall wrap *gomock.Call
+type MockDBConnectorGetContextCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockDBConnectorGetContextCall) Return(arg0 error) *MockDBConnectorGetContextCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockDBConnectorGetContextCall) Do(f func(context.Context, any, string, ...any) error) *MockDBConnectorGetContextCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockDBConnectorGetContextCall) DoAndReturn(f func(context.Context, any, string, ...any) error) *MockDBConnectorGetContextCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // NamedExec mocks base method.
 func (m *MockDBConnector) NamedExec(query string, arg any) (sql.Result, error) {
 	m.ctrl.T.Helper()
@@ -204,6 +331,45 @@ func (c *MockDBConnectorNamedExecCall) DoAndReturn(f func(string, any) (sql.Resu
 	return c
 }
 
+// NamedExecContext mocks base method.
+func (m *MockDBConnector) NamedExecContext(ctx context.Context, query string, arg any) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NamedExecContext", ctx, query, arg)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NamedExecContext indicates an expected call of NamedExecContext.
+func (mr *MockDBConnectorMockRecorder) NamedExecContext(ctx, query, arg any) *MockDBConnectorNamedExecContextCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NamedExecContext", reflect.TypeOf((*MockDBConnector)(nil).NamedExecContext), ctx, query, arg)
+	return &MockDBConnectorNamedExecContextCall{Call: call}
+}
+
+// MockDBConnectorNamedExecContextCall wrap *gomock.Call
+type MockDBConnectorNamedExecContextCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockDBConnectorNamedExecContextCall) Return(arg0 sql.Result, arg1 error) *MockDBConnectorNamedExecContextCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockDBConnectorNamedExecContextCall) Do(f func(context.Context, string, any) (sql.Result, error)) *MockDBConnectorNamedExecContextCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockDBConnectorNamedExecContextCall) DoAndReturn(f func(context.Context, string, any) (sql.Result, error)) *MockDBConnectorNamedExecContextCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // NamedQuery mocks base method.
 func (m *MockDBConnector) NamedQuery(query string, arg any) (*sqlx.Rows, error) {
 	m.ctrl.T.Helper()
@@ -243,6 +409,45 @@ func (c *MockDBConnectorNamedQueryCall) DoAndReturn(f func(string, any) (*sqlx.R
 	return c
 }
 
+// NamedQueryContext mocks base method.
+func (m *MockDBConnector) NamedQueryContext(ctx context.Context, query string, arg any) (*sqlx.Rows, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NamedQueryContext", ctx, query, arg)
+	ret0, _ := ret[0].(*sqlx.Rows)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NamedQueryContext indicates an expected call of NamedQueryContext.
+func (mr *MockDBConnectorMockRecorder) NamedQueryContext(ctx, query, arg any) *MockDBConnectorNamedQueryContextCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NamedQueryContext", reflect.TypeOf((*MockDBConnector)(nil).NamedQueryContext), ctx, query, arg)
+	return &MockDBConnectorNamedQueryContextCall{Call: call}
+}
+
+// MockDBConnectorNamedQueryContextCall wrap *gomock.Call
+type MockDBConnectorNamedQueryContextCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockDBConnectorNamedQueryContextCall) Return(arg0 *sqlx.Rows, arg1 error) *MockDBConnectorNamedQueryContextCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockDBConnectorNamedQueryContextCall) Do(f func(context.Context, string, any) (*sqlx.Rows, error)) *MockDBConnectorNamedQueryContextCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockDBConnectorNamedQueryContextCall) DoAndReturn(f func(context.Context, string, any) (*sqlx.Rows, error)) *MockDBConnectorNamedQueryContextCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // Query mocks base method.
 func (m *MockDBConnector) Query(query string, args ...any) (*sql.Rows, error) {
 	m.ctrl.T.Helper()
@@ -287,6 +492,50 @@ func (c *MockDBConnectorQueryCall) DoAndReturn(f func(string, ...any) (*sql.Rows
 	return c
 }
 
+// QueryContext mocks base method.
+func (m *MockDBConnector) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, query}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "QueryContext", varargs...)
+	ret0, _ := ret[0].(*sql.Rows)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryContext indicates an expected call of QueryContext.
+func (mr *MockDBConnectorMockRecorder) QueryContext(ctx, query any, args ...any) *MockDBConnectorQueryContextCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, query}, args...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryContext", reflect.TypeOf((*MockDBConnector)(nil).QueryContext), varargs...)
+	return &MockDBConnectorQueryContextCall{Call: call}
+}
+
+// MockDBConnectorQueryContextCall wrap *gomock.Call
+type MockDBConnectorQueryContextCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockDBConnectorQueryContextCall) Return(arg0 *sql.Rows, arg1 error) *MockDBConnectorQueryContextCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockDBConnectorQueryContextCall) Do(f func(context.Context, string, ...any) (*sql.Rows, error)) *MockDBConnectorQueryContextCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockDBConnectorQueryContextCall) DoAndReturn(f func(context.Context, string, ...any) (*sql.Rows, error)) *MockDBConnectorQueryContextCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // QueryRow mocks base method.
 func (m *MockDBConnector) QueryRow(query string, args ...any) *sql.Row {
 	m.ctrl.T.Helper()
@@ -330,6 +579,49 @@ func (c *MockDBConnectorQueryRowCall) DoAndReturn(f func(string, ...any) *sql.Ro
 	return c
 }
 
+// QueryRowContext mocks base method.
+func (m *MockDBConnector) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, query}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "QueryRowContext", varargs...)
+	ret0, _ := ret[0].(*sql.Row)
+	return ret0
+}
+
+// QueryRowContext indicates an expected call of QueryRowContext.
+func (mr *MockDBConnectorMockRecorder) QueryRowContext(ctx, query any, args ...any) *MockDBConnectorQueryRowContextCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, query}, args...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryRowContext", reflect.TypeOf((*MockDBConnector)(nil).QueryRowContext), varargs...)
+	return &MockDBConnectorQueryRowContextCall{Call: call}
+}
+
+// MockDBConnectorQueryRowContextCall wrap *gomock.Call
+type MockDBConnectorQueryRowContextCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockDBConnectorQueryRowContextCall) Return(arg0 *sql.Row) *MockDBConnectorQueryRowContextCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockDBConnectorQueryRowContextCall) Do(f func(context.Context, string, ...any) *sql.Row) *MockDBConnectorQueryRowContextCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockDBConnectorQueryRowContextCall) DoAndReturn(f func(context.Context, string, ...any) *sql.Row) *MockDBConnectorQueryRowContextCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // QueryRowx mocks base method.
 func (m *MockDBConnector) QueryRowx(query string, args ...any) *sqlx.Row {
 	m.ctrl.T.Helper()
@@ -373,6 +665,49 @@ func (c *MockDBConnectorQueryRowxCall) DoAndReturn(f func(string, ...any) *sqlx.
 	return c
 }
 
+// QueryRowxContext mocks base method.
+func (m *MockDBConnector) QueryRowxContext(ctx context.Context, query string, args ...any) *sqlx.Row {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, query}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "QueryRowxContext", varargs...)
+	ret0, _ := ret[0].(*sqlx.Row)
+	return ret0
+}
+
+// QueryRowxContext indicates an expected call of QueryRowxContext.
+func (mr *MockDBConnectorMockRecorder) QueryRowxContext(ctx, query any, args ...any) *MockDBConnectorQueryRowxContextCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, query}, args...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryRowxContext", reflect.TypeOf((*MockDBConnector)(nil).QueryRowxContext), varargs...)
+	return &MockDBConnectorQueryRowxContextCall{Call: call}
+}
+
+// MockDBConnectorQueryRowxContextCall wrap *gomock.Call
+type MockDBConnectorQueryRowxContextCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockDBConnectorQueryRowxContextCall) Return(arg0 *sqlx.Row) *MockDBConnectorQueryRowxContextCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockDBConnectorQueryRowxContextCall) Do(f func(context.Context, string, ...any) *sqlx.Row) *MockDBConnectorQueryRowxContextCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockDBConnectorQueryRowxContextCall) DoAndReturn(f func(context.Context, string, ...any) *sqlx.Row) *MockDBConnectorQueryRowxContextCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // Queryx mocks base method.
 func (m *MockDBConnector) Queryx(query string, args ...any) (*sqlx.Rows, error) {
 	m.ctrl.T.Helper()
@@ -417,6 +752,88 @@ func (c *MockDBConnectorQueryxCall) DoAndReturn(f func(string, ...any) (*sqlx.Ro
 	return c
 }
 
+// QueryxContext mocks base method.
+func (m *MockDBConnector) QueryxContext(ctx context.Context, query string, args ...any) (*sqlx.Rows, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, query}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "QueryxContext", varargs...)
+	ret0, _ := ret[0].(*sqlx.Rows)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryxContext indicates an expected call of QueryxContext.
+func (mr *MockDBConnectorMockRecorder) QueryxContext(ctx, query any, args ...any) *MockDBConnectorQueryxContextCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, query}, args...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryxContext", reflect.TypeOf((*MockDBConnector)(nil).QueryxContext), varargs...)
+	return &MockDBConnectorQueryxContextCall{Call: call}
+}
+
+// MockDBConnectorQueryxContextCall wrap *gomock.Call
+type MockDBConnectorQueryxContextCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockDBConnectorQueryxContextCall) Return(arg0 *sqlx.Rows, arg1 error) *MockDBConnectorQueryxContextCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockDBConnectorQueryxContextCall) Do(f func(context.Context, string, ...any) (*sqlx.Rows, error)) *MockDBConnectorQueryxContextCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockDBConnectorQueryxContextCall) DoAndReturn(f func(context.Context, string, ...any) (*sqlx.Rows, error)) *MockDBConnectorQueryxContextCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Rebind mocks base method.
+func (m *MockDBConnector) Rebind(query string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rebind", query)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Rebind indicates an expected call of Rebind.
+func (mr *MockDBConnectorMockRecorder) Rebind(query any) *MockDBConnectorRebindCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rebind", reflect.TypeOf((*MockDBConnector)(nil).Rebind), query)
+	return &MockDBConnectorRebindCall{Call: call}
+}
+
+// MockDBConnectorRebindCall wrap *gomock.Call
+type MockDBConnectorRebindCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockDBConnectorRebindCall) Return(arg0 string) *MockDBConnectorRebindCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockDBConnectorRebindCall) Do(f func(string) string) *MockDBConnectorRebindCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockDBConnectorRebindCall) DoAndReturn(f func(string) string) *MockDBConnectorRebindCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // Select mocks base method.
 func (m *MockDBConnector) Select(dest any, query string, args ...any) error {
 	m.ctrl.T.Helper()
@@ -459,3 +876,46 @@ func (c *MockDBConnectorSelectCall) DoAndReturn(f func(any, string, ...any) erro
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
+
+// SelectContext mocks base method.
+func (m *MockDBConnector) SelectContext(ctx context.Context, dest any, query string, args ...any) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, dest, query}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SelectContext", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SelectContext indicates an expected call of SelectContext.
+func (mr *MockDBConnectorMockRecorder) SelectContext(ctx, dest, query any, args ...any) *MockDBConnectorSelectContextCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, dest, query}, args...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectContext", reflect.TypeOf((*MockDBConnector)(nil).SelectContext), varargs...)
+	return &MockDBConnectorSelectContextCall{Call: call}
+}
+
+// MockDBConnectorSelectContextCall wrap *gomock.Call
+type MockDBConnectorSelectContextCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockDBConnectorSelectContextCall) Return(arg0 error) *MockDBConnectorSelectContextCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockDBConnectorSelectContextCall) Do(f func(context.Context, any, string, ...any) error) *MockDBConnectorSelectContextCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockDBConnectorSelectContextCall) DoAndReturn(f func(context.Context, any, string, ...any) error) *MockDBConnectorSelectContextCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -0,0 +1,78 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/onsi/gomega"
+)
+
+func setupConn(t *testing.T, queryTimeout time.Duration) (*Conn, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return &Conn{db: sqlx.NewDb(db, "sqlmock"), queryTimeout: queryTimeout}, mock
+}
+
+func TestConn_QueryTimeout(t *testing.T) {
+	t.Parallel()
+
+	t.Run("cancels a query running past the timeout", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		conn, mock := setupConn(t, 10*time.Millisecond)
+
+		mock.ExpectExec("UPDATE vendor SET name = $1").
+			WithArgs("a").
+			WillDelayFor(time.Second).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		start := time.Now()
+		_, err := conn.ExecContext(context.Background(), "UPDATE vendor SET name = $1", "a")
+		g.Expect(err).ToNot(gomega.BeNil())
+		g.Expect(time.Since(start)).To(gomega.BeNumerically("<", time.Second))
+	})
+
+	t.Run("cancels a query along with its context", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		conn, mock := setupConn(t, 0)
+
+		mock.ExpectQuery("SELECT name FROM vendor").
+			WillDelayFor(time.Second).
+			WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("a"))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		start := time.Now()
+		var names []string
+		err := conn.SelectContext(ctx, &names, "SELECT name FROM vendor")
+		g.Expect(err).ToNot(gomega.BeNil())
+		g.Expect(time.Since(start)).To(gomega.BeNumerically("<", time.Second))
+	})
+
+	t.Run("keeps the rows readable once the query returns", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		conn, mock := setupConn(t, time.Second)
+
+		mock.ExpectQuery("SELECT name FROM vendor").
+			WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("a").AddRow("b"))
+
+		rows, err := conn.QueryxContext(context.Background(), "SELECT name FROM vendor")
+		g.Expect(err).To(gomega.BeNil())
+		defer rows.Close()
+
+		var names []string
+		for rows.Next() {
+			var name string
+			g.Expect(rows.Scan(&name)).To(gomega.Succeed())
+			names = append(names, name)
+		}
+		g.Expect(rows.Err()).To(gomega.BeNil())
+		g.Expect(names).To(gomega.Equal([]string{"a", "b"}))
+	})
+}
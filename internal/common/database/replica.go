@@ -0,0 +1,331 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"kg/procurement/cmd/utils"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// DefaultReplicaCheckInterval is how often replicas are pinged when no interval is set
+const DefaultReplicaCheckInterval = 5 * time.Second
+
+type routingKey struct{}
+
+// routing is how the queries of a request pick between the primary and the replicas
+type routing struct {
+	// stick sends the reads to the primary once the request wrote something
+	stick bool
+	wrote atomic.Bool
+	force bool
+}
+
+// WithPrimary sends every read made with ctx to the primary
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, routingKey{}, &routing{force: true})
+}
+
+// WithReadYourWrites sends the reads made with ctx to the primary once a write was made
+// with it, so a request reads back its own writes despite replication lag
+func WithReadYourWrites(ctx context.Context) context.Context {
+	return context.WithValue(ctx, routingKey{}, &routing{stick: true})
+}
+
+func routingOf(ctx context.Context) *routing {
+	if ctx == nil {
+		return nil
+	}
+	r, _ := ctx.Value(routingKey{}).(*routing)
+	return r
+}
+
+type pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+type replica struct {
+	db      DBConnector
+	healthy atomic.Bool
+}
+
+// RoutedConn is a DBConnector sending read only queries to healthy replicas in turn and
+// everything else, transactions included, to the primary. Replicas are pinged in the
+// background and left out while they fail, the primary serves the reads when none is
+// healthy or a replica drops the connection mid query
+type RoutedConn struct {
+	primary  DBConnector
+	replicas []*replica
+	next     atomic.Uint64
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewRoutedConn routes the reads of primary to replicas, checking them every interval
+func NewRoutedConn(primary DBConnector, replicas []DBConnector, interval time.Duration) *RoutedConn {
+	if interval <= 0 {
+		interval = DefaultReplicaCheckInterval
+	}
+	c := &RoutedConn{primary: primary, stop: make(chan struct{})}
+	for _, db := range replicas {
+		c.replicas = append(c.replicas, &replica{db: db})
+	}
+
+	c.checkReplicas(interval)
+	if len(c.replicas) > 0 {
+		go c.watchReplicas(interval)
+	}
+	return c
+}
+
+func (c *RoutedConn) watchReplicas(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-ticker.C:
+			c.checkReplicas(interval)
+		}
+	}
+}
+
+func (c *RoutedConn) checkReplicas(timeout time.Duration) {
+	for i, r := range c.replicas {
+		p, ok := r.db.(pinger)
+		if !ok {
+			r.healthy.Store(true)
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		err := p.PingContext(ctx)
+		cancel()
+		if err != nil && r.healthy.Load() {
+			utils.Logger.Errorf("replica %d is unhealthy: %v", i, err)
+		}
+		r.healthy.Store(err == nil)
+	}
+}
+
+// HealthyReplicas is the number of replicas currently serving reads
+func (c *RoutedConn) HealthyReplicas() int {
+	n := 0
+	for _, r := range c.replicas {
+		if r.healthy.Load() {
+			n++
+		}
+	}
+	return n
+}
+
+// reader picks the connection a query is read from, nil stands for the primary
+func (c *RoutedConn) reader(ctx context.Context, query string) *replica {
+	if len(c.replicas) == 0 || !readOnlyQuery(query) {
+		return nil
+	}
+	if r := routingOf(ctx); r != nil && (r.force || r.wrote.Load()) {
+		return nil
+	}
+	start := c.next.Add(1)
+	for i := range c.replicas {
+		r := c.replicas[(start+uint64(i))%uint64(len(c.replicas))]
+		if r.healthy.Load() {
+			return r
+		}
+	}
+	return nil
+}
+
+// wrote records a write made with ctx so the reads that follow stick to the primary
+func (c *RoutedConn) wrote(ctx context.Context) {
+	if r := routingOf(ctx); r != nil && r.stick {
+		r.wrote.Store(true)
+	}
+}
+
+// fellBack tells whether a read failed because the replica dropped the connection, the
+// replica is left out until it answers its pings again
+func (c *RoutedConn) fellBack(r *replica, err error) bool {
+	if err == nil || !connectionError(err) {
+		return false
+	}
+	utils.Logger.Errorf("replica read failed, falling back to primary: %v", err)
+	r.healthy.Store(false)
+	return true
+}
+
+func connectionError(err error) bool {
+	var netErr net.Error
+	return errors.Is(err, driver.ErrBadConn) || errors.As(err, &netErr)
+}
+
+var writeKeywords = regexp.MustCompile(`(?i)\b(INSERT|UPDATE|DELETE|MERGE|NEXTVAL|SETVAL)\b|\bFOR\s+(NO\s+KEY\s+)?(UPDATE|SHARE|KEY\s+SHARE)\b`)
+
+// readOnlyQuery tells whether a query only reads, queries written through the read
+// methods such as an UPDATE ... RETURNING are kept on the primary
+func readOnlyQuery(query string) bool {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return false
+	}
+	switch strings.ToUpper(fields[0]) {
+	case "SELECT", "WITH":
+		return !writeKeywords.MatchString(query)
+	default:
+		return false
+	}
+}
+
+func (c *RoutedConn) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return c.primary.Exec(query, args...)
+}
+
+func (c *RoutedConn) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return c.QueryContext(context.Background(), query, args...)
+}
+
+func (c *RoutedConn) QueryRow(query string, args ...interface{}) *sql.Row {
+	return c.QueryRowContext(context.Background(), query, args...)
+}
+
+func (c *RoutedConn) Queryx(query string, args ...interface{}) (*sqlx.Rows, error) {
+	return c.QueryxContext(context.Background(), query, args...)
+}
+
+func (c *RoutedConn) QueryRowx(query string, args ...interface{}) *sqlx.Row {
+	return c.QueryRowxContext(context.Background(), query, args...)
+}
+
+func (c *RoutedConn) NamedQuery(query string, arg interface{}) (*sqlx.Rows, error) {
+	return c.primary.NamedQuery(query, arg)
+}
+
+func (c *RoutedConn) NamedExec(query string, arg interface{}) (sql.Result, error) {
+	return c.primary.NamedExec(query, arg)
+}
+
+func (c *RoutedConn) Select(dest interface{}, query string, args ...interface{}) error {
+	return c.SelectContext(context.Background(), dest, query, args...)
+}
+
+func (c *RoutedConn) Get(dest interface{}, query string, args ...interface{}) error {
+	return c.GetContext(context.Background(), dest, query, args...)
+}
+
+func (c *RoutedConn) Rebind(query string) string {
+	return c.primary.Rebind(query)
+}
+
+// Close stops the replica checks and closes every connection
+func (c *RoutedConn) Close() error {
+	c.stopOnce.Do(func() { close(c.stop) })
+	errs := []error{c.primary.Close()}
+	for _, r := range c.replicas {
+		errs = append(errs, r.db.Close())
+	}
+	return errors.Join(errs...)
+}
+
+func (c *RoutedConn) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	c.wrote(ctx)
+	return c.primary.ExecContext(ctx, query, args...)
+}
+
+func (c *RoutedConn) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	r := c.reader(ctx, query)
+	if r == nil {
+		c.wrote(ctx)
+		return c.primary.QueryContext(ctx, query, args...)
+	}
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if c.fellBack(r, err) {
+		return c.primary.QueryContext(ctx, query, args...)
+	}
+	return rows, err
+}
+
+// QueryRowContext reports its error on Scan, a replica dropping the connection is only
+// left out on its next failed ping
+func (c *RoutedConn) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	r := c.reader(ctx, query)
+	if r == nil {
+		c.wrote(ctx)
+		return c.primary.QueryRowContext(ctx, query, args...)
+	}
+	return r.db.QueryRowContext(ctx, query, args...)
+}
+
+func (c *RoutedConn) QueryxContext(ctx context.Context, query string, args ...interface{}) (*sqlx.Rows, error) {
+	r := c.reader(ctx, query)
+	if r == nil {
+		c.wrote(ctx)
+		return c.primary.QueryxContext(ctx, query, args...)
+	}
+	rows, err := r.db.QueryxContext(ctx, query, args...)
+	if c.fellBack(r, err) {
+		return c.primary.QueryxContext(ctx, query, args...)
+	}
+	return rows, err
+}
+
+// QueryRowxContext reports its error on Scan, see QueryRowContext
+func (c *RoutedConn) QueryRowxContext(ctx context.Context, query string, args ...interface{}) *sqlx.Row {
+	r := c.reader(ctx, query)
+	if r == nil {
+		c.wrote(ctx)
+		return c.primary.QueryRowxContext(ctx, query, args...)
+	}
+	return r.db.QueryRowxContext(ctx, query, args...)
+}
+
+func (c *RoutedConn) NamedQueryContext(ctx context.Context, query string, arg interface{}) (*sqlx.Rows, error) {
+	c.wrote(ctx)
+	return c.primary.NamedQueryContext(ctx, query, arg)
+}
+
+func (c *RoutedConn) NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error) {
+	c.wrote(ctx)
+	return c.primary.NamedExecContext(ctx, query, arg)
+}
+
+func (c *RoutedConn) SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	r := c.reader(ctx, query)
+	if r == nil {
+		c.wrote(ctx)
+		return c.primary.SelectContext(ctx, dest, query, args...)
+	}
+	err := r.db.SelectContext(ctx, dest, query, args...)
+	if c.fellBack(r, err) {
+		return c.primary.SelectContext(ctx, dest, query, args...)
+	}
+	return err
+}
+
+func (c *RoutedConn) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	r := c.reader(ctx, query)
+	if r == nil {
+		c.wrote(ctx)
+		return c.primary.GetContext(ctx, dest, query, args...)
+	}
+	err := r.db.GetContext(ctx, dest, query, args...)
+	if c.fellBack(r, err) {
+		return c.primary.GetContext(ctx, dest, query, args...)
+	}
+	return err
+}
+
+// BeginTxx starts the transaction on the primary, reads within it stay there
+func (c *RoutedConn) BeginTxx(ctx context.Context, opts *sql.TxOptions) (*sqlx.Tx, error) {
+	c.wrote(ctx)
+	return c.primary.BeginTxx(ctx, opts)
+}
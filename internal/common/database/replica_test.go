@@ -0,0 +1,220 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/onsi/gomega"
+)
+
+// setupReplica is a replica answering its first ping when healthy
+func setupReplica(t *testing.T, healthy bool) (*Conn, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New(
+		sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual),
+		sqlmock.MonitorPingsOption(true),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if healthy {
+		mock.ExpectPing()
+	} else {
+		mock.ExpectPing().WillReturnError(errors.New("connection refused"))
+	}
+	return &Conn{db: sqlx.NewDb(db, "sqlmock")}, mock
+}
+
+func TestRoutedConn(t *testing.T) {
+	t.Parallel()
+
+	const (
+		selectQuery = "SELECT name FROM vendor"
+		insertQuery = "INSERT INTO vendor (name) VALUES ($1)"
+	)
+	selectName := func(ctx context.Context, db DBConnector) error {
+		var names []string
+		return db.SelectContext(ctx, &names, selectQuery)
+	}
+	nameRows := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"name"}).AddRow("a")
+	}
+
+	t.Run("reads from the replicas in turn and writes to the primary", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		primary, primaryMock := setupConn(t, 0)
+		first, firstMock := setupReplica(t, true)
+		second, secondMock := setupReplica(t, true)
+		conn := NewRoutedConn(primary, []DBConnector{first, second}, time.Hour)
+		defer conn.Close()
+		ctx := context.Background()
+
+		firstMock.ExpectQuery(selectQuery).WillReturnRows(nameRows())
+		secondMock.ExpectQuery(selectQuery).WillReturnRows(nameRows())
+		primaryMock.ExpectExec(insertQuery).WithArgs("a").WillReturnResult(sqlmock.NewResult(0, 1))
+
+		g.Expect(conn.HealthyReplicas()).To(gomega.Equal(2))
+		g.Expect(selectName(ctx, conn)).To(gomega.Succeed())
+		g.Expect(selectName(ctx, conn)).To(gomega.Succeed())
+		_, err := conn.ExecContext(ctx, insertQuery, "a")
+		g.Expect(err).To(gomega.BeNil())
+
+		g.Expect(primaryMock.ExpectationsWereMet()).To(gomega.BeNil())
+		g.Expect(firstMock.ExpectationsWereMet()).To(gomega.BeNil())
+		g.Expect(secondMock.ExpectationsWereMet()).To(gomega.BeNil())
+	})
+
+	t.Run("skips unhealthy replicas", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		primary, primaryMock := setupConn(t, 0)
+		down, downMock := setupReplica(t, false)
+		up, upMock := setupReplica(t, true)
+		conn := NewRoutedConn(primary, []DBConnector{down, up}, time.Hour)
+		defer conn.Close()
+
+		upMock.ExpectQuery(selectQuery).WillReturnRows(nameRows())
+		upMock.ExpectQuery(selectQuery).WillReturnRows(nameRows())
+
+		g.Expect(conn.HealthyReplicas()).To(gomega.Equal(1))
+		g.Expect(selectName(context.Background(), conn)).To(gomega.Succeed())
+		g.Expect(selectName(context.Background(), conn)).To(gomega.Succeed())
+
+		g.Expect(primaryMock.ExpectationsWereMet()).To(gomega.BeNil())
+		g.Expect(downMock.ExpectationsWereMet()).To(gomega.BeNil())
+		g.Expect(upMock.ExpectationsWereMet()).To(gomega.BeNil())
+	})
+
+	t.Run("reads from the primary when no replica is healthy", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		primary, primaryMock := setupConn(t, 0)
+		down, _ := setupReplica(t, false)
+		conn := NewRoutedConn(primary, []DBConnector{down}, time.Hour)
+		defer conn.Close()
+
+		primaryMock.ExpectQuery(selectQuery).WillReturnRows(nameRows())
+
+		g.Expect(selectName(context.Background(), conn)).To(gomega.Succeed())
+		g.Expect(primaryMock.ExpectationsWereMet()).To(gomega.BeNil())
+	})
+
+	t.Run("falls back to the primary when a replica drops the connection", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		primary, primaryMock := setupConn(t, 0)
+		replica, replicaMock := setupReplica(t, true)
+		conn := NewRoutedConn(primary, []DBConnector{replica}, time.Hour)
+		defer conn.Close()
+
+		replicaMock.ExpectQuery(selectQuery).WillReturnError(&net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset by peer")})
+		primaryMock.ExpectQuery(selectQuery).WillReturnRows(nameRows())
+
+		g.Expect(selectName(context.Background(), conn)).To(gomega.Succeed())
+		g.Expect(conn.HealthyReplicas()).To(gomega.Equal(0))
+		g.Expect(primaryMock.ExpectationsWereMet()).To(gomega.BeNil())
+	})
+
+	t.Run("returns query errors of a replica", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		primary, _ := setupConn(t, 0)
+		replica, replicaMock := setupReplica(t, true)
+		conn := NewRoutedConn(primary, []DBConnector{replica}, time.Hour)
+		defer conn.Close()
+
+		replicaMock.ExpectQuery(selectQuery).WillReturnError(errors.New("syntax error"))
+
+		g.Expect(selectName(context.Background(), conn)).To(gomega.MatchError("syntax error"))
+		g.Expect(conn.HealthyReplicas()).To(gomega.Equal(1))
+	})
+
+	t.Run("reads from the primary after a write of the same request", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		primary, primaryMock := setupConn(t, 0)
+		replica, replicaMock := setupReplica(t, true)
+		conn := NewRoutedConn(primary, []DBConnector{replica}, time.Hour)
+		defer conn.Close()
+		ctx := WithReadYourWrites(context.Background())
+
+		replicaMock.ExpectQuery(selectQuery).WillReturnRows(nameRows())
+		primaryMock.ExpectExec(insertQuery).WithArgs("a").WillReturnResult(sqlmock.NewResult(0, 1))
+		primaryMock.ExpectQuery(selectQuery).WillReturnRows(nameRows())
+		replicaMock.ExpectQuery(selectQuery).WillReturnRows(nameRows())
+
+		g.Expect(selectName(ctx, conn)).To(gomega.Succeed())
+		_, err := conn.ExecContext(ctx, insertQuery, "a")
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(selectName(ctx, conn)).To(gomega.Succeed())
+		// other requests keep reading from the replica
+		g.Expect(selectName(context.Background(), conn)).To(gomega.Succeed())
+
+		g.Expect(primaryMock.ExpectationsWereMet()).To(gomega.BeNil())
+		g.Expect(replicaMock.ExpectationsWereMet()).To(gomega.BeNil())
+	})
+
+	t.Run("reads from the primary after a transaction of the same request", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		primary, primaryMock := setupConn(t, 0)
+		replica, _ := setupReplica(t, true)
+		conn := NewRoutedConn(primary, []DBConnector{replica}, time.Hour)
+		defer conn.Close()
+		ctx := WithReadYourWrites(context.Background())
+
+		primaryMock.ExpectBegin()
+		primaryMock.ExpectQuery(selectQuery).WillReturnRows(nameRows())
+		primaryMock.ExpectCommit()
+		primaryMock.ExpectQuery(selectQuery).WillReturnRows(nameRows())
+
+		err := RunInTx(ctx, conn, func(tx DBConnector) error {
+			return selectName(ctx, tx)
+		})
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(selectName(ctx, conn)).To(gomega.Succeed())
+		g.Expect(primaryMock.ExpectationsWereMet()).To(gomega.BeNil())
+	})
+
+	t.Run("reads from the primary when asked to", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		primary, primaryMock := setupConn(t, 0)
+		replica, _ := setupReplica(t, true)
+		conn := NewRoutedConn(primary, []DBConnector{replica}, time.Hour)
+		defer conn.Close()
+
+		primaryMock.ExpectQuery(selectQuery).WillReturnRows(nameRows())
+
+		g.Expect(selectName(WithPrimary(context.Background()), conn)).To(gomega.Succeed())
+		g.Expect(primaryMock.ExpectationsWereMet()).To(gomega.BeNil())
+	})
+
+	t.Run("rechecks the replicas", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		primary, _ := setupConn(t, 0)
+		replica, replicaMock := setupReplica(t, false)
+		replicaMock.ExpectPing()
+		conn := NewRoutedConn(primary, []DBConnector{replica}, time.Hour)
+		defer conn.Close()
+		g.Expect(conn.HealthyReplicas()).To(gomega.Equal(0))
+
+		conn.checkReplicas(time.Second)
+		g.Expect(conn.HealthyReplicas()).To(gomega.Equal(1))
+	})
+}
+
+func Test_readOnlyQuery(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	g.Expect(readOnlyQuery("SELECT id FROM vendor WHERE id = $1")).To(gomega.BeTrue())
+	g.Expect(readOnlyQuery("\n\t  select count(*) FROM price")).To(gomega.BeTrue())
+	g.Expect(readOnlyQuery("WITH latest AS (SELECT * FROM price) SELECT * FROM latest")).To(gomega.BeTrue())
+	g.Expect(readOnlyQuery("SELECT updated_at FROM product")).To(gomega.BeTrue())
+
+	g.Expect(readOnlyQuery("")).To(gomega.BeFalse())
+	g.Expect(readOnlyQuery("INSERT INTO vendor (name) VALUES ($1) RETURNING id")).To(gomega.BeFalse())
+	g.Expect(readOnlyQuery("UPDATE vendor SET name = $1 RETURNING *")).To(gomega.BeFalse())
+	g.Expect(readOnlyQuery("SELECT * FROM price WHERE id = $1 FOR UPDATE")).To(gomega.BeFalse())
+	g.Expect(readOnlyQuery("WITH moved AS (DELETE FROM price RETURNING *) SELECT * FROM moved")).To(gomega.BeFalse())
+	g.Expect(readOnlyQuery("SELECT nextval('vendor_seq')")).To(gomega.BeFalse())
+}
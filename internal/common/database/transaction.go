@@ -0,0 +1,180 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// ErrTxInProgress is returned when a transaction is begun on a transaction, nested
+// units of work go through RunInTx which uses a savepoint instead
+var ErrTxInProgress = errors.New("transaction already in progress")
+
+// RunInTx runs fn as a unit of work on db. fn gets a transactional DBConnector to build
+// its accessors on, the transaction is committed when fn succeeds and rolled back when it
+// fails or panics. When db is already a transaction fn runs under a savepoint, so a
+// failing fn only undoes its own writes and the outer unit of work decides about the rest
+func RunInTx(ctx context.Context, db DBConnector, fn func(tx DBConnector) error) error {
+	if tx, ok := db.(*Tx); ok {
+		return tx.runInSavepoint(ctx, fn)
+	}
+	// transactions only run on the primary
+	if routed, ok := db.(*RoutedConn); ok {
+		routed.wrote(ctx)
+		db = routed.primary
+	}
+
+	sqlTx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	tx := &Tx{tx: sqlTx}
+	if conn, ok := db.(*Conn); ok {
+		tx.queryTimeout = conn.queryTimeout
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			_ = sqlTx.Rollback()
+			panic(r)
+		}
+	}()
+
+	if err := fn(tx); err != nil {
+		if rollbackErr := sqlTx.Rollback(); rollbackErr != nil {
+			return errors.Join(err, rollbackErr)
+		}
+		return err
+	}
+	return sqlTx.Commit()
+}
+
+// Tx is a DBConnector bound to a transaction started by RunInTx, which also commits or
+// rolls it back
+type Tx struct {
+	tx           *sqlx.Tx
+	queryTimeout time.Duration
+	// savepoints numbers the savepoints of nested units of work
+	savepoints int
+}
+
+func (t *Tx) runInSavepoint(ctx context.Context, fn func(tx DBConnector) error) error {
+	t.savepoints++
+	savepoint := fmt.Sprintf("sp_%d", t.savepoints)
+	if _, err := t.tx.ExecContext(ctx, "SAVEPOINT "+savepoint); err != nil {
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			_, _ = t.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+savepoint)
+			panic(r)
+		}
+	}()
+
+	if err := fn(t); err != nil {
+		if _, rollbackErr := t.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+savepoint); rollbackErr != nil {
+			return errors.Join(err, rollbackErr)
+		}
+		return err
+	}
+	_, err := t.tx.ExecContext(ctx, "RELEASE SAVEPOINT "+savepoint)
+	return err
+}
+
+func (t *Tx) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return t.tx.Exec(query, args...)
+}
+
+func (t *Tx) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return t.tx.Query(query, args...)
+}
+
+func (t *Tx) QueryRow(query string, args ...interface{}) *sql.Row {
+	return t.tx.QueryRow(query, args...)
+}
+
+func (t *Tx) Queryx(query string, args ...interface{}) (*sqlx.Rows, error) {
+	return t.tx.Queryx(query, args...)
+}
+
+func (t *Tx) QueryRowx(query string, args ...interface{}) *sqlx.Row {
+	return t.tx.QueryRowx(query, args...)
+}
+
+func (t *Tx) NamedQuery(query string, arg interface{}) (*sqlx.Rows, error) {
+	return t.tx.NamedQuery(query, arg)
+}
+
+func (t *Tx) NamedExec(query string, arg interface{}) (sql.Result, error) {
+	return t.tx.NamedExec(query, arg)
+}
+
+func (t *Tx) Select(dest interface{}, query string, args ...interface{}) error {
+	return t.tx.Select(dest, query, args...)
+}
+
+func (t *Tx) Get(dest interface{}, query string, args ...interface{}) error {
+	return t.tx.Get(dest, query, args...)
+}
+
+func (t *Tx) Rebind(query string) string {
+	return t.tx.Rebind(query)
+}
+
+// Close leaves the transaction alone, RunInTx ends it
+func (t *Tx) Close() error {
+	return nil
+}
+
+func (t *Tx) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	ctx, cancel := withTimeout(ctx, t.queryTimeout)
+	defer cancel()
+	return t.tx.ExecContext(ctx, query, args...)
+}
+
+func (t *Tx) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return t.tx.QueryContext(rowsTimeout(ctx, t.queryTimeout), query, args...)
+}
+
+func (t *Tx) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return t.tx.QueryRowContext(rowsTimeout(ctx, t.queryTimeout), query, args...)
+}
+
+func (t *Tx) QueryxContext(ctx context.Context, query string, args ...interface{}) (*sqlx.Rows, error) {
+	return t.tx.QueryxContext(rowsTimeout(ctx, t.queryTimeout), query, args...)
+}
+
+func (t *Tx) QueryRowxContext(ctx context.Context, query string, args ...interface{}) *sqlx.Row {
+	return t.tx.QueryRowxContext(rowsTimeout(ctx, t.queryTimeout), query, args...)
+}
+
+func (t *Tx) NamedQueryContext(ctx context.Context, query string, arg interface{}) (*sqlx.Rows, error) {
+	return sqlx.NamedQueryContext(rowsTimeout(ctx, t.queryTimeout), t.tx, query, arg)
+}
+
+func (t *Tx) NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error) {
+	ctx, cancel := withTimeout(ctx, t.queryTimeout)
+	defer cancel()
+	return t.tx.NamedExecContext(ctx, query, arg)
+}
+
+func (t *Tx) SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	ctx, cancel := withTimeout(ctx, t.queryTimeout)
+	defer cancel()
+	return t.tx.SelectContext(ctx, dest, query, args...)
+}
+
+func (t *Tx) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	ctx, cancel := withTimeout(ctx, t.queryTimeout)
+	defer cancel()
+	return t.tx.GetContext(ctx, dest, query, args...)
+}
+
+func (t *Tx) BeginTxx(_ context.Context, _ *sql.TxOptions) (*sqlx.Tx, error) {
+	return nil, ErrTxInProgress
+}
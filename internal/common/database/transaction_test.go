@@ -0,0 +1,142 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/onsi/gomega"
+)
+
+func TestRunInTx(t *testing.T) {
+	t.Parallel()
+
+	insert := func(ctx context.Context, db DBConnector, name string) error {
+		_, err := db.ExecContext(ctx, "INSERT INTO vendor (name) VALUES ($1)", name)
+		return err
+	}
+
+	t.Run("commits when the unit of work succeeds", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		conn, mock := setupConn(t, 0)
+		ctx := context.Background()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO vendor (name) VALUES ($1)").WithArgs("a").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO vendor (name) VALUES ($1)").WithArgs("b").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := RunInTx(ctx, conn, func(tx DBConnector) error {
+			if err := insert(ctx, tx, "a"); err != nil {
+				return err
+			}
+			return insert(ctx, tx, "b")
+		})
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(mock.ExpectationsWereMet()).To(gomega.BeNil())
+	})
+
+	t.Run("rolls back when the unit of work fails", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		conn, mock := setupConn(t, 0)
+		ctx := context.Background()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO vendor (name) VALUES ($1)").WithArgs("a").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO vendor (name) VALUES ($1)").WithArgs("b").WillReturnError(errors.New("error"))
+		mock.ExpectRollback()
+
+		err := RunInTx(ctx, conn, func(tx DBConnector) error {
+			if err := insert(ctx, tx, "a"); err != nil {
+				return err
+			}
+			return insert(ctx, tx, "b")
+		})
+		g.Expect(err).To(gomega.MatchError("error"))
+		g.Expect(mock.ExpectationsWereMet()).To(gomega.BeNil())
+	})
+
+	t.Run("rolls back and panics again when the unit of work panics", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		conn, mock := setupConn(t, 0)
+
+		mock.ExpectBegin()
+		mock.ExpectRollback()
+
+		g.Expect(func() {
+			_ = RunInTx(context.Background(), conn, func(tx DBConnector) error {
+				panic("boom")
+			})
+		}).To(gomega.PanicWith("boom"))
+		g.Expect(mock.ExpectationsWereMet()).To(gomega.BeNil())
+	})
+
+	t.Run("releases the savepoint of a nested unit of work", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		conn, mock := setupConn(t, 0)
+		ctx := context.Background()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("INSERT INTO vendor (name) VALUES ($1)").WithArgs("a").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("RELEASE SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		err := RunInTx(ctx, conn, func(tx DBConnector) error {
+			return RunInTx(ctx, tx, func(nested DBConnector) error {
+				return insert(ctx, nested, "a")
+			})
+		})
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(mock.ExpectationsWereMet()).To(gomega.BeNil())
+	})
+
+	t.Run("undoes only the writes of a failing nested unit of work", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		conn, mock := setupConn(t, 0)
+		ctx := context.Background()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO vendor (name) VALUES ($1)").WithArgs("a").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("INSERT INTO vendor (name) VALUES ($1)").WithArgs("b").WillReturnError(errors.New("error"))
+		mock.ExpectExec("ROLLBACK TO SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		err := RunInTx(ctx, conn, func(tx DBConnector) error {
+			if err := insert(ctx, tx, "a"); err != nil {
+				return err
+			}
+			nestedErr := RunInTx(ctx, tx, func(nested DBConnector) error {
+				return insert(ctx, nested, "b")
+			})
+			g.Expect(nestedErr).To(gomega.MatchError("error"))
+			return nil
+		})
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(mock.ExpectationsWereMet()).To(gomega.BeNil())
+	})
+
+	t.Run("error on begin", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		conn, mock := setupConn(t, 0)
+
+		mock.ExpectBegin().WillReturnError(errors.New("error"))
+
+		called := false
+		err := RunInTx(context.Background(), conn, func(tx DBConnector) error {
+			called = true
+			return nil
+		})
+		g.Expect(err).ToNot(gomega.BeNil())
+		g.Expect(called).To(gomega.BeFalse())
+	})
+
+	t.Run("a transaction can't be begun twice", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		_, err := (&Tx{}).BeginTxx(context.Background(), nil)
+		g.Expect(errors.Is(err, ErrTxInProgress)).To(gomega.BeTrue())
+	})
+}
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"kg/procurement/internal/audit"
+	"kg/procurement/internal/common/helper"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestIDHeader carries the id a request is traced by, a caller may set its own
+const RequestIDHeader = "X-Request-ID"
+
+// RequestID ties the changes a request makes to its id on the audit log, the id is
+// generated unless the caller sent one and is echoed back on the response
+func RequestID() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		requestID := ctx.GetHeader(RequestIDHeader)
+		if requestID == "" {
+			var err error
+			if requestID, err = helper.GenerateRandomID(); err != nil {
+				ctx.Next()
+				return
+			}
+		}
+
+		ctx.Header(RequestIDHeader, requestID)
+		ctx.Request = ctx.Request.WithContext(audit.WithRequestID(ctx.Request.Context(), requestID))
+		ctx.Next()
+	}
+}
@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/onsi/gomega"
+)
+
+func TestRequestID(t *testing.T) {
+	t.Parallel()
+
+	handle := func(header string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("GET", "/", nil)
+		if header != "" {
+			req.Header.Set(RequestIDHeader, header)
+		}
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		RequestID()(c)
+		return w
+	}
+
+	t.Run("keeps the id of the caller", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		g.Expect(handle("R1").Header().Get(RequestIDHeader)).To(gomega.Equal("R1"))
+	})
+
+	t.Run("generates an id", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		g.Expect(handle("").Header().Get(RequestIDHeader)).ToNot(gomega.BeEmpty())
+	})
+}
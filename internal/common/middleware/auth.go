@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"github.com/gin-gonic/gin"
+	"kg/procurement/internal/audit"
 	"kg/procurement/internal/token"
 	"net/http"
 	"strings"
@@ -13,6 +14,9 @@ const (
 	ErrorAuthHeader     = "authorization header not provided"
 	ErrorAuthInvalid    = "authorization header not valid"
 	ErrorAuthType       = "authorization type not valid"
+
+	// AuthPayloadKey holds the token.ClaimSpec of an authenticated request
+	AuthPayloadKey = "auth_payload"
 )
 
 type AuthMiddleware struct {
@@ -64,9 +68,11 @@ func (m *AuthMiddleware) MustAuthenticated() gin.HandlerFunc {
 			return
 		}
 
-		ctx.Set("auth_payload", token.ClaimSpec{
+		ctx.Set(AuthPayloadKey, token.ClaimSpec{
 			UserID: claims.Subject,
 		})
+		// changes made by the request are attributed to the user on the audit log
+		ctx.Request = ctx.Request.WithContext(audit.WithActor(ctx.Request.Context(), claims.Subject))
 
 		ctx.Next()
 	}
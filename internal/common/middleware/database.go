@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"kg/procurement/internal/common/database"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReadYourWrites sends the reads of a request to the primary database once it wrote
+// something, so a handler reading back what it just saved isn't served a lagging replica
+func ReadYourWrites() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Request = ctx.Request.WithContext(database.WithReadYourWrites(ctx.Request.Context()))
+		ctx.Next()
+	}
+}
+
+// ReadFromPrimary sends every read of a request to the primary database, for routes
+// whose callers can't tolerate replication lag
+func ReadFromPrimary() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Request = ctx.Request.WithContext(database.WithPrimary(ctx.Request.Context()))
+		ctx.Next()
+	}
+}
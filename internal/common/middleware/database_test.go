@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/onsi/gomega"
+)
+
+func TestDatabaseRouting(t *testing.T) {
+	t.Parallel()
+
+	handle := func(handler gin.HandlerFunc) context.Context {
+		req, _ := http.NewRequest("GET", "/", nil)
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = req
+		handler(c)
+		return c.Request.Context()
+	}
+
+	t.Run("ReadYourWrites", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		g.Expect(handle(ReadYourWrites())).ToNot(gomega.Equal(context.Background()))
+	})
+
+	t.Run("ReadFromPrimary", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		g.Expect(handle(ReadFromPrimary())).ToNot(gomega.Equal(context.Background()))
+	})
+}
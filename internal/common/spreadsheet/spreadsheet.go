@@ -0,0 +1,115 @@
+package spreadsheet
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported file format")
+	ErrMissingHeader     = errors.New("file has no header row")
+)
+
+// Row is a data row keyed by its header column, Line is the row number in the
+// file where the header is line 1 so errors can point the user to the right row
+type Row struct {
+	Line   int
+	Values map[string]string
+}
+
+// FormatFromFilename infers the format from the file extension
+func FormatFromFilename(filename string) (string, error) {
+	switch strings.ToLower(strings.TrimPrefix(filepath.Ext(filename), ".")) {
+	case FormatCSV:
+		return FormatCSV, nil
+	case FormatXLSX:
+		return FormatXLSX, nil
+	}
+	return "", ErrUnsupportedFormat
+}
+
+// Read returns the normalized header and every non blank row of the file,
+// only the first sheet of an XLSX workbook is read
+func Read(file io.Reader, format string) ([]string, []Row, error) {
+	var (
+		records [][]string
+		err     error
+	)
+	switch format {
+	case FormatCSV:
+		records, err = readCSV(file)
+	case FormatXLSX:
+		records, err = readXLSX(file)
+	default:
+		return nil, nil, ErrUnsupportedFormat
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil, ErrMissingHeader
+	}
+
+	header := make([]string, len(records[0]))
+	for i, column := range records[0] {
+		header[i] = strings.ToLower(strings.TrimSpace(column))
+	}
+
+	var rows []Row
+	for i, record := range records[1:] {
+		if isBlank(record) {
+			continue
+		}
+
+		values := make(map[string]string, len(header))
+		for j, column := range header {
+			if column == "" || j >= len(record) {
+				continue
+			}
+			values[column] = strings.TrimSpace(record[j])
+		}
+		rows = append(rows, Row{Line: i + 2, Values: values})
+	}
+
+	return header, rows, nil
+}
+
+func readCSV(file io.Reader) ([][]string, error) {
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	return reader.ReadAll()
+}
+
+func readXLSX(file io.Reader) ([][]string, error) {
+	workbook, err := excelize.OpenReader(file)
+	if err != nil {
+		return nil, err
+	}
+	defer workbook.Close()
+
+	sheets := workbook.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, nil
+	}
+	return workbook.GetRows(sheets[0])
+}
+
+func isBlank(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,79 @@
+package spreadsheet
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/onsi/gomega"
+	"github.com/xuri/excelize/v2"
+)
+
+func Test_FormatFromFilename(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	format, err := FormatFromFilename("prices.CSV")
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(format).To(gomega.Equal(FormatCSV))
+
+	format, err = FormatFromFilename("prices.xlsx")
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(format).To(gomega.Equal(FormatXLSX))
+
+	_, err = FormatFromFilename("prices.xls")
+	g.Expect(err).To(gomega.MatchError(ErrUnsupportedFormat))
+}
+
+func Test_Read(t *testing.T) {
+	t.Parallel()
+
+	expectedHeader := []string{"product_vendor_id", "price"}
+	expectedRows := []Row{
+		{Line: 2, Values: map[string]string{"product_vendor_id": "PV1", "price": "100"}},
+		{Line: 4, Values: map[string]string{"product_vendor_id": "PV2"}},
+	}
+
+	t.Run("csv", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		file := strings.NewReader(" Product_Vendor_ID ,price\nPV1, 100\n,\nPV2\n")
+		header, rows, err := Read(file, FormatCSV)
+
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(header).To(gomega.Equal(expectedHeader))
+		g.Expect(rows).To(gomega.Equal(expectedRows))
+	})
+
+	t.Run("xlsx", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		workbook := excelize.NewFile()
+		sheet := workbook.GetSheetName(0)
+		g.Expect(workbook.SetSheetRow(sheet, "A1", &[]interface{}{"Product_Vendor_ID", "price"})).To(gomega.Succeed())
+		g.Expect(workbook.SetSheetRow(sheet, "A2", &[]interface{}{"PV1", 100})).To(gomega.Succeed())
+		g.Expect(workbook.SetSheetRow(sheet, "A4", &[]interface{}{"PV2"})).To(gomega.Succeed())
+
+		var buffer bytes.Buffer
+		g.Expect(workbook.Write(&buffer)).To(gomega.Succeed())
+
+		header, rows, err := Read(&buffer, FormatXLSX)
+
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(header).To(gomega.Equal(expectedHeader))
+		g.Expect(rows).To(gomega.Equal(expectedRows))
+	})
+
+	t.Run("error on empty file", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		_, _, err := Read(strings.NewReader(""), FormatCSV)
+		g.Expect(err).To(gomega.MatchError(ErrMissingHeader))
+	})
+
+	t.Run("error on unsupported format", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		_, _, err := Read(strings.NewReader("a,b"), "xls")
+		g.Expect(err).To(gomega.MatchError(ErrUnsupportedFormat))
+	})
+}
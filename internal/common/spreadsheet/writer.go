@@ -0,0 +1,310 @@
+package spreadsheet
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	FormatPDF = "pdf"
+
+	// csvFlushRows is how often the CSV writer pushes rows to the client
+	csvFlushRows = 500
+
+	pdfFontSize   = 8
+	pdfLineHeight = 5
+)
+
+// Writer writes records of an export one at a time, Close must be called
+// once every record is written to complete the file, or Abort to release
+// the writer without completing it when the export fails
+type Writer interface {
+	Write(record []interface{}) error
+	Close() error
+	Abort()
+}
+
+// NewWriter returns a writer of the given format on w, the title is used as
+// the sheet name of XLSX files and as the heading of PDF documents
+func NewWriter(w io.Writer, format string, title string) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return &csvWriter{w: w, writer: csv.NewWriter(w)}, nil
+	case FormatXLSX:
+		return newXLSXWriter(w, title)
+	case FormatPDF:
+		return &pdfWriter{w: w, title: title}, nil
+	}
+	return nil, ErrUnsupportedFormat
+}
+
+// ContentType is the MIME type of the format
+func ContentType(format string) string {
+	switch format {
+	case FormatCSV:
+		return "text/csv"
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	case FormatPDF:
+		return "application/pdf"
+	}
+	return "application/octet-stream"
+}
+
+// FormatCell renders a cell value as text, zero times are left blank
+func FormatCell(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case int:
+		return strconv.Itoa(v)
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		return v.Format(time.RFC3339)
+	}
+	return fmt.Sprint(value)
+}
+
+type csvWriter struct {
+	w      io.Writer
+	writer *csv.Writer
+	rows   int
+}
+
+func (c *csvWriter) Write(record []interface{}) error {
+	values := make([]string, len(record))
+	for i, value := range record {
+		values[i] = FormatCell(value)
+	}
+	if err := c.writer.Write(values); err != nil {
+		return err
+	}
+
+	c.rows++
+	if c.rows%csvFlushRows == 0 {
+		return c.flush()
+	}
+	return nil
+}
+
+func (c *csvWriter) Close() error {
+	return c.flush()
+}
+
+func (c *csvWriter) Abort() {}
+
+// flush hands the buffered rows to the client so a large export is
+// received while it is still being read from the database
+func (c *csvWriter) flush() error {
+	c.writer.Flush()
+	if err := c.writer.Error(); err != nil {
+		return err
+	}
+	if flusher, ok := c.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return nil
+}
+
+// xlsxWriter uses the excelize stream writer which keeps large sheets on disk
+// instead of memory, the workbook can only be written out once complete
+type xlsxWriter struct {
+	w        io.Writer
+	workbook *excelize.File
+	stream   *excelize.StreamWriter
+	rows     int
+}
+
+func newXLSXWriter(w io.Writer, title string) (*xlsxWriter, error) {
+	workbook := excelize.NewFile()
+	sheet := workbook.GetSheetName(0)
+	if title != "" {
+		if err := workbook.SetSheetName(sheet, sheetName(title)); err != nil {
+			workbook.Close()
+			return nil, err
+		}
+		sheet = sheetName(title)
+	}
+
+	stream, err := workbook.NewStreamWriter(sheet)
+	if err != nil {
+		workbook.Close()
+		return nil, err
+	}
+
+	return &xlsxWriter{w: w, workbook: workbook, stream: stream}, nil
+}
+
+func (x *xlsxWriter) Write(record []interface{}) error {
+	values := make([]interface{}, len(record))
+	for i, value := range record {
+		switch v := value.(type) {
+		case float64, int:
+			values[i] = v
+		default:
+			values[i] = FormatCell(v)
+		}
+	}
+
+	x.rows++
+	cell, err := excelize.CoordinatesToCellName(1, x.rows)
+	if err != nil {
+		return err
+	}
+	return x.stream.SetRow(cell, values)
+}
+
+func (x *xlsxWriter) Close() error {
+	defer x.workbook.Close()
+
+	if err := x.stream.Flush(); err != nil {
+		return err
+	}
+	return x.workbook.Write(x.w)
+}
+
+func (x *xlsxWriter) Abort() {
+	x.workbook.Close()
+}
+
+// sheetName trims the title to the 31 characters a sheet name is limited to
+func sheetName(title string) string {
+	runes := []rune(title)
+	if len(runes) > 31 {
+		return string(runes[:31])
+	}
+	return title
+}
+
+// pdfWriter renders the records as a landscape table, the first record is
+// the header and is repeated on every page. PDF documents are laid out once
+// complete so the records are kept until Close
+type pdfWriter struct {
+	w       io.Writer
+	title   string
+	records [][]string
+}
+
+func (p *pdfWriter) Write(record []interface{}) error {
+	values := make([]string, len(record))
+	for i, value := range record {
+		values[i] = FormatCell(value)
+	}
+	p.records = append(p.records, values)
+	return nil
+}
+
+func (p *pdfWriter) Abort() {
+	p.records = nil
+}
+
+func (p *pdfWriter) Close() error {
+	pdf := fpdf.New("L", "mm", "A4", "")
+	translate := pdf.UnicodeTranslatorFromDescriptor("")
+	pdf.SetFont("Helvetica", "", pdfFontSize)
+	pdf.SetAutoPageBreak(true, 10)
+
+	var header []string
+	if len(p.records) > 0 {
+		header = p.records[0]
+	}
+	widths := p.columnWidths(pdf)
+
+	writeRow := func(values []string, fill bool) {
+		for i, width := range widths {
+			value := ""
+			if i < len(values) {
+				value = values[i]
+			}
+			pdf.CellFormat(width, pdfLineHeight, translate(fitText(pdf, value, width)), "1", 0, "L", fill, 0, "")
+		}
+		pdf.Ln(-1)
+	}
+
+	pdf.SetHeaderFunc(func() {
+		if p.title != "" {
+			pdf.SetFont("Helvetica", "B", 12)
+			pdf.CellFormat(0, 8, translate(p.title), "", 1, "L", false, 0, "")
+		}
+		if len(header) > 0 {
+			pdf.SetFont("Helvetica", "B", pdfFontSize)
+			pdf.SetFillColor(230, 230, 230)
+			writeRow(header, true)
+		}
+		pdf.SetFont("Helvetica", "", pdfFontSize)
+	})
+	pdf.AddPage()
+
+	if len(p.records) > 1 {
+		for _, record := range p.records[1:] {
+			writeRow(record, false)
+		}
+	}
+
+	if err := pdf.Error(); err != nil {
+		return err
+	}
+	return pdf.Output(p.w)
+}
+
+// columnWidths spreads the page width over the columns in proportion to
+// their longest value
+func (p *pdfWriter) columnWidths(pdf *fpdf.Fpdf) []float64 {
+	columns := 0
+	for _, record := range p.records {
+		columns = max(columns, len(record))
+	}
+	if columns == 0 {
+		return nil
+	}
+
+	longest := make([]float64, columns)
+	for _, record := range p.records {
+		for i, value := range record {
+			longest[i] = max(longest[i], pdf.GetStringWidth(value)+2)
+		}
+	}
+
+	pageWidth, _ := pdf.GetPageSize()
+	left, _, right, _ := pdf.GetMargins()
+	available := pageWidth - left - right
+
+	total := 0.0
+	for _, width := range longest {
+		total += width
+	}
+	if total <= available {
+		return longest
+	}
+
+	widths := make([]float64, columns)
+	for i, width := range longest {
+		widths[i] = width / total * available
+	}
+	return widths
+}
+
+// fitText cuts a value that doesn't fit its cell
+func fitText(pdf *fpdf.Fpdf, value string, width float64) string {
+	if pdf.GetStringWidth(value)+2 <= width {
+		return value
+	}
+	runes := []rune(value)
+	for len(runes) > 0 && pdf.GetStringWidth(string(runes)+"...")+2 > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "..."
+}
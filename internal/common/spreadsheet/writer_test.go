@@ -0,0 +1,76 @@
+package spreadsheet
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	"github.com/xuri/excelize/v2"
+)
+
+func Test_NewWriter(t *testing.T) {
+	t.Parallel()
+
+	var (
+		date    = time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+		records = [][]interface{}{
+			{"id", "price", "quantity", "valid_to"},
+			{"P1", 15800.5, 10, date},
+			{"P2", 200.0, 1, time.Time{}},
+		}
+	)
+
+	write := func(g *gomega.WithT, format string) *bytes.Buffer {
+		var buffer bytes.Buffer
+		writer, err := NewWriter(&buffer, format, "Prices")
+		g.Expect(err).To(gomega.BeNil())
+		for _, record := range records {
+			g.Expect(writer.Write(record)).To(gomega.Succeed())
+		}
+		g.Expect(writer.Close()).To(gomega.Succeed())
+		return &buffer
+	}
+
+	t.Run("csv", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		buffer := write(g, FormatCSV)
+		g.Expect(buffer.String()).To(gomega.Equal("id,price,quantity,valid_to\n" +
+			"P1,15800.5,10,2024-03-01T00:00:00Z\n" +
+			"P2,200,1,\n"))
+	})
+
+	t.Run("xlsx", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		buffer := write(g, FormatXLSX)
+		workbook, err := excelize.OpenReader(buffer)
+		g.Expect(err).To(gomega.BeNil())
+		defer workbook.Close()
+
+		g.Expect(workbook.GetSheetList()).To(gomega.Equal([]string{"Prices"}))
+		rows, err := workbook.GetRows("Prices")
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(rows).To(gomega.Equal([][]string{
+			{"id", "price", "quantity", "valid_to"},
+			{"P1", "15800.5", "10", "2024-03-01T00:00:00Z"},
+			{"P2", "200", "1"},
+		}))
+	})
+
+	t.Run("pdf", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		buffer := write(g, FormatPDF)
+		g.Expect(buffer.String()).To(gomega.HavePrefix("%PDF-"))
+	})
+
+	t.Run("error on unsupported format", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		writer, err := NewWriter(&bytes.Buffer{}, "xls", "Prices")
+		g.Expect(err).To(gomega.MatchError(ErrUnsupportedFormat))
+		g.Expect(writer).To(gomega.BeNil())
+	})
+}
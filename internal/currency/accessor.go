@@ -0,0 +1,248 @@
+package currency
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"kg/procurement/cmd/utils"
+	"kg/procurement/internal/common/database"
+	"strings"
+	"time"
+
+	"github.com/benbjohnson/clock"
+)
+
+const (
+	getCurrenciesQuery = `
+		SELECT id, code, name, modified_date, modified_by
+		FROM currency
+		ORDER BY code
+	`
+
+	getExchangeRatesQuery = `
+		SELECT
+			id,
+			from_currency,
+			to_currency,
+			rate,
+			rate_date,
+			source,
+			modified_date,
+			modified_by,
+			COUNT(*) OVER () AS total_entries
+		FROM exchange_rate
+	`
+
+	// getExchangeRateQuery returns the most recent rate of the pair effective at $3,
+	// a rate recorded the other way around is inverted. The direct rate wins when
+	// both directions were recorded on the same date
+	getExchangeRateQuery = `
+		SELECT id, from_currency, to_currency, rate, rate_date, source, modified_date, modified_by
+		FROM (
+			SELECT
+				er.id,
+				er.from_currency,
+				er.to_currency,
+				er.rate,
+				er.rate_date,
+				er.source,
+				er.modified_date,
+				er.modified_by,
+				0 AS inverted
+			FROM exchange_rate er
+			WHERE er.from_currency = $1
+				AND er.to_currency = $2
+				AND er.rate_date <= $3
+			UNION ALL
+			SELECT
+				er.id,
+				er.to_currency AS from_currency,
+				er.from_currency AS to_currency,
+				1 / er.rate AS rate,
+				er.rate_date,
+				er.source,
+				er.modified_date,
+				er.modified_by,
+				1 AS inverted
+			FROM exchange_rate er
+			WHERE er.from_currency = $2
+				AND er.to_currency = $1
+				AND er.rate_date <= $3
+		) rates
+		ORDER BY rate_date DESC, inverted
+		LIMIT 1
+	`
+
+	upsertExchangeRatesQuery = `
+		INSERT INTO exchange_rate
+			(id, from_currency, to_currency, rate, rate_date, source, modified_date, modified_by)
+		VALUES
+			%s
+		ON CONFLICT (from_currency, to_currency, rate_date) DO UPDATE SET
+			rate = EXCLUDED.rate,
+			source = EXCLUDED.source,
+			modified_date = EXCLUDED.modified_date,
+			modified_by = EXCLUDED.modified_by
+	`
+)
+
+// exchangeRateSortableColumns maps the accepted order_by fields to their column
+var exchangeRateSortableColumns = map[string]string{
+	"rate_date":     "rate_date",
+	"from_currency": "from_currency",
+	"to_currency":   "to_currency",
+	"rate":          "rate",
+	"modified_date": "modified_date",
+}
+
+type postgresCurrencyAccessor struct {
+	db    database.DBConnector
+	clock clock.Clock
+}
+
+type exchangeRateRow struct {
+	ExchangeRate
+	TotalEntries int `db:"total_entries"`
+}
+
+func (p *postgresCurrencyAccessor) GetCurrencies(ctx context.Context) ([]Currency, error) {
+	res := []Currency{}
+	if err := p.db.SelectContext(ctx, &res, getCurrenciesQuery); err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+	return res, nil
+}
+
+func (p *postgresCurrencyAccessor) GetExchangeRates(
+	ctx context.Context,
+	spec GetExchangeRatesSpec,
+) (*AccessorGetExchangeRatesPaginationData, error) {
+	paginationArgs := database.BuildPaginationArgs(spec.PaginationSpec)
+
+	var (
+		whereClauses []string
+		args         []interface{}
+		argsIndex    = 1
+	)
+
+	if spec.FromCurrency != "" {
+		whereClauses = append(whereClauses, fmt.Sprintf("from_currency = $%d", argsIndex))
+		args = append(args, spec.FromCurrency)
+		argsIndex++
+	}
+	if spec.ToCurrency != "" {
+		whereClauses = append(whereClauses, fmt.Sprintf("to_currency = $%d", argsIndex))
+		args = append(args, spec.ToCurrency)
+		argsIndex++
+	}
+	if !spec.DateFrom.IsZero() {
+		whereClauses = append(whereClauses, fmt.Sprintf("rate_date >= $%d", argsIndex))
+		args = append(args, spec.DateFrom)
+		argsIndex++
+	}
+	if !spec.DateTo.IsZero() {
+		whereClauses = append(whereClauses, fmt.Sprintf("rate_date <= $%d", argsIndex))
+		args = append(args, spec.DateTo)
+		argsIndex++
+	}
+
+	orderBy := paginationArgs.OrderBy
+	if orderBy == "" {
+		orderBy = "rate_date"
+	}
+	orderByClause, err := database.BuildOrderByClause(orderBy, paginationArgs.Order, exchangeRateSortableColumns)
+	if err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	whereClause := ""
+	if len(whereClauses) > 0 {
+		whereClause = "WHERE " + strings.Join(whereClauses, " AND ")
+	}
+
+	query := fmt.Sprintf(`%s
+		%s
+		%s, id
+		LIMIT $%d
+		OFFSET $%d
+	`, getExchangeRatesQuery, whereClause, orderByClause, argsIndex, argsIndex+1)
+	args = append(args, paginationArgs.Limit, paginationArgs.Offset)
+
+	rows := []exchangeRateRow{}
+	if err := p.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	totalEntries := 0
+	rates := make([]ExchangeRate, 0, len(rows))
+	for _, row := range rows {
+		totalEntries = row.TotalEntries
+		rates = append(rates, row.ExchangeRate)
+	}
+
+	return &AccessorGetExchangeRatesPaginationData{
+		ExchangeRates: rates,
+		Metadata:      database.GeneratePaginationMetadata(spec.PaginationSpec, totalEntries),
+	}, nil
+}
+
+// GetExchangeRate returns the rate converting from into to effective at date,
+// ErrRateNotFound is returned when the pair has no rate on or before it
+func (p *postgresCurrencyAccessor) GetExchangeRate(
+	ctx context.Context,
+	from string,
+	to string,
+	date time.Time,
+) (*ExchangeRate, error) {
+	res := ExchangeRate{}
+	if err := p.db.GetContext(ctx, &res, getExchangeRateQuery, from, to, date); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrRateNotFound
+		}
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+	return &res, nil
+}
+
+// UpsertExchangeRates writes every rate in a single statement so an import is
+// applied entirely or not at all, a rate already recorded for the pair and date is replaced
+func (p *postgresCurrencyAccessor) UpsertExchangeRates(ctx context.Context, rates []ExchangeRate) error {
+	values := make([]string, 0, len(rates))
+	args := make([]interface{}, 0, len(rates)*8)
+	for i, rate := range rates {
+		n := i * 8
+		values = append(values, fmt.Sprintf("($%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d)",
+			n+1, n+2, n+3, n+4, n+5, n+6, n+7, n+8))
+		args = append(args,
+			rate.ID,
+			rate.FromCurrency,
+			rate.ToCurrency,
+			rate.Rate,
+			rate.RateDate,
+			rate.Source,
+			rate.ModifiedDate,
+			rate.ModifiedBy,
+		)
+	}
+
+	query := fmt.Sprintf(upsertExchangeRatesQuery, strings.Join(values, ",\n\t\t\t"))
+	if _, err := p.db.ExecContext(ctx, query, args...); err != nil {
+		utils.Logger.Error(err.Error())
+		return err
+	}
+	return nil
+}
+
+// newPostgresCurrencyAccessor is only accessible by the currency package
+// entrypoint for other verticals should refer to the interface declared on service
+func newPostgresCurrencyAccessor(db database.DBConnector, clock clock.Clock) *postgresCurrencyAccessor {
+	return &postgresCurrencyAccessor{
+		db:    db,
+		clock: clock,
+	}
+}
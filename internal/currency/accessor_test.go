@@ -0,0 +1,230 @@
+package currency
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"kg/procurement/internal/common/database"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/benbjohnson/clock"
+	"github.com/jmoiron/sqlx"
+	"github.com/onsi/gomega"
+)
+
+func Test_newPostgresCurrencyAccessor(t *testing.T) {
+	_ = newPostgresCurrencyAccessor(nil, nil)
+}
+
+var exchangeRateColumnsWithTotal = []string{
+	"id", "from_currency", "to_currency", "rate", "rate_date", "source", "modified_date", "modified_by", "total_entries",
+}
+
+func Test_GetCurrencies(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, time.December, 6, 0, 0, 0, 0, time.UTC)
+
+	t.Run("success", func(t *testing.T) {
+		c := setupCurrencyAccessorTestComponent(t)
+		defer c.db.Close()
+
+		rows := sqlmock.NewRows([]string{"id", "code", "name", "modified_date", "modified_by"}).
+			AddRow("1", "IDR", "Indonesian Rupiah", now, "migration").
+			AddRow("2", "USD", "US Dollar", now, "migration")
+		c.mock.ExpectQuery(regexp.QuoteMeta(getCurrenciesQuery)).WillReturnRows(rows)
+
+		res, err := c.accessor.GetCurrencies(context.Background())
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(res).To(gomega.Equal([]Currency{
+			{ID: "1", Code: "IDR", Name: "Indonesian Rupiah", ModifiedDate: now, ModifiedBy: "migration"},
+			{ID: "2", Code: "USD", Name: "US Dollar", ModifiedDate: now, ModifiedBy: "migration"},
+		}))
+	})
+
+	t.Run("error on query", func(t *testing.T) {
+		c := setupCurrencyAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectQuery(regexp.QuoteMeta(getCurrenciesQuery)).WillReturnError(errors.New("error"))
+
+		res, err := c.accessor.GetCurrencies(context.Background())
+		c.g.Expect(err).ToNot(gomega.BeNil())
+		c.g.Expect(res).To(gomega.BeNil())
+	})
+}
+
+func Test_GetExchangeRates(t *testing.T) {
+	t.Parallel()
+
+	var (
+		now      = time.Date(2024, time.December, 6, 0, 0, 0, 0, time.UTC)
+		dateFrom = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	)
+
+	t.Run("success with filters", func(t *testing.T) {
+		c := setupCurrencyAccessorTestComponent(t)
+		defer c.db.Close()
+
+		rows := sqlmock.NewRows(exchangeRateColumnsWithTotal).
+			AddRow("1", "USD", "IDR", 15800.5, now, "csv", now, "admin", 3)
+		c.mock.ExpectQuery(regexp.QuoteMeta(getExchangeRatesQuery)+
+			`\s*WHERE from_currency = \$1 AND rate_date >= \$2\s+ORDER BY rate_date DESC, id\s+LIMIT \$3\s+OFFSET \$4`).
+			WithArgs("USD", dateFrom, 1, 0).
+			WillReturnRows(rows)
+
+		res, err := c.accessor.GetExchangeRates(context.Background(), GetExchangeRatesSpec{
+			FromCurrency:   "USD",
+			DateFrom:       dateFrom,
+			PaginationSpec: database.PaginationSpec{Limit: 1, Page: 1, Order: "DESC"},
+		})
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(res.ExchangeRates).To(gomega.Equal([]ExchangeRate{{
+			ID:           "1",
+			FromCurrency: "USD",
+			ToCurrency:   "IDR",
+			Rate:         15800.5,
+			RateDate:     now,
+			Source:       "csv",
+			ModifiedDate: now,
+			ModifiedBy:   "admin",
+		}}))
+		c.g.Expect(res.Metadata.TotalEntries).To(gomega.Equal(3))
+	})
+
+	t.Run("error on invalid sort column", func(t *testing.T) {
+		c := setupCurrencyAccessorTestComponent(t)
+		defer c.db.Close()
+
+		res, err := c.accessor.GetExchangeRates(context.Background(), GetExchangeRatesSpec{
+			PaginationSpec: database.PaginationSpec{Limit: 10, Page: 1, OrderBy: "source"},
+		})
+		c.g.Expect(errors.Is(err, database.ErrInvalidSortColumn)).To(gomega.BeTrue())
+		c.g.Expect(res).To(gomega.BeNil())
+	})
+
+	t.Run("error on query", func(t *testing.T) {
+		c := setupCurrencyAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectQuery(regexp.QuoteMeta(getExchangeRatesQuery)).WillReturnError(errors.New("error"))
+
+		res, err := c.accessor.GetExchangeRates(context.Background(), GetExchangeRatesSpec{
+			PaginationSpec: database.PaginationSpec{Limit: 10, Page: 1},
+		})
+		c.g.Expect(err).ToNot(gomega.BeNil())
+		c.g.Expect(res).To(gomega.BeNil())
+	})
+}
+
+func Test_GetExchangeRate(t *testing.T) {
+	t.Parallel()
+
+	var (
+		now     = time.Date(2024, time.December, 6, 0, 0, 0, 0, time.UTC)
+		columns = []string{"id", "from_currency", "to_currency", "rate", "rate_date", "source", "modified_date", "modified_by"}
+	)
+
+	t.Run("success", func(t *testing.T) {
+		c := setupCurrencyAccessorTestComponent(t)
+		defer c.db.Close()
+
+		rows := sqlmock.NewRows(columns).
+			AddRow("1", "USD", "IDR", 15800.0, now, "manual", now, "admin")
+		c.mock.ExpectQuery(regexp.QuoteMeta(getExchangeRateQuery)).
+			WithArgs("USD", "IDR", now).
+			WillReturnRows(rows)
+
+		res, err := c.accessor.GetExchangeRate(context.Background(), "USD", "IDR", now)
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(res.Rate).To(gomega.Equal(15800.0))
+		c.g.Expect(res.ToCurrency).To(gomega.Equal("IDR"))
+	})
+
+	t.Run("returns ErrRateNotFound without rate", func(t *testing.T) {
+		c := setupCurrencyAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectQuery(regexp.QuoteMeta(getExchangeRateQuery)).
+			WithArgs("USD", "IDR", now).
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		res, err := c.accessor.GetExchangeRate(context.Background(), "USD", "IDR", now)
+		c.g.Expect(err).To(gomega.MatchError(ErrRateNotFound))
+		c.g.Expect(res).To(gomega.BeNil())
+	})
+
+	t.Run("error on query", func(t *testing.T) {
+		c := setupCurrencyAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectQuery(regexp.QuoteMeta(getExchangeRateQuery)).
+			WithArgs("USD", "IDR", now).
+			WillReturnError(errors.New("error"))
+
+		res, err := c.accessor.GetExchangeRate(context.Background(), "USD", "IDR", now)
+		c.g.Expect(err).ToNot(gomega.BeNil())
+		c.g.Expect(errors.Is(err, ErrRateNotFound)).To(gomega.BeFalse())
+		c.g.Expect(res).To(gomega.BeNil())
+	})
+}
+
+func Test_UpsertExchangeRates(t *testing.T) {
+	t.Parallel()
+
+	var (
+		now   = time.Date(2024, time.December, 6, 0, 0, 0, 0, time.UTC)
+		rates = []ExchangeRate{
+			{ID: "1", FromCurrency: "USD", ToCurrency: "IDR", Rate: 15800, RateDate: now, Source: "csv", ModifiedDate: now, ModifiedBy: "admin"},
+			{ID: "2", FromCurrency: "EUR", ToCurrency: "IDR", Rate: 17100, RateDate: now, Source: "csv", ModifiedDate: now, ModifiedBy: "admin"},
+		}
+	)
+
+	t.Run("success", func(t *testing.T) {
+		c := setupCurrencyAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectExec(`INSERT INTO exchange_rate .* VALUES\s+\(\$1, \$2, \$3, \$4, \$5, \$6, \$7, \$8\),\s+\(\$9, \$10, \$11, \$12, \$13, \$14, \$15, \$16\)\s+ON CONFLICT \(from_currency, to_currency, rate_date\) DO UPDATE`).
+			WithArgs(
+				"1", "USD", "IDR", 15800.0, now, "csv", now, "admin",
+				"2", "EUR", "IDR", 17100.0, now, "csv", now, "admin",
+			).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		err := c.accessor.UpsertExchangeRates(context.Background(), rates)
+		c.g.Expect(err).To(gomega.BeNil())
+	})
+
+	t.Run("error on exec", func(t *testing.T) {
+		c := setupCurrencyAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectExec(`INSERT INTO exchange_rate`).WillReturnError(errors.New("error"))
+
+		err := c.accessor.UpsertExchangeRates(context.Background(), rates)
+		c.g.Expect(err).ToNot(gomega.BeNil())
+	})
+}
+
+type currencyAccessorTestComponent struct {
+	g        *gomega.WithT
+	mock     sqlmock.Sqlmock
+	db       *sql.DB
+	accessor *postgresCurrencyAccessor
+}
+
+func setupCurrencyAccessorTestComponent(t *testing.T) currencyAccessorTestComponent {
+	g := gomega.NewWithT(t)
+	db, sqlMock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	return currencyAccessorTestComponent{
+		g:        g,
+		mock:     sqlMock,
+		db:       db,
+		accessor: newPostgresCurrencyAccessor(sqlxDB, clock.NewMock()),
+	}
+}
@@ -0,0 +1,100 @@
+package currency
+
+import (
+	"errors"
+	"fmt"
+	"kg/procurement/internal/common/database"
+	"strings"
+	"time"
+)
+
+const (
+	ExchangeRateSourceManual = "manual"
+	ExchangeRateSourceCSV    = "csv"
+
+	// maxImportRows keeps an import within the bind parameter limit of a single statement
+	maxImportRows = 5000
+)
+
+var (
+	ErrRateNotFound      = errors.New("exchange rate not found")
+	ErrUnknownCurrency   = errors.New("unknown currency")
+	ErrSameCurrency      = errors.New("exchange rate currencies must differ")
+	ErrInvalidRate       = errors.New("exchange rate must be greater than zero")
+	ErrMissingRateDate   = errors.New("rate_date is required")
+	ErrEmptyImport       = errors.New("import file has no exchange rates")
+	ErrTooManyImportRows = fmt.Errorf("import file exceeds %d exchange rates", maxImportRows)
+)
+
+type Currency struct {
+	ID           string    `db:"id" json:"id"`
+	Code         string    `db:"code" json:"code"`
+	Name         string    `db:"name" json:"name"`
+	ModifiedDate time.Time `db:"modified_date" json:"modified_date"`
+	ModifiedBy   string    `db:"modified_by" json:"modified_by"`
+}
+
+// ExchangeRate is the amount of ToCurrency one unit of FromCurrency buys,
+// it applies from RateDate until a more recent rate of the same pair
+type ExchangeRate struct {
+	ID           string    `db:"id" json:"id"`
+	FromCurrency string    `db:"from_currency" json:"from_currency"`
+	ToCurrency   string    `db:"to_currency" json:"to_currency"`
+	Rate         float64   `db:"rate" json:"rate"`
+	RateDate     time.Time `db:"rate_date" json:"rate_date"`
+	Source       string    `db:"source" json:"source"`
+	ModifiedDate time.Time `db:"modified_date" json:"modified_date"`
+	ModifiedBy   string    `db:"modified_by" json:"modified_by"`
+}
+
+type GetExchangeRatesSpec struct {
+	FromCurrency string    `json:"from_currency"`
+	ToCurrency   string    `json:"to_currency"`
+	DateFrom     time.Time `json:"date_from"`
+	DateTo       time.Time `json:"date_to"`
+	database.PaginationSpec
+}
+
+type AccessorGetExchangeRatesPaginationData struct {
+	ExchangeRates []ExchangeRate              `json:"exchange_rates"`
+	Metadata      database.PaginationMetadata `json:"metadata"`
+}
+
+type PostExchangeRateSpec struct {
+	FromCurrency string    `json:"from_currency" binding:"required"`
+	ToCurrency   string    `json:"to_currency" binding:"required"`
+	Rate         float64   `json:"rate" binding:"required"`
+	RateDate     time.Time `json:"rate_date" binding:"required"`
+	ModifiedBy   string    `json:"modified_by"`
+}
+
+// Conversion is an amount converted to the base currency along with the rate used
+type Conversion struct {
+	Amount       float64   `json:"amount"`
+	CurrencyCode string    `json:"currency_code"`
+	Rate         float64   `json:"rate"`
+	RateDate     time.Time `json:"rate_date"`
+}
+
+// RowError describes why a line of an import file was rejected, Row counts the header as line 1
+type RowError struct {
+	Row     int    `json:"row"`
+	Message string `json:"message"`
+}
+
+// ImportError is returned when an import file has invalid rows, nothing is imported
+type ImportError struct {
+	Rows []RowError `json:"rows"`
+}
+
+func (e *ImportError) Error() string {
+	messages := make([]string, 0, len(e.Rows))
+	for _, row := range e.Rows {
+		messages = append(messages, fmt.Sprintf("row %d: %s", row.Row, row.Message))
+	}
+	return "invalid import file: " + strings.Join(messages, "; ")
+}
+
+type ImportExchangeRatesResult struct {
+	Imported int `json:"imported"`
+}
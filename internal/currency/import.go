@@ -0,0 +1,95 @@
+package currency
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// exchangeRateColumns are the columns an import file must declare in its header, in any order
+var exchangeRateColumns = []string{"from_currency", "to_currency", "rate", "rate_date"}
+
+var ErrInvalidImportHeader = fmt.Errorf("import file header must contain %s", strings.Join(exchangeRateColumns, ", "))
+
+type parsedExchangeRate struct {
+	line int
+	rate ExchangeRate
+	err  error
+}
+
+// parseExchangeRatesCSV reads every row of the file, a row that can't be parsed
+// carries its error so every invalid row can be reported at once
+func parseExchangeRatesCSV(file io.Reader) ([]parsedExchangeRate, error) {
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, ErrEmptyImport
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	index := map[string]int{}
+	for i, column := range header {
+		index[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+	for _, column := range exchangeRateColumns {
+		if _, ok := index[column]; !ok {
+			return nil, ErrInvalidImportHeader
+		}
+	}
+
+	var res []parsedExchangeRate
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			res = append(res, parsedExchangeRate{line: line, err: err})
+			continue
+		}
+
+		field := func(column string) string {
+			if i := index[column]; i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		row := parsedExchangeRate{
+			line: line,
+			rate: ExchangeRate{
+				FromCurrency: normalizeCode(field("from_currency")),
+				ToCurrency:   normalizeCode(field("to_currency")),
+			},
+		}
+
+		rate, err := strconv.ParseFloat(field("rate"), 64)
+		if err != nil {
+			row.err = fmt.Errorf("invalid rate: %s", field("rate"))
+			res = append(res, row)
+			continue
+		}
+		row.rate.Rate = rate
+
+		rateDate, err := time.Parse(time.DateOnly, field("rate_date"))
+		if err != nil {
+			row.err = fmt.Errorf("invalid rate_date: %s", field("rate_date"))
+			res = append(res, row)
+			continue
+		}
+		row.rate.RateDate = rateDate
+
+		res = append(res, row)
+	}
+
+	return res, nil
+}
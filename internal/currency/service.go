@@ -0,0 +1,217 @@
+//go:generate mockgen -typed -source=service.go -destination=service_mock.go -package=currency
+package currency
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"kg/procurement/cmd/utils"
+	"kg/procurement/internal/common/database"
+	"kg/procurement/internal/common/helper"
+	"strings"
+	"time"
+
+	"github.com/benbjohnson/clock"
+)
+
+type currencyDBAccessor interface {
+	GetCurrencies(ctx context.Context) ([]Currency, error)
+	GetExchangeRates(ctx context.Context, spec GetExchangeRatesSpec) (*AccessorGetExchangeRatesPaginationData, error)
+	GetExchangeRate(ctx context.Context, from string, to string, date time.Time) (*ExchangeRate, error)
+	UpsertExchangeRates(ctx context.Context, rates []ExchangeRate) error
+}
+
+type CurrencyService struct {
+	currencyDBAccessor
+	baseCurrency string
+	clock        clock.Clock
+}
+
+// BaseCurrency is the currency amounts are converted to for comparison
+func (c *CurrencyService) BaseCurrency() string {
+	return c.baseCurrency
+}
+
+// GetBaseRate returns the rate converting code into the base currency at date,
+// amounts already in the base currency use an identity rate
+func (c *CurrencyService) GetBaseRate(ctx context.Context, code string, date time.Time) (*ExchangeRate, error) {
+	code = normalizeCode(code)
+	if code == c.baseCurrency {
+		return &ExchangeRate{
+			FromCurrency: code,
+			ToCurrency:   code,
+			Rate:         1,
+			RateDate:     date,
+		}, nil
+	}
+
+	return c.currencyDBAccessor.GetExchangeRate(ctx, code, c.baseCurrency, date)
+}
+
+// Convert converts amount from code into the base currency at date
+func (c *CurrencyService) Convert(ctx context.Context, amount float64, code string, date time.Time) (*Conversion, error) {
+	rate, err := c.GetBaseRate(ctx, code, date)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Conversion{
+		Amount:       amount * rate.Rate,
+		CurrencyCode: rate.ToCurrency,
+		Rate:         rate.Rate,
+		RateDate:     rate.RateDate,
+	}, nil
+}
+
+// CreateExchangeRate records a manually entered rate, replacing the rate of the same pair and date
+func (c *CurrencyService) CreateExchangeRate(ctx context.Context, spec PostExchangeRateSpec) (*ExchangeRate, error) {
+	currencies, err := c.currencyCodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rate := ExchangeRate{
+		FromCurrency: normalizeCode(spec.FromCurrency),
+		ToCurrency:   normalizeCode(spec.ToCurrency),
+		Rate:         spec.Rate,
+		RateDate:     truncateToDate(spec.RateDate),
+		Source:       ExchangeRateSourceManual,
+		ModifiedDate: c.clock.Now(),
+		ModifiedBy:   spec.ModifiedBy,
+	}
+	if err := validateExchangeRate(rate, currencies); err != nil {
+		return nil, err
+	}
+
+	rate.ID, err = helper.GenerateRandomID()
+	if err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	if err := c.currencyDBAccessor.UpsertExchangeRates(ctx, []ExchangeRate{rate}); err != nil {
+		return nil, err
+	}
+
+	return &rate, nil
+}
+
+// ImportExchangeRates records every rate of a CSV file. The file is rejected as a whole
+// with an *ImportError listing each invalid row, so a partially valid file imports nothing
+func (c *CurrencyService) ImportExchangeRates(ctx context.Context, file io.Reader, modifiedBy string) (*ImportExchangeRatesResult, error) {
+	currencies, err := c.currencyCodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := parseExchangeRatesCSV(file)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, ErrEmptyImport
+	}
+	if len(rows) > maxImportRows {
+		return nil, ErrTooManyImportRows
+	}
+
+	var (
+		now       = c.clock.Now()
+		rates     = make([]ExchangeRate, 0, len(rows))
+		rowErrors []RowError
+		seen      = map[string]int{}
+	)
+	for _, row := range rows {
+		if row.err != nil {
+			rowErrors = append(rowErrors, RowError{Row: row.line, Message: row.err.Error()})
+			continue
+		}
+
+		rate := row.rate
+		rate.Source = ExchangeRateSourceCSV
+		rate.ModifiedDate = now
+		rate.ModifiedBy = modifiedBy
+		if err := validateExchangeRate(rate, currencies); err != nil {
+			rowErrors = append(rowErrors, RowError{Row: row.line, Message: err.Error()})
+			continue
+		}
+
+		key := rate.FromCurrency + "/" + rate.ToCurrency + "/" + rate.RateDate.Format(time.DateOnly)
+		if line, ok := seen[key]; ok {
+			rowErrors = append(rowErrors, RowError{Row: row.line, Message: fmt.Sprintf("duplicates row %d", line)})
+			continue
+		}
+		seen[key] = row.line
+
+		rate.ID, err = helper.GenerateRandomID()
+		if err != nil {
+			utils.Logger.Error(err.Error())
+			return nil, err
+		}
+		rates = append(rates, rate)
+	}
+
+	if len(rowErrors) > 0 {
+		return nil, &ImportError{Rows: rowErrors}
+	}
+
+	if err := c.currencyDBAccessor.UpsertExchangeRates(ctx, rates); err != nil {
+		return nil, err
+	}
+
+	return &ImportExchangeRatesResult{Imported: len(rates)}, nil
+}
+
+// currencyCodes returns the set of known currency codes
+func (c *CurrencyService) currencyCodes(ctx context.Context) (map[string]bool, error) {
+	currencies, err := c.currencyDBAccessor.GetCurrencies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]bool, len(currencies))
+	for _, currency := range currencies {
+		res[currency.Code] = true
+	}
+	return res, nil
+}
+
+func validateExchangeRate(rate ExchangeRate, currencies map[string]bool) error {
+	switch {
+	case !currencies[rate.FromCurrency]:
+		return fmt.Errorf("%w: %s", ErrUnknownCurrency, rate.FromCurrency)
+	case !currencies[rate.ToCurrency]:
+		return fmt.Errorf("%w: %s", ErrUnknownCurrency, rate.ToCurrency)
+	case rate.FromCurrency == rate.ToCurrency:
+		return ErrSameCurrency
+	case rate.Rate <= 0:
+		return ErrInvalidRate
+	case rate.RateDate.IsZero():
+		return ErrMissingRateDate
+	}
+	return nil
+}
+
+func normalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// truncateToDate drops the time of day since rates apply to whole days
+func truncateToDate(t time.Time) time.Time {
+	if t.IsZero() {
+		return t
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func NewCurrencyService(
+	conn database.DBConnector,
+	clock clock.Clock,
+	baseCurrency string,
+) *CurrencyService {
+	return &CurrencyService{
+		currencyDBAccessor: newPostgresCurrencyAccessor(conn, clock),
+		baseCurrency:       normalizeCode(baseCurrency),
+		clock:              clock,
+	}
+}
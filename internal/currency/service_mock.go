@@ -0,0 +1,196 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+//
+// Generated by this command:
+//
+//	mockgen -typed -source=service.go -destination=service_mock.go -package=currency
+//
+
+// Package currency is a generated GoMock package.
+package currency
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockcurrencyDBAccessor is a mock of currencyDBAccessor interface.
+type MockcurrencyDBAccessor struct {
+	ctrl     *gomock.Controller
+	recorder *MockcurrencyDBAccessorMockRecorder
+}
+
+// MockcurrencyDBAccessorMockRecorder is the mock recorder for MockcurrencyDBAccessor.
+type MockcurrencyDBAccessorMockRecorder struct {
+	mock *MockcurrencyDBAccessor
+}
+
+// NewMockcurrencyDBAccessor creates a new mock instance.
+func NewMockcurrencyDBAccessor(ctrl *gomock.Controller) *MockcurrencyDBAccessor {
+	mock := &MockcurrencyDBAccessor{ctrl: ctrl}
+	mock.recorder = &MockcurrencyDBAccessorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcurrencyDBAccessor) EXPECT() *MockcurrencyDBAccessorMockRecorder {
+	return m.recorder
+}
+
+// GetCurrencies mocks base method.
+func (m *MockcurrencyDBAccessor) GetCurrencies(ctx context.Context) ([]Currency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrencies", ctx)
+	ret0, _ := ret[0].([]Currency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrencies indicates an expected call of GetCurrencies.
+func (mr *MockcurrencyDBAccessorMockRecorder) GetCurrencies(ctx any) *MockcurrencyDBAccessorGetCurrenciesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrencies", reflect.TypeOf((*MockcurrencyDBAccessor)(nil).GetCurrencies), ctx)
+	return &MockcurrencyDBAccessorGetCurrenciesCall{Call: call}
+}
+
+// MockcurrencyDBAccessorGetCurrenciesCall wrap *gomock.Call
+type MockcurrencyDBAccessorGetCurrenciesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockcurrencyDBAccessorGetCurrenciesCall) Return(arg0 []Currency, arg1 error) *MockcurrencyDBAccessorGetCurrenciesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockcurrencyDBAccessorGetCurrenciesCall) Do(f func(context.Context) ([]Currency, error)) *MockcurrencyDBAccessorGetCurrenciesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockcurrencyDBAccessorGetCurrenciesCall) DoAndReturn(f func(context.Context) ([]Currency, error)) *MockcurrencyDBAccessorGetCurrenciesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetExchangeRate mocks base method.
+func (m *MockcurrencyDBAccessor) GetExchangeRate(ctx context.Context, from, to string, date time.Time) (*ExchangeRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExchangeRate", ctx, from, to, date)
+	ret0, _ := ret[0].(*ExchangeRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExchangeRate indicates an expected call of GetExchangeRate.
+func (mr *MockcurrencyDBAccessorMockRecorder) GetExchangeRate(ctx, from, to, date any) *MockcurrencyDBAccessorGetExchangeRateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExchangeRate", reflect.TypeOf((*MockcurrencyDBAccessor)(nil).GetExchangeRate), ctx, from, to, date)
+	return &MockcurrencyDBAccessorGetExchangeRateCall{Call: call}
+}
+
+// MockcurrencyDBAccessorGetExchangeRateCall wrap *gomock.Call
+type MockcurrencyDBAccessorGetExchangeRateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockcurrencyDBAccessorGetExchangeRateCall) Return(arg0 *ExchangeRate, arg1 error) *MockcurrencyDBAccessorGetExchangeRateCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockcurrencyDBAccessorGetExchangeRateCall) Do(f func(context.Context, string, string, time.Time) (*ExchangeRate, error)) *MockcurrencyDBAccessorGetExchangeRateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockcurrencyDBAccessorGetExchangeRateCall) DoAndReturn(f func(context.Context, string, string, time.Time) (*ExchangeRate, error)) *MockcurrencyDBAccessorGetExchangeRateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetExchangeRates mocks base method.
+func (m *MockcurrencyDBAccessor) GetExchangeRates(ctx context.Context, spec GetExchangeRatesSpec) (*AccessorGetExchangeRatesPaginationData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExchangeRates", ctx, spec)
+	ret0, _ := ret[0].(*AccessorGetExchangeRatesPaginationData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExchangeRates indicates an expected call of GetExchangeRates.
+func (mr *MockcurrencyDBAccessorMockRecorder) GetExchangeRates(ctx, spec any) *MockcurrencyDBAccessorGetExchangeRatesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExchangeRates", reflect.TypeOf((*MockcurrencyDBAccessor)(nil).GetExchangeRates), ctx, spec)
+	return &MockcurrencyDBAccessorGetExchangeRatesCall{Call: call}
+}
+
+// MockcurrencyDBAccessorGetExchangeRatesCall wrap *gomock.Call
+type MockcurrencyDBAccessorGetExchangeRatesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockcurrencyDBAccessorGetExchangeRatesCall) Return(arg0 *AccessorGetExchangeRatesPaginationData, arg1 error) *MockcurrencyDBAccessorGetExchangeRatesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockcurrencyDBAccessorGetExchangeRatesCall) Do(f func(context.Context, GetExchangeRatesSpec) (*AccessorGetExchangeRatesPaginationData, error)) *MockcurrencyDBAccessorGetExchangeRatesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockcurrencyDBAccessorGetExchangeRatesCall) DoAndReturn(f func(context.Context, GetExchangeRatesSpec) (*AccessorGetExchangeRatesPaginationData, error)) *MockcurrencyDBAccessorGetExchangeRatesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// UpsertExchangeRates mocks base method.
+func (m *MockcurrencyDBAccessor) UpsertExchangeRates(ctx context.Context, rates []ExchangeRate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertExchangeRates", ctx, rates)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertExchangeRates indicates an expected call of UpsertExchangeRates.
+func (mr *MockcurrencyDBAccessorMockRecorder) UpsertExchangeRates(ctx, rates any) *MockcurrencyDBAccessorUpsertExchangeRatesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertExchangeRates", reflect.TypeOf((*MockcurrencyDBAccessor)(nil).UpsertExchangeRates), ctx, rates)
+	return &MockcurrencyDBAccessorUpsertExchangeRatesCall{Call: call}
+}
+
+// MockcurrencyDBAccessorUpsertExchangeRatesCall wrap *gomock.Call
+type MockcurrencyDBAccessorUpsertExchangeRatesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockcurrencyDBAccessorUpsertExchangeRatesCall) Return(arg0 error) *MockcurrencyDBAccessorUpsertExchangeRatesCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockcurrencyDBAccessorUpsertExchangeRatesCall) Do(f func(context.Context, []ExchangeRate) error) *MockcurrencyDBAccessorUpsertExchangeRatesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockcurrencyDBAccessorUpsertExchangeRatesCall) DoAndReturn(f func(context.Context, []ExchangeRate) error) *MockcurrencyDBAccessorUpsertExchangeRatesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
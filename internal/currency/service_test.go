@@ -0,0 +1,266 @@
+package currency
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_NewCurrencyService(t *testing.T) {
+	svc := NewCurrencyService(nil, nil, " idr ")
+	gomega.NewWithT(t).Expect(svc.BaseCurrency()).To(gomega.Equal("IDR"))
+}
+
+var knownCurrencies = []Currency{{Code: "IDR"}, {Code: "USD"}, {Code: "EUR"}}
+
+func TestCurrencyService_GetBaseRate(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mockCurrencyAccessor *MockcurrencyDBAccessor
+		subject              *CurrencyService
+		date                 = time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	)
+
+	setup := func(t *testing.T) *gomega.GomegaWithT {
+		ctrl := gomock.NewController(t)
+		mockCurrencyAccessor = NewMockcurrencyDBAccessor(ctrl)
+		subject = &CurrencyService{
+			currencyDBAccessor: mockCurrencyAccessor,
+			baseCurrency:       "IDR",
+			clock:              clock.NewMock(),
+		}
+		return gomega.NewWithT(t)
+	}
+
+	t.Run("identity rate for the base currency", func(t *testing.T) {
+		g := setup(t)
+
+		res, err := subject.GetBaseRate(context.Background(), "idr", date)
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(res.Rate).To(gomega.Equal(1.0))
+		g.Expect(res.ToCurrency).To(gomega.Equal("IDR"))
+	})
+
+	t.Run("looks up the rate to the base currency", func(t *testing.T) {
+		g := setup(t)
+		ctx := context.Background()
+
+		expected := &ExchangeRate{FromCurrency: "USD", ToCurrency: "IDR", Rate: 15800, RateDate: date}
+		mockCurrencyAccessor.EXPECT().GetExchangeRate(ctx, "USD", "IDR", date).Return(expected, nil)
+
+		res, err := subject.GetBaseRate(ctx, "USD", date)
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(res).To(gomega.Equal(expected))
+	})
+
+	t.Run("converts an amount", func(t *testing.T) {
+		g := setup(t)
+		ctx := context.Background()
+
+		mockCurrencyAccessor.EXPECT().GetExchangeRate(ctx, "USD", "IDR", date).
+			Return(&ExchangeRate{FromCurrency: "USD", ToCurrency: "IDR", Rate: 15800, RateDate: date}, nil)
+
+		res, err := subject.Convert(ctx, 2.5, "USD", date)
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(res).To(gomega.Equal(&Conversion{Amount: 39500, CurrencyCode: "IDR", Rate: 15800, RateDate: date}))
+	})
+
+	t.Run("returns error without rate", func(t *testing.T) {
+		g := setup(t)
+		ctx := context.Background()
+
+		mockCurrencyAccessor.EXPECT().GetExchangeRate(ctx, "USD", "IDR", date).Return(nil, ErrRateNotFound)
+
+		res, err := subject.Convert(ctx, 2.5, "USD", date)
+		g.Expect(err).To(gomega.MatchError(ErrRateNotFound))
+		g.Expect(res).To(gomega.BeNil())
+	})
+}
+
+func TestCurrencyService_CreateExchangeRate(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mockCurrencyAccessor *MockcurrencyDBAccessor
+		subject              *CurrencyService
+		now                  = time.Date(2024, time.December, 6, 10, 0, 0, 0, time.UTC)
+	)
+
+	setup := func(t *testing.T) *gomega.GomegaWithT {
+		ctrl := gomock.NewController(t)
+		mockCurrencyAccessor = NewMockcurrencyDBAccessor(ctrl)
+		clockMock := clock.NewMock()
+		clockMock.Set(now)
+		subject = &CurrencyService{
+			currencyDBAccessor: mockCurrencyAccessor,
+			baseCurrency:       "IDR",
+			clock:              clockMock,
+		}
+		return gomega.NewWithT(t)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		g := setup(t)
+		ctx := context.Background()
+
+		mockCurrencyAccessor.EXPECT().GetCurrencies(ctx).Return(knownCurrencies, nil)
+		mockCurrencyAccessor.EXPECT().UpsertExchangeRates(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, rates []ExchangeRate) error {
+				g.Expect(rates).To(gomega.HaveLen(1))
+				g.Expect(rates[0].ID).To(gomega.HaveLen(15))
+				return nil
+			})
+
+		res, err := subject.CreateExchangeRate(ctx, PostExchangeRateSpec{
+			FromCurrency: "usd",
+			ToCurrency:   "IDR",
+			Rate:         15800,
+			RateDate:     time.Date(2024, time.March, 1, 13, 45, 0, 0, time.UTC),
+			ModifiedBy:   "admin",
+		})
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(res.FromCurrency).To(gomega.Equal("USD"))
+		g.Expect(res.RateDate).To(gomega.Equal(time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)))
+		g.Expect(res.Source).To(gomega.Equal(ExchangeRateSourceManual))
+		g.Expect(res.ModifiedDate).To(gomega.Equal(now))
+	})
+
+	t.Run("rejects invalid rates", func(t *testing.T) {
+		specs := map[error]PostExchangeRateSpec{
+			ErrUnknownCurrency: {FromCurrency: "JPY", ToCurrency: "IDR", Rate: 100, RateDate: now},
+			ErrSameCurrency:    {FromCurrency: "IDR", ToCurrency: "IDR", Rate: 1, RateDate: now},
+			ErrInvalidRate:     {FromCurrency: "USD", ToCurrency: "IDR", Rate: -1, RateDate: now},
+			ErrMissingRateDate: {FromCurrency: "USD", ToCurrency: "IDR", Rate: 15800},
+		}
+		for expected, spec := range specs {
+			g := setup(t)
+			ctx := context.Background()
+
+			mockCurrencyAccessor.EXPECT().GetCurrencies(ctx).Return(knownCurrencies, nil)
+
+			res, err := subject.CreateExchangeRate(ctx, spec)
+			g.Expect(err).To(gomega.MatchError(expected))
+			g.Expect(res).To(gomega.BeNil())
+		}
+	})
+
+	t.Run("returns error on accessor failure", func(t *testing.T) {
+		g := setup(t)
+		ctx := context.Background()
+
+		mockCurrencyAccessor.EXPECT().GetCurrencies(ctx).Return(knownCurrencies, nil)
+		mockCurrencyAccessor.EXPECT().UpsertExchangeRates(ctx, gomock.Any()).Return(errors.New("error"))
+
+		res, err := subject.CreateExchangeRate(ctx, PostExchangeRateSpec{
+			FromCurrency: "USD",
+			ToCurrency:   "IDR",
+			Rate:         15800,
+			RateDate:     now,
+		})
+		g.Expect(err).ToNot(gomega.BeNil())
+		g.Expect(res).To(gomega.BeNil())
+	})
+}
+
+func TestCurrencyService_ImportExchangeRates(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mockCurrencyAccessor *MockcurrencyDBAccessor
+		subject              *CurrencyService
+		now                  = time.Date(2024, time.December, 6, 10, 0, 0, 0, time.UTC)
+	)
+
+	setup := func(t *testing.T) *gomega.GomegaWithT {
+		ctrl := gomock.NewController(t)
+		mockCurrencyAccessor = NewMockcurrencyDBAccessor(ctrl)
+		clockMock := clock.NewMock()
+		clockMock.Set(now)
+		subject = &CurrencyService{
+			currencyDBAccessor: mockCurrencyAccessor,
+			baseCurrency:       "IDR",
+			clock:              clockMock,
+		}
+		return gomega.NewWithT(t)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		g := setup(t)
+		ctx := context.Background()
+
+		file := strings.NewReader("rate_date,from_currency,to_currency,rate\n" +
+			"2024-03-01,USD,IDR,15800.5\n" +
+			"2024-03-01, eur ,IDR,17100\n")
+
+		mockCurrencyAccessor.EXPECT().GetCurrencies(ctx).Return(knownCurrencies, nil)
+		mockCurrencyAccessor.EXPECT().UpsertExchangeRates(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, rates []ExchangeRate) error {
+				g.Expect(rates).To(gomega.HaveLen(2))
+				g.Expect(rates[0].Rate).To(gomega.Equal(15800.5))
+				g.Expect(rates[1].FromCurrency).To(gomega.Equal("EUR"))
+				g.Expect(rates[1].RateDate).To(gomega.Equal(time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)))
+				g.Expect(rates[1].Source).To(gomega.Equal(ExchangeRateSourceCSV))
+				g.Expect(rates[1].ModifiedBy).To(gomega.Equal("admin"))
+				return nil
+			})
+
+		res, err := subject.ImportExchangeRates(ctx, file, "admin")
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(res).To(gomega.Equal(&ImportExchangeRatesResult{Imported: 2}))
+	})
+
+	t.Run("reports every invalid row and imports nothing", func(t *testing.T) {
+		g := setup(t)
+		ctx := context.Background()
+
+		file := strings.NewReader("from_currency,to_currency,rate,rate_date\n" +
+			"USD,IDR,15800,2024-03-01\n" +
+			"USD,IDR,abc,2024-03-01\n" +
+			"USD,IDR,15800,01/03/2024\n" +
+			"JPY,IDR,105,2024-03-01\n" +
+			"USD,IDR,15900,2024-03-01\n")
+
+		mockCurrencyAccessor.EXPECT().GetCurrencies(ctx).Return(knownCurrencies, nil)
+
+		res, err := subject.ImportExchangeRates(ctx, file, "admin")
+		g.Expect(res).To(gomega.BeNil())
+
+		var importErr *ImportError
+		g.Expect(errors.As(err, &importErr)).To(gomega.BeTrue())
+		g.Expect(importErr.Rows).To(gomega.Equal([]RowError{
+			{Row: 3, Message: "invalid rate: abc"},
+			{Row: 4, Message: "invalid rate_date: 01/03/2024"},
+			{Row: 5, Message: "unknown currency: JPY"},
+			{Row: 6, Message: "duplicates row 2"},
+		}))
+	})
+
+	t.Run("rejects a file without the expected header", func(t *testing.T) {
+		g := setup(t)
+		ctx := context.Background()
+
+		mockCurrencyAccessor.EXPECT().GetCurrencies(ctx).Return(knownCurrencies, nil)
+
+		res, err := subject.ImportExchangeRates(ctx, strings.NewReader("from,to,rate\nUSD,IDR,15800\n"), "admin")
+		g.Expect(err).To(gomega.MatchError(ErrInvalidImportHeader))
+		g.Expect(res).To(gomega.BeNil())
+	})
+
+	t.Run("rejects an empty file", func(t *testing.T) {
+		g := setup(t)
+		ctx := context.Background()
+
+		mockCurrencyAccessor.EXPECT().GetCurrencies(ctx).Return(knownCurrencies, nil)
+
+		res, err := subject.ImportExchangeRates(ctx, strings.NewReader("from_currency,to_currency,rate,rate_date\n"), "admin")
+		g.Expect(err).To(gomega.MatchError(ErrEmptyImport))
+		g.Expect(res).To(gomega.BeNil())
+	})
+}
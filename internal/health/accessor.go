@@ -0,0 +1,52 @@
+package health
+
+import (
+	"context"
+	"kg/procurement/internal/common/database"
+)
+
+const (
+	pingQuery = `SELECT 1`
+	// goose records every migration applied or rolled back, a version counts when its
+	// latest record is applied
+	schemaVersionQuery = `
+		SELECT COALESCE(MAX(version_id), 0)
+		FROM (
+			SELECT DISTINCT ON (version_id) version_id, is_applied
+			FROM goose_db_version
+			ORDER BY version_id, id DESC
+		) v
+		WHERE v.is_applied
+	`
+)
+
+type postgresHealthAccessor struct {
+	db database.DBConnector
+}
+
+func (p *postgresHealthAccessor) Ping(ctx context.Context) error {
+	_, err := p.db.ExecContext(ctx, pingQuery)
+	return err
+}
+
+// GetSchemaVersion reads the version of the newest migration applied, from the primary
+// since that is where migrations run
+func (p *postgresHealthAccessor) GetSchemaVersion(ctx context.Context) (int64, error) {
+	var version int64
+	if err := p.db.GetContext(database.WithPrimary(ctx), &version, schemaVersionQuery); err != nil {
+		return 0, err
+	}
+	return version, nil
+}
+
+func (p *postgresHealthAccessor) GetPoolStats() []database.PoolStats {
+	return database.GetPoolStats(p.db)
+}
+
+// newPostgresHealthAccessor is only accessible by the health package
+// entrypoint for other verticals should refer to the interface declared on service
+func newPostgresHealthAccessor(db database.DBConnector) *postgresHealthAccessor {
+	return &postgresHealthAccessor{
+		db: db,
+	}
+}
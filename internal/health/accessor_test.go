@@ -0,0 +1,85 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/onsi/gomega"
+)
+
+func Test_newPostgresHealthAccessor(t *testing.T) {
+	_ = newPostgresHealthAccessor(nil)
+}
+
+func setupHealthAccessor(t *testing.T) (*postgresHealthAccessor, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return newPostgresHealthAccessor(sqlx.NewDb(db, "sqlmock")), mock
+}
+
+func Test_Ping(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		accessor, mock := setupHealthAccessor(t)
+
+		mock.ExpectExec(regexp.QuoteMeta(pingQuery)).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		g.Expect(accessor.Ping(context.Background())).To(gomega.Succeed())
+		g.Expect(mock.ExpectationsWereMet()).To(gomega.BeNil())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		accessor, mock := setupHealthAccessor(t)
+
+		mock.ExpectExec(regexp.QuoteMeta(pingQuery)).WillReturnError(errors.New("connection refused"))
+
+		g.Expect(accessor.Ping(context.Background())).ToNot(gomega.Succeed())
+	})
+}
+
+func Test_GetSchemaVersion(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		accessor, mock := setupHealthAccessor(t)
+
+		mock.ExpectQuery(regexp.QuoteMeta(schemaVersionQuery)).
+			WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow(int64(20241210031520)))
+
+		version, err := accessor.GetSchemaVersion(context.Background())
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(version).To(gomega.Equal(int64(20241210031520)))
+	})
+
+	t.Run("error", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		accessor, mock := setupHealthAccessor(t)
+
+		mock.ExpectQuery(regexp.QuoteMeta(schemaVersionQuery)).
+			WillReturnError(errors.New(`relation "goose_db_version" does not exist`))
+
+		_, err := accessor.GetSchemaVersion(context.Background())
+		g.Expect(err).ToNot(gomega.BeNil())
+	})
+}
+
+func Test_GetPoolStats(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+	accessor, _ := setupHealthAccessor(t)
+
+	stats := accessor.GetPoolStats()
+	g.Expect(stats).To(gomega.HaveLen(1))
+	g.Expect(stats[0].Name).To(gomega.Equal("primary"))
+}
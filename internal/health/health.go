@@ -0,0 +1,33 @@
+package health
+
+import "kg/procurement/internal/common/database"
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+
+	CheckPostgres      = "postgres"
+	CheckEmailProvider = "email_provider"
+	CheckMigrations    = "migrations"
+)
+
+// Liveness tells the process is up, along with the usage of its connection pools
+type Liveness struct {
+	Status string               `json:"status"`
+	Uptime string               `json:"uptime"`
+	Pools  []database.PoolStats `json:"pools"`
+}
+
+// CheckResult is the outcome of probing a single dependency
+type CheckResult struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Error   string `json:"error,omitempty"`
+	Latency string `json:"latency"`
+}
+
+// Readiness is up when every dependency answered its check
+type Readiness struct {
+	Status string        `json:"status"`
+	Checks []CheckResult `json:"checks"`
+}
@@ -0,0 +1,136 @@
+//go:generate mockgen -typed -source=service.go -destination=service_mock.go -package=health
+package health
+
+import (
+	"context"
+	"fmt"
+	"kg/procurement/internal/common/database"
+	"kg/procurement/migrations"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+)
+
+// checkTimeout bounds each readiness check so a hanging dependency reports as down
+const checkTimeout = 3 * time.Second
+
+type healthDBAccessor interface {
+	Ping(ctx context.Context) error
+	GetSchemaVersion(ctx context.Context) (int64, error)
+	GetPoolStats() []database.PoolStats
+}
+
+// emailPinger is an email provider able to check its server without sending anything
+type emailPinger interface {
+	Ping() error
+}
+
+type HealthService struct {
+	healthDBAccessor
+	emailProvider emailPinger
+	clock         clock.Clock
+	startedAt     time.Time
+	// latestMigration is the schema version the binary expects
+	latestMigration func() (int64, error)
+}
+
+// Liveness reports the process is up, it doesn't reach any dependency
+func (s *HealthService) Liveness() Liveness {
+	return Liveness{
+		Status: StatusUp,
+		Uptime: s.clock.Since(s.startedAt).Round(time.Second).String(),
+		Pools:  s.healthDBAccessor.GetPoolStats(),
+	}
+}
+
+// Readiness checks postgres, the email provider and the schema version concurrently,
+// it is down as soon as one of them fails
+func (s *HealthService) Readiness(ctx context.Context) Readiness {
+	checks := []struct {
+		name  string
+		check func(ctx context.Context) error
+	}{
+		{CheckPostgres, s.healthDBAccessor.Ping},
+		{CheckEmailProvider, s.pingEmailProvider},
+		{CheckMigrations, s.checkSchemaVersion},
+	}
+
+	res := Readiness{Status: StatusUp, Checks: make([]CheckResult, len(checks))}
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res.Checks[i] = s.runCheck(ctx, c.name, c.check)
+		}()
+	}
+	wg.Wait()
+
+	for _, c := range res.Checks {
+		if c.Status != StatusUp {
+			res.Status = StatusDown
+		}
+	}
+	return res
+}
+
+func (s *HealthService) runCheck(ctx context.Context, name string, check func(ctx context.Context) error) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	start := s.clock.Now()
+	done := make(chan error, 1)
+	go func() { done <- check(ctx) }()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	res := CheckResult{Name: name, Status: StatusUp, Latency: s.clock.Since(start).String()}
+	if err != nil {
+		res.Status = StatusDown
+		res.Error = err.Error()
+	}
+	return res
+}
+
+// pingEmailProvider dials the email server, it can't be cancelled so runCheck stops
+// waiting on it once the check times out
+func (s *HealthService) pingEmailProvider(_ context.Context) error {
+	return s.emailProvider.Ping()
+}
+
+// checkSchemaVersion fails when the database misses migrations, a schema ahead of the
+// binary is fine as migrations are applied before a deployment rolls out
+func (s *HealthService) checkSchemaVersion(ctx context.Context) error {
+	expected, err := s.latestMigration()
+	if err != nil {
+		return err
+	}
+	current, err := s.healthDBAccessor.GetSchemaVersion(ctx)
+	if err != nil {
+		return err
+	}
+	if current < expected {
+		return fmt.Errorf("%w: %d, expected %d", migrations.ErrSchemaBehind, current, expected)
+	}
+	return nil
+}
+
+func NewHealthService(
+	conn database.DBConnector,
+	clock clock.Clock,
+	emailProvider emailPinger,
+) *HealthService {
+	return &HealthService{
+		healthDBAccessor: newPostgresHealthAccessor(conn),
+		emailProvider:    emailProvider,
+		clock:            clock,
+		startedAt:        clock.Now(),
+		latestMigration:  migrations.LatestVersion,
+	}
+}
@@ -0,0 +1,217 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+//
+// Generated by this command:
+//
+//	mockgen -typed -source=service.go -destination=service_mock.go -package=health
+//
+
+// Package health is a generated GoMock package.
+package health
+
+import (
+	context "context"
+	database "kg/procurement/internal/common/database"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockhealthDBAccessor is a mock of healthDBAccessor interface.
+type MockhealthDBAccessor struct {
+	ctrl     *gomock.Controller
+	recorder *MockhealthDBAccessorMockRecorder
+}
+
+// MockhealthDBAccessorMockRecorder is the mock recorder for MockhealthDBAccessor.
+type MockhealthDBAccessorMockRecorder struct {
+	mock *MockhealthDBAccessor
+}
+
+// NewMockhealthDBAccessor creates a new mock instance.
+func NewMockhealthDBAccessor(ctrl *gomock.Controller) *MockhealthDBAccessor {
+	mock := &MockhealthDBAccessor{ctrl: ctrl}
+	mock.recorder = &MockhealthDBAccessorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockhealthDBAccessor) EXPECT() *MockhealthDBAccessorMockRecorder {
+	return m.recorder
+}
+
+// GetPoolStats mocks base method.
+func (m *MockhealthDBAccessor) GetPoolStats() []database.PoolStats {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPoolStats")
+	ret0, _ := ret[0].([]database.PoolStats)
+	return ret0
+}
+
+// GetPoolStats indicates an expected call of GetPoolStats.
+func (mr *MockhealthDBAccessorMockRecorder) GetPoolStats() *MockhealthDBAccessorGetPoolStatsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPoolStats", reflect.TypeOf((*MockhealthDBAccessor)(nil).GetPoolStats))
+	return &MockhealthDBAccessorGetPoolStatsCall{Call: call}
+}
+
+// MockhealthDBAccessorGetPoolStatsCall wrap *gomock.Call
+type MockhealthDBAccessorGetPoolStatsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockhealthDBAccessorGetPoolStatsCall) Return(arg0 []database.PoolStats) *MockhealthDBAccessorGetPoolStatsCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockhealthDBAccessorGetPoolStatsCall) Do(f func() []database.PoolStats) *MockhealthDBAccessorGetPoolStatsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockhealthDBAccessorGetPoolStatsCall) DoAndReturn(f func() []database.PoolStats) *MockhealthDBAccessorGetPoolStatsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetSchemaVersion mocks base method.
+func (m *MockhealthDBAccessor) GetSchemaVersion(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchemaVersion", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSchemaVersion indicates an expected call of GetSchemaVersion.
+func (mr *MockhealthDBAccessorMockRecorder) GetSchemaVersion(ctx any) *MockhealthDBAccessorGetSchemaVersionCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchemaVersion", reflect.TypeOf((*MockhealthDBAccessor)(nil).GetSchemaVersion), ctx)
+	return &MockhealthDBAccessorGetSchemaVersionCall{Call: call}
+}
+
+// MockhealthDBAccessorGetSchemaVersionCall wrap *gomock.Call
+type MockhealthDBAccessorGetSchemaVersionCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockhealthDBAccessorGetSchemaVersionCall) Return(arg0 int64, arg1 error) *MockhealthDBAccessorGetSchemaVersionCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockhealthDBAccessorGetSchemaVersionCall) Do(f func(context.Context) (int64, error)) *MockhealthDBAccessorGetSchemaVersionCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockhealthDBAccessorGetSchemaVersionCall) DoAndReturn(f func(context.Context) (int64, error)) *MockhealthDBAccessorGetSchemaVersionCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Ping mocks base method.
+func (m *MockhealthDBAccessor) Ping(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockhealthDBAccessorMockRecorder) Ping(ctx any) *MockhealthDBAccessorPingCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockhealthDBAccessor)(nil).Ping), ctx)
+	return &MockhealthDBAccessorPingCall{Call: call}
+}
+
+// MockhealthDBAccessorPingCall wrap *gomock.Call
+type MockhealthDBAccessorPingCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockhealthDBAccessorPingCall) Return(arg0 error) *MockhealthDBAccessorPingCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockhealthDBAccessorPingCall) Do(f func(context.Context) error) *MockhealthDBAccessorPingCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockhealthDBAccessorPingCall) DoAndReturn(f func(context.Context) error) *MockhealthDBAccessorPingCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockemailPinger is a mock of emailPinger interface.
+type MockemailPinger struct {
+	ctrl     *gomock.Controller
+	recorder *MockemailPingerMockRecorder
+}
+
+// MockemailPingerMockRecorder is the mock recorder for MockemailPinger.
+type MockemailPingerMockRecorder struct {
+	mock *MockemailPinger
+}
+
+// NewMockemailPinger creates a new mock instance.
+func NewMockemailPinger(ctrl *gomock.Controller) *MockemailPinger {
+	mock := &MockemailPinger{ctrl: ctrl}
+	mock.recorder = &MockemailPingerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockemailPinger) EXPECT() *MockemailPingerMockRecorder {
+	return m.recorder
+}
+
+// Ping mocks base method.
+func (m *MockemailPinger) Ping() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockemailPingerMockRecorder) Ping() *MockemailPingerPingCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockemailPinger)(nil).Ping))
+	return &MockemailPingerPingCall{Call: call}
+}
+
+// MockemailPingerPingCall wrap *gomock.Call
+type MockemailPingerPingCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockemailPingerPingCall) Return(arg0 error) *MockemailPingerPingCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockemailPingerPingCall) Do(f func() error) *MockemailPingerPingCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockemailPingerPingCall) DoAndReturn(f func() error) *MockemailPingerPingCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
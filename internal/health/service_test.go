@@ -0,0 +1,135 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"kg/procurement/internal/common/database"
+	"kg/procurement/migrations"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_NewHealthService(t *testing.T) {
+	_ = NewHealthService(nil, clock.NewMock(), nil)
+}
+
+func TestHealthService_Liveness(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+	ctrl := gomock.NewController(t)
+	mockAccessor := NewMockhealthDBAccessor(ctrl)
+	clockMock := clock.NewMock()
+	subject := &HealthService{
+		healthDBAccessor: mockAccessor,
+		clock:            clockMock,
+		startedAt:        clockMock.Now(),
+	}
+
+	pools := []database.PoolStats{{Name: "primary", Healthy: true, OpenConnections: 3}}
+	mockAccessor.EXPECT().GetPoolStats().Return(pools)
+	clockMock.Add(90 * time.Second)
+
+	res := subject.Liveness()
+	g.Expect(res).To(gomega.Equal(Liveness{Status: StatusUp, Uptime: "1m30s", Pools: pools}))
+}
+
+func TestHealthService_Readiness(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mockAccessor *MockhealthDBAccessor
+		mockEmail    *MockemailPinger
+		subject      *HealthService
+	)
+
+	setup := func(t *testing.T, latest int64) *gomega.GomegaWithT {
+		ctrl := gomock.NewController(t)
+		mockAccessor = NewMockhealthDBAccessor(ctrl)
+		mockEmail = NewMockemailPinger(ctrl)
+		subject = &HealthService{
+			healthDBAccessor: mockAccessor,
+			emailProvider:    mockEmail,
+			clock:            clock.NewMock(),
+			latestMigration:  func() (int64, error) { return latest, nil },
+		}
+		return gomega.NewWithT(t)
+	}
+	statuses := func(res Readiness) map[string]string {
+		m := map[string]string{}
+		for _, c := range res.Checks {
+			m[c.Name] = c.Status
+		}
+		return m
+	}
+
+	t.Run("up when every dependency answers", func(t *testing.T) {
+		g := setup(t, 20241210031520)
+		ctx := context.Background()
+
+		mockAccessor.EXPECT().Ping(gomock.Any()).Return(nil)
+		mockEmail.EXPECT().Ping().Return(nil)
+		mockAccessor.EXPECT().GetSchemaVersion(gomock.Any()).Return(int64(20241210031520), nil)
+
+		res := subject.Readiness(ctx)
+		g.Expect(res.Status).To(gomega.Equal(StatusUp))
+		g.Expect(statuses(res)).To(gomega.Equal(map[string]string{
+			CheckPostgres:      StatusUp,
+			CheckEmailProvider: StatusUp,
+			CheckMigrations:    StatusUp,
+		}))
+	})
+
+	t.Run("down when postgres or the email provider fails", func(t *testing.T) {
+		g := setup(t, 1)
+		ctx := context.Background()
+
+		mockAccessor.EXPECT().Ping(gomock.Any()).Return(errors.New("connection refused"))
+		mockEmail.EXPECT().Ping().Return(errors.New("535 authentication failed"))
+		mockAccessor.EXPECT().GetSchemaVersion(gomock.Any()).Return(int64(2), nil)
+
+		res := subject.Readiness(ctx)
+		g.Expect(res.Status).To(gomega.Equal(StatusDown))
+		g.Expect(statuses(res)).To(gomega.Equal(map[string]string{
+			CheckPostgres:      StatusDown,
+			CheckEmailProvider: StatusDown,
+			CheckMigrations:    StatusUp,
+		}))
+		g.Expect(res.Checks[0].Error).To(gomega.Equal("connection refused"))
+	})
+
+	t.Run("down when the schema is behind", func(t *testing.T) {
+		g := setup(t, 20241210031520)
+		ctx := context.Background()
+
+		mockAccessor.EXPECT().Ping(gomock.Any()).Return(nil)
+		mockEmail.EXPECT().Ping().Return(nil)
+		mockAccessor.EXPECT().GetSchemaVersion(gomock.Any()).Return(int64(20241209024012), nil)
+
+		res := subject.Readiness(ctx)
+		g.Expect(res.Status).To(gomega.Equal(StatusDown))
+		g.Expect(res.Checks[2].Error).To(gomega.ContainSubstring(migrations.ErrSchemaBehind.Error()))
+	})
+
+	t.Run("down when a check outlives the request", func(t *testing.T) {
+		g := setup(t, 1)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		release := make(chan struct{})
+		defer close(release)
+		mockAccessor.EXPECT().Ping(gomock.Any()).Return(nil).AnyTimes()
+		mockEmail.EXPECT().Ping().DoAndReturn(func() error {
+			<-release
+			return nil
+		}).AnyTimes()
+		mockAccessor.EXPECT().GetSchemaVersion(gomock.Any()).Return(int64(1), nil).AnyTimes()
+
+		res := subject.Readiness(ctx)
+		g.Expect(res.Status).To(gomega.Equal(StatusDown))
+		g.Expect(res.Checks[1].Error).To(gomega.Equal(context.Canceled.Error()))
+	})
+}
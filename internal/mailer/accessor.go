@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"kg/procurement/cmd/utils"
+	"kg/procurement/internal/audit"
 	"kg/procurement/internal/common/database"
 	"log"
 	"strings"
@@ -14,15 +15,25 @@ import (
 const (
 	insertEmailStatus = `
 		INSERT INTO email_status
-			(id, email_to, status, vendor_id, date_sent, modified_date)
+			(id, email_to, status, vendor_id, date_sent, modified_date, modified_by)
 		VALUES
-			(:id, :email_to, :status, :vendor_id, :date_sent, :modified_date)
+			(:id, :email_to, :status, :vendor_id, :date_sent, :modified_date, :modified_by)
+	`
+	// the seeder upserts by id so fixtures can be loaded again
+	upsertEmailStatus = insertEmailStatus + `
+		ON CONFLICT (id) DO UPDATE SET
+			email_to = EXCLUDED.email_to,
+			status = EXCLUDED.status,
+			vendor_id = EXCLUDED.vendor_id,
+			date_sent = EXCLUDED.date_sent,
+			modified_date = EXCLUDED.modified_date,
+			modified_by = EXCLUDED.modified_by
 	`
 	updateEmailStatus = `
 		UPDATE email_status
-		SET status = :status, modified_date = :modified_date
+		SET status = :status, modified_date = :modified_date, modified_by = :modified_by
 		WHERE id = :id
-		RETURNING id, email_to, status, vendor_id, date_sent, modified_date
+		RETURNING id, email_to, status, vendor_id, date_sent, modified_date, modified_by
 	`
 )
 
@@ -31,18 +42,38 @@ type postgresEmailStatusAccessor struct {
 	clock clock.Clock
 }
 
-func (p *postgresEmailStatusAccessor) WriteEmailStatus(_ context.Context, es EmailStatus) error {
-	if _, err := p.db.NamedExec(insertEmailStatus, es); err != nil {
-		log.Printf("error writing email status: %v", err)
+func (p *postgresEmailStatusAccessor) WriteEmailStatus(ctx context.Context, es EmailStatus) error {
+	return p.audited(ctx, es.ID, es.ModifiedBy, func(tx *postgresEmailStatusAccessor) error {
+		if _, err := tx.db.NamedExecContext(ctx, insertEmailStatus, es); err != nil {
+			log.Printf("error writing email status: %v", err)
+			return err
+		}
+		return nil
+	})
+}
+
+func (p *postgresEmailStatusAccessor) writeEmailStatus(ctx context.Context, es EmailStatus) error {
+	if _, err := p.db.NamedExecContext(ctx, upsertEmailStatus, es); err != nil {
+		utils.Logger.Errorf("failed upserting email status: %s", es.ID)
 		return err
 	}
 	return nil
 }
 
-func (p *postgresEmailStatusAccessor) UpdateEmailStatus(_ context.Context, es EmailStatus) (*EmailStatus, error) {
+func (p *postgresEmailStatusAccessor) UpdateEmailStatus(ctx context.Context, es EmailStatus) (*EmailStatus, error) {
+	var updated *EmailStatus
+	err := p.audited(ctx, es.ID, es.ModifiedBy, func(tx *postgresEmailStatusAccessor) error {
+		var err error
+		updated, err = tx.updateEmailStatusRow(ctx, es)
+		return err
+	})
+	return updated, err
+}
+
+func (p *postgresEmailStatusAccessor) updateEmailStatusRow(ctx context.Context, es EmailStatus) (*EmailStatus, error) {
 	es.ModifiedDate = p.clock.Now()
 	var updatedEmailStatus EmailStatus
-	rows, err := p.db.NamedQuery(updateEmailStatus, es)
+	rows, err := p.db.NamedQueryContext(ctx, updateEmailStatus, es)
 	if err != nil {
 		utils.Logger.Errorf("error updating email status: %v", err)
 		return nil, err
@@ -83,6 +114,10 @@ func (p *postgresEmailStatusAccessor) GetAll(ctx context.Context, spec GetAllEma
 		argsIndex++
 	}
 
+	if spec.IsCursor() {
+		return p.getAllByCursor(ctx, spec.PaginationSpec, whereClauses, args, argsIndex)
+	}
+
 	// Set order by default value
 	if paginationArgs.OrderBy == "" {
 		paginationArgs.OrderBy = "es.modified_date"
@@ -128,7 +163,7 @@ func (p *postgresEmailStatusAccessor) GetAll(ctx context.Context, spec GetAllEma
     `, joinClause, whereClause, extraClause)
 
 	// Execute the query
-	rows, err := p.db.Queryx(dataQuery, args...)
+	rows, err := p.db.QueryxContext(ctx, dataQuery, args...)
 	if err != nil {
 		utils.Logger.Error(err.Error())
 		return nil, err
@@ -155,7 +190,7 @@ func (p *postgresEmailStatusAccessor) GetAll(ctx context.Context, spec GetAllEma
 		countQuery += " " + whereClause
 	}
 	totalEntries := 0
-	err = p.db.QueryRow(countQuery, countArgs...).Scan(&totalEntries)
+	err = p.db.QueryRowContext(ctx, countQuery, countArgs...).Scan(&totalEntries)
 	if err != nil {
 		utils.Logger.Error(err.Error())
 		return nil, err
@@ -167,10 +202,115 @@ func (p *postgresEmailStatusAccessor) GetAll(ctx context.Context, spec GetAllEma
 	return &AccessorGetEmailStatusPaginationData{EmailStatus: emailStatus, Metadata: metadata}, nil
 }
 
+// emailStatusSortableColumns maps the order_by fields accepted in cursor mode
+var emailStatusSortableColumns = map[string]string{
+	"id":            "es.id",
+	"email_to":      "es.email_to",
+	"status":        "es.status",
+	"date_sent":     "es.date_sent",
+	"modified_date": "es.modified_date",
+}
+
+// emailStatusCursorRow carries the sort column value used to build the cursors
+type emailStatusCursorRow struct {
+	EmailStatus
+	CursorValue interface{} `db:"cursor_value"`
+}
+
+// getAllByCursor runs the email status listing in keyset mode on top of the
+// filters built by GetAll, the count query is skipped since cursors don't need it
+func (p *postgresEmailStatusAccessor) getAllByCursor(
+	ctx context.Context,
+	spec database.PaginationSpec,
+	whereClauses []string,
+	args []interface{},
+	argsIndex int,
+) (*AccessorGetEmailStatusPaginationData, error) {
+	keysetArgs, err := database.BuildKeysetArgs(spec, "modified_date", "es.id", emailStatusSortableColumns)
+	if err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	condition := keysetArgs.Condition(fmt.Sprintf("$%d", argsIndex), fmt.Sprintf("$%d", argsIndex+1))
+	if condition != "" {
+		whereClauses = append(whereClauses, condition)
+		args = append(args, keysetArgs.Cursor.Value, keysetArgs.Cursor.ID)
+		argsIndex += 2
+	}
+	args = append(args, keysetArgs.FetchLimit())
+
+	whereClause := ""
+	if len(whereClauses) > 0 {
+		whereClause = "WHERE " + strings.Join(whereClauses, " AND ")
+	}
+
+	dataQuery := fmt.Sprintf(`
+		SELECT
+			es.id,
+			es.email_to,
+			es.status,
+			es.modified_date,
+			es.vendor_id,
+			es.date_sent,
+			%s AS %s
+		FROM email_status es
+		%s
+		%s
+		LIMIT $%d
+	`, keysetArgs.Column, database.CursorValueColumn, whereClause, keysetArgs.OrderByClause(), argsIndex)
+
+	rows, err := p.db.QueryxContext(ctx, dataQuery, args...)
+	if err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	cursorRows := []emailStatusCursorRow{}
+	for rows.Next() {
+		var row emailStatusCursorRow
+		if err := rows.StructScan(&row); err != nil {
+			return nil, err
+		}
+		cursorRows = append(cursorRows, row)
+	}
+	if err := rows.Err(); err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	cursorRows, metadata := database.GenerateCursorMetadata(keysetArgs, cursorRows, func(row emailStatusCursorRow) database.Cursor {
+		return database.Cursor{Value: row.CursorValue, ID: row.ID}
+	})
+
+	emailStatus := make([]EmailStatus, 0, len(cursorRows))
+	for _, row := range cursorRows {
+		emailStatus = append(emailStatus, row.EmailStatus)
+	}
+
+	return &AccessorGetEmailStatusPaginationData{EmailStatus: emailStatus, Metadata: metadata}, nil
+}
+
 func (p *postgresEmailStatusAccessor) Close() error {
 	return p.db.Close()
 }
 
+// runInTx runs fn as a unit of work, the accessor fn gets writes in its transaction
+func (p *postgresEmailStatusAccessor) runInTx(ctx context.Context, fn func(tx emailStatusDBAccessor) error) error {
+	return database.RunInTx(ctx, p.db, func(tx database.DBConnector) error {
+		return fn(newPostgresEmailStatusAccessor(tx, p.clock))
+	})
+}
+
+// audited runs write on an accessor of its transaction, recording the changes it makes
+// to the email status on the audit log. actor is who sent the email or updated its status
+func (p *postgresEmailStatusAccessor) audited(ctx context.Context, id, actor string, write func(tx *postgresEmailStatusAccessor) error) error {
+	return audit.Track(ctx, p.db, p.clock, audit.EntityEmailStatus, []string{id}, actor, func(tx database.DBConnector) error {
+		return write(newPostgresEmailStatusAccessor(tx, p.clock))
+	})
+}
+
 // newPostgresEmailStatusAccessor is only accessible by the mailer package
 // entrypoint for other verticals should refer to the interface declared on service
 func newPostgresEmailStatusAccessor(db database.DBConnector, clock clock.Clock) *postgresEmailStatusAccessor {
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"fmt"
+	"kg/procurement/internal/audit"
 	"kg/procurement/internal/common/database"
 	"log"
 	"regexp"
@@ -14,6 +15,7 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/benbjohnson/clock"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/onsi/gomega"
 )
 
@@ -45,12 +47,15 @@ func Test_WriteEmailStatus(t *testing.T) {
 			driverArgs[i] = arg
 		}
 
-		c.mock.ExpectExec(regexp.QuoteMeta(transformedQuery)).WithArgs(
-			driverArgs...,
-		).WillReturnResult(sqlmock.NewResult(1, 1))
+		expectAudited(c.mock, "123", "", `{"id":"123","status":"sent"}`, func() {
+			c.mock.ExpectExec(regexp.QuoteMeta(transformedQuery)).WithArgs(
+				driverArgs...,
+			).WillReturnResult(sqlmock.NewResult(1, 1))
+		})
 
 		err := c.accessor.WriteEmailStatus(ctx, emailStatus)
 		c.g.Expect(err).Should(gomega.BeNil())
+		c.g.Expect(c.mock.ExpectationsWereMet()).Should(gomega.Succeed())
 	})
 
 	t.Run("returns error on db failure", func(t *testing.T) {
@@ -83,6 +88,52 @@ func Test_WriteEmailStatus(t *testing.T) {
 	})
 }
 
+func Test_writeEmailStatus(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		var (
+			ctx         = context.Background()
+			c           = setupEmailStatusAccessorTestComponent(t, WithQueryMatcher(sqlmock.QueryMatcherRegexp))
+			emailStatus = EmailStatus{ID: "123", VendorID: "100", Status: "sent"}
+		)
+
+		transformedQuery, args, _ := sqlx.Named(upsertEmailStatus, emailStatus)
+		driverArgs := make([]driver.Value, len(args))
+		for i, arg := range args {
+			driverArgs[i] = arg
+		}
+
+		c.mock.ExpectExec(regexp.QuoteMeta(transformedQuery)).WithArgs(
+			driverArgs...,
+		).WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err := c.accessor.writeEmailStatus(ctx, emailStatus)
+		c.g.Expect(err).Should(gomega.BeNil())
+	})
+
+	t.Run("returns error on db failure", func(t *testing.T) {
+		var (
+			ctx         = context.Background()
+			c           = setupEmailStatusAccessorTestComponent(t, WithQueryMatcher(sqlmock.QueryMatcherRegexp))
+			emailStatus = EmailStatus{ID: "123", VendorID: "100", Status: "sent"}
+		)
+
+		transformedQuery, args, _ := sqlx.Named(upsertEmailStatus, emailStatus)
+		driverArgs := make([]driver.Value, len(args))
+		for i, arg := range args {
+			driverArgs[i] = arg
+		}
+
+		c.mock.ExpectExec(regexp.QuoteMeta(transformedQuery)).WithArgs(
+			driverArgs...,
+		).WillReturnError(sql.ErrConnDone)
+
+		err := c.accessor.writeEmailStatus(ctx, emailStatus)
+		c.g.Expect(err).To(gomega.Equal(sql.ErrConnDone))
+	})
+}
+
 func Test_GetAll(t *testing.T) {
 	t.Parallel()
 
@@ -522,12 +573,15 @@ func Test_UpdateEmailStatus(t *testing.T) {
 		rows := sqlmock.NewRows([]string{"id", "vendor_id", "email_to", "status", "date_sent", "modified_date"}).
 			AddRow(emailStatus.ID, emailStatus.VendorID, emailStatus.EmailTo, emailStatus.Status, emailStatus.DateSent, emailStatus.ModifiedDate)
 
-		c.mock.ExpectQuery(regexp.QuoteMeta(transformedQuery)).WithArgs(
-			driverArgs...,
-		).WillReturnRows(rows)
+		expectAudited(c.mock, "123", `{"id":"123","status":"failed"}`, `{"id":"123","status":"sent"}`, func() {
+			c.mock.ExpectQuery(regexp.QuoteMeta(transformedQuery)).WithArgs(
+				driverArgs...,
+			).WillReturnRows(rows)
+		})
 
 		updatedEmailStatus, err := c.accessor.UpdateEmailStatus(ctx, emailStatus)
 		c.g.Expect(err).Should(gomega.BeNil())
+		c.g.Expect(c.mock.ExpectationsWereMet()).Should(gomega.Succeed())
 		c.g.Expect(updatedEmailStatus).ShouldNot(gomega.BeNil())
 		c.g.Expect(updatedEmailStatus.ID).Should(gomega.Equal(emailStatus.ID))
 		c.g.Expect(updatedEmailStatus.EmailTo).Should(gomega.Equal(emailStatus.EmailTo))
@@ -599,6 +653,29 @@ func Test_UpdateEmailStatus(t *testing.T) {
 	})
 }
 
+// expectAudited expects the writes of an audited mutation between the snapshots the
+// audit log takes of the email status, an entry is recorded when before and after differ
+func expectAudited(mock sqlmock.Sqlmock, id string, before string, after string, writes func()) {
+	snapshot := func(row string) {
+		rows := sqlmock.NewRows([]string{"id", "row"})
+		if row != "" {
+			rows.AddRow(id, []byte(row))
+		}
+		mock.ExpectQuery(regexp.QuoteMeta(audit.SnapshotQuery(audit.EntityEmailStatus))).
+			WithArgs(pq.Array([]string{id})).
+			WillReturnRows(rows)
+	}
+
+	mock.ExpectBegin()
+	snapshot(before)
+	writes()
+	snapshot(after)
+	if before != after {
+		mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	mock.ExpectCommit()
+}
+
 type emailStatusAccessorTestComponent struct {
 	g        *gomega.WithT
 	mock     sqlmock.Sqlmock
@@ -642,3 +719,71 @@ func setupEmailStatusAccessorTestComponent(t *testing.T, opts ...Option) emailSt
 		cmock:    clockMock,
 	}
 }
+
+func Test_GetAll_WithCursor(t *testing.T) {
+	t.Parallel()
+
+	modifiedDate := time.Date(2024, time.September, 23, 12, 30, 0, 0, time.UTC)
+
+	t.Run("success", func(t *testing.T) {
+		c := setupEmailStatusAccessorTestComponent(t)
+		defer c.db.Close()
+
+		query := `
+			SELECT
+				es.id,
+				es.email_to,
+				es.status,
+				es.modified_date,
+				es.vendor_id,
+				es.date_sent,
+				es.modified_date AS cursor_value
+			FROM email_status es
+			WHERE es.email_to ILIKE $1 AND (es.modified_date < $2 OR (es.modified_date IS NOT DISTINCT FROM $2 AND es.id < $3) OR (es.modified_date IS NOT NULL AND $2 IS NULL))
+			ORDER BY es.modified_date DESC, es.id DESC
+			LIMIT $4
+		`
+		rows := sqlmock.NewRows([]string{"id", "email_to", "modified_date", "cursor_value"}).
+			AddRow("2", "vendor@example.com", modifiedDate, modifiedDate)
+		c.mock.ExpectQuery(query).
+			WithArgs("%vendor%", "2024-09-24T00:00:00Z", "3", 11).
+			WillReturnRows(rows)
+
+		res, err := c.accessor.GetAll(context.Background(), GetAllEmailStatusSpec{
+			EmailTo: "vendor",
+			PaginationSpec: database.PaginationSpec{
+				Order: "DESC",
+				Cursor: database.EncodeCursor(database.Cursor{
+					Value: time.Date(2024, time.September, 24, 0, 0, 0, 0, time.UTC),
+					ID:    "3",
+				}),
+			},
+		})
+
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(res.EmailStatus).To(gomega.Equal([]EmailStatus{{
+			ID:           "2",
+			EmailTo:      "vendor@example.com",
+			ModifiedDate: modifiedDate,
+		}}))
+		c.g.Expect(res.Metadata.NextCursor).To(gomega.BeEmpty())
+		c.g.Expect(res.Metadata.PrevCursor).To(gomega.Equal(
+			database.EncodeCursor(database.Cursor{Value: modifiedDate, ID: "2", Backward: true}),
+		))
+	})
+
+	t.Run("error on multiple sort columns", func(t *testing.T) {
+		c := setupEmailStatusAccessorTestComponent(t)
+		defer c.db.Close()
+
+		res, err := c.accessor.GetAll(context.Background(), GetAllEmailStatusSpec{
+			PaginationSpec: database.PaginationSpec{
+				Mode:    database.PaginationModeCursor,
+				OrderBy: "status,modified_date",
+			},
+		})
+
+		c.g.Expect(err).To(gomega.Equal(database.ErrCursorMultiColumn))
+		c.g.Expect(res).To(gomega.BeNil())
+	})
+}
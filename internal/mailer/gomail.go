@@ -14,6 +14,7 @@ import (
 // Dialer is an interface for sending emails.
 type Dialer interface {
 	DialAndSend(m ...*gomail.Message) error
+	Dial() (gomail.SendCloser, error)
 }
 
 type gomailSMTP struct {
@@ -31,6 +32,15 @@ func (g gomailSMTP) SendEmail(email Email) error {
 	return nil
 }
 
+// Ping checks the SMTP server accepts our credentials by dialing and hanging up
+func (g gomailSMTP) Ping() error {
+	sender, err := g.dialer.Dial()
+	if err != nil {
+		return err
+	}
+	return sender.Close()
+}
+
 func (gomailSMTP) buildEmailPayload(email Email) *gomail.Message {
 	m := gomail.NewMessage()
 
@@ -20,7 +20,6 @@ import (
 type MockDialer struct {
 	ctrl     *gomock.Controller
 	recorder *MockDialerMockRecorder
-	isgomock struct{}
 }
 
 // MockDialerMockRecorder is the mock recorder for MockDialer.
@@ -40,6 +39,45 @@ func (m *MockDialer) EXPECT() *MockDialerMockRecorder {
 	return m.recorder
 }
 
+// Dial mocks base method.
+func (m *MockDialer) Dial() (gomail.SendCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Dial")
+	ret0, _ := ret[0].(gomail.SendCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Dial indicates an expected call of Dial.
+func (mr *MockDialerMockRecorder) Dial() *MockDialerDialCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Dial", reflect.TypeOf((*MockDialer)(nil).Dial))
+	return &MockDialerDialCall{Call: call}
+}
+
+// MockDialerDialCall wrap *gomock.Call
+type MockDialerDialCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockDialerDialCall) Return(arg0 gomail.SendCloser, arg1 error) *MockDialerDialCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockDialerDialCall) Do(f func() (gomail.SendCloser, error)) *MockDialerDialCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockDialerDialCall) DoAndReturn(f func() (gomail.SendCloser, error)) *MockDialerDialCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // DialAndSend mocks base method.
 func (m_2 *MockDialer) DialAndSend(m ...*gomail.Message) error {
 	m_2.ctrl.T.Helper()
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/base64"
 	"errors"
+	"io"
 	"kg/procurement/cmd/config"
 	"testing"
 
@@ -113,3 +114,43 @@ func Test_GomailSendEmail(t *testing.T) {
 		g.Expect(err).ToNot(gomega.BeNil())
 	})
 }
+
+// sendCloser is a gomail connection that only records it was closed
+type sendCloser struct {
+	closed bool
+}
+
+func (s *sendCloser) Send(_ string, _ []string, _ io.WriterTo) error {
+	return nil
+}
+
+func (s *sendCloser) Close() error {
+	s.closed = true
+	return nil
+}
+
+func Test_GomailPing(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		ctrl := gomock.NewController(t)
+		mockDialer := NewMockDialer(ctrl)
+		gm := &gomailSMTP{dialer: mockDialer}
+
+		sender := &sendCloser{}
+		mockDialer.EXPECT().Dial().Return(sender, nil)
+
+		g.Expect(gm.Ping()).To(gomega.Succeed())
+		g.Expect(sender.closed).To(gomega.BeTrue())
+	})
+
+	t.Run("returns the dial error", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		ctrl := gomock.NewController(t)
+		mockDialer := NewMockDialer(ctrl)
+		gm := &gomailSMTP{dialer: mockDialer}
+
+		mockDialer.EXPECT().Dial().Return(nil, errors.New("535 authentication failed"))
+
+		g.Expect(gm.Ping()).To(gomega.MatchError("535 authentication failed"))
+	})
+}
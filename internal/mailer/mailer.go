@@ -58,6 +58,7 @@ type EmailStatus struct {
 	VendorID     string    `db:"vendor_id" json:"vendor_id"`
 	DateSent     time.Time `db:"date_sent" json:"date_sent"`
 	ModifiedDate time.Time `db:"modified_date" json:"modified_date"`
+	ModifiedBy   string    `db:"modified_by" json:"modified_by"`
 }
 
 type EmailProvider interface {
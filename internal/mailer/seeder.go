@@ -0,0 +1,44 @@
+//go:generate mockgen -typed -source=seeder.go -destination=seeder_mock.go -package=mailer
+package mailer
+
+import (
+	"context"
+	"kg/procurement/internal/common/database"
+
+	"github.com/benbjohnson/clock"
+)
+
+type seederDataWriter interface {
+	writeEmailStatus(ctx context.Context, es EmailStatus) error
+	Close() error
+}
+
+type Seeder struct {
+	seederDataWriter
+}
+
+func (s *Seeder) SetupEmailStatuses(ctx context.Context, statuses []EmailStatus) error {
+	for _, es := range statuses {
+		if err := s.seederDataWriter.writeEmailStatus(ctx, es); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Seeder) Close() error {
+	return s.seederDataWriter.Close()
+}
+
+func NewSeeder(
+	seederDataWriter seederDataWriter,
+) *Seeder {
+	return &Seeder{seederDataWriter}
+}
+
+func NewDBSeederWriter(
+	dbClient database.DBConnector,
+	clock clock.Clock,
+) seederDataWriter {
+	return newPostgresEmailStatusAccessor(dbClient, clock)
+}
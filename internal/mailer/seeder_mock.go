@@ -0,0 +1,116 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: seeder.go
+//
+// Generated by this command:
+//
+//	mockgen -typed -source=seeder.go -destination=seeder_mock.go -package=mailer
+//
+
+// Package mailer is a generated GoMock package.
+package mailer
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockseederDataWriter is a mock of seederDataWriter interface.
+type MockseederDataWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockseederDataWriterMockRecorder
+}
+
+// MockseederDataWriterMockRecorder is the mock recorder for MockseederDataWriter.
+type MockseederDataWriterMockRecorder struct {
+	mock *MockseederDataWriter
+}
+
+// NewMockseederDataWriter creates a new mock instance.
+func NewMockseederDataWriter(ctrl *gomock.Controller) *MockseederDataWriter {
+	mock := &MockseederDataWriter{ctrl: ctrl}
+	mock.recorder = &MockseederDataWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockseederDataWriter) EXPECT() *MockseederDataWriterMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockseederDataWriter) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockseederDataWriterMockRecorder) Close() *MockseederDataWriterCloseCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockseederDataWriter)(nil).Close))
+	return &MockseederDataWriterCloseCall{Call: call}
+}
+
+// MockseederDataWriterCloseCall wrap *gomock.Call
+type MockseederDataWriterCloseCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockseederDataWriterCloseCall) Return(arg0 error) *MockseederDataWriterCloseCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockseederDataWriterCloseCall) Do(f func() error) *MockseederDataWriterCloseCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockseederDataWriterCloseCall) DoAndReturn(f func() error) *MockseederDataWriterCloseCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// writeEmailStatus mocks base method.
+func (m *MockseederDataWriter) writeEmailStatus(ctx context.Context, es EmailStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "writeEmailStatus", ctx, es)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// writeEmailStatus indicates an expected call of writeEmailStatus.
+func (mr *MockseederDataWriterMockRecorder) writeEmailStatus(ctx, es any) *MockseederDataWriterwriteEmailStatusCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "writeEmailStatus", reflect.TypeOf((*MockseederDataWriter)(nil).writeEmailStatus), ctx, es)
+	return &MockseederDataWriterwriteEmailStatusCall{Call: call}
+}
+
+// MockseederDataWriterwriteEmailStatusCall wrap *gomock.Call
+type MockseederDataWriterwriteEmailStatusCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockseederDataWriterwriteEmailStatusCall) Return(arg0 error) *MockseederDataWriterwriteEmailStatusCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockseederDataWriterwriteEmailStatusCall) Do(f func(context.Context, EmailStatus) error) *MockseederDataWriterwriteEmailStatusCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockseederDataWriterwriteEmailStatusCall) DoAndReturn(f func(context.Context, EmailStatus) error) *MockseederDataWriterwriteEmailStatusCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -0,0 +1,79 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_NewSeeder(t *testing.T) {
+	_ = NewSeeder(nil)
+}
+
+func Test_NewDBSeederWriter(t *testing.T) {
+	_ = NewDBSeederWriter(nil, nil)
+}
+
+func Test_Seeder(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	var (
+		mockWriter *MockseederDataWriter
+		subject    *Seeder
+	)
+
+	setup := func(t *testing.T) *gomega.GomegaWithT {
+		ctrl := gomock.NewController(t)
+
+		mockWriter = NewMockseederDataWriter(ctrl)
+		subject = NewSeeder(mockWriter)
+		return gomega.NewWithT(t)
+	}
+
+	t.Run("setup email statuses", func(t *testing.T) {
+		statuses := []EmailStatus{{ID: "1"}, {ID: "2"}}
+
+		t.Run("success", func(t *testing.T) {
+			g := setup(t)
+
+			mockWriter.EXPECT().writeEmailStatus(ctx, EmailStatus{ID: "1"})
+			mockWriter.EXPECT().writeEmailStatus(ctx, EmailStatus{ID: "2"})
+
+			err := subject.SetupEmailStatuses(ctx, statuses)
+			g.Expect(err).ShouldNot(gomega.HaveOccurred())
+		})
+
+		t.Run("error", func(t *testing.T) {
+			g := setup(t)
+
+			mockWriter.EXPECT().writeEmailStatus(ctx, EmailStatus{ID: "1"}).Return(errors.New("error"))
+
+			err := subject.SetupEmailStatuses(ctx, statuses)
+			g.Expect(err).Should(gomega.HaveOccurred())
+		})
+	})
+
+	t.Run("Close", func(t *testing.T) {
+		t.Run("success", func(t *testing.T) {
+			g := setup(t)
+
+			mockWriter.EXPECT().Close()
+
+			err := subject.Close()
+			g.Expect(err).ShouldNot(gomega.HaveOccurred())
+		})
+
+		t.Run("error", func(t *testing.T) {
+			g := setup(t)
+
+			mockWriter.EXPECT().Close().Return(errors.New("error"))
+
+			err := subject.Close()
+			g.Expect(err).Should(gomega.HaveOccurred())
+		})
+	})
+}
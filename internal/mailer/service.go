@@ -9,6 +9,7 @@ import (
 )
 
 type emailStatusDBAccessor interface {
+	runInTx(ctx context.Context, fn func(tx emailStatusDBAccessor) error) error
 	WriteEmailStatus(ctx context.Context, payload EmailStatus) error
 	GetAll(ctx context.Context, spec GetAllEmailStatusSpec) (*AccessorGetEmailStatusPaginationData, error)
 	UpdateEmailStatus(ctx context.Context, payload EmailStatus) (*EmailStatus, error)
@@ -22,6 +23,19 @@ func (p *EmailStatusService) WriteEmailStatus(ctx context.Context, payload Email
 	return p.emailStatusDBAccessor.WriteEmailStatus(ctx, payload)
 }
 
+// WriteEmailStatuses writes the statuses of an email blast at once, none are written
+// when one fails
+func (p *EmailStatusService) WriteEmailStatuses(ctx context.Context, statuses []EmailStatus) error {
+	return p.emailStatusDBAccessor.runInTx(ctx, func(tx emailStatusDBAccessor) error {
+		for _, status := range statuses {
+			if err := tx.WriteEmailStatus(ctx, status); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 func (p *EmailStatusService) GetAllEmailStatus(ctx context.Context, spec GetAllEmailStatusSpec) (*AccessorGetEmailStatusPaginationData, error) {
 	return p.emailStatusDBAccessor.GetAll(ctx, spec)
 }
@@ -154,3 +154,41 @@ func (c *MockemailStatusDBAccessorWriteEmailStatusCall) DoAndReturn(f func(conte
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
+
+// runInTx mocks base method.
+func (m *MockemailStatusDBAccessor) runInTx(ctx context.Context, fn func(emailStatusDBAccessor) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "runInTx", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// runInTx indicates an expected call of runInTx.
+func (mr *MockemailStatusDBAccessorMockRecorder) runInTx(ctx, fn any) *MockemailStatusDBAccessorrunInTxCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "runInTx", reflect.TypeOf((*MockemailStatusDBAccessor)(nil).runInTx), ctx, fn)
+	return &MockemailStatusDBAccessorrunInTxCall{Call: call}
+}
+
+// MockemailStatusDBAccessorrunInTxCall wrap *gomock.Call
+type MockemailStatusDBAccessorrunInTxCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockemailStatusDBAccessorrunInTxCall) Return(arg0 error) *MockemailStatusDBAccessorrunInTxCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockemailStatusDBAccessorrunInTxCall) Do(f func(context.Context, func(emailStatusDBAccessor) error) error) *MockemailStatusDBAccessorrunInTxCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockemailStatusDBAccessorrunInTxCall) DoAndReturn(f func(context.Context, func(emailStatusDBAccessor) error) error) *MockemailStatusDBAccessorrunInTxCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
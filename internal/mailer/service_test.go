@@ -66,6 +66,47 @@ func TestEmailStatusService_WriteEmailStatus(t *testing.T) {
 	})
 }
 
+func TestEmailStatusService_WriteEmailStatuses(t *testing.T) {
+	statuses := []EmailStatus{
+		{ID: "1", EmailTo: "a@email.com", Status: "sent"},
+		{ID: "2", EmailTo: "b@email.com", Status: "failed"},
+	}
+
+	setup := func(t *testing.T) (*gomega.WithT, *MockemailStatusDBAccessor, *EmailStatusService) {
+		mockEmailStatusAccessor := NewMockemailStatusDBAccessor(gomock.NewController(t))
+		mockEmailStatusAccessor.EXPECT().runInTx(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, fn func(tx emailStatusDBAccessor) error) error {
+				return fn(mockEmailStatusAccessor)
+			})
+		return gomega.NewWithT(t), mockEmailStatusAccessor, &EmailStatusService{
+			emailStatusDBAccessor: mockEmailStatusAccessor,
+		}
+	}
+
+	t.Run("writes every status in one unit of work", func(t *testing.T) {
+		g, mockEmailStatusAccessor, svc := setup(t)
+		ctx := context.Background()
+
+		gomock.InOrder(
+			mockEmailStatusAccessor.EXPECT().WriteEmailStatus(ctx, statuses[0]).Return(nil),
+			mockEmailStatusAccessor.EXPECT().WriteEmailStatus(ctx, statuses[1]).Return(nil),
+		)
+
+		err := svc.WriteEmailStatuses(ctx, statuses)
+		g.Expect(err).To(gomega.BeNil())
+	})
+
+	t.Run("stops at the first failure", func(t *testing.T) {
+		g, mockEmailStatusAccessor, svc := setup(t)
+		ctx := context.Background()
+
+		mockEmailStatusAccessor.EXPECT().WriteEmailStatus(ctx, statuses[0]).Return(errors.New("create error"))
+
+		err := svc.WriteEmailStatuses(ctx, statuses)
+		g.Expect(err).ShouldNot(gomega.BeNil())
+	})
+}
+
 func TestEmailStatusService_UpdateEmailStatus(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -2,13 +2,19 @@ package product
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"kg/procurement/cmd/utils"
+	"kg/procurement/internal/audit"
 	"kg/procurement/internal/common/database"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/benbjohnson/clock"
+	"github.com/lib/pq"
 )
 
 const (
@@ -16,25 +22,26 @@ const (
 		SELECT pv.id, pv.product_id, pv.code, pv.name, pv.income_tax_id, pv.income_tax_name, pv.income_tax_percentage, pv.description, pv.uom_id, pv.sap_code, pv.modified_date, pv.modified_by
 		FROM product_vendor pv
 		JOIN price pr ON pr.product_vendor_id = pv.id
-		WHERE pr.vendor_id = $1
+		WHERE pr.vendor_id = $1 AND pv.deleted_at IS NULL AND pr.deleted_at IS NULL
 	`	
 	getProductCategoryByIDQuery = `
 		SELECT *
 		FROM product_category
-		WHERE id = $1
+		WHERE id = $1 AND deleted_at IS NULL
 	`
 	getUOMByIDQuery = `
 		SELECT *
 		FROM uom
-		WHERE id = $1
+		WHERE id = $1 AND deleted_at IS NULL
 	`
   
-	getProductByIDQuery = `SELECT * FROM product WHERE id = $1`
-	getPriceByPVIDQuery = `
+	getProductByIDQuery = `SELECT * FROM product WHERE id = $1 AND deleted_at IS NULL`
+	getProductTypeByIDQuery = `SELECT * FROM product_type WHERE id = $1 AND deleted_at IS NULL`
+	getPricesByPVIDQuery = `
 		SELECT pr.*
 		FROM price pr 
 		JOIN product_vendor pv ON pv.id = pr.product_vendor_id
-		WHERE pv.id = $1
+		WHERE pv.id = $1 AND pr.deleted_at IS NULL
 	`
 	insertProduct = `
 		INSERT INTO product
@@ -56,9 +63,9 @@ const (
 	`
 	insertUOM = `
 		INSERT INTO uom
-			(id, name, description, dimension, sap_code, modified_date, modified_by)
+			(id, name, description, dimension, sap_code, base_factor, modified_date, modified_by)
 		VALUES 
-			(:id, :name, :description, :dimension, :sap_code, :modified_date, :modified_by)
+			(:id, :name, :description, :dimension, :sap_code, :base_factor, :modified_date, :modified_by)
 	`
 	insertProductVendor = `
 		INSERT INTO product_vendor
@@ -72,6 +79,98 @@ const (
 		VALUES 
 			(:id, :purchasing_org_id, :purchasing_org_name, :vendor_id, :product_vendor_id, :quantity_min, :quantity_max, :quantity_uom_id, :lead_time_min, :lead_time_max, :currency_id, :currency_name, :currency_code, :price, :price_quantity, :price_uom_id, :valid_from, :valid_to, :valid_pattern_id, :valid_pattern_name, :area_group_id, :area_group_name, :reference_number, :reference_date, :document_type_id, :document_type_name, :document_id, :item_id, :term_of_payment_id, :term_of_payment_days, :term_of_payment_text, :invocation_order, :modified_date, :modified_by)
 	`
+	// the seeder upserts by id so fixtures can be loaded again
+	upsertProduct = insertProduct + `
+		ON CONFLICT (id) DO UPDATE SET
+			product_category_id = EXCLUDED.product_category_id,
+			uom_id = EXCLUDED.uom_id,
+			income_tax_id = EXCLUDED.income_tax_id,
+			product_type_id = EXCLUDED.product_type_id,
+			name = EXCLUDED.name,
+			description = EXCLUDED.description,
+			modified_date = EXCLUDED.modified_date,
+			modified_by = EXCLUDED.modified_by
+	`
+	upsertProductCategory = insertProductCategory + `
+		ON CONFLICT (id) DO UPDATE SET
+			name = EXCLUDED.name,
+			code = EXCLUDED.code,
+			description = EXCLUDED.description,
+			parent_id = EXCLUDED.parent_id,
+			specialist_bpid = EXCLUDED.specialist_bpid,
+			modified_date = EXCLUDED.modified_date,
+			modified_by = EXCLUDED.modified_by
+	`
+	upsertProductType = insertProductType + `
+		ON CONFLICT (id) DO UPDATE SET
+			name = EXCLUDED.name,
+			description = EXCLUDED.description,
+			goods = EXCLUDED.goods,
+			asset = EXCLUDED.asset,
+			stock = EXCLUDED.stock,
+			modified_date = EXCLUDED.modified_date,
+			modified_by = EXCLUDED.modified_by
+	`
+	upsertUOM = insertUOM + `
+		ON CONFLICT (id) DO UPDATE SET
+			name = EXCLUDED.name,
+			description = EXCLUDED.description,
+			dimension = EXCLUDED.dimension,
+			sap_code = EXCLUDED.sap_code,
+			base_factor = EXCLUDED.base_factor,
+			modified_date = EXCLUDED.modified_date,
+			modified_by = EXCLUDED.modified_by
+	`
+	upsertProductVendor = insertProductVendor + `
+		ON CONFLICT (id) DO UPDATE SET
+			product_id = EXCLUDED.product_id,
+			code = EXCLUDED.code,
+			name = EXCLUDED.name,
+			income_tax_id = EXCLUDED.income_tax_id,
+			income_tax_name = EXCLUDED.income_tax_name,
+			income_tax_percentage = EXCLUDED.income_tax_percentage,
+			description = EXCLUDED.description,
+			uom_id = EXCLUDED.uom_id,
+			sap_code = EXCLUDED.sap_code,
+			modified_date = EXCLUDED.modified_date,
+			modified_by = EXCLUDED.modified_by
+	`
+	upsertPrice = insertPrice + `
+		ON CONFLICT (id) DO UPDATE SET
+			purchasing_org_id = EXCLUDED.purchasing_org_id,
+			purchasing_org_name = EXCLUDED.purchasing_org_name,
+			vendor_id = EXCLUDED.vendor_id,
+			product_vendor_id = EXCLUDED.product_vendor_id,
+			quantity_min = EXCLUDED.quantity_min,
+			quantity_max = EXCLUDED.quantity_max,
+			quantity_uom_id = EXCLUDED.quantity_uom_id,
+			lead_time_min = EXCLUDED.lead_time_min,
+			lead_time_max = EXCLUDED.lead_time_max,
+			currency_id = EXCLUDED.currency_id,
+			currency_name = EXCLUDED.currency_name,
+			currency_code = EXCLUDED.currency_code,
+			price = EXCLUDED.price,
+			price_quantity = EXCLUDED.price_quantity,
+			price_uom_id = EXCLUDED.price_uom_id,
+			valid_from = EXCLUDED.valid_from,
+			valid_to = EXCLUDED.valid_to,
+			valid_pattern_id = EXCLUDED.valid_pattern_id,
+			valid_pattern_name = EXCLUDED.valid_pattern_name,
+			area_group_id = EXCLUDED.area_group_id,
+			area_group_name = EXCLUDED.area_group_name,
+			reference_number = EXCLUDED.reference_number,
+			reference_date = EXCLUDED.reference_date,
+			document_type_id = EXCLUDED.document_type_id,
+			document_type_name = EXCLUDED.document_type_name,
+			document_id = EXCLUDED.document_id,
+			item_id = EXCLUDED.item_id,
+			term_of_payment_id = EXCLUDED.term_of_payment_id,
+			term_of_payment_days = EXCLUDED.term_of_payment_days,
+			term_of_payment_text = EXCLUDED.term_of_payment_text,
+			invocation_order = EXCLUDED.invocation_order,
+			modified_date = EXCLUDED.modified_date,
+			modified_by = EXCLUDED.modified_by
+	`
 	updateProduct = `UPDATE product SET
         product_category_id = $2,
         uom_id = $3,
@@ -80,7 +179,7 @@ const (
         name = $6,
         description = $7,
         modified_date = $8
-    WHERE id = $1
+    WHERE id = $1 AND deleted_at IS NULL
     RETURNING 
         id,
         product_category_id,
@@ -118,6 +217,278 @@ const (
 	`
 )
 
+// catalogue listings only return entries that aren't deleted, filters are appended with AND
+const (
+	getProductsQuery = `
+		SELECT
+			id,
+			product_category_id,
+			uom_id,
+			income_tax_id,
+			product_type_id,
+			name,
+			description,
+			modified_date,
+			modified_by,
+			COUNT(*) OVER () AS total_entries
+		FROM product
+		WHERE deleted_at IS NULL
+	`
+	getProductCategoriesQuery = `
+		SELECT
+			id,
+			name,
+			code,
+			description,
+			parent_id,
+			specialist_bpid,
+			modified_date,
+			modified_by,
+			COUNT(*) OVER () AS total_entries
+		FROM product_category
+		WHERE deleted_at IS NULL
+	`
+	getProductTypesQuery = `
+		SELECT
+			id,
+			name,
+			description,
+			goods,
+			asset,
+			stock,
+			modified_date,
+			modified_by,
+			COUNT(*) OVER () AS total_entries
+		FROM product_type
+		WHERE deleted_at IS NULL
+	`
+	getUOMsQuery = `
+		SELECT
+			id,
+			name,
+			description,
+			dimension,
+			sap_code,
+			base_factor,
+			modified_date,
+			modified_by,
+			COUNT(*) OVER () AS total_entries
+		FROM uom
+		WHERE deleted_at IS NULL
+	`
+)
+
+// catalogueSortableColumns maps the order_by fields accepted by the catalogue listings
+var catalogueSortableColumns = map[string]string{
+	"name":          "name",
+	"modified_date": "modified_date",
+}
+
+const (
+	getPriceHistoryByPVIDQuery = `
+		SELECT
+			ph.id,
+			ph.price_id,
+			ph.product_vendor_id,
+			ph.vendor_id,
+			ph.old_price,
+			ph.new_price,
+			ph.currency_id,
+			ph.price_quantity,
+			ph.price_uom_id,
+			ph.valid_from,
+			ph.valid_to,
+			ph.document_type_id,
+			ph.document_id,
+			ph.reference_number,
+			ph.changed_by,
+			ph.reason,
+			ph.modified_date,
+			COUNT(*) OVER () AS total_entries
+		FROM price_history ph
+		WHERE ph.product_vendor_id = $1
+		ORDER BY ph.modified_date DESC, ph.id
+		LIMIT $2
+		OFFSET $3
+	`
+	// getEffectivePricesQuery picks, for every price of the product vendor, the latest
+	// version recorded by the given date and keeps it when its validity window contains
+	// that date. A price whose latest version no longer covers the date has no effective
+	// version, an older one it superseded is never returned
+	getEffectivePricesQuery = `
+		WITH latest AS (
+			SELECT DISTINCT ON (ph.price_id) ph.*
+			FROM price_history ph
+			WHERE ph.product_vendor_id = $1
+				AND ph.modified_date <= $2
+			ORDER BY ph.price_id, ph.modified_date DESC, ph.id DESC
+		)
+		SELECT
+			l.id,
+			l.price_id,
+			l.product_vendor_id,
+			l.vendor_id,
+			l.old_price,
+			l.new_price,
+			l.currency_id,
+			l.price_quantity,
+			l.price_uom_id,
+			l.valid_from,
+			l.valid_to,
+			l.document_type_id,
+			l.document_id,
+			l.reference_number,
+			l.changed_by,
+			l.reason,
+			l.modified_date
+		FROM latest l
+		WHERE l.valid_from <= $2
+			AND (l.valid_to IS NULL OR l.valid_to >= $2)
+		ORDER BY l.price_id
+	`
+)
+
+const (
+	getImportPricesQuery             = `SELECT * FROM price WHERE id = ANY($1) AND deleted_at IS NULL`
+	getExistingProductVendorIDsQuery = `SELECT id FROM product_vendor WHERE id = ANY($1) AND deleted_at IS NULL`
+	getExistingVendorIDsQuery        = `SELECT id FROM vendor WHERE id = ANY($1) AND deleted_at IS NULL`
+	getExistingUOMIDsQuery           = `SELECT id FROM uom WHERE id = ANY($1) AND deleted_at IS NULL`
+	getImportCurrenciesQuery         = `SELECT id, code, name FROM currency WHERE code = ANY($1)`
+	getVendorNamesQuery              = `SELECT id, name FROM vendor WHERE id = ANY($1) AND deleted_at IS NULL`
+
+	// the references of a page of product vendors are looked up with one query each
+	getPageProductsQuery   = `SELECT * FROM product WHERE id = ANY($1) AND deleted_at IS NULL`
+	getPageCategoriesQuery = `SELECT * FROM product_category WHERE id = ANY($1) AND deleted_at IS NULL`
+	getPagePricesQuery     = `SELECT * FROM price WHERE product_vendor_id = ANY($1) AND deleted_at IS NULL`
+	getPageUOMsQuery       = `SELECT * FROM uom WHERE id = ANY($1) AND deleted_at IS NULL`
+
+	// lockProductVendorQuery holds the product vendor row until the transaction ends so
+	// the prices of a product vendor are checked against each other and written one at a time
+	lockProductVendorQuery = `SELECT id FROM product_vendor WHERE id = $1 FOR UPDATE`
+	getDeletedPriceQuery   = `SELECT * FROM price WHERE id = $1 AND deleted_at IS NOT NULL`
+
+	// createPriceQuery inserts the price $1 rendered like an imported row so a nil ValidTo is
+	// stored as NULL, its history baseline comes from the price insert trigger
+	createPriceQuery = `INSERT INTO price SELECT * FROM json_populate_record(NULL::price, $1::json)`
+
+	// importPricesQuery upserts every price of $1 in a single statement so the import
+	// is applied as one transaction. Updated prices get a history entry like UpdatePrice
+	// does, created ones get theirs from the price insert trigger. A price soft deleted
+	// since it was looked up isn't updated, the count then falls short and fails the import
+	importPricesQuery = `
+		WITH input AS (
+			SELECT * FROM json_populate_recordset(NULL::price, $1::json)
+		),
+		previous AS (
+			SELECT pr.id, pr.price
+			FROM price pr
+			JOIN input i ON i.id = pr.id
+		),
+		upserted AS (
+			INSERT INTO price
+			SELECT * FROM input
+			ON CONFLICT (id) DO UPDATE SET
+				purchasing_org_id = EXCLUDED.purchasing_org_id,
+				purchasing_org_name = EXCLUDED.purchasing_org_name,
+				vendor_id = EXCLUDED.vendor_id,
+				product_vendor_id = EXCLUDED.product_vendor_id,
+				quantity_min = EXCLUDED.quantity_min,
+				quantity_max = EXCLUDED.quantity_max,
+				quantity_uom_id = EXCLUDED.quantity_uom_id,
+				lead_time_min = EXCLUDED.lead_time_min,
+				lead_time_max = EXCLUDED.lead_time_max,
+				currency_id = EXCLUDED.currency_id,
+				currency_name = EXCLUDED.currency_name,
+				currency_code = EXCLUDED.currency_code,
+				price = EXCLUDED.price,
+				price_quantity = EXCLUDED.price_quantity,
+				price_uom_id = EXCLUDED.price_uom_id,
+				valid_from = EXCLUDED.valid_from,
+				valid_to = EXCLUDED.valid_to,
+				valid_pattern_id = EXCLUDED.valid_pattern_id,
+				valid_pattern_name = EXCLUDED.valid_pattern_name,
+				area_group_id = EXCLUDED.area_group_id,
+				area_group_name = EXCLUDED.area_group_name,
+				reference_number = EXCLUDED.reference_number,
+				reference_date = EXCLUDED.reference_date,
+				document_type_id = EXCLUDED.document_type_id,
+				document_type_name = EXCLUDED.document_type_name,
+				document_id = EXCLUDED.document_id,
+				item_id = EXCLUDED.item_id,
+				term_of_payment_id = EXCLUDED.term_of_payment_id,
+				term_of_payment_days = EXCLUDED.term_of_payment_days,
+				term_of_payment_text = EXCLUDED.term_of_payment_text,
+				invocation_order = EXCLUDED.invocation_order,
+				modified_date = EXCLUDED.modified_date,
+				modified_by = EXCLUDED.modified_by
+			WHERE price.deleted_at IS NULL
+			RETURNING *
+		),
+		history AS (
+			INSERT INTO price_history
+				(id, price_id, product_vendor_id, vendor_id, old_price, new_price, currency_id, price_quantity, price_uom_id, valid_from, valid_to, document_type_id, document_id, reference_number, changed_by, reason, modified_date)
+			SELECT
+				left(md5(random()::text || u.id), 15), u.id, u.product_vendor_id, u.vendor_id, pr.price, u.price, COALESCE(u.currency_id, ''), COALESCE(u.price_quantity, 0), COALESCE(u.price_uom_id, ''), u.valid_from, u.valid_to, COALESCE(u.document_type_id, ''), COALESCE(u.document_id, ''), COALESCE(u.reference_number, ''), $2, $3, u.modified_date
+			FROM upserted u
+			JOIN previous pr ON pr.id = u.id
+		)
+		SELECT COUNT(*) FROM upserted
+	`
+)
+
+const (
+	// getPeerPricesQuery returns, for every product vendor of $1, the prices in effect at $2
+	// of the other product vendors offering the same product
+	getPeerPricesQuery = `
+		SELECT
+			pv_in.id AS for_product_vendor_id,
+			pr.id,
+			COALESCE(pr.vendor_id, '') AS vendor_id,
+			pr.product_vendor_id,
+			COALESCE(pr.currency_id, '') AS currency_id,
+			pr.price,
+			COALESCE(pr.price_quantity, 0) AS price_quantity,
+			COALESCE(pr.price_uom_id, '') AS price_uom_id
+		FROM product_vendor pv_in
+		JOIN product_vendor pv ON pv.product_id = pv_in.product_id AND pv.id <> pv_in.id AND pv.deleted_at IS NULL
+		JOIN price pr ON pr.product_vendor_id = pv.id AND pr.deleted_at IS NULL
+		WHERE pv_in.id = ANY($1)
+			AND pr.valid_from <= $2
+			AND (pr.valid_to IS NULL OR pr.valid_to >= $2)
+	`
+	insertPriceAnomalyQuery = `
+		INSERT INTO price_anomaly
+			(id, price_id, product_vendor_id, vendor_id, source, old_price, new_price, currency_id, history_ratio, peer_median, peer_ratio, peer_count, status, proposed, requested_by, reason, reviewed_by, reviewed_date, modified_date)
+		VALUES
+			(:id, :price_id, :product_vendor_id, :vendor_id, :source, :old_price, :new_price, :currency_id, :history_ratio, :peer_median, :peer_ratio, :peer_count, :status, :proposed, :requested_by, :reason, :reviewed_by, :reviewed_date, :modified_date)
+	`
+	// updatePriceAnomalyReviewQuery only reviews a pending anomaly so a review racing
+	// another one leaves no row updated
+	updatePriceAnomalyReviewQuery = `
+		UPDATE price_anomaly
+		SET status = :status, reviewed_by = :reviewed_by, reviewed_date = :reviewed_date, modified_date = :modified_date
+		WHERE id = :id AND status = 'pending'
+	`
+	// lockPriceAnomalyQuery holds the anomaly until the transaction reviewing it ends
+	lockPriceAnomalyQuery = `
+		SELECT id, price_id, product_vendor_id, vendor_id, source, old_price, new_price, currency_id, history_ratio, peer_median, peer_ratio, peer_count, status, proposed, requested_by, reason, reviewed_by, reviewed_date, modified_date
+		FROM price_anomaly
+		WHERE id = $1
+		FOR UPDATE
+	`
+	getPriceAnomaliesQuery = `
+		SELECT
+			id, price_id, product_vendor_id, vendor_id, source, old_price, new_price, currency_id, history_ratio, peer_median, peer_ratio, peer_count, status, proposed, requested_by, reason, reviewed_by, reviewed_date, modified_date,
+			COUNT(*) OVER () AS total_entries
+		FROM price_anomaly
+		WHERE ($1 = '' OR status = $1)
+			AND ($2 = '' OR product_vendor_id = $2)
+		ORDER BY modified_date DESC, id
+		LIMIT $3
+		OFFSET $4
+	`
+)
+
 type postgresProductAccessor struct {
 	db    database.DBConnector
 	clock clock.Clock
@@ -129,7 +500,7 @@ type AccessorGetProductVendorsPaginationData struct {
 }
 
 func (p *postgresProductAccessor) GetProductVendorsByVendor(
-	_ context.Context,
+	ctx context.Context,
 	vendorID string,
 	spec GetProductVendorByVendorSpec,
 ) (*AccessorGetProductVendorsPaginationData, error) {
@@ -179,7 +550,7 @@ func (p *postgresProductAccessor) GetProductVendorsByVendor(
 		query += " " + strings.Join(extraClauses, " ")
 	}
 
-	rows, err := p.db.Queryx(query, args...)
+	rows, err := p.db.QueryxContext(ctx, query, args...)
 	if err != nil {
 		utils.Logger.Error(err.Error())
 		return nil, err
@@ -204,7 +575,7 @@ func (p *postgresProductAccessor) GetProductVendorsByVendor(
 	countQuery := `SELECT COUNT(*)
 		FROM product_vendor pv
 		JOIN price pr ON pr.product_vendor_id = pv.id
-		WHERE pr.vendor_id = $1`
+		WHERE pr.vendor_id = $1 AND pv.deleted_at IS NULL AND pr.deleted_at IS NULL`
 
 	args = []interface{}{vendorID}
 	argsIndex = 2
@@ -221,7 +592,7 @@ func (p *postgresProductAccessor) GetProductVendorsByVendor(
 	}
 
 	var totalEntries int
-	row := p.db.QueryRow(countQuery, args...)
+	row := p.db.QueryRowContext(ctx, countQuery, args...)
 	if err = row.Scan(&totalEntries); err != nil {
 		utils.Logger.Errorf(err.Error())
 		return nil, fmt.Errorf("failed to execute count query: %w", err)
@@ -234,14 +605,14 @@ func (p *postgresProductAccessor) GetProductVendorsByVendor(
 }
 
 func (p *postgresProductAccessor) GetAllProductVendors(
-	_ context.Context,
+	ctx context.Context,
 	spec GetProductVendorsSpec,
 ) (*AccessorGetProductVendorsPaginationData, error) {
 	paginationArgs := database.BuildPaginationArgs(spec.PaginationSpec)
 
-	// Initialize clauses and arguments
+	// Initialize clauses and arguments, deleted product vendors are never listed
 	var (
-		whereClauses []string
+		whereClauses = []string{"pv.deleted_at IS NULL"}
 		extraClauses []string
 		args         = map[string]interface{}{
 			"limit":  paginationArgs.Limit,
@@ -264,6 +635,10 @@ func (p *postgresProductAccessor) GetAllProductVendors(
 		}
 	}
 
+	if spec.IsCursor() {
+		return p.getAllProductVendorsByCursor(ctx, spec.PaginationSpec, whereClauses, args)
+	}
+
 	// Build extra clauses
 	if paginationArgs.OrderBy != "" {
 		extraClauses = append(extraClauses, fmt.Sprintf("ORDER BY %s %s",
@@ -284,7 +659,7 @@ func (p *postgresProductAccessor) GetAllProductVendors(
 		query += " " + strings.Join(extraClauses, " ")
 	}
 
-	rows, err := p.db.NamedQuery(query, args)
+	rows, err := p.db.NamedQueryContext(ctx, query, args)
 	if err != nil {
 		utils.Logger.Error(err.Error())
 		return nil, err
@@ -307,7 +682,7 @@ func (p *postgresProductAccessor) GetAllProductVendors(
 	}
 
 	var totalEntries int
-	rows, err = p.db.NamedQuery(countQuery, countArgs)
+	rows, err = p.db.NamedQueryContext(ctx, countQuery, countArgs)
 	if err != nil {
 		utils.Logger.Error(err.Error())
 		return nil, err
@@ -331,20 +706,143 @@ func (p *postgresProductAccessor) GetAllProductVendors(
 	}, nil
 }
 
-func (p *postgresProductAccessor) getPriceByPVID(
-	_ context.Context,
+// productVendorSortableColumns maps the order_by fields accepted in cursor mode
+var productVendorSortableColumns = map[string]string{
+	"id":            "pv.id",
+	"name":          "pv.name",
+	"code":          "pv.code",
+	"sap_code":      "pv.sap_code",
+	"modified_date": "pv.modified_date",
+}
+
+// productVendorCursorRow carries the sort column value used to build the cursors
+type productVendorCursorRow struct {
+	ProductVendor
+	CursorValue interface{} `db:"cursor_value"`
+}
+
+// getAllProductVendorsByCursor runs the product vendor listing in keyset mode,
+// the count query is skipped since cursors don't need it
+func (p *postgresProductAccessor) getAllProductVendorsByCursor(
+	ctx context.Context,
+	spec database.PaginationSpec,
+	whereClauses []string,
+	args map[string]interface{},
+) (*AccessorGetProductVendorsPaginationData, error) {
+	keysetArgs, err := database.BuildKeysetArgs(spec, "modified_date", "pv.id", productVendorSortableColumns)
+	if err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	if condition := keysetArgs.Condition(":cursor_value", ":cursor_id"); condition != "" {
+		whereClauses = append(whereClauses, condition)
+		args["cursor_value"] = keysetArgs.Cursor.Value
+		args["cursor_id"] = keysetArgs.Cursor.ID
+	}
+	args["limit"] = keysetArgs.FetchLimit()
+	delete(args, "offset")
+
+	whereClause := ""
+	if len(whereClauses) > 0 {
+		whereClause = "WHERE " + strings.Join(whereClauses, " AND ")
+	}
+
+	query := fmt.Sprintf(`
+		SELECT
+			pv.id,
+			pv.product_id,
+			pv.code,
+			pv.name,
+			pv.income_tax_id,
+			pv.income_tax_name,
+			pv.income_tax_percentage,
+			pv.description,
+			pv.uom_id,
+			pv.sap_code,
+			pv.modified_date,
+			pv.modified_by,
+			%s AS %s
+		FROM
+			product_vendor pv
+		%s
+		%s
+		LIMIT :limit
+	`, keysetArgs.Column, database.CursorValueColumn, whereClause, keysetArgs.OrderByClause())
+
+	rows, err := p.db.NamedQueryContext(ctx, query, args)
+	if err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	cursorRows := []productVendorCursorRow{}
+	for rows.Next() {
+		var row productVendorCursorRow
+		if err := rows.StructScan(&row); err != nil {
+			utils.Logger.Error(err.Error())
+			return nil, err
+		}
+		cursorRows = append(cursorRows, row)
+	}
+	if err := rows.Err(); err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	cursorRows, metadata := database.GenerateCursorMetadata(keysetArgs, cursorRows, func(row productVendorCursorRow) database.Cursor {
+		return database.Cursor{Value: row.CursorValue, ID: row.ID}
+	})
+
+	res := make([]ProductVendor, 0, len(cursorRows))
+	for _, row := range cursorRows {
+		res = append(res, row.ProductVendor)
+	}
+
+	return &AccessorGetProductVendorsPaginationData{
+		ProductVendors: res,
+		Metadata:       metadata,
+	}, nil
+}
+
+// getPricesByPVID returns every price of the product vendor, the applicable one is picked by resolvePrice
+func (p *postgresProductAccessor) getPricesByPVID(
+	ctx context.Context,
 	pvID string,
-) (*Price, error) {
-	rows := p.db.QueryRowx(getPriceByPVIDQuery, pvID)
+) ([]Price, error) {
+	res := []Price{}
+	if err := p.db.SelectContext(ctx, &res, getPricesByPVIDQuery, pvID); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// lockProductVendor locks the product vendor for the rest of the transaction, an
+// unknown product vendor is left to the validation of the price
+func (p *postgresProductAccessor) lockProductVendor(ctx context.Context, pvID string) error {
+	if _, err := p.db.ExecContext(ctx, lockProductVendorQuery, pvID); err != nil {
+		utils.Logger.Error(err.Error())
+		return err
+	}
+	return nil
+}
+
+// getDeletedPrice returns a soft deleted price, ErrPriceNotFound when the price is missing or live
+func (p *postgresProductAccessor) getDeletedPrice(ctx context.Context, id string) (*Price, error) {
 	res := Price{}
-	if err := rows.StructScan(&res); err != nil {
+	if err := p.db.GetContext(ctx, &res, getDeletedPriceQuery, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrPriceNotFound
+		}
+		utils.Logger.Error(err.Error())
 		return nil, err
 	}
 	return &res, nil
 }
 
-func (p *postgresProductAccessor) getProductByID(_ context.Context, productID string) (*Product, error) {
-	rows := p.db.QueryRowx(getProductByIDQuery, productID)
+func (p *postgresProductAccessor) getProductByID(ctx context.Context, productID string) (*Product, error) {
+	rows := p.db.QueryRowxContext(ctx, getProductByIDQuery, productID)
 	res := Product{}
 	if err := rows.StructScan(&res); err != nil {
 		utils.Logger.Errorf(err.Error())
@@ -353,8 +851,8 @@ func (p *postgresProductAccessor) getProductByID(_ context.Context, productID st
 	return &res, nil
 }
 
-func (p *postgresProductAccessor) getProductCategoryByID(_ context.Context, productCategoryID string) (*ProductCategory, error) {
-	rows := p.db.QueryRowx(getProductCategoryByIDQuery, productCategoryID)
+func (p *postgresProductAccessor) getProductCategoryByID(ctx context.Context, productCategoryID string) (*ProductCategory, error) {
+	rows := p.db.QueryRowxContext(ctx, getProductCategoryByIDQuery, productCategoryID)
 	res := ProductCategory{}
 	if err := rows.StructScan(&res); err != nil {
 		utils.Logger.Errorf(err.Error())
@@ -363,8 +861,8 @@ func (p *postgresProductAccessor) getProductCategoryByID(_ context.Context, prod
 	return &res, nil
 }
 
-func (p *postgresProductAccessor) getUOMByID(_ context.Context, uomID string) (*UOM, error) {
-	rows := p.db.QueryRowx(getUOMByIDQuery, uomID)
+func (p *postgresProductAccessor) getUOMByID(ctx context.Context, uomID string) (*UOM, error) {
+	rows := p.db.QueryRowxContext(ctx, getUOMByIDQuery, uomID)
 	res := UOM{}
 	if err := rows.StructScan(&res); err != nil {
 		utils.Logger.Errorf(err.Error())
@@ -373,11 +871,21 @@ func (p *postgresProductAccessor) getUOMByID(_ context.Context, uomID string) (*
 	return &res, nil
 }
 
-func (p *postgresProductAccessor) UpdateProduct(_ context.Context, payload Product) (Product, error) {
+func (p *postgresProductAccessor) UpdateProduct(ctx context.Context, payload Product) (Product, error) {
+	var updated Product
+	err := p.audited(ctx, audit.EntityProduct, []string{string(payload.ID)}, payload.ModifiedBy, func(tx *postgresProductAccessor) error {
+		var err error
+		updated, err = tx.updateProductRow(ctx, payload)
+		return err
+	})
+	return updated, err
+}
+
+func (p *postgresProductAccessor) updateProductRow(ctx context.Context, payload Product) (Product, error) {
 	now := p.clock.Now()
 
 	updatedProduct := Product{}
-	row := p.db.QueryRow(updateProduct,
+	row := p.db.QueryRowContext(ctx, updateProduct,
 		payload.ID,
 		payload.ProductCategoryID,
 		payload.UOMID,
@@ -406,67 +914,93 @@ func (p *postgresProductAccessor) UpdateProduct(_ context.Context, payload Produ
 	return updatedProduct, nil
 }
 
-func (p *postgresProductAccessor) UpdatePrice(ctx context.Context, price Price) (Price, error) {
+// UpdatePrice overwrites the price row and records the change on price_history
+// within the same statement, so a price never changes without its history entry
+func (p *postgresProductAccessor) UpdatePrice(ctx context.Context, price Price, change PriceChange) (Price, error) {
+	var updated Price
+	err := p.audited(ctx, audit.EntityPrice, []string{price.ID}, change.ChangedBy, func(tx *postgresProductAccessor) error {
+		var err error
+		updated, err = tx.updatePriceRow(ctx, price, change)
+		return err
+	})
+	return updated, err
+}
+
+func (p *postgresProductAccessor) updatePriceRow(ctx context.Context, price Price, change PriceChange) (Price, error) {
 	now := p.clock.Now()
-	query := `UPDATE price
-        SET 
-            purchasing_org_id = $2,
-            vendor_id = $3,
-            product_vendor_id = $4,
-            quantity_min = $5,
-            quantity_max = $6,
-            quantity_uom_id = $7,
-            lead_time_min = $8,
-            lead_time_max = $9,
-            currency_id = $10,
-            price = $11,
-            price_quantity = $12,
-            price_uom_id = $13,
-            valid_from = $14,
-            valid_to = $15,
-            valid_pattern_id = $16,
-            area_group_id = $17,
-            reference_number = $18,
-            reference_date = $19,
-            document_type_id = $20,
-            document_id = $21,
-            item_id = $22,
-            term_of_payment_id = $23,
-            invocation_order = $24,
-            modified_date = $25
-        WHERE 
-            id = $1
-        RETURNING 
-            id,
-            purchasing_org_id,
-            vendor_id,
-            product_vendor_id,
-            quantity_min,
-            quantity_max,
-            quantity_uom_id,
-            lead_time_min,
-            lead_time_max,
-            currency_id,
-            price,
-            price_quantity,
-            price_uom_id,
-            valid_from,
-            valid_to,
-            valid_pattern_id,
-            area_group_id,
-            reference_number,
-            reference_date,
-            document_type_id,
-            document_id,
-            item_id,
-            term_of_payment_id,
-            invocation_order,
-            modified_date,
-            modified_by
+	query := `WITH previous AS (
+            SELECT id, price FROM price WHERE id = $1 AND deleted_at IS NULL
+        ),
+        updated AS (
+            UPDATE price
+            SET 
+                purchasing_org_id = $2,
+                vendor_id = $3,
+                product_vendor_id = $4,
+                quantity_min = $5,
+                quantity_max = $6,
+                quantity_uom_id = $7,
+                lead_time_min = $8,
+                lead_time_max = $9,
+                currency_id = $10,
+                price = $11,
+                price_quantity = $12,
+                price_uom_id = $13,
+                valid_from = $14,
+                valid_to = $15,
+                valid_pattern_id = $16,
+                area_group_id = $17,
+                reference_number = $18,
+                reference_date = $19,
+                document_type_id = $20,
+                document_id = $21,
+                item_id = $22,
+                term_of_payment_id = $23,
+                invocation_order = $24,
+                modified_date = $25
+            WHERE 
+                id = $1 AND deleted_at IS NULL
+            RETURNING 
+                id,
+                purchasing_org_id,
+                vendor_id,
+                product_vendor_id,
+                quantity_min,
+                quantity_max,
+                quantity_uom_id,
+                lead_time_min,
+                lead_time_max,
+                currency_id,
+                price,
+                price_quantity,
+                price_uom_id,
+                valid_from,
+                valid_to,
+                valid_pattern_id,
+                area_group_id,
+                reference_number,
+                reference_date,
+                document_type_id,
+                document_id,
+                item_id,
+                term_of_payment_id,
+                invocation_order,
+                modified_date,
+                modified_by
+        ),
+        history AS (
+            INSERT INTO price_history
+                (id, price_id, product_vendor_id, vendor_id, old_price, new_price, currency_id, price_quantity, price_uom_id, valid_from, valid_to, document_type_id, document_id, reference_number, changed_by, reason, modified_date)
+            SELECT
+                $26, u.id, u.product_vendor_id, u.vendor_id, pr.price, u.price, u.currency_id, u.price_quantity, u.price_uom_id, u.valid_from, u.valid_to, u.document_type_id, u.document_id, u.reference_number, $27, $28, $25
+            FROM updated u
+            JOIN previous pr ON pr.id = u.id
+        )
+        SELECT * FROM updated
     `
 
 	updatedPrice := Price{}
-	row := p.db.QueryRow(query,
+	row := p.db.QueryRowContext(ctx, query,
 		price.ID,
 		price.PurchasingOrgID,
 		price.VendorID,
@@ -492,6 +1026,9 @@ func (p *postgresProductAccessor) UpdatePrice(ctx context.Context, price Price)
 		price.TermOfPaymentID,
 		price.InvocationOrder,
 		now,
+		change.HistoryID,
+		change.ChangedBy,
+		change.Reason,
 	)
 
 	if err := row.Scan(
@@ -529,60 +1066,853 @@ func (p *postgresProductAccessor) UpdatePrice(ctx context.Context, price Price)
 	return updatedPrice, nil
 }
 
-func (p *postgresProductAccessor) writeProduct(_ context.Context, product Product) error {
-	if _, err := p.db.NamedExec(insertProduct, product); err != nil {
-		utils.Logger.Errorf("failed inserting product: %s", product.ID)
+type priceHistoryRow struct {
+	PriceHistory
+	TotalEntries int `db:"total_entries"`
+}
+
+func (p *postgresProductAccessor) GetPriceHistory(
+	ctx context.Context,
+	productVendorID string,
+	spec GetPriceHistorySpec,
+) (*AccessorGetPriceHistoryPaginationData, error) {
+	paginationArgs := database.BuildPaginationArgs(spec.PaginationSpec)
+
+	rows, err := p.db.QueryxContext(ctx, getPriceHistoryByPVIDQuery, productVendorID, paginationArgs.Limit, paginationArgs.Offset)
+	if err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	totalEntries := 0
+	res := []PriceHistory{}
+	for rows.Next() {
+		var row priceHistoryRow
+		if err := rows.StructScan(&row); err != nil {
+			utils.Logger.Error(err.Error())
+			return nil, err
+		}
+		totalEntries = row.TotalEntries
+		res = append(res, row.PriceHistory)
+	}
+	if err := rows.Err(); err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	return &AccessorGetPriceHistoryPaginationData{
+		PriceHistory: res,
+		Metadata:     database.GeneratePaginationMetadata(spec.PaginationSpec, totalEntries),
+	}, nil
+}
+
+// GetEffectivePrices returns the prices of the product vendor as they were in effect at the given date
+func (p *postgresProductAccessor) GetEffectivePrices(ctx context.Context, productVendorID string, at time.Time) ([]PriceHistory, error) {
+	res := []PriceHistory{}
+	if err := p.db.SelectContext(ctx, &res, getEffectivePricesQuery, productVendorID, at); err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+	return res, nil
+}
+
+// getPriceImportReferences looks up every reference of an import at once, the
+// prices are loaded in full since an imported row only overwrites its columns
+func (p *postgresProductAccessor) getPriceImportReferences(
+	ctx context.Context,
+	keys priceImportKeys,
+) (*priceImportReferences, error) {
+	res := &priceImportReferences{
+		Prices:         map[string]Price{},
+		ProductVendors: map[string]bool{},
+		Vendors:        map[string]bool{},
+		UOMs:           map[string]bool{},
+		Currencies:     map[string]importCurrency{},
+	}
+
+	if len(keys.PriceIDs) > 0 {
+		prices := []Price{}
+		if err := p.db.SelectContext(ctx, &prices, getImportPricesQuery, pq.Array(keys.PriceIDs)); err != nil {
+			utils.Logger.Error(err.Error())
+			return nil, err
+		}
+		for _, price := range prices {
+			res.Prices[price.ID] = price
+		}
+	}
+
+	existing := []struct {
+		query string
+		ids   []string
+		set   map[string]bool
+	}{
+		{getExistingProductVendorIDsQuery, keys.ProductVendorIDs, res.ProductVendors},
+		{getExistingVendorIDsQuery, keys.VendorIDs, res.Vendors},
+		{getExistingUOMIDsQuery, keys.UOMIDs, res.UOMs},
+	}
+	for _, e := range existing {
+		if len(e.ids) == 0 {
+			continue
+		}
+		ids := []string{}
+		if err := p.db.SelectContext(ctx, &ids, e.query, pq.Array(e.ids)); err != nil {
+			utils.Logger.Error(err.Error())
+			return nil, err
+		}
+		for _, id := range ids {
+			e.set[id] = true
+		}
+	}
+
+	if len(keys.CurrencyCodes) > 0 {
+		currencies := []importCurrency{}
+		if err := p.db.SelectContext(ctx, &currencies, getImportCurrenciesQuery, pq.Array(keys.CurrencyCodes)); err != nil {
+			utils.Logger.Error(err.Error())
+			return nil, err
+		}
+		for _, c := range currencies {
+			res.Currencies[c.Code] = c
+		}
+	}
+
+	return res, nil
+}
+
+// productVendorPageReferences holds what a page of product vendors refers to, the
+// prices are keyed by product vendor and the UOMs are those the prices are quoted in
+type productVendorPageReferences struct {
+	Products   map[string]Product
+	Categories map[string]ProductCategory
+	Prices     map[string][]Price
+	UOMs       map[string]UOM
+}
+
+// getProductVendorPageReferences looks up the products, categories, prices and price
+// UOMs of a page of product vendors at once, so a page takes the same number of
+// queries whatever its size. Deleted entries are left out
+func (p *postgresProductAccessor) getProductVendorPageReferences(
+	ctx context.Context,
+	productVendors []ProductVendor,
+) (*productVendorPageReferences, error) {
+	res := &productVendorPageReferences{
+		Products:   map[string]Product{},
+		Categories: map[string]ProductCategory{},
+		Prices:     map[string][]Price{},
+		UOMs:       map[string]UOM{},
+	}
+	if len(productVendors) == 0 {
+		return res, nil
+	}
+
+	productIDs := distinctValues(len(productVendors), func(i int) string { return productVendors[i].ProductID })
+	products := []Product{}
+	if err := p.db.SelectContext(ctx, &products, getPageProductsQuery, pq.Array(productIDs)); err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+	for _, product := range products {
+		res.Products[string(product.ID)] = product
+	}
+
+	categoryIDs := distinctValues(len(products), func(i int) string { return products[i].ProductCategoryID })
+	if len(categoryIDs) > 0 {
+		categories := []ProductCategory{}
+		if err := p.db.SelectContext(ctx, &categories, getPageCategoriesQuery, pq.Array(categoryIDs)); err != nil {
+			utils.Logger.Error(err.Error())
+			return nil, err
+		}
+		for _, category := range categories {
+			res.Categories[category.ID] = category
+		}
+	}
+
+	productVendorIDs := distinctValues(len(productVendors), func(i int) string { return productVendors[i].ID })
+	prices := []Price{}
+	if err := p.db.SelectContext(ctx, &prices, getPagePricesQuery, pq.Array(productVendorIDs)); err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+	for _, price := range prices {
+		res.Prices[price.ProductVendorID] = append(res.Prices[price.ProductVendorID], price)
+	}
+
+	uomIDs := distinctValues(len(prices), func(i int) string { return prices[i].PriceUOMID })
+	if len(uomIDs) > 0 {
+		uoms := []UOM{}
+		if err := p.db.SelectContext(ctx, &uoms, getPageUOMsQuery, pq.Array(uomIDs)); err != nil {
+			utils.Logger.Error(err.Error())
+			return nil, err
+		}
+		for _, uom := range uoms {
+			res.UOMs[uom.ID] = uom
+		}
+	}
+
+	return res, nil
+}
+
+// distinctValues returns the distinct non empty values of n entries in order
+func distinctValues(n int, value func(i int) string) []string {
+	res := []string{}
+	seen := map[string]bool{}
+	for i := 0; i < n; i++ {
+		v := value(i)
+		if v != "" && !seen[v] {
+			seen[v] = true
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+// getVendorNames returns the name of the vendors by id, unknown ids are left out
+func (p *postgresProductAccessor) getVendorNames(ctx context.Context, vendorIDs []string) (map[string]string, error) {
+	res := map[string]string{}
+	if len(vendorIDs) == 0 {
+		return res, nil
+	}
+
+	vendors := []struct {
+		ID   string `db:"id"`
+		Name string `db:"name"`
+	}{}
+	if err := p.db.SelectContext(ctx, &vendors, getVendorNamesQuery, pq.Array(vendorIDs)); err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+	for _, vendor := range vendors {
+		res[vendor.ID] = vendor.Name
+	}
+	return res, nil
+}
+
+// ImportPrices creates or overwrites every price at once, change describes who imported them and why
+func (p *postgresProductAccessor) ImportPrices(ctx context.Context, prices []Price, change PriceChange) error {
+	ids := make([]string, 0, len(prices))
+	for _, price := range prices {
+		ids = append(ids, price.ID)
+	}
+	return p.audited(ctx, audit.EntityPrice, ids, change.ChangedBy, func(tx *postgresProductAccessor) error {
+		return tx.importPriceRows(ctx, prices, change)
+	})
+}
+
+func (p *postgresProductAccessor) importPriceRows(ctx context.Context, prices []Price, change PriceChange) error {
+	records := make([]map[string]interface{}, 0, len(prices))
+	for _, price := range prices {
+		records = append(records, priceImportRecord(price))
+	}
+	payload, err := json.Marshal(records)
+	if err != nil {
+		utils.Logger.Error(err.Error())
+		return err
+	}
+
+	var imported int
+	if err := p.db.GetContext(ctx, &imported, importPricesQuery, string(payload), change.ChangedBy, change.Reason); err != nil {
+		utils.Logger.Error(err.Error())
+		return err
+	}
+	if imported != len(prices) {
+		err := fmt.Errorf("imported %d prices out of %d", imported, len(prices))
+		utils.Logger.Error(err.Error())
+		return err
+	}
+	return nil
+}
+
+func (p *postgresProductAccessor) getProductTypeByID(ctx context.Context, productTypeID string) (*ProductType, error) {
+	res := ProductType{}
+	if err := p.db.GetContext(ctx, &res, getProductTypeByIDQuery, productTypeID); err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+	return &res, nil
+}
+
+// catalogueListFilter is a condition of a catalogue listing, %d is replaced by the placeholder of value
+type catalogueListFilter struct {
+	condition string
+	value     interface{}
+}
+
+// nameListFilters matches entries whose name contains every word of name
+func nameListFilters(name string) []catalogueListFilter {
+	var filters []catalogueListFilter
+	for _, word := range strings.Fields(name) {
+		filters = append(filters, catalogueListFilter{condition: "name iLIKE $%d", value: "%" + word + "%"})
+	}
+	return filters
+}
+
+// buildCatalogueListQuery appends the filters, ordering and pagination of a catalogue listing to query
+func buildCatalogueListQuery(
+	query string,
+	filters []catalogueListFilter,
+	spec database.PaginationSpec,
+) (string, []interface{}, error) {
+	paginationArgs := database.BuildPaginationArgs(spec)
+
+	var args []interface{}
+	for _, filter := range filters {
+		args = append(args, filter.value)
+		query += fmt.Sprintf(" AND "+filter.condition, len(args))
+	}
+
+	orderBy := paginationArgs.OrderBy
+	if orderBy == "" {
+		orderBy = "name"
+	}
+	orderByClause, err := database.BuildOrderByClause(orderBy, paginationArgs.Order, catalogueSortableColumns)
+	if err != nil {
+		return "", nil, err
+	}
+
+	query += fmt.Sprintf(`
+		%s, id
+		LIMIT $%d
+		OFFSET $%d
+	`, orderByClause, len(args)+1, len(args)+2)
+	args = append(args, paginationArgs.Limit, paginationArgs.Offset)
+
+	return query, args, nil
+}
+
+func (p *postgresProductAccessor) GetProducts(ctx context.Context, spec GetProductsSpec) (*AccessorGetProductsPaginationData, error) {
+	filters := nameListFilters(spec.Name)
+	switch {
+	case spec.ProductCategoryID != "" && spec.IncludeSubcategories:
+		filters = append(filters, catalogueListFilter{
+			condition: "product_category_id IN (" + categorySubtreeIDsQuery("$%d") + ")",
+			value:     spec.ProductCategoryID,
+		})
+	case spec.ProductCategoryID != "":
+		filters = append(filters, catalogueListFilter{condition: "product_category_id = $%d", value: spec.ProductCategoryID})
+	}
+	if spec.ProductTypeID != "" {
+		filters = append(filters, catalogueListFilter{condition: "product_type_id = $%d", value: spec.ProductTypeID})
+	}
+
+	query, args, err := buildCatalogueListQuery(getProductsQuery, filters, spec.PaginationSpec)
+	if err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	rows := []struct {
+		Product
+		TotalEntries int `db:"total_entries"`
+	}{}
+	if err := p.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	res := &AccessorGetProductsPaginationData{Products: []Product{}}
+	totalEntries := 0
+	for _, row := range rows {
+		totalEntries = row.TotalEntries
+		res.Products = append(res.Products, row.Product)
+	}
+	res.Metadata = database.GeneratePaginationMetadata(spec.PaginationSpec, totalEntries)
+	return res, nil
+}
+
+func (p *postgresProductAccessor) GetProductCategories(
+	ctx context.Context,
+	spec GetProductCategoriesSpec,
+) (*AccessorGetProductCategoriesPaginationData, error) {
+	filters := nameListFilters(spec.Name)
+	if spec.ParentID != "" {
+		filters = append(filters, catalogueListFilter{condition: "parent_id = $%d", value: spec.ParentID})
+	}
+
+	query, args, err := buildCatalogueListQuery(getProductCategoriesQuery, filters, spec.PaginationSpec)
+	if err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	rows := []struct {
+		ProductCategory
+		TotalEntries int `db:"total_entries"`
+	}{}
+	if err := p.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	res := &AccessorGetProductCategoriesPaginationData{ProductCategories: []ProductCategory{}}
+	totalEntries := 0
+	for _, row := range rows {
+		totalEntries = row.TotalEntries
+		res.ProductCategories = append(res.ProductCategories, row.ProductCategory)
+	}
+	res.Metadata = database.GeneratePaginationMetadata(spec.PaginationSpec, totalEntries)
+	return res, nil
+}
+
+func (p *postgresProductAccessor) GetProductTypes(ctx context.Context, spec GetCatalogueSpec) (*AccessorGetProductTypesPaginationData, error) {
+	query, args, err := buildCatalogueListQuery(getProductTypesQuery, nameListFilters(spec.Name), spec.PaginationSpec)
+	if err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	rows := []struct {
+		ProductType
+		TotalEntries int `db:"total_entries"`
+	}{}
+	if err := p.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	res := &AccessorGetProductTypesPaginationData{ProductTypes: []ProductType{}}
+	totalEntries := 0
+	for _, row := range rows {
+		totalEntries = row.TotalEntries
+		res.ProductTypes = append(res.ProductTypes, row.ProductType)
+	}
+	res.Metadata = database.GeneratePaginationMetadata(spec.PaginationSpec, totalEntries)
+	return res, nil
+}
+
+func (p *postgresProductAccessor) GetUOMs(ctx context.Context, spec GetCatalogueSpec) (*AccessorGetUOMsPaginationData, error) {
+	query, args, err := buildCatalogueListQuery(getUOMsQuery, nameListFilters(spec.Name), spec.PaginationSpec)
+	if err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	rows := []struct {
+		UOM
+		TotalEntries int `db:"total_entries"`
+	}{}
+	if err := p.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	res := &AccessorGetUOMsPaginationData{UOMs: []UOM{}}
+	totalEntries := 0
+	for _, row := range rows {
+		totalEntries = row.TotalEntries
+		res.UOMs = append(res.UOMs, row.UOM)
+	}
+	res.Metadata = database.GeneratePaginationMetadata(spec.PaginationSpec, totalEntries)
+	return res, nil
+}
+
+func (p *postgresProductAccessor) CreateProduct(ctx context.Context, product Product) error {
+	return p.audited(ctx, audit.EntityProduct, []string{string(product.ID)}, product.ModifiedBy, func(tx *postgresProductAccessor) error {
+		if _, err := tx.db.NamedExecContext(ctx, insertProduct, product); err != nil {
+			utils.Logger.Error(err.Error())
+			return err
+		}
+		return nil
+	})
+}
+
+func (p *postgresProductAccessor) CreateProductCategory(ctx context.Context, category ProductCategory) error {
+	if _, err := p.db.NamedExecContext(ctx, insertProductCategory, category); err != nil {
+		utils.Logger.Error(err.Error())
+		return err
+	}
+	return nil
+}
+
+func (p *postgresProductAccessor) CreateProductType(ctx context.Context, productType ProductType) error {
+	if _, err := p.db.NamedExecContext(ctx, insertProductType, productType); err != nil {
+		utils.Logger.Error(err.Error())
+		return err
+	}
+	return nil
+}
+
+func (p *postgresProductAccessor) CreateUOM(ctx context.Context, uom UOM) error {
+	if _, err := p.db.NamedExecContext(ctx, insertUOM, uom); err != nil {
+		utils.Logger.Error(err.Error())
+		return err
+	}
+	return nil
+}
+
+// softDelete marks the entry as deleted in a single statement that also checks its
+// references. The check only sees committed references, one added by a transaction
+// still running isn't guarded against. When nothing was deleted the references are
+// counted to tell a missing entry from one in use
+func (p *postgresProductAccessor) softDelete(ctx context.Context, table catalogueTable, id string, deletedBy string) error {
+	if table.Audit == "" {
+		return p.softDeleteRow(ctx, table, id, deletedBy)
+	}
+	return p.audited(ctx, table.Audit, []string{id}, deletedBy, func(tx *postgresProductAccessor) error {
+		return tx.softDeleteRow(ctx, table, id, deletedBy)
+	})
+}
+
+func (p *postgresProductAccessor) softDeleteRow(ctx context.Context, table catalogueTable, id string, deletedBy string) error {
+	result, err := p.db.ExecContext(ctx, table.softDeleteQuery(), id, p.clock.Now(), deletedBy)
+	if err != nil {
+		utils.Logger.Error(err.Error())
+		return err
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		utils.Logger.Error(err.Error())
+		return err
+	}
+	if deleted > 0 {
+		return nil
+	}
+
+	var (
+		found  bool
+		counts = make([]int, len(table.References))
+		dest   = []interface{}{&found}
+	)
+	for i := range counts {
+		dest = append(dest, &counts[i])
+	}
+	if err := p.db.QueryRowContext(ctx, table.referencesQuery(), id).Scan(dest...); err != nil {
+		utils.Logger.Error(err.Error())
+		return err
+	}
+	if !found {
+		return table.NotFound
+	}
+
+	var inUse []string
+	for i, reference := range table.References {
+		if counts[i] > 0 {
+			inUse = append(inUse, fmt.Sprintf("%d %s", counts[i], reference.Name))
+		}
+	}
+	if len(inUse) == 0 {
+		// the references were removed since the delete ran
+		return ErrCatalogueInUse
+	}
+	return fmt.Errorf("%w: %s is referenced by %s", ErrCatalogueInUse, strings.ReplaceAll(table.Table, "_", " "), strings.Join(inUse, ", "))
+}
+
+// restore clears the deletion of the entry in a single statement that also checks the
+// entries it requires, so it can't come back referring to a deleted one. When nothing
+// was restored the deleted requirements are counted to tell a missing entry from a blocked one
+func (p *postgresProductAccessor) restore(ctx context.Context, table catalogueTable, id string, restoredBy string) error {
+	if table.Audit == "" {
+		return p.restoreRow(ctx, table, id, restoredBy)
+	}
+	return p.audited(ctx, table.Audit, []string{id}, restoredBy, func(tx *postgresProductAccessor) error {
+		return tx.restoreRow(ctx, table, id, restoredBy)
+	})
+}
+
+func (p *postgresProductAccessor) restoreRow(ctx context.Context, table catalogueTable, id string, restoredBy string) error {
+	result, err := p.db.ExecContext(ctx, table.restoreQuery(), id, p.clock.Now(), restoredBy)
+	if err != nil {
+		utils.Logger.Error(err.Error())
+		return err
+	}
+	restored, err := result.RowsAffected()
+	if err != nil {
+		utils.Logger.Error(err.Error())
+		return err
+	}
+	if restored > 0 {
+		return nil
+	}
+
+	var (
+		deleted bool
+		counts  = make([]int, len(table.Requires))
+		dest    = []interface{}{&deleted}
+	)
+	for i := range counts {
+		dest = append(dest, &counts[i])
+	}
+	if err := p.db.QueryRowContext(ctx, table.requiresQuery(), id).Scan(dest...); err != nil {
+		utils.Logger.Error(err.Error())
+		return err
+	}
+	if !deleted {
+		return table.NotFound
+	}
+
+	var blocked []string
+	for i, required := range table.Requires {
+		if counts[i] > 0 {
+			blocked = append(blocked, required.Name)
+		}
+	}
+	if len(blocked) == 0 {
+		// the requirements were restored since the restore ran
+		return ErrCatalogueDeletedReference
+	}
+	return fmt.Errorf("%w: %s refers to a deleted %s", ErrCatalogueDeletedReference, strings.ReplaceAll(table.Table, "_", " "), strings.Join(blocked, ", "))
+}
+
+func (p *postgresProductAccessor) getCategoryTree(ctx context.Context, parentID string) ([]ProductCategory, error) {
+	res := []ProductCategory{}
+	if err := p.db.SelectContext(ctx, &res, getCategoryTreeQuery, parentID); err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+	return res, nil
+}
+
+func (p *postgresProductAccessor) getCategorySubtree(ctx context.Context, categoryID string) ([]ProductCategory, error) {
+	res := []ProductCategory{}
+	if err := p.db.SelectContext(ctx, &res, getCategorySubtreeQuery, categoryID); err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+	return res, nil
+}
+
+func (p *postgresProductAccessor) getCategoryAncestors(ctx context.Context, categoryID string) ([]ProductCategory, error) {
+	res := []ProductCategory{}
+	if err := p.db.SelectContext(ctx, &res, getCategoryAncestorsQuery, categoryID); err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+	return res, nil
+}
+
+// moveProductCategory sets the parent of a category, when nothing was moved the
+// move is checked again to tell which of the guards rejected it
+func (p *postgresProductAccessor) moveProductCategory(ctx context.Context, categoryID string, parentID string, modifiedBy string) error {
+	result, err := p.db.ExecContext(ctx, moveProductCategoryQuery, categoryID, parentID, p.clock.Now(), modifiedBy)
+	if err != nil {
+		utils.Logger.Error(err.Error())
+		return err
+	}
+	moved, err := result.RowsAffected()
+	if err != nil {
+		utils.Logger.Error(err.Error())
+		return err
+	}
+	if moved > 0 {
+		return nil
+	}
+
+	var found, parentFound, cycle bool
+	if err := p.db.QueryRowContext(ctx, checkProductCategoryMoveQuery, categoryID, parentID).Scan(&found, &parentFound, &cycle); err != nil {
+		utils.Logger.Error(err.Error())
+		return err
+	}
+	switch {
+	case !found:
+		return ErrProductCategoryNotFound
+	case !parentFound:
+		return fmt.Errorf("%w: %s: %s", ErrInvalidReference, ErrProductCategoryNotFound.Error(), parentID)
+	case cycle:
+		return ErrCategoryCycle
+	}
+	// the category or its parent changed since the move ran
+	return fmt.Errorf("product category %s was modified concurrently", categoryID)
+}
+
+func (p *postgresProductAccessor) writeProduct(ctx context.Context, product Product) error {
+	if _, err := p.db.NamedExecContext(ctx, upsertProduct, product); err != nil {
+		utils.Logger.Errorf("failed upserting product: %s", product.ID)
+		return err
+	}
+	return nil
+}
+
+func (p *postgresProductAccessor) writeProductCategory(ctx context.Context, category ProductCategory) error {
+	if _, err := p.db.NamedExecContext(ctx, upsertProductCategory, category); err != nil {
+		utils.Logger.Errorf("failed upserting product category: %s", category.ID)
+		return err
+	}
+	return nil
+}
+
+func (p *postgresProductAccessor) writeProductType(ctx context.Context, pType ProductType) error {
+	if _, err := p.db.NamedExecContext(ctx, upsertProductType, pType); err != nil {
+		utils.Logger.Errorf("failed upserting product type: %s", pType.ID)
+		return err
+	}
+	return nil
+}
+
+func (p *postgresProductAccessor) writeUOM(ctx context.Context, uom UOM) error {
+	if _, err := p.db.NamedExecContext(ctx, upsertUOM, uom); err != nil {
+		utils.Logger.Errorf("failed upserting uom: %s", uom.ID)
 		return err
 	}
 	return nil
 }
 
-func (p *postgresProductAccessor) writeProductCategory(_ context.Context, category ProductCategory) error {
-	if _, err := p.db.NamedExec(insertProductCategory, category); err != nil {
-		utils.Logger.Errorf("failed inserting product category: %s", category.ID)
+func (p *postgresProductAccessor) writeProductVendor(ctx context.Context, pv ProductVendor) error {
+	if _, err := p.db.NamedExecContext(ctx, upsertProductVendor, pv); err != nil {
+		utils.Logger.Errorf("failed upserting product_vendor: %s, product_id: %s", pv.ID, pv.ProductID)
 		return err
 	}
 	return nil
 }
 
-func (p *postgresProductAccessor) writeProductType(_ context.Context, pType ProductType) error {
-	if _, err := p.db.NamedExec(insertProductType, pType); err != nil {
-		utils.Logger.Errorf("failed inserting product type: %s", pType.ID)
+func (p *postgresProductAccessor) writePrice(ctx context.Context, price Price) error {
+	if _, err := p.db.NamedExecContext(ctx, upsertPrice, price); err != nil {
+		utils.Logger.Errorf("failed upserting price: %s", price.ID)
 		return err
 	}
 	return nil
 }
 
-func (p *postgresProductAccessor) writeUOM(_ context.Context, uom UOM) error {
-	if _, err := p.db.NamedExec(insertUOM, uom); err != nil {
-		utils.Logger.Errorf("failed inserting uom: %s", uom.ID)
+func (p *postgresProductAccessor) CreateProductVendor(ctx context.Context, productVendor ProductVendor) error {
+	if _, err := p.db.NamedExecContext(ctx, insertProductVendor, productVendor); err != nil {
+		utils.Logger.Error(err.Error())
 		return err
 	}
 	return nil
 }
 
-func (p *postgresProductAccessor) writeProductVendor(_ context.Context, pv ProductVendor) error {
-	if _, err := p.db.NamedExec(insertProductVendor, pv); err != nil {
-		utils.Logger.Errorf("failed inserting product_vendor: %s, product_id: %s", pv.ID, pv.ProductID)
+func (p *postgresProductAccessor) CreatePrice(ctx context.Context, price Price) error {
+	payload, err := json.Marshal(priceImportRecord(price))
+	if err != nil {
+		utils.Logger.Error(err.Error())
+		return err
+	}
+	return p.audited(ctx, audit.EntityPrice, []string{price.ID}, price.ModifiedBy, func(tx *postgresProductAccessor) error {
+		if _, err := tx.db.ExecContext(ctx, createPriceQuery, string(payload)); err != nil {
+			utils.Logger.Error(err.Error())
+			return err
+		}
+		return nil
+	})
+}
+
+// getPeerPrices returns the prices in effect at the given date of the other product
+// vendors offering the same product, keyed by each given product vendor
+func (p *postgresProductAccessor) getPeerPrices(ctx context.Context, productVendorIDs []string, at time.Time) (map[string][]Price, error) {
+	res := map[string][]Price{}
+	if len(productVendorIDs) == 0 {
+		return res, nil
+	}
+
+	rows := []struct {
+		ForProductVendorID string `db:"for_product_vendor_id"`
+		Price
+	}{}
+	if err := p.db.SelectContext(ctx, &rows, getPeerPricesQuery, pq.Array(productVendorIDs), at); err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+	for _, row := range rows {
+		res[row.ForProductVendorID] = append(res[row.ForProductVendorID], row.Price)
+	}
+	return res, nil
+}
+
+func (p *postgresProductAccessor) WritePriceAnomalies(ctx context.Context, anomalies []PriceAnomaly) error {
+	if len(anomalies) == 0 {
+		return nil
+	}
+	if _, err := p.db.NamedExecContext(ctx, insertPriceAnomalyQuery, anomalies); err != nil {
+		utils.Logger.Error(err.Error())
 		return err
 	}
 	return nil
 }
 
-func (p *postgresProductAccessor) writePrice(_ context.Context, price Price) error {
-	if _, err := p.db.NamedExec(insertPrice, price); err != nil {
-		utils.Logger.Errorf("failed inserting price: %s", price.ID)
+// UpdatePriceAnomalyReview records the review of a pending anomaly, ErrPriceAnomalyNotPending
+// is returned when it was reviewed in the meantime
+func (p *postgresProductAccessor) UpdatePriceAnomalyReview(ctx context.Context, anomaly PriceAnomaly) error {
+	result, err := p.db.NamedExecContext(ctx, updatePriceAnomalyReviewQuery, anomaly)
+	if err != nil {
+		utils.Logger.Error(err.Error())
 		return err
 	}
+	reviewed, err := result.RowsAffected()
+	if err != nil {
+		utils.Logger.Error(err.Error())
+		return err
+	}
+	if reviewed == 0 {
+		return ErrPriceAnomalyNotPending
+	}
 	return nil
 }
 
+// lockPriceAnomaly returns the anomaly and locks it for the rest of the transaction
+func (p *postgresProductAccessor) lockPriceAnomaly(ctx context.Context, id string) (*PriceAnomaly, error) {
+	anomaly := PriceAnomaly{}
+	if err := p.db.QueryRowxContext(ctx, lockPriceAnomalyQuery, id).StructScan(&anomaly); err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+	return &anomaly, nil
+}
+
+type priceAnomalyRow struct {
+	PriceAnomaly
+	TotalEntries int `db:"total_entries"`
+}
+
+func (p *postgresProductAccessor) GetPriceAnomalies(
+	ctx context.Context,
+	spec GetPriceAnomaliesSpec,
+) (*AccessorGetPriceAnomaliesPaginationData, error) {
+	paginationArgs := database.BuildPaginationArgs(spec.PaginationSpec)
+
+	rows, err := p.db.QueryxContext(ctx, getPriceAnomaliesQuery, spec.Status, spec.ProductVendorID, paginationArgs.Limit, paginationArgs.Offset)
+	if err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	totalEntries := 0
+	res := []PriceAnomaly{}
+	for rows.Next() {
+		var row priceAnomalyRow
+		if err := rows.StructScan(&row); err != nil {
+			utils.Logger.Error(err.Error())
+			return nil, err
+		}
+		totalEntries = row.TotalEntries
+		res = append(res, row.PriceAnomaly)
+	}
+	if err := rows.Err(); err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	return &AccessorGetPriceAnomaliesPaginationData{
+		PriceAnomalies: res,
+		Metadata:       database.GeneratePaginationMetadata(spec.PaginationSpec, totalEntries),
+	}, nil
+}
+
 func (p *postgresProductAccessor) Close() error {
 	return p.db.Close()
 }
 
 // newPostgresProductAccessor is only accessible by the Product package
 // entrypoint for other verticals should refer to the interface declared on service
+// runInTx runs fn as a unit of work, the accessor fn gets writes in its transaction
+func (p *postgresProductAccessor) runInTx(ctx context.Context, fn func(tx productDBAccessor) error) error {
+	return database.RunInTx(ctx, p.db, func(tx database.DBConnector) error {
+		return fn(newPostgresProductAccessor(tx, p.clock))
+	})
+}
+
+// audited runs write on an accessor of its transaction, recording the changes it
+// makes to the rows of entity among ids on the audit log
+func (p *postgresProductAccessor) audited(
+	ctx context.Context,
+	entity audit.Entity,
+	ids []string,
+	actor string,
+	write func(tx *postgresProductAccessor) error,
+) error {
+	return audit.Track(ctx, p.db, p.clock, entity, ids, actor, func(tx database.DBConnector) error {
+		return write(newPostgresProductAccessor(tx, p.clock))
+	})
+}
+
 func newPostgresProductAccessor(db database.DBConnector, clock clock.Clock) *postgresProductAccessor {
 	return &postgresProductAccessor{
 		db:    db,
@@ -4,8 +4,10 @@ import (
 	"context"
 	"database/sql"
 	"database/sql/driver"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"kg/procurement/internal/audit"
 	"kg/procurement/internal/common/database"
 	"log"
 	"regexp"
@@ -16,6 +18,7 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/benbjohnson/clock"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/onsi/gomega"
 )
 
@@ -90,17 +93,19 @@ func Test_UpdateProduct(t *testing.T) {
 				ModifiedDate:      now,
 			}
 
-			c.mock.ExpectQuery(updateProduct).
-				WithArgs(
-					updatedProduct.ID,
-					updatedProduct.ProductCategoryID,
-					updatedProduct.UOMID,
-					updatedProduct.IncomeTaxID,
-					updatedProduct.ProductTypeID,
-					updatedProduct.Name,
-					updatedProduct.Description,
-					now,
-				).WillReturnRows(expectedResult)
+			expectAudited(c.mock, audit.EntityProduct, "inv1", `{"id":"inv1","name":"Product A"}`, `{"id":"inv1","name":"Product A Updated"}`, func() {
+				c.mock.ExpectQuery(updateProduct).
+					WithArgs(
+						updatedProduct.ID,
+						updatedProduct.ProductCategoryID,
+						updatedProduct.UOMID,
+						updatedProduct.IncomeTaxID,
+						updatedProduct.ProductTypeID,
+						updatedProduct.Name,
+						updatedProduct.Description,
+						now,
+					).WillReturnRows(expectedResult)
+			})
 
 			res, err := c.accessor.UpdateProduct(ctx, updatedProduct)
 
@@ -179,61 +184,75 @@ func Test_UpdatePrice(t *testing.T) {
 		"modified_by",
 	}
 
-	query := `UPDATE price
-        SET 
-            purchasing_org_id = $2,
-            vendor_id = $3,
-            product_vendor_id = $4,
-            quantity_min = $5,
-            quantity_max = $6,
-            quantity_uom_id = $7,
-            lead_time_min = $8,
-            lead_time_max = $9,
-            currency_id = $10,
-            price = $11,
-            price_quantity = $12,
-            price_uom_id = $13,
-            valid_from = $14,
-            valid_to = $15,
-            valid_pattern_id = $16,
-            area_group_id = $17,
-            reference_number = $18,
-            reference_date = $19,
-            document_type_id = $20,
-            document_id = $21,
-            item_id = $22,
-            term_of_payment_id = $23,
-            invocation_order = $24,
-            modified_date = $25
-        WHERE 
-            id = $1
-        RETURNING 
-            id,
-            purchasing_org_id,
-            vendor_id,
-            product_vendor_id,
-            quantity_min,
-            quantity_max,
-            quantity_uom_id,
-            lead_time_min,
-            lead_time_max,
-            currency_id,
-            price,
-            price_quantity,
-            price_uom_id,
-            valid_from,
-            valid_to,
-            valid_pattern_id,
-            area_group_id,
-            reference_number,
-            reference_date,
-            document_type_id,
-            document_id,
-            item_id,
-            term_of_payment_id,
-            invocation_order,
-            modified_date,
-            modified_by
+	query := `WITH previous AS (
+            SELECT id, price FROM price WHERE id = $1 AND deleted_at IS NULL
+        ),
+        updated AS (
+            UPDATE price
+            SET 
+                purchasing_org_id = $2,
+                vendor_id = $3,
+                product_vendor_id = $4,
+                quantity_min = $5,
+                quantity_max = $6,
+                quantity_uom_id = $7,
+                lead_time_min = $8,
+                lead_time_max = $9,
+                currency_id = $10,
+                price = $11,
+                price_quantity = $12,
+                price_uom_id = $13,
+                valid_from = $14,
+                valid_to = $15,
+                valid_pattern_id = $16,
+                area_group_id = $17,
+                reference_number = $18,
+                reference_date = $19,
+                document_type_id = $20,
+                document_id = $21,
+                item_id = $22,
+                term_of_payment_id = $23,
+                invocation_order = $24,
+                modified_date = $25
+            WHERE 
+                id = $1 AND deleted_at IS NULL
+            RETURNING 
+                id,
+                purchasing_org_id,
+                vendor_id,
+                product_vendor_id,
+                quantity_min,
+                quantity_max,
+                quantity_uom_id,
+                lead_time_min,
+                lead_time_max,
+                currency_id,
+                price,
+                price_quantity,
+                price_uom_id,
+                valid_from,
+                valid_to,
+                valid_pattern_id,
+                area_group_id,
+                reference_number,
+                reference_date,
+                document_type_id,
+                document_id,
+                item_id,
+                term_of_payment_id,
+                invocation_order,
+                modified_date,
+                modified_by
+        ),
+        history AS (
+            INSERT INTO price_history
+                (id, price_id, product_vendor_id, vendor_id, old_price, new_price, currency_id, price_quantity, price_uom_id, valid_from, valid_to, document_type_id, document_id, reference_number, changed_by, reason, modified_date)
+            SELECT
+                $26, u.id, u.product_vendor_id, u.vendor_id, pr.price, u.price, u.currency_id, u.price_quantity, u.price_uom_id, u.valid_from, u.valid_to, u.document_type_id, u.document_id, u.reference_number, $27, $28, $25
+            FROM updated u
+            JOIN previous pr ON pr.id = u.id
+        )
+        SELECT * FROM updated
     `
 	fixedTime := time.Now()
 	updatedFixedTime := time.Now().Add(1 * time.Hour)
@@ -293,11 +312,11 @@ func Test_UpdatePrice(t *testing.T) {
 			PriceQuantity:   1,
 			PriceUOMID:      "price_uom_id_updated",
 			ValidFrom:       fixedTime,
-			ValidTo:         updatedFixedTime,
+			ValidTo:         &updatedFixedTime,
 			ValidPatternID:  "valid_pattern_id_updated",
 			AreaGroupID:     "area_group_id_updated",
 			ReferenceNumber: "reference_number_updated",
-			ReferenceDate:   fixedTime,
+			ReferenceDate:   &fixedTime,
 			DocumentTypeID:  "document_type_id_updated",
 			DocumentID:      "document_id_updated",
 			ItemID:          "item_id_updated",
@@ -307,36 +326,45 @@ func Test_UpdatePrice(t *testing.T) {
 			ModifiedBy:      "modified_by_updated",
 		}
 
-		c.mock.ExpectQuery(query).
-			WithArgs(
-				"ID",
-				updatedPrice.PurchasingOrgID,
-				updatedPrice.VendorID,
-				updatedPrice.ProductVendorID,
-				updatedPrice.QuantityMin,
-				updatedPrice.QuantityMax,
-				updatedPrice.QuantityUOMID,
-				updatedPrice.LeadTimeMin,
-				updatedPrice.LeadTimeMax,
-				updatedPrice.CurrencyID,
-				updatedPrice.Price,
-				updatedPrice.PriceQuantity,
-				updatedPrice.PriceUOMID,
-				updatedPrice.ValidFrom,
-				updatedPrice.ValidTo,
-				updatedPrice.ValidPatternID,
-				updatedPrice.AreaGroupID,
-				updatedPrice.ReferenceNumber,
-				updatedPrice.ReferenceDate,
-				updatedPrice.DocumentTypeID,
-				updatedPrice.DocumentID,
-				updatedPrice.ItemID,
-				updatedPrice.TermOfPaymentID,
-				updatedPrice.InvocationOrder,
-				now,
-			).WillReturnRows(expectedResult)
+		expectAudited(c.mock, audit.EntityPrice, "ID", `{"id":"ID","price":10}`, `{"id":"ID","price":99.99}`, func() {
+			c.mock.ExpectQuery(query).
+				WithArgs(
+					"ID",
+					updatedPrice.PurchasingOrgID,
+					updatedPrice.VendorID,
+					updatedPrice.ProductVendorID,
+					updatedPrice.QuantityMin,
+					updatedPrice.QuantityMax,
+					updatedPrice.QuantityUOMID,
+					updatedPrice.LeadTimeMin,
+					updatedPrice.LeadTimeMax,
+					updatedPrice.CurrencyID,
+					updatedPrice.Price,
+					updatedPrice.PriceQuantity,
+					updatedPrice.PriceUOMID,
+					updatedPrice.ValidFrom,
+					updatedPrice.ValidTo,
+					updatedPrice.ValidPatternID,
+					updatedPrice.AreaGroupID,
+					updatedPrice.ReferenceNumber,
+					updatedPrice.ReferenceDate,
+					updatedPrice.DocumentTypeID,
+					updatedPrice.DocumentID,
+					updatedPrice.ItemID,
+					updatedPrice.TermOfPaymentID,
+					updatedPrice.InvocationOrder,
+					now,
+					"HISTORY_ID",
+					"buyer",
+					"new quotation",
+				).WillReturnRows(expectedResult)
+		})
 
-		res, err := c.accessor.UpdatePrice(ctx, updatedPrice)
+		res, err := c.accessor.UpdatePrice(ctx, updatedPrice, PriceChange{
+			HistoryID: "HISTORY_ID",
+			ChangedBy: "buyer",
+			Reason:    "new quotation",
+		})
 
 		c.g.Expect(err).To(gomega.BeNil())
 		c.g.Expect(res).To(gomega.Equal(updatedPrice))
@@ -407,7 +435,7 @@ func Test_UpdatePrice(t *testing.T) {
 				updatedFixedTime,
 			).WillReturnRows(expectedResult)
 
-		res, err := c.accessor.UpdatePrice(ctx, Price{})
+		res, err := c.accessor.UpdatePrice(ctx, Price{}, PriceChange{})
 
 		c.g.Expect(err).ToNot(gomega.BeNil())
 		c.g.Expect(res).To(gomega.Equal(Price{}))
@@ -461,7 +489,7 @@ func Test_GetProductVendorsByVendor(t *testing.T) {
 		countQuery := `SELECT COUNT(*)
 			FROM product_vendor pv
 			JOIN price pr ON pr.product_vendor_id = pv.id
-			WHERE pr.vendor_id = $1
+			WHERE pr.vendor_id = $1 AND pv.deleted_at IS NULL AND pr.deleted_at IS NULL
 		`
 		c.mock.ExpectQuery(countQuery).
 			WithArgs(vendorID).
@@ -503,7 +531,7 @@ func Test_GetProductVendorsByVendor(t *testing.T) {
 		countQuery := `SELECT COUNT(*)
 			FROM product_vendor pv
 			JOIN price pr ON pr.product_vendor_id = pv.id
-			WHERE pr.vendor_id = $1`
+			WHERE pr.vendor_id = $1 AND pv.deleted_at IS NULL AND pr.deleted_at IS NULL`
 
 		c.mock.ExpectQuery(countQuery+" AND pv.name iLIKE $2 AND pv.name iLIKE $3").
 			WithArgs(vendorID, "%"+productNameList[0]+"%", "%"+productNameList[1]+"%").
@@ -548,7 +576,7 @@ func Test_GetProductVendorsByVendor(t *testing.T) {
 		countQuery := `SELECT COUNT(*)
 			FROM product_vendor pv
 			JOIN price pr ON pr.product_vendor_id = pv.id
-			WHERE pr.vendor_id = $1
+			WHERE pr.vendor_id = $1 AND pv.deleted_at IS NULL AND pr.deleted_at IS NULL
 		`
 
 		c.mock.ExpectQuery(countQuery).
@@ -596,7 +624,7 @@ func Test_GetProductVendorsByVendor(t *testing.T) {
 		countQuery := `SELECT COUNT(*)
 			FROM product_vendor pv
 			JOIN price pr ON pr.product_vendor_id = pv.id
-			WHERE pr.vendor_id = $1
+			WHERE pr.vendor_id = $1 AND pv.deleted_at IS NULL AND pr.deleted_at IS NULL
 		`
 
 		c.mock.ExpectQuery(countQuery+" AND pv.name iLIKE $2 AND pv.name iLIKE $3").
@@ -695,7 +723,7 @@ func Test_GetProductVendorsByVendor(t *testing.T) {
 		countQuery := `SELECT COUNT(*)
 			FROM product_vendor pv
 			JOIN price pr ON pr.product_vendor_id = pv.id
-			WHERE pr.vendor_id = $1
+			WHERE pr.vendor_id = $1 AND pv.deleted_at IS NULL AND pr.deleted_at IS NULL
 		`
 
 		c.mock.ExpectQuery(countQuery).
@@ -708,7 +736,7 @@ func Test_GetProductVendorsByVendor(t *testing.T) {
 	})
 }
 
-func Test_getPriceByPVID(t *testing.T) {
+func Test_getPricesByPVID(t *testing.T) {
 	t.Parallel()
 
 	var (
@@ -739,9 +767,9 @@ func Test_getPriceByPVID(t *testing.T) {
 		ID:            "1",
 		Price:         100.0,
 		ValidFrom:     now,
-		ValidTo:       now,
+		ValidTo:       &now,
 		ModifiedDate:  now,
-		ReferenceDate: now,
+		ReferenceDate: &now,
 	}
 
 	t.Run("success", func(t *testing.T) {
@@ -750,33 +778,49 @@ func Test_getPriceByPVID(t *testing.T) {
 
 		rows := sqlmock.NewRows(priceFields).
 			AddRow(
-				price.ID, "", "", "", "", 0, 0, 0, 0, "", "", "", 100.0, 0, "", price.ValidFrom, price.ValidTo,
-				"", "", "", "", "", price.ReferenceDate, "", "", "", "", "", 0, "", 0, price.ModifiedDate, "")
+				price.ID, "", "", "", "", 0, 0, 0, 0, "", "", "", 100.0, 0, "", price.ValidFrom, *price.ValidTo,
+				"", "", "", "", "", *price.ReferenceDate, "", "", "", "", "", 0, "", 0, price.ModifiedDate, "")
 
-		mock.ExpectQuery(getPriceByPVIDQuery).
+		mock.ExpectQuery(getPricesByPVIDQuery).
 			WithArgs("1").
 			WillReturnRows(rows)
 
 		ctx := context.Background()
-		res, err := accessor.getPriceByPVID(ctx, "1")
+		res, err := accessor.getPricesByPVID(ctx, "1")
 
 		g.Expect(err).To(gomega.BeNil())
-		g.Expect(res).To(gomega.Equal(price))
+		g.Expect(res).To(gomega.Equal([]Price{*price}))
 	})
 
-	t.Run("error on row scan", func(t *testing.T) {
+	t.Run("reads an open-ended price", func(t *testing.T) {
 		g, db := setup(t)
 		defer db.Close()
 
 		rows := sqlmock.NewRows(priceFields).
-			RowError(1, fmt.Errorf("error"))
+			AddRow(
+				price.ID, "", "", "", "", 0, 0, 0, 0, "", "", "", 100.0, 0, "", price.ValidFrom, nil,
+				"", "", "", "", "", nil, "", "", "", "", "", 0, "", 0, price.ModifiedDate, "")
 
-		mock.ExpectQuery(getPriceByPVIDQuery).
+		mock.ExpectQuery(getPricesByPVIDQuery).
 			WithArgs("1").
 			WillReturnRows(rows)
 
+		res, err := accessor.getPricesByPVID(context.Background(), "1")
+
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(res).To(gomega.Equal([]Price{{ID: "1", Price: 100.0, ValidFrom: now, ModifiedDate: now}}))
+	})
+
+	t.Run("error on row scan", func(t *testing.T) {
+		g, db := setup(t)
+		defer db.Close()
+
+		mock.ExpectQuery(getPricesByPVIDQuery).
+			WithArgs("1").
+			WillReturnError(fmt.Errorf("error"))
+
 		ctx := context.Background()
-		res, err := accessor.getPriceByPVID(ctx, "1")
+		res, err := accessor.getPricesByPVID(ctx, "1")
 
 		g.Expect(err).ToNot(gomega.BeNil())
 		g.Expect(res).To(gomega.BeNil())
@@ -1084,7 +1128,7 @@ func Test_GetAllProductVendors(t *testing.T) {
 			)
 		}
 
-		query := getProductVendorsQuery + " LIMIT :limit OFFSET :offset"
+		query := getProductVendorsQuery + " WHERE pv.deleted_at IS NULL LIMIT :limit OFFSET :offset"
 		transformedQuery, args, _ := sqlx.Named(query, map[string]interface{}{
 			"limit":  args.Limit,
 			"offset": args.Offset,
@@ -1100,7 +1144,7 @@ func Test_GetAllProductVendors(t *testing.T) {
 			).WillReturnRows(expectedResult)
 
 		totalRows := sqlmock.NewRows([]string{"count"}).AddRow(2)
-		c.mock.ExpectQuery(countProductVendorsQuery).
+		c.mock.ExpectQuery(countProductVendorsQuery + " WHERE pv.deleted_at IS NULL").
 			WillReturnRows(totalRows)
 
 		expect := &AccessorGetProductVendorsPaginationData{
@@ -1146,7 +1190,7 @@ func Test_GetAllProductVendors(t *testing.T) {
 			)
 		}
 
-		query := getProductVendorsQuery + " WHERE pv.name iLIKE :name0 LIMIT :limit OFFSET :offset"
+		query := getProductVendorsQuery + " WHERE pv.deleted_at IS NULL AND pv.name iLIKE :name0 LIMIT :limit OFFSET :offset"
 		transformedQuery, args, _ := sqlx.Named(query, map[string]interface{}{
 			"limit":  args.Limit,
 			"offset": args.Offset,
@@ -1163,7 +1207,7 @@ func Test_GetAllProductVendors(t *testing.T) {
 			).WillReturnRows(expectedResult)
 
 		totalRows := sqlmock.NewRows([]string{"count"}).AddRow(1)
-		countQuery := countProductVendorsQuery + " WHERE pv.name iLIKE :name0"
+		countQuery := countProductVendorsQuery + " WHERE pv.deleted_at IS NULL AND pv.name iLIKE :name0"
 
 		transformedCountQuery, countArgs, _ := sqlx.Named(countQuery, map[string]interface{}{
 			"name0": "%" + customSpec.Name + "%",
@@ -1224,7 +1268,7 @@ func Test_GetAllProductVendors(t *testing.T) {
 			)
 		}
 
-		query := getProductVendorsQuery + " ORDER BY name ASC LIMIT :limit OFFSET :offset"
+		query := getProductVendorsQuery + " WHERE pv.deleted_at IS NULL ORDER BY name ASC LIMIT :limit OFFSET :offset"
 		transformedQuery, args, _ := sqlx.Named(query, map[string]interface{}{
 			"limit":  args.Limit,
 			"offset": args.Offset,
@@ -1240,7 +1284,7 @@ func Test_GetAllProductVendors(t *testing.T) {
 			).WillReturnRows(expectedResult)
 
 		totalRows := sqlmock.NewRows([]string{"count"}).AddRow(2)
-		c.mock.ExpectQuery(countProductVendorsQuery).
+		c.mock.ExpectQuery(countProductVendorsQuery + " WHERE pv.deleted_at IS NULL").
 			WillReturnRows(totalRows)
 
 		expect := &AccessorGetProductVendorsPaginationData{
@@ -1289,7 +1333,7 @@ func Test_GetAllProductVendors(t *testing.T) {
 			)
 		}
 
-		query := getProductVendorsQuery + " WHERE pv.name iLIKE :name0 ORDER BY name ASC LIMIT :limit OFFSET :offset"
+		query := getProductVendorsQuery + " WHERE pv.deleted_at IS NULL AND pv.name iLIKE :name0 ORDER BY name ASC LIMIT :limit OFFSET :offset"
 		transformedQuery, args, _ := sqlx.Named(query, map[string]interface{}{
 			"limit":  args.Limit,
 			"offset": args.Offset,
@@ -1306,7 +1350,7 @@ func Test_GetAllProductVendors(t *testing.T) {
 			).WillReturnRows(expectedResult)
 
 		totalRows := sqlmock.NewRows([]string{"count"}).AddRow(1)
-		countQuery := countProductVendorsQuery + " WHERE pv.name iLIKE :name0"
+		countQuery := countProductVendorsQuery + " WHERE pv.deleted_at IS NULL AND pv.name iLIKE :name0"
 
 		transformedCountQuery, countArgs, _ := sqlx.Named(countQuery, map[string]interface{}{
 			"name0": "%" + customSpec.Name + "%",
@@ -1342,7 +1386,7 @@ func Test_GetAllProductVendors(t *testing.T) {
 		)
 		defer c.db.Close()
 
-		query := getProductVendorsQuery + " LIMIT :limit OFFSET :offset"
+		query := getProductVendorsQuery + " WHERE pv.deleted_at IS NULL LIMIT :limit OFFSET :offset"
 		transformedQuery, args, _ := sqlx.Named(query, map[string]interface{}{
 			"limit":  args.Limit,
 			"offset": args.Offset,
@@ -1402,7 +1446,7 @@ func Test_GetAllProductVendors(t *testing.T) {
 			nil,
 		)
 
-		query := getProductVendorsQuery + " LIMIT :limit OFFSET :offset"
+		query := getProductVendorsQuery + " WHERE pv.deleted_at IS NULL LIMIT :limit OFFSET :offset"
 		transformedQuery, args, _ := sqlx.Named(query, map[string]interface{}{
 			"limit":  args.Limit,
 			"offset": args.Offset,
@@ -1449,7 +1493,7 @@ func Test_GetAllProductVendors(t *testing.T) {
 		}
 		expectedResult.RowError(0, fmt.Errorf("some error"))
 
-		query := getProductVendorsQuery + " LIMIT :limit OFFSET :offset"
+		query := getProductVendorsQuery + " WHERE pv.deleted_at IS NULL LIMIT :limit OFFSET :offset"
 		transformedQuery, args, _ := sqlx.Named(query, map[string]interface{}{
 			"limit":  args.Limit,
 			"offset": args.Offset,
@@ -1495,7 +1539,7 @@ func Test_GetAllProductVendors(t *testing.T) {
 			)
 		}
 
-		query := getProductVendorsQuery + " LIMIT :limit OFFSET :offset"
+		query := getProductVendorsQuery + " WHERE pv.deleted_at IS NULL LIMIT :limit OFFSET :offset"
 		transformedQuery, args, _ := sqlx.Named(query, map[string]interface{}{
 			"limit":  args.Limit,
 			"offset": args.Offset,
@@ -1510,7 +1554,7 @@ func Test_GetAllProductVendors(t *testing.T) {
 				driverArgs...,
 			).WillReturnRows(expectedResult)
 
-		c.mock.ExpectQuery(countProductVendorsQuery).
+		c.mock.ExpectQuery(countProductVendorsQuery + " WHERE pv.deleted_at IS NULL").
 			WillReturnError(errors.New("error"))
 
 		res, err := c.accessor.GetAllProductVendors(ctx, spec)
@@ -1543,7 +1587,7 @@ func Test_GetAllProductVendors(t *testing.T) {
 			)
 		}
 
-		query := getProductVendorsQuery + " LIMIT :limit OFFSET :offset"
+		query := getProductVendorsQuery + " WHERE pv.deleted_at IS NULL LIMIT :limit OFFSET :offset"
 		transformedQuery, args, _ := sqlx.Named(query, map[string]interface{}{
 			"limit":  args.Limit,
 			"offset": args.Offset,
@@ -1559,7 +1603,7 @@ func Test_GetAllProductVendors(t *testing.T) {
 			).WillReturnRows(expectedResult)
 
 		totalRows := sqlmock.NewRows([]string{"count"}).AddRow(nil)
-		c.mock.ExpectQuery(countProductVendorsQuery).
+		c.mock.ExpectQuery(countProductVendorsQuery + " WHERE pv.deleted_at IS NULL").
 			WillReturnRows(totalRows)
 
 		res, err := c.accessor.GetAllProductVendors(ctx, spec)
@@ -1592,7 +1636,7 @@ func Test_GetAllProductVendors(t *testing.T) {
 			)
 		}
 
-		query := getProductVendorsQuery + " LIMIT :limit OFFSET :offset"
+		query := getProductVendorsQuery + " WHERE pv.deleted_at IS NULL LIMIT :limit OFFSET :offset"
 		transformedQuery, args, _ := sqlx.Named(query, map[string]interface{}{
 			"limit":  args.Limit,
 			"offset": args.Offset,
@@ -1609,7 +1653,7 @@ func Test_GetAllProductVendors(t *testing.T) {
 
 		totalRows := sqlmock.NewRows([]string{"count"}).AddRow(2).
 			RowError(0, fmt.Errorf("row error"))
-		c.mock.ExpectQuery(countProductVendorsQuery).
+		c.mock.ExpectQuery(countProductVendorsQuery + " WHERE pv.deleted_at IS NULL").
 			WillReturnRows(totalRows)
 
 		res, err := c.accessor.GetAllProductVendors(ctx, spec)
@@ -1629,7 +1673,7 @@ func Test_writeProduct(t *testing.T) {
 			product = Product{ID: "123", ModifiedDate: now}
 		)
 
-		transformedQuery, args, _ := sqlx.Named(insertProduct, product)
+		transformedQuery, args, _ := sqlx.Named(upsertProduct, product)
 		driverArgs := make([]driver.Value, len(args))
 		for i, arg := range args {
 			driverArgs[i] = arg
@@ -1651,7 +1695,7 @@ func Test_writeProduct(t *testing.T) {
 			product = Product{ID: "123", ModifiedDate: now}
 		)
 
-		transformedQuery, args, _ := sqlx.Named(insertProduct, product)
+		transformedQuery, args, _ := sqlx.Named(upsertProduct, product)
 		driverArgs := make([]driver.Value, len(args))
 		for i, arg := range args {
 			driverArgs[i] = arg
@@ -1677,7 +1721,7 @@ func Test_writeProductCategory(t *testing.T) {
 			category = ProductCategory{ID: "123", ModifiedDate: now}
 		)
 
-		transformedQuery, args, _ := sqlx.Named(insertProductCategory, category)
+		transformedQuery, args, _ := sqlx.Named(upsertProductCategory, category)
 		driverArgs := make([]driver.Value, len(args))
 		for i, arg := range args {
 			driverArgs[i] = arg
@@ -1699,7 +1743,7 @@ func Test_writeProductCategory(t *testing.T) {
 			category = ProductCategory{ID: "123", ModifiedDate: now}
 		)
 
-		transformedQuery, args, _ := sqlx.Named(insertProductCategory, category)
+		transformedQuery, args, _ := sqlx.Named(upsertProductCategory, category)
 		driverArgs := make([]driver.Value, len(args))
 		for i, arg := range args {
 			driverArgs[i] = arg
@@ -1725,7 +1769,7 @@ func Test_writeProductType(t *testing.T) {
 			pType = ProductType{ID: "123", ModifiedDate: now}
 		)
 
-		transformedQuery, args, _ := sqlx.Named(insertProductType, pType)
+		transformedQuery, args, _ := sqlx.Named(upsertProductType, pType)
 		driverArgs := make([]driver.Value, len(args))
 		for i, arg := range args {
 			driverArgs[i] = arg
@@ -1747,7 +1791,7 @@ func Test_writeProductType(t *testing.T) {
 			pType = ProductType{ID: "123", ModifiedDate: now}
 		)
 
-		transformedQuery, args, _ := sqlx.Named(insertProductType, pType)
+		transformedQuery, args, _ := sqlx.Named(upsertProductType, pType)
 		driverArgs := make([]driver.Value, len(args))
 		for i, arg := range args {
 			driverArgs[i] = arg
@@ -1773,7 +1817,7 @@ func Test_writeUOM(t *testing.T) {
 			uom = UOM{ID: "123", ModifiedDate: now}
 		)
 
-		transformedQuery, args, _ := sqlx.Named(insertUOM, uom)
+		transformedQuery, args, _ := sqlx.Named(upsertUOM, uom)
 		driverArgs := make([]driver.Value, len(args))
 		for i, arg := range args {
 			driverArgs[i] = arg
@@ -1795,7 +1839,7 @@ func Test_writeUOM(t *testing.T) {
 			uom = UOM{ID: "123", ModifiedDate: now}
 		)
 
-		transformedQuery, args, _ := sqlx.Named(insertUOM, uom)
+		transformedQuery, args, _ := sqlx.Named(upsertUOM, uom)
 		driverArgs := make([]driver.Value, len(args))
 		for i, arg := range args {
 			driverArgs[i] = arg
@@ -1820,7 +1864,7 @@ func Test_writeProductVendor(t *testing.T) {
 			pv  = ProductVendor{ProductID: "123", ID: "321"}
 		)
 
-		transformedQuery, args, _ := sqlx.Named(insertProductVendor, pv)
+		transformedQuery, args, _ := sqlx.Named(upsertProductVendor, pv)
 		driverArgs := make([]driver.Value, len(args))
 		for i, arg := range args {
 			driverArgs[i] = arg
@@ -1841,7 +1885,7 @@ func Test_writeProductVendor(t *testing.T) {
 			pv  = ProductVendor{ProductID: "123", ID: "321"}
 		)
 
-		transformedQuery, args, _ := sqlx.Named(insertProductVendor, pv)
+		transformedQuery, args, _ := sqlx.Named(upsertProductVendor, pv)
 		driverArgs := make([]driver.Value, len(args))
 		for i, arg := range args {
 			driverArgs[i] = arg
@@ -1866,7 +1910,7 @@ func Test_writePrice(t *testing.T) {
 			price = Price{ID: "321"}
 		)
 
-		transformedQuery, args, _ := sqlx.Named(insertPrice, price)
+		transformedQuery, args, _ := sqlx.Named(upsertPrice, price)
 		driverArgs := make([]driver.Value, len(args))
 		for i, arg := range args {
 			driverArgs[i] = arg
@@ -1887,7 +1931,7 @@ func Test_writePrice(t *testing.T) {
 			price = Price{ID: "321"}
 		)
 
-		transformedQuery, args, _ := sqlx.Named(insertPrice, price)
+		transformedQuery, args, _ := sqlx.Named(upsertPrice, price)
 		driverArgs := make([]driver.Value, len(args))
 		for i, arg := range args {
 			driverArgs[i] = arg
@@ -1965,3 +2009,1753 @@ func setupProductAccessorTestComponent(t *testing.T, opts ...Option) productAcce
 		cmock:    clockMock,
 	}
 }
+
+// expectAudited expects the writes of an audited mutation between the snapshots the
+// audit log takes of the row, before and after are the row as json and empty when it
+// is missing. An entry is recorded when they differ
+func expectAudited(mock sqlmock.Sqlmock, entity audit.Entity, id string, before string, after string, writes func()) {
+	snapshot := func(row string) {
+		rows := sqlmock.NewRows([]string{"id", "row"})
+		if row != "" {
+			rows.AddRow(id, []byte(row))
+		}
+		mock.ExpectQuery(audit.SnapshotQuery(entity)).WithArgs(pq.Array([]string{id})).WillReturnRows(rows)
+	}
+
+	mock.ExpectBegin()
+	snapshot(before)
+	writes()
+	snapshot(after)
+	if before != after {
+		insert, _, _ := sqlx.Named(audit.InsertEntryQuery, audit.Entry{})
+		args := make([]driver.Value, 10)
+		for i := range args {
+			args[i] = sqlmock.AnyArg()
+		}
+		mock.ExpectExec(insert).WithArgs(args...).WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	mock.ExpectCommit()
+}
+
+func Test_GetAllProductVendors_WithCursor(t *testing.T) {
+	t.Parallel()
+
+	var (
+		columns      = []string{"id", "name", "cursor_value"}
+		cursorSelect = `
+			SELECT
+				pv.id,
+				pv.product_id,
+				pv.code,
+				pv.name,
+				pv.income_tax_id,
+				pv.income_tax_name,
+				pv.income_tax_percentage,
+				pv.description,
+				pv.uom_id,
+				pv.sap_code,
+				pv.modified_date,
+				pv.modified_by,
+				pv.name AS cursor_value
+			FROM
+				product_vendor pv
+		`
+	)
+
+	t.Run("first page", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		query := cursorSelect + `
+			WHERE pv.deleted_at IS NULL AND pv.name iLIKE :name0
+			ORDER BY pv.name ASC, pv.id ASC
+			LIMIT :limit
+		`
+		transformedQuery, args, _ := sqlx.Named(query, map[string]interface{}{
+			"name0": "%Buku%",
+			"limit": 2,
+		})
+		driverArgs := make([]driver.Value, len(args))
+		for i, arg := range args {
+			driverArgs[i] = arg
+		}
+
+		rows := sqlmock.NewRows(columns).
+			AddRow("1", "Buku Tulis", "Buku Tulis").
+			AddRow("2", "Buku Gambar", "Buku Gambar")
+		c.mock.ExpectQuery(transformedQuery).WithArgs(driverArgs...).WillReturnRows(rows)
+
+		res, err := c.accessor.GetAllProductVendors(context.Background(), GetProductVendorsSpec{
+			Name: "Buku",
+			PaginationSpec: database.PaginationSpec{
+				Mode:    database.PaginationModeCursor,
+				OrderBy: "name",
+				Limit:   1,
+			},
+		})
+
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(res.ProductVendors).To(gomega.Equal([]ProductVendor{{ID: "1", Name: "Buku Tulis"}}))
+		c.g.Expect(res.Metadata.NextCursor).To(gomega.Equal(
+			database.EncodeCursor(database.Cursor{Value: "Buku Tulis", ID: "1"}),
+		))
+		c.g.Expect(c.mock.ExpectationsWereMet()).To(gomega.Succeed())
+	})
+
+	t.Run("previous page", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		query := cursorSelect + `
+			WHERE pv.deleted_at IS NULL AND (pv.name < :cursor_value OR (pv.name IS NOT DISTINCT FROM :cursor_value AND pv.id < :cursor_id) OR (pv.name IS NOT NULL AND :cursor_value IS NULL))
+			ORDER BY pv.name DESC, pv.id DESC
+			LIMIT :limit
+		`
+		transformedQuery, args, _ := sqlx.Named(query, map[string]interface{}{
+			"cursor_value": "Buku Tulis",
+			"cursor_id":    "1",
+			"limit":        2,
+		})
+		driverArgs := make([]driver.Value, len(args))
+		for i, arg := range args {
+			driverArgs[i] = arg
+		}
+
+		rows := sqlmock.NewRows(columns).AddRow("2", "Buku Gambar", "Buku Gambar")
+		c.mock.ExpectQuery(transformedQuery).WithArgs(driverArgs...).WillReturnRows(rows)
+
+		res, err := c.accessor.GetAllProductVendors(context.Background(), GetProductVendorsSpec{
+			PaginationSpec: database.PaginationSpec{
+				OrderBy: "name",
+				Limit:   1,
+				Cursor:  database.EncodeCursor(database.Cursor{Value: "Buku Tulis", ID: "1", Backward: true}),
+			},
+		})
+
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(res.ProductVendors).To(gomega.Equal([]ProductVendor{{ID: "2", Name: "Buku Gambar"}}))
+		c.g.Expect(res.Metadata.NextCursor).To(gomega.Equal(
+			database.EncodeCursor(database.Cursor{Value: "Buku Gambar", ID: "2"}),
+		))
+		c.g.Expect(res.Metadata.PrevCursor).To(gomega.BeEmpty())
+	})
+
+	t.Run("error on unknown sort column", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		res, err := c.accessor.GetAllProductVendors(context.Background(), GetProductVendorsSpec{
+			PaginationSpec: database.PaginationSpec{
+				Mode:    database.PaginationModeCursor,
+				OrderBy: "price",
+			},
+		})
+
+		c.g.Expect(errors.Is(err, database.ErrInvalidSortColumn)).To(gomega.BeTrue())
+		c.g.Expect(res).To(gomega.BeNil())
+	})
+}
+
+func Test_GetPriceHistory(t *testing.T) {
+	t.Parallel()
+
+	var (
+		columns = []string{
+			"id", "price_id", "product_vendor_id", "vendor_id", "old_price", "new_price",
+			"currency_id", "price_quantity", "price_uom_id", "valid_from", "valid_to",
+			"document_type_id", "document_id", "reference_number", "changed_by", "reason",
+			"modified_date", "total_entries",
+		}
+		validFrom    = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+		modifiedDate = time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)
+		oldPrice     = 90.0
+		spec         = GetPriceHistorySpec{
+			PaginationSpec: database.PaginationSpec{Limit: 10, Page: 1},
+		}
+	)
+
+	t.Run("success", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		rows := sqlmock.NewRows(columns).
+			AddRow("2", "P1", "PV1", "V1", oldPrice, 100.0, "IDR", 1, "PCS", validFrom, nil, "DT", "D1", "REF", "buyer", "new quotation", modifiedDate, 2).
+			AddRow("1", "P1", "PV1", "V1", nil, 90.0, "IDR", 1, "PCS", validFrom, nil, "", "", "", "", "created", validFrom, 2)
+		c.mock.ExpectQuery(getPriceHistoryByPVIDQuery).
+			WithArgs("PV1", 10, 0).
+			WillReturnRows(rows)
+
+		res, err := c.accessor.GetPriceHistory(context.Background(), "PV1", spec)
+
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(res.PriceHistory).To(gomega.Equal([]PriceHistory{
+			{
+				ID:              "2",
+				PriceID:         "P1",
+				ProductVendorID: "PV1",
+				VendorID:        "V1",
+				OldPrice:        &oldPrice,
+				NewPrice:        100,
+				CurrencyID:      "IDR",
+				PriceQuantity:   1,
+				PriceUOMID:      "PCS",
+				ValidFrom:       &validFrom,
+				DocumentTypeID:  "DT",
+				DocumentID:      "D1",
+				ReferenceNumber: "REF",
+				ChangedBy:       "buyer",
+				Reason:          "new quotation",
+				ModifiedDate:    modifiedDate,
+			},
+			{
+				ID:              "1",
+				PriceID:         "P1",
+				ProductVendorID: "PV1",
+				VendorID:        "V1",
+				NewPrice:        90,
+				CurrencyID:      "IDR",
+				PriceQuantity:   1,
+				PriceUOMID:      "PCS",
+				ValidFrom:       &validFrom,
+				Reason:          "created",
+				ModifiedDate:    validFrom,
+			},
+		}))
+		c.g.Expect(res.Metadata).To(gomega.Equal(database.PaginationMetadata{
+			TotalPage:    1,
+			CurrentPage:  1,
+			TotalEntries: 2,
+		}))
+	})
+
+	t.Run("error on query", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectQuery(getPriceHistoryByPVIDQuery).
+			WithArgs("PV1", 10, 0).
+			WillReturnError(errors.New("db error"))
+
+		res, err := c.accessor.GetPriceHistory(context.Background(), "PV1", spec)
+
+		c.g.Expect(err).ToNot(gomega.BeNil())
+		c.g.Expect(res).To(gomega.BeNil())
+	})
+
+	t.Run("error on scan", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectQuery(getPriceHistoryByPVIDQuery).
+			WithArgs("PV1", 10, 0).
+			WillReturnRows(sqlmock.NewRows([]string{"unknown"}).AddRow("1"))
+
+		res, err := c.accessor.GetPriceHistory(context.Background(), "PV1", spec)
+
+		c.g.Expect(err).ToNot(gomega.BeNil())
+		c.g.Expect(res).To(gomega.BeNil())
+	})
+}
+
+func Test_GetEffectivePrices(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("success", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		rows := sqlmock.NewRows([]string{"id", "price_id", "product_vendor_id", "new_price", "modified_date"}).
+			AddRow("2", "P1", "PV1", 100.0, at)
+		c.mock.ExpectQuery(getEffectivePricesQuery).
+			WithArgs("PV1", at).
+			WillReturnRows(rows)
+
+		res, err := c.accessor.GetEffectivePrices(context.Background(), "PV1", at)
+
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(res).To(gomega.Equal([]PriceHistory{
+			{ID: "2", PriceID: "P1", ProductVendorID: "PV1", NewPrice: 100, ModifiedDate: at},
+		}))
+	})
+
+	t.Run("a newer version narrowing the window leaves the price out", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		// the latest version per price is picked before its window is checked, so an older
+		// version still covering the date isn't returned in place of the newer one
+		latest, window, _ := strings.Cut(getEffectivePricesQuery, "ORDER BY ph.price_id, ph.modified_date DESC, ph.id DESC")
+		c.g.Expect(latest).To(gomega.ContainSubstring("SELECT DISTINCT ON (ph.price_id) ph.*"))
+		c.g.Expect(latest).To(gomega.ContainSubstring("AND ph.modified_date <= $2"))
+		c.g.Expect(latest).ToNot(gomega.ContainSubstring("valid_"))
+		c.g.Expect(window).To(gomega.ContainSubstring("WHERE l.valid_from <= $2\n\t\t\tAND (l.valid_to IS NULL OR l.valid_to >= $2)"))
+
+		// version 1 was valid from January on, version 2 moved valid_from to April
+		c.mock.ExpectQuery(getEffectivePricesQuery).
+			WithArgs("PV1", at).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "price_id", "product_vendor_id", "new_price", "modified_date"}))
+
+		res, err := c.accessor.GetEffectivePrices(context.Background(), "PV1", at)
+
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(res).To(gomega.BeEmpty())
+		c.g.Expect(c.mock.ExpectationsWereMet()).To(gomega.Succeed())
+	})
+
+	t.Run("error on query", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectQuery(getEffectivePricesQuery).
+			WithArgs("PV1", at).
+			WillReturnError(errors.New("db error"))
+
+		res, err := c.accessor.GetEffectivePrices(context.Background(), "PV1", at)
+
+		c.g.Expect(err).ToNot(gomega.BeNil())
+		c.g.Expect(res).To(gomega.BeNil())
+	})
+}
+
+func Test_getPriceImportReferences(t *testing.T) {
+	t.Parallel()
+
+	keys := priceImportKeys{
+		PriceIDs:         []string{"P1"},
+		ProductVendorIDs: []string{"PV1", "PV2"},
+		VendorIDs:        []string{"V1"},
+		UOMIDs:           []string{"U1"},
+		CurrencyCodes:    []string{"IDR"},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectQuery(getImportPricesQuery).
+			WithArgs(pq.Array([]string{"P1"})).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "product_vendor_id", "price"}).AddRow("P1", "PV1", 100.0))
+		c.mock.ExpectQuery(getExistingProductVendorIDsQuery).
+			WithArgs(pq.Array([]string{"PV1", "PV2"})).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("PV1"))
+		c.mock.ExpectQuery(getExistingVendorIDsQuery).
+			WithArgs(pq.Array([]string{"V1"})).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("V1"))
+		c.mock.ExpectQuery(getExistingUOMIDsQuery).
+			WithArgs(pq.Array([]string{"U1"})).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("U1"))
+		c.mock.ExpectQuery(getImportCurrenciesQuery).
+			WithArgs(pq.Array([]string{"IDR"})).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "code", "name"}).AddRow("C1", "IDR", "Rupiah"))
+
+		res, err := c.accessor.getPriceImportReferences(context.Background(), keys)
+
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(res).To(gomega.Equal(&priceImportReferences{
+			Prices:         map[string]Price{"P1": {ID: "P1", ProductVendorID: "PV1", Price: 100}},
+			ProductVendors: map[string]bool{"PV1": true},
+			Vendors:        map[string]bool{"V1": true},
+			UOMs:           map[string]bool{"U1": true},
+			Currencies:     map[string]importCurrency{"IDR": {ID: "C1", Code: "IDR", Name: "Rupiah"}},
+		}))
+	})
+
+	t.Run("leaves soft deleted entries out", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		// P1, PV2 and U1 were soft deleted, a row referring to them reads as unknown
+		c.mock.ExpectQuery("SELECT * FROM price WHERE id = ANY($1) AND deleted_at IS NULL").
+			WithArgs(pq.Array([]string{"P1"})).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		c.mock.ExpectQuery("SELECT id FROM product_vendor WHERE id = ANY($1) AND deleted_at IS NULL").
+			WithArgs(pq.Array([]string{"PV1", "PV2"})).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("PV1"))
+		c.mock.ExpectQuery("SELECT id FROM vendor WHERE id = ANY($1) AND deleted_at IS NULL").
+			WithArgs(pq.Array([]string{"V1"})).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("V1"))
+		c.mock.ExpectQuery("SELECT id FROM uom WHERE id = ANY($1) AND deleted_at IS NULL").
+			WithArgs(pq.Array([]string{"U1"})).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		c.mock.ExpectQuery(getImportCurrenciesQuery).
+			WithArgs(pq.Array([]string{"IDR"})).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "code", "name"}).AddRow("C1", "IDR", "Rupiah"))
+
+		res, err := c.accessor.getPriceImportReferences(context.Background(), keys)
+
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(res.Prices).To(gomega.BeEmpty())
+		c.g.Expect(res.ProductVendors).To(gomega.Equal(map[string]bool{"PV1": true}))
+		c.g.Expect(res.UOMs).To(gomega.BeEmpty())
+		c.g.Expect(c.mock.ExpectationsWereMet()).To(gomega.Succeed())
+	})
+
+	t.Run("skips lookups without keys", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectQuery(getExistingProductVendorIDsQuery).
+			WithArgs(pq.Array([]string{"PV1"})).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("PV1"))
+
+		res, err := c.accessor.getPriceImportReferences(context.Background(), priceImportKeys{ProductVendorIDs: []string{"PV1"}})
+
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(res.ProductVendors).To(gomega.Equal(map[string]bool{"PV1": true}))
+		c.g.Expect(c.mock.ExpectationsWereMet()).To(gomega.Succeed())
+	})
+
+	t.Run("error on query", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectQuery(getImportPricesQuery).
+			WithArgs(pq.Array([]string{"P1"})).
+			WillReturnError(errors.New("db error"))
+
+		res, err := c.accessor.getPriceImportReferences(context.Background(), keys)
+
+		c.g.Expect(err).ToNot(gomega.BeNil())
+		c.g.Expect(res).To(gomega.BeNil())
+	})
+}
+
+func Test_ImportPrices(t *testing.T) {
+	t.Parallel()
+
+	var (
+		validFrom = time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+		prices    = []Price{{ID: "P1", ProductVendorID: "PV1", Price: 100, ValidFrom: validFrom}}
+		change    = PriceChange{ChangedBy: "admin", Reason: "import"}
+	)
+
+	payload, _ := json.Marshal([]map[string]interface{}{priceImportRecord(prices[0])})
+
+	t.Run("success", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		expectAudited(c.mock, audit.EntityPrice, "P1", "", `{"id":"P1","price":100}`, func() {
+			c.mock.ExpectQuery(importPricesQuery).
+				WithArgs(string(payload), "admin", "import").
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		})
+
+		err := c.accessor.ImportPrices(context.Background(), prices, change)
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(c.mock.ExpectationsWereMet()).To(gomega.BeNil())
+	})
+
+	t.Run("error when not every price is written", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		// a price soft deleted since the lookup is left as is
+		c.g.Expect(importPricesQuery).To(gomega.ContainSubstring("WHERE price.deleted_at IS NULL\n\t\t\tRETURNING *"))
+		c.mock.ExpectQuery(importPricesQuery).
+			WithArgs(string(payload), "admin", "import").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+		err := c.accessor.ImportPrices(context.Background(), prices, change)
+		c.g.Expect(err).ToNot(gomega.BeNil())
+	})
+
+	t.Run("error on query", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectQuery(importPricesQuery).
+			WithArgs(string(payload), "admin", "import").
+			WillReturnError(errors.New("db error"))
+
+		err := c.accessor.ImportPrices(context.Background(), prices, change)
+		c.g.Expect(err).ToNot(gomega.BeNil())
+	})
+}
+
+func Test_getVendorNames(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectQuery(getVendorNamesQuery).
+			WithArgs(pq.Array([]string{"V1", "V2"})).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow("V1", "Vendor 1"))
+
+		res, err := c.accessor.getVendorNames(context.Background(), []string{"V1", "V2"})
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(res).To(gomega.Equal(map[string]string{"V1": "Vendor 1"}))
+	})
+
+	t.Run("leaves soft deleted vendors out", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectQuery("SELECT id, name FROM vendor WHERE id = ANY($1) AND deleted_at IS NULL").
+			WithArgs(pq.Array([]string{"V1"})).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+
+		res, err := c.accessor.getVendorNames(context.Background(), []string{"V1"})
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(res).To(gomega.BeEmpty())
+	})
+
+	t.Run("skips the query without ids", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		res, err := c.accessor.getVendorNames(context.Background(), nil)
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(res).To(gomega.BeEmpty())
+	})
+
+	t.Run("error on query", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectQuery(getVendorNamesQuery).
+			WithArgs(pq.Array([]string{"V1"})).
+			WillReturnError(errors.New("error"))
+
+		res, err := c.accessor.getVendorNames(context.Background(), []string{"V1"})
+		c.g.Expect(err).ToNot(gomega.BeNil())
+		c.g.Expect(res).To(gomega.BeNil())
+	})
+}
+
+func Test_getProductVendorPageReferences(t *testing.T) {
+	t.Parallel()
+
+	productVendors := []ProductVendor{
+		{ID: "PV1", ProductID: "PR1"},
+		{ID: "PV2", ProductID: "PR1"},
+		{ID: "PV3", ProductID: "PR2"},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectQuery(getPageProductsQuery).
+			WithArgs(pq.Array([]string{"PR1", "PR2"})).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "product_category_id", "name"}).
+				AddRow("PR1", "C1", "Pen").
+				AddRow("PR2", "C1", "Ink"))
+		c.mock.ExpectQuery(getPageCategoriesQuery).
+			WithArgs(pq.Array([]string{"C1"})).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow("C1", "Office"))
+		c.mock.ExpectQuery(getPagePricesQuery).
+			WithArgs(pq.Array([]string{"PV1", "PV2", "PV3"})).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "product_vendor_id", "price_uom_id"}).
+				AddRow("P1", "PV1", "PCS").
+				AddRow("P2", "PV1", "BOX").
+				AddRow("P3", "PV3", "PCS"))
+		c.mock.ExpectQuery(getPageUOMsQuery).
+			WithArgs(pq.Array([]string{"PCS", "BOX"})).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+				AddRow("PCS", "Pieces").
+				AddRow("BOX", "Box"))
+
+		res, err := c.accessor.getProductVendorPageReferences(context.Background(), productVendors)
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(res).To(gomega.Equal(&productVendorPageReferences{
+			Products: map[string]Product{
+				"PR1": {ID: "PR1", ProductCategoryID: "C1", Name: "Pen"},
+				"PR2": {ID: "PR2", ProductCategoryID: "C1", Name: "Ink"},
+			},
+			Categories: map[string]ProductCategory{"C1": {ID: "C1", Name: "Office"}},
+			Prices: map[string][]Price{
+				"PV1": {{ID: "P1", ProductVendorID: "PV1", PriceUOMID: "PCS"}, {ID: "P2", ProductVendorID: "PV1", PriceUOMID: "BOX"}},
+				"PV3": {{ID: "P3", ProductVendorID: "PV3", PriceUOMID: "PCS"}},
+			},
+			UOMs: map[string]UOM{
+				"PCS": {ID: "PCS", Name: "Pieces"},
+				"BOX": {ID: "BOX", Name: "Box"},
+			},
+		}))
+		c.g.Expect(c.mock.ExpectationsWereMet()).To(gomega.BeNil())
+	})
+
+	t.Run("skips the queries of an empty page", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		res, err := c.accessor.getProductVendorPageReferences(context.Background(), nil)
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(res.Products).To(gomega.BeEmpty())
+		c.g.Expect(res.Prices).To(gomega.BeEmpty())
+		c.g.Expect(c.mock.ExpectationsWereMet()).To(gomega.BeNil())
+	})
+
+	t.Run("skips the category and uom queries without references", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectQuery(getPageProductsQuery).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("PR1"))
+		c.mock.ExpectQuery(getPagePricesQuery).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		res, err := c.accessor.getProductVendorPageReferences(context.Background(), productVendors[:1])
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(res.Products).To(gomega.HaveKey("PR1"))
+		c.g.Expect(c.mock.ExpectationsWereMet()).To(gomega.BeNil())
+	})
+
+	t.Run("takes the same queries whatever the page size", func(t *testing.T) {
+		for _, size := range []int{1, 10, 100} {
+			c := setupProductAccessorTestComponent(t)
+
+			page := expectPageReferenceQueries(c.mock, size)
+			res, err := c.accessor.getProductVendorPageReferences(context.Background(), page)
+			c.g.Expect(err).To(gomega.BeNil())
+			c.g.Expect(res.Prices).To(gomega.HaveLen(size))
+			c.g.Expect(c.mock.ExpectationsWereMet()).To(gomega.BeNil())
+			c.db.Close()
+		}
+	})
+
+	t.Run("error on products query", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectQuery(getPageProductsQuery).WillReturnError(errors.New("error"))
+
+		res, err := c.accessor.getProductVendorPageReferences(context.Background(), productVendors)
+		c.g.Expect(err).ToNot(gomega.BeNil())
+		c.g.Expect(res).To(gomega.BeNil())
+	})
+
+	t.Run("error on prices query", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectQuery(getPageProductsQuery).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("PR1"))
+		c.mock.ExpectQuery(getPagePricesQuery).WillReturnError(errors.New("error"))
+
+		res, err := c.accessor.getProductVendorPageReferences(context.Background(), productVendors)
+		c.g.Expect(err).ToNot(gomega.BeNil())
+		c.g.Expect(res).To(gomega.BeNil())
+	})
+}
+
+// countingDB counts the statements sent to the database through it
+type countingDB struct {
+	database.DBConnector
+	statements int
+}
+
+func (c *countingDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	c.statements++
+	return c.DBConnector.ExecContext(ctx, query, args...)
+}
+
+func (c *countingDB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	c.statements++
+	return c.DBConnector.QueryContext(ctx, query, args...)
+}
+
+func (c *countingDB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	c.statements++
+	return c.DBConnector.QueryRowContext(ctx, query, args...)
+}
+
+func (c *countingDB) QueryxContext(ctx context.Context, query string, args ...interface{}) (*sqlx.Rows, error) {
+	c.statements++
+	return c.DBConnector.QueryxContext(ctx, query, args...)
+}
+
+func (c *countingDB) QueryRowxContext(ctx context.Context, query string, args ...interface{}) *sqlx.Row {
+	c.statements++
+	return c.DBConnector.QueryRowxContext(ctx, query, args...)
+}
+
+func (c *countingDB) NamedQueryContext(ctx context.Context, query string, arg interface{}) (*sqlx.Rows, error) {
+	c.statements++
+	return c.DBConnector.NamedQueryContext(ctx, query, arg)
+}
+
+func (c *countingDB) NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error) {
+	c.statements++
+	return c.DBConnector.NamedExecContext(ctx, query, arg)
+}
+
+func (c *countingDB) SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	c.statements++
+	return c.DBConnector.SelectContext(ctx, dest, query, args...)
+}
+
+func (c *countingDB) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	c.statements++
+	return c.DBConnector.GetContext(ctx, dest, query, args...)
+}
+
+// pageReferenceSizes are the page sizes the lookups of a page are measured on
+var pageReferenceSizes = []int{1, 10, 100, 1000}
+
+// countPageReferenceQueries runs the lookups of a page of size product vendors and
+// returns the statements they sent
+func countPageReferenceQueries(size int) (int, error) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		return 0, err
+	}
+	defer db.Close()
+	counter := &countingDB{DBConnector: sqlx.NewDb(db, "sqlmock")}
+	accessor := newPostgresProductAccessor(counter, clock.NewMock())
+
+	page := expectPageReferenceQueries(mock, size)
+	if _, err := accessor.getProductVendorPageReferences(context.Background(), page); err != nil {
+		return 0, err
+	}
+	return counter.statements, mock.ExpectationsWereMet()
+}
+
+// Test_getProductVendorPageReferences_queries checks the lookups of a page take as many
+// statements whatever the page size, one per referenced table
+func Test_getProductVendorPageReferences_queries(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	for _, size := range pageReferenceSizes {
+		statements, err := countPageReferenceQueries(size)
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(statements).To(gomega.Equal(4), "page of %d", size)
+	}
+}
+
+// Benchmark_getProductVendorPageReferences reports the statements a page takes as
+// counted on the connection, the count stays flat as the page grows
+func Benchmark_getProductVendorPageReferences(b *testing.B) {
+	for _, size := range pageReferenceSizes {
+		b.Run(fmt.Sprintf("page of %d", size), func(b *testing.B) {
+			statements := 0
+			for i := 0; i < b.N; i++ {
+				count, err := countPageReferenceQueries(size)
+				if err != nil {
+					b.Fatal(err)
+				}
+				statements += count
+			}
+			b.ReportMetric(float64(statements)/float64(b.N), "queries/op")
+		})
+	}
+}
+
+// expectPageReferenceQueries expects the lookups of a page of size product vendors,
+// spread over 10 products of a single category, each with a price in pieces
+func expectPageReferenceQueries(mock sqlmock.Sqlmock, size int) []ProductVendor {
+	var (
+		page       = make([]ProductVendor, 0, size)
+		pvIDs      = make([]string, 0, size)
+		productIDs []string
+		products   = sqlmock.NewRows([]string{"id", "product_category_id"})
+		prices     = sqlmock.NewRows([]string{"id", "product_vendor_id", "price_uom_id"})
+	)
+	for i := 0; i < size; i++ {
+		pv := ProductVendor{ID: fmt.Sprintf("PV%d", i), ProductID: fmt.Sprintf("PR%d", i%10)}
+		page = append(page, pv)
+		pvIDs = append(pvIDs, pv.ID)
+		if i < 10 {
+			productIDs = append(productIDs, pv.ProductID)
+			products.AddRow(pv.ProductID, "C1")
+		}
+		prices.AddRow(fmt.Sprintf("P%d", i), pv.ID, "PCS")
+	}
+
+	mock.ExpectQuery(getPageProductsQuery).WithArgs(pq.Array(productIDs)).WillReturnRows(products)
+	mock.ExpectQuery(getPageCategoriesQuery).WithArgs(pq.Array([]string{"C1"})).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("C1"))
+	mock.ExpectQuery(getPagePricesQuery).WithArgs(pq.Array(pvIDs)).WillReturnRows(prices)
+	mock.ExpectQuery(getPageUOMsQuery).WithArgs(pq.Array([]string{"PCS"})).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("PCS"))
+	return page
+}
+
+func Test_GetProducts(t *testing.T) {
+	t.Parallel()
+
+	var (
+		columns = []string{
+			"id", "product_category_id", "uom_id", "income_tax_id", "product_type_id",
+			"name", "description", "modified_date", "modified_by", "total_entries",
+		}
+		modifiedDate = time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	)
+
+	t.Run("success", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		query := getProductsQuery + `
+		ORDER BY name ASC, id
+		LIMIT $1
+		OFFSET $2
+	`
+		c.mock.ExpectQuery(query).
+			WithArgs(10, 0).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow("P1", "C1", "PCS", "", "T1", "Semen", "", modifiedDate, "admin", 1))
+
+		res, err := c.accessor.GetProducts(context.Background(), GetProductsSpec{
+			PaginationSpec: database.PaginationSpec{Limit: 10, Page: 1},
+		})
+
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(res.Products).To(gomega.Equal([]Product{
+			{
+				ID:                "P1",
+				ProductCategoryID: "C1",
+				UOMID:             "PCS",
+				ProductTypeID:     "T1",
+				Name:              "Semen",
+				ModifiedDate:      modifiedDate,
+				ModifiedBy:        "admin",
+			},
+		}))
+		c.g.Expect(res.Metadata).To(gomega.Equal(database.PaginationMetadata{
+			TotalPage:    1,
+			CurrentPage:  1,
+			TotalEntries: 1,
+		}))
+	})
+
+	t.Run("success with filters", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		query := getProductsQuery + ` AND name iLIKE $1 AND name iLIKE $2 AND product_category_id = $3 AND product_type_id = $4
+		ORDER BY modified_date DESC, id
+		LIMIT $5
+		OFFSET $6
+	`
+		c.mock.ExpectQuery(query).
+			WithArgs("%semen%", "%gresik%", "C1", "T1", 10, 10).
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		res, err := c.accessor.GetProducts(context.Background(), GetProductsSpec{
+			Name:              "semen gresik",
+			ProductCategoryID: "C1",
+			ProductTypeID:     "T1",
+			PaginationSpec:    database.PaginationSpec{Limit: 10, Page: 2, OrderBy: "modified_date", Order: "desc"},
+		})
+
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(res.Products).To(gomega.BeEmpty())
+	})
+
+	t.Run("error on unknown sort column", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		res, err := c.accessor.GetProducts(context.Background(), GetProductsSpec{
+			PaginationSpec: database.PaginationSpec{Limit: 10, Page: 1, OrderBy: "description"},
+		})
+
+		c.g.Expect(errors.Is(err, database.ErrInvalidSortColumn)).To(gomega.BeTrue())
+		c.g.Expect(res).To(gomega.BeNil())
+	})
+
+	t.Run("error on query", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t, WithQueryMatcher(sqlmock.QueryMatcherRegexp))
+		defer c.db.Close()
+
+		c.mock.ExpectQuery("FROM product").WillReturnError(errors.New("db error"))
+
+		res, err := c.accessor.GetProducts(context.Background(), GetProductsSpec{
+			PaginationSpec: database.PaginationSpec{Limit: 10, Page: 1},
+		})
+
+		c.g.Expect(err).ToNot(gomega.BeNil())
+		c.g.Expect(res).To(gomega.BeNil())
+	})
+}
+
+func Test_GetProductCategories(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success with filter by parent", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		query := getProductCategoriesQuery + ` AND parent_id = $1
+		ORDER BY name ASC, id
+		LIMIT $2
+		OFFSET $3
+	`
+		c.mock.ExpectQuery(query).
+			WithArgs("0", 10, 0).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "code", "parent_id", "total_entries"}).
+				AddRow("C1", "Bahan Bangunan", "BB", "0", 1))
+
+		res, err := c.accessor.GetProductCategories(context.Background(), GetProductCategoriesSpec{
+			ParentID:       RootCategoryParentID,
+			PaginationSpec: database.PaginationSpec{Limit: 10, Page: 1},
+		})
+
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(res.ProductCategories).To(gomega.Equal([]ProductCategory{
+			{ID: "C1", Name: "Bahan Bangunan", Code: "BB", ParentID: "0"},
+		}))
+	})
+}
+
+func Test_GetProductTypes(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		query := getProductTypesQuery + ` AND name iLIKE $1
+		ORDER BY name ASC, id
+		LIMIT $2
+		OFFSET $3
+	`
+		c.mock.ExpectQuery(query).
+			WithArgs("%jasa%", 10, 0).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "goods", "total_entries"}).
+				AddRow("T1", "Jasa", false, 1))
+
+		res, err := c.accessor.GetProductTypes(context.Background(), GetCatalogueSpec{
+			Name:           "jasa",
+			PaginationSpec: database.PaginationSpec{Limit: 10, Page: 1},
+		})
+
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(res.ProductTypes).To(gomega.Equal([]ProductType{{ID: "T1", Name: "Jasa"}}))
+	})
+}
+
+func Test_GetUOMs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		query := getUOMsQuery + `
+		ORDER BY name ASC, id
+		LIMIT $1
+		OFFSET $2
+	`
+		c.mock.ExpectQuery(query).
+			WithArgs(10, 0).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "total_entries"}).
+				AddRow("PCS", "Pieces", 1))
+
+		res, err := c.accessor.GetUOMs(context.Background(), GetCatalogueSpec{
+			PaginationSpec: database.PaginationSpec{Limit: 10, Page: 1},
+		})
+
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(res.UOMs).To(gomega.Equal([]UOM{{ID: "PCS", Name: "Pieces"}}))
+	})
+}
+
+func Test_getProductTypeByID(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectQuery(getProductTypeByIDQuery).
+			WithArgs("T1").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow("T1", "Jasa"))
+
+		res, err := c.accessor.getProductTypeByID(context.Background(), "T1")
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(res).To(gomega.Equal(&ProductType{ID: "T1", Name: "Jasa"}))
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectQuery(getProductTypeByIDQuery).
+			WithArgs("T1").
+			WillReturnError(sql.ErrNoRows)
+
+		res, err := c.accessor.getProductTypeByID(context.Background(), "T1")
+		c.g.Expect(err).To(gomega.Equal(sql.ErrNoRows))
+		c.g.Expect(res).To(gomega.BeNil())
+	})
+}
+
+func Test_CreateProductCategory(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t, WithQueryMatcher(sqlmock.QueryMatcherRegexp))
+		defer c.db.Close()
+
+		category := ProductCategory{ID: "C1", Name: "Semen", ParentID: "0", ModifiedDate: c.cmock.Now()}
+		transformedQuery, args, _ := sqlx.Named(insertProductCategory, category)
+		driverArgs := make([]driver.Value, len(args))
+		for i, arg := range args {
+			driverArgs[i] = arg
+		}
+
+		c.mock.ExpectExec(regexp.QuoteMeta(transformedQuery)).
+			WithArgs(driverArgs...).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err := c.accessor.CreateProductCategory(context.Background(), category)
+		c.g.Expect(err).To(gomega.BeNil())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t, WithQueryMatcher(sqlmock.QueryMatcherRegexp))
+		defer c.db.Close()
+
+		c.mock.ExpectExec("INSERT INTO product_category").WillReturnError(errors.New("db error"))
+
+		err := c.accessor.CreateProductCategory(context.Background(), ProductCategory{ID: "C1"})
+		c.g.Expect(err).ToNot(gomega.BeNil())
+	})
+}
+
+func Test_softDelete(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectExec(productCategoryTable.softDeleteQuery()).
+			WithArgs("C1", c.cmock.Now(), "admin").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := c.accessor.softDelete(context.Background(), productCategoryTable, "C1", "admin")
+		c.g.Expect(err).To(gomega.BeNil())
+	})
+
+	t.Run("records the delete of an audited table", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		expectAudited(c.mock, audit.EntityPrice, "P1", `{"id":"P1","deleted_at":null}`, `{"id":"P1","deleted_at":"2024-12-11T00:00:00"}`, func() {
+			c.mock.ExpectExec(priceTable.softDeleteQuery()).
+				WithArgs("P1", c.cmock.Now(), "admin").
+				WillReturnResult(sqlmock.NewResult(0, 1))
+		})
+
+		err := c.accessor.softDelete(context.Background(), priceTable, "P1", "admin")
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(c.mock.ExpectationsWereMet()).To(gomega.BeNil())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectExec(productCategoryTable.softDeleteQuery()).
+			WithArgs("C1", c.cmock.Now(), "admin").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		c.mock.ExpectQuery(productCategoryTable.referencesQuery()).
+			WithArgs("C1").
+			WillReturnRows(sqlmock.NewRows([]string{"exists", "subcategories", "products"}).AddRow(false, 0, 0))
+
+		err := c.accessor.softDelete(context.Background(), productCategoryTable, "C1", "admin")
+		c.g.Expect(err).To(gomega.Equal(ErrProductCategoryNotFound))
+	})
+
+	t.Run("in use", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectExec(productCategoryTable.softDeleteQuery()).
+			WithArgs("C1", c.cmock.Now(), "admin").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		c.mock.ExpectQuery(productCategoryTable.referencesQuery()).
+			WithArgs("C1").
+			WillReturnRows(sqlmock.NewRows([]string{"exists", "subcategories", "products"}).AddRow(true, 2, 1))
+
+		err := c.accessor.softDelete(context.Background(), productCategoryTable, "C1", "admin")
+		c.g.Expect(errors.Is(err, ErrCatalogueInUse)).To(gomega.BeTrue())
+		c.g.Expect(err.Error()).To(gomega.Equal("still in use: product category is referenced by 2 subcategories, 1 products"))
+	})
+
+	t.Run("error on exec", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectExec(uomTable.softDeleteQuery()).
+			WithArgs("PCS", c.cmock.Now(), "admin").
+			WillReturnError(errors.New("db error"))
+
+		err := c.accessor.softDelete(context.Background(), uomTable, "PCS", "admin")
+		c.g.Expect(err).ToNot(gomega.BeNil())
+	})
+}
+
+func Test_restore(t *testing.T) {
+	t.Parallel()
+
+	t.Run("records the restore of an audited table", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		expectAudited(c.mock, audit.EntityProduct, "P1", `{"id":"P1","deleted_at":"2024-12-11T00:00:00"}`, `{"id":"P1","deleted_at":null}`, func() {
+			c.mock.ExpectExec(productTable.restoreQuery()).
+				WithArgs("P1", c.cmock.Now(), "admin").
+				WillReturnResult(sqlmock.NewResult(0, 1))
+		})
+
+		err := c.accessor.restore(context.Background(), productTable, "P1", "admin")
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(c.mock.ExpectationsWereMet()).To(gomega.BeNil())
+	})
+
+	t.Run("not found when the entry isn't deleted", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectExec(uomTable.restoreQuery()).
+			WithArgs("PCS", c.cmock.Now(), "admin").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		c.mock.ExpectQuery(uomTable.requiresQuery()).
+			WithArgs("PCS").
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+		err := c.accessor.restore(context.Background(), uomTable, "PCS", "admin")
+		c.g.Expect(err).To(gomega.Equal(ErrUOMNotFound))
+	})
+
+	t.Run("blocked by a deleted requirement", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectBegin()
+		c.mock.ExpectQuery(audit.SnapshotQuery(audit.EntityPrice)).
+			WithArgs(pq.Array([]string{"P1"})).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "row"}).AddRow("P1", []byte(`{"id":"P1"}`)))
+		c.mock.ExpectExec(priceTable.restoreQuery()).
+			WithArgs("P1", c.cmock.Now(), "admin").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		c.mock.ExpectQuery(priceTable.requiresQuery()).
+			WithArgs("P1").
+			WillReturnRows(sqlmock.NewRows([]string{"exists", "product_vendor", "vendor"}).AddRow(true, 0, 1))
+		c.mock.ExpectRollback()
+
+		err := c.accessor.restore(context.Background(), priceTable, "P1", "admin")
+		c.g.Expect(errors.Is(err, ErrCatalogueDeletedReference)).To(gomega.BeTrue())
+		c.g.Expect(err.Error()).To(gomega.Equal("refers to a deleted entry: price refers to a deleted vendor"))
+		c.g.Expect(c.mock.ExpectationsWereMet()).To(gomega.BeNil())
+	})
+
+	t.Run("blocked by a deleted parent category", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectExec(productCategoryTable.restoreQuery()).
+			WithArgs("C2", c.cmock.Now(), "admin").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		c.mock.ExpectQuery(productCategoryTable.requiresQuery()).
+			WithArgs("C2").
+			WillReturnRows(sqlmock.NewRows([]string{"exists", "parent category"}).AddRow(true, 1))
+
+		err := c.accessor.restore(context.Background(), productCategoryTable, "C2", "admin")
+		c.g.Expect(errors.Is(err, ErrCatalogueDeletedReference)).To(gomega.BeTrue())
+		c.g.Expect(err.Error()).To(gomega.Equal("refers to a deleted entry: product category refers to a deleted parent category"))
+		c.g.Expect(c.mock.ExpectationsWereMet()).To(gomega.BeNil())
+	})
+
+	t.Run("error on exec", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectExec(uomTable.restoreQuery()).
+			WithArgs("PCS", c.cmock.Now(), "admin").
+			WillReturnError(errors.New("db error"))
+
+		err := c.accessor.restore(context.Background(), uomTable, "PCS", "admin")
+		c.g.Expect(err).ToNot(gomega.BeNil())
+	})
+}
+
+func Test_GetProducts_IncludeSubcategories(t *testing.T) {
+	t.Parallel()
+
+	c := setupProductAccessorTestComponent(t)
+	defer c.db.Close()
+
+	query := getProductsQuery + " AND product_category_id IN (" + categorySubtreeIDsQuery("$1") + `)
+		ORDER BY name ASC, id
+		LIMIT $2
+		OFFSET $3
+	`
+	c.mock.ExpectQuery(query).
+		WithArgs("C1", 10, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "product_category_id", "total_entries"}).
+			AddRow("P1", "C1", 2).
+			AddRow("P2", "C2", 2))
+
+	res, err := c.accessor.GetProducts(context.Background(), GetProductsSpec{
+		ProductCategoryID:    "C1",
+		IncludeSubcategories: true,
+		PaginationSpec:       database.PaginationSpec{Limit: 10, Page: 1},
+	})
+
+	c.g.Expect(err).To(gomega.BeNil())
+	c.g.Expect(res.Products).To(gomega.Equal([]Product{
+		{ID: "P1", ProductCategoryID: "C1"},
+		{ID: "P2", ProductCategoryID: "C2"},
+	}))
+}
+
+func Test_getCategoryTree(t *testing.T) {
+	t.Parallel()
+
+	columns := []string{"id", "name", "parent_id"}
+
+	t.Run("success", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectQuery(getCategoryTreeQuery).
+			WithArgs("0").
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow("A", "Bahan Bangunan", "0").
+				AddRow("A1", "Semen", "A"))
+
+		res, err := c.accessor.getCategoryTree(context.Background(), "0")
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(res).To(gomega.Equal([]ProductCategory{
+			{ID: "A", Name: "Bahan Bangunan", ParentID: "0"},
+			{ID: "A1", Name: "Semen", ParentID: "A"},
+		}))
+	})
+
+	t.Run("error on query", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectQuery(getCategoryTreeQuery).
+			WithArgs("0").
+			WillReturnError(errors.New("db error"))
+
+		res, err := c.accessor.getCategoryTree(context.Background(), "0")
+		c.g.Expect(err).ToNot(gomega.BeNil())
+		c.g.Expect(res).To(gomega.BeNil())
+	})
+}
+
+func Test_getCategorySubtree(t *testing.T) {
+	t.Parallel()
+
+	c := setupProductAccessorTestComponent(t)
+	defer c.db.Close()
+
+	c.mock.ExpectQuery(getCategorySubtreeQuery).
+		WithArgs("A").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "parent_id"}).AddRow("A", "0").AddRow("A1", "A"))
+
+	res, err := c.accessor.getCategorySubtree(context.Background(), "A")
+	c.g.Expect(err).To(gomega.BeNil())
+	c.g.Expect(res).To(gomega.HaveLen(2))
+}
+
+func Test_getCategoryAncestors(t *testing.T) {
+	t.Parallel()
+
+	c := setupProductAccessorTestComponent(t)
+	defer c.db.Close()
+
+	c.mock.ExpectQuery(getCategoryAncestorsQuery).
+		WithArgs("A1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "parent_id"}).AddRow("A", "0").AddRow("A1", "A"))
+
+	res, err := c.accessor.getCategoryAncestors(context.Background(), "A1")
+	c.g.Expect(err).To(gomega.BeNil())
+	c.g.Expect(res).To(gomega.Equal([]ProductCategory{
+		{ID: "A", ParentID: "0"},
+		{ID: "A1", ParentID: "A"},
+	}))
+}
+
+func Test_moveProductCategory(t *testing.T) {
+	t.Parallel()
+
+	checkColumns := []string{"found", "parent_found", "cycle"}
+
+	t.Run("success", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectExec(moveProductCategoryQuery).
+			WithArgs("A1", "B", c.cmock.Now(), "admin").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := c.accessor.moveProductCategory(context.Background(), "A1", "B", "admin")
+		c.g.Expect(err).To(gomega.BeNil())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectExec(moveProductCategoryQuery).
+			WithArgs("A1", "B", c.cmock.Now(), "admin").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		c.mock.ExpectQuery(checkProductCategoryMoveQuery).
+			WithArgs("A1", "B").
+			WillReturnRows(sqlmock.NewRows(checkColumns).AddRow(false, true, false))
+
+		err := c.accessor.moveProductCategory(context.Background(), "A1", "B", "admin")
+		c.g.Expect(err).To(gomega.Equal(ErrProductCategoryNotFound))
+	})
+
+	t.Run("missing parent", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectExec(moveProductCategoryQuery).
+			WithArgs("A1", "B", c.cmock.Now(), "admin").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		c.mock.ExpectQuery(checkProductCategoryMoveQuery).
+			WithArgs("A1", "B").
+			WillReturnRows(sqlmock.NewRows(checkColumns).AddRow(true, false, false))
+
+		err := c.accessor.moveProductCategory(context.Background(), "A1", "B", "admin")
+		c.g.Expect(errors.Is(err, ErrInvalidReference)).To(gomega.BeTrue())
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectExec(moveProductCategoryQuery).
+			WithArgs("A", "A1", c.cmock.Now(), "admin").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		c.mock.ExpectQuery(checkProductCategoryMoveQuery).
+			WithArgs("A", "A1").
+			WillReturnRows(sqlmock.NewRows(checkColumns).AddRow(true, true, true))
+
+		err := c.accessor.moveProductCategory(context.Background(), "A", "A1", "admin")
+		c.g.Expect(err).To(gomega.Equal(ErrCategoryCycle))
+	})
+
+	t.Run("error on exec", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectExec(moveProductCategoryQuery).
+			WithArgs("A1", "B", c.cmock.Now(), "admin").
+			WillReturnError(errors.New("db error"))
+
+		err := c.accessor.moveProductCategory(context.Background(), "A1", "B", "admin")
+		c.g.Expect(err).ToNot(gomega.BeNil())
+	})
+}
+
+func Test_CreateProductVendor(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t, WithQueryMatcher(sqlmock.QueryMatcherRegexp))
+		defer c.db.Close()
+
+		productVendor := ProductVendor{ID: "PV1", ProductID: "PR1", Name: "Semen", UOMID: "PCS", ModifiedDate: c.cmock.Now()}
+		transformedQuery, args, _ := sqlx.Named(insertProductVendor, productVendor)
+		driverArgs := make([]driver.Value, len(args))
+		for i, arg := range args {
+			driverArgs[i] = arg
+		}
+
+		c.mock.ExpectExec(regexp.QuoteMeta(transformedQuery)).
+			WithArgs(driverArgs...).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err := c.accessor.CreateProductVendor(context.Background(), productVendor)
+		c.g.Expect(err).To(gomega.BeNil())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t, WithQueryMatcher(sqlmock.QueryMatcherRegexp))
+		defer c.db.Close()
+
+		c.mock.ExpectExec("INSERT INTO product_vendor").WillReturnError(errors.New("db error"))
+
+		err := c.accessor.CreateProductVendor(context.Background(), ProductVendor{ID: "PV1"})
+		c.g.Expect(err).ToNot(gomega.BeNil())
+	})
+}
+
+func Test_lockProductVendor(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectExec(lockProductVendorQuery).
+			WithArgs("PV1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := c.accessor.lockProductVendor(context.Background(), "PV1")
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(lockProductVendorQuery).To(gomega.HaveSuffix("FOR UPDATE"))
+	})
+
+	t.Run("error", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectExec(lockProductVendorQuery).
+			WithArgs("PV1").
+			WillReturnError(errors.New("db error"))
+
+		err := c.accessor.lockProductVendor(context.Background(), "PV1")
+		c.g.Expect(err).ToNot(gomega.BeNil())
+	})
+}
+
+func Test_getDeletedPrice(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectQuery(getDeletedPriceQuery).
+			WithArgs("P1").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "product_vendor_id", "quantity_min"}).AddRow("P1", "PV1", 5))
+
+		res, err := c.accessor.getDeletedPrice(context.Background(), "P1")
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(res).To(gomega.Equal(&Price{ID: "P1", ProductVendorID: "PV1", QuantityMin: 5}))
+	})
+
+	t.Run("error on a missing or live price", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectQuery(getDeletedPriceQuery).
+			WithArgs("P1").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		res, err := c.accessor.getDeletedPrice(context.Background(), "P1")
+		c.g.Expect(err).To(gomega.Equal(ErrPriceNotFound))
+		c.g.Expect(res).To(gomega.BeNil())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectQuery(getDeletedPriceQuery).
+			WithArgs("P1").
+			WillReturnError(errors.New("db error"))
+
+		res, err := c.accessor.getDeletedPrice(context.Background(), "P1")
+		c.g.Expect(err).To(gomega.MatchError("db error"))
+		c.g.Expect(res).To(gomega.BeNil())
+	})
+}
+
+func Test_CreatePrice(t *testing.T) {
+	t.Parallel()
+
+	validFrom := time.Date(2024, time.December, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("stores open windows as null", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		price := Price{ID: "P1", ProductVendorID: "PV1", Price: 1000, ValidFrom: validFrom, ModifiedDate: c.cmock.Now()}
+		payload, _ := json.Marshal(priceImportRecord(price))
+		c.g.Expect(string(payload)).To(gomega.ContainSubstring(`"valid_to":null`))
+
+		expectAudited(c.mock, audit.EntityPrice, "P1", "", `{"id":"P1","price":1000}`, func() {
+			c.mock.ExpectExec(createPriceQuery).
+				WithArgs(string(payload)).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+		})
+
+		err := c.accessor.CreatePrice(context.Background(), price)
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(c.mock.ExpectationsWereMet()).To(gomega.BeNil())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectExec(createPriceQuery).WillReturnError(errors.New("db error"))
+
+		err := c.accessor.CreatePrice(context.Background(), Price{ID: "P1", ValidFrom: validFrom})
+		c.g.Expect(err).ToNot(gomega.BeNil())
+	})
+}
+
+func Test_getPeerPrices(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2024, time.December, 10, 0, 0, 0, 0, time.UTC)
+
+	t.Run("groups the prices by product vendor", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		rows := sqlmock.NewRows([]string{"for_product_vendor_id", "id", "vendor_id", "product_vendor_id", "currency_id", "price", "price_quantity", "price_uom_id"}).
+			AddRow("PV1", "P2", "V2", "PV2", "C1", 900.0, 1, "PCS").
+			AddRow("PV1", "P3", "V3", "PV3", "C1", 1100.0, 1, "PCS")
+		c.mock.ExpectQuery(getPeerPricesQuery).
+			WithArgs(pq.Array([]string{"PV1"}), at).
+			WillReturnRows(rows)
+
+		res, err := c.accessor.getPeerPrices(context.Background(), []string{"PV1"}, at)
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(res).To(gomega.Equal(map[string][]Price{
+			"PV1": {
+				{ID: "P2", VendorID: "V2", ProductVendorID: "PV2", CurrencyID: "C1", Price: 900, PriceQuantity: 1, PriceUOMID: "PCS"},
+				{ID: "P3", VendorID: "V3", ProductVendorID: "PV3", CurrencyID: "C1", Price: 1100, PriceQuantity: 1, PriceUOMID: "PCS"},
+			},
+		}))
+	})
+
+	t.Run("skips the query without product vendors", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		res, err := c.accessor.getPeerPrices(context.Background(), nil, at)
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(res).To(gomega.BeEmpty())
+		c.g.Expect(c.mock.ExpectationsWereMet()).To(gomega.Succeed())
+	})
+
+	t.Run("error on query", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectQuery(getPeerPricesQuery).WillReturnError(errors.New("db error"))
+
+		res, err := c.accessor.getPeerPrices(context.Background(), []string{"PV1"}, at)
+		c.g.Expect(err).ToNot(gomega.BeNil())
+		c.g.Expect(res).To(gomega.BeNil())
+	})
+}
+
+func Test_WritePriceAnomalies(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ratio    = 10.0
+		oldPrice = 1000.0
+		proposed = PriceProposal(Price{ID: "P1", Price: 10000})
+		anomaly  = PriceAnomaly{
+			ID:           "A1",
+			PriceID:      "P1",
+			Source:       PriceAnomalySourceUpdate,
+			OldPrice:     &oldPrice,
+			NewPrice:     10000,
+			HistoryRatio: &ratio,
+			Status:       PriceAnomalyStatusPending.String(),
+			Proposed:     &proposed,
+			RequestedBy:  "buyer",
+		}
+	)
+
+	t.Run("success", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t, WithQueryMatcher(sqlmock.QueryMatcherRegexp))
+		defer c.db.Close()
+
+		anomaly.ModifiedDate = c.cmock.Now()
+		transformedQuery, args, _ := sqlx.Named(insertPriceAnomalyQuery, anomaly)
+		driverArgs := make([]driver.Value, len(args))
+		for i, arg := range args {
+			driverArgs[i] = arg
+		}
+
+		c.mock.ExpectExec(regexp.QuoteMeta(transformedQuery)).
+			WithArgs(driverArgs...).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err := c.accessor.WritePriceAnomalies(context.Background(), []PriceAnomaly{anomaly})
+		c.g.Expect(err).To(gomega.BeNil())
+	})
+
+	t.Run("skips the insert without anomalies", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		err := c.accessor.WritePriceAnomalies(context.Background(), nil)
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(c.mock.ExpectationsWereMet()).To(gomega.Succeed())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t, WithQueryMatcher(sqlmock.QueryMatcherRegexp))
+		defer c.db.Close()
+
+		c.mock.ExpectExec("INSERT INTO price_anomaly").WillReturnError(errors.New("db error"))
+
+		err := c.accessor.WritePriceAnomalies(context.Background(), []PriceAnomaly{anomaly})
+		c.g.Expect(err).ToNot(gomega.BeNil())
+	})
+}
+
+func Test_runInTx(t *testing.T) {
+	t.Parallel()
+
+	t.Run("commits the writes of the unit of work", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t, WithQueryMatcher(sqlmock.QueryMatcherRegexp))
+		defer c.db.Close()
+
+		c.mock.ExpectBegin()
+		c.mock.ExpectExec("UPDATE price_anomaly").WillReturnResult(sqlmock.NewResult(0, 1))
+		c.mock.ExpectCommit()
+
+		err := c.accessor.runInTx(context.Background(), func(tx productDBAccessor) error {
+			return tx.UpdatePriceAnomalyReview(context.Background(), PriceAnomaly{ID: "A1"})
+		})
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(c.mock.ExpectationsWereMet()).To(gomega.BeNil())
+	})
+
+	t.Run("rolls back the writes of a failing unit of work", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t, WithQueryMatcher(sqlmock.QueryMatcherRegexp))
+		defer c.db.Close()
+
+		c.mock.ExpectBegin()
+		c.mock.ExpectExec("UPDATE price_anomaly").WillReturnResult(sqlmock.NewResult(0, 1))
+		c.mock.ExpectRollback()
+
+		err := c.accessor.runInTx(context.Background(), func(tx productDBAccessor) error {
+			if err := tx.UpdatePriceAnomalyReview(context.Background(), PriceAnomaly{ID: "A1"}); err != nil {
+				return err
+			}
+			return errors.New("error")
+		})
+		c.g.Expect(err).ToNot(gomega.BeNil())
+		c.g.Expect(c.mock.ExpectationsWereMet()).To(gomega.BeNil())
+	})
+}
+
+func Test_UpdatePriceAnomalyReview(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t, WithQueryMatcher(sqlmock.QueryMatcherRegexp))
+		defer c.db.Close()
+
+		reviewed := c.cmock.Now()
+		anomaly := PriceAnomaly{ID: "A1", Status: PriceAnomalyStatusRejected.String(), ReviewedBy: "manager", ReviewedDate: &reviewed, ModifiedDate: reviewed}
+		c.mock.ExpectExec(`UPDATE price_anomaly\s+SET status`).
+			WithArgs(anomaly.Status, "manager", &reviewed, reviewed, "A1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := c.accessor.UpdatePriceAnomalyReview(context.Background(), anomaly)
+		c.g.Expect(err).To(gomega.BeNil())
+	})
+
+	t.Run("returns ErrPriceAnomalyNotPending once reviewed", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t, WithQueryMatcher(sqlmock.QueryMatcherRegexp))
+		defer c.db.Close()
+
+		c.mock.ExpectExec(`UPDATE price_anomaly\s+SET .+\s+WHERE id = .+ AND status = 'pending'`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := c.accessor.UpdatePriceAnomalyReview(context.Background(), PriceAnomaly{ID: "A1"})
+		c.g.Expect(err).To(gomega.Equal(ErrPriceAnomalyNotPending))
+	})
+
+	t.Run("error", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t, WithQueryMatcher(sqlmock.QueryMatcherRegexp))
+		defer c.db.Close()
+
+		c.mock.ExpectExec("UPDATE price_anomaly").WillReturnError(errors.New("db error"))
+
+		err := c.accessor.UpdatePriceAnomalyReview(context.Background(), PriceAnomaly{ID: "A1"})
+		c.g.Expect(err).ToNot(gomega.BeNil())
+	})
+}
+
+var priceAnomalyColumns = []string{
+	"id", "price_id", "product_vendor_id", "vendor_id", "source", "old_price", "new_price",
+	"currency_id", "history_ratio", "peer_median", "peer_ratio", "peer_count", "status",
+	"proposed", "requested_by", "reason", "reviewed_by", "reviewed_date", "modified_date",
+}
+
+func Test_lockPriceAnomaly(t *testing.T) {
+	t.Parallel()
+
+	modifiedDate := time.Date(2024, time.December, 10, 0, 0, 0, 0, time.UTC)
+
+	t.Run("success", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		rows := sqlmock.NewRows(priceAnomalyColumns).
+			AddRow("A1", "P1", "PV1", "V1", "update", 1000.0, 10000.0, "C1", 10.0, nil, nil, 0, "pending",
+				[]byte(`{"id":"P1","price":10000}`), "buyer", "typo", "", nil, modifiedDate)
+		c.mock.ExpectQuery(lockPriceAnomalyQuery).WithArgs("A1").WillReturnRows(rows)
+
+		res, err := c.accessor.lockPriceAnomaly(context.Background(), "A1")
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(res.Status).To(gomega.Equal("pending"))
+		c.g.Expect(*res.OldPrice).To(gomega.Equal(1000.0))
+		c.g.Expect(*res.HistoryRatio).To(gomega.Equal(10.0))
+		c.g.Expect(res.PeerRatio).To(gomega.BeNil())
+		c.g.Expect(res.Proposed).To(gomega.Equal(&PriceProposal{ID: "P1", Price: 10000}))
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectQuery(lockPriceAnomalyQuery).WithArgs("A1").WillReturnRows(sqlmock.NewRows(priceAnomalyColumns))
+
+		res, err := c.accessor.lockPriceAnomaly(context.Background(), "A1")
+		c.g.Expect(errors.Is(err, sql.ErrNoRows)).To(gomega.BeTrue())
+		c.g.Expect(res).To(gomega.BeNil())
+	})
+}
+
+func Test_GetPriceAnomalies(t *testing.T) {
+	t.Parallel()
+
+	var (
+		modifiedDate = time.Date(2024, time.December, 10, 0, 0, 0, 0, time.UTC)
+		spec         = GetPriceAnomaliesSpec{
+			Status:         "pending",
+			PaginationSpec: database.PaginationSpec{Limit: 10, Page: 1},
+		}
+	)
+
+	t.Run("success", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		rows := sqlmock.NewRows(append(priceAnomalyColumns, "total_entries")).
+			AddRow("A1", "P1", "PV1", "V1", "update", 1000.0, 10000.0, "C1", 10.0, nil, nil, 0, "pending", nil, "buyer", "", "", nil, modifiedDate, 1)
+		c.mock.ExpectQuery(getPriceAnomaliesQuery).
+			WithArgs("pending", "", 10, 0).
+			WillReturnRows(rows)
+
+		res, err := c.accessor.GetPriceAnomalies(context.Background(), spec)
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(res.PriceAnomalies).To(gomega.HaveLen(1))
+		c.g.Expect(res.PriceAnomalies[0].ID).To(gomega.Equal("A1"))
+		c.g.Expect(res.PriceAnomalies[0].Proposed).To(gomega.BeNil())
+		c.g.Expect(res.Metadata.TotalEntries).To(gomega.Equal(1))
+	})
+
+	t.Run("error on query", func(t *testing.T) {
+		c := setupProductAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectQuery(getPriceAnomaliesQuery).WillReturnError(errors.New("db error"))
+
+		res, err := c.accessor.GetPriceAnomalies(context.Background(), spec)
+		c.g.Expect(err).ToNot(gomega.BeNil())
+		c.g.Expect(res).To(gomega.BeNil())
+	})
+}
@@ -0,0 +1,232 @@
+package product
+
+import (
+	"errors"
+	"fmt"
+	"kg/procurement/internal/audit"
+	"kg/procurement/internal/common/database"
+	"strings"
+)
+
+// RootCategoryParentID is the parent_id of top level categories
+const RootCategoryParentID = "0"
+
+var (
+	ErrProductNotFound         = errors.New("product not found")
+	ErrProductCategoryNotFound = errors.New("product category not found")
+	ErrProductTypeNotFound     = errors.New("product type not found")
+	ErrUOMNotFound             = errors.New("uom not found")
+
+	// ErrInvalidReference is returned when a created entry refers to a missing or deleted one
+	ErrInvalidReference = errors.New("invalid reference")
+	// ErrCatalogueInUse is returned when deleting an entry other entries still refer to
+	ErrCatalogueInUse = errors.New("still in use")
+	// ErrCatalogueDeletedReference is returned when restoring an entry that refers to a deleted one
+	ErrCatalogueDeletedReference = errors.New("refers to a deleted entry")
+)
+
+type PostProductSpec struct {
+	ProductCategoryID string `json:"product_category_id" binding:"required"`
+	UOMID             string `json:"uom_id" binding:"required"`
+	IncomeTaxID       string `json:"income_tax_id"`
+	ProductTypeID     string `json:"product_type_id" binding:"required"`
+	Name              string `json:"name" binding:"required"`
+	Description       string `json:"description"`
+	ModifiedBy        string `json:"modified_by"`
+}
+
+type PostProductCategorySpec struct {
+	Name           string `json:"name" binding:"required"`
+	Code           string `json:"code" binding:"required"`
+	Description    string `json:"description"`
+	ParentID       string `json:"parent_id"`
+	SpecialistBPID string `json:"specialist_bpid"`
+	ModifiedBy     string `json:"modified_by"`
+}
+
+type PostProductTypeSpec struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+	Goods       bool   `json:"goods"`
+	Asset       bool   `json:"asset"`
+	Stock       bool   `json:"stock"`
+	ModifiedBy  string `json:"modified_by"`
+}
+
+// PostUOMSpec creates a UOM, BaseFactor is the amount of the base unit of
+// its dimension the UOM holds and is used to convert within the dimension
+type PostUOMSpec struct {
+	Name        string   `json:"name" binding:"required"`
+	Description string   `json:"description"`
+	Dimension   string   `json:"dimension"`
+	SAPCode     string   `json:"sap_code"`
+	BaseFactor  *float64 `json:"base_factor"`
+	ModifiedBy  string   `json:"modified_by"`
+}
+
+// GetProductsSpec filters the product listing, IncludeSubcategories also lists
+// the products of every category under ProductCategoryID
+type GetProductsSpec struct {
+	Name                 string `json:"name"`
+	ProductCategoryID    string `json:"product_category_id"`
+	IncludeSubcategories bool   `json:"include_subcategories"`
+	ProductTypeID        string `json:"product_type_id"`
+	database.PaginationSpec
+}
+
+type GetProductCategoriesSpec struct {
+	Name     string `json:"name"`
+	ParentID string `json:"parent_id"`
+	database.PaginationSpec
+}
+
+// GetCatalogueSpec filters the product type and UOM listings
+type GetCatalogueSpec struct {
+	Name string `json:"name"`
+	database.PaginationSpec
+}
+
+type AccessorGetProductsPaginationData struct {
+	Products []Product                   `json:"products"`
+	Metadata database.PaginationMetadata `json:"metadata"`
+}
+
+type AccessorGetProductCategoriesPaginationData struct {
+	ProductCategories []ProductCategory           `json:"product_categories"`
+	Metadata          database.PaginationMetadata `json:"metadata"`
+}
+
+type AccessorGetProductTypesPaginationData struct {
+	ProductTypes []ProductType               `json:"product_types"`
+	Metadata     database.PaginationMetadata `json:"metadata"`
+}
+
+type AccessorGetUOMsPaginationData struct {
+	UOMs     []UOM                       `json:"uoms"`
+	Metadata database.PaginationMetadata `json:"metadata"`
+}
+
+// catalogueReference is an entry referring to a catalogue entry, From selects
+// the referring rows of the entry given as $1
+type catalogueReference struct {
+	Name string
+	From string
+}
+
+// catalogueTable describes how an entry of a catalogue table is soft deleted and
+// restored, an entry can only be deleted once none of its References remain and only
+// restored once none of its Requires is deleted. Deletes and restores of an audited
+// table are recorded on the audit log as Audit
+type catalogueTable struct {
+	Table      string
+	NotFound   error
+	References []catalogueReference
+	Requires   []catalogueReference
+	Audit      audit.Entity
+}
+
+var (
+	productTable = catalogueTable{
+		Table:    "product",
+		NotFound: ErrProductNotFound,
+		Audit:    audit.EntityProduct,
+		References: []catalogueReference{
+			{Name: "product vendors", From: "FROM product_vendor WHERE product_id = $1 AND deleted_at IS NULL"},
+		},
+		Requires: []catalogueReference{
+			{Name: "product category", From: "FROM product_category WHERE id = (SELECT product_category_id FROM product WHERE id = $1) AND deleted_at IS NOT NULL"},
+			{Name: "product type", From: "FROM product_type WHERE id = (SELECT product_type_id FROM product WHERE id = $1) AND deleted_at IS NOT NULL"},
+			{Name: "uom", From: "FROM uom WHERE id = (SELECT uom_id FROM product WHERE id = $1) AND deleted_at IS NOT NULL"},
+		},
+	}
+	productCategoryTable = catalogueTable{
+		Table:    "product_category",
+		NotFound: ErrProductCategoryNotFound,
+		References: []catalogueReference{
+			{Name: "subcategories", From: "FROM product_category WHERE parent_id = $1 AND deleted_at IS NULL"},
+			{Name: "products", From: "FROM product WHERE product_category_id = $1 AND deleted_at IS NULL"},
+		},
+		Requires: []catalogueReference{
+			{Name: "parent category", From: "FROM product_category WHERE id = (SELECT parent_id FROM product_category WHERE id = $1) AND deleted_at IS NOT NULL"},
+		},
+	}
+	productTypeTable = catalogueTable{
+		Table:    "product_type",
+		NotFound: ErrProductTypeNotFound,
+		References: []catalogueReference{
+			{Name: "products", From: "FROM product WHERE product_type_id = $1 AND deleted_at IS NULL"},
+		},
+	}
+	uomTable = catalogueTable{
+		Table:    "uom",
+		NotFound: ErrUOMNotFound,
+		References: []catalogueReference{
+			{Name: "products", From: "FROM product WHERE uom_id = $1 AND deleted_at IS NULL"},
+			{Name: "product vendors", From: "FROM product_vendor WHERE uom_id = $1 AND deleted_at IS NULL"},
+			{Name: "prices", From: "FROM price WHERE (price_uom_id = $1 OR quantity_uom_id = $1) AND deleted_at IS NULL"},
+			{Name: "product conversions", From: "FROM product_uom_conversion WHERE from_uom_id = $1 OR to_uom_id = $1"},
+		},
+	}
+)
+
+// softDeleteQuery marks the entry $1 as deleted at $2 by $3, the guards keep
+// it untouched while it is already deleted or still referenced
+func (t catalogueTable) softDeleteQuery() string {
+	var guards []string
+	for _, reference := range t.References {
+		guards = append(guards, fmt.Sprintf("AND NOT EXISTS (SELECT 1 %s)", reference.From))
+	}
+	return fmt.Sprintf(`
+		UPDATE %s SET
+			deleted_at = $2,
+			deleted_by = $3,
+			modified_date = $2,
+			modified_by = $3
+		WHERE id = $1
+			AND deleted_at IS NULL
+			%s
+	`, t.Table, strings.Join(guards, "\n\t\t\t"))
+}
+
+// restoreQuery clears the deletion of the entry $1 at $2 by $3, the guards keep it
+// deleted while an entry it requires is deleted
+func (t catalogueTable) restoreQuery() string {
+	var guards []string
+	for _, required := range t.Requires {
+		guards = append(guards, fmt.Sprintf("AND NOT EXISTS (SELECT 1 %s)", required.From))
+	}
+	return fmt.Sprintf(`
+		UPDATE %s SET
+			deleted_at = NULL,
+			deleted_by = NULL,
+			modified_date = $2,
+			modified_by = $3
+		WHERE id = $1
+			AND deleted_at IS NOT NULL
+			%s
+	`, t.Table, strings.Join(guards, "\n\t\t\t"))
+}
+
+// referencesQuery tells whether the entry $1 exists and counts each of its references,
+// it explains why softDeleteQuery left an entry untouched
+func (t catalogueTable) referencesQuery() string {
+	columns := []string{
+		fmt.Sprintf("EXISTS (SELECT 1 FROM %s WHERE id = $1 AND deleted_at IS NULL)", t.Table),
+	}
+	for _, reference := range t.References {
+		columns = append(columns, fmt.Sprintf("(SELECT COUNT(*) %s)", reference.From))
+	}
+	return "SELECT " + strings.Join(columns, ", ")
+}
+
+// requiresQuery tells whether the entry $1 is deleted and counts each of its deleted
+// requirements, it explains why restoreQuery left an entry untouched
+func (t catalogueTable) requiresQuery() string {
+	columns := []string{
+		fmt.Sprintf("EXISTS (SELECT 1 FROM %s WHERE id = $1 AND deleted_at IS NOT NULL)", t.Table),
+	}
+	for _, required := range t.Requires {
+		columns = append(columns, fmt.Sprintf("(SELECT COUNT(*) %s)", required.From))
+	}
+	return "SELECT " + strings.Join(columns, ", ")
+}
@@ -0,0 +1,55 @@
+package product
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func Test_catalogueTable_softDeleteQuery(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	query := uomTable.softDeleteQuery()
+
+	g.Expect(query).To(gomega.ContainSubstring("UPDATE uom SET"))
+	g.Expect(query).To(gomega.ContainSubstring("deleted_by = $3"))
+	g.Expect(query).To(gomega.ContainSubstring("AND deleted_at IS NULL"))
+	g.Expect(strings.Count(query, "AND NOT EXISTS")).To(gomega.Equal(len(uomTable.References)))
+	g.Expect(query).To(gomega.ContainSubstring("AND NOT EXISTS (SELECT 1 FROM price WHERE (price_uom_id = $1 OR quantity_uom_id = $1) AND deleted_at IS NULL)"))
+}
+
+func Test_catalogueTable_referencesQuery(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	g.Expect(productTable.referencesQuery()).To(gomega.Equal(
+		"SELECT EXISTS (SELECT 1 FROM product WHERE id = $1 AND deleted_at IS NULL), " +
+			"(SELECT COUNT(*) FROM product_vendor WHERE product_id = $1 AND deleted_at IS NULL)",
+	))
+}
+
+func Test_catalogueTable_restoreQuery(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	query := priceTable.restoreQuery()
+
+	g.Expect(query).To(gomega.ContainSubstring("UPDATE price SET"))
+	g.Expect(query).To(gomega.ContainSubstring("deleted_at = NULL"))
+	g.Expect(query).To(gomega.ContainSubstring("AND deleted_at IS NOT NULL"))
+	g.Expect(strings.Count(query, "AND NOT EXISTS")).To(gomega.Equal(len(priceTable.Requires)))
+	g.Expect(query).To(gomega.ContainSubstring("AND NOT EXISTS (SELECT 1 FROM vendor WHERE id = (SELECT vendor_id FROM price WHERE id = $1) AND deleted_at IS NOT NULL)"))
+}
+
+func Test_catalogueTable_requiresQuery(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	g.Expect(productVendorTable.requiresQuery()).To(gomega.Equal(
+		"SELECT EXISTS (SELECT 1 FROM product_vendor WHERE id = $1 AND deleted_at IS NOT NULL), " +
+			"(SELECT COUNT(*) FROM product WHERE id = (SELECT product_id FROM product_vendor WHERE id = $1) AND deleted_at IS NOT NULL), " +
+			"(SELECT COUNT(*) FROM uom WHERE id = (SELECT uom_id FROM product_vendor WHERE id = $1) AND deleted_at IS NOT NULL)",
+	))
+}
@@ -0,0 +1,127 @@
+package product
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrCategoryCycle is returned when a category is moved under itself or one of its descendants
+var ErrCategoryCycle = errors.New("category can't be moved under itself or its descendants")
+
+type MoveProductCategorySpec struct {
+	ParentID   string `json:"parent_id"`
+	ModifiedBy string `json:"modified_by"`
+}
+
+// ProductCategoryNode is a category of the category tree with its subcategories
+type ProductCategoryNode struct {
+	ProductCategory
+	Children []*ProductCategoryNode `json:"children"`
+}
+
+const productCategoryTreeColumns = "id, name, code, description, parent_id, specialist_bpid, modified_date, modified_by"
+
+// categoryTreeQuery walks down the category hierarchy from the categories matching
+// anchor. The path of every branch is kept so a cycle left in the data ends the walk
+// instead of looping, parents are returned before their children
+func categoryTreeQuery(anchor string) string {
+	return fmt.Sprintf(`
+		WITH RECURSIVE tree AS (
+			SELECT %[1]s, 0 AS depth, ARRAY[id] AS path
+			FROM product_category
+			WHERE %[2]s AND deleted_at IS NULL
+			UNION ALL
+			SELECT pc.id, pc.name, pc.code, pc.description, pc.parent_id, pc.specialist_bpid, pc.modified_date, pc.modified_by,
+				t.depth + 1, t.path || pc.id
+			FROM product_category pc
+			JOIN tree t ON pc.parent_id = t.id
+			WHERE pc.deleted_at IS NULL AND NOT pc.id = ANY(t.path)
+		)
+		SELECT %[1]s
+		FROM tree
+		ORDER BY depth, name, id
+	`, productCategoryTreeColumns, anchor)
+}
+
+// categorySubtreeIDsQuery selects the ids of the category given as placeholder and of
+// all its descendants, UNION drops the ids already found so cycles end the walk
+func categorySubtreeIDsQuery(placeholder string) string {
+	return fmt.Sprintf(`
+		WITH RECURSIVE subtree AS (
+			SELECT id FROM product_category WHERE id = %[1]s AND deleted_at IS NULL
+			UNION
+			SELECT pc.id
+			FROM product_category pc
+			JOIN subtree s ON pc.parent_id = s.id
+			WHERE pc.deleted_at IS NULL
+		)
+		SELECT id FROM subtree
+	`, placeholder)
+}
+
+var (
+	// getCategoryTreeQuery returns the categories under the parent $1
+	getCategoryTreeQuery = categoryTreeQuery("parent_id = $1")
+	// getCategorySubtreeQuery returns the category $1 and its descendants
+	getCategorySubtreeQuery = categoryTreeQuery("id = $1")
+
+	// getCategoryAncestorsQuery walks up from the category $1, the root comes first
+	getCategoryAncestorsQuery = fmt.Sprintf(`
+		WITH RECURSIVE ancestors AS (
+			SELECT %[1]s, 0 AS depth, ARRAY[id] AS path
+			FROM product_category
+			WHERE id = $1 AND deleted_at IS NULL
+			UNION ALL
+			SELECT pc.id, pc.name, pc.code, pc.description, pc.parent_id, pc.specialist_bpid, pc.modified_date, pc.modified_by,
+				a.depth + 1, a.path || pc.id
+			FROM product_category pc
+			JOIN ancestors a ON pc.id = a.parent_id
+			WHERE pc.deleted_at IS NULL AND NOT pc.id = ANY(a.path)
+		)
+		SELECT %[1]s
+		FROM ancestors
+		ORDER BY depth DESC
+	`, productCategoryTreeColumns)
+
+	// moveProductCategoryQuery sets the parent of the category $1 to $2 unless $2 is
+	// a missing category or the category itself or one of its descendants, the check
+	// and the update are a single statement so concurrent moves can't form a cycle
+	moveProductCategoryQuery = fmt.Sprintf(`
+		UPDATE product_category SET
+			parent_id = $2,
+			modified_date = $3,
+			modified_by = $4
+		WHERE id = $1
+			AND deleted_at IS NULL
+			AND $2 NOT IN (%[1]s)
+			AND ($2 = '%[2]s' OR EXISTS (SELECT 1 FROM product_category WHERE id = $2 AND deleted_at IS NULL))
+	`, categorySubtreeIDsQuery("$1"), RootCategoryParentID)
+
+	// checkProductCategoryMoveQuery tells why moveProductCategoryQuery left a category untouched
+	checkProductCategoryMoveQuery = fmt.Sprintf(`
+		SELECT
+			EXISTS (SELECT 1 FROM product_category WHERE id = $1 AND deleted_at IS NULL),
+			$2 = '%[2]s' OR EXISTS (SELECT 1 FROM product_category WHERE id = $2 AND deleted_at IS NULL),
+			$2 IN (%[1]s)
+	`, categorySubtreeIDsQuery("$1"), RootCategoryParentID)
+)
+
+// buildCategoryTree nests the categories under their parents, categories are
+// expected parents first. Categories whose parent isn't part of the list are roots
+func buildCategoryTree(categories []ProductCategory) []*ProductCategoryNode {
+	var (
+		roots = []*ProductCategoryNode{}
+		nodes = make(map[string]*ProductCategoryNode, len(categories))
+	)
+	for _, category := range categories {
+		node := &ProductCategoryNode{ProductCategory: category, Children: []*ProductCategoryNode{}}
+		nodes[category.ID] = node
+
+		if parent, ok := nodes[category.ParentID]; ok {
+			parent.Children = append(parent.Children, node)
+			continue
+		}
+		roots = append(roots, node)
+	}
+	return roots
+}
@@ -0,0 +1,60 @@
+package product
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func Test_buildCategoryTree(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nests categories under their parents", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		roots := buildCategoryTree([]ProductCategory{
+			{ID: "A", ParentID: "0"},
+			{ID: "B", ParentID: "0"},
+			{ID: "A1", ParentID: "A"},
+			{ID: "A2", ParentID: "A"},
+			{ID: "A1a", ParentID: "A1"},
+		})
+
+		g.Expect(roots).To(gomega.HaveLen(2))
+		g.Expect(roots[0].ID).To(gomega.Equal("A"))
+		g.Expect(roots[0].Children).To(gomega.HaveLen(2))
+		g.Expect(roots[0].Children[0].ID).To(gomega.Equal("A1"))
+		g.Expect(roots[0].Children[0].Children[0].ID).To(gomega.Equal("A1a"))
+		g.Expect(roots[0].Children[1].Children).To(gomega.BeEmpty())
+		g.Expect(roots[1].ID).To(gomega.Equal("B"))
+	})
+
+	t.Run("categories whose parent isn't listed are roots", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		roots := buildCategoryTree([]ProductCategory{
+			{ID: "A1", ParentID: "A"},
+			{ID: "A1a", ParentID: "A1"},
+		})
+
+		g.Expect(roots).To(gomega.HaveLen(1))
+		g.Expect(roots[0].ID).To(gomega.Equal("A1"))
+		g.Expect(roots[0].Children).To(gomega.HaveLen(1))
+	})
+
+	t.Run("no categories", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		g.Expect(buildCategoryTree(nil)).To(gomega.BeEmpty())
+	})
+}
+
+func Test_categoryTreeQuery(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	query := categoryTreeQuery("id = $1")
+
+	g.Expect(query).To(gomega.ContainSubstring("WITH RECURSIVE tree AS"))
+	g.Expect(query).To(gomega.ContainSubstring("WHERE id = $1 AND deleted_at IS NULL"))
+	g.Expect(query).To(gomega.ContainSubstring("NOT pc.id = ANY(t.path)"))
+}
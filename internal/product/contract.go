@@ -2,6 +2,7 @@ package product
 
 import (
 	"kg/procurement/internal/common/database"
+	"kg/procurement/internal/currency"
 	"time"
 )
 
@@ -67,6 +68,8 @@ func newProductResponseFromProduct(product *Product, productCategory *ProductCat
 	}
 }
 
+// PriceResponse carries the price in its original currency, Converted is the same
+// price in the base currency and is nil when no exchange rate is known
 type PriceResponse struct {
 	ID            string         `json:"id"`
 	Price         float64        `json:"price"`
@@ -74,10 +77,51 @@ type PriceResponse struct {
 	PriceQuantity int         `json:"price_quantity"`
 	VendorID      string         `json:"vendor_id"`
 	UOM           UOMResponse    `json:"uom"`
+	Converted     *ConvertedPriceResponse `json:"converted"`
+	UnitPrice     *UnitPriceResponse      `json:"unit_price"`
 	ModifiedDate  time.Time      `json:"modified_date"`
 	ModifiedBy    string         `json:"modified_by"`
 }
 
+type ConvertedPriceResponse struct {
+	Price        float64   `json:"price"`
+	CurrencyCode string    `json:"currency_code"`
+	ExchangeRate float64   `json:"exchange_rate"`
+	RateDate     time.Time `json:"rate_date"`
+}
+
+func newConvertedPriceResponse(price *Price, rate *currency.ExchangeRate) *ConvertedPriceResponse {
+	return &ConvertedPriceResponse{
+		Price:        price.Price * rate.Rate,
+		CurrencyCode: rate.ToCurrency,
+		ExchangeRate: rate.Rate,
+		RateDate:     rate.RateDate,
+	}
+}
+
+// UnitPriceResponse is the price of a single unit of the product's UOM, prices
+// quoted per box and per piece are compared through it. ConvertedPrice is in
+// the base currency when the price could be converted
+type UnitPriceResponse struct {
+	Price          float64  `json:"price"`
+	UOMID          string   `json:"uom_id"`
+	ConvertedPrice *float64 `json:"converted_price"`
+}
+
+// newUnitPriceResponse divides the price by the amount of the product's UOM it is quoted for
+func newUnitPriceResponse(price *Price, uomID string, factor float64, converted *ConvertedPriceResponse) *UnitPriceResponse {
+	units := float64(max(price.PriceQuantity, 1)) * factor
+	res := &UnitPriceResponse{
+		Price: price.Price / units,
+		UOMID: uomID,
+	}
+	if converted != nil {
+		convertedPrice := converted.Price / units
+		res.ConvertedPrice = &convertedPrice
+	}
+	return res
+}
+
 func newPriceResponseFromPrice(price *Price, uom *UOM) *PriceResponse {
 	UOMResponse := newFromUOM(uom)
 	return &PriceResponse{
@@ -95,7 +139,7 @@ func newPriceResponseFromPrice(price *Price, uom *UOM) *PriceResponse {
 type ProductVendorResponse struct {
 	ID                  string          `json:"id"`
 	Product             ProductResponse `json:"product"`
-	Price               PriceResponse   `json:"price"`
+	Price               *PriceResponse  `json:"price"`
 	Code                string          `json:"code"`
 	Name                string          `json:"name"`
 	IncomeTaxID         string          `json:"income_tax_id"`
@@ -108,13 +152,20 @@ type ProductVendorResponse struct {
 	ModifiedBy          string          `json:"modified_by"`
 }
 
+// ToProductVendorResponse builds the response of a product vendor, pr is nil
+// when the product vendor has no applicable price
 func ToProductVendorResponse(pv *ProductVendor, p *Product, pr *Price, pc *ProductCategory, uom *UOM) *ProductVendorResponse {
 	productResponse := newProductResponseFromProduct(p, pc)
-	priceResponse := newPriceResponseFromPrice(pr, uom)
+
+	var priceResponse *PriceResponse
+	if pr != nil {
+		priceResponse = newPriceResponseFromPrice(pr, uom)
+	}
+
 	return &ProductVendorResponse{
 		ID:                  pv.ID,
 		Product:             *productResponse,
-		Price:               *priceResponse,
+		Price:               priceResponse,
 		Code:                pv.Code,
 		Name:                pv.Name,
 		IncomeTaxID:         pv.IncomeTaxID,
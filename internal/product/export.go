@@ -0,0 +1,149 @@
+package product
+
+import (
+	"fmt"
+	"sort"
+)
+
+var productVendorExportHeader = []interface{}{
+	"id", "code", "name", "product_id", "product_name", "product_category", "sap_code", "uom_id",
+	"income_tax_name", "income_tax_percentage", "price_id", "vendor_id", "price", "currency_code",
+	"price_quantity", "price_uom", "base_price", "base_currency", "exchange_rate", "rate_date",
+	"unit_price", "unit_uom_id", "base_unit_price", "modified_date", "modified_by",
+}
+
+func productVendorExportRecord(pv ProductVendorResponse) []interface{} {
+	record := []interface{}{
+		pv.ID, pv.Code, pv.Name, string(pv.Product.ID), pv.Product.Name, pv.Product.ProductCategory.CategoryName,
+		pv.SAPCode, pv.UOMID, pv.IncomeTaxName, pv.IncomeTaxPercentage,
+	}
+
+	// product vendors without an applicable price, rate or conversion leave those columns blank
+	var (
+		price     = []interface{}{"", "", "", "", "", ""}
+		converted = []interface{}{"", "", "", ""}
+		unitPrice = []interface{}{"", "", ""}
+	)
+	if pv.Price != nil {
+		price = []interface{}{
+			pv.Price.ID, pv.Price.VendorID, pv.Price.Price, pv.Price.CurrencyCode, pv.Price.PriceQuantity, pv.Price.UOM.UOMName,
+		}
+		if c := pv.Price.Converted; c != nil {
+			converted = []interface{}{c.Price, c.CurrencyCode, c.ExchangeRate, c.RateDate}
+		}
+		if u := pv.Price.UnitPrice; u != nil {
+			unitPrice = []interface{}{u.Price, u.UOMID, ""}
+			if u.ConvertedPrice != nil {
+				unitPrice[2] = *u.ConvertedPrice
+			}
+		}
+	}
+
+	record = append(record, price...)
+	record = append(record, converted...)
+	record = append(record, unitPrice...)
+	return append(record, pv.ModifiedDate, pv.ModifiedBy)
+}
+
+var priceComparisonHeader = []interface{}{
+	"Product", "Rank", "Vendor", "Product vendor", "Price", "Currency", "Per", "UOM",
+	"Unit price", "Unit", "Base currency", "vs cheapest",
+}
+
+// priceComparisonEntry is the price of a product vendor on the comparison sheet, UnitPrice
+// is the converted price of a single unit of the product's UOM used to rank the vendors
+type priceComparisonEntry struct {
+	ProductID         string
+	ProductName       string
+	ProductVendorName string
+	VendorID          string
+	Price             float64
+	CurrencyCode      string
+	PriceQuantity     int
+	UOMName           string
+	UnitPrice         float64
+	UnitUOMID         string
+	BaseCurrency      string
+	Comparable        bool
+}
+
+func newPriceComparisonEntry(pv ProductVendorResponse) priceComparisonEntry {
+	entry := priceComparisonEntry{
+		ProductID:         string(pv.Product.ID),
+		ProductName:       pv.Product.Name,
+		ProductVendorName: pv.Name,
+		VendorID:          pv.Price.VendorID,
+		Price:             pv.Price.Price,
+		CurrencyCode:      pv.Price.CurrencyCode,
+		PriceQuantity:     pv.Price.PriceQuantity,
+		UOMName:           pv.Price.UOM.UOMName,
+	}
+
+	// prices without a rate to the base currency or a conversion to the product's
+	// UOM can't be ranked against the others
+	if u := pv.Price.UnitPrice; u != nil && u.ConvertedPrice != nil && pv.Price.Converted != nil {
+		entry.UnitPrice = *u.ConvertedPrice
+		entry.UnitUOMID = u.UOMID
+		entry.BaseCurrency = pv.Price.Converted.CurrencyCode
+		entry.Comparable = true
+	}
+	return entry
+}
+
+// sortPriceComparison groups the entries by product and orders the prices of
+// a product from the cheapest, prices that aren't comparable come last
+func sortPriceComparison(entries []priceComparisonEntry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.ProductName != b.ProductName {
+			return a.ProductName < b.ProductName
+		}
+		if a.ProductID != b.ProductID {
+			return a.ProductID < b.ProductID
+		}
+		if a.Comparable != b.Comparable {
+			return a.Comparable
+		}
+		return a.UnitPrice < b.UnitPrice
+	})
+}
+
+// priceComparisonRecords renders the sorted entries, the rank and the difference
+// to the cheapest price restart for every product
+func priceComparisonRecords(entries []priceComparisonEntry, vendorNames map[string]string) [][]interface{} {
+	var (
+		records  [][]interface{}
+		product  string
+		rank     int
+		cheapest float64
+	)
+	for _, entry := range entries {
+		if entry.ProductID != product {
+			product = entry.ProductID
+			rank = 0
+			cheapest = entry.UnitPrice
+		}
+
+		vendor := vendorNames[entry.VendorID]
+		if vendor == "" {
+			vendor = entry.VendorID
+		}
+
+		var rankCell, unitPrice, difference interface{} = "", "", ""
+		if entry.Comparable {
+			rank++
+			rankCell = rank
+			unitPrice = entry.UnitPrice
+			difference = "-"
+			if cheapest > 0 && rank > 1 {
+				difference = fmt.Sprintf("+%.1f%%", (entry.UnitPrice-cheapest)/cheapest*100)
+			}
+		}
+
+		records = append(records, []interface{}{
+			entry.ProductName, rankCell, vendor, entry.ProductVendorName, entry.Price, entry.CurrencyCode,
+			entry.PriceQuantity, entry.UOMName, unitPrice, entry.UnitUOMID, entry.BaseCurrency, difference,
+		})
+	}
+	return records
+}
@@ -0,0 +1,122 @@
+package product
+
+import (
+	"errors"
+	"kg/procurement/internal/audit"
+	"time"
+)
+
+var (
+	ErrProductVendorNotFound = errors.New("product vendor not found")
+	ErrPriceNotFound         = errors.New("price not found")
+
+	// ErrInvalidPrice is returned when a created price misses a field or refers to a missing entry
+	ErrInvalidPrice = errors.New("invalid price")
+	// ErrOverlappingPrice is returned when a created price shares its quantity tier and
+	// validity window with a price of the same vendor, purchasing org and area group
+	ErrOverlappingPrice = errors.New("price overlaps an existing price")
+)
+
+// PostProductVendorSpec creates the offering of a product by a vendor, the
+// product's UOM is used when UOMID is left empty
+type PostProductVendorSpec struct {
+	ProductID           string `json:"product_id" binding:"required"`
+	Code                string `json:"code"`
+	Name                string `json:"name" binding:"required"`
+	IncomeTaxID         string `json:"income_tax_id"`
+	IncomeTaxName       string `json:"income_tax_name"`
+	IncomeTaxPercentage string `json:"income_tax_percentage"`
+	Description         string `json:"description"`
+	UOMID               string `json:"uom_id"`
+	SAPCode             string `json:"sap_code"`
+	ModifiedBy          string `json:"modified_by"`
+}
+
+// PostPriceSpec creates a price of a product vendor, a nil ValidTo leaves the
+// validity window open and a zero QuantityMax leaves the quantity tier open
+type PostPriceSpec struct {
+	PurchasingOrgID   string     `json:"purchasing_org_id"`
+	PurchasingOrgName string     `json:"purchasing_org_name"`
+	VendorID          string     `json:"vendor_id" binding:"required"`
+	ProductVendorID   string     `json:"product_vendor_id" binding:"required"`
+	QuantityMin       int        `json:"quantity_min"`
+	QuantityMax       int        `json:"quantity_max"`
+	QuantityUOMID     string     `json:"quantity_uom_id"`
+	LeadTimeMin       int        `json:"lead_time_min"`
+	LeadTimeMax       int        `json:"lead_time_max"`
+	CurrencyCode      string     `json:"currency_code" binding:"required"`
+	Price             float64    `json:"price" binding:"required"`
+	PriceQuantity     int        `json:"price_quantity"`
+	PriceUOMID        string     `json:"price_uom_id" binding:"required"`
+	ValidFrom         time.Time  `json:"valid_from" binding:"required"`
+	ValidTo           *time.Time `json:"valid_to"`
+	ValidPatternID    string     `json:"valid_pattern_id"`
+	AreaGroupID       string     `json:"area_group_id"`
+	AreaGroupName     string     `json:"area_group_name"`
+	ReferenceNumber   string     `json:"reference_number"`
+	ReferenceDate     *time.Time `json:"reference_date"`
+	DocumentTypeID    string     `json:"document_type_id"`
+	DocumentID        string     `json:"document_id"`
+	ItemID            string     `json:"item_id"`
+	TermOfPaymentID   string     `json:"term_of_payment_id"`
+	InvocationOrder   int        `json:"invocation_order"`
+	ModifiedBy        string     `json:"modified_by"`
+}
+
+var (
+	productVendorTable = catalogueTable{
+		Table:    "product_vendor",
+		NotFound: ErrProductVendorNotFound,
+		References: []catalogueReference{
+			{Name: "prices", From: "FROM price WHERE product_vendor_id = $1 AND deleted_at IS NULL"},
+		},
+		Requires: []catalogueReference{
+			{Name: "product", From: "FROM product WHERE id = (SELECT product_id FROM product_vendor WHERE id = $1) AND deleted_at IS NOT NULL"},
+			{Name: "uom", From: "FROM uom WHERE id = (SELECT uom_id FROM product_vendor WHERE id = $1) AND deleted_at IS NOT NULL"},
+		},
+	}
+	// prices keep their history once deleted, nothing else refers to them
+	priceTable = catalogueTable{
+		Table:    "price",
+		NotFound: ErrPriceNotFound,
+		Audit:    audit.EntityPrice,
+		Requires: []catalogueReference{
+			{Name: "product vendor", From: "FROM product_vendor WHERE id = (SELECT product_vendor_id FROM price WHERE id = $1) AND deleted_at IS NOT NULL"},
+			{Name: "vendor", From: "FROM vendor WHERE id = (SELECT vendor_id FROM price WHERE id = $1) AND deleted_at IS NOT NULL"},
+		},
+	}
+)
+
+// pricesOverlap tells whether two prices would both apply to the same purchase, that
+// is when they are offered to the same purchasing org and area group and both their
+// quantity tiers and validity windows intersect
+func pricesOverlap(a Price, b Price) bool {
+	if a.VendorID != b.VendorID || a.PurchasingOrgID != b.PurchasingOrgID || a.AreaGroupID != b.AreaGroupID {
+		return false
+	}
+	return rangesOverlap(a.QuantityMin, a.QuantityMax, b.QuantityMin, b.QuantityMax) &&
+		windowsOverlap(a.ValidFrom, a.ValidTo, b.ValidFrom, b.ValidTo)
+}
+
+// rangesOverlap tells whether two quantity tiers intersect, a zero max is unbounded
+func rangesOverlap(aMin, aMax, bMin, bMax int) bool {
+	if aMax > 0 && aMax < bMin {
+		return false
+	}
+	if bMax > 0 && bMax < aMin {
+		return false
+	}
+	return true
+}
+
+// windowsOverlap tells whether two validity windows intersect, a zero start or nil
+// end leaves them open
+func windowsOverlap(aFrom time.Time, aTo *time.Time, bFrom time.Time, bTo *time.Time) bool {
+	if aTo != nil && !bFrom.IsZero() && aTo.Before(bFrom) {
+		return false
+	}
+	if bTo != nil && !aFrom.IsZero() && bTo.Before(aFrom) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,43 @@
+package product
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+)
+
+func Test_pricesOverlap(t *testing.T) {
+	t.Parallel()
+
+	var (
+		december = time.Date(2024, time.December, 1, 0, 0, 0, 0, time.UTC)
+		january  = time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+		february = time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC)
+		base     = Price{VendorID: "V1", PurchasingOrgID: "O1", AreaGroupID: "A1", QuantityMin: 1, QuantityMax: 10, ValidFrom: december, ValidTo: &january}
+	)
+
+	tests := []struct {
+		name     string
+		other    func(p Price) Price
+		expected bool
+	}{
+		{"same tier and window", func(p Price) Price { return p }, true},
+		{"next quantity tier", func(p Price) Price { p.QuantityMin, p.QuantityMax = 11, 50; return p }, false},
+		{"tiers sharing a bound", func(p Price) Price { p.QuantityMin, p.QuantityMax = 10, 50; return p }, true},
+		{"open quantity tier", func(p Price) Price { p.QuantityMin, p.QuantityMax = 5, 0; return p }, true},
+		{"later window", func(p Price) Price { p.ValidFrom, p.ValidTo = january.Add(time.Hour), &february; return p }, false},
+		{"open window", func(p Price) Price { p.ValidFrom, p.ValidTo = december.Add(-time.Hour), nil; return p }, true},
+		{"other purchasing org", func(p Price) Price { p.PurchasingOrgID = "O2"; return p }, false},
+		{"other area group", func(p Price) Price { p.AreaGroupID = ""; return p }, false},
+		{"other vendor", func(p Price) Price { p.VendorID = "V2"; return p }, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			other := tt.other(base)
+			g.Expect(pricesOverlap(base, other)).To(gomega.Equal(tt.expected))
+			g.Expect(pricesOverlap(other, base)).To(gomega.Equal(tt.expected))
+		})
+	}
+}
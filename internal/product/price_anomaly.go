@@ -0,0 +1,262 @@
+package product
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"kg/procurement/cmd/config"
+	"kg/procurement/internal/common/database"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	PriceAnomalyModeBlock    = "block"
+	PriceAnomalyModeConfirm  = "confirm"
+	PriceAnomalyModeApproval = "approval"
+
+	PriceAnomalySourceUpdate = "update"
+	PriceAnomalySourceImport = "import"
+)
+
+type PriceAnomalyStatus string
+
+const (
+	// PriceAnomalyStatusBlocked is an anomalous price that was rejected
+	PriceAnomalyStatusBlocked PriceAnomalyStatus = "blocked"
+	// PriceAnomalyStatusConfirmed is an anomalous price its requester confirmed
+	PriceAnomalyStatusConfirmed PriceAnomalyStatus = "confirmed"
+	// PriceAnomalyStatusPending is an anomalous price held until it is reviewed
+	PriceAnomalyStatusPending  PriceAnomalyStatus = "pending"
+	PriceAnomalyStatusApproved PriceAnomalyStatus = "approved"
+	PriceAnomalyStatusRejected PriceAnomalyStatus = "rejected"
+)
+
+func (s PriceAnomalyStatus) String() string {
+	return string(s)
+}
+
+var (
+	// ErrPriceAnomaly is returned when a price strays too far from its previous value
+	// or from other vendors, see PriceAnomalyError
+	ErrPriceAnomaly                = errors.New("price anomaly")
+	ErrPriceAnomalyNotFound        = errors.New("price anomaly not found")
+	ErrPriceAnomalyNotPending      = errors.New("price anomaly is not pending approval")
+	ErrPriceAnomalySelfApproval    = errors.New("price anomaly cannot be reviewed by its requester")
+	ErrPriceAnomalyStale           = errors.New("price changed since the price anomaly was raised")
+	ErrInvalidPriceAnomalyStatus   = errors.New("invalid price anomaly status")
+	errPriceAnomalyWithoutProposal = errors.New("pending price anomaly has no proposed price")
+)
+
+// PriceProposal is the price a pending anomaly applies once approved, stored as JSON.
+// Its ModifiedDate is the one of the price version it was proposed against
+type PriceProposal Price
+
+func (p PriceProposal) Value() (driver.Value, error) {
+	payload, err := json.Marshal(Price(p))
+	if err != nil {
+		return nil, err
+	}
+	// lib/pq sends []byte as bytea, a string is cast to JSONB
+	return string(payload), nil
+}
+
+func (p *PriceProposal) Scan(src interface{}) error {
+	var payload []byte
+	switch v := src.(type) {
+	case []byte:
+		payload = v
+	case string:
+		payload = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into PriceProposal", src)
+	}
+	price := Price{}
+	if err := json.Unmarshal(payload, &price); err != nil {
+		return err
+	}
+	*p = PriceProposal(price)
+	return nil
+}
+
+// PriceAnomaly records a price that strayed beyond the configured thresholds. The ratios
+// are always at least 1, a price dropping to a tenth of its previous value has a ratio of 10
+type PriceAnomaly struct {
+	ID              string         `db:"id" json:"id"`
+	PriceID         string         `db:"price_id" json:"price_id"`
+	ProductVendorID string         `db:"product_vendor_id" json:"product_vendor_id"`
+	VendorID        string         `db:"vendor_id" json:"vendor_id"`
+	Source          string         `db:"source" json:"source"`
+	OldPrice        *float64       `db:"old_price" json:"old_price"`
+	NewPrice        float64        `db:"new_price" json:"new_price"`
+	CurrencyID      string         `db:"currency_id" json:"currency_id"`
+	HistoryRatio    *float64       `db:"history_ratio" json:"history_ratio"`
+	PeerMedian      *float64       `db:"peer_median" json:"peer_median"`
+	PeerRatio       *float64       `db:"peer_ratio" json:"peer_ratio"`
+	PeerCount       int            `db:"peer_count" json:"peer_count"`
+	Status          string         `db:"status" json:"status"`
+	Proposed        *PriceProposal `db:"proposed" json:"proposed,omitempty"`
+	RequestedBy     string         `db:"requested_by" json:"requested_by"`
+	Reason          string         `db:"reason" json:"reason"`
+	ReviewedBy      string         `db:"reviewed_by" json:"reviewed_by"`
+	ReviewedDate    *time.Time     `db:"reviewed_date" json:"reviewed_date"`
+	ModifiedDate    time.Time      `db:"modified_date" json:"modified_date"`
+}
+
+// describe explains which threshold the price crossed
+func (a PriceAnomaly) describe() string {
+	var reasons []string
+	if a.HistoryRatio != nil && a.OldPrice != nil {
+		reasons = append(reasons, fmt.Sprintf("%.1fx away from its previous price %v", *a.HistoryRatio, *a.OldPrice))
+	}
+	if a.PeerRatio != nil && a.PeerMedian != nil {
+		reasons = append(reasons, fmt.Sprintf("%.1fx away from the median unit price %v of %d other vendors", *a.PeerRatio, *a.PeerMedian, a.PeerCount))
+	}
+	return fmt.Sprintf("price %v is %s", a.NewPrice, strings.Join(reasons, " and "))
+}
+
+// PriceAnomalyError is returned when an anomalous price isn't applied, either because it
+// is blocked, awaits confirmation or is held for approval as told by the anomaly status
+type PriceAnomalyError struct {
+	Anomaly PriceAnomaly `json:"anomaly"`
+}
+
+func (e *PriceAnomalyError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrPriceAnomaly.Error(), e.Anomaly.describe())
+}
+
+func (e *PriceAnomalyError) Unwrap() error {
+	return ErrPriceAnomaly
+}
+
+type GetPriceAnomaliesSpec struct {
+	Status          string
+	ProductVendorID string
+	database.PaginationSpec
+}
+
+type AccessorGetPriceAnomaliesPaginationData struct {
+	PriceAnomalies []PriceAnomaly              `json:"price_anomalies"`
+	Metadata       database.PaginationMetadata `json:"metadata"`
+}
+
+type ReviewPriceAnomalySpec struct {
+	Approved   bool   `json:"approved"`
+	ReviewedBy string `json:"reviewed_by" binding:"required"`
+}
+
+// ParsePriceAnomalyStatus validates a status filter of the anomaly listing
+func ParsePriceAnomalyStatus(status string) (PriceAnomalyStatus, error) {
+	switch PriceAnomalyStatus(status) {
+	case PriceAnomalyStatusBlocked, PriceAnomalyStatusConfirmed, PriceAnomalyStatusPending,
+		PriceAnomalyStatusApproved, PriceAnomalyStatusRejected:
+		return PriceAnomalyStatus(status), nil
+	default:
+		return "", ErrInvalidPriceAnomalyStatus
+	}
+}
+
+// priceAnomalyChecksEnabled tells whether any comparison is configured
+func priceAnomalyChecksEnabled(cfg config.PriceAnomalyConfig) bool {
+	return cfg.MaxHistoryRatio > 0 || cfg.MaxPeerRatio > 0
+}
+
+// detectPriceAnomaly compares the unit price of price against its previous version and
+// the median unit price of peers, the prices other vendors offer for the same product.
+// Only prices in the same currency and price UOM are compared, nil is returned when the
+// price stays within the thresholds
+func detectPriceAnomaly(cfg config.PriceAnomalyConfig, price Price, previous *Price, peers []Price) *PriceAnomaly {
+	unit := unitPrice(price)
+	if unit <= 0 {
+		return nil
+	}
+
+	anomaly := PriceAnomaly{
+		PriceID:         price.ID,
+		ProductVendorID: price.ProductVendorID,
+		VendorID:        price.VendorID,
+		NewPrice:        price.Price,
+		CurrencyID:      price.CurrencyID,
+	}
+	flagged := false
+
+	if cfg.MaxHistoryRatio > 0 && previous != nil && comparablePrices(price, *previous) {
+		if previousUnit := unitPrice(*previous); previousUnit > 0 {
+			ratio := priceRatio(unit, previousUnit)
+			oldPrice := previous.Price
+			anomaly.OldPrice = &oldPrice
+			anomaly.HistoryRatio = &ratio
+			flagged = ratio > cfg.MaxHistoryRatio
+		}
+	}
+
+	if cfg.MaxPeerRatio > 0 {
+		var units []float64
+		for _, peer := range peers {
+			if peer.VendorID == price.VendorID || !comparablePrices(price, peer) {
+				continue
+			}
+			if peerUnit := unitPrice(peer); peerUnit > 0 {
+				units = append(units, peerUnit)
+			}
+		}
+		if len(units) > 0 && len(units) >= cfg.MinPeers {
+			median := medianPrice(units)
+			ratio := priceRatio(unit, median)
+			anomaly.PeerMedian = &median
+			anomaly.PeerRatio = &ratio
+			anomaly.PeerCount = len(units)
+			flagged = flagged || ratio > cfg.MaxPeerRatio
+		}
+	}
+
+	if !flagged {
+		return nil
+	}
+	return &anomaly
+}
+
+// comparablePrices tells whether two prices are quoted in the same currency and unit
+func comparablePrices(a Price, b Price) bool {
+	return a.CurrencyID == b.CurrencyID && a.PriceUOMID == b.PriceUOMID
+}
+
+// unitPrice is the price of a single price UOM, a zero price quantity counts as one
+func unitPrice(price Price) float64 {
+	if price.PriceQuantity > 1 {
+		return price.Price / float64(price.PriceQuantity)
+	}
+	return price.Price
+}
+
+// priceRatio is how many times a is away from b in either direction
+func priceRatio(a float64, b float64) float64 {
+	if a > b {
+		return a / b
+	}
+	return b / a
+}
+
+func medianPrice(units []float64) float64 {
+	sorted := append([]float64(nil), units...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+// priceImportAnomalyMessage is the row error of an imported price flagged as an anomaly
+func priceImportAnomalyMessage(mode string, anomaly PriceAnomaly) string {
+	switch mode {
+	case PriceAnomalyModeConfirm:
+		return anomaly.describe() + ", set confirm_anomalies to import it anyway"
+	case PriceAnomalyModeApproval:
+		return anomaly.describe() + ", update the price on its own to request approval"
+	default:
+		return anomaly.describe()
+	}
+}
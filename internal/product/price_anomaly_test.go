@@ -0,0 +1,132 @@
+package product
+
+import (
+	"kg/procurement/cmd/config"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+)
+
+func Test_detectPriceAnomaly(t *testing.T) {
+	t.Parallel()
+
+	var (
+		cfg = config.PriceAnomalyConfig{
+			Mode:            PriceAnomalyModeConfirm,
+			MaxHistoryRatio: 5,
+			MaxPeerRatio:    3,
+			MinPeers:        2,
+		}
+		previous = Price{ID: "P1", VendorID: "V1", Price: 1000, PriceQuantity: 1, CurrencyID: "C1", PriceUOMID: "PCS"}
+		peers    = []Price{
+			{ID: "P2", VendorID: "V2", Price: 900, CurrencyID: "C1", PriceUOMID: "PCS"},
+			{ID: "P3", VendorID: "V3", Price: 12000, PriceQuantity: 12, CurrencyID: "C1", PriceUOMID: "PCS"},
+			{ID: "P4", VendorID: "V4", Price: 1100, CurrencyID: "C1", PriceUOMID: "PCS"},
+		}
+	)
+	priced := func(amount float64) Price {
+		price := previous
+		price.Price = amount
+		return price
+	}
+
+	t.Run("within the thresholds", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		g.Expect(detectPriceAnomaly(cfg, priced(1200), &previous, peers)).To(gomega.BeNil())
+	})
+
+	t.Run("an extra zero", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		res := detectPriceAnomaly(cfg, priced(10000), &previous, peers)
+		g.Expect(res).ToNot(gomega.BeNil())
+		g.Expect(res.PriceID).To(gomega.Equal("P1"))
+		g.Expect(*res.OldPrice).To(gomega.Equal(1000.0))
+		g.Expect(*res.HistoryRatio).To(gomega.Equal(10.0))
+		g.Expect(*res.PeerMedian).To(gomega.Equal(1000.0))
+		g.Expect(*res.PeerRatio).To(gomega.Equal(10.0))
+		g.Expect(res.PeerCount).To(gomega.Equal(3))
+		g.Expect(res.describe()).To(gomega.ContainSubstring("10.0x away from its previous price 1000"))
+	})
+
+	t.Run("a missing zero", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		res := detectPriceAnomaly(cfg, priced(100), &previous, nil)
+		g.Expect(res).ToNot(gomega.BeNil())
+		g.Expect(*res.HistoryRatio).To(gomega.Equal(10.0))
+		g.Expect(res.PeerRatio).To(gomega.BeNil())
+	})
+
+	t.Run("compares unit prices", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		perDozen := priced(12000)
+		perDozen.PriceQuantity = 12
+		g.Expect(detectPriceAnomaly(cfg, perDozen, &previous, peers)).To(gomega.BeNil())
+	})
+
+	t.Run("flags a price far from other vendors", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		// a new price has no history to compare against
+		res := detectPriceAnomaly(cfg, priced(4000), nil, peers)
+		g.Expect(res).ToNot(gomega.BeNil())
+		g.Expect(res.HistoryRatio).To(gomega.BeNil())
+		g.Expect(*res.PeerRatio).To(gomega.Equal(4.0))
+	})
+
+	t.Run("needs enough peers", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		g.Expect(detectPriceAnomaly(cfg, priced(4000), nil, peers[:1])).To(gomega.BeNil())
+	})
+
+	t.Run("skips the same vendor and other currencies or units", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		others := []Price{
+			{VendorID: "V1", Price: 100, CurrencyID: "C1", PriceUOMID: "PCS"},
+			{VendorID: "V2", Price: 100, CurrencyID: "C2", PriceUOMID: "PCS"},
+			{VendorID: "V3", Price: 100, CurrencyID: "C1", PriceUOMID: "BOX"},
+		}
+		g.Expect(detectPriceAnomaly(cfg, priced(4000), nil, others)).To(gomega.BeNil())
+
+		inUSD := previous
+		inUSD.CurrencyID = "C2"
+		g.Expect(detectPriceAnomaly(cfg, priced(10000), &inUSD, nil)).To(gomega.BeNil())
+	})
+
+	t.Run("a zero ratio disables the comparison", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		historyOnly := cfg
+		historyOnly.MaxPeerRatio = 0
+		g.Expect(detectPriceAnomaly(historyOnly, priced(4000), &previous, peers)).To(gomega.BeNil())
+		g.Expect(priceAnomalyChecksEnabled(config.PriceAnomalyConfig{})).To(gomega.BeFalse())
+	})
+}
+
+func Test_medianPrice(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	g.Expect(medianPrice([]float64{3, 1, 2})).To(gomega.Equal(2.0))
+	g.Expect(medianPrice([]float64{4, 1, 3, 2})).To(gomega.Equal(2.5))
+}
+
+func Test_PriceProposal(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	proposal := PriceProposal(Price{ID: "P1", Price: 1000, ValidFrom: time.Date(2024, time.December, 1, 0, 0, 0, 0, time.UTC)})
+	value, err := proposal.Value()
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(value).To(gomega.BeAssignableToTypeOf(""))
+
+	scanned := PriceProposal{}
+	g.Expect(scanned.Scan([]byte(value.(string)))).To(gomega.Succeed())
+	g.Expect(scanned).To(gomega.Equal(proposal))
+
+	g.Expect(scanned.Scan(42)).ToNot(gomega.Succeed())
+}
@@ -0,0 +1,275 @@
+package product
+
+import (
+	"errors"
+	"fmt"
+	"kg/procurement/internal/common/spreadsheet"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	PriceImportActionCreate = "create"
+	PriceImportActionUpdate = "update"
+
+	// maxPriceImportRows keeps an import within a reasonable statement size
+	maxPriceImportRows = 5000
+)
+
+var (
+	ErrEmptyPriceImport       = errors.New("import file has no prices")
+	ErrTooManyPriceImportRows = fmt.Errorf("import file exceeds %d prices", maxPriceImportRows)
+)
+
+// priceImportDerivedColumns are filled by the import itself and can't be set from the file
+var priceImportDerivedColumns = map[string]bool{
+	"currency_id":   true,
+	"currency_name": true,
+	"modified_date": true,
+	"modified_by":   true,
+	"deleted_at":    true,
+	"deleted_by":    true,
+}
+
+// priceImportColumns maps every importable column to the index of its Price field
+var priceImportColumns = func() map[string]int {
+	res := map[string]int{}
+	priceType := reflect.TypeOf(Price{})
+	for i := 0; i < priceType.NumField(); i++ {
+		column := priceType.Field(i).Tag.Get("db")
+		if column != "" && !priceImportDerivedColumns[column] {
+			res[column] = i
+		}
+	}
+	return res
+}()
+
+// PriceImportSpec describes an import, ConfirmAnomalies imports the rows flagged as
+// price anomalies when anomalies need confirmation
+type PriceImportSpec struct {
+	DryRun           bool   `json:"dry_run"`
+	ConfirmAnomalies bool   `json:"confirm_anomalies"`
+	ModifiedBy       string `json:"modified_by"`
+}
+
+type PriceImportRowResult struct {
+	Row     int    `json:"row"`
+	PriceID string `json:"price_id"`
+	Action  string `json:"action"`
+}
+
+// PriceImportResult describes what the import did, or would do on a dry run
+type PriceImportResult struct {
+	DryRun  bool                   `json:"dry_run"`
+	Created int                    `json:"created"`
+	Updated int                    `json:"updated"`
+	Rows    []PriceImportRowResult `json:"rows"`
+	// Anomalies are the confirmed price anomalies of the import
+	Anomalies []PriceAnomaly `json:"anomalies,omitempty"`
+}
+
+type PriceImportRowError struct {
+	Row     int    `json:"row"`
+	Message string `json:"message"`
+}
+
+// PriceImportError is returned when rows of the file are invalid, nothing is imported
+type PriceImportError struct {
+	Rows []PriceImportRowError `json:"rows"`
+}
+
+func (e *PriceImportError) Error() string {
+	messages := make([]string, 0, len(e.Rows))
+	for _, row := range e.Rows {
+		messages = append(messages, fmt.Sprintf("row %d: %s", row.Row, row.Message))
+	}
+	return "invalid import file: " + strings.Join(messages, "; ")
+}
+
+// priceImportKeys are the references of every row, looked up at once before validating rows
+type priceImportKeys struct {
+	PriceIDs         []string
+	ProductVendorIDs []string
+	VendorIDs        []string
+	UOMIDs           []string
+	CurrencyCodes    []string
+}
+
+type importCurrency struct {
+	ID   string `db:"id"`
+	Code string `db:"code"`
+	Name string `db:"name"`
+}
+
+// priceImportReferences holds the references of the import that exist
+type priceImportReferences struct {
+	Prices         map[string]Price
+	ProductVendors map[string]bool
+	Vendors        map[string]bool
+	UOMs           map[string]bool
+	Currencies     map[string]importCurrency
+}
+
+// validatePriceImportHeader rejects columns that don't map to a price field so a
+// typo in the header doesn't silently drop a column
+func validatePriceImportHeader(header []string) error {
+	var unknown []string
+	for _, column := range header {
+		if column == "" {
+			continue
+		}
+		if _, ok := priceImportColumns[column]; !ok {
+			unknown = append(unknown, column)
+		}
+	}
+	if len(unknown) > 0 {
+		return &PriceImportError{Rows: []PriceImportRowError{{
+			Row:     1,
+			Message: "unknown columns: " + strings.Join(unknown, ", "),
+		}}}
+	}
+	return nil
+}
+
+// collectPriceImportKeys gathers the distinct references of every row
+func collectPriceImportKeys(rows []spreadsheet.Row) priceImportKeys {
+	sets := map[string]map[string]bool{}
+	add := func(kind, value string) {
+		if value == "" {
+			return
+		}
+		if sets[kind] == nil {
+			sets[kind] = map[string]bool{}
+		}
+		sets[kind][value] = true
+	}
+
+	for _, row := range rows {
+		add("price", row.Values["id"])
+		add("product_vendor", row.Values["product_vendor_id"])
+		add("vendor", row.Values["vendor_id"])
+		add("uom", row.Values["price_uom_id"])
+		add("uom", row.Values["quantity_uom_id"])
+		add("currency", strings.ToUpper(row.Values["currency_code"]))
+	}
+
+	keys := func(kind string) []string {
+		res := []string{}
+		for value := range sets[kind] {
+			res = append(res, value)
+		}
+		sort.Strings(res)
+		return res
+	}
+
+	return priceImportKeys{
+		PriceIDs:         keys("price"),
+		ProductVendorIDs: keys("product_vendor"),
+		VendorIDs:        keys("vendor"),
+		UOMIDs:           keys("uom"),
+		CurrencyCodes:    keys("currency"),
+	}
+}
+
+// applyPriceImportRow sets the fields of the columns present in the row, empty
+// cells keep the current value so an update only needs the columns it changes
+func applyPriceImportRow(price *Price, values map[string]string) error {
+	target := reflect.ValueOf(price).Elem()
+	for column, value := range values {
+		index, ok := priceImportColumns[column]
+		if !ok || value == "" || column == "id" {
+			continue
+		}
+
+		field := target.Field(index)
+		switch field.Interface().(type) {
+		case string:
+			field.SetString(value)
+		case int:
+			parsed, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("invalid %s: %s", column, value)
+			}
+			field.SetInt(int64(parsed))
+		case float64:
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return fmt.Errorf("invalid %s: %s", column, value)
+			}
+			field.SetFloat(parsed)
+		case time.Time:
+			parsed, err := parseImportTime(value)
+			if err != nil {
+				return fmt.Errorf("invalid %s: %s", column, value)
+			}
+			field.Set(reflect.ValueOf(parsed))
+		case *time.Time:
+			parsed, err := parseImportTime(value)
+			if err != nil {
+				return fmt.Errorf("invalid %s: %s", column, value)
+			}
+			field.Set(reflect.ValueOf(&parsed))
+		}
+	}
+	price.CurrencyCode = strings.ToUpper(price.CurrencyCode)
+	return nil
+}
+
+func parseImportTime(value string) (time.Time, error) {
+	for _, layout := range []string{time.DateOnly, time.DateTime, time.RFC3339} {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed, nil
+		}
+	}
+	return time.Time{}, errors.New("invalid time")
+}
+
+// validateImportedPrice checks the required fields and references of a price once the row is applied
+func validateImportedPrice(price Price, refs *priceImportReferences) error {
+	switch {
+	case price.ProductVendorID == "":
+		return errors.New("product_vendor_id is required")
+	case !refs.ProductVendors[price.ProductVendorID]:
+		return fmt.Errorf("unknown product_vendor_id: %s", price.ProductVendorID)
+	case price.VendorID == "":
+		return errors.New("vendor_id is required")
+	case !refs.Vendors[price.VendorID]:
+		return fmt.Errorf("unknown vendor_id: %s", price.VendorID)
+	case price.Price <= 0:
+		return errors.New("price must be greater than zero")
+	case price.CurrencyCode == "":
+		return errors.New("currency_code is required")
+	case refs.Currencies[price.CurrencyCode].Code == "":
+		return fmt.Errorf("unknown currency_code: %s", price.CurrencyCode)
+	case price.PriceUOMID == "":
+		return errors.New("price_uom_id is required")
+	case !refs.UOMs[price.PriceUOMID]:
+		return fmt.Errorf("unknown price_uom_id: %s", price.PriceUOMID)
+	case price.QuantityUOMID != "" && !refs.UOMs[price.QuantityUOMID]:
+		return fmt.Errorf("unknown quantity_uom_id: %s", price.QuantityUOMID)
+	case price.QuantityMin < 0 || price.QuantityMax < 0:
+		return errors.New("quantities can't be negative")
+	case price.QuantityMax > 0 && price.QuantityMin > price.QuantityMax:
+		return errors.New("quantity_min is greater than quantity_max")
+	case price.ValidFrom.IsZero():
+		return errors.New("valid_from is required")
+	case price.ValidTo != nil && price.ValidTo.Before(price.ValidFrom):
+		return errors.New("valid_to is before valid_from")
+	}
+	return nil
+}
+
+// priceImportRecord renders a price as a price table row, a nil ValidTo is stored
+// as NULL so open validity windows stay open
+func priceImportRecord(price Price) map[string]interface{} {
+	res := map[string]interface{}{}
+	value := reflect.ValueOf(price)
+	priceType := value.Type()
+	for i := 0; i < priceType.NumField(); i++ {
+		res[priceType.Field(i).Tag.Get("db")] = value.Field(i).Interface()
+	}
+	return res
+}
@@ -0,0 +1,322 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"kg/procurement/cmd/config"
+	"kg/procurement/internal/common/spreadsheet"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+func TestProductService_ImportPrices(t *testing.T) {
+	t.Parallel()
+
+	var (
+		now       = time.Date(2024, time.December, 9, 8, 0, 0, 0, time.UTC)
+		validFrom = time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+		existing  = Price{
+			ID:              "P1",
+			ProductVendorID: "PV1",
+			VendorID:        "V1",
+			Price:           100,
+			CurrencyCode:    "IDR",
+			PriceUOMID:      "U1",
+			ValidFrom:       validFrom,
+			ReferenceNumber: "REF-1",
+		}
+		refs = &priceImportReferences{
+			Prices:         map[string]Price{"P1": existing},
+			ProductVendors: map[string]bool{"PV1": true, "PV2": true},
+			Vendors:        map[string]bool{"V1": true},
+			UOMs:           map[string]bool{"U1": true},
+			Currencies: map[string]importCurrency{
+				"IDR": {ID: "C1", Code: "IDR", Name: "Rupiah"},
+				"USD": {ID: "C2", Code: "USD", Name: "US Dollar"},
+			},
+		}
+		validFile = "id,product_vendor_id,vendor_id,price,currency_code,price_uom_id,valid_from,quantity_min\n" +
+			"P1,,,120,,,,\n" +
+			",PV2,V1,9.5,usd,U1,2024-04-01,10\n"
+	)
+
+	setup := func(t *testing.T) (*gomega.GomegaWithT, *MockproductDBAccessor, *ProductService) {
+		mockCtrl := gomock.NewController(t)
+		mockProductAccessor := NewMockproductDBAccessor(mockCtrl)
+		mockClock := clock.NewMock()
+		mockClock.Set(now)
+
+		svc := &ProductService{
+			productDBAccessor: mockProductAccessor,
+			clock:             mockClock,
+		}
+		expectUnitOfWork(mockProductAccessor)
+		return gomega.NewWithT(t), mockProductAccessor, svc
+	}
+	// the prices of validFile are checked against the live prices of PV1 and PV2
+	expectOverlapCheck := func(ctx context.Context, m *MockproductDBAccessor) {
+		gomock.InOrder(
+			m.EXPECT().lockProductVendor(ctx, "PV1").Return(nil),
+			m.EXPECT().getPricesByPVID(ctx, "PV1").Return([]Price{existing}, nil),
+			m.EXPECT().lockProductVendor(ctx, "PV2").Return(nil),
+			m.EXPECT().getPricesByPVID(ctx, "PV2").Return(nil, nil),
+		)
+	}
+
+	t.Run("updates and creates prices", func(t *testing.T) {
+		g, mockProductAccessor, svc := setup(t)
+		ctx := context.Background()
+
+		mockProductAccessor.EXPECT().getPriceImportReferences(ctx, priceImportKeys{
+			PriceIDs:         []string{"P1"},
+			ProductVendorIDs: []string{"PV2"},
+			VendorIDs:        []string{"V1"},
+			UOMIDs:           []string{"U1"},
+			CurrencyCodes:    []string{"USD"},
+		}).Return(refs, nil)
+		expectOverlapCheck(ctx, mockProductAccessor)
+		mockProductAccessor.EXPECT().ImportPrices(ctx, gomock.Any(), PriceChange{ChangedBy: "admin", Reason: "import"}).
+			DoAndReturn(func(_ context.Context, prices []Price, _ PriceChange) error {
+				g.Expect(prices).To(gomega.HaveLen(2))
+
+				// only the columns of the row are overwritten
+				updated := existing
+				updated.Price = 120
+				updated.CurrencyID = "C1"
+				updated.CurrencyName = "Rupiah"
+				updated.ModifiedDate = now
+				updated.ModifiedBy = "admin"
+				g.Expect(prices[0]).To(gomega.Equal(updated))
+
+				g.Expect(prices[1].ID).To(gomega.HaveLen(15))
+				g.Expect(prices[1].ProductVendorID).To(gomega.Equal("PV2"))
+				g.Expect(prices[1].Price).To(gomega.Equal(9.5))
+				g.Expect(prices[1].CurrencyCode).To(gomega.Equal("USD"))
+				g.Expect(prices[1].CurrencyID).To(gomega.Equal("C2"))
+				g.Expect(prices[1].QuantityMin).To(gomega.Equal(10))
+				g.Expect(prices[1].ValidFrom).To(gomega.Equal(time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC)))
+				return nil
+			})
+
+		res, err := svc.ImportPrices(ctx, strings.NewReader(validFile), spreadsheet.FormatCSV, PriceImportSpec{ModifiedBy: "admin"})
+
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(res.DryRun).To(gomega.BeFalse())
+		g.Expect(res.Created).To(gomega.Equal(1))
+		g.Expect(res.Updated).To(gomega.Equal(1))
+		g.Expect(res.Rows[0]).To(gomega.Equal(PriceImportRowResult{Row: 2, PriceID: "P1", Action: PriceImportActionUpdate}))
+		g.Expect(res.Rows[1].Action).To(gomega.Equal(PriceImportActionCreate))
+	})
+
+	t.Run("dry run validates without writing", func(t *testing.T) {
+		g, mockProductAccessor, svc := setup(t)
+		ctx := context.Background()
+
+		mockProductAccessor.EXPECT().getPriceImportReferences(ctx, gomock.Any()).Return(refs, nil)
+		expectOverlapCheck(ctx, mockProductAccessor)
+
+		res, err := svc.ImportPrices(ctx, strings.NewReader(validFile), spreadsheet.FormatCSV, PriceImportSpec{DryRun: true})
+
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(res.DryRun).To(gomega.BeTrue())
+		g.Expect(res.Created).To(gomega.Equal(1))
+		g.Expect(res.Updated).To(gomega.Equal(1))
+	})
+
+	t.Run("reports every invalid row and imports nothing", func(t *testing.T) {
+		g, mockProductAccessor, svc := setup(t)
+		ctx := context.Background()
+
+		file := "id,product_vendor_id,vendor_id,price,currency_code,price_uom_id,valid_from,quantity_min,quantity_max\n" +
+			"P9,,,120,,,,,\n" +
+			",PV3,V1,10,IDR,U1,2024-04-01,,\n" +
+			",PV1,V1,abc,IDR,U1,2024-04-01,,\n" +
+			",PV1,V1,10,JPY,U1,2024-04-01,,\n" +
+			",PV1,V1,10,IDR,U2,2024-04-01,,\n" +
+			",PV1,V1,10,IDR,U1,,,\n" +
+			",PV1,V1,10,IDR,U1,2024-04-01,20,10\n" +
+			"P1,,,130,,,,,\n" +
+			"P1,,,140,,,,,\n"
+
+		mockProductAccessor.EXPECT().getPriceImportReferences(ctx, gomock.Any()).Return(refs, nil)
+
+		res, err := svc.ImportPrices(ctx, strings.NewReader(file), spreadsheet.FormatCSV, PriceImportSpec{})
+		g.Expect(res).To(gomega.BeNil())
+
+		var importErr *PriceImportError
+		g.Expect(errors.As(err, &importErr)).To(gomega.BeTrue())
+		g.Expect(importErr.Rows).To(gomega.Equal([]PriceImportRowError{
+			{Row: 2, Message: "unknown price id: P9"},
+			{Row: 3, Message: "unknown product_vendor_id: PV3"},
+			{Row: 4, Message: "invalid price: abc"},
+			{Row: 5, Message: "unknown currency_code: JPY"},
+			{Row: 6, Message: "unknown price_uom_id: U2"},
+			{Row: 7, Message: "valid_from is required"},
+			{Row: 8, Message: "quantity_min is greater than quantity_max"},
+			{Row: 10, Message: "price P1 is already updated on row 9"},
+		}))
+	})
+
+	t.Run("reports a soft deleted price as unknown", func(t *testing.T) {
+		g, mockProductAccessor, svc := setup(t)
+		ctx := context.Background()
+
+		// the lookup leaves the soft deleted P2 out, the row can't update it
+		mockProductAccessor.EXPECT().getPriceImportReferences(ctx, priceImportKeys{
+			PriceIDs:         []string{"P2"},
+			ProductVendorIDs: []string{},
+			VendorIDs:        []string{},
+			UOMIDs:           []string{},
+			CurrencyCodes:    []string{},
+		}).Return(&priceImportReferences{
+			Prices:         map[string]Price{},
+			ProductVendors: map[string]bool{},
+			Vendors:        map[string]bool{},
+			UOMs:           map[string]bool{},
+			Currencies:     map[string]importCurrency{},
+		}, nil)
+
+		res, err := svc.ImportPrices(ctx, strings.NewReader("id,price\nP2,120\n"), spreadsheet.FormatCSV, PriceImportSpec{})
+		g.Expect(res).To(gomega.BeNil())
+
+		var importErr *PriceImportError
+		g.Expect(errors.As(err, &importErr)).To(gomega.BeTrue())
+		g.Expect(importErr.Rows).To(gomega.Equal([]PriceImportRowError{{Row: 2, Message: "unknown price id: P2"}}))
+	})
+
+	t.Run("reports rows overlapping a live price or an earlier row", func(t *testing.T) {
+		g, mockProductAccessor, svc := setup(t)
+		ctx := context.Background()
+
+		live := Price{ID: "P2", ProductVendorID: "PV2", VendorID: "V1", QuantityMin: 1, QuantityMax: 9, ValidFrom: validFrom}
+		file := "product_vendor_id,vendor_id,price,currency_code,price_uom_id,valid_from,quantity_min,quantity_max\n" +
+			"PV2,V1,9.5,usd,U1,2024-04-01,5,20\n" +
+			"PV1,V1,9.5,usd,U1,2024-04-01,10,20\n" +
+			"PV1,V1,9.5,usd,U1,2024-04-01,15,30\n"
+
+		mockProductAccessor.EXPECT().getPriceImportReferences(ctx, gomock.Any()).Return(refs, nil)
+		gomock.InOrder(
+			mockProductAccessor.EXPECT().lockProductVendor(ctx, "PV1").Return(nil),
+			mockProductAccessor.EXPECT().getPricesByPVID(ctx, "PV1").Return(nil, nil),
+			mockProductAccessor.EXPECT().lockProductVendor(ctx, "PV2").Return(nil),
+			mockProductAccessor.EXPECT().getPricesByPVID(ctx, "PV2").Return([]Price{live}, nil),
+		)
+
+		res, err := svc.ImportPrices(ctx, strings.NewReader(file), spreadsheet.FormatCSV, PriceImportSpec{})
+		g.Expect(res).To(gomega.BeNil())
+
+		var importErr *PriceImportError
+		g.Expect(errors.As(err, &importErr)).To(gomega.BeTrue())
+		g.Expect(importErr.Rows).To(gomega.Equal([]PriceImportRowError{
+			{Row: 2, Message: ErrOverlappingPrice.Error() + ": P2"},
+			{Row: 4, Message: ErrOverlappingPrice.Error() + ": row 3"},
+		}))
+	})
+
+	t.Run("rejects unknown columns", func(t *testing.T) {
+		g, _, svc := setup(t)
+
+		res, err := svc.ImportPrices(context.Background(), strings.NewReader("product_vendor_id,prize\nPV1,10\n"), spreadsheet.FormatCSV, PriceImportSpec{})
+
+		var importErr *PriceImportError
+		g.Expect(errors.As(err, &importErr)).To(gomega.BeTrue())
+		g.Expect(importErr.Rows).To(gomega.Equal([]PriceImportRowError{{Row: 1, Message: "unknown columns: prize"}}))
+		g.Expect(res).To(gomega.BeNil())
+	})
+
+	t.Run("rejects a file without prices", func(t *testing.T) {
+		g, _, svc := setup(t)
+
+		res, err := svc.ImportPrices(context.Background(), strings.NewReader("product_vendor_id,price\n"), spreadsheet.FormatCSV, PriceImportSpec{})
+
+		g.Expect(err).To(gomega.MatchError(ErrEmptyPriceImport))
+		g.Expect(res).To(gomega.BeNil())
+	})
+
+	t.Run("rejects rows flagged as price anomalies", func(t *testing.T) {
+		g, mockProductAccessor, svc := setup(t)
+		ctx := context.Background()
+
+		svc.anomalyCfg = config.PriceAnomalyConfig{Mode: PriceAnomalyModeConfirm, MaxHistoryRatio: 5}
+		quoted := existing
+		quoted.CurrencyID = "C1"
+		quotedRefs := *refs
+		quotedRefs.Prices = map[string]Price{"P1": quoted}
+		mockProductAccessor.EXPECT().getPriceImportReferences(ctx, gomock.Any()).Return(&quotedRefs, nil)
+
+		res, err := svc.ImportPrices(ctx, strings.NewReader("id,price\nP1,1000\n"), spreadsheet.FormatCSV, PriceImportSpec{})
+		g.Expect(res).To(gomega.BeNil())
+
+		var importErr *PriceImportError
+		g.Expect(errors.As(err, &importErr)).To(gomega.BeTrue())
+		g.Expect(importErr.Rows).To(gomega.HaveLen(1))
+		g.Expect(importErr.Rows[0].Row).To(gomega.Equal(2))
+		g.Expect(importErr.Rows[0].Message).To(gomega.ContainSubstring("10.0x away from its previous price 100"))
+		g.Expect(importErr.Rows[0].Message).To(gomega.ContainSubstring("confirm_anomalies"))
+	})
+
+	t.Run("imports and records confirmed price anomalies", func(t *testing.T) {
+		g, mockProductAccessor, svc := setup(t)
+		ctx := context.Background()
+
+		svc.anomalyCfg = config.PriceAnomalyConfig{Mode: PriceAnomalyModeConfirm, MaxHistoryRatio: 5}
+		quoted := existing
+		quoted.CurrencyID = "C1"
+		quotedRefs := *refs
+		quotedRefs.Prices = map[string]Price{"P1": quoted}
+		mockProductAccessor.EXPECT().getPriceImportReferences(ctx, gomock.Any()).Return(&quotedRefs, nil)
+		gomock.InOrder(
+			mockProductAccessor.EXPECT().lockProductVendor(ctx, "PV1").Return(nil),
+			mockProductAccessor.EXPECT().getPricesByPVID(ctx, "PV1").Return([]Price{quoted}, nil),
+			mockProductAccessor.EXPECT().ImportPrices(ctx, gomock.Any(), gomock.Any()).Return(nil),
+			mockProductAccessor.EXPECT().WritePriceAnomalies(ctx, gomock.Any()).
+				DoAndReturn(func(_ context.Context, anomalies []PriceAnomaly) error {
+					g.Expect(anomalies).To(gomega.HaveLen(1))
+					g.Expect(anomalies[0].PriceID).To(gomega.Equal("P1"))
+					g.Expect(anomalies[0].Source).To(gomega.Equal(PriceAnomalySourceImport))
+					g.Expect(anomalies[0].Status).To(gomega.Equal(PriceAnomalyStatusConfirmed.String()))
+					g.Expect(anomalies[0].RequestedBy).To(gomega.Equal("admin"))
+					return nil
+				}),
+		)
+
+		res, err := svc.ImportPrices(ctx, strings.NewReader("id,price\nP1,1000\n"), spreadsheet.FormatCSV, PriceImportSpec{
+			ConfirmAnomalies: true,
+			ModifiedBy:       "admin",
+		})
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(res.Updated).To(gomega.Equal(1))
+		g.Expect(res.Anomalies).To(gomega.HaveLen(1))
+	})
+
+	t.Run("returns error on accessor failure", func(t *testing.T) {
+		g, mockProductAccessor, svc := setup(t)
+		ctx := context.Background()
+
+		mockProductAccessor.EXPECT().getPriceImportReferences(ctx, gomock.Any()).Return(refs, nil)
+		expectOverlapCheck(ctx, mockProductAccessor)
+		mockProductAccessor.EXPECT().ImportPrices(ctx, gomock.Any(), gomock.Any()).Return(errors.New("error"))
+
+		res, err := svc.ImportPrices(ctx, strings.NewReader(validFile), spreadsheet.FormatCSV, PriceImportSpec{})
+
+		g.Expect(err).ToNot(gomega.BeNil())
+		g.Expect(res).To(gomega.BeNil())
+	})
+}
+
+func Test_priceImportRecord(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	validFrom := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	record := priceImportRecord(Price{ID: "P1", Price: 10, ValidFrom: validFrom})
+
+	g.Expect(record["id"]).To(gomega.Equal("P1"))
+	g.Expect(record["valid_from"]).To(gomega.Equal(validFrom))
+	g.Expect(record["valid_to"]).To(gomega.BeNil())
+	g.Expect(record["reference_date"]).To(gomega.BeNil())
+}
@@ -0,0 +1,96 @@
+package product
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+var ErrNoApplicablePrice = errors.New("no applicable price")
+
+// PriceContext describes the purchase a price is resolved for. Empty fields
+// don't filter, a zero Date means now and a zero Quantity skips quantity tiers
+type PriceContext struct {
+	Quantity        int       `json:"quantity"`
+	AreaGroupID     string    `json:"area_group_id"`
+	PurchasingOrgID string    `json:"purchasing_org_id"`
+	Date            time.Time `json:"date"`
+}
+
+// priceCandidate is an applicable price along with how specifically it matches the context
+type priceCandidate struct {
+	price       Price
+	specificity int
+}
+
+// resolvePrice picks the price applying to the context out of every price of a product vendor.
+//
+// A price applies when the date is within its validity window, the quantity is within its
+// tier and its area group and purchasing org either match the context or are left empty.
+// Among applicable prices the most specific wins: exact area group and purchasing org
+// matches first, then the highest quantity break when a quantity is given, then the lowest
+// invocation order and finally the most recent validity start.
+func resolvePrice(prices []Price, pc PriceContext) (*Price, error) {
+	var candidates []priceCandidate
+	for _, price := range prices {
+		if !price.ValidFrom.IsZero() && price.ValidFrom.After(pc.Date) {
+			continue
+		}
+		if price.ValidTo != nil && price.ValidTo.Before(pc.Date) {
+			continue
+		}
+		if pc.Quantity > 0 {
+			if pc.Quantity < price.QuantityMin {
+				continue
+			}
+			if price.QuantityMax > 0 && pc.Quantity > price.QuantityMax {
+				continue
+			}
+		}
+
+		specificity := 0
+		if pc.AreaGroupID != "" {
+			switch price.AreaGroupID {
+			case pc.AreaGroupID:
+				specificity += 2
+			case "":
+			default:
+				continue
+			}
+		}
+		if pc.PurchasingOrgID != "" {
+			switch price.PurchasingOrgID {
+			case pc.PurchasingOrgID:
+				specificity++
+			case "":
+			default:
+				continue
+			}
+		}
+
+		candidates = append(candidates, priceCandidate{price: price, specificity: specificity})
+	}
+
+	if len(candidates) == 0 {
+		return nil, ErrNoApplicablePrice
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.specificity != b.specificity {
+			return a.specificity > b.specificity
+		}
+		if pc.Quantity > 0 && a.price.QuantityMin != b.price.QuantityMin {
+			return a.price.QuantityMin > b.price.QuantityMin
+		}
+		if a.price.InvocationOrder != b.price.InvocationOrder {
+			return a.price.InvocationOrder < b.price.InvocationOrder
+		}
+		if !a.price.ValidFrom.Equal(b.price.ValidFrom) {
+			return a.price.ValidFrom.After(b.price.ValidFrom)
+		}
+		return a.price.ID < b.price.ID
+	})
+
+	return &candidates[0].price, nil
+}
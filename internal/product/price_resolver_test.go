@@ -0,0 +1,127 @@
+package product
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+)
+
+func Test_resolvePrice(t *testing.T) {
+	t.Parallel()
+
+	var (
+		now       = time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+		yesterday = now.AddDate(0, 0, -1)
+		nextMonth = now.AddDate(0, 1, 0)
+	)
+
+	tests := []struct {
+		name    string
+		prices  []Price
+		pc      PriceContext
+		want    string
+		wantErr error
+	}{
+		{
+			name: "skips prices outside their validity window",
+			prices: []Price{
+				{ID: "expired", ValidFrom: now.AddDate(-1, 0, 0), ValidTo: &yesterday},
+				{ID: "future", ValidFrom: now.AddDate(0, 0, 1)},
+				{ID: "current", ValidFrom: now.AddDate(0, -1, 0), ValidTo: &nextMonth},
+			},
+			pc:   PriceContext{Date: now},
+			want: "current",
+		},
+		{
+			name: "picks the highest applicable quantity break",
+			prices: []Price{
+				{ID: "base", QuantityMin: 1, QuantityMax: 9},
+				{ID: "bulk", QuantityMin: 10},
+				{ID: "wholesale", QuantityMin: 100},
+			},
+			pc:   PriceContext{Quantity: 50, Date: now},
+			want: "bulk",
+		},
+		{
+			name: "ignores quantity tiers without a quantity",
+			prices: []Price{
+				{ID: "base", QuantityMin: 1, InvocationOrder: 1},
+				{ID: "bulk", QuantityMin: 10, InvocationOrder: 2},
+			},
+			pc:   PriceContext{Date: now},
+			want: "base",
+		},
+		{
+			name: "prefers the matching area group over a generic price",
+			prices: []Price{
+				{ID: "generic"},
+				{ID: "area", AreaGroupID: "AG1"},
+				{ID: "other area", AreaGroupID: "AG2"},
+			},
+			pc:   PriceContext{AreaGroupID: "AG1", Date: now},
+			want: "area",
+		},
+		{
+			name: "falls back to a generic price for another area group",
+			prices: []Price{
+				{ID: "generic"},
+				{ID: "area", AreaGroupID: "AG1"},
+			},
+			pc:   PriceContext{AreaGroupID: "AG2", Date: now},
+			want: "generic",
+		},
+		{
+			name: "prefers the matching purchasing org",
+			prices: []Price{
+				{ID: "generic"},
+				{ID: "org", PurchasingOrgID: "PO1"},
+			},
+			pc:   PriceContext{PurchasingOrgID: "PO1", Date: now},
+			want: "org",
+		},
+		{
+			name: "breaks ties with the lowest invocation order",
+			prices: []Price{
+				{ID: "second", InvocationOrder: 2},
+				{ID: "first", InvocationOrder: 1},
+			},
+			pc:   PriceContext{Date: now},
+			want: "first",
+		},
+		{
+			name: "breaks remaining ties with the most recent validity start",
+			prices: []Price{
+				{ID: "older", ValidFrom: now.AddDate(0, -2, 0)},
+				{ID: "newer", ValidFrom: now.AddDate(0, -1, 0)},
+			},
+			pc:   PriceContext{Date: now},
+			want: "newer",
+		},
+		{
+			name: "returns ErrNoApplicablePrice when nothing applies",
+			prices: []Price{
+				{ID: "area", AreaGroupID: "AG1"},
+				{ID: "bulk", QuantityMin: 10},
+			},
+			pc:      PriceContext{AreaGroupID: "AG2", Quantity: 5, Date: now},
+			wantErr: ErrNoApplicablePrice,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			res, err := resolvePrice(tt.prices, tt.pc)
+			if tt.wantErr != nil {
+				g.Expect(err).To(gomega.MatchError(tt.wantErr))
+				g.Expect(res).To(gomega.BeNil())
+				return
+			}
+
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(res.ID).To(gomega.Equal(tt.want))
+		})
+	}
+}
@@ -3,25 +3,80 @@ package product
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"kg/procurement/cmd/config"
 	"kg/procurement/internal/common/database"
+	"kg/procurement/internal/common/helper"
+	"kg/procurement/internal/common/spreadsheet"
+	"kg/procurement/internal/currency"
+	"kg/procurement/internal/uom"
 	"kg/procurement/cmd/utils"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/benbjohnson/clock"
 )
 
 type productDBAccessor interface {
+	runInTx(ctx context.Context, fn func(tx productDBAccessor) error) error
 	getProductCategoryByID(ctx context.Context, pvID string) (*ProductCategory, error)
 	GetProductVendorsByVendor(ctx context.Context, vendorID string, spec GetProductVendorByVendorSpec) (*AccessorGetProductVendorsPaginationData, error)
 	getProductByID(ctx context.Context, productID string) (*Product, error)
-	getPriceByPVID(ctx context.Context, pvID string) (*Price, error)
+	getPricesByPVID(ctx context.Context, pvID string) ([]Price, error)
+	lockProductVendor(ctx context.Context, pvID string) error
+	getDeletedPrice(ctx context.Context, id string) (*Price, error)
+	getProductVendorPageReferences(ctx context.Context, productVendors []ProductVendor) (*productVendorPageReferences, error)
 	getUOMByID(ctx context.Context, uomID string) (*UOM, error)
 	GetAllProductVendors(ctx context.Context, spec GetProductVendorsSpec) (*AccessorGetProductVendorsPaginationData, error)
-	UpdatePrice(ctx context.Context, price Price) (Price, error)
+	UpdatePrice(ctx context.Context, price Price, change PriceChange) (Price, error)
 	UpdateProduct(ctx context.Context, payload Product) (Product, error)
+	GetPriceHistory(ctx context.Context, productVendorID string, spec GetPriceHistorySpec) (*AccessorGetPriceHistoryPaginationData, error)
+	GetEffectivePrices(ctx context.Context, productVendorID string, at time.Time) ([]PriceHistory, error)
+	getPriceImportReferences(ctx context.Context, keys priceImportKeys) (*priceImportReferences, error)
+	ImportPrices(ctx context.Context, prices []Price, change PriceChange) error
+	getVendorNames(ctx context.Context, vendorIDs []string) (map[string]string, error)
+	getProductTypeByID(ctx context.Context, productTypeID string) (*ProductType, error)
+	GetProducts(ctx context.Context, spec GetProductsSpec) (*AccessorGetProductsPaginationData, error)
+	GetProductCategories(ctx context.Context, spec GetProductCategoriesSpec) (*AccessorGetProductCategoriesPaginationData, error)
+	GetProductTypes(ctx context.Context, spec GetCatalogueSpec) (*AccessorGetProductTypesPaginationData, error)
+	GetUOMs(ctx context.Context, spec GetCatalogueSpec) (*AccessorGetUOMsPaginationData, error)
+	CreateProduct(ctx context.Context, product Product) error
+	CreateProductCategory(ctx context.Context, category ProductCategory) error
+	CreateProductType(ctx context.Context, productType ProductType) error
+	CreateUOM(ctx context.Context, uom UOM) error
+	softDelete(ctx context.Context, table catalogueTable, id string, deletedBy string) error
+	restore(ctx context.Context, table catalogueTable, id string, restoredBy string) error
+	getCategoryTree(ctx context.Context, parentID string) ([]ProductCategory, error)
+	getCategorySubtree(ctx context.Context, categoryID string) ([]ProductCategory, error)
+	getCategoryAncestors(ctx context.Context, categoryID string) ([]ProductCategory, error)
+	moveProductCategory(ctx context.Context, categoryID string, parentID string, modifiedBy string) error
+	CreateProductVendor(ctx context.Context, productVendor ProductVendor) error
+	CreatePrice(ctx context.Context, price Price) error
+	getPeerPrices(ctx context.Context, productVendorIDs []string, at time.Time) (map[string][]Price, error)
+	WritePriceAnomalies(ctx context.Context, anomalies []PriceAnomaly) error
+	UpdatePriceAnomalyReview(ctx context.Context, anomaly PriceAnomaly) error
+	lockPriceAnomaly(ctx context.Context, id string) (*PriceAnomaly, error)
+	GetPriceAnomalies(ctx context.Context, spec GetPriceAnomaliesSpec) (*AccessorGetPriceAnomaliesPaginationData, error)
+}
+
+type currencyConverter interface {
+	GetBaseRate(ctx context.Context, code string, date time.Time) (*currency.ExchangeRate, error)
+}
+
+type uomConverter interface {
+	ConversionTables(ctx context.Context, productIDs []string) (map[string]*uom.ConversionTable, error)
 }
 
 type ProductService struct {
 	productDBAccessor
+	currencySvc currencyConverter
+	uomSvc      uomConverter
+	anomalyCfg  config.PriceAnomalyConfig
+	clock       clock.Clock
 }
 
 func (p *ProductService) GetProductVendorsByVendor(
@@ -34,7 +89,7 @@ func (p *ProductService) GetProductVendorsByVendor(
 		utils.Logger.Errorf(err.Error())
 		return nil, err
 	}
-	return p.buildProductVendorsResponse(ctx, productVendors)
+	return p.buildProductVendorsResponse(ctx, productVendors, spec.PriceContext)
 }
 
 func (p *ProductService) GetProductVendors(
@@ -45,41 +100,98 @@ func (p *ProductService) GetProductVendors(
 	if err != nil {
 		return nil, err
 	}
-	return p.buildProductVendorsResponse(ctx, productVendors)
+	return p.buildProductVendorsResponse(ctx, productVendors, spec.PriceContext)
 }
 
+// buildProductVendorsResponse populates the product vendors with the price applying
+// to the price context, product vendors without an applicable price have no price.
+// Prices are also converted to the base currency with the rate of the context date
+// and normalised to the price of a single unit of the product's UOM. The references
+// of the page are looked up at once rather than for every product vendor
 func (p *ProductService) buildProductVendorsResponse(
 	ctx context.Context,
 	productVendors *AccessorGetProductVendorsPaginationData,
+	priceContext PriceContext,
 ) (*GetProductVendorsResponse, error) {
+	if priceContext.Date.IsZero() {
+		priceContext.Date = p.clock.Now()
+	}
+
+	refs, err := p.productDBAccessor.getProductVendorPageReferences(ctx, productVendors.ProductVendors)
+	if err != nil {
+		utils.Logger.Errorf(err.Error())
+		return nil, err
+	}
+
 	res := GetProductVendorsResponse{}
+	rates := map[string]*currency.ExchangeRate{}
+	conversions := p.pageConversionTables(productVendors.ProductVendors)
 	for _, pv := range productVendors.ProductVendors {
-		product, err := p.getProductByID(ctx, pv.ProductID)
-		if err != nil {
+		product, ok := refs.Products[pv.ProductID]
+		if !ok {
+			err := fmt.Errorf("%w: %s", ErrProductNotFound, pv.ProductID)
 			utils.Logger.Errorf(err.Error())
 			return nil, err
 		}
 
-		category, err := p.getProductCategoryByID(ctx, product.ProductCategoryID)
-		if err != nil {
+		category, ok := refs.Categories[product.ProductCategoryID]
+		if !ok {
+			err := fmt.Errorf("%w: %s", ErrProductCategoryNotFound, product.ProductCategoryID)
 			utils.Logger.Errorf(err.Error())
 			return nil, err
 		}
 
-		price, err := p.getPriceByPVID(ctx, pv.ID)
-		if err != nil {
+		price, err := resolvePrice(refs.Prices[pv.ID], priceContext)
+		if err != nil && !errors.Is(err, ErrNoApplicablePrice) {
 			utils.Logger.Errorf(err.Error())
 			return nil, err
 		}
 
-		uom, err := p.getUOMByID(ctx, price.PriceUOMID)
-		if err != nil {
-			utils.Logger.Errorf(err.Error())
-			return nil, err
+		var priceUOM *UOM
+		if price != nil {
+			found, ok := refs.UOMs[price.PriceUOMID]
+			if !ok {
+				err := fmt.Errorf("%w: %s", ErrUOMNotFound, price.PriceUOMID)
+				utils.Logger.Errorf(err.Error())
+				return nil, err
+			}
+			priceUOM = &found
+		}
+
+		pvr := ToProductVendorResponse(&pv, &product, price, &category, priceUOM)
+
+		if price != nil && price.CurrencyCode != "" {
+			rate, ok := rates[price.CurrencyCode]
+			if !ok {
+				rate, err = p.currencySvc.GetBaseRate(ctx, price.CurrencyCode, priceContext.Date)
+				if err != nil && !errors.Is(err, currency.ErrRateNotFound) {
+					utils.Logger.Errorf(err.Error())
+					return nil, err
+				}
+				rates[price.CurrencyCode] = rate
+			}
+			// without a rate only the original amount is returned
+			if rate != nil {
+				pvr.Price.Converted = newConvertedPriceResponse(price, rate)
+			}
+		}
+
+		if price != nil && price.PriceUOMID != "" && product.UOMID != "" {
+			tables, err := conversions(ctx)
+			if err != nil {
+				utils.Logger.Errorf(err.Error())
+				return nil, err
+			}
+			// without a conversion to the product's UOM the price can't be normalised
+			factor, err := tables[pv.ProductID].Factor(price.PriceUOMID, product.UOMID)
+			if err == nil {
+				pvr.Price.UnitPrice = newUnitPriceResponse(price, product.UOMID, factor, pvr.Price.Converted)
+			} else if !errors.Is(err, uom.ErrNoConversion) {
+				utils.Logger.Errorf(err.Error())
+				return nil, err
+			}
 		}
 
-		pvr := ToProductVendorResponse(&pv, product, price, category, uom)
-    
 		res.ProductVendors = append(res.ProductVendors, *pvr)
 	}
 
@@ -87,28 +199,993 @@ func (p *ProductService) buildProductVendorsResponse(
 	return &res, nil
 }
 
+// pageConversionTables returns a loader of the UOM conversion tables of the products
+// of a page, they are read once on first use and only when a price is normalised
+func (p *ProductService) pageConversionTables(productVendors []ProductVendor) func(ctx context.Context) (map[string]*uom.ConversionTable, error) {
+	var tables map[string]*uom.ConversionTable
+	return func(ctx context.Context) (map[string]*uom.ConversionTable, error) {
+		if tables != nil {
+			return tables, nil
+		}
+
+		productIDs := []string{}
+		seen := map[string]bool{}
+		for _, pv := range productVendors {
+			if !seen[pv.ProductID] {
+				seen[pv.ProductID] = true
+				productIDs = append(productIDs, pv.ProductID)
+			}
+		}
+
+		var err error
+		tables, err = p.uomSvc.ConversionTables(ctx, productIDs)
+		return tables, err
+	}
+}
+
+// ExportProductVendors writes every product vendor matching spec to w with its price
+// applying to the price context, the listing is read and resolved in batches
+func (p *ProductService) ExportProductVendors(ctx context.Context, spec GetProductVendorsSpec, w spreadsheet.Writer) error {
+	if err := w.Write(productVendorExportHeader); err != nil {
+		return err
+	}
+
+	return database.WalkCursor(spec.PaginationSpec, func(page database.PaginationSpec) (database.PaginationMetadata, error) {
+		spec.PaginationSpec = page
+		res, err := p.GetProductVendors(ctx, spec)
+		if err != nil {
+			utils.Logger.Errorf(err.Error())
+			return database.PaginationMetadata{}, err
+		}
+
+		for _, pv := range res.ProductVendors {
+			if err := w.Write(productVendorExportRecord(pv)); err != nil {
+				return database.PaginationMetadata{}, err
+			}
+		}
+		return res.Metadata, nil
+	})
+}
+
+// ExportPriceComparison writes the prices of the product vendors matching spec grouped
+// by product and ranked from the cheapest unit price in the base currency. Ranking
+// needs every price of a product so the sheet is built once the listing is read,
+// it is meant for the filtered selections management compares
+func (p *ProductService) ExportPriceComparison(ctx context.Context, spec GetProductVendorsSpec, w spreadsheet.Writer) error {
+	var entries []priceComparisonEntry
+	err := database.WalkCursor(spec.PaginationSpec, func(page database.PaginationSpec) (database.PaginationMetadata, error) {
+		spec.PaginationSpec = page
+		res, err := p.GetProductVendors(ctx, spec)
+		if err != nil {
+			utils.Logger.Errorf(err.Error())
+			return database.PaginationMetadata{}, err
+		}
+
+		for _, pv := range res.ProductVendors {
+			if pv.Price != nil {
+				entries = append(entries, newPriceComparisonEntry(pv))
+			}
+		}
+		return res.Metadata, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	vendorIDs := []string{}
+	seen := map[string]bool{}
+	for _, entry := range entries {
+		if !seen[entry.VendorID] {
+			seen[entry.VendorID] = true
+			vendorIDs = append(vendorIDs, entry.VendorID)
+		}
+	}
+	vendorNames, err := p.productDBAccessor.getVendorNames(ctx, vendorIDs)
+	if err != nil {
+		utils.Logger.Errorf(err.Error())
+		return err
+	}
+
+	sortPriceComparison(entries)
+
+	if err := w.Write(priceComparisonHeader); err != nil {
+		return err
+	}
+	for _, record := range priceComparisonRecords(entries, vendorNames) {
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ResolvePrice returns the price of the product vendor applying to the price context,
+// ErrNoApplicablePrice is returned when none of its prices apply
+func (p *ProductService) ResolvePrice(ctx context.Context, productVendorID string, priceContext PriceContext) (*Price, error) {
+	if priceContext.Date.IsZero() {
+		priceContext.Date = p.clock.Now()
+	}
+
+	prices, err := p.productDBAccessor.getPricesByPVID(ctx, productVendorID)
+	if err != nil {
+		utils.Logger.Errorf(err.Error())
+		return nil, err
+	}
+
+	return resolvePrice(prices, priceContext)
+}
+
 func (p *ProductService) UpdateProduct(ctx context.Context, payload Product) (Product, error) {
 	return p.productDBAccessor.UpdateProduct(ctx, payload)
 }
 
-func (p *ProductService) UpdatePrice(ctx context.Context, price Price) (Price, error) {
-	return p.productDBAccessor.UpdatePrice(ctx, price)
+// UpdatePrice updates the price and records the previous value on its history. A price
+// straying beyond the anomaly thresholds is blocked, applied once the change is confirmed
+// or held for approval depending on the anomaly mode, a *PriceAnomalyError is returned
+// whenever it isn't applied. Like CreatePrice it is rejected when another price of the
+// product vendor would already apply to the same purchases
+func (p *ProductService) UpdatePrice(ctx context.Context, price Price, change PriceChange) (Price, error) {
+	var (
+		anomaly *PriceAnomaly
+		current Price
+	)
+	if priceAnomalyChecksEnabled(p.anomalyCfg) {
+		refs, err := p.productDBAccessor.getPriceImportReferences(ctx, priceImportKeys{PriceIDs: []string{price.ID}})
+		if err != nil {
+			utils.Logger.Errorf(err.Error())
+			return Price{}, err
+		}
+		var ok bool
+		if current, ok = refs.Prices[price.ID]; !ok {
+			return Price{}, ErrPriceNotFound
+		}
+		anomalies, err := p.findPriceAnomalies(ctx, []Price{price}, refs.Prices)
+		if err != nil {
+			return Price{}, err
+		}
+		anomaly = anomalies[0]
+	}
+
+	if anomaly != nil {
+		anomaly.Source = PriceAnomalySourceUpdate
+		anomaly.RequestedBy = change.ChangedBy
+		anomaly.Reason = change.Reason
+
+		switch p.anomalyCfg.Mode {
+		case PriceAnomalyModeConfirm:
+			if !change.Confirmed {
+				return Price{}, &PriceAnomalyError{Anomaly: *anomaly}
+			}
+			anomaly.Status = PriceAnomalyStatusConfirmed.String()
+		case PriceAnomalyModeApproval:
+			proposed := PriceProposal(price)
+			proposed.ModifiedDate = current.ModifiedDate
+			anomaly.Proposed = &proposed
+			anomaly.Status = PriceAnomalyStatusPending.String()
+		default:
+			anomaly.Status = PriceAnomalyStatusBlocked.String()
+		}
+
+		if anomaly.Status != PriceAnomalyStatusConfirmed.String() {
+			if err := p.productDBAccessor.WritePriceAnomalies(ctx, []PriceAnomaly{*anomaly}); err != nil {
+				return Price{}, err
+			}
+			return Price{}, &PriceAnomalyError{Anomaly: *anomaly}
+		}
+	}
+
+	id, err := helper.GenerateRandomID()
+	if err != nil {
+		utils.Logger.Error(err.Error())
+		return Price{}, err
+	}
+	change.HistoryID = id
+
+	// a confirmed anomaly is only recorded along with the price it let through
+	var updated Price
+	err = p.productDBAccessor.runInTx(ctx, func(tx productDBAccessor) error {
+		if err := tx.lockProductVendor(ctx, price.ProductVendorID); err != nil {
+			return err
+		}
+		if err := checkPriceOverlap(ctx, tx, price); err != nil {
+			return err
+		}
+
+		var err error
+		if updated, err = tx.UpdatePrice(ctx, price, change); err != nil {
+			return err
+		}
+		if anomaly != nil {
+			return tx.WritePriceAnomalies(ctx, []PriceAnomaly{*anomaly})
+		}
+		return nil
+	})
+	if err != nil {
+		return Price{}, err
+	}
+	return updated, nil
+}
+
+// findPriceAnomalies compares every price against its previous version, looked up in
+// previous by id, and against the prices other vendors currently offer for the same
+// product. The anomalies are keyed by the index of their price
+func (p *ProductService) findPriceAnomalies(ctx context.Context, prices []Price, previous map[string]Price) (map[int]*PriceAnomaly, error) {
+	res := map[int]*PriceAnomaly{}
+	if !priceAnomalyChecksEnabled(p.anomalyCfg) || len(prices) == 0 {
+		return res, nil
+	}
+
+	now := p.clock.Now()
+	peers := map[string][]Price{}
+	if p.anomalyCfg.MaxPeerRatio > 0 {
+		seen := map[string]bool{}
+		productVendorIDs := []string{}
+		for _, price := range prices {
+			if !seen[price.ProductVendorID] {
+				seen[price.ProductVendorID] = true
+				productVendorIDs = append(productVendorIDs, price.ProductVendorID)
+			}
+		}
+
+		var err error
+		peers, err = p.productDBAccessor.getPeerPrices(ctx, productVendorIDs, now)
+		if err != nil {
+			utils.Logger.Errorf(err.Error())
+			return nil, err
+		}
+	}
+
+	for i, price := range prices {
+		var previousPrice *Price
+		if existing, ok := previous[price.ID]; ok {
+			previousPrice = &existing
+		}
+		anomaly := detectPriceAnomaly(p.anomalyCfg, price, previousPrice, peers[price.ProductVendorID])
+		if anomaly == nil {
+			continue
+		}
+
+		id, err := helper.GenerateRandomID()
+		if err != nil {
+			utils.Logger.Errorf(err.Error())
+			return nil, err
+		}
+		anomaly.ID = id
+		anomaly.ModifiedDate = now
+		res[i] = anomaly
+	}
+	return res, nil
+}
+
+// GetPriceAnomalies lists the recorded price anomalies, latest first
+func (p *ProductService) GetPriceAnomalies(ctx context.Context, spec GetPriceAnomaliesSpec) (*AccessorGetPriceAnomaliesPaginationData, error) {
+	if spec.Status != "" {
+		if _, err := ParsePriceAnomalyStatus(spec.Status); err != nil {
+			return nil, err
+		}
+	}
+	return p.productDBAccessor.GetPriceAnomalies(ctx, spec)
+}
+
+// ReviewPriceAnomaly approves or rejects a price held for approval, the proposed price
+// is only applied when approved and its history entry credits the requester. The anomaly
+// is locked while it is reviewed and ErrPriceAnomalyStale is returned when the price
+// changed since the proposal was made, the proposal would otherwise overwrite the change
+func (p *ProductService) ReviewPriceAnomaly(ctx context.Context, id string, spec ReviewPriceAnomalySpec) (*PriceAnomaly, error) {
+	historyID, err := helper.GenerateRandomID()
+	if err != nil {
+		utils.Logger.Errorf(err.Error())
+		return nil, err
+	}
+
+	var reviewed *PriceAnomaly
+	err = p.productDBAccessor.runInTx(ctx, func(tx productDBAccessor) error {
+		anomaly, err := tx.lockPriceAnomaly(ctx, id)
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrPriceAnomalyNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		if anomaly.Status != PriceAnomalyStatusPending.String() {
+			return ErrPriceAnomalyNotPending
+		}
+		if anomaly.RequestedBy != "" && anomaly.RequestedBy == spec.ReviewedBy {
+			return ErrPriceAnomalySelfApproval
+		}
+
+		now := p.clock.Now()
+		anomaly.ReviewedBy = spec.ReviewedBy
+		anomaly.ReviewedDate = &now
+		anomaly.ModifiedDate = now
+		reviewed = anomaly
+
+		if !spec.Approved {
+			anomaly.Status = PriceAnomalyStatusRejected.String()
+			return tx.UpdatePriceAnomalyReview(ctx, *anomaly)
+		}
+
+		if anomaly.Proposed == nil {
+			return errPriceAnomalyWithoutProposal
+		}
+		proposed := Price(*anomaly.Proposed)
+		if err := tx.lockProductVendor(ctx, proposed.ProductVendorID); err != nil {
+			return err
+		}
+		refs, err := tx.getPriceImportReferences(ctx, priceImportKeys{PriceIDs: []string{proposed.ID}})
+		if err != nil {
+			utils.Logger.Errorf(err.Error())
+			return err
+		}
+		current, ok := refs.Prices[proposed.ID]
+		if !ok {
+			return ErrPriceNotFound
+		}
+		if !current.ModifiedDate.Equal(proposed.ModifiedDate) {
+			return ErrPriceAnomalyStale
+		}
+		if err := checkPriceOverlap(ctx, tx, proposed); err != nil {
+			return err
+		}
+
+		change := PriceChange{HistoryID: historyID, ChangedBy: anomaly.RequestedBy, Reason: anomaly.Reason}
+		if _, err := tx.UpdatePrice(ctx, proposed, change); err != nil {
+			return err
+		}
+		anomaly.Status = PriceAnomalyStatusApproved.String()
+		return tx.UpdatePriceAnomalyReview(ctx, *anomaly)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reviewed, nil
+}
+
+func (p *ProductService) GetPriceHistory(
+	ctx context.Context,
+	productVendorID string,
+	spec GetPriceHistorySpec,
+) (*AccessorGetPriceHistoryPaginationData, error) {
+	return p.productDBAccessor.GetPriceHistory(ctx, productVendorID, spec)
+}
+
+// GetEffectivePrices returns the prices of the product vendor in effect at the given date
+func (p *ProductService) GetEffectivePrices(ctx context.Context, productVendorID string, at time.Time) ([]PriceHistory, error) {
+	return p.productDBAccessor.GetEffectivePrices(ctx, productVendorID, at)
+}
+
+// GetProduct returns a product that isn't deleted
+func (p *ProductService) GetProduct(ctx context.Context, id string) (*Product, error) {
+	product, err := p.productDBAccessor.getProductByID(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrProductNotFound
+	}
+	return product, err
+}
+
+func (p *ProductService) GetProductCategory(ctx context.Context, id string) (*ProductCategory, error) {
+	category, err := p.productDBAccessor.getProductCategoryByID(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrProductCategoryNotFound
+	}
+	return category, err
+}
+
+func (p *ProductService) GetProductType(ctx context.Context, id string) (*ProductType, error) {
+	productType, err := p.productDBAccessor.getProductTypeByID(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrProductTypeNotFound
+	}
+	return productType, err
+}
+
+func (p *ProductService) GetUOM(ctx context.Context, id string) (*UOM, error) {
+	uom, err := p.productDBAccessor.getUOMByID(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrUOMNotFound
+	}
+	return uom, err
+}
+
+// CreateProduct creates a product whose category, type and UOM exist and aren't deleted
+func (p *ProductService) CreateProduct(ctx context.Context, spec PostProductSpec) (*Product, error) {
+	if _, err := p.GetProductCategory(ctx, spec.ProductCategoryID); err != nil {
+		return nil, invalidReference(err, ErrProductCategoryNotFound, spec.ProductCategoryID)
+	}
+	if _, err := p.GetProductType(ctx, spec.ProductTypeID); err != nil {
+		return nil, invalidReference(err, ErrProductTypeNotFound, spec.ProductTypeID)
+	}
+	if _, err := p.GetUOM(ctx, spec.UOMID); err != nil {
+		return nil, invalidReference(err, ErrUOMNotFound, spec.UOMID)
+	}
+
+	id, err := helper.GenerateRandomID()
+	if err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	product := Product{
+		ID:                ProductID(id),
+		ProductCategoryID: spec.ProductCategoryID,
+		UOMID:             spec.UOMID,
+		IncomeTaxID:       spec.IncomeTaxID,
+		ProductTypeID:     spec.ProductTypeID,
+		Name:              spec.Name,
+		Description:       spec.Description,
+		ModifiedDate:      p.clock.Now(),
+		ModifiedBy:        spec.ModifiedBy,
+	}
+	if err := p.productDBAccessor.CreateProduct(ctx, product); err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+// CreateProductCategory creates a category under an existing parent,
+// a category without a parent is a top level one
+func (p *ProductService) CreateProductCategory(ctx context.Context, spec PostProductCategorySpec) (*ProductCategory, error) {
+	parentID := spec.ParentID
+	if parentID == "" {
+		parentID = RootCategoryParentID
+	}
+	if parentID != RootCategoryParentID {
+		if _, err := p.GetProductCategory(ctx, parentID); err != nil {
+			return nil, invalidReference(err, ErrProductCategoryNotFound, parentID)
+		}
+	}
+
+	id, err := helper.GenerateRandomID()
+	if err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	category := ProductCategory{
+		ID:             id,
+		Name:           spec.Name,
+		Code:           spec.Code,
+		Description:    spec.Description,
+		ParentID:       parentID,
+		SpecialistBPID: spec.SpecialistBPID,
+		ModifiedDate:   p.clock.Now(),
+		ModifiedBy:     spec.ModifiedBy,
+	}
+	if err := p.productDBAccessor.CreateProductCategory(ctx, category); err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+func (p *ProductService) CreateProductType(ctx context.Context, spec PostProductTypeSpec) (*ProductType, error) {
+	id, err := helper.GenerateRandomID()
+	if err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	productType := ProductType{
+		ID:           id,
+		Name:         spec.Name,
+		Description:  spec.Description,
+		Goods:        spec.Goods,
+		Asset:        spec.Asset,
+		Stock:        spec.Stock,
+		ModifiedDate: p.clock.Now(),
+		ModifiedBy:   spec.ModifiedBy,
+	}
+	if err := p.productDBAccessor.CreateProductType(ctx, productType); err != nil {
+		return nil, err
+	}
+	return &productType, nil
+}
+
+func (p *ProductService) CreateUOM(ctx context.Context, spec PostUOMSpec) (*UOM, error) {
+	id, err := helper.GenerateRandomID()
+	if err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	uom := UOM{
+		ID:           id,
+		Name:         spec.Name,
+		Description:  spec.Description,
+		Dimension:    spec.Dimension,
+		SAPCode:      spec.SAPCode,
+		BaseFactor:   spec.BaseFactor,
+		ModifiedDate: p.clock.Now(),
+		ModifiedBy:   spec.ModifiedBy,
+	}
+	if err := p.productDBAccessor.CreateUOM(ctx, uom); err != nil {
+		return nil, err
+	}
+	return &uom, nil
+}
+
+// DeleteProduct soft deletes a product, ErrCatalogueInUse is returned while product vendors refer to it
+func (p *ProductService) DeleteProduct(ctx context.Context, id string, deletedBy string) error {
+	return p.productDBAccessor.softDelete(ctx, productTable, id, deletedBy)
+}
+
+// RestoreProduct restores a deleted product, ErrCatalogueDeletedReference is returned while its
+// category, type or UOM is deleted
+func (p *ProductService) RestoreProduct(ctx context.Context, id string, restoredBy string) error {
+	return p.productDBAccessor.restore(ctx, productTable, id, restoredBy)
+}
+
+// DeleteProductCategory soft deletes a category without subcategories or products
+func (p *ProductService) DeleteProductCategory(ctx context.Context, id string, deletedBy string) error {
+	return p.productDBAccessor.softDelete(ctx, productCategoryTable, id, deletedBy)
+}
+
+// DeleteProductType soft deletes a product type no product refers to
+func (p *ProductService) DeleteProductType(ctx context.Context, id string, deletedBy string) error {
+	return p.productDBAccessor.softDelete(ctx, productTypeTable, id, deletedBy)
+}
+
+// DeleteUOM soft deletes a UOM no product, product vendor or price refers to
+func (p *ProductService) DeleteUOM(ctx context.Context, id string, deletedBy string) error {
+	return p.productDBAccessor.softDelete(ctx, uomTable, id, deletedBy)
+}
+
+// GetCategoryTree returns every category nested under its parent
+func (p *ProductService) GetCategoryTree(ctx context.Context) ([]*ProductCategoryNode, error) {
+	categories, err := p.productDBAccessor.getCategoryTree(ctx, RootCategoryParentID)
+	if err != nil {
+		return nil, err
+	}
+	return buildCategoryTree(categories), nil
+}
+
+// GetCategorySubtree returns a category with all its descendants
+func (p *ProductService) GetCategorySubtree(ctx context.Context, id string) (*ProductCategoryNode, error) {
+	categories, err := p.productDBAccessor.getCategorySubtree(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(categories) == 0 {
+		return nil, ErrProductCategoryNotFound
+	}
+	return buildCategoryTree(categories)[0], nil
+}
+
+// GetCategoryAncestors returns the breadcrumbs of a category, from the top level
+// category down to the category itself
+func (p *ProductService) GetCategoryAncestors(ctx context.Context, id string) ([]ProductCategory, error) {
+	categories, err := p.productDBAccessor.getCategoryAncestors(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(categories) == 0 {
+		return nil, ErrProductCategoryNotFound
+	}
+	return categories, nil
+}
+
+// MoveProductCategory moves a category with its descendants under another parent,
+// a category without a parent becomes a top level one
+func (p *ProductService) MoveProductCategory(ctx context.Context, id string, spec MoveProductCategorySpec) (*ProductCategory, error) {
+	parentID := spec.ParentID
+	if parentID == "" {
+		parentID = RootCategoryParentID
+	}
+	if parentID == id {
+		return nil, ErrCategoryCycle
+	}
+
+	if err := p.productDBAccessor.moveProductCategory(ctx, id, parentID, spec.ModifiedBy); err != nil {
+		return nil, err
+	}
+	return p.GetProductCategory(ctx, id)
+}
+
+// invalidReference reports a referenced entry that is missing as an invalid reference
+func invalidReference(err error, notFound error, id string) error {
+	if errors.Is(err, notFound) {
+		return fmt.Errorf("%w: %s: %s", ErrInvalidReference, notFound.Error(), id)
+	}
+	return err
+}
+
+// ImportPrices creates or updates the prices of a CSV or XLSX price list. Rows with an id
+// update that price, other rows create one. Every row is validated before anything is
+// written and a *PriceImportError lists each invalid row, a dry run stops after validation.
+// Rows flagged as price anomalies are invalid unless anomalies need confirmation and the
+// import confirms them, confirmed anomalies are recorded once the prices are imported.
+// Rows overlapping a live price or an earlier row are invalid as well
+func (p *ProductService) ImportPrices(
+	ctx context.Context,
+	file io.Reader,
+	format string,
+	spec PriceImportSpec,
+) (*PriceImportResult, error) {
+	header, rows, err := spreadsheet.Read(file, format)
+	if err != nil {
+		return nil, err
+	}
+	if err := validatePriceImportHeader(header); err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, ErrEmptyPriceImport
+	}
+	if len(rows) > maxPriceImportRows {
+		return nil, ErrTooManyPriceImportRows
+	}
+
+	refs, err := p.productDBAccessor.getPriceImportReferences(ctx, collectPriceImportKeys(rows))
+	if err != nil {
+		utils.Logger.Errorf(err.Error())
+		return nil, err
+	}
+
+	var (
+		now       = p.clock.Now()
+		prices    = make([]Price, 0, len(rows))
+		rowErrors []PriceImportRowError
+		seen      = map[string]int{}
+		res       = PriceImportResult{DryRun: spec.DryRun}
+	)
+	for _, row := range rows {
+		price := Price{}
+		action := PriceImportActionCreate
+		if id := row.Values["id"]; id != "" {
+			existing, ok := refs.Prices[id]
+			if !ok {
+				rowErrors = append(rowErrors, PriceImportRowError{Row: row.Line, Message: "unknown price id: " + id})
+				continue
+			}
+			if line, ok := seen[id]; ok {
+				rowErrors = append(rowErrors, PriceImportRowError{Row: row.Line, Message: fmt.Sprintf("price %s is already updated on row %d", id, line)})
+				continue
+			}
+			seen[id] = row.Line
+			price = existing
+			action = PriceImportActionUpdate
+		} else {
+			price.ID, err = helper.GenerateRandomID()
+			if err != nil {
+				utils.Logger.Errorf(err.Error())
+				return nil, err
+			}
+		}
+
+		if err := applyPriceImportRow(&price, row.Values); err != nil {
+			rowErrors = append(rowErrors, PriceImportRowError{Row: row.Line, Message: err.Error()})
+			continue
+		}
+		if err := validateImportedPrice(price, refs); err != nil {
+			rowErrors = append(rowErrors, PriceImportRowError{Row: row.Line, Message: err.Error()})
+			continue
+		}
+
+		priceCurrency := refs.Currencies[price.CurrencyCode]
+		price.CurrencyID = priceCurrency.ID
+		price.CurrencyName = priceCurrency.Name
+		price.ModifiedDate = now
+		price.ModifiedBy = spec.ModifiedBy
+		prices = append(prices, price)
+
+		if action == PriceImportActionCreate {
+			res.Created++
+		} else {
+			res.Updated++
+		}
+		res.Rows = append(res.Rows, PriceImportRowResult{Row: row.Line, PriceID: price.ID, Action: action})
+	}
+
+	anomalies, err := p.findPriceAnomalies(ctx, prices, refs.Prices)
+	if err != nil {
+		return nil, err
+	}
+	var confirmed []PriceAnomaly
+	for i := range prices {
+		anomaly, ok := anomalies[i]
+		if !ok {
+			continue
+		}
+		if p.anomalyCfg.Mode != PriceAnomalyModeConfirm || !spec.ConfirmAnomalies {
+			rowErrors = append(rowErrors, PriceImportRowError{Row: res.Rows[i].Row, Message: priceImportAnomalyMessage(p.anomalyCfg.Mode, *anomaly)})
+			continue
+		}
+		anomaly.Source = PriceAnomalySourceImport
+		anomaly.Status = PriceAnomalyStatusConfirmed.String()
+		anomaly.RequestedBy = spec.ModifiedBy
+		anomaly.Reason = "import"
+		confirmed = append(confirmed, *anomaly)
+	}
+	res.Anomalies = confirmed
+
+	if len(rowErrors) > 0 {
+		sort.SliceStable(rowErrors, func(i, j int) bool {
+			return rowErrors[i].Row < rowErrors[j].Row
+		})
+		return nil, &PriceImportError{Rows: rowErrors}
+	}
+
+	// a dry run checks the overlaps under the same locks and writes nothing
+	change := PriceChange{ChangedBy: spec.ModifiedBy, Reason: "import"}
+	err = p.productDBAccessor.runInTx(ctx, func(tx productDBAccessor) error {
+		overlaps, err := findPriceImportOverlaps(ctx, tx, prices, res.Rows)
+		if err != nil {
+			return err
+		}
+		if len(overlaps) > 0 {
+			return &PriceImportError{Rows: overlaps}
+		}
+		if spec.DryRun {
+			return nil
+		}
+
+		if err := tx.ImportPrices(ctx, prices, change); err != nil {
+			return err
+		}
+		if len(confirmed) > 0 {
+			return tx.WritePriceAnomalies(ctx, confirmed)
+		}
+		return nil
+	})
+	var importErr *PriceImportError
+	if errors.As(err, &importErr) {
+		return nil, err
+	}
+	if err != nil {
+		utils.Logger.Errorf(err.Error())
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// findPriceImportOverlaps locks the product vendors of the imported prices and reports the
+// row of every price overlapping a live price of its product vendor or an earlier row. The
+// live version of an updated price is left out since the imported one replaces it
+func findPriceImportOverlaps(
+	ctx context.Context,
+	tx productDBAccessor,
+	prices []Price,
+	rows []PriceImportRowResult,
+) ([]PriceImportRowError, error) {
+	byProductVendor := map[string][]int{}
+	productVendorIDs := []string{}
+	for i, price := range prices {
+		if _, ok := byProductVendor[price.ProductVendorID]; !ok {
+			productVendorIDs = append(productVendorIDs, price.ProductVendorID)
+		}
+		byProductVendor[price.ProductVendorID] = append(byProductVendor[price.ProductVendorID], i)
+	}
+	// every import locks in the same order so two of them can't deadlock
+	sort.Strings(productVendorIDs)
+
+	var rowErrors []PriceImportRowError
+	for _, productVendorID := range productVendorIDs {
+		if err := tx.lockProductVendor(ctx, productVendorID); err != nil {
+			return nil, err
+		}
+		existing, err := tx.getPricesByPVID(ctx, productVendorID)
+		if err != nil {
+			utils.Logger.Errorf(err.Error())
+			return nil, err
+		}
+
+		indexes := byProductVendor[productVendorID]
+		imported := map[string]bool{}
+		for _, i := range indexes {
+			imported[prices[i].ID] = true
+		}
+
+	next:
+		for n, i := range indexes {
+			for _, other := range existing {
+				if !imported[other.ID] && pricesOverlap(prices[i], other) {
+					rowErrors = append(rowErrors, PriceImportRowError{
+						Row:     rows[i].Row,
+						Message: fmt.Sprintf("%s: %s", ErrOverlappingPrice.Error(), other.ID),
+					})
+					continue next
+				}
+			}
+			for _, j := range indexes[:n] {
+				if pricesOverlap(prices[i], prices[j]) {
+					rowErrors = append(rowErrors, PriceImportRowError{
+						Row:     rows[i].Row,
+						Message: fmt.Sprintf("%s: row %d", ErrOverlappingPrice.Error(), rows[j].Row),
+					})
+					continue next
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(rowErrors, func(i, j int) bool {
+		return rowErrors[i].Row < rowErrors[j].Row
+	})
+	return rowErrors, nil
+}
+
+// CreateProductVendor creates the offering of an existing product, the product's UOM
+// is used when none is given
+func (p *ProductService) CreateProductVendor(ctx context.Context, spec PostProductVendorSpec) (*ProductVendor, error) {
+	product, err := p.GetProduct(ctx, spec.ProductID)
+	if err != nil {
+		return nil, invalidReference(err, ErrProductNotFound, spec.ProductID)
+	}
+	uomID := spec.UOMID
+	if uomID == "" {
+		uomID = product.UOMID
+	} else if _, err := p.GetUOM(ctx, uomID); err != nil {
+		return nil, invalidReference(err, ErrUOMNotFound, uomID)
+	}
+
+	id, err := helper.GenerateRandomID()
+	if err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	productVendor := ProductVendor{
+		ID:                  id,
+		ProductID:           spec.ProductID,
+		Code:                spec.Code,
+		Name:                spec.Name,
+		IncomeTaxID:         spec.IncomeTaxID,
+		IncomeTaxName:       spec.IncomeTaxName,
+		IncomeTaxPercentage: spec.IncomeTaxPercentage,
+		Description:         spec.Description,
+		UOMID:               uomID,
+		SAPCode:             spec.SAPCode,
+		ModifiedDate:        p.clock.Now(),
+		ModifiedBy:          spec.ModifiedBy,
+	}
+	if err := p.productDBAccessor.CreateProductVendor(ctx, productVendor); err != nil {
+		return nil, err
+	}
+	return &productVendor, nil
 }
 
-func (p *ProductService) getProductByID(ctx context.Context, productID string) (*Product, error) {
-	product, err := p.productDBAccessor.getProductByID(ctx, productID)
+// CreatePrice creates a price of a product vendor. It is validated like an imported row
+// and rejected when a price of the product vendor would already apply to the same purchases
+func (p *ProductService) CreatePrice(ctx context.Context, spec PostPriceSpec) (*Price, error) {
+	price := Price{
+		PurchasingOrgID:   spec.PurchasingOrgID,
+		PurchasingOrgName: spec.PurchasingOrgName,
+		VendorID:          spec.VendorID,
+		ProductVendorID:   spec.ProductVendorID,
+		QuantityMin:       spec.QuantityMin,
+		QuantityMax:       spec.QuantityMax,
+		QuantityUOMID:     spec.QuantityUOMID,
+		LeadTimeMin:       spec.LeadTimeMin,
+		LeadTimeMax:       spec.LeadTimeMax,
+		CurrencyCode:      strings.ToUpper(spec.CurrencyCode),
+		Price:             spec.Price,
+		PriceQuantity:     spec.PriceQuantity,
+		PriceUOMID:        spec.PriceUOMID,
+		ValidFrom:         spec.ValidFrom,
+		ValidTo:           spec.ValidTo,
+		ValidPatternID:    spec.ValidPatternID,
+		AreaGroupID:       spec.AreaGroupID,
+		AreaGroupName:     spec.AreaGroupName,
+		ReferenceNumber:   spec.ReferenceNumber,
+		ReferenceDate:     spec.ReferenceDate,
+		DocumentTypeID:    spec.DocumentTypeID,
+		DocumentID:        spec.DocumentID,
+		ItemID:            spec.ItemID,
+		TermOfPaymentID:   spec.TermOfPaymentID,
+		InvocationOrder:   spec.InvocationOrder,
+		ModifiedDate:      p.clock.Now(),
+		ModifiedBy:        spec.ModifiedBy,
+	}
+
+	keys := priceImportKeys{
+		ProductVendorIDs: []string{price.ProductVendorID},
+		VendorIDs:        []string{price.VendorID},
+		UOMIDs:           []string{price.PriceUOMID},
+		CurrencyCodes:    []string{price.CurrencyCode},
+	}
+	if price.QuantityUOMID != "" {
+		keys.UOMIDs = append(keys.UOMIDs, price.QuantityUOMID)
+	}
+
+	var err error
+	price.ID, err = helper.GenerateRandomID()
 	if err != nil {
 		utils.Logger.Errorf(err.Error())
 		return nil, err
 	}
-	return product, nil
+
+	// the product vendor stays locked from the overlap check to the insert
+	err = p.productDBAccessor.runInTx(ctx, func(tx productDBAccessor) error {
+		if err := tx.lockProductVendor(ctx, price.ProductVendorID); err != nil {
+			return err
+		}
+
+		refs, err := tx.getPriceImportReferences(ctx, keys)
+		if err != nil {
+			utils.Logger.Errorf(err.Error())
+			return err
+		}
+		if err := validateImportedPrice(price, refs); err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidPrice, err.Error())
+		}
+		priceCurrency := refs.Currencies[price.CurrencyCode]
+		price.CurrencyID = priceCurrency.ID
+		price.CurrencyName = priceCurrency.Name
+
+		if err := checkPriceOverlap(ctx, tx, price); err != nil {
+			return err
+		}
+		return tx.CreatePrice(ctx, price)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &price, nil
+}
+
+// checkPriceOverlap returns ErrOverlappingPrice when a live price of the same product
+// vendor would apply to the same purchases as price, the product vendor is expected to
+// be locked so no other price of it is written meanwhile
+func checkPriceOverlap(ctx context.Context, tx productDBAccessor, price Price) error {
+	existing, err := tx.getPricesByPVID(ctx, price.ProductVendorID)
+	if err != nil {
+		utils.Logger.Errorf(err.Error())
+		return err
+	}
+	for _, other := range existing {
+		if other.ID != price.ID && pricesOverlap(price, other) {
+			return fmt.Errorf("%w: %s", ErrOverlappingPrice, other.ID)
+		}
+	}
+	return nil
+}
+
+// DeleteProductVendor soft deletes a product vendor once none of its prices remain
+func (p *ProductService) DeleteProductVendor(ctx context.Context, id string, deletedBy string) error {
+	return p.productDBAccessor.softDelete(ctx, productVendorTable, id, deletedBy)
+}
+
+// DeletePrice soft deletes a price, its history is kept
+func (p *ProductService) DeletePrice(ctx context.Context, id string, deletedBy string) error {
+	return p.productDBAccessor.softDelete(ctx, priceTable, id, deletedBy)
+}
+
+// RestoreProductVendor restores a deleted product vendor once its product and UOM are restored
+func (p *ProductService) RestoreProductVendor(ctx context.Context, id string, restoredBy string) error {
+	return p.productDBAccessor.restore(ctx, productVendorTable, id, restoredBy)
+}
+
+// RestorePrice restores a deleted price once its product vendor and vendor are restored,
+// a price overlapping one created since it was deleted stays deleted
+func (p *ProductService) RestorePrice(ctx context.Context, id string, restoredBy string) error {
+	return p.productDBAccessor.runInTx(ctx, func(tx productDBAccessor) error {
+		price, err := tx.getDeletedPrice(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := tx.lockProductVendor(ctx, price.ProductVendorID); err != nil {
+			return err
+		}
+		if err := checkPriceOverlap(ctx, tx, *price); err != nil {
+			return err
+		}
+		return tx.restore(ctx, priceTable, id, restoredBy)
+	})
 }
 
 func NewProductService(
 	conn database.DBConnector,
 	clock clock.Clock,
+	currencySvc currencyConverter,
+	uomSvc uomConverter,
+	anomalyCfg config.PriceAnomalyConfig,
 ) *ProductService {
 	return &ProductService{
 		productDBAccessor: newPostgresProductAccessor(conn, clock),
+		currencySvc:       currencySvc,
+		uomSvc:            uomSvc,
+		anomalyCfg:        anomalyCfg,
+		clock:             clock,
 	}
 }
@@ -11,7 +11,10 @@ package product
 
 import (
 	context "context"
+	currency "kg/procurement/internal/currency"
+	uom "kg/procurement/internal/uom"
 	reflect "reflect"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -39,6 +42,234 @@ func (m *MockproductDBAccessor) EXPECT() *MockproductDBAccessorMockRecorder {
 	return m.recorder
 }
 
+// CreatePrice mocks base method.
+func (m *MockproductDBAccessor) CreatePrice(ctx context.Context, price Price) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePrice", ctx, price)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePrice indicates an expected call of CreatePrice.
+func (mr *MockproductDBAccessorMockRecorder) CreatePrice(ctx, price any) *MockproductDBAccessorCreatePriceCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePrice", reflect.TypeOf((*MockproductDBAccessor)(nil).CreatePrice), ctx, price)
+	return &MockproductDBAccessorCreatePriceCall{Call: call}
+}
+
+// MockproductDBAccessorCreatePriceCall wrap *gomock.Call
+type MockproductDBAccessorCreatePriceCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockproductDBAccessorCreatePriceCall) Return(arg0 error) *MockproductDBAccessorCreatePriceCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockproductDBAccessorCreatePriceCall) Do(f func(context.Context, Price) error) *MockproductDBAccessorCreatePriceCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockproductDBAccessorCreatePriceCall) DoAndReturn(f func(context.Context, Price) error) *MockproductDBAccessorCreatePriceCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CreateProduct mocks base method.
+func (m *MockproductDBAccessor) CreateProduct(ctx context.Context, product Product) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProduct", ctx, product)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateProduct indicates an expected call of CreateProduct.
+func (mr *MockproductDBAccessorMockRecorder) CreateProduct(ctx, product any) *MockproductDBAccessorCreateProductCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProduct", reflect.TypeOf((*MockproductDBAccessor)(nil).CreateProduct), ctx, product)
+	return &MockproductDBAccessorCreateProductCall{Call: call}
+}
+
+// MockproductDBAccessorCreateProductCall wrap *gomock.Call
+type MockproductDBAccessorCreateProductCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockproductDBAccessorCreateProductCall) Return(arg0 error) *MockproductDBAccessorCreateProductCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockproductDBAccessorCreateProductCall) Do(f func(context.Context, Product) error) *MockproductDBAccessorCreateProductCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockproductDBAccessorCreateProductCall) DoAndReturn(f func(context.Context, Product) error) *MockproductDBAccessorCreateProductCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CreateProductCategory mocks base method.
+func (m *MockproductDBAccessor) CreateProductCategory(ctx context.Context, category ProductCategory) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProductCategory", ctx, category)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateProductCategory indicates an expected call of CreateProductCategory.
+func (mr *MockproductDBAccessorMockRecorder) CreateProductCategory(ctx, category any) *MockproductDBAccessorCreateProductCategoryCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProductCategory", reflect.TypeOf((*MockproductDBAccessor)(nil).CreateProductCategory), ctx, category)
+	return &MockproductDBAccessorCreateProductCategoryCall{Call: call}
+}
+
+// MockproductDBAccessorCreateProductCategoryCall wrap *gomock.Call
+type MockproductDBAccessorCreateProductCategoryCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockproductDBAccessorCreateProductCategoryCall) Return(arg0 error) *MockproductDBAccessorCreateProductCategoryCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockproductDBAccessorCreateProductCategoryCall) Do(f func(context.Context, ProductCategory) error) *MockproductDBAccessorCreateProductCategoryCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockproductDBAccessorCreateProductCategoryCall) DoAndReturn(f func(context.Context, ProductCategory) error) *MockproductDBAccessorCreateProductCategoryCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CreateProductType mocks base method.
+func (m *MockproductDBAccessor) CreateProductType(ctx context.Context, productType ProductType) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProductType", ctx, productType)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateProductType indicates an expected call of CreateProductType.
+func (mr *MockproductDBAccessorMockRecorder) CreateProductType(ctx, productType any) *MockproductDBAccessorCreateProductTypeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProductType", reflect.TypeOf((*MockproductDBAccessor)(nil).CreateProductType), ctx, productType)
+	return &MockproductDBAccessorCreateProductTypeCall{Call: call}
+}
+
+// MockproductDBAccessorCreateProductTypeCall wrap *gomock.Call
+type MockproductDBAccessorCreateProductTypeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockproductDBAccessorCreateProductTypeCall) Return(arg0 error) *MockproductDBAccessorCreateProductTypeCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockproductDBAccessorCreateProductTypeCall) Do(f func(context.Context, ProductType) error) *MockproductDBAccessorCreateProductTypeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockproductDBAccessorCreateProductTypeCall) DoAndReturn(f func(context.Context, ProductType) error) *MockproductDBAccessorCreateProductTypeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CreateProductVendor mocks base method.
+func (m *MockproductDBAccessor) CreateProductVendor(ctx context.Context, productVendor ProductVendor) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProductVendor", ctx, productVendor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateProductVendor indicates an expected call of CreateProductVendor.
+func (mr *MockproductDBAccessorMockRecorder) CreateProductVendor(ctx, productVendor any) *MockproductDBAccessorCreateProductVendorCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProductVendor", reflect.TypeOf((*MockproductDBAccessor)(nil).CreateProductVendor), ctx, productVendor)
+	return &MockproductDBAccessorCreateProductVendorCall{Call: call}
+}
+
+// MockproductDBAccessorCreateProductVendorCall wrap *gomock.Call
+type MockproductDBAccessorCreateProductVendorCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockproductDBAccessorCreateProductVendorCall) Return(arg0 error) *MockproductDBAccessorCreateProductVendorCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockproductDBAccessorCreateProductVendorCall) Do(f func(context.Context, ProductVendor) error) *MockproductDBAccessorCreateProductVendorCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockproductDBAccessorCreateProductVendorCall) DoAndReturn(f func(context.Context, ProductVendor) error) *MockproductDBAccessorCreateProductVendorCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CreateUOM mocks base method.
+func (m *MockproductDBAccessor) CreateUOM(ctx context.Context, uom UOM) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUOM", ctx, uom)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateUOM indicates an expected call of CreateUOM.
+func (mr *MockproductDBAccessorMockRecorder) CreateUOM(ctx, uom any) *MockproductDBAccessorCreateUOMCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUOM", reflect.TypeOf((*MockproductDBAccessor)(nil).CreateUOM), ctx, uom)
+	return &MockproductDBAccessorCreateUOMCall{Call: call}
+}
+
+// MockproductDBAccessorCreateUOMCall wrap *gomock.Call
+type MockproductDBAccessorCreateUOMCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockproductDBAccessorCreateUOMCall) Return(arg0 error) *MockproductDBAccessorCreateUOMCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockproductDBAccessorCreateUOMCall) Do(f func(context.Context, UOM) error) *MockproductDBAccessorCreateUOMCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockproductDBAccessorCreateUOMCall) DoAndReturn(f func(context.Context, UOM) error) *MockproductDBAccessorCreateUOMCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // GetAllProductVendors mocks base method.
 func (m *MockproductDBAccessor) GetAllProductVendors(ctx context.Context, spec GetProductVendorsSpec) (*AccessorGetProductVendorsPaginationData, error) {
 	m.ctrl.T.Helper()
@@ -78,275 +309,1366 @@ func (c *MockproductDBAccessorGetAllProductVendorsCall) DoAndReturn(f func(conte
 	return c
 }
 
-// GetProductVendorsByVendor mocks base method.
-func (m *MockproductDBAccessor) GetProductVendorsByVendor(ctx context.Context, vendorID string, spec GetProductVendorByVendorSpec) (*AccessorGetProductVendorsPaginationData, error) {
+// GetEffectivePrices mocks base method.
+func (m *MockproductDBAccessor) GetEffectivePrices(ctx context.Context, productVendorID string, at time.Time) ([]PriceHistory, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProductVendorsByVendor", ctx, vendorID, spec)
-	ret0, _ := ret[0].(*AccessorGetProductVendorsPaginationData)
+	ret := m.ctrl.Call(m, "GetEffectivePrices", ctx, productVendorID, at)
+	ret0, _ := ret[0].([]PriceHistory)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetProductVendorsByVendor indicates an expected call of GetProductVendorsByVendor.
-func (mr *MockproductDBAccessorMockRecorder) GetProductVendorsByVendor(ctx, vendorID, spec any) *MockproductDBAccessorGetProductVendorsByVendorCall {
+// GetEffectivePrices indicates an expected call of GetEffectivePrices.
+func (mr *MockproductDBAccessorMockRecorder) GetEffectivePrices(ctx, productVendorID, at any) *MockproductDBAccessorGetEffectivePricesCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductVendorsByVendor", reflect.TypeOf((*MockproductDBAccessor)(nil).GetProductVendorsByVendor), ctx, vendorID, spec)
-	return &MockproductDBAccessorGetProductVendorsByVendorCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEffectivePrices", reflect.TypeOf((*MockproductDBAccessor)(nil).GetEffectivePrices), ctx, productVendorID, at)
+	return &MockproductDBAccessorGetEffectivePricesCall{Call: call}
 }
 
-// MockproductDBAccessorGetProductVendorsByVendorCall wrap *gomock.Call
-type MockproductDBAccessorGetProductVendorsByVendorCall struct {
+// MockproductDBAccessorGetEffectivePricesCall wrap *gomock.Call
+type MockproductDBAccessorGetEffectivePricesCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *MockproductDBAccessorGetProductVendorsByVendorCall) Return(arg0 *AccessorGetProductVendorsPaginationData, arg1 error) *MockproductDBAccessorGetProductVendorsByVendorCall {
+func (c *MockproductDBAccessorGetEffectivePricesCall) Return(arg0 []PriceHistory, arg1 error) *MockproductDBAccessorGetEffectivePricesCall {
 	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *MockproductDBAccessorGetProductVendorsByVendorCall) Do(f func(context.Context, string, GetProductVendorByVendorSpec) (*AccessorGetProductVendorsPaginationData, error)) *MockproductDBAccessorGetProductVendorsByVendorCall {
+func (c *MockproductDBAccessorGetEffectivePricesCall) Do(f func(context.Context, string, time.Time) ([]PriceHistory, error)) *MockproductDBAccessorGetEffectivePricesCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *MockproductDBAccessorGetProductVendorsByVendorCall) DoAndReturn(f func(context.Context, string, GetProductVendorByVendorSpec) (*AccessorGetProductVendorsPaginationData, error)) *MockproductDBAccessorGetProductVendorsByVendorCall {
+func (c *MockproductDBAccessorGetEffectivePricesCall) DoAndReturn(f func(context.Context, string, time.Time) ([]PriceHistory, error)) *MockproductDBAccessorGetEffectivePricesCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// UpdatePrice mocks base method.
-func (m *MockproductDBAccessor) UpdatePrice(ctx context.Context, price Price) (Price, error) {
+// GetPriceAnomalies mocks base method.
+func (m *MockproductDBAccessor) GetPriceAnomalies(ctx context.Context, spec GetPriceAnomaliesSpec) (*AccessorGetPriceAnomaliesPaginationData, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdatePrice", ctx, price)
-	ret0, _ := ret[0].(Price)
+	ret := m.ctrl.Call(m, "GetPriceAnomalies", ctx, spec)
+	ret0, _ := ret[0].(*AccessorGetPriceAnomaliesPaginationData)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdatePrice indicates an expected call of UpdatePrice.
-func (mr *MockproductDBAccessorMockRecorder) UpdatePrice(ctx, price any) *MockproductDBAccessorUpdatePriceCall {
+// GetPriceAnomalies indicates an expected call of GetPriceAnomalies.
+func (mr *MockproductDBAccessorMockRecorder) GetPriceAnomalies(ctx, spec any) *MockproductDBAccessorGetPriceAnomaliesCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePrice", reflect.TypeOf((*MockproductDBAccessor)(nil).UpdatePrice), ctx, price)
-	return &MockproductDBAccessorUpdatePriceCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPriceAnomalies", reflect.TypeOf((*MockproductDBAccessor)(nil).GetPriceAnomalies), ctx, spec)
+	return &MockproductDBAccessorGetPriceAnomaliesCall{Call: call}
 }
 
-// MockproductDBAccessorUpdatePriceCall wrap *gomock.Call
-type MockproductDBAccessorUpdatePriceCall struct {
+// MockproductDBAccessorGetPriceAnomaliesCall wrap *gomock.Call
+type MockproductDBAccessorGetPriceAnomaliesCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *MockproductDBAccessorUpdatePriceCall) Return(arg0 Price, arg1 error) *MockproductDBAccessorUpdatePriceCall {
+func (c *MockproductDBAccessorGetPriceAnomaliesCall) Return(arg0 *AccessorGetPriceAnomaliesPaginationData, arg1 error) *MockproductDBAccessorGetPriceAnomaliesCall {
 	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *MockproductDBAccessorUpdatePriceCall) Do(f func(context.Context, Price) (Price, error)) *MockproductDBAccessorUpdatePriceCall {
+func (c *MockproductDBAccessorGetPriceAnomaliesCall) Do(f func(context.Context, GetPriceAnomaliesSpec) (*AccessorGetPriceAnomaliesPaginationData, error)) *MockproductDBAccessorGetPriceAnomaliesCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *MockproductDBAccessorUpdatePriceCall) DoAndReturn(f func(context.Context, Price) (Price, error)) *MockproductDBAccessorUpdatePriceCall {
+func (c *MockproductDBAccessorGetPriceAnomaliesCall) DoAndReturn(f func(context.Context, GetPriceAnomaliesSpec) (*AccessorGetPriceAnomaliesPaginationData, error)) *MockproductDBAccessorGetPriceAnomaliesCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// UpdateProduct mocks base method.
-func (m *MockproductDBAccessor) UpdateProduct(ctx context.Context, payload Product) (Product, error) {
+// GetPriceHistory mocks base method.
+func (m *MockproductDBAccessor) GetPriceHistory(ctx context.Context, productVendorID string, spec GetPriceHistorySpec) (*AccessorGetPriceHistoryPaginationData, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateProduct", ctx, payload)
-	ret0, _ := ret[0].(Product)
+	ret := m.ctrl.Call(m, "GetPriceHistory", ctx, productVendorID, spec)
+	ret0, _ := ret[0].(*AccessorGetPriceHistoryPaginationData)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdateProduct indicates an expected call of UpdateProduct.
-func (mr *MockproductDBAccessorMockRecorder) UpdateProduct(ctx, payload any) *MockproductDBAccessorUpdateProductCall {
+// GetPriceHistory indicates an expected call of GetPriceHistory.
+func (mr *MockproductDBAccessorMockRecorder) GetPriceHistory(ctx, productVendorID, spec any) *MockproductDBAccessorGetPriceHistoryCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProduct", reflect.TypeOf((*MockproductDBAccessor)(nil).UpdateProduct), ctx, payload)
-	return &MockproductDBAccessorUpdateProductCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPriceHistory", reflect.TypeOf((*MockproductDBAccessor)(nil).GetPriceHistory), ctx, productVendorID, spec)
+	return &MockproductDBAccessorGetPriceHistoryCall{Call: call}
 }
 
-// MockproductDBAccessorUpdateProductCall wrap *gomock.Call
-type MockproductDBAccessorUpdateProductCall struct {
+// MockproductDBAccessorGetPriceHistoryCall wrap *gomock.Call
+type MockproductDBAccessorGetPriceHistoryCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *MockproductDBAccessorUpdateProductCall) Return(arg0 Product, arg1 error) *MockproductDBAccessorUpdateProductCall {
+func (c *MockproductDBAccessorGetPriceHistoryCall) Return(arg0 *AccessorGetPriceHistoryPaginationData, arg1 error) *MockproductDBAccessorGetPriceHistoryCall {
 	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *MockproductDBAccessorUpdateProductCall) Do(f func(context.Context, Product) (Product, error)) *MockproductDBAccessorUpdateProductCall {
+func (c *MockproductDBAccessorGetPriceHistoryCall) Do(f func(context.Context, string, GetPriceHistorySpec) (*AccessorGetPriceHistoryPaginationData, error)) *MockproductDBAccessorGetPriceHistoryCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *MockproductDBAccessorUpdateProductCall) DoAndReturn(f func(context.Context, Product) (Product, error)) *MockproductDBAccessorUpdateProductCall {
+func (c *MockproductDBAccessorGetPriceHistoryCall) DoAndReturn(f func(context.Context, string, GetPriceHistorySpec) (*AccessorGetPriceHistoryPaginationData, error)) *MockproductDBAccessorGetPriceHistoryCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// getPriceByPVID mocks base method.
-func (m *MockproductDBAccessor) getPriceByPVID(ctx context.Context, pvID string) (*Price, error) {
+// GetProductCategories mocks base method.
+func (m *MockproductDBAccessor) GetProductCategories(ctx context.Context, spec GetProductCategoriesSpec) (*AccessorGetProductCategoriesPaginationData, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "getPriceByPVID", ctx, pvID)
-	ret0, _ := ret[0].(*Price)
+	ret := m.ctrl.Call(m, "GetProductCategories", ctx, spec)
+	ret0, _ := ret[0].(*AccessorGetProductCategoriesPaginationData)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// getPriceByPVID indicates an expected call of getPriceByPVID.
-func (mr *MockproductDBAccessorMockRecorder) getPriceByPVID(ctx, pvID any) *MockproductDBAccessorgetPriceByPVIDCall {
+// GetProductCategories indicates an expected call of GetProductCategories.
+func (mr *MockproductDBAccessorMockRecorder) GetProductCategories(ctx, spec any) *MockproductDBAccessorGetProductCategoriesCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getPriceByPVID", reflect.TypeOf((*MockproductDBAccessor)(nil).getPriceByPVID), ctx, pvID)
-	return &MockproductDBAccessorgetPriceByPVIDCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductCategories", reflect.TypeOf((*MockproductDBAccessor)(nil).GetProductCategories), ctx, spec)
+	return &MockproductDBAccessorGetProductCategoriesCall{Call: call}
 }
 
-// MockproductDBAccessorgetPriceByPVIDCall wrap *gomock.Call
-type MockproductDBAccessorgetPriceByPVIDCall struct {
+// MockproductDBAccessorGetProductCategoriesCall wrap *gomock.Call
+type MockproductDBAccessorGetProductCategoriesCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *MockproductDBAccessorgetPriceByPVIDCall) Return(arg0 *Price, arg1 error) *MockproductDBAccessorgetPriceByPVIDCall {
+func (c *MockproductDBAccessorGetProductCategoriesCall) Return(arg0 *AccessorGetProductCategoriesPaginationData, arg1 error) *MockproductDBAccessorGetProductCategoriesCall {
 	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *MockproductDBAccessorgetPriceByPVIDCall) Do(f func(context.Context, string) (*Price, error)) *MockproductDBAccessorgetPriceByPVIDCall {
+func (c *MockproductDBAccessorGetProductCategoriesCall) Do(f func(context.Context, GetProductCategoriesSpec) (*AccessorGetProductCategoriesPaginationData, error)) *MockproductDBAccessorGetProductCategoriesCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *MockproductDBAccessorgetPriceByPVIDCall) DoAndReturn(f func(context.Context, string) (*Price, error)) *MockproductDBAccessorgetPriceByPVIDCall {
+func (c *MockproductDBAccessorGetProductCategoriesCall) DoAndReturn(f func(context.Context, GetProductCategoriesSpec) (*AccessorGetProductCategoriesPaginationData, error)) *MockproductDBAccessorGetProductCategoriesCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// getProductByID mocks base method.
-func (m *MockproductDBAccessor) getProductByID(ctx context.Context, productID string) (*Product, error) {
+// GetProductTypes mocks base method.
+func (m *MockproductDBAccessor) GetProductTypes(ctx context.Context, spec GetCatalogueSpec) (*AccessorGetProductTypesPaginationData, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "getProductByID", ctx, productID)
-	ret0, _ := ret[0].(*Product)
+	ret := m.ctrl.Call(m, "GetProductTypes", ctx, spec)
+	ret0, _ := ret[0].(*AccessorGetProductTypesPaginationData)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// getProductByID indicates an expected call of getProductByID.
-func (mr *MockproductDBAccessorMockRecorder) getProductByID(ctx, productID any) *MockproductDBAccessorgetProductByIDCall {
+// GetProductTypes indicates an expected call of GetProductTypes.
+func (mr *MockproductDBAccessorMockRecorder) GetProductTypes(ctx, spec any) *MockproductDBAccessorGetProductTypesCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getProductByID", reflect.TypeOf((*MockproductDBAccessor)(nil).getProductByID), ctx, productID)
-	return &MockproductDBAccessorgetProductByIDCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductTypes", reflect.TypeOf((*MockproductDBAccessor)(nil).GetProductTypes), ctx, spec)
+	return &MockproductDBAccessorGetProductTypesCall{Call: call}
 }
 
-// MockproductDBAccessorgetProductByIDCall wrap *gomock.Call
-type MockproductDBAccessorgetProductByIDCall struct {
+// MockproductDBAccessorGetProductTypesCall wrap *gomock.Call
+type MockproductDBAccessorGetProductTypesCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *MockproductDBAccessorgetProductByIDCall) Return(arg0 *Product, arg1 error) *MockproductDBAccessorgetProductByIDCall {
+func (c *MockproductDBAccessorGetProductTypesCall) Return(arg0 *AccessorGetProductTypesPaginationData, arg1 error) *MockproductDBAccessorGetProductTypesCall {
 	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *MockproductDBAccessorgetProductByIDCall) Do(f func(context.Context, string) (*Product, error)) *MockproductDBAccessorgetProductByIDCall {
+func (c *MockproductDBAccessorGetProductTypesCall) Do(f func(context.Context, GetCatalogueSpec) (*AccessorGetProductTypesPaginationData, error)) *MockproductDBAccessorGetProductTypesCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *MockproductDBAccessorgetProductByIDCall) DoAndReturn(f func(context.Context, string) (*Product, error)) *MockproductDBAccessorgetProductByIDCall {
+func (c *MockproductDBAccessorGetProductTypesCall) DoAndReturn(f func(context.Context, GetCatalogueSpec) (*AccessorGetProductTypesPaginationData, error)) *MockproductDBAccessorGetProductTypesCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// getProductCategoryByID mocks base method.
-func (m *MockproductDBAccessor) getProductCategoryByID(ctx context.Context, pvID string) (*ProductCategory, error) {
+// GetProductVendorsByVendor mocks base method.
+func (m *MockproductDBAccessor) GetProductVendorsByVendor(ctx context.Context, vendorID string, spec GetProductVendorByVendorSpec) (*AccessorGetProductVendorsPaginationData, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "getProductCategoryByID", ctx, pvID)
-	ret0, _ := ret[0].(*ProductCategory)
+	ret := m.ctrl.Call(m, "GetProductVendorsByVendor", ctx, vendorID, spec)
+	ret0, _ := ret[0].(*AccessorGetProductVendorsPaginationData)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// getProductCategoryByID indicates an expected call of getProductCategoryByID.
-func (mr *MockproductDBAccessorMockRecorder) getProductCategoryByID(ctx, pvID any) *MockproductDBAccessorgetProductCategoryByIDCall {
+// GetProductVendorsByVendor indicates an expected call of GetProductVendorsByVendor.
+func (mr *MockproductDBAccessorMockRecorder) GetProductVendorsByVendor(ctx, vendorID, spec any) *MockproductDBAccessorGetProductVendorsByVendorCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getProductCategoryByID", reflect.TypeOf((*MockproductDBAccessor)(nil).getProductCategoryByID), ctx, pvID)
-	return &MockproductDBAccessorgetProductCategoryByIDCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductVendorsByVendor", reflect.TypeOf((*MockproductDBAccessor)(nil).GetProductVendorsByVendor), ctx, vendorID, spec)
+	return &MockproductDBAccessorGetProductVendorsByVendorCall{Call: call}
 }
 
-// MockproductDBAccessorgetProductCategoryByIDCall wrap *gomock.Call
-type MockproductDBAccessorgetProductCategoryByIDCall struct {
+// MockproductDBAccessorGetProductVendorsByVendorCall wrap *gomock.Call
+type MockproductDBAccessorGetProductVendorsByVendorCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *MockproductDBAccessorgetProductCategoryByIDCall) Return(arg0 *ProductCategory, arg1 error) *MockproductDBAccessorgetProductCategoryByIDCall {
+func (c *MockproductDBAccessorGetProductVendorsByVendorCall) Return(arg0 *AccessorGetProductVendorsPaginationData, arg1 error) *MockproductDBAccessorGetProductVendorsByVendorCall {
 	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *MockproductDBAccessorgetProductCategoryByIDCall) Do(f func(context.Context, string) (*ProductCategory, error)) *MockproductDBAccessorgetProductCategoryByIDCall {
+func (c *MockproductDBAccessorGetProductVendorsByVendorCall) Do(f func(context.Context, string, GetProductVendorByVendorSpec) (*AccessorGetProductVendorsPaginationData, error)) *MockproductDBAccessorGetProductVendorsByVendorCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *MockproductDBAccessorgetProductCategoryByIDCall) DoAndReturn(f func(context.Context, string) (*ProductCategory, error)) *MockproductDBAccessorgetProductCategoryByIDCall {
+func (c *MockproductDBAccessorGetProductVendorsByVendorCall) DoAndReturn(f func(context.Context, string, GetProductVendorByVendorSpec) (*AccessorGetProductVendorsPaginationData, error)) *MockproductDBAccessorGetProductVendorsByVendorCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// getUOMByID mocks base method.
-func (m *MockproductDBAccessor) getUOMByID(ctx context.Context, uomID string) (*UOM, error) {
+// GetProducts mocks base method.
+func (m *MockproductDBAccessor) GetProducts(ctx context.Context, spec GetProductsSpec) (*AccessorGetProductsPaginationData, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "getUOMByID", ctx, uomID)
-	ret0, _ := ret[0].(*UOM)
+	ret := m.ctrl.Call(m, "GetProducts", ctx, spec)
+	ret0, _ := ret[0].(*AccessorGetProductsPaginationData)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// getUOMByID indicates an expected call of getUOMByID.
-func (mr *MockproductDBAccessorMockRecorder) getUOMByID(ctx, uomID any) *MockproductDBAccessorgetUOMByIDCall {
+// GetProducts indicates an expected call of GetProducts.
+func (mr *MockproductDBAccessorMockRecorder) GetProducts(ctx, spec any) *MockproductDBAccessorGetProductsCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getUOMByID", reflect.TypeOf((*MockproductDBAccessor)(nil).getUOMByID), ctx, uomID)
-	return &MockproductDBAccessorgetUOMByIDCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProducts", reflect.TypeOf((*MockproductDBAccessor)(nil).GetProducts), ctx, spec)
+	return &MockproductDBAccessorGetProductsCall{Call: call}
 }
 
-// MockproductDBAccessorgetUOMByIDCall wrap *gomock.Call
-type MockproductDBAccessorgetUOMByIDCall struct {
+// MockproductDBAccessorGetProductsCall wrap *gomock.Call
+type MockproductDBAccessorGetProductsCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *MockproductDBAccessorgetUOMByIDCall) Return(arg0 *UOM, arg1 error) *MockproductDBAccessorgetUOMByIDCall {
+func (c *MockproductDBAccessorGetProductsCall) Return(arg0 *AccessorGetProductsPaginationData, arg1 error) *MockproductDBAccessorGetProductsCall {
 	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *MockproductDBAccessorgetUOMByIDCall) Do(f func(context.Context, string) (*UOM, error)) *MockproductDBAccessorgetUOMByIDCall {
+func (c *MockproductDBAccessorGetProductsCall) Do(f func(context.Context, GetProductsSpec) (*AccessorGetProductsPaginationData, error)) *MockproductDBAccessorGetProductsCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *MockproductDBAccessorgetUOMByIDCall) DoAndReturn(f func(context.Context, string) (*UOM, error)) *MockproductDBAccessorgetUOMByIDCall {
+func (c *MockproductDBAccessorGetProductsCall) DoAndReturn(f func(context.Context, GetProductsSpec) (*AccessorGetProductsPaginationData, error)) *MockproductDBAccessorGetProductsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetUOMs mocks base method.
+func (m *MockproductDBAccessor) GetUOMs(ctx context.Context, spec GetCatalogueSpec) (*AccessorGetUOMsPaginationData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUOMs", ctx, spec)
+	ret0, _ := ret[0].(*AccessorGetUOMsPaginationData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUOMs indicates an expected call of GetUOMs.
+func (mr *MockproductDBAccessorMockRecorder) GetUOMs(ctx, spec any) *MockproductDBAccessorGetUOMsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUOMs", reflect.TypeOf((*MockproductDBAccessor)(nil).GetUOMs), ctx, spec)
+	return &MockproductDBAccessorGetUOMsCall{Call: call}
+}
+
+// MockproductDBAccessorGetUOMsCall wrap *gomock.Call
+type MockproductDBAccessorGetUOMsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockproductDBAccessorGetUOMsCall) Return(arg0 *AccessorGetUOMsPaginationData, arg1 error) *MockproductDBAccessorGetUOMsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockproductDBAccessorGetUOMsCall) Do(f func(context.Context, GetCatalogueSpec) (*AccessorGetUOMsPaginationData, error)) *MockproductDBAccessorGetUOMsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockproductDBAccessorGetUOMsCall) DoAndReturn(f func(context.Context, GetCatalogueSpec) (*AccessorGetUOMsPaginationData, error)) *MockproductDBAccessorGetUOMsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ImportPrices mocks base method.
+func (m *MockproductDBAccessor) ImportPrices(ctx context.Context, prices []Price, change PriceChange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportPrices", ctx, prices, change)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportPrices indicates an expected call of ImportPrices.
+func (mr *MockproductDBAccessorMockRecorder) ImportPrices(ctx, prices, change any) *MockproductDBAccessorImportPricesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportPrices", reflect.TypeOf((*MockproductDBAccessor)(nil).ImportPrices), ctx, prices, change)
+	return &MockproductDBAccessorImportPricesCall{Call: call}
+}
+
+// MockproductDBAccessorImportPricesCall wrap *gomock.Call
+type MockproductDBAccessorImportPricesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockproductDBAccessorImportPricesCall) Return(arg0 error) *MockproductDBAccessorImportPricesCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockproductDBAccessorImportPricesCall) Do(f func(context.Context, []Price, PriceChange) error) *MockproductDBAccessorImportPricesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockproductDBAccessorImportPricesCall) DoAndReturn(f func(context.Context, []Price, PriceChange) error) *MockproductDBAccessorImportPricesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// UpdatePrice mocks base method.
+func (m *MockproductDBAccessor) UpdatePrice(ctx context.Context, price Price, change PriceChange) (Price, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePrice", ctx, price, change)
+	ret0, _ := ret[0].(Price)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePrice indicates an expected call of UpdatePrice.
+func (mr *MockproductDBAccessorMockRecorder) UpdatePrice(ctx, price, change any) *MockproductDBAccessorUpdatePriceCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePrice", reflect.TypeOf((*MockproductDBAccessor)(nil).UpdatePrice), ctx, price, change)
+	return &MockproductDBAccessorUpdatePriceCall{Call: call}
+}
+
+// MockproductDBAccessorUpdatePriceCall wrap *gomock.Call
+type MockproductDBAccessorUpdatePriceCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockproductDBAccessorUpdatePriceCall) Return(arg0 Price, arg1 error) *MockproductDBAccessorUpdatePriceCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockproductDBAccessorUpdatePriceCall) Do(f func(context.Context, Price, PriceChange) (Price, error)) *MockproductDBAccessorUpdatePriceCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockproductDBAccessorUpdatePriceCall) DoAndReturn(f func(context.Context, Price, PriceChange) (Price, error)) *MockproductDBAccessorUpdatePriceCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// UpdatePriceAnomalyReview mocks base method.
+func (m *MockproductDBAccessor) UpdatePriceAnomalyReview(ctx context.Context, anomaly PriceAnomaly) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePriceAnomalyReview", ctx, anomaly)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePriceAnomalyReview indicates an expected call of UpdatePriceAnomalyReview.
+func (mr *MockproductDBAccessorMockRecorder) UpdatePriceAnomalyReview(ctx, anomaly any) *MockproductDBAccessorUpdatePriceAnomalyReviewCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePriceAnomalyReview", reflect.TypeOf((*MockproductDBAccessor)(nil).UpdatePriceAnomalyReview), ctx, anomaly)
+	return &MockproductDBAccessorUpdatePriceAnomalyReviewCall{Call: call}
+}
+
+// MockproductDBAccessorUpdatePriceAnomalyReviewCall wrap *gomock.Call
+type MockproductDBAccessorUpdatePriceAnomalyReviewCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockproductDBAccessorUpdatePriceAnomalyReviewCall) Return(arg0 error) *MockproductDBAccessorUpdatePriceAnomalyReviewCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockproductDBAccessorUpdatePriceAnomalyReviewCall) Do(f func(context.Context, PriceAnomaly) error) *MockproductDBAccessorUpdatePriceAnomalyReviewCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockproductDBAccessorUpdatePriceAnomalyReviewCall) DoAndReturn(f func(context.Context, PriceAnomaly) error) *MockproductDBAccessorUpdatePriceAnomalyReviewCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// UpdateProduct mocks base method.
+func (m *MockproductDBAccessor) UpdateProduct(ctx context.Context, payload Product) (Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProduct", ctx, payload)
+	ret0, _ := ret[0].(Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProduct indicates an expected call of UpdateProduct.
+func (mr *MockproductDBAccessorMockRecorder) UpdateProduct(ctx, payload any) *MockproductDBAccessorUpdateProductCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProduct", reflect.TypeOf((*MockproductDBAccessor)(nil).UpdateProduct), ctx, payload)
+	return &MockproductDBAccessorUpdateProductCall{Call: call}
+}
+
+// MockproductDBAccessorUpdateProductCall wrap *gomock.Call
+type MockproductDBAccessorUpdateProductCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockproductDBAccessorUpdateProductCall) Return(arg0 Product, arg1 error) *MockproductDBAccessorUpdateProductCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockproductDBAccessorUpdateProductCall) Do(f func(context.Context, Product) (Product, error)) *MockproductDBAccessorUpdateProductCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockproductDBAccessorUpdateProductCall) DoAndReturn(f func(context.Context, Product) (Product, error)) *MockproductDBAccessorUpdateProductCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// WritePriceAnomalies mocks base method.
+func (m *MockproductDBAccessor) WritePriceAnomalies(ctx context.Context, anomalies []PriceAnomaly) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WritePriceAnomalies", ctx, anomalies)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WritePriceAnomalies indicates an expected call of WritePriceAnomalies.
+func (mr *MockproductDBAccessorMockRecorder) WritePriceAnomalies(ctx, anomalies any) *MockproductDBAccessorWritePriceAnomaliesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WritePriceAnomalies", reflect.TypeOf((*MockproductDBAccessor)(nil).WritePriceAnomalies), ctx, anomalies)
+	return &MockproductDBAccessorWritePriceAnomaliesCall{Call: call}
+}
+
+// MockproductDBAccessorWritePriceAnomaliesCall wrap *gomock.Call
+type MockproductDBAccessorWritePriceAnomaliesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockproductDBAccessorWritePriceAnomaliesCall) Return(arg0 error) *MockproductDBAccessorWritePriceAnomaliesCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockproductDBAccessorWritePriceAnomaliesCall) Do(f func(context.Context, []PriceAnomaly) error) *MockproductDBAccessorWritePriceAnomaliesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockproductDBAccessorWritePriceAnomaliesCall) DoAndReturn(f func(context.Context, []PriceAnomaly) error) *MockproductDBAccessorWritePriceAnomaliesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// getCategoryAncestors mocks base method.
+func (m *MockproductDBAccessor) getCategoryAncestors(ctx context.Context, categoryID string) ([]ProductCategory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getCategoryAncestors", ctx, categoryID)
+	ret0, _ := ret[0].([]ProductCategory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// getCategoryAncestors indicates an expected call of getCategoryAncestors.
+func (mr *MockproductDBAccessorMockRecorder) getCategoryAncestors(ctx, categoryID any) *MockproductDBAccessorgetCategoryAncestorsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getCategoryAncestors", reflect.TypeOf((*MockproductDBAccessor)(nil).getCategoryAncestors), ctx, categoryID)
+	return &MockproductDBAccessorgetCategoryAncestorsCall{Call: call}
+}
+
+// MockproductDBAccessorgetCategoryAncestorsCall wrap *gomock.Call
+type MockproductDBAccessorgetCategoryAncestorsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockproductDBAccessorgetCategoryAncestorsCall) Return(arg0 []ProductCategory, arg1 error) *MockproductDBAccessorgetCategoryAncestorsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockproductDBAccessorgetCategoryAncestorsCall) Do(f func(context.Context, string) ([]ProductCategory, error)) *MockproductDBAccessorgetCategoryAncestorsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockproductDBAccessorgetCategoryAncestorsCall) DoAndReturn(f func(context.Context, string) ([]ProductCategory, error)) *MockproductDBAccessorgetCategoryAncestorsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// getCategorySubtree mocks base method.
+func (m *MockproductDBAccessor) getCategorySubtree(ctx context.Context, categoryID string) ([]ProductCategory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getCategorySubtree", ctx, categoryID)
+	ret0, _ := ret[0].([]ProductCategory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// getCategorySubtree indicates an expected call of getCategorySubtree.
+func (mr *MockproductDBAccessorMockRecorder) getCategorySubtree(ctx, categoryID any) *MockproductDBAccessorgetCategorySubtreeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getCategorySubtree", reflect.TypeOf((*MockproductDBAccessor)(nil).getCategorySubtree), ctx, categoryID)
+	return &MockproductDBAccessorgetCategorySubtreeCall{Call: call}
+}
+
+// MockproductDBAccessorgetCategorySubtreeCall wrap *gomock.Call
+type MockproductDBAccessorgetCategorySubtreeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockproductDBAccessorgetCategorySubtreeCall) Return(arg0 []ProductCategory, arg1 error) *MockproductDBAccessorgetCategorySubtreeCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockproductDBAccessorgetCategorySubtreeCall) Do(f func(context.Context, string) ([]ProductCategory, error)) *MockproductDBAccessorgetCategorySubtreeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockproductDBAccessorgetCategorySubtreeCall) DoAndReturn(f func(context.Context, string) ([]ProductCategory, error)) *MockproductDBAccessorgetCategorySubtreeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// getCategoryTree mocks base method.
+func (m *MockproductDBAccessor) getCategoryTree(ctx context.Context, parentID string) ([]ProductCategory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getCategoryTree", ctx, parentID)
+	ret0, _ := ret[0].([]ProductCategory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// getCategoryTree indicates an expected call of getCategoryTree.
+func (mr *MockproductDBAccessorMockRecorder) getCategoryTree(ctx, parentID any) *MockproductDBAccessorgetCategoryTreeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getCategoryTree", reflect.TypeOf((*MockproductDBAccessor)(nil).getCategoryTree), ctx, parentID)
+	return &MockproductDBAccessorgetCategoryTreeCall{Call: call}
+}
+
+// MockproductDBAccessorgetCategoryTreeCall wrap *gomock.Call
+type MockproductDBAccessorgetCategoryTreeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockproductDBAccessorgetCategoryTreeCall) Return(arg0 []ProductCategory, arg1 error) *MockproductDBAccessorgetCategoryTreeCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockproductDBAccessorgetCategoryTreeCall) Do(f func(context.Context, string) ([]ProductCategory, error)) *MockproductDBAccessorgetCategoryTreeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockproductDBAccessorgetCategoryTreeCall) DoAndReturn(f func(context.Context, string) ([]ProductCategory, error)) *MockproductDBAccessorgetCategoryTreeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// getDeletedPrice mocks base method.
+func (m *MockproductDBAccessor) getDeletedPrice(ctx context.Context, id string) (*Price, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getDeletedPrice", ctx, id)
+	ret0, _ := ret[0].(*Price)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// getDeletedPrice indicates an expected call of getDeletedPrice.
+func (mr *MockproductDBAccessorMockRecorder) getDeletedPrice(ctx, id any) *MockproductDBAccessorgetDeletedPriceCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getDeletedPrice", reflect.TypeOf((*MockproductDBAccessor)(nil).getDeletedPrice), ctx, id)
+	return &MockproductDBAccessorgetDeletedPriceCall{Call: call}
+}
+
+// MockproductDBAccessorgetDeletedPriceCall wrap *gomock.Call
+type MockproductDBAccessorgetDeletedPriceCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockproductDBAccessorgetDeletedPriceCall) Return(arg0 *Price, arg1 error) *MockproductDBAccessorgetDeletedPriceCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockproductDBAccessorgetDeletedPriceCall) Do(f func(context.Context, string) (*Price, error)) *MockproductDBAccessorgetDeletedPriceCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockproductDBAccessorgetDeletedPriceCall) DoAndReturn(f func(context.Context, string) (*Price, error)) *MockproductDBAccessorgetDeletedPriceCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// getPeerPrices mocks base method.
+func (m *MockproductDBAccessor) getPeerPrices(ctx context.Context, productVendorIDs []string, at time.Time) (map[string][]Price, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getPeerPrices", ctx, productVendorIDs, at)
+	ret0, _ := ret[0].(map[string][]Price)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// getPeerPrices indicates an expected call of getPeerPrices.
+func (mr *MockproductDBAccessorMockRecorder) getPeerPrices(ctx, productVendorIDs, at any) *MockproductDBAccessorgetPeerPricesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getPeerPrices", reflect.TypeOf((*MockproductDBAccessor)(nil).getPeerPrices), ctx, productVendorIDs, at)
+	return &MockproductDBAccessorgetPeerPricesCall{Call: call}
+}
+
+// MockproductDBAccessorgetPeerPricesCall wrap *gomock.Call
+type MockproductDBAccessorgetPeerPricesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockproductDBAccessorgetPeerPricesCall) Return(arg0 map[string][]Price, arg1 error) *MockproductDBAccessorgetPeerPricesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockproductDBAccessorgetPeerPricesCall) Do(f func(context.Context, []string, time.Time) (map[string][]Price, error)) *MockproductDBAccessorgetPeerPricesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockproductDBAccessorgetPeerPricesCall) DoAndReturn(f func(context.Context, []string, time.Time) (map[string][]Price, error)) *MockproductDBAccessorgetPeerPricesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// getPriceImportReferences mocks base method.
+func (m *MockproductDBAccessor) getPriceImportReferences(ctx context.Context, keys priceImportKeys) (*priceImportReferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getPriceImportReferences", ctx, keys)
+	ret0, _ := ret[0].(*priceImportReferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// getPriceImportReferences indicates an expected call of getPriceImportReferences.
+func (mr *MockproductDBAccessorMockRecorder) getPriceImportReferences(ctx, keys any) *MockproductDBAccessorgetPriceImportReferencesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getPriceImportReferences", reflect.TypeOf((*MockproductDBAccessor)(nil).getPriceImportReferences), ctx, keys)
+	return &MockproductDBAccessorgetPriceImportReferencesCall{Call: call}
+}
+
+// MockproductDBAccessorgetPriceImportReferencesCall wrap *gomock.Call
+type MockproductDBAccessorgetPriceImportReferencesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockproductDBAccessorgetPriceImportReferencesCall) Return(arg0 *priceImportReferences, arg1 error) *MockproductDBAccessorgetPriceImportReferencesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockproductDBAccessorgetPriceImportReferencesCall) Do(f func(context.Context, priceImportKeys) (*priceImportReferences, error)) *MockproductDBAccessorgetPriceImportReferencesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockproductDBAccessorgetPriceImportReferencesCall) DoAndReturn(f func(context.Context, priceImportKeys) (*priceImportReferences, error)) *MockproductDBAccessorgetPriceImportReferencesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// getPricesByPVID mocks base method.
+func (m *MockproductDBAccessor) getPricesByPVID(ctx context.Context, pvID string) ([]Price, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getPricesByPVID", ctx, pvID)
+	ret0, _ := ret[0].([]Price)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// getPricesByPVID indicates an expected call of getPricesByPVID.
+func (mr *MockproductDBAccessorMockRecorder) getPricesByPVID(ctx, pvID any) *MockproductDBAccessorgetPricesByPVIDCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getPricesByPVID", reflect.TypeOf((*MockproductDBAccessor)(nil).getPricesByPVID), ctx, pvID)
+	return &MockproductDBAccessorgetPricesByPVIDCall{Call: call}
+}
+
+// MockproductDBAccessorgetPricesByPVIDCall wrap *gomock.Call
+type MockproductDBAccessorgetPricesByPVIDCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockproductDBAccessorgetPricesByPVIDCall) Return(arg0 []Price, arg1 error) *MockproductDBAccessorgetPricesByPVIDCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockproductDBAccessorgetPricesByPVIDCall) Do(f func(context.Context, string) ([]Price, error)) *MockproductDBAccessorgetPricesByPVIDCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockproductDBAccessorgetPricesByPVIDCall) DoAndReturn(f func(context.Context, string) ([]Price, error)) *MockproductDBAccessorgetPricesByPVIDCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// getProductByID mocks base method.
+func (m *MockproductDBAccessor) getProductByID(ctx context.Context, productID string) (*Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getProductByID", ctx, productID)
+	ret0, _ := ret[0].(*Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// getProductByID indicates an expected call of getProductByID.
+func (mr *MockproductDBAccessorMockRecorder) getProductByID(ctx, productID any) *MockproductDBAccessorgetProductByIDCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getProductByID", reflect.TypeOf((*MockproductDBAccessor)(nil).getProductByID), ctx, productID)
+	return &MockproductDBAccessorgetProductByIDCall{Call: call}
+}
+
+// MockproductDBAccessorgetProductByIDCall wrap *gomock.Call
+type MockproductDBAccessorgetProductByIDCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockproductDBAccessorgetProductByIDCall) Return(arg0 *Product, arg1 error) *MockproductDBAccessorgetProductByIDCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockproductDBAccessorgetProductByIDCall) Do(f func(context.Context, string) (*Product, error)) *MockproductDBAccessorgetProductByIDCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockproductDBAccessorgetProductByIDCall) DoAndReturn(f func(context.Context, string) (*Product, error)) *MockproductDBAccessorgetProductByIDCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// getProductCategoryByID mocks base method.
+func (m *MockproductDBAccessor) getProductCategoryByID(ctx context.Context, pvID string) (*ProductCategory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getProductCategoryByID", ctx, pvID)
+	ret0, _ := ret[0].(*ProductCategory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// getProductCategoryByID indicates an expected call of getProductCategoryByID.
+func (mr *MockproductDBAccessorMockRecorder) getProductCategoryByID(ctx, pvID any) *MockproductDBAccessorgetProductCategoryByIDCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getProductCategoryByID", reflect.TypeOf((*MockproductDBAccessor)(nil).getProductCategoryByID), ctx, pvID)
+	return &MockproductDBAccessorgetProductCategoryByIDCall{Call: call}
+}
+
+// MockproductDBAccessorgetProductCategoryByIDCall wrap *gomock.Call
+type MockproductDBAccessorgetProductCategoryByIDCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockproductDBAccessorgetProductCategoryByIDCall) Return(arg0 *ProductCategory, arg1 error) *MockproductDBAccessorgetProductCategoryByIDCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockproductDBAccessorgetProductCategoryByIDCall) Do(f func(context.Context, string) (*ProductCategory, error)) *MockproductDBAccessorgetProductCategoryByIDCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockproductDBAccessorgetProductCategoryByIDCall) DoAndReturn(f func(context.Context, string) (*ProductCategory, error)) *MockproductDBAccessorgetProductCategoryByIDCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// getProductTypeByID mocks base method.
+func (m *MockproductDBAccessor) getProductTypeByID(ctx context.Context, productTypeID string) (*ProductType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getProductTypeByID", ctx, productTypeID)
+	ret0, _ := ret[0].(*ProductType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// getProductTypeByID indicates an expected call of getProductTypeByID.
+func (mr *MockproductDBAccessorMockRecorder) getProductTypeByID(ctx, productTypeID any) *MockproductDBAccessorgetProductTypeByIDCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getProductTypeByID", reflect.TypeOf((*MockproductDBAccessor)(nil).getProductTypeByID), ctx, productTypeID)
+	return &MockproductDBAccessorgetProductTypeByIDCall{Call: call}
+}
+
+// MockproductDBAccessorgetProductTypeByIDCall wrap *gomock.Call
+type MockproductDBAccessorgetProductTypeByIDCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockproductDBAccessorgetProductTypeByIDCall) Return(arg0 *ProductType, arg1 error) *MockproductDBAccessorgetProductTypeByIDCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockproductDBAccessorgetProductTypeByIDCall) Do(f func(context.Context, string) (*ProductType, error)) *MockproductDBAccessorgetProductTypeByIDCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockproductDBAccessorgetProductTypeByIDCall) DoAndReturn(f func(context.Context, string) (*ProductType, error)) *MockproductDBAccessorgetProductTypeByIDCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// getProductVendorPageReferences mocks base method.
+func (m *MockproductDBAccessor) getProductVendorPageReferences(ctx context.Context, productVendors []ProductVendor) (*productVendorPageReferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getProductVendorPageReferences", ctx, productVendors)
+	ret0, _ := ret[0].(*productVendorPageReferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// getProductVendorPageReferences indicates an expected call of getProductVendorPageReferences.
+func (mr *MockproductDBAccessorMockRecorder) getProductVendorPageReferences(ctx, productVendors any) *MockproductDBAccessorgetProductVendorPageReferencesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getProductVendorPageReferences", reflect.TypeOf((*MockproductDBAccessor)(nil).getProductVendorPageReferences), ctx, productVendors)
+	return &MockproductDBAccessorgetProductVendorPageReferencesCall{Call: call}
+}
+
+// MockproductDBAccessorgetProductVendorPageReferencesCall wrap *gomock.Call
+type MockproductDBAccessorgetProductVendorPageReferencesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockproductDBAccessorgetProductVendorPageReferencesCall) Return(arg0 *productVendorPageReferences, arg1 error) *MockproductDBAccessorgetProductVendorPageReferencesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockproductDBAccessorgetProductVendorPageReferencesCall) Do(f func(context.Context, []ProductVendor) (*productVendorPageReferences, error)) *MockproductDBAccessorgetProductVendorPageReferencesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockproductDBAccessorgetProductVendorPageReferencesCall) DoAndReturn(f func(context.Context, []ProductVendor) (*productVendorPageReferences, error)) *MockproductDBAccessorgetProductVendorPageReferencesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// getUOMByID mocks base method.
+func (m *MockproductDBAccessor) getUOMByID(ctx context.Context, uomID string) (*UOM, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getUOMByID", ctx, uomID)
+	ret0, _ := ret[0].(*UOM)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// getUOMByID indicates an expected call of getUOMByID.
+func (mr *MockproductDBAccessorMockRecorder) getUOMByID(ctx, uomID any) *MockproductDBAccessorgetUOMByIDCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getUOMByID", reflect.TypeOf((*MockproductDBAccessor)(nil).getUOMByID), ctx, uomID)
+	return &MockproductDBAccessorgetUOMByIDCall{Call: call}
+}
+
+// MockproductDBAccessorgetUOMByIDCall wrap *gomock.Call
+type MockproductDBAccessorgetUOMByIDCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockproductDBAccessorgetUOMByIDCall) Return(arg0 *UOM, arg1 error) *MockproductDBAccessorgetUOMByIDCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockproductDBAccessorgetUOMByIDCall) Do(f func(context.Context, string) (*UOM, error)) *MockproductDBAccessorgetUOMByIDCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockproductDBAccessorgetUOMByIDCall) DoAndReturn(f func(context.Context, string) (*UOM, error)) *MockproductDBAccessorgetUOMByIDCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// getVendorNames mocks base method.
+func (m *MockproductDBAccessor) getVendorNames(ctx context.Context, vendorIDs []string) (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getVendorNames", ctx, vendorIDs)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// getVendorNames indicates an expected call of getVendorNames.
+func (mr *MockproductDBAccessorMockRecorder) getVendorNames(ctx, vendorIDs any) *MockproductDBAccessorgetVendorNamesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getVendorNames", reflect.TypeOf((*MockproductDBAccessor)(nil).getVendorNames), ctx, vendorIDs)
+	return &MockproductDBAccessorgetVendorNamesCall{Call: call}
+}
+
+// MockproductDBAccessorgetVendorNamesCall wrap *gomock.Call
+type MockproductDBAccessorgetVendorNamesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockproductDBAccessorgetVendorNamesCall) Return(arg0 map[string]string, arg1 error) *MockproductDBAccessorgetVendorNamesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockproductDBAccessorgetVendorNamesCall) Do(f func(context.Context, []string) (map[string]string, error)) *MockproductDBAccessorgetVendorNamesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockproductDBAccessorgetVendorNamesCall) DoAndReturn(f func(context.Context, []string) (map[string]string, error)) *MockproductDBAccessorgetVendorNamesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// lockPriceAnomaly mocks base method.
+func (m *MockproductDBAccessor) lockPriceAnomaly(ctx context.Context, id string) (*PriceAnomaly, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "lockPriceAnomaly", ctx, id)
+	ret0, _ := ret[0].(*PriceAnomaly)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// lockPriceAnomaly indicates an expected call of lockPriceAnomaly.
+func (mr *MockproductDBAccessorMockRecorder) lockPriceAnomaly(ctx, id any) *MockproductDBAccessorlockPriceAnomalyCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "lockPriceAnomaly", reflect.TypeOf((*MockproductDBAccessor)(nil).lockPriceAnomaly), ctx, id)
+	return &MockproductDBAccessorlockPriceAnomalyCall{Call: call}
+}
+
+// MockproductDBAccessorlockPriceAnomalyCall wrap *gomock.Call
+type MockproductDBAccessorlockPriceAnomalyCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockproductDBAccessorlockPriceAnomalyCall) Return(arg0 *PriceAnomaly, arg1 error) *MockproductDBAccessorlockPriceAnomalyCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockproductDBAccessorlockPriceAnomalyCall) Do(f func(context.Context, string) (*PriceAnomaly, error)) *MockproductDBAccessorlockPriceAnomalyCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockproductDBAccessorlockPriceAnomalyCall) DoAndReturn(f func(context.Context, string) (*PriceAnomaly, error)) *MockproductDBAccessorlockPriceAnomalyCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// lockProductVendor mocks base method.
+func (m *MockproductDBAccessor) lockProductVendor(ctx context.Context, pvID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "lockProductVendor", ctx, pvID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// lockProductVendor indicates an expected call of lockProductVendor.
+func (mr *MockproductDBAccessorMockRecorder) lockProductVendor(ctx, pvID any) *MockproductDBAccessorlockProductVendorCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "lockProductVendor", reflect.TypeOf((*MockproductDBAccessor)(nil).lockProductVendor), ctx, pvID)
+	return &MockproductDBAccessorlockProductVendorCall{Call: call}
+}
+
+// MockproductDBAccessorlockProductVendorCall wrap *gomock.Call
+type MockproductDBAccessorlockProductVendorCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockproductDBAccessorlockProductVendorCall) Return(arg0 error) *MockproductDBAccessorlockProductVendorCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockproductDBAccessorlockProductVendorCall) Do(f func(context.Context, string) error) *MockproductDBAccessorlockProductVendorCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockproductDBAccessorlockProductVendorCall) DoAndReturn(f func(context.Context, string) error) *MockproductDBAccessorlockProductVendorCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// moveProductCategory mocks base method.
+func (m *MockproductDBAccessor) moveProductCategory(ctx context.Context, categoryID, parentID, modifiedBy string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "moveProductCategory", ctx, categoryID, parentID, modifiedBy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// moveProductCategory indicates an expected call of moveProductCategory.
+func (mr *MockproductDBAccessorMockRecorder) moveProductCategory(ctx, categoryID, parentID, modifiedBy any) *MockproductDBAccessormoveProductCategoryCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "moveProductCategory", reflect.TypeOf((*MockproductDBAccessor)(nil).moveProductCategory), ctx, categoryID, parentID, modifiedBy)
+	return &MockproductDBAccessormoveProductCategoryCall{Call: call}
+}
+
+// MockproductDBAccessormoveProductCategoryCall wrap *gomock.Call
+type MockproductDBAccessormoveProductCategoryCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockproductDBAccessormoveProductCategoryCall) Return(arg0 error) *MockproductDBAccessormoveProductCategoryCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockproductDBAccessormoveProductCategoryCall) Do(f func(context.Context, string, string, string) error) *MockproductDBAccessormoveProductCategoryCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockproductDBAccessormoveProductCategoryCall) DoAndReturn(f func(context.Context, string, string, string) error) *MockproductDBAccessormoveProductCategoryCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// restore mocks base method.
+func (m *MockproductDBAccessor) restore(ctx context.Context, table catalogueTable, id, restoredBy string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "restore", ctx, table, id, restoredBy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// restore indicates an expected call of restore.
+func (mr *MockproductDBAccessorMockRecorder) restore(ctx, table, id, restoredBy any) *MockproductDBAccessorrestoreCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "restore", reflect.TypeOf((*MockproductDBAccessor)(nil).restore), ctx, table, id, restoredBy)
+	return &MockproductDBAccessorrestoreCall{Call: call}
+}
+
+// MockproductDBAccessorrestoreCall wrap *gomock.Call
+type MockproductDBAccessorrestoreCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockproductDBAccessorrestoreCall) Return(arg0 error) *MockproductDBAccessorrestoreCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockproductDBAccessorrestoreCall) Do(f func(context.Context, catalogueTable, string, string) error) *MockproductDBAccessorrestoreCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockproductDBAccessorrestoreCall) DoAndReturn(f func(context.Context, catalogueTable, string, string) error) *MockproductDBAccessorrestoreCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// runInTx mocks base method.
+func (m *MockproductDBAccessor) runInTx(ctx context.Context, fn func(productDBAccessor) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "runInTx", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// runInTx indicates an expected call of runInTx.
+func (mr *MockproductDBAccessorMockRecorder) runInTx(ctx, fn any) *MockproductDBAccessorrunInTxCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "runInTx", reflect.TypeOf((*MockproductDBAccessor)(nil).runInTx), ctx, fn)
+	return &MockproductDBAccessorrunInTxCall{Call: call}
+}
+
+// MockproductDBAccessorrunInTxCall wrap *gomock.Call
+type MockproductDBAccessorrunInTxCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockproductDBAccessorrunInTxCall) Return(arg0 error) *MockproductDBAccessorrunInTxCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockproductDBAccessorrunInTxCall) Do(f func(context.Context, func(productDBAccessor) error) error) *MockproductDBAccessorrunInTxCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockproductDBAccessorrunInTxCall) DoAndReturn(f func(context.Context, func(productDBAccessor) error) error) *MockproductDBAccessorrunInTxCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// softDelete mocks base method.
+func (m *MockproductDBAccessor) softDelete(ctx context.Context, table catalogueTable, id, deletedBy string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "softDelete", ctx, table, id, deletedBy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// softDelete indicates an expected call of softDelete.
+func (mr *MockproductDBAccessorMockRecorder) softDelete(ctx, table, id, deletedBy any) *MockproductDBAccessorsoftDeleteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "softDelete", reflect.TypeOf((*MockproductDBAccessor)(nil).softDelete), ctx, table, id, deletedBy)
+	return &MockproductDBAccessorsoftDeleteCall{Call: call}
+}
+
+// MockproductDBAccessorsoftDeleteCall wrap *gomock.Call
+type MockproductDBAccessorsoftDeleteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockproductDBAccessorsoftDeleteCall) Return(arg0 error) *MockproductDBAccessorsoftDeleteCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockproductDBAccessorsoftDeleteCall) Do(f func(context.Context, catalogueTable, string, string) error) *MockproductDBAccessorsoftDeleteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockproductDBAccessorsoftDeleteCall) DoAndReturn(f func(context.Context, catalogueTable, string, string) error) *MockproductDBAccessorsoftDeleteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockcurrencyConverter is a mock of currencyConverter interface.
+type MockcurrencyConverter struct {
+	ctrl     *gomock.Controller
+	recorder *MockcurrencyConverterMockRecorder
+}
+
+// MockcurrencyConverterMockRecorder is the mock recorder for MockcurrencyConverter.
+type MockcurrencyConverterMockRecorder struct {
+	mock *MockcurrencyConverter
+}
+
+// NewMockcurrencyConverter creates a new mock instance.
+func NewMockcurrencyConverter(ctrl *gomock.Controller) *MockcurrencyConverter {
+	mock := &MockcurrencyConverter{ctrl: ctrl}
+	mock.recorder = &MockcurrencyConverterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcurrencyConverter) EXPECT() *MockcurrencyConverterMockRecorder {
+	return m.recorder
+}
+
+// GetBaseRate mocks base method.
+func (m *MockcurrencyConverter) GetBaseRate(ctx context.Context, code string, date time.Time) (*currency.ExchangeRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBaseRate", ctx, code, date)
+	ret0, _ := ret[0].(*currency.ExchangeRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBaseRate indicates an expected call of GetBaseRate.
+func (mr *MockcurrencyConverterMockRecorder) GetBaseRate(ctx, code, date any) *MockcurrencyConverterGetBaseRateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBaseRate", reflect.TypeOf((*MockcurrencyConverter)(nil).GetBaseRate), ctx, code, date)
+	return &MockcurrencyConverterGetBaseRateCall{Call: call}
+}
+
+// MockcurrencyConverterGetBaseRateCall wrap *gomock.Call
+type MockcurrencyConverterGetBaseRateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockcurrencyConverterGetBaseRateCall) Return(arg0 *currency.ExchangeRate, arg1 error) *MockcurrencyConverterGetBaseRateCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockcurrencyConverterGetBaseRateCall) Do(f func(context.Context, string, time.Time) (*currency.ExchangeRate, error)) *MockcurrencyConverterGetBaseRateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockcurrencyConverterGetBaseRateCall) DoAndReturn(f func(context.Context, string, time.Time) (*currency.ExchangeRate, error)) *MockcurrencyConverterGetBaseRateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockuomConverter is a mock of uomConverter interface.
+type MockuomConverter struct {
+	ctrl     *gomock.Controller
+	recorder *MockuomConverterMockRecorder
+}
+
+// MockuomConverterMockRecorder is the mock recorder for MockuomConverter.
+type MockuomConverterMockRecorder struct {
+	mock *MockuomConverter
+}
+
+// NewMockuomConverter creates a new mock instance.
+func NewMockuomConverter(ctrl *gomock.Controller) *MockuomConverter {
+	mock := &MockuomConverter{ctrl: ctrl}
+	mock.recorder = &MockuomConverterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockuomConverter) EXPECT() *MockuomConverterMockRecorder {
+	return m.recorder
+}
+
+// ConversionTables mocks base method.
+func (m *MockuomConverter) ConversionTables(ctx context.Context, productIDs []string) (map[string]*uom.ConversionTable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConversionTables", ctx, productIDs)
+	ret0, _ := ret[0].(map[string]*uom.ConversionTable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConversionTables indicates an expected call of ConversionTables.
+func (mr *MockuomConverterMockRecorder) ConversionTables(ctx, productIDs any) *MockuomConverterConversionTablesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConversionTables", reflect.TypeOf((*MockuomConverter)(nil).ConversionTables), ctx, productIDs)
+	return &MockuomConverterConversionTablesCall{Call: call}
+}
+
+// MockuomConverterConversionTablesCall wrap *gomock.Call
+type MockuomConverterConversionTablesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockuomConverterConversionTablesCall) Return(arg0 map[string]*uom.ConversionTable, arg1 error) *MockuomConverterConversionTablesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockuomConverterConversionTablesCall) Do(f func(context.Context, []string) (map[string]*uom.ConversionTable, error)) *MockuomConverterConversionTablesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockuomConverterConversionTablesCall) DoAndReturn(f func(context.Context, []string) (map[string]*uom.ConversionTable, error)) *MockuomConverterConversionTablesCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
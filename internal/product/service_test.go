@@ -1,18 +1,25 @@
 package product
 
 import (
+	"bytes"
 	"context"
+	"database/sql"
 	"errors"
+	"kg/procurement/cmd/config"
 	"kg/procurement/internal/common/database"
+	"kg/procurement/internal/common/spreadsheet"
+	"kg/procurement/internal/currency"
+	"kg/procurement/internal/uom"
 	"testing"
 	"time"
 
+	"github.com/benbjohnson/clock"
 	"github.com/onsi/gomega"
 	"go.uber.org/mock/gomock"
 )
 
 func Test_NewProductService(t *testing.T) {
-	_ = NewProductService(nil, nil)
+	_ = NewProductService(nil, nil, nil, nil, config.PriceAnomalyConfig{})
 }
 
 func TestProductService_GetProductVendorsByVendor(t *testing.T) {
@@ -49,7 +56,8 @@ func TestProductService_GetProductVendorsByVendor(t *testing.T) {
 		)
 
 		svc := &ProductService{
-			mockProductAccessor,
+			productDBAccessor: mockProductAccessor,
+			clock:             clock.NewMock(),
 		}
 
 		expect := &GetProductVendorsResponse{
@@ -57,6 +65,7 @@ func TestProductService_GetProductVendorsByVendor(t *testing.T) {
 				{
 					ID:           productVendors[0].ID,
 					Product:      ProductResponse{},
+					Price:        &PriceResponse{},
 					Name:         productVendors[0].Name,
 					ModifiedDate: productVendors[0].ModifiedDate,
 				},
@@ -68,14 +77,8 @@ func TestProductService_GetProductVendorsByVendor(t *testing.T) {
 			},
 		}
 
-		mockProductAccessor.EXPECT().getProductByID(ctx, gomock.Any()).
-			Return(&Product{}, nil)
-		mockProductAccessor.EXPECT().getProductCategoryByID(ctx, gomock.Any()).
-			Return(&ProductCategory{}, nil)
-		mockProductAccessor.EXPECT().getPriceByPVID(ctx, gomock.Any()).
-			Return(&Price{}, nil)
-		mockProductAccessor.EXPECT().getUOMByID(ctx, gomock.Any()).
-			Return(&UOM{}, nil)
+		mockProductAccessor.EXPECT().getProductVendorPageReferences(ctx, gomock.Any()).
+			Return(pageReferences(productVendors), nil)
 		mockProductAccessor.EXPECT().GetProductVendorsByVendor(ctx, vendorID, spec).
 			Return(accessorResponse, nil)
 
@@ -97,7 +100,8 @@ func TestProductService_GetProductVendorsByVendor(t *testing.T) {
 		)
 
 		svc := &ProductService{
-			mockProductAccessor,
+			productDBAccessor: mockProductAccessor,
+			clock:             clock.NewMock(),
 		}
 
 		expect := &GetProductVendorsResponse{
@@ -105,6 +109,7 @@ func TestProductService_GetProductVendorsByVendor(t *testing.T) {
 				{
 					ID:           productVendors[0].ID,
 					Product:      ProductResponse{},
+					Price:        &PriceResponse{},
 					Name:         productVendors[0].Name,
 					ModifiedDate: productVendors[0].ModifiedDate,
 				},
@@ -116,14 +121,8 @@ func TestProductService_GetProductVendorsByVendor(t *testing.T) {
 			},
 		}
 
-		mockProductAccessor.EXPECT().getPriceByPVID(ctx, gomock.Any()).
-			Return(&Price{}, nil)
-		mockProductAccessor.EXPECT().getProductByID(ctx, gomock.Any()).
-			Return(&Product{}, nil)
-		mockProductAccessor.EXPECT().getProductCategoryByID(ctx, gomock.Any()).
-			Return(&ProductCategory{}, nil)
-		mockProductAccessor.EXPECT().getUOMByID(ctx, gomock.Any()).
-			Return(&UOM{}, nil)
+		mockProductAccessor.EXPECT().getProductVendorPageReferences(ctx, gomock.Any()).
+			Return(pageReferences(productVendors), nil)
 		mockProductAccessor.EXPECT().GetProductVendorsByVendor(ctx, vendorID, spec).
 			Return(accessorResponse, nil)
 
@@ -142,7 +141,8 @@ func TestProductService_GetProductVendorsByVendor(t *testing.T) {
 		)
 
 		svc := &ProductService{
-			mockProductAccessor,
+			productDBAccessor: mockProductAccessor,
+			clock:             clock.NewMock(),
 		}
 
 		mockProductAccessor.EXPECT().GetProductVendorsByVendor(ctx, vendorID, spec).
@@ -153,7 +153,7 @@ func TestProductService_GetProductVendorsByVendor(t *testing.T) {
 		g.Expect(err).ShouldNot(gomega.BeNil())
 	})
 
-	t.Run("returns err on product accessor error", func(t *testing.T) {
+	t.Run("returns err on page references error", func(t *testing.T) {
 		var (
 			g                   = gomega.NewWithT(t)
 			ctx                 = context.Background()
@@ -163,10 +163,11 @@ func TestProductService_GetProductVendorsByVendor(t *testing.T) {
 		)
 
 		svc := &ProductService{
-			mockProductAccessor,
+			productDBAccessor: mockProductAccessor,
+			clock:             clock.NewMock(),
 		}
 
-		mockProductAccessor.EXPECT().getProductByID(ctx, gomock.Any()).
+		mockProductAccessor.EXPECT().getProductVendorPageReferences(ctx, productVendors).
 			Return(nil, errors.New("error"))
 		mockProductAccessor.EXPECT().GetProductVendorsByVendor(ctx, vendorID, spec).
 			Return(accessorResponse, nil)
@@ -176,7 +177,7 @@ func TestProductService_GetProductVendorsByVendor(t *testing.T) {
 		g.Expect(err).ShouldNot(gomega.BeNil())
 	})
 
-	t.Run("returns err on price accessor error", func(t *testing.T) {
+	t.Run("returns err on missing product", func(t *testing.T) {
 		var (
 			g                   = gomega.NewWithT(t)
 			ctx                 = context.Background()
@@ -186,24 +187,23 @@ func TestProductService_GetProductVendorsByVendor(t *testing.T) {
 		)
 
 		svc := &ProductService{
-			mockProductAccessor,
+			productDBAccessor: mockProductAccessor,
+			clock:             clock.NewMock(),
 		}
 
-		mockProductAccessor.EXPECT().getProductByID(ctx, gomock.Any()).
-			Return(&Product{}, nil)
-		mockProductAccessor.EXPECT().getProductCategoryByID(ctx, gomock.Any()).
-			Return(&ProductCategory{}, nil)
-		mockProductAccessor.EXPECT().getPriceByPVID(ctx, gomock.Any()).
-			Return(nil, errors.New("error"))
+		refs := pageReferences(productVendors)
+		delete(refs.Products, "")
+		mockProductAccessor.EXPECT().getProductVendorPageReferences(ctx, productVendors).
+			Return(refs, nil)
 		mockProductAccessor.EXPECT().GetProductVendorsByVendor(ctx, vendorID, spec).
 			Return(accessorResponse, nil)
 
 		res, err := svc.GetProductVendorsByVendor(ctx, vendorID, spec)
 		g.Expect(res).To(gomega.BeNil())
-		g.Expect(err).ShouldNot(gomega.BeNil())
+		g.Expect(errors.Is(err, ErrProductNotFound)).To(gomega.BeTrue())
 	})
 
-	t.Run("returns err on uom accessor error", func(t *testing.T) {
+	t.Run("returns err on missing price uom", func(t *testing.T) {
 		var (
 			g                   = gomega.NewWithT(t)
 			ctx                 = context.Background()
@@ -213,26 +213,23 @@ func TestProductService_GetProductVendorsByVendor(t *testing.T) {
 		)
 
 		svc := &ProductService{
-			mockProductAccessor,
+			productDBAccessor: mockProductAccessor,
+			clock:             clock.NewMock(),
 		}
 
-		mockProductAccessor.EXPECT().getProductByID(ctx, gomock.Any()).
-			Return(&Product{}, nil)
-		mockProductAccessor.EXPECT().getProductCategoryByID(ctx, gomock.Any()).
-			Return(&ProductCategory{}, nil)
-		mockProductAccessor.EXPECT().getPriceByPVID(ctx, gomock.Any()).
-			Return(&Price{}, nil)
-		mockProductAccessor.EXPECT().getUOMByID(ctx, gomock.Any()).
-			Return(nil, errors.New("error"))
+		refs := pageReferences(productVendors)
+		delete(refs.UOMs, "")
+		mockProductAccessor.EXPECT().getProductVendorPageReferences(ctx, productVendors).
+			Return(refs, nil)
 		mockProductAccessor.EXPECT().GetProductVendorsByVendor(ctx, vendorID, spec).
 			Return(accessorResponse, nil)
 
 		res, err := svc.GetProductVendorsByVendor(ctx, vendorID, spec)
 		g.Expect(res).To(gomega.BeNil())
-		g.Expect(err).ShouldNot(gomega.BeNil())
+		g.Expect(errors.Is(err, ErrUOMNotFound)).To(gomega.BeTrue())
 	})
   
-	t.Run("returns err on product category accessor error", func(t *testing.T) {
+	t.Run("returns err on missing product category", func(t *testing.T) {
 		var (
 			g                   = gomega.NewWithT(t)
 			ctx                 = context.Background()
@@ -242,19 +239,20 @@ func TestProductService_GetProductVendorsByVendor(t *testing.T) {
 		)
 
 		svc := &ProductService{
-			mockProductAccessor,
+			productDBAccessor: mockProductAccessor,
+			clock:             clock.NewMock(),
 		}
 
-		mockProductAccessor.EXPECT().getProductByID(ctx, gomock.Any()).
-			Return(&Product{}, nil)
-		mockProductAccessor.EXPECT().getProductCategoryByID(ctx, gomock.Any()).
-			Return(nil, errors.New("error"))
+		refs := pageReferences(productVendors)
+		delete(refs.Categories, "")
+		mockProductAccessor.EXPECT().getProductVendorPageReferences(ctx, productVendors).
+			Return(refs, nil)
 		mockProductAccessor.EXPECT().GetProductVendorsByVendor(ctx, vendorID, spec).
 			Return(accessorResponse, nil)
 
 		res, err := svc.GetProductVendorsByVendor(ctx, vendorID, spec)
 		g.Expect(res).To(gomega.BeNil())
-		g.Expect(err).ShouldNot(gomega.BeNil())
+		g.Expect(errors.Is(err, ErrProductCategoryNotFound)).To(gomega.BeTrue())
 	})
 }
 
@@ -292,7 +290,8 @@ func TestProductService_GetProductVendors(t *testing.T) {
 		)
 
 		svc := &ProductService{
-			mockProductAccessor,
+			productDBAccessor: mockProductAccessor,
+			clock:             clock.NewMock(),
 		}
 
 		accessorResponse := &AccessorGetProductVendorsPaginationData{
@@ -305,14 +304,14 @@ func TestProductService_GetProductVendors(t *testing.T) {
 				{
 					ID:           productVendors[0].ID,
 					Product:      ProductResponse{},
-					Price:        PriceResponse{},
+					Price:        &PriceResponse{},
 					Name:         productVendors[0].Name,
 					ModifiedDate: productVendors[0].ModifiedDate,
 				},
 				{
 					ID:           productVendors[1].ID,
 					Product:      ProductResponse{},
-					Price:        PriceResponse{},
+					Price:        &PriceResponse{},
 					Name:         productVendors[1].Name,
 					ModifiedDate: productVendors[1].ModifiedDate,
 				},
@@ -320,14 +319,8 @@ func TestProductService_GetProductVendors(t *testing.T) {
 			Metadata: metadata,
 		}
 
-		mockProductAccessor.EXPECT().getProductCategoryByID(ctx, gomock.Any()).
-			Return(&ProductCategory{}, nil).AnyTimes()
-		mockProductAccessor.EXPECT().getPriceByPVID(ctx, gomock.Any()).
-			Return(&Price{}, nil).AnyTimes()
-		mockProductAccessor.EXPECT().getProductByID(ctx, gomock.Any()).
-			Return(&Product{}, nil).AnyTimes()
-		mockProductAccessor.EXPECT().getUOMByID(ctx, gomock.Any()).
-			Return(&UOM{}, nil).AnyTimes()
+		mockProductAccessor.EXPECT().getProductVendorPageReferences(ctx, gomock.Any()).
+			Return(pageReferences(productVendors), nil)
 		mockProductAccessor.EXPECT().GetAllProductVendors(ctx, spec).
 			Return(accessorResponse, nil)
 
@@ -354,7 +347,8 @@ func TestProductService_GetProductVendors(t *testing.T) {
 		)
 
 		svc := &ProductService{
-			mockProductAccessor,
+			productDBAccessor: mockProductAccessor,
+			clock:             clock.NewMock(),
 		}
 
 		accessorResponse := &AccessorGetProductVendorsPaginationData{
@@ -367,7 +361,7 @@ func TestProductService_GetProductVendors(t *testing.T) {
 				{
 					ID:           productVendors[0].ID,
 					Product:      ProductResponse{},
-					Price:        PriceResponse{},
+					Price:        &PriceResponse{},
 					Name:         productVendors[0].Name,
 					ModifiedDate: productVendors[0].ModifiedDate,
 				},
@@ -375,14 +369,8 @@ func TestProductService_GetProductVendors(t *testing.T) {
 			Metadata: metadata,
 		}
 		
-		mockProductAccessor.EXPECT().getProductCategoryByID(ctx, gomock.Any()).
-			Return(&ProductCategory{}, nil).AnyTimes()
-		mockProductAccessor.EXPECT().getPriceByPVID(ctx, gomock.Any()).
-			Return(&Price{}, nil).AnyTimes()
-		mockProductAccessor.EXPECT().getProductByID(ctx, gomock.Any()).
-			Return(&Product{}, nil).AnyTimes()
-		mockProductAccessor.EXPECT().getUOMByID(ctx, gomock.Any()).
-			Return(&UOM{}, nil).AnyTimes()
+		mockProductAccessor.EXPECT().getProductVendorPageReferences(ctx, gomock.Any()).
+			Return(pageReferences(productVendors), nil)
 		mockProductAccessor.EXPECT().GetAllProductVendors(ctx, spec).
 			Return(accessorResponse, nil)
 
@@ -413,7 +401,8 @@ func TestProductService_GetProductVendors(t *testing.T) {
 		)
 
 		svc := &ProductService{
-			mockProductAccessor,
+			productDBAccessor: mockProductAccessor,
+			clock:             clock.NewMock(),
 		}
 
 		accessorResponse := &AccessorGetProductVendorsPaginationData{
@@ -426,14 +415,14 @@ func TestProductService_GetProductVendors(t *testing.T) {
 				{
 					ID:           productVendors[0].ID,
 					Product:      ProductResponse{},
-					Price:        PriceResponse{},
+					Price:        &PriceResponse{},
 					Name:         productVendors[0].Name,
 					ModifiedDate: productVendors[0].ModifiedDate,
 				},
 				{
 					ID:           productVendors[1].ID,
 					Product:      ProductResponse{},
-					Price:        PriceResponse{},
+					Price:        &PriceResponse{},
 					Name:         productVendors[1].Name,
 					ModifiedDate: productVendors[1].ModifiedDate,
 				},
@@ -441,14 +430,8 @@ func TestProductService_GetProductVendors(t *testing.T) {
 			Metadata: metadata,
 		}
 
-		mockProductAccessor.EXPECT().getProductCategoryByID(ctx, gomock.Any()).
-			Return(&ProductCategory{}, nil).AnyTimes()
-		mockProductAccessor.EXPECT().getPriceByPVID(ctx, gomock.Any()).
-			Return(&Price{}, nil).AnyTimes()
-		mockProductAccessor.EXPECT().getProductByID(ctx, gomock.Any()).
-			Return(&Product{}, nil).AnyTimes()
-		mockProductAccessor.EXPECT().getUOMByID(ctx, gomock.Any()).
-			Return(&UOM{}, nil).AnyTimes()
+		mockProductAccessor.EXPECT().getProductVendorPageReferences(ctx, gomock.Any()).
+			Return(pageReferences(productVendors), nil)
 		mockProductAccessor.EXPECT().GetAllProductVendors(ctx, spec).
 			Return(accessorResponse, nil)
 
@@ -467,17 +450,10 @@ func TestProductService_GetProductVendors(t *testing.T) {
 		)
 
 		svc := &ProductService{
-			mockProductAccessor,
+			productDBAccessor: mockProductAccessor,
+			clock:             clock.NewMock(),
 		}
 
-		mockProductAccessor.EXPECT().getProductCategoryByID(ctx, gomock.Any()).
-			Return(&ProductCategory{}, nil).AnyTimes()
-		mockProductAccessor.EXPECT().getPriceByPVID(ctx, gomock.Any()).
-			Return(&Price{}, nil).AnyTimes()
-		mockProductAccessor.EXPECT().getProductByID(ctx, gomock.Any()).
-			Return(&Product{}, nil).AnyTimes()
-		mockProductAccessor.EXPECT().getUOMByID(ctx, gomock.Any()).
-			Return(&UOM{}, nil).AnyTimes()
 		mockProductAccessor.EXPECT().GetAllProductVendors(ctx, spec).
 			Return(nil, errors.New("error"))
 
@@ -564,6 +540,7 @@ func TestPriceService_UpdatePrice(t *testing.T) {
 		updatedTime = time.Now()
 		updateSpec  = Price{
 			ID:              priceID,
+			ProductVendorID: "PV1",
 			PurchasingOrgID: "org_id_updated",
 			VendorID:        "vendor_id_updated",
 			Price:           99.99,
@@ -596,9 +573,22 @@ func TestPriceService_UpdatePrice(t *testing.T) {
 			productDBAccessor: mockProductAccessor,
 		}
 
-		mockProductAccessor.EXPECT().UpdatePrice(ctx, updateSpec).Return(updatedPrice, nil)
-
-		res, err := svc.UpdatePrice(ctx, updateSpec)
+		change := PriceChange{ChangedBy: "buyer", Reason: "new quotation"}
+		expectUnitOfWork(mockProductAccessor)
+		mockProductAccessor.EXPECT().lockProductVendor(ctx, "PV1").Return(nil)
+		// its own current version is left out of the overlap check
+		mockProductAccessor.EXPECT().getPricesByPVID(ctx, "PV1").
+			Return([]Price{{ID: priceID, PurchasingOrgID: "org_id_updated", VendorID: "vendor_id_updated", QuantityMin: 1}}, nil)
+		mockProductAccessor.EXPECT().
+			UpdatePrice(ctx, updateSpec, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ Price, recorded PriceChange) (Price, error) {
+				g.Expect(recorded.HistoryID).To(gomega.HaveLen(15))
+				g.Expect(recorded.ChangedBy).To(gomega.Equal(change.ChangedBy))
+				g.Expect(recorded.Reason).To(gomega.Equal(change.Reason))
+				return updatedPrice, nil
+			})
+
+		res, err := svc.UpdatePrice(ctx, updateSpec, change)
 		g.Expect(res).Should(gomega.BeComparableTo(updatedPrice))
 		g.Expect(err).To(gomega.BeNil())
 	})
@@ -615,10 +605,1526 @@ func TestPriceService_UpdatePrice(t *testing.T) {
 			productDBAccessor: mockProductAccessor,
 		}
 
-		mockProductAccessor.EXPECT().UpdatePrice(ctx, updateSpec).Return(Price{}, errors.New("update error"))
+		expectUnitOfWork(mockProductAccessor)
+		mockProductAccessor.EXPECT().lockProductVendor(ctx, "PV1").Return(nil)
+		mockProductAccessor.EXPECT().getPricesByPVID(ctx, "PV1").Return(nil, nil)
+		mockProductAccessor.EXPECT().UpdatePrice(ctx, updateSpec, gomock.Any()).Return(Price{}, errors.New("update error"))
 
-		res, err := svc.UpdatePrice(ctx, updateSpec)
+		res, err := svc.UpdatePrice(ctx, updateSpec, PriceChange{})
 		g.Expect(res).To(gomega.Equal(Price{}))
 		g.Expect(err).ShouldNot(gomega.BeNil())
 	})
+
+	t.Run("returns ErrOverlappingPrice when the tier overlaps another price", func(t *testing.T) {
+		var (
+			g                   = gomega.NewWithT(t)
+			ctx                 = context.Background()
+			mockCtrl            = gomock.NewController(t)
+			mockProductAccessor = NewMockproductDBAccessor(mockCtrl)
+		)
+
+		svc := &ProductService{
+			productDBAccessor: mockProductAccessor,
+		}
+
+		expectUnitOfWork(mockProductAccessor)
+		mockProductAccessor.EXPECT().lockProductVendor(ctx, "PV1").Return(nil)
+		mockProductAccessor.EXPECT().getPricesByPVID(ctx, "PV1").
+			Return([]Price{{ID: "P0", PurchasingOrgID: "org_id_updated", VendorID: "vendor_id_updated", QuantityMin: 50}}, nil)
+
+		res, err := svc.UpdatePrice(ctx, updateSpec, PriceChange{})
+		g.Expect(errors.Is(err, ErrOverlappingPrice)).To(gomega.BeTrue())
+		g.Expect(err.Error()).To(gomega.ContainSubstring("P0"))
+		g.Expect(res).To(gomega.Equal(Price{}))
+	})
+}
+
+func TestProductService_UpdatePriceAnomalies(t *testing.T) {
+	t.Parallel()
+
+	var (
+		now      = time.Date(2024, time.December, 10, 8, 0, 0, 0, time.UTC)
+		previous = Price{ID: "P1", ProductVendorID: "PV1", VendorID: "V1", Price: 1000, CurrencyID: "C1", PriceUOMID: "PCS", ModifiedDate: now.Add(-time.Hour)}
+		typo     = Price{ID: "P1", ProductVendorID: "PV1", VendorID: "V1", Price: 10000, CurrencyID: "C1", PriceUOMID: "PCS"}
+		refs     = &priceImportReferences{Prices: map[string]Price{"P1": previous}}
+		change   = PriceChange{ChangedBy: "buyer", Reason: "new quotation"}
+	)
+
+	setup := func(t *testing.T, mode string) (*gomega.GomegaWithT, *MockproductDBAccessor, *ProductService) {
+		mockCtrl := gomock.NewController(t)
+		mockProductAccessor := NewMockproductDBAccessor(mockCtrl)
+		mockClock := clock.NewMock()
+		mockClock.Set(now)
+
+		svc := &ProductService{
+			productDBAccessor: mockProductAccessor,
+			anomalyCfg:        config.PriceAnomalyConfig{Mode: mode, MaxHistoryRatio: 5},
+			clock:             mockClock,
+		}
+		expectUnitOfWork(mockProductAccessor)
+		return gomega.NewWithT(t), mockProductAccessor, svc
+	}
+	expectLookup := func(ctx context.Context, m *MockproductDBAccessor) {
+		m.EXPECT().getPriceImportReferences(ctx, priceImportKeys{PriceIDs: []string{"P1"}}).Return(refs, nil)
+	}
+	expectOverlapCheck := func(ctx context.Context, m *MockproductDBAccessor) {
+		m.EXPECT().lockProductVendor(ctx, "PV1").Return(nil)
+		m.EXPECT().getPricesByPVID(ctx, "PV1").Return([]Price{previous}, nil)
+	}
+
+	t.Run("applies a price within the thresholds", func(t *testing.T) {
+		g, m, svc := setup(t, PriceAnomalyModeBlock)
+		ctx := context.Background()
+
+		within := typo
+		within.Price = 1200
+		expectLookup(ctx, m)
+		expectOverlapCheck(ctx, m)
+		m.EXPECT().UpdatePrice(ctx, within, gomock.Any()).Return(within, nil)
+
+		res, err := svc.UpdatePrice(ctx, within, change)
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(res).To(gomega.Equal(within))
+	})
+
+	t.Run("blocks and records an anomaly", func(t *testing.T) {
+		g, m, svc := setup(t, PriceAnomalyModeBlock)
+		ctx := context.Background()
+
+		expectLookup(ctx, m)
+		m.EXPECT().WritePriceAnomalies(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, anomalies []PriceAnomaly) error {
+				g.Expect(anomalies).To(gomega.HaveLen(1))
+				g.Expect(anomalies[0].ID).To(gomega.HaveLen(15))
+				g.Expect(anomalies[0].Status).To(gomega.Equal(PriceAnomalyStatusBlocked.String()))
+				g.Expect(anomalies[0].Source).To(gomega.Equal(PriceAnomalySourceUpdate))
+				g.Expect(anomalies[0].RequestedBy).To(gomega.Equal("buyer"))
+				g.Expect(anomalies[0].ModifiedDate).To(gomega.Equal(now))
+				g.Expect(anomalies[0].Proposed).To(gomega.BeNil())
+				return nil
+			})
+
+		res, err := svc.UpdatePrice(ctx, typo, change)
+		g.Expect(errors.Is(err, ErrPriceAnomaly)).To(gomega.BeTrue())
+		g.Expect(res).To(gomega.Equal(Price{}))
+	})
+
+	t.Run("asks for confirmation without recording it", func(t *testing.T) {
+		g, m, svc := setup(t, PriceAnomalyModeConfirm)
+		ctx := context.Background()
+
+		expectLookup(ctx, m)
+
+		_, err := svc.UpdatePrice(ctx, typo, change)
+		var anomalyErr *PriceAnomalyError
+		g.Expect(errors.As(err, &anomalyErr)).To(gomega.BeTrue())
+		g.Expect(*anomalyErr.Anomaly.HistoryRatio).To(gomega.Equal(10.0))
+	})
+
+	t.Run("applies and records a confirmed anomaly", func(t *testing.T) {
+		g, m, svc := setup(t, PriceAnomalyModeConfirm)
+		ctx := context.Background()
+
+		confirmed := change
+		confirmed.Confirmed = true
+		expectLookup(ctx, m)
+		expectOverlapCheck(ctx, m)
+		gomock.InOrder(
+			m.EXPECT().UpdatePrice(ctx, typo, gomock.Any()).Return(typo, nil),
+			m.EXPECT().WritePriceAnomalies(ctx, gomock.Any()).
+				DoAndReturn(func(_ context.Context, anomalies []PriceAnomaly) error {
+					g.Expect(anomalies[0].Status).To(gomega.Equal(PriceAnomalyStatusConfirmed.String()))
+					return nil
+				}),
+		)
+
+		res, err := svc.UpdatePrice(ctx, typo, confirmed)
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(res).To(gomega.Equal(typo))
+	})
+
+	t.Run("fails the update when the confirmed anomaly isn't recorded", func(t *testing.T) {
+		g, m, svc := setup(t, PriceAnomalyModeConfirm)
+		ctx := context.Background()
+
+		confirmed := change
+		confirmed.Confirmed = true
+		expectLookup(ctx, m)
+		expectOverlapCheck(ctx, m)
+		gomock.InOrder(
+			m.EXPECT().UpdatePrice(ctx, typo, gomock.Any()).Return(typo, nil),
+			m.EXPECT().WritePriceAnomalies(ctx, gomock.Any()).Return(errors.New("error")),
+		)
+
+		res, err := svc.UpdatePrice(ctx, typo, confirmed)
+		g.Expect(err).ToNot(gomega.BeNil())
+		g.Expect(res).To(gomega.Equal(Price{}))
+	})
+
+	t.Run("holds an anomaly for approval", func(t *testing.T) {
+		g, m, svc := setup(t, PriceAnomalyModeApproval)
+		ctx := context.Background()
+
+		expectLookup(ctx, m)
+		m.EXPECT().WritePriceAnomalies(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, anomalies []PriceAnomaly) error {
+				g.Expect(anomalies[0].Status).To(gomega.Equal(PriceAnomalyStatusPending.String()))
+				// the proposal keeps the modified date of the price it was made against
+				g.Expect(anomalies[0].Proposed).To(gomega.Equal(&PriceProposal{
+					ID: "P1", ProductVendorID: "PV1", VendorID: "V1", Price: 10000, CurrencyID: "C1", PriceUOMID: "PCS",
+					ModifiedDate: now.Add(-time.Hour),
+				}))
+				return nil
+			})
+
+		_, err := svc.UpdatePrice(ctx, typo, change)
+		var anomalyErr *PriceAnomalyError
+		g.Expect(errors.As(err, &anomalyErr)).To(gomega.BeTrue())
+		g.Expect(anomalyErr.Anomaly.Status).To(gomega.Equal(PriceAnomalyStatusPending.String()))
+	})
+
+	t.Run("unknown price", func(t *testing.T) {
+		g, m, svc := setup(t, PriceAnomalyModeBlock)
+		ctx := context.Background()
+
+		m.EXPECT().getPriceImportReferences(ctx, gomock.Any()).Return(&priceImportReferences{Prices: map[string]Price{}}, nil)
+
+		_, err := svc.UpdatePrice(ctx, typo, change)
+		g.Expect(errors.Is(err, ErrPriceNotFound)).To(gomega.BeTrue())
+	})
+
+	t.Run("compares against other vendors", func(t *testing.T) {
+		g, m, svc := setup(t, PriceAnomalyModeBlock)
+		ctx := context.Background()
+
+		svc.anomalyCfg = config.PriceAnomalyConfig{Mode: PriceAnomalyModeBlock, MaxPeerRatio: 3, MinPeers: 1}
+		expectLookup(ctx, m)
+		m.EXPECT().getPeerPrices(ctx, []string{"PV1"}, now).Return(map[string][]Price{
+			"PV1": {{ID: "P2", VendorID: "V2", Price: 1000, CurrencyID: "C1", PriceUOMID: "PCS"}},
+		}, nil)
+		m.EXPECT().WritePriceAnomalies(ctx, gomock.Any()).Return(nil)
+
+		_, err := svc.UpdatePrice(ctx, typo, change)
+		var anomalyErr *PriceAnomalyError
+		g.Expect(errors.As(err, &anomalyErr)).To(gomega.BeTrue())
+		g.Expect(anomalyErr.Anomaly.PeerCount).To(gomega.Equal(1))
+	})
+}
+
+func TestProductService_ReviewPriceAnomaly(t *testing.T) {
+	t.Parallel()
+
+	var (
+		now      = time.Date(2024, time.December, 10, 9, 0, 0, 0, time.UTC)
+		basedOn  = time.Date(2024, time.December, 1, 0, 0, 0, 0, time.UTC)
+		current  = Price{ID: "P1", ProductVendorID: "PV1", Price: 1000, ModifiedDate: basedOn}
+		proposed = PriceProposal(Price{ID: "P1", ProductVendorID: "PV1", Price: 10000, ModifiedDate: basedOn})
+		pending  = PriceAnomaly{
+			ID:          "A1",
+			PriceID:     "P1",
+			Status:      PriceAnomalyStatusPending.String(),
+			Proposed:    &proposed,
+			RequestedBy: "buyer",
+			Reason:      "new quotation",
+		}
+	)
+
+	setup := func(t *testing.T) (*gomega.GomegaWithT, *MockproductDBAccessor, *ProductService) {
+		mockCtrl := gomock.NewController(t)
+		mockProductAccessor := NewMockproductDBAccessor(mockCtrl)
+		mockClock := clock.NewMock()
+		mockClock.Set(now)
+
+		svc := &ProductService{
+			productDBAccessor: mockProductAccessor,
+			clock:             mockClock,
+		}
+		expectUnitOfWork(mockProductAccessor)
+		return gomega.NewWithT(t), mockProductAccessor, svc
+	}
+
+	t.Run("approving applies the proposed price", func(t *testing.T) {
+		g, m, svc := setup(t)
+		ctx := context.Background()
+
+		anomaly := pending
+		m.EXPECT().lockPriceAnomaly(ctx, "A1").Return(&anomaly, nil)
+		m.EXPECT().lockProductVendor(ctx, "PV1").Return(nil)
+		m.EXPECT().getPriceImportReferences(ctx, priceImportKeys{PriceIDs: []string{"P1"}}).
+			Return(&priceImportReferences{Prices: map[string]Price{"P1": current}}, nil)
+		m.EXPECT().getPricesByPVID(ctx, "PV1").Return([]Price{current}, nil)
+		m.EXPECT().UpdatePrice(ctx, Price(proposed), gomock.Any()).
+			DoAndReturn(func(_ context.Context, price Price, change PriceChange) (Price, error) {
+				g.Expect(change.HistoryID).To(gomega.HaveLen(15))
+				g.Expect(change.ChangedBy).To(gomega.Equal("buyer"))
+				g.Expect(change.Reason).To(gomega.Equal("new quotation"))
+				return price, nil
+			})
+		m.EXPECT().UpdatePriceAnomalyReview(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, reviewed PriceAnomaly) error {
+				g.Expect(reviewed.Status).To(gomega.Equal(PriceAnomalyStatusApproved.String()))
+				g.Expect(reviewed.ReviewedBy).To(gomega.Equal("manager"))
+				g.Expect(*reviewed.ReviewedDate).To(gomega.Equal(now))
+				return nil
+			})
+
+		res, err := svc.ReviewPriceAnomaly(ctx, "A1", ReviewPriceAnomalySpec{Approved: true, ReviewedBy: "manager"})
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(res.Status).To(gomega.Equal(PriceAnomalyStatusApproved.String()))
+	})
+
+	t.Run("returns ErrPriceAnomalyStale when the price changed since the proposal", func(t *testing.T) {
+		g, m, svc := setup(t)
+		ctx := context.Background()
+
+		anomaly := pending
+		edited := current
+		edited.ModifiedDate = basedOn.Add(time.Hour)
+		m.EXPECT().lockPriceAnomaly(ctx, "A1").Return(&anomaly, nil)
+		m.EXPECT().lockProductVendor(ctx, "PV1").Return(nil)
+		m.EXPECT().getPriceImportReferences(ctx, priceImportKeys{PriceIDs: []string{"P1"}}).
+			Return(&priceImportReferences{Prices: map[string]Price{"P1": edited}}, nil)
+
+		res, err := svc.ReviewPriceAnomaly(ctx, "A1", ReviewPriceAnomalySpec{Approved: true, ReviewedBy: "manager"})
+		g.Expect(errors.Is(err, ErrPriceAnomalyStale)).To(gomega.BeTrue())
+		g.Expect(res).To(gomega.BeNil())
+	})
+
+	t.Run("returns ErrPriceAnomalyNotPending when reviewed concurrently", func(t *testing.T) {
+		g, m, svc := setup(t)
+		ctx := context.Background()
+
+		anomaly := pending
+		m.EXPECT().lockPriceAnomaly(ctx, "A1").Return(&anomaly, nil)
+		m.EXPECT().UpdatePriceAnomalyReview(ctx, gomock.Any()).Return(ErrPriceAnomalyNotPending)
+
+		res, err := svc.ReviewPriceAnomaly(ctx, "A1", ReviewPriceAnomalySpec{ReviewedBy: "manager"})
+		g.Expect(errors.Is(err, ErrPriceAnomalyNotPending)).To(gomega.BeTrue())
+		g.Expect(res).To(gomega.BeNil())
+	})
+
+	t.Run("rejecting leaves the price untouched", func(t *testing.T) {
+		g, m, svc := setup(t)
+		ctx := context.Background()
+
+		anomaly := pending
+		m.EXPECT().lockPriceAnomaly(ctx, "A1").Return(&anomaly, nil)
+		m.EXPECT().UpdatePriceAnomalyReview(ctx, gomock.Any()).Return(nil)
+
+		res, err := svc.ReviewPriceAnomaly(ctx, "A1", ReviewPriceAnomalySpec{ReviewedBy: "manager"})
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(res.Status).To(gomega.Equal(PriceAnomalyStatusRejected.String()))
+	})
+
+	t.Run("rejects a review by the requester", func(t *testing.T) {
+		g, m, svc := setup(t)
+		ctx := context.Background()
+
+		anomaly := pending
+		m.EXPECT().lockPriceAnomaly(ctx, "A1").Return(&anomaly, nil)
+
+		res, err := svc.ReviewPriceAnomaly(ctx, "A1", ReviewPriceAnomalySpec{Approved: true, ReviewedBy: "buyer"})
+		g.Expect(errors.Is(err, ErrPriceAnomalySelfApproval)).To(gomega.BeTrue())
+		g.Expect(res).To(gomega.BeNil())
+	})
+
+	t.Run("rejects an anomaly that isn't pending", func(t *testing.T) {
+		g, m, svc := setup(t)
+		ctx := context.Background()
+
+		blocked := pending
+		blocked.Status = PriceAnomalyStatusBlocked.String()
+		m.EXPECT().lockPriceAnomaly(ctx, "A1").Return(&blocked, nil)
+
+		res, err := svc.ReviewPriceAnomaly(ctx, "A1", ReviewPriceAnomalySpec{Approved: true, ReviewedBy: "manager"})
+		g.Expect(errors.Is(err, ErrPriceAnomalyNotPending)).To(gomega.BeTrue())
+		g.Expect(res).To(gomega.BeNil())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		g, m, svc := setup(t)
+		ctx := context.Background()
+
+		m.EXPECT().lockPriceAnomaly(ctx, "A1").Return(nil, sql.ErrNoRows)
+
+		res, err := svc.ReviewPriceAnomaly(ctx, "A1", ReviewPriceAnomalySpec{ReviewedBy: "manager"})
+		g.Expect(errors.Is(err, ErrPriceAnomalyNotFound)).To(gomega.BeTrue())
+		g.Expect(res).To(gomega.BeNil())
+	})
+}
+
+func TestProductService_GetPriceAnomalies(t *testing.T) {
+	t.Parallel()
+
+	t.Run("rejects an unknown status", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		svc := &ProductService{productDBAccessor: NewMockproductDBAccessor(gomock.NewController(t))}
+
+		res, err := svc.GetPriceAnomalies(context.Background(), GetPriceAnomaliesSpec{Status: "unknown"})
+		g.Expect(errors.Is(err, ErrInvalidPriceAnomalyStatus)).To(gomega.BeTrue())
+		g.Expect(res).To(gomega.BeNil())
+	})
+}
+
+func TestProductService_GetPriceHistory(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		var (
+			g                   = gomega.NewWithT(t)
+			ctx                 = context.Background()
+			mockCtrl            = gomock.NewController(t)
+			mockProductAccessor = NewMockproductDBAccessor(mockCtrl)
+		)
+
+		svc := &ProductService{
+			productDBAccessor: mockProductAccessor,
+		}
+
+		spec := GetPriceHistorySpec{PaginationSpec: database.PaginationSpec{Limit: 10, Page: 1}}
+		expected := &AccessorGetPriceHistoryPaginationData{
+			PriceHistory: []PriceHistory{{ID: "1", PriceID: "P1", NewPrice: 100}},
+		}
+		mockProductAccessor.EXPECT().GetPriceHistory(ctx, "PV1", spec).Return(expected, nil)
+
+		res, err := svc.GetPriceHistory(ctx, "PV1", spec)
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(res).To(gomega.Equal(expected))
+	})
+}
+
+func TestProductService_GetEffectivePrices(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		var (
+			g                   = gomega.NewWithT(t)
+			ctx                 = context.Background()
+			mockCtrl            = gomock.NewController(t)
+			mockProductAccessor = NewMockproductDBAccessor(mockCtrl)
+			at                  = time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+		)
+
+		svc := &ProductService{
+			productDBAccessor: mockProductAccessor,
+		}
+
+		expected := []PriceHistory{{ID: "1", PriceID: "P1", NewPrice: 100}}
+		mockProductAccessor.EXPECT().GetEffectivePrices(ctx, "PV1", at).Return(expected, nil)
+
+		res, err := svc.GetEffectivePrices(ctx, "PV1", at)
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(res).To(gomega.Equal(expected))
+	})
+}
+
+func TestProductService_GetProductVendorsWithoutApplicablePrice(t *testing.T) {
+	t.Parallel()
+
+	var (
+		g                   = gomega.NewWithT(t)
+		ctx                 = context.Background()
+		mockCtrl            = gomock.NewController(t)
+		mockProductAccessor = NewMockproductDBAccessor(mockCtrl)
+		spec                = GetProductVendorsSpec{PriceContext: PriceContext{Quantity: 5}}
+		accessorResponse    = &AccessorGetProductVendorsPaginationData{
+			ProductVendors: []ProductVendor{{ID: "1111", Name: "Mixer"}},
+		}
+	)
+
+	svc := &ProductService{
+		productDBAccessor: mockProductAccessor,
+		clock:             clock.NewMock(),
+	}
+
+	mockProductAccessor.EXPECT().GetAllProductVendors(ctx, spec).
+		Return(accessorResponse, nil)
+	refs := pageReferences(accessorResponse.ProductVendors)
+	refs.Prices["1111"] = []Price{{ID: "P1", QuantityMin: 10}}
+	mockProductAccessor.EXPECT().getProductVendorPageReferences(ctx, accessorResponse.ProductVendors).
+		Return(refs, nil)
+
+	res, err := svc.GetProductVendors(ctx, spec)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(res.ProductVendors).To(gomega.HaveLen(1))
+	g.Expect(res.ProductVendors[0].Price).To(gomega.BeNil())
+}
+
+func TestProductService_ResolvePrice(t *testing.T) {
+	t.Parallel()
+
+	var (
+		now       = time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+		lastMonth = now.AddDate(0, -1, 0)
+		prices    = []Price{
+			{ID: "expired", Price: 90, ValidFrom: now.AddDate(-1, 0, 0), ValidTo: &lastMonth},
+			{ID: "current", Price: 100, ValidFrom: now.AddDate(0, -1, 0)},
+		}
+	)
+
+	t.Run("defaults the date to now", func(t *testing.T) {
+		var (
+			g                   = gomega.NewWithT(t)
+			ctx                 = context.Background()
+			mockCtrl            = gomock.NewController(t)
+			mockProductAccessor = NewMockproductDBAccessor(mockCtrl)
+			mockClock           = clock.NewMock()
+		)
+		mockClock.Set(now)
+
+		svc := &ProductService{
+			productDBAccessor: mockProductAccessor,
+			clock:             mockClock,
+		}
+
+		mockProductAccessor.EXPECT().getPricesByPVID(ctx, "PV1").Return(prices, nil)
+
+		res, err := svc.ResolvePrice(ctx, "PV1", PriceContext{})
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(res.ID).To(gomega.Equal("current"))
+	})
+
+	t.Run("returns ErrNoApplicablePrice when none applies", func(t *testing.T) {
+		var (
+			g                   = gomega.NewWithT(t)
+			ctx                 = context.Background()
+			mockCtrl            = gomock.NewController(t)
+			mockProductAccessor = NewMockproductDBAccessor(mockCtrl)
+		)
+
+		svc := &ProductService{
+			productDBAccessor: mockProductAccessor,
+			clock:             clock.NewMock(),
+		}
+
+		mockProductAccessor.EXPECT().getPricesByPVID(ctx, "PV1").Return(prices, nil)
+
+		res, err := svc.ResolvePrice(ctx, "PV1", PriceContext{Date: now.AddDate(-2, 0, 0)})
+		g.Expect(err).To(gomega.MatchError(ErrNoApplicablePrice))
+		g.Expect(res).To(gomega.BeNil())
+	})
+
+	t.Run("returns error on accessor failure", func(t *testing.T) {
+		var (
+			g                   = gomega.NewWithT(t)
+			ctx                 = context.Background()
+			mockCtrl            = gomock.NewController(t)
+			mockProductAccessor = NewMockproductDBAccessor(mockCtrl)
+		)
+
+		svc := &ProductService{
+			productDBAccessor: mockProductAccessor,
+			clock:             clock.NewMock(),
+		}
+
+		mockProductAccessor.EXPECT().getPricesByPVID(ctx, "PV1").Return(nil, errors.New("error"))
+
+		res, err := svc.ResolvePrice(ctx, "PV1", PriceContext{})
+		g.Expect(err).ToNot(gomega.BeNil())
+		g.Expect(res).To(gomega.BeNil())
+	})
+}
+
+func TestProductService_GetProductVendorsConvertsPrices(t *testing.T) {
+	t.Parallel()
+
+	var (
+		rateDate       = time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+		productVendors = []ProductVendor{{ID: "1111"}, {ID: "2222"}, {ID: "3333"}}
+		prices         = map[string][]Price{
+			"1111": {{ID: "P1", Price: 10, CurrencyCode: "USD"}},
+			"2222": {{ID: "P2", Price: 20, CurrencyCode: "USD"}},
+			"3333": {{ID: "P3", Price: 30, CurrencyCode: "EUR"}},
+		}
+	)
+
+	setup := func(t *testing.T) (*gomega.GomegaWithT, *MockproductDBAccessor, *MockcurrencyConverter, *ProductService) {
+		mockCtrl := gomock.NewController(t)
+		mockProductAccessor := NewMockproductDBAccessor(mockCtrl)
+		mockConverter := NewMockcurrencyConverter(mockCtrl)
+
+		refs := pageReferences(productVendors)
+		refs.Prices = prices
+		mockProductAccessor.EXPECT().getProductVendorPageReferences(gomock.Any(), productVendors).
+			Return(refs, nil).AnyTimes()
+
+		svc := &ProductService{
+			productDBAccessor: mockProductAccessor,
+			currencySvc:       mockConverter,
+			clock:             clock.NewMock(),
+		}
+		return gomega.NewWithT(t), mockProductAccessor, mockConverter, svc
+	}
+
+	t.Run("converts with one rate lookup per currency", func(t *testing.T) {
+		g, mockProductAccessor, mockConverter, svc := setup(t)
+		ctx := context.Background()
+		spec := GetProductVendorsSpec{PriceContext: PriceContext{Date: rateDate}}
+
+		mockProductAccessor.EXPECT().GetAllProductVendors(ctx, spec).
+			Return(&AccessorGetProductVendorsPaginationData{ProductVendors: productVendors}, nil)
+		mockConverter.EXPECT().GetBaseRate(ctx, "USD", rateDate).
+			Return(&currency.ExchangeRate{FromCurrency: "USD", ToCurrency: "IDR", Rate: 15000, RateDate: rateDate}, nil).
+			Times(1)
+		mockConverter.EXPECT().GetBaseRate(ctx, "EUR", rateDate).
+			Return(nil, currency.ErrRateNotFound).
+			Times(1)
+
+		res, err := svc.GetProductVendors(ctx, spec)
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(res.ProductVendors).To(gomega.HaveLen(3))
+
+		g.Expect(res.ProductVendors[0].Price.Price).To(gomega.Equal(10.0))
+		g.Expect(res.ProductVendors[0].Price.CurrencyCode).To(gomega.Equal("USD"))
+		g.Expect(res.ProductVendors[0].Price.Converted).To(gomega.Equal(&ConvertedPriceResponse{
+			Price:        150000,
+			CurrencyCode: "IDR",
+			ExchangeRate: 15000,
+			RateDate:     rateDate,
+		}))
+		g.Expect(res.ProductVendors[1].Price.Converted.Price).To(gomega.Equal(300000.0))
+		g.Expect(res.ProductVendors[2].Price.Price).To(gomega.Equal(30.0))
+		g.Expect(res.ProductVendors[2].Price.Converted).To(gomega.BeNil())
+	})
+
+	t.Run("returns error on converter failure", func(t *testing.T) {
+		g, mockProductAccessor, mockConverter, svc := setup(t)
+		ctx := context.Background()
+		spec := GetProductVendorsSpec{PriceContext: PriceContext{Date: rateDate}}
+
+		mockProductAccessor.EXPECT().GetAllProductVendors(ctx, spec).
+			Return(&AccessorGetProductVendorsPaginationData{ProductVendors: productVendors}, nil)
+		mockConverter.EXPECT().GetBaseRate(ctx, "USD", rateDate).
+			Return(nil, errors.New("error"))
+
+		res, err := svc.GetProductVendors(ctx, spec)
+		g.Expect(err).ToNot(gomega.BeNil())
+		g.Expect(res).To(gomega.BeNil())
+	})
+}
+
+func TestProductService_GetProductVendorsNormalisesUnitPrices(t *testing.T) {
+	t.Parallel()
+
+	var (
+		rateDate       = time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+		productVendors = []ProductVendor{{ID: "PV1", ProductID: "PR1"}, {ID: "PV2", ProductID: "PR1"}, {ID: "PV3", ProductID: "PR1"}}
+		prices         = map[string][]Price{
+			"PV1": {{ID: "P1", Price: 120000, CurrencyCode: "IDR", PriceQuantity: 1, PriceUOMID: "BOX"}},
+			"PV2": {{ID: "P2", Price: 9000, CurrencyCode: "IDR", PriceQuantity: 1, PriceUOMID: "PCS"}},
+			"PV3": {{ID: "P3", Price: 50000, CurrencyCode: "IDR", PriceQuantity: 1, PriceUOMID: "KG"}},
+		}
+		units = []uom.Unit{
+			{ID: "PCS", Dimension: "count"},
+			{ID: "BOX", Dimension: "count"},
+			{ID: "KG", Dimension: "mass"},
+		}
+	)
+
+	setup := func(t *testing.T) (*gomega.GomegaWithT, *MockproductDBAccessor, *MockuomConverter, *ProductService) {
+		mockCtrl := gomock.NewController(t)
+		mockProductAccessor := NewMockproductDBAccessor(mockCtrl)
+		mockConverter := NewMockcurrencyConverter(mockCtrl)
+		mockUOMConverter := NewMockuomConverter(mockCtrl)
+
+		mockProductAccessor.EXPECT().getProductVendorPageReferences(gomock.Any(), productVendors).
+			Return(&productVendorPageReferences{
+				Products:   map[string]Product{"PR1": {ID: "PR1", UOMID: "PCS"}},
+				Categories: map[string]ProductCategory{"": {}},
+				Prices:     prices,
+				UOMs:       map[string]UOM{"BOX": {ID: "BOX"}, "PCS": {ID: "PCS"}, "KG": {ID: "KG"}},
+			}, nil).AnyTimes()
+		mockConverter.EXPECT().GetBaseRate(gomock.Any(), "IDR", rateDate).
+			Return(&currency.ExchangeRate{FromCurrency: "IDR", ToCurrency: "IDR", Rate: 1, RateDate: rateDate}, nil).AnyTimes()
+
+		svc := &ProductService{
+			productDBAccessor: mockProductAccessor,
+			currencySvc:       mockConverter,
+			uomSvc:            mockUOMConverter,
+			clock:             clock.NewMock(),
+		}
+		return gomega.NewWithT(t), mockProductAccessor, mockUOMConverter, svc
+	}
+
+	t.Run("prices per box and per piece compare per piece", func(t *testing.T) {
+		g, mockProductAccessor, mockUOMConverter, svc := setup(t)
+		ctx := context.Background()
+		spec := GetProductVendorsSpec{PriceContext: PriceContext{Date: rateDate}}
+
+		mockProductAccessor.EXPECT().GetAllProductVendors(ctx, spec).
+			Return(&AccessorGetProductVendorsPaginationData{ProductVendors: productVendors}, nil)
+		mockUOMConverter.EXPECT().ConversionTables(ctx, []string{"PR1"}).
+			Return(map[string]*uom.ConversionTable{
+				"PR1": uom.NewConversionTable(units, []uom.ProductConversion{
+					{ProductID: "PR1", FromUOMID: "BOX", ToUOMID: "PCS", Factor: 12},
+				}),
+			}, nil).
+			Times(1)
+
+		res, err := svc.GetProductVendors(ctx, spec)
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(res.ProductVendors).To(gomega.HaveLen(3))
+
+		boxPrice := 10000.0
+		g.Expect(res.ProductVendors[0].Price.UnitPrice).To(gomega.Equal(&UnitPriceResponse{
+			Price:          10000,
+			UOMID:          "PCS",
+			ConvertedPrice: &boxPrice,
+		}))
+		g.Expect(res.ProductVendors[1].Price.UnitPrice.Price).To(gomega.Equal(9000.0))
+		// a kilogram doesn't convert into pieces
+		g.Expect(res.ProductVendors[2].Price.UnitPrice).To(gomega.BeNil())
+	})
+
+	t.Run("returns error on conversion tables failure", func(t *testing.T) {
+		g, mockProductAccessor, mockUOMConverter, svc := setup(t)
+		ctx := context.Background()
+		spec := GetProductVendorsSpec{PriceContext: PriceContext{Date: rateDate}}
+
+		mockProductAccessor.EXPECT().GetAllProductVendors(ctx, spec).
+			Return(&AccessorGetProductVendorsPaginationData{ProductVendors: productVendors}, nil)
+		mockUOMConverter.EXPECT().ConversionTables(ctx, []string{"PR1"}).Return(nil, errors.New("error"))
+
+		res, err := svc.GetProductVendors(ctx, spec)
+		g.Expect(err).ToNot(gomega.BeNil())
+		g.Expect(res).To(gomega.BeNil())
+	})
+}
+
+func TestProductService_ExportProductVendors(t *testing.T) {
+	t.Parallel()
+
+	rateDate := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	setup := func(t *testing.T) (*gomega.GomegaWithT, *MockproductDBAccessor, *MockcurrencyConverter, *ProductService) {
+		mockCtrl := gomock.NewController(t)
+		mockProductAccessor := NewMockproductDBAccessor(mockCtrl)
+		mockConverter := NewMockcurrencyConverter(mockCtrl)
+		mockUOMConverter := NewMockuomConverter(mockCtrl)
+
+		mockUOMConverter.EXPECT().ConversionTables(gomock.Any(), []string{"PR1"}).
+			Return(map[string]*uom.ConversionTable{"PR1": uom.NewConversionTable(nil, nil)}, nil).AnyTimes()
+
+		svc := &ProductService{
+			productDBAccessor: mockProductAccessor,
+			currencySvc:       mockConverter,
+			uomSvc:            mockUOMConverter,
+			clock:             clock.NewMock(),
+		}
+		return gomega.NewWithT(t), mockProductAccessor, mockConverter, svc
+	}
+
+	t.Run("writes the product vendors with their price", func(t *testing.T) {
+		g, mockProductAccessor, mockConverter, svc := setup(t)
+		ctx := context.Background()
+
+		mockProductAccessor.EXPECT().GetAllProductVendors(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, spec GetProductVendorsSpec) (*AccessorGetProductVendorsPaginationData, error) {
+				g.Expect(spec.Name).To(gomega.Equal("pen"))
+				g.Expect(spec.IsCursor()).To(gomega.BeTrue())
+				g.Expect(spec.Limit).To(gomega.Equal(database.ExportBatchSize))
+				return &AccessorGetProductVendorsPaginationData{
+					ProductVendors: []ProductVendor{{ID: "PV1", Name: "Pen", ProductID: "PR1"}, {ID: "PV2", Name: "Ink", ProductID: "PR1"}},
+				}, nil
+			})
+		mockProductAccessor.EXPECT().getProductVendorPageReferences(ctx, gomock.Any()).
+			Return(&productVendorPageReferences{
+				Products:   map[string]Product{"PR1": {ID: "PR1", Name: "Pen", UOMID: "U1", ProductCategoryID: "C1"}},
+				Categories: map[string]ProductCategory{"C1": {ID: "C1", Name: "Office"}},
+				Prices: map[string][]Price{
+					"PV1": {{ID: "P1", VendorID: "V1", Price: 2, CurrencyCode: "USD", PriceQuantity: 1, PriceUOMID: "U1"}},
+				},
+				UOMs: map[string]UOM{"U1": {ID: "U1", Name: "pcs"}},
+			}, nil)
+		mockConverter.EXPECT().GetBaseRate(ctx, "USD", rateDate).
+			Return(&currency.ExchangeRate{FromCurrency: "USD", ToCurrency: "IDR", Rate: 15000, RateDate: rateDate}, nil)
+
+		var buffer bytes.Buffer
+		writer, err := spreadsheet.NewWriter(&buffer, spreadsheet.FormatCSV, "Product vendors")
+		g.Expect(err).To(gomega.BeNil())
+
+		err = svc.ExportProductVendors(ctx, GetProductVendorsSpec{Name: "pen", PriceContext: PriceContext{Date: rateDate}}, writer)
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(writer.Close()).To(gomega.Succeed())
+		g.Expect(buffer.String()).To(gomega.Equal(
+			"id,code,name,product_id,product_name,product_category,sap_code,uom_id,income_tax_name,income_tax_percentage," +
+				"price_id,vendor_id,price,currency_code,price_quantity,price_uom,base_price,base_currency,exchange_rate,rate_date," +
+				"unit_price,unit_uom_id,base_unit_price,modified_date,modified_by\n" +
+				"PV1,,Pen,PR1,Pen,Office,,,,,P1,V1,2,USD,1,pcs,30000,IDR,15000,2024-03-01T00:00:00Z,2,U1,30000,,\n" +
+				"PV2,,Ink,PR1,Pen,Office,,,,,,,,,,,,,,,,,,,\n"))
+	})
+
+	t.Run("returns error on accessor failure", func(t *testing.T) {
+		g, mockProductAccessor, _, svc := setup(t)
+		ctx := context.Background()
+
+		mockProductAccessor.EXPECT().GetAllProductVendors(ctx, gomock.Any()).Return(nil, errors.New("error"))
+
+		writer, _ := spreadsheet.NewWriter(&bytes.Buffer{}, spreadsheet.FormatCSV, "Product vendors")
+		err := svc.ExportProductVendors(ctx, GetProductVendorsSpec{}, writer)
+		g.Expect(err).ToNot(gomega.BeNil())
+	})
+}
+
+func TestProductService_ExportPriceComparison(t *testing.T) {
+	t.Parallel()
+
+	var (
+		rateDate = time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+		products = map[ProductID]*Product{
+			"PR1": {ID: "PR1", Name: "Alpha", UOMID: "U1"},
+			"PR2": {ID: "PR2", Name: "Beta", UOMID: "U1"},
+		}
+		prices = map[string][]Price{
+			"PV1": {{ID: "P1", VendorID: "V1", Price: 10, CurrencyCode: "USD", PriceQuantity: 1, PriceUOMID: "U1"}},
+			"PV2": {{ID: "P2", VendorID: "V2", Price: 240000, CurrencyCode: "IDR", PriceQuantity: 2, PriceUOMID: "U1"}},
+			"PV3": {{ID: "P3", VendorID: "V3", Price: 30, CurrencyCode: "EUR", PriceQuantity: 1, PriceUOMID: "U1"}},
+		}
+	)
+
+	setup := func(t *testing.T) (*gomega.GomegaWithT, *MockproductDBAccessor, *MockcurrencyConverter, *ProductService) {
+		mockCtrl := gomock.NewController(t)
+		mockProductAccessor := NewMockproductDBAccessor(mockCtrl)
+		mockConverter := NewMockcurrencyConverter(mockCtrl)
+		mockUOMConverter := NewMockuomConverter(mockCtrl)
+
+		mockUOMConverter.EXPECT().ConversionTables(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, productIDs []string) (map[string]*uom.ConversionTable, error) {
+				tables := map[string]*uom.ConversionTable{}
+				for _, id := range productIDs {
+					tables[id] = uom.NewConversionTable(nil, nil)
+				}
+				return tables, nil
+			}).AnyTimes()
+
+		mockProductAccessor.EXPECT().getProductVendorPageReferences(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, productVendors []ProductVendor) (*productVendorPageReferences, error) {
+				refs := pageReferences(productVendors)
+				for _, pv := range productVendors {
+					refs.Products[pv.ProductID] = *products[ProductID(pv.ProductID)]
+				}
+				refs.Prices = prices
+				refs.UOMs["U1"] = UOM{ID: "U1", Name: "pcs"}
+				return refs, nil
+			}).AnyTimes()
+		mockConverter.EXPECT().GetBaseRate(gomock.Any(), "USD", rateDate).
+			Return(&currency.ExchangeRate{FromCurrency: "USD", ToCurrency: "IDR", Rate: 15000, RateDate: rateDate}, nil).AnyTimes()
+		mockConverter.EXPECT().GetBaseRate(gomock.Any(), "IDR", rateDate).
+			Return(&currency.ExchangeRate{FromCurrency: "IDR", ToCurrency: "IDR", Rate: 1, RateDate: rateDate}, nil).AnyTimes()
+		mockConverter.EXPECT().GetBaseRate(gomock.Any(), "EUR", rateDate).
+			Return(nil, currency.ErrRateNotFound).AnyTimes()
+
+		svc := &ProductService{
+			productDBAccessor: mockProductAccessor,
+			currencySvc:       mockConverter,
+			uomSvc:            mockUOMConverter,
+			clock:             clock.NewMock(),
+		}
+		return gomega.NewWithT(t), mockProductAccessor, mockConverter, svc
+	}
+
+	t.Run("ranks the vendors of every product", func(t *testing.T) {
+		g, mockProductAccessor, _, svc := setup(t)
+		ctx := context.Background()
+
+		mockProductAccessor.EXPECT().GetAllProductVendors(ctx, gomock.Any()).
+			Return(&AccessorGetProductVendorsPaginationData{
+				ProductVendors: []ProductVendor{
+					{ID: "PV3", Name: "Book", ProductID: "PR2"},
+					{ID: "PV1", Name: "Pen A", ProductID: "PR1"},
+					{ID: "PV2", Name: "Pen B", ProductID: "PR1"},
+					{ID: "PV4", Name: "Pen C", ProductID: "PR1"},
+				},
+			}, nil)
+		mockProductAccessor.EXPECT().getVendorNames(ctx, []string{"V3", "V1", "V2"}).
+			Return(map[string]string{"V1": "Vendor 1", "V2": "Vendor 2"}, nil)
+
+		var buffer bytes.Buffer
+		writer, err := spreadsheet.NewWriter(&buffer, spreadsheet.FormatCSV, "Price comparison")
+		g.Expect(err).To(gomega.BeNil())
+
+		err = svc.ExportPriceComparison(ctx, GetProductVendorsSpec{PriceContext: PriceContext{Date: rateDate}}, writer)
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(writer.Close()).To(gomega.Succeed())
+		g.Expect(buffer.String()).To(gomega.Equal(
+			"Product,Rank,Vendor,Product vendor,Price,Currency,Per,UOM,Unit price,Unit,Base currency,vs cheapest\n" +
+				"Alpha,1,Vendor 2,Pen B,240000,IDR,2,pcs,120000,U1,IDR,-\n" +
+				"Alpha,2,Vendor 1,Pen A,10,USD,1,pcs,150000,U1,IDR,+25.0%\n" +
+				"Beta,,V3,Book,30,EUR,1,pcs,,,,\n"))
+	})
+
+	t.Run("returns error on vendor lookup failure", func(t *testing.T) {
+		g, mockProductAccessor, _, svc := setup(t)
+		ctx := context.Background()
+
+		mockProductAccessor.EXPECT().GetAllProductVendors(ctx, gomock.Any()).
+			Return(&AccessorGetProductVendorsPaginationData{
+				ProductVendors: []ProductVendor{{ID: "PV1", ProductID: "PR1"}},
+			}, nil)
+		mockProductAccessor.EXPECT().getVendorNames(ctx, []string{"V1"}).Return(nil, errors.New("error"))
+
+		var buffer bytes.Buffer
+		writer, _ := spreadsheet.NewWriter(&buffer, spreadsheet.FormatCSV, "Price comparison")
+		err := svc.ExportPriceComparison(ctx, GetProductVendorsSpec{PriceContext: PriceContext{Date: rateDate}}, writer)
+		g.Expect(err).ToNot(gomega.BeNil())
+		g.Expect(writer.Close()).To(gomega.Succeed())
+		g.Expect(buffer.String()).To(gomega.BeEmpty())
+	})
+}
+
+func TestProductService_GetProduct(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns ErrProductNotFound when missing or deleted", func(t *testing.T) {
+		var (
+			g                   = gomega.NewWithT(t)
+			ctx                 = context.Background()
+			mockCtrl            = gomock.NewController(t)
+			mockProductAccessor = NewMockproductDBAccessor(mockCtrl)
+		)
+
+		svc := &ProductService{
+			productDBAccessor: mockProductAccessor,
+			clock:             clock.NewMock(),
+		}
+
+		mockProductAccessor.EXPECT().getProductByID(ctx, "P1").Return(nil, sql.ErrNoRows)
+
+		res, err := svc.GetProduct(ctx, "P1")
+		g.Expect(err).To(gomega.MatchError(ErrProductNotFound))
+		g.Expect(res).To(gomega.BeNil())
+	})
+}
+
+func TestProductService_CreateProduct(t *testing.T) {
+	t.Parallel()
+
+	spec := PostProductSpec{
+		ProductCategoryID: "C1",
+		UOMID:             "PCS",
+		ProductTypeID:     "T1",
+		Name:              "Semen",
+		ModifiedBy:        "admin",
+	}
+
+	t.Run("success", func(t *testing.T) {
+		var (
+			g                   = gomega.NewWithT(t)
+			ctx                 = context.Background()
+			mockCtrl            = gomock.NewController(t)
+			mockProductAccessor = NewMockproductDBAccessor(mockCtrl)
+			mockClock           = clock.NewMock()
+		)
+
+		svc := &ProductService{
+			productDBAccessor: mockProductAccessor,
+			clock:             mockClock,
+		}
+
+		mockProductAccessor.EXPECT().getProductCategoryByID(ctx, "C1").Return(&ProductCategory{ID: "C1"}, nil)
+		mockProductAccessor.EXPECT().getProductTypeByID(ctx, "T1").Return(&ProductType{ID: "T1"}, nil)
+		mockProductAccessor.EXPECT().getUOMByID(ctx, "PCS").Return(&UOM{ID: "PCS"}, nil)
+		mockProductAccessor.EXPECT().CreateProduct(ctx, gomock.Any()).Return(nil)
+
+		res, err := svc.CreateProduct(ctx, spec)
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(res.ID).To(gomega.HaveLen(15))
+		g.Expect(res.Name).To(gomega.Equal("Semen"))
+		g.Expect(res.ModifiedDate).To(gomega.Equal(mockClock.Now()))
+		g.Expect(res.ModifiedBy).To(gomega.Equal("admin"))
+	})
+
+	t.Run("returns ErrInvalidReference on a missing type", func(t *testing.T) {
+		var (
+			g                   = gomega.NewWithT(t)
+			ctx                 = context.Background()
+			mockCtrl            = gomock.NewController(t)
+			mockProductAccessor = NewMockproductDBAccessor(mockCtrl)
+		)
+
+		svc := &ProductService{
+			productDBAccessor: mockProductAccessor,
+			clock:             clock.NewMock(),
+		}
+
+		mockProductAccessor.EXPECT().getProductCategoryByID(ctx, "C1").Return(&ProductCategory{ID: "C1"}, nil)
+		mockProductAccessor.EXPECT().getProductTypeByID(ctx, "T1").Return(nil, sql.ErrNoRows)
+
+		res, err := svc.CreateProduct(ctx, spec)
+		g.Expect(errors.Is(err, ErrInvalidReference)).To(gomega.BeTrue())
+		g.Expect(err.Error()).To(gomega.Equal("invalid reference: product type not found: T1"))
+		g.Expect(res).To(gomega.BeNil())
+	})
+
+	t.Run("returns error on accessor failure", func(t *testing.T) {
+		var (
+			g                   = gomega.NewWithT(t)
+			ctx                 = context.Background()
+			mockCtrl            = gomock.NewController(t)
+			mockProductAccessor = NewMockproductDBAccessor(mockCtrl)
+		)
+
+		svc := &ProductService{
+			productDBAccessor: mockProductAccessor,
+			clock:             clock.NewMock(),
+		}
+
+		mockProductAccessor.EXPECT().getProductCategoryByID(ctx, "C1").Return(nil, errors.New("error"))
+
+		res, err := svc.CreateProduct(ctx, spec)
+		g.Expect(err).ToNot(gomega.BeNil())
+		g.Expect(errors.Is(err, ErrInvalidReference)).To(gomega.BeFalse())
+		g.Expect(res).To(gomega.BeNil())
+	})
+}
+
+func TestProductService_CreateProductCategory(t *testing.T) {
+	t.Parallel()
+
+	t.Run("defaults to a top level category", func(t *testing.T) {
+		var (
+			g                   = gomega.NewWithT(t)
+			ctx                 = context.Background()
+			mockCtrl            = gomock.NewController(t)
+			mockProductAccessor = NewMockproductDBAccessor(mockCtrl)
+		)
+
+		svc := &ProductService{
+			productDBAccessor: mockProductAccessor,
+			clock:             clock.NewMock(),
+		}
+
+		mockProductAccessor.EXPECT().CreateProductCategory(ctx, gomock.Any()).Return(nil)
+
+		res, err := svc.CreateProductCategory(ctx, PostProductCategorySpec{Name: "Semen", Code: "SMN"})
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(res.ParentID).To(gomega.Equal(RootCategoryParentID))
+	})
+
+	t.Run("returns ErrInvalidReference on a missing parent", func(t *testing.T) {
+		var (
+			g                   = gomega.NewWithT(t)
+			ctx                 = context.Background()
+			mockCtrl            = gomock.NewController(t)
+			mockProductAccessor = NewMockproductDBAccessor(mockCtrl)
+		)
+
+		svc := &ProductService{
+			productDBAccessor: mockProductAccessor,
+			clock:             clock.NewMock(),
+		}
+
+		mockProductAccessor.EXPECT().getProductCategoryByID(ctx, "C0").Return(nil, sql.ErrNoRows)
+
+		res, err := svc.CreateProductCategory(ctx, PostProductCategorySpec{Name: "Semen", Code: "SMN", ParentID: "C0"})
+		g.Expect(errors.Is(err, ErrInvalidReference)).To(gomega.BeTrue())
+		g.Expect(res).To(gomega.BeNil())
+	})
+}
+
+func TestProductService_DeleteUOM(t *testing.T) {
+	t.Parallel()
+
+	t.Run("checks the references of a uom", func(t *testing.T) {
+		var (
+			g                   = gomega.NewWithT(t)
+			ctx                 = context.Background()
+			mockCtrl            = gomock.NewController(t)
+			mockProductAccessor = NewMockproductDBAccessor(mockCtrl)
+		)
+
+		svc := &ProductService{
+			productDBAccessor: mockProductAccessor,
+			clock:             clock.NewMock(),
+		}
+
+		mockProductAccessor.EXPECT().softDelete(ctx, uomTable, "PCS", "admin").Return(ErrCatalogueInUse)
+
+		err := svc.DeleteUOM(ctx, "PCS", "admin")
+		g.Expect(err).To(gomega.MatchError(ErrCatalogueInUse))
+	})
+}
+
+func TestProductService_GetCategoryTree(t *testing.T) {
+	t.Parallel()
+
+	var (
+		g                   = gomega.NewWithT(t)
+		ctx                 = context.Background()
+		mockCtrl            = gomock.NewController(t)
+		mockProductAccessor = NewMockproductDBAccessor(mockCtrl)
+	)
+
+	svc := &ProductService{
+		productDBAccessor: mockProductAccessor,
+		clock:             clock.NewMock(),
+	}
+
+	mockProductAccessor.EXPECT().getCategoryTree(ctx, RootCategoryParentID).Return([]ProductCategory{
+		{ID: "A", ParentID: "0"},
+		{ID: "A1", ParentID: "A"},
+	}, nil)
+
+	res, err := svc.GetCategoryTree(ctx)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(res).To(gomega.HaveLen(1))
+	g.Expect(res[0].Children[0].ID).To(gomega.Equal("A1"))
+}
+
+func TestProductService_GetCategorySubtree(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns the category with its descendants", func(t *testing.T) {
+		var (
+			g                   = gomega.NewWithT(t)
+			ctx                 = context.Background()
+			mockCtrl            = gomock.NewController(t)
+			mockProductAccessor = NewMockproductDBAccessor(mockCtrl)
+		)
+
+		svc := &ProductService{
+			productDBAccessor: mockProductAccessor,
+			clock:             clock.NewMock(),
+		}
+
+		mockProductAccessor.EXPECT().getCategorySubtree(ctx, "A").Return([]ProductCategory{
+			{ID: "A", ParentID: "0"},
+			{ID: "A1", ParentID: "A"},
+		}, nil)
+
+		res, err := svc.GetCategorySubtree(ctx, "A")
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(res.ID).To(gomega.Equal("A"))
+		g.Expect(res.Children).To(gomega.HaveLen(1))
+	})
+
+	t.Run("returns ErrProductCategoryNotFound when missing", func(t *testing.T) {
+		var (
+			g                   = gomega.NewWithT(t)
+			ctx                 = context.Background()
+			mockCtrl            = gomock.NewController(t)
+			mockProductAccessor = NewMockproductDBAccessor(mockCtrl)
+		)
+
+		svc := &ProductService{
+			productDBAccessor: mockProductAccessor,
+			clock:             clock.NewMock(),
+		}
+
+		mockProductAccessor.EXPECT().getCategorySubtree(ctx, "A").Return([]ProductCategory{}, nil)
+
+		res, err := svc.GetCategorySubtree(ctx, "A")
+		g.Expect(err).To(gomega.MatchError(ErrProductCategoryNotFound))
+		g.Expect(res).To(gomega.BeNil())
+	})
+}
+
+func TestProductService_MoveProductCategory(t *testing.T) {
+	t.Parallel()
+
+	t.Run("moves to the top level without a parent", func(t *testing.T) {
+		var (
+			g                   = gomega.NewWithT(t)
+			ctx                 = context.Background()
+			mockCtrl            = gomock.NewController(t)
+			mockProductAccessor = NewMockproductDBAccessor(mockCtrl)
+		)
+
+		svc := &ProductService{
+			productDBAccessor: mockProductAccessor,
+			clock:             clock.NewMock(),
+		}
+
+		mockProductAccessor.EXPECT().moveProductCategory(ctx, "A1", RootCategoryParentID, "admin").Return(nil)
+		mockProductAccessor.EXPECT().getProductCategoryByID(ctx, "A1").Return(&ProductCategory{ID: "A1", ParentID: "0"}, nil)
+
+		res, err := svc.MoveProductCategory(ctx, "A1", MoveProductCategorySpec{ModifiedBy: "admin"})
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(res.ParentID).To(gomega.Equal(RootCategoryParentID))
+	})
+
+	t.Run("returns ErrCategoryCycle when moved under itself", func(t *testing.T) {
+		var (
+			g                   = gomega.NewWithT(t)
+			ctx                 = context.Background()
+			mockCtrl            = gomock.NewController(t)
+			mockProductAccessor = NewMockproductDBAccessor(mockCtrl)
+		)
+
+		svc := &ProductService{
+			productDBAccessor: mockProductAccessor,
+			clock:             clock.NewMock(),
+		}
+
+		res, err := svc.MoveProductCategory(ctx, "A1", MoveProductCategorySpec{ParentID: "A1"})
+		g.Expect(err).To(gomega.MatchError(ErrCategoryCycle))
+		g.Expect(res).To(gomega.BeNil())
+	})
+
+	t.Run("returns error on accessor failure", func(t *testing.T) {
+		var (
+			g                   = gomega.NewWithT(t)
+			ctx                 = context.Background()
+			mockCtrl            = gomock.NewController(t)
+			mockProductAccessor = NewMockproductDBAccessor(mockCtrl)
+		)
+
+		svc := &ProductService{
+			productDBAccessor: mockProductAccessor,
+			clock:             clock.NewMock(),
+		}
+
+		mockProductAccessor.EXPECT().moveProductCategory(ctx, "A", "A1", "").Return(ErrCategoryCycle)
+
+		res, err := svc.MoveProductCategory(ctx, "A", MoveProductCategorySpec{ParentID: "A1"})
+		g.Expect(err).To(gomega.MatchError(ErrCategoryCycle))
+		g.Expect(res).To(gomega.BeNil())
+	})
+}
+
+func TestProductService_CreateProductVendor(t *testing.T) {
+	t.Parallel()
+
+	spec := PostProductVendorSpec{ProductID: "PR1", Name: "Semen 50kg", ModifiedBy: "admin"}
+
+	setup := func(t *testing.T) (*gomega.GomegaWithT, *MockproductDBAccessor, *ProductService) {
+		mockCtrl := gomock.NewController(t)
+		mockProductAccessor := NewMockproductDBAccessor(mockCtrl)
+		svc := &ProductService{
+			productDBAccessor: mockProductAccessor,
+			clock:             clock.NewMock(),
+		}
+		return gomega.NewWithT(t), mockProductAccessor, svc
+	}
+
+	t.Run("uses the product's uom by default", func(t *testing.T) {
+		g, mockProductAccessor, svc := setup(t)
+		ctx := context.Background()
+
+		mockProductAccessor.EXPECT().getProductByID(ctx, "PR1").Return(&Product{ID: "PR1", UOMID: "SAK"}, nil)
+		mockProductAccessor.EXPECT().CreateProductVendor(ctx, gomock.Any()).Return(nil)
+
+		res, err := svc.CreateProductVendor(ctx, spec)
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(res.ID).To(gomega.HaveLen(15))
+		g.Expect(res.UOMID).To(gomega.Equal("SAK"))
+		g.Expect(res.ModifiedDate).To(gomega.Equal(svc.clock.Now()))
+		g.Expect(res.ModifiedBy).To(gomega.Equal("admin"))
+	})
+
+	t.Run("returns ErrInvalidReference on a missing uom", func(t *testing.T) {
+		g, mockProductAccessor, svc := setup(t)
+		ctx := context.Background()
+
+		withUOM := spec
+		withUOM.UOMID = "BOX"
+		mockProductAccessor.EXPECT().getProductByID(ctx, "PR1").Return(&Product{ID: "PR1", UOMID: "SAK"}, nil)
+		mockProductAccessor.EXPECT().getUOMByID(ctx, "BOX").Return(nil, sql.ErrNoRows)
+
+		res, err := svc.CreateProductVendor(ctx, withUOM)
+		g.Expect(errors.Is(err, ErrInvalidReference)).To(gomega.BeTrue())
+		g.Expect(err.Error()).To(gomega.Equal("invalid reference: uom not found: BOX"))
+		g.Expect(res).To(gomega.BeNil())
+	})
+
+	t.Run("returns ErrInvalidReference on a missing product", func(t *testing.T) {
+		g, mockProductAccessor, svc := setup(t)
+		ctx := context.Background()
+
+		mockProductAccessor.EXPECT().getProductByID(ctx, "PR1").Return(nil, sql.ErrNoRows)
+
+		res, err := svc.CreateProductVendor(ctx, spec)
+		g.Expect(errors.Is(err, ErrInvalidReference)).To(gomega.BeTrue())
+		g.Expect(res).To(gomega.BeNil())
+	})
+}
+
+func TestProductService_CreatePrice(t *testing.T) {
+	t.Parallel()
+
+	var (
+		validFrom = time.Date(2024, time.December, 1, 0, 0, 0, 0, time.UTC)
+		spec      = PostPriceSpec{
+			VendorID:        "V1",
+			ProductVendorID: "PV1",
+			QuantityMin:     1,
+			QuantityMax:     10,
+			CurrencyCode:    "idr",
+			Price:           52000,
+			PriceQuantity:   1,
+			PriceUOMID:      "SAK",
+			ValidFrom:       validFrom,
+			ModifiedBy:      "admin",
+		}
+		refs = &priceImportReferences{
+			ProductVendors: map[string]bool{"PV1": true},
+			Vendors:        map[string]bool{"V1": true},
+			UOMs:           map[string]bool{"SAK": true},
+			Currencies:     map[string]importCurrency{"IDR": {ID: "C1", Code: "IDR", Name: "Rupiah"}},
+		}
+	)
+
+	setup := func(t *testing.T) (*gomega.GomegaWithT, *MockproductDBAccessor, *ProductService) {
+		mockCtrl := gomock.NewController(t)
+		mockProductAccessor := NewMockproductDBAccessor(mockCtrl)
+		svc := &ProductService{
+			productDBAccessor: mockProductAccessor,
+			clock:             clock.NewMock(),
+		}
+		expectUnitOfWork(mockProductAccessor)
+		return gomega.NewWithT(t), mockProductAccessor, svc
+	}
+
+	t.Run("success next to another quantity tier", func(t *testing.T) {
+		g, mockProductAccessor, svc := setup(t)
+		ctx := context.Background()
+
+		mockProductAccessor.EXPECT().lockProductVendor(ctx, "PV1").Return(nil)
+		mockProductAccessor.EXPECT().getPriceImportReferences(ctx, priceImportKeys{
+			ProductVendorIDs: []string{"PV1"},
+			VendorIDs:        []string{"V1"},
+			UOMIDs:           []string{"SAK"},
+			CurrencyCodes:    []string{"IDR"},
+		}).Return(refs, nil)
+		mockProductAccessor.EXPECT().getPricesByPVID(ctx, "PV1").
+			Return([]Price{{ID: "P0", VendorID: "V1", QuantityMin: 11, ValidFrom: validFrom}}, nil)
+		mockProductAccessor.EXPECT().CreatePrice(ctx, gomock.Any()).Return(nil)
+
+		res, err := svc.CreatePrice(ctx, spec)
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(res.ID).To(gomega.HaveLen(15))
+		g.Expect(res.CurrencyID).To(gomega.Equal("C1"))
+		g.Expect(res.CurrencyCode).To(gomega.Equal("IDR"))
+		g.Expect(res.ModifiedDate).To(gomega.Equal(svc.clock.Now()))
+		g.Expect(res.ModifiedBy).To(gomega.Equal("admin"))
+	})
+
+	t.Run("success leaving the window open after an expired one of the same tier", func(t *testing.T) {
+		g, mockProductAccessor, svc := setup(t)
+		ctx := context.Background()
+
+		expiredTo := validFrom.AddDate(0, 0, -1)
+		mockProductAccessor.EXPECT().lockProductVendor(ctx, "PV1").Return(nil)
+		mockProductAccessor.EXPECT().getPriceImportReferences(ctx, gomock.Any()).Return(refs, nil)
+		mockProductAccessor.EXPECT().getPricesByPVID(ctx, "PV1").
+			Return([]Price{{ID: "P0", VendorID: "V1", QuantityMin: 1, QuantityMax: 10, ValidFrom: validFrom.AddDate(0, -1, 0), ValidTo: &expiredTo}}, nil)
+		mockProductAccessor.EXPECT().CreatePrice(ctx, gomock.Any()).Return(nil)
+
+		res, err := svc.CreatePrice(ctx, spec)
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(res.ValidTo).To(gomega.BeNil())
+	})
+
+	t.Run("returns ErrInvalidPrice on an invalid validity window", func(t *testing.T) {
+		g, mockProductAccessor, svc := setup(t)
+		ctx := context.Background()
+
+		invalid := spec
+		dayBefore := validFrom.AddDate(0, 0, -1)
+		invalid.ValidTo = &dayBefore
+		mockProductAccessor.EXPECT().lockProductVendor(ctx, "PV1").Return(nil)
+		mockProductAccessor.EXPECT().getPriceImportReferences(ctx, gomock.Any()).Return(refs, nil)
+
+		res, err := svc.CreatePrice(ctx, invalid)
+		g.Expect(errors.Is(err, ErrInvalidPrice)).To(gomega.BeTrue())
+		g.Expect(err.Error()).To(gomega.Equal("invalid price: valid_to is before valid_from"))
+		g.Expect(res).To(gomega.BeNil())
+	})
+
+	t.Run("returns ErrInvalidPrice on an unknown product vendor", func(t *testing.T) {
+		g, mockProductAccessor, svc := setup(t)
+		ctx := context.Background()
+
+		mockProductAccessor.EXPECT().lockProductVendor(ctx, "PV1").Return(nil)
+		mockProductAccessor.EXPECT().getPriceImportReferences(ctx, gomock.Any()).
+			Return(&priceImportReferences{Vendors: refs.Vendors, UOMs: refs.UOMs, Currencies: refs.Currencies}, nil)
+
+		res, err := svc.CreatePrice(ctx, spec)
+		g.Expect(errors.Is(err, ErrInvalidPrice)).To(gomega.BeTrue())
+		g.Expect(res).To(gomega.BeNil())
+	})
+
+	t.Run("checks the overlap with the product vendor locked", func(t *testing.T) {
+		g, mockProductAccessor, svc := setup(t)
+		ctx := context.Background()
+
+		gomock.InOrder(
+			mockProductAccessor.EXPECT().lockProductVendor(ctx, "PV1").Return(nil),
+			mockProductAccessor.EXPECT().getPriceImportReferences(ctx, gomock.Any()).Return(refs, nil),
+			mockProductAccessor.EXPECT().getPricesByPVID(ctx, "PV1").Return([]Price{}, nil),
+			mockProductAccessor.EXPECT().CreatePrice(ctx, gomock.Any()).Return(nil),
+		)
+
+		_, err := svc.CreatePrice(ctx, spec)
+		g.Expect(err).To(gomega.BeNil())
+	})
+
+	t.Run("error on lock", func(t *testing.T) {
+		g, mockProductAccessor, svc := setup(t)
+		ctx := context.Background()
+
+		mockProductAccessor.EXPECT().lockProductVendor(ctx, "PV1").Return(errors.New("db error"))
+
+		res, err := svc.CreatePrice(ctx, spec)
+		g.Expect(err).To(gomega.MatchError("db error"))
+		g.Expect(res).To(gomega.BeNil())
+	})
+
+	t.Run("returns ErrOverlappingPrice on an overlapping tier", func(t *testing.T) {
+		g, mockProductAccessor, svc := setup(t)
+		ctx := context.Background()
+
+		mockProductAccessor.EXPECT().lockProductVendor(ctx, "PV1").Return(nil)
+		mockProductAccessor.EXPECT().getPriceImportReferences(ctx, gomock.Any()).Return(refs, nil)
+		mockProductAccessor.EXPECT().getPricesByPVID(ctx, "PV1").
+			Return([]Price{{ID: "P0", VendorID: "V1", QuantityMin: 5, QuantityMax: 20, ValidFrom: validFrom.AddDate(0, -1, 0)}}, nil)
+
+		res, err := svc.CreatePrice(ctx, spec)
+		g.Expect(errors.Is(err, ErrOverlappingPrice)).To(gomega.BeTrue())
+		g.Expect(err.Error()).To(gomega.ContainSubstring("P0"))
+		g.Expect(res).To(gomega.BeNil())
+	})
+}
+
+func TestProductService_DeletePrice(t *testing.T) {
+	t.Parallel()
+
+	t.Run("soft deletes the price", func(t *testing.T) {
+		var (
+			g                   = gomega.NewWithT(t)
+			ctx                 = context.Background()
+			mockCtrl            = gomock.NewController(t)
+			mockProductAccessor = NewMockproductDBAccessor(mockCtrl)
+		)
+
+		svc := &ProductService{productDBAccessor: mockProductAccessor}
+
+		mockProductAccessor.EXPECT().softDelete(ctx, priceTable, "P1", "admin").Return(ErrPriceNotFound)
+
+		err := svc.DeletePrice(ctx, "P1", "admin")
+		g.Expect(errors.Is(err, ErrPriceNotFound)).To(gomega.BeTrue())
+	})
+
+	t.Run("product vendors are only deleted without prices", func(t *testing.T) {
+		var (
+			g                   = gomega.NewWithT(t)
+			ctx                 = context.Background()
+			mockCtrl            = gomock.NewController(t)
+			mockProductAccessor = NewMockproductDBAccessor(mockCtrl)
+		)
+
+		svc := &ProductService{productDBAccessor: mockProductAccessor}
+
+		mockProductAccessor.EXPECT().softDelete(ctx, productVendorTable, "PV1", "admin").Return(ErrCatalogueInUse)
+
+		err := svc.DeleteProductVendor(ctx, "PV1", "admin")
+		g.Expect(errors.Is(err, ErrCatalogueInUse)).To(gomega.BeTrue())
+	})
+}
+
+func TestProductService_RestorePrice(t *testing.T) {
+	t.Parallel()
+
+	t.Run("restores the price", func(t *testing.T) {
+		var (
+			g                   = gomega.NewWithT(t)
+			ctx                 = context.Background()
+			mockCtrl            = gomock.NewController(t)
+			mockProductAccessor = NewMockproductDBAccessor(mockCtrl)
+		)
+
+		svc := &ProductService{productDBAccessor: mockProductAccessor}
+		expectUnitOfWork(mockProductAccessor)
+
+		gomock.InOrder(
+			mockProductAccessor.EXPECT().getDeletedPrice(ctx, "P1").
+				Return(&Price{ID: "P1", ProductVendorID: "PV1", VendorID: "V1", QuantityMin: 1, QuantityMax: 10}, nil),
+			mockProductAccessor.EXPECT().lockProductVendor(ctx, "PV1").Return(nil),
+			mockProductAccessor.EXPECT().getPricesByPVID(ctx, "PV1").
+				Return([]Price{{ID: "P2", ProductVendorID: "PV1", VendorID: "V1", QuantityMin: 11}}, nil),
+			mockProductAccessor.EXPECT().restore(ctx, priceTable, "P1", "admin").Return(nil),
+		)
+
+		err := svc.RestorePrice(ctx, "P1", "admin")
+		g.Expect(err).To(gomega.BeNil())
+	})
+
+	t.Run("a price overlapping a live tier stays deleted", func(t *testing.T) {
+		var (
+			g                   = gomega.NewWithT(t)
+			ctx                 = context.Background()
+			mockCtrl            = gomock.NewController(t)
+			mockProductAccessor = NewMockproductDBAccessor(mockCtrl)
+		)
+
+		svc := &ProductService{productDBAccessor: mockProductAccessor}
+		expectUnitOfWork(mockProductAccessor)
+
+		mockProductAccessor.EXPECT().getDeletedPrice(ctx, "P1").
+			Return(&Price{ID: "P1", ProductVendorID: "PV1", VendorID: "V1", QuantityMin: 1, QuantityMax: 10}, nil)
+		mockProductAccessor.EXPECT().lockProductVendor(ctx, "PV1").Return(nil)
+		mockProductAccessor.EXPECT().getPricesByPVID(ctx, "PV1").
+			Return([]Price{{ID: "P2", ProductVendorID: "PV1", VendorID: "V1", QuantityMin: 5, QuantityMax: 20}}, nil)
+
+		err := svc.RestorePrice(ctx, "P1", "admin")
+		g.Expect(errors.Is(err, ErrOverlappingPrice)).To(gomega.BeTrue())
+		g.Expect(err.Error()).To(gomega.ContainSubstring("P2"))
+	})
+
+	t.Run("error on a price that isn't deleted", func(t *testing.T) {
+		var (
+			g                   = gomega.NewWithT(t)
+			ctx                 = context.Background()
+			mockCtrl            = gomock.NewController(t)
+			mockProductAccessor = NewMockproductDBAccessor(mockCtrl)
+		)
+
+		svc := &ProductService{productDBAccessor: mockProductAccessor}
+		expectUnitOfWork(mockProductAccessor)
+
+		mockProductAccessor.EXPECT().getDeletedPrice(ctx, "P1").Return(nil, ErrPriceNotFound)
+
+		err := svc.RestorePrice(ctx, "P1", "admin")
+		g.Expect(errors.Is(err, ErrPriceNotFound)).To(gomega.BeTrue())
+	})
+
+	t.Run("product vendors are only restored with their product", func(t *testing.T) {
+		var (
+			g                   = gomega.NewWithT(t)
+			ctx                 = context.Background()
+			mockCtrl            = gomock.NewController(t)
+			mockProductAccessor = NewMockproductDBAccessor(mockCtrl)
+		)
+
+		svc := &ProductService{productDBAccessor: mockProductAccessor}
+
+		mockProductAccessor.EXPECT().restore(ctx, productVendorTable, "PV1", "admin").Return(ErrCatalogueDeletedReference)
+
+		err := svc.RestoreProductVendor(ctx, "PV1", "admin")
+		g.Expect(errors.Is(err, ErrCatalogueDeletedReference)).To(gomega.BeTrue())
+	})
+}
+
+// pageReferences resolves every product vendor of a page to an empty product, category
+// and price UOM with a single empty price
+func pageReferences(productVendors []ProductVendor) *productVendorPageReferences {
+	prices := map[string][]Price{}
+	for _, pv := range productVendors {
+		prices[pv.ID] = []Price{{}}
+	}
+	return &productVendorPageReferences{
+		Products:   map[string]Product{"": {}},
+		Categories: map[string]ProductCategory{"": {}},
+		Prices:     prices,
+		UOMs:       map[string]UOM{"": {}},
+	}
+}
+
+// expectUnitOfWork runs the units of work of the service on the accessor mock itself
+func expectUnitOfWork(m *MockproductDBAccessor) {
+	m.EXPECT().runInTx(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, fn func(tx productDBAccessor) error) error {
+			return fn(m)
+		}).
+		AnyTimes()
 }
@@ -0,0 +1,74 @@
+package retention
+
+import (
+	"context"
+	"kg/procurement/cmd/utils"
+	"kg/procurement/internal/audit"
+	"kg/procurement/internal/common/database"
+	"slices"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/lib/pq"
+)
+
+type postgresRetentionAccessor struct {
+	db    database.DBConnector
+	clock clock.Clock
+}
+
+// purge hard deletes the rows of step soft deleted before cutoff along with their
+// history, returning how many rows were removed from the table of step
+func (p *postgresRetentionAccessor) purge(ctx context.Context, step purgeStep, cutoff time.Time) (int, error) {
+	ids := []string{}
+	if err := p.db.SelectContext(ctx, &ids, step.Select, cutoff); err != nil {
+		utils.Logger.Error(err.Error())
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	// the history goes first, it refers to the rows
+	queries := append(slices.Clone(step.Dependents), step.deleteQuery())
+	write := func(tx database.DBConnector) error {
+		for _, query := range step.Setup {
+			if _, err := tx.ExecContext(ctx, query); err != nil {
+				utils.Logger.Error(err.Error())
+				return err
+			}
+		}
+		for _, query := range queries {
+			if _, err := tx.ExecContext(ctx, query, pq.Array(ids)); err != nil {
+				utils.Logger.Error(err.Error())
+				return err
+			}
+		}
+		return nil
+	}
+
+	var err error
+	if step.Audit == "" {
+		err = write(p.db)
+	} else {
+		err = audit.Track(ctx, p.db, p.clock, step.Audit, ids, purgeActor, write)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return len(ids), nil
+}
+
+// runInTx runs fn as a unit of work, the accessor fn gets writes in its transaction
+func (p *postgresRetentionAccessor) runInTx(ctx context.Context, fn func(tx retentionDBAccessor) error) error {
+	return database.RunInTx(ctx, p.db, func(tx database.DBConnector) error {
+		return fn(newPostgresRetentionAccessor(tx, p.clock))
+	})
+}
+
+func newPostgresRetentionAccessor(db database.DBConnector, clock clock.Clock) *postgresRetentionAccessor {
+	return &postgresRetentionAccessor{
+		db:    db,
+		clock: clock,
+	}
+}
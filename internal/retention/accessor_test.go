@@ -0,0 +1,196 @@
+package retention
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io/fs"
+	"kg/procurement/internal/audit"
+	"kg/procurement/migrations"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/benbjohnson/clock"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/onsi/gomega"
+)
+
+func Test_newPostgresRetentionAccessor(t *testing.T) {
+	_ = newPostgresRetentionAccessor(nil, nil)
+}
+
+type retentionAccessorTestComponent struct {
+	g        *gomega.WithT
+	mock     sqlmock.Sqlmock
+	accessor *postgresRetentionAccessor
+	cutoff   time.Time
+}
+
+func setupRetentionAccessorTestComponent(t *testing.T) retentionAccessorTestComponent {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	return retentionAccessorTestComponent{
+		g:        gomega.NewWithT(t),
+		mock:     mock,
+		accessor: newPostgresRetentionAccessor(sqlx.NewDb(db, "sqlmock"), clock.NewMock()),
+		cutoff:   time.Date(2024, time.September, 13, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func (c retentionAccessorTestComponent) expectSelect(step purgeStep, ids ...string) {
+	rows := sqlmock.NewRows([]string{"id"})
+	for _, id := range ids {
+		rows.AddRow(id)
+	}
+	c.mock.ExpectQuery(regexp.QuoteMeta(step.Select)).WithArgs(c.cutoff).WillReturnRows(rows)
+}
+
+func (c retentionAccessorTestComponent) expectDeletes(step purgeStep, ids ...string) {
+	for _, query := range step.Setup {
+		c.mock.ExpectExec(regexp.QuoteMeta(query)).WithoutArgs().WillReturnResult(sqlmock.NewResult(0, 0))
+	}
+	for _, query := range append(slices.Clone(step.Dependents), step.deleteQuery()) {
+		c.mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(pq.Array(ids)).WillReturnResult(sqlmock.NewResult(0, int64(len(ids))))
+	}
+}
+
+func Test_purge(t *testing.T) {
+	t.Parallel()
+
+	var (
+		prices         = purgeSteps[0]
+		productVendors = purgeSteps[1]
+		vendors        = purgeSteps[3]
+	)
+
+	t.Run("removes the rows with their history", func(t *testing.T) {
+		c := setupRetentionAccessorTestComponent(t)
+
+		c.expectSelect(productVendors, "PV1", "PV2")
+		c.expectDeletes(productVendors, "PV1", "PV2")
+
+		rows, err := c.accessor.purge(context.Background(), productVendors, c.cutoff)
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(rows).To(gomega.Equal(2))
+		c.g.Expect(c.mock.ExpectationsWereMet()).To(gomega.BeNil())
+	})
+
+	t.Run("records the purge of an audited table", func(t *testing.T) {
+		c := setupRetentionAccessorTestComponent(t)
+
+		snapshot := func(rows *sqlmock.Rows) {
+			c.mock.ExpectQuery(regexp.QuoteMeta(audit.SnapshotQuery(audit.EntityVendor))).
+				WithArgs(pq.Array([]string{"V1"})).
+				WillReturnRows(rows)
+		}
+		args := make([]driver.Value, 10)
+		for i := range args {
+			args[i] = sqlmock.AnyArg()
+		}
+
+		c.expectSelect(vendors, "V1")
+		c.mock.ExpectBegin()
+		snapshot(sqlmock.NewRows([]string{"id", "row"}).AddRow("V1", []byte(`{"id":"V1"}`)))
+		c.expectDeletes(vendors, "V1")
+		snapshot(sqlmock.NewRows([]string{"id", "row"}))
+		c.mock.ExpectExec("INSERT INTO audit_log").WithArgs(args...).WillReturnResult(sqlmock.NewResult(0, 1))
+		c.mock.ExpectCommit()
+
+		rows, err := c.accessor.purge(context.Background(), vendors, c.cutoff)
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(rows).To(gomega.Equal(1))
+		c.g.Expect(c.mock.ExpectationsWereMet()).To(gomega.BeNil())
+	})
+
+	t.Run("allows the removal of the price history first", func(t *testing.T) {
+		c := setupRetentionAccessorTestComponent(t)
+
+		snapshot := func(rows *sqlmock.Rows) {
+			c.mock.ExpectQuery(regexp.QuoteMeta(audit.SnapshotQuery(audit.EntityPrice))).
+				WithArgs(pq.Array([]string{"PR1"})).
+				WillReturnRows(rows)
+		}
+		args := make([]driver.Value, 10)
+		for i := range args {
+			args[i] = sqlmock.AnyArg()
+		}
+
+		c.expectSelect(prices, "PR1")
+		c.mock.ExpectBegin()
+		snapshot(sqlmock.NewRows([]string{"id", "row"}).AddRow("PR1", []byte(`{"id":"PR1"}`)))
+		c.mock.ExpectExec(regexp.QuoteMeta(allowPurgeQuery)).WithoutArgs().WillReturnResult(sqlmock.NewResult(0, 0))
+		c.mock.ExpectExec(regexp.QuoteMeta("DELETE FROM price_history")).WithArgs(pq.Array([]string{"PR1"})).WillReturnResult(sqlmock.NewResult(0, 2))
+		c.mock.ExpectExec(regexp.QuoteMeta("DELETE FROM price_anomaly")).WithArgs(pq.Array([]string{"PR1"})).WillReturnResult(sqlmock.NewResult(0, 0))
+		c.mock.ExpectExec(regexp.QuoteMeta(prices.deleteQuery())).WithArgs(pq.Array([]string{"PR1"})).WillReturnResult(sqlmock.NewResult(0, 1))
+		snapshot(sqlmock.NewRows([]string{"id", "row"}))
+		c.mock.ExpectExec("INSERT INTO audit_log").WithArgs(args...).WillReturnResult(sqlmock.NewResult(0, 1))
+		c.mock.ExpectCommit()
+
+		rows, err := c.accessor.purge(context.Background(), prices, c.cutoff)
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(rows).To(gomega.Equal(1))
+		c.g.Expect(c.mock.ExpectationsWereMet()).To(gomega.BeNil())
+	})
+
+	t.Run("nothing past retention", func(t *testing.T) {
+		c := setupRetentionAccessorTestComponent(t)
+
+		c.expectSelect(vendors)
+
+		rows, err := c.accessor.purge(context.Background(), vendors, c.cutoff)
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(rows).To(gomega.BeZero())
+		c.g.Expect(c.mock.ExpectationsWereMet()).To(gomega.BeNil())
+	})
+
+	t.Run("error on delete", func(t *testing.T) {
+		c := setupRetentionAccessorTestComponent(t)
+
+		c.expectSelect(productVendors, "PV1")
+		c.mock.ExpectExec(regexp.QuoteMeta(productVendors.deleteQuery())).WillReturnError(errors.New("db error"))
+
+		rows, err := c.accessor.purge(context.Background(), productVendors, c.cutoff)
+		c.g.Expect(err).ToNot(gomega.BeNil())
+		c.g.Expect(rows).To(gomega.BeZero())
+	})
+}
+
+// Test_allowPurgeQuery checks the price step against the trigger keeping the price history
+// immutable as the embedded migrations leave it: deletes get past it once the setting the
+// step enables is on, updates never do
+func Test_allowPurgeQuery(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	setting := regexp.MustCompile(`^SET LOCAL ([a-z_.]+) = '(\w+)'$`).FindStringSubmatch(allowPurgeQuery)
+	g.Expect(setting).ToNot(gomega.BeNil())
+	g.Expect(purgeSteps[0].Setup).To(gomega.ContainElement(allowPurgeQuery))
+
+	// the Up section of the last migration (re)defining the trigger function wins
+	definition := regexp.MustCompile(`(?s)CREATE (?:OR REPLACE )?FUNCTION reject_price_history_change\(\).*?\$\$(.*?)\$\$`)
+	files, err := fs.Glob(migrations.FS, "*.sql")
+	g.Expect(err).To(gomega.BeNil())
+	body := ""
+	for _, file := range files {
+		content, err := fs.ReadFile(migrations.FS, file)
+		g.Expect(err).To(gomega.BeNil())
+		up, _, _ := strings.Cut(string(content), "-- +goose Down")
+		if match := definition.FindStringSubmatch(up); match != nil {
+			body = match[1]
+		}
+	}
+
+	g.Expect(body).To(gomega.ContainSubstring(
+		fmt.Sprintf("IF TG_OP = 'DELETE' AND current_setting('%s', true) = '%s' THEN\n        RETURN OLD;", setting[1], setting[2]),
+	))
+	g.Expect(body).To(gomega.ContainSubstring("RAISE EXCEPTION 'price_history entries are immutable'"))
+}
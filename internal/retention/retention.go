@@ -0,0 +1,80 @@
+package retention
+
+import (
+	"fmt"
+	"kg/procurement/internal/audit"
+)
+
+// purgeActor is recorded on the audit log as the author of every purge
+const purgeActor = "purge"
+
+// allowPurgeQuery lets the deletes of the transaction past the trigger keeping the
+// price history immutable
+const allowPurgeQuery = "SET LOCAL procurement.purge = 'on'"
+
+// PurgedTable counts the rows a purge removed from a table, the history removed
+// along with them isn't counted
+type PurgedTable struct {
+	Table string `json:"table"`
+	Rows  int    `json:"rows"`
+}
+
+// purgeStep hard deletes the rows of Table soft deleted before a cutoff. Select locks
+// and lists the ids of the rows deleted before the cutoff $1 nothing refers to anymore,
+// Dependents remove the history of the ids $1 beforehand, after Setup lets them past the
+// triggers guarding it. Purges of an audited table are recorded on the audit log as Audit
+// deletes
+type purgeStep struct {
+	Table      string
+	Select     string
+	Setup      []string
+	Dependents []string
+	Audit      audit.Entity
+}
+
+// deleteQuery removes the rows of the ids $1
+func (s purgeStep) deleteQuery() string {
+	return fmt.Sprintf("DELETE FROM %s WHERE id = ANY($1)", s.Table)
+}
+
+// purgeSteps run in order, the rows referring to an entry are purged before it so a
+// product vendor or a vendor goes once the prices referring to it are gone. Soft deleted
+// rows still within retention keep their parents from being purged
+var purgeSteps = []purgeStep{
+	{
+		Table:  "price",
+		Select: "SELECT id FROM price WHERE deleted_at < $1 FOR UPDATE",
+		Setup:  []string{allowPurgeQuery},
+		Dependents: []string{
+			"DELETE FROM price_history WHERE price_id = ANY($1)",
+			"DELETE FROM price_anomaly WHERE price_id = ANY($1)",
+		},
+		Audit: audit.EntityPrice,
+	},
+	{
+		Table:  "product_vendor",
+		Select: "SELECT id FROM product_vendor pv WHERE deleted_at < $1 AND NOT EXISTS (SELECT 1 FROM price WHERE product_vendor_id = pv.id) FOR UPDATE",
+	},
+	{
+		Table:  "product",
+		Select: "SELECT id FROM product p WHERE deleted_at < $1 AND NOT EXISTS (SELECT 1 FROM product_vendor WHERE product_id = p.id) FOR UPDATE",
+		Dependents: []string{
+			"DELETE FROM product_uom_conversion WHERE product_id = ANY($1)",
+		},
+		Audit: audit.EntityProduct,
+	},
+	{
+		// email statuses keep their history, their vendor is set to null
+		Table:  "vendor",
+		Select: "SELECT id FROM vendor v WHERE deleted_at < $1 AND NOT EXISTS (SELECT 1 FROM price WHERE vendor_id = v.id) FOR UPDATE",
+		Dependents: []string{
+			"DELETE FROM vendor_evaluation WHERE vendor_id = ANY($1)",
+			"DELETE FROM vendor_status_history WHERE vendor_id = ANY($1)",
+		},
+		Audit: audit.EntityVendor,
+	},
+	{
+		Table:  "account",
+		Select: "SELECT id FROM account WHERE deleted_at < $1 FOR UPDATE",
+	},
+}
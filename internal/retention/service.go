@@ -0,0 +1,66 @@
+//go:generate mockgen -typed -source=service.go -destination=service_mock.go -package=retention
+package retention
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"kg/procurement/cmd/config"
+	"kg/procurement/internal/common/database"
+	"time"
+
+	"github.com/benbjohnson/clock"
+)
+
+// errDryRun rolls back the transaction of a dry run
+var errDryRun = errors.New("dry run")
+
+type retentionDBAccessor interface {
+	runInTx(ctx context.Context, fn func(tx retentionDBAccessor) error) error
+	purge(ctx context.Context, step purgeStep, cutoff time.Time) (int, error)
+}
+
+type RetentionService struct {
+	retentionDBAccessor
+	clock clock.Clock
+	cfg   config.RetentionConfig
+}
+
+// Purge hard deletes the vendors, products, product vendors, prices and accounts soft
+// deleted for longer than the retention, in a single transaction. A dry run counts the
+// rows it would remove in a transaction that is rolled back
+func (r *RetentionService) Purge(ctx context.Context, dryRun bool) ([]PurgedTable, error) {
+	cutoff := r.clock.Now().Add(-r.cfg.SoftDeleted)
+
+	var res []PurgedTable
+	err := r.retentionDBAccessor.runInTx(ctx, func(tx retentionDBAccessor) error {
+		res = make([]PurgedTable, 0, len(purgeSteps))
+		for _, step := range purgeSteps {
+			rows, err := tx.purge(ctx, step, cutoff)
+			if err != nil {
+				return fmt.Errorf("failed purging %s: %w", step.Table, err)
+			}
+			res = append(res, PurgedTable{Table: step.Table, Rows: rows})
+		}
+		if dryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		return nil, err
+	}
+	return res, nil
+}
+
+func NewRetentionService(
+	conn database.DBConnector,
+	clock clock.Clock,
+	cfg config.RetentionConfig,
+) *RetentionService {
+	return &RetentionService{
+		retentionDBAccessor: newPostgresRetentionAccessor(conn, clock),
+		clock:               clock,
+		cfg:                 cfg,
+	}
+}
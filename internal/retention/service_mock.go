@@ -0,0 +1,118 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+//
+// Generated by this command:
+//
+//	mockgen -typed -source=service.go -destination=service_mock.go -package=retention
+//
+
+// Package retention is a generated GoMock package.
+package retention
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockretentionDBAccessor is a mock of retentionDBAccessor interface.
+type MockretentionDBAccessor struct {
+	ctrl     *gomock.Controller
+	recorder *MockretentionDBAccessorMockRecorder
+}
+
+// MockretentionDBAccessorMockRecorder is the mock recorder for MockretentionDBAccessor.
+type MockretentionDBAccessorMockRecorder struct {
+	mock *MockretentionDBAccessor
+}
+
+// NewMockretentionDBAccessor creates a new mock instance.
+func NewMockretentionDBAccessor(ctrl *gomock.Controller) *MockretentionDBAccessor {
+	mock := &MockretentionDBAccessor{ctrl: ctrl}
+	mock.recorder = &MockretentionDBAccessorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockretentionDBAccessor) EXPECT() *MockretentionDBAccessorMockRecorder {
+	return m.recorder
+}
+
+// purge mocks base method.
+func (m *MockretentionDBAccessor) purge(ctx context.Context, step purgeStep, cutoff time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "purge", ctx, step, cutoff)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// purge indicates an expected call of purge.
+func (mr *MockretentionDBAccessorMockRecorder) purge(ctx, step, cutoff any) *MockretentionDBAccessorpurgeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "purge", reflect.TypeOf((*MockretentionDBAccessor)(nil).purge), ctx, step, cutoff)
+	return &MockretentionDBAccessorpurgeCall{Call: call}
+}
+
+// MockretentionDBAccessorpurgeCall wrap *gomock.Call
+type MockretentionDBAccessorpurgeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockretentionDBAccessorpurgeCall) Return(arg0 int, arg1 error) *MockretentionDBAccessorpurgeCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockretentionDBAccessorpurgeCall) Do(f func(context.Context, purgeStep, time.Time) (int, error)) *MockretentionDBAccessorpurgeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockretentionDBAccessorpurgeCall) DoAndReturn(f func(context.Context, purgeStep, time.Time) (int, error)) *MockretentionDBAccessorpurgeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// runInTx mocks base method.
+func (m *MockretentionDBAccessor) runInTx(ctx context.Context, fn func(retentionDBAccessor) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "runInTx", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// runInTx indicates an expected call of runInTx.
+func (mr *MockretentionDBAccessorMockRecorder) runInTx(ctx, fn any) *MockretentionDBAccessorrunInTxCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "runInTx", reflect.TypeOf((*MockretentionDBAccessor)(nil).runInTx), ctx, fn)
+	return &MockretentionDBAccessorrunInTxCall{Call: call}
+}
+
+// MockretentionDBAccessorrunInTxCall wrap *gomock.Call
+type MockretentionDBAccessorrunInTxCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockretentionDBAccessorrunInTxCall) Return(arg0 error) *MockretentionDBAccessorrunInTxCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockretentionDBAccessorrunInTxCall) Do(f func(context.Context, func(retentionDBAccessor) error) error) *MockretentionDBAccessorrunInTxCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockretentionDBAccessorrunInTxCall) DoAndReturn(f func(context.Context, func(retentionDBAccessor) error) error) *MockretentionDBAccessorrunInTxCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
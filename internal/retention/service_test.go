@@ -0,0 +1,92 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"kg/procurement/cmd/config"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_NewRetentionService(t *testing.T) {
+	_ = NewRetentionService(nil, nil, config.RetentionConfig{})
+}
+
+func TestRetentionService_Purge(t *testing.T) {
+	t.Parallel()
+
+	setup := func(t *testing.T) (*RetentionService, *MockretentionDBAccessor, time.Time) {
+		ctrl := gomock.NewController(t)
+		accessor := NewMockretentionDBAccessor(ctrl)
+		clockMock := clock.NewMock()
+		clockMock.Set(time.Date(2024, time.December, 12, 0, 0, 0, 0, time.UTC))
+
+		svc := &RetentionService{
+			retentionDBAccessor: accessor,
+			clock:               clockMock,
+			cfg:                 config.RetentionConfig{SoftDeleted: 90 * 24 * time.Hour},
+		}
+		return svc, accessor, time.Date(2024, time.September, 13, 0, 0, 0, 0, time.UTC)
+	}
+
+	// the unit of work runs on the accessor itself
+	expectTx := func(accessor *MockretentionDBAccessor) {
+		accessor.EXPECT().runInTx(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, fn func(tx retentionDBAccessor) error) error {
+				return fn(accessor)
+			})
+	}
+
+	t.Run("purges every table past the retention", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		svc, accessor, cutoff := setup(t)
+
+		expectTx(accessor)
+		for i, step := range purgeSteps {
+			accessor.EXPECT().purge(gomock.Any(), step, cutoff).Return(i, nil)
+		}
+
+		res, err := svc.Purge(context.Background(), false)
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(res).To(gomega.Equal([]PurgedTable{
+			{Table: "price", Rows: 0},
+			{Table: "product_vendor", Rows: 1},
+			{Table: "product", Rows: 2},
+			{Table: "vendor", Rows: 3},
+			{Table: "account", Rows: 4},
+		}))
+	})
+
+	t.Run("a dry run is rolled back", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		svc, accessor, cutoff := setup(t)
+
+		accessor.EXPECT().runInTx(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, fn func(tx retentionDBAccessor) error) error {
+				err := fn(accessor)
+				g.Expect(errors.Is(err, errDryRun)).To(gomega.BeTrue())
+				return err
+			})
+		accessor.EXPECT().purge(gomock.Any(), gomock.Any(), cutoff).Return(1, nil).Times(len(purgeSteps))
+
+		res, err := svc.Purge(context.Background(), true)
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(res).To(gomega.HaveLen(len(purgeSteps)))
+	})
+
+	t.Run("stops at the first failing table", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		svc, accessor, cutoff := setup(t)
+
+		expectTx(accessor)
+		accessor.EXPECT().purge(gomock.Any(), purgeSteps[0], cutoff).Return(0, errors.New("db error"))
+
+		res, err := svc.Purge(context.Background(), false)
+		g.Expect(err).To(gomega.MatchError("failed purging price: db error"))
+		g.Expect(res).To(gomega.BeNil())
+	})
+}
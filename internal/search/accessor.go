@@ -0,0 +1,132 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"kg/procurement/cmd/utils"
+	"kg/procurement/internal/common/database"
+	"strings"
+)
+
+// Each entity query reads the search terms from $1, the documents are built the
+// same way as the expression indexes so postgres is able to use them
+const (
+	vendorSearchQuery = `
+		SELECT
+			'vendor' AS type,
+			v.id,
+			coalesce(v.name, '') AS title,
+			ts_headline('simple', coalesce(v.name, '') || ' ' || coalesce(v.description, '') || ' ' || coalesce(v.bp_name, ''), q.query, 'StartSel=<mark>, StopSel=</mark>') AS snippet,
+			ts_rank(to_tsvector('simple', coalesce(v.name, '') || ' ' || coalesce(v.description, '') || ' ' || coalesce(v.bp_name, '')), q.query)
+				+ greatest(similarity(coalesce(v.name, ''), $1), similarity(coalesce(v.bp_name, ''), $1)) AS rank
+		FROM vendor v, q
+		WHERE v.deleted_at IS NULL
+			AND (to_tsvector('simple', coalesce(v.name, '') || ' ' || coalesce(v.description, '') || ' ' || coalesce(v.bp_name, '')) @@ q.query
+				OR v.name % $1
+				OR v.bp_name % $1)
+	`
+	productSearchQuery = `
+		SELECT
+			'product' AS type,
+			p.id,
+			p.name AS title,
+			ts_headline('simple', coalesce(p.name, '') || ' ' || coalesce(p.description, ''), q.query, 'StartSel=<mark>, StopSel=</mark>') AS snippet,
+			ts_rank(to_tsvector('simple', coalesce(p.name, '') || ' ' || coalesce(p.description, '')), q.query)
+				+ similarity(p.name, $1) AS rank
+		FROM product p, q
+		WHERE p.deleted_at IS NULL
+			AND (to_tsvector('simple', coalesce(p.name, '') || ' ' || coalesce(p.description, '')) @@ q.query
+				OR p.name % $1)
+	`
+	productCategorySearchQuery = `
+		SELECT
+			'product_category' AS type,
+			pc.id,
+			coalesce(pc.name, '') AS title,
+			ts_headline('simple', coalesce(pc.name, '') || ' ' || coalesce(pc.description, ''), q.query, 'StartSel=<mark>, StopSel=</mark>') AS snippet,
+			ts_rank(to_tsvector('simple', coalesce(pc.name, '') || ' ' || coalesce(pc.description, '')), q.query)
+				+ similarity(coalesce(pc.name, ''), $1) AS rank
+		FROM product_category pc, q
+		WHERE pc.deleted_at IS NULL
+			AND (to_tsvector('simple', coalesce(pc.name, '') || ' ' || coalesce(pc.description, '')) @@ q.query
+				OR pc.name % $1)
+	`
+)
+
+var entitySearchQueries = map[EntityType]string{
+	EntityVendor:          vendorSearchQuery,
+	EntityProduct:         productSearchQuery,
+	EntityProductCategory: productCategorySearchQuery,
+}
+
+type postgresSearchAccessor struct {
+	db database.DBConnector
+}
+
+type searchResultRow struct {
+	SearchResult
+	TotalEntries int `db:"total_entries"`
+}
+
+func buildSearchQuery(types []EntityType) string {
+	var subQueries []string
+	for _, entity := range types {
+		subQueries = append(subQueries, entitySearchQueries[entity])
+	}
+
+	return fmt.Sprintf(`
+		WITH q AS (SELECT websearch_to_tsquery('simple', $1) AS query)
+		SELECT
+			type,
+			id,
+			title,
+			snippet,
+			rank,
+			COUNT(*) OVER () AS total_entries
+		FROM (%s) results
+		ORDER BY rank DESC, id
+		LIMIT $2
+		OFFSET $3
+	`, strings.Join(subQueries, "UNION ALL"))
+}
+
+func (p *postgresSearchAccessor) Search(ctx context.Context, spec SearchSpec) (*AccessorSearchPaginationData, error) {
+	paginationArgs := database.BuildPaginationArgs(spec.PaginationSpec)
+
+	query := buildSearchQuery(spec.Types)
+	rows, err := p.db.QueryxContext(ctx, query, spec.Query, paginationArgs.Limit, paginationArgs.Offset)
+	if err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	totalEntries := 0
+	results := []SearchResult{}
+	for rows.Next() {
+		var row searchResultRow
+		if err := rows.StructScan(&row); err != nil {
+			utils.Logger.Error(err.Error())
+			return nil, err
+		}
+		totalEntries = row.TotalEntries
+		results = append(results, row.SearchResult)
+	}
+	if err := rows.Err(); err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	return &AccessorSearchPaginationData{
+		Results:  results,
+		Metadata: database.GeneratePaginationMetadata(spec.PaginationSpec, totalEntries),
+	}, nil
+}
+
+// newPostgresSearchAccessor is only accessible by the search package
+// entrypoint for other verticals should refer to the interface declared on service
+func newPostgresSearchAccessor(db database.DBConnector) *postgresSearchAccessor {
+	return &postgresSearchAccessor{
+		db: db,
+	}
+}
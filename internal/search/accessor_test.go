@@ -0,0 +1,163 @@
+package search
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"kg/procurement/internal/common/database"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/onsi/gomega"
+)
+
+func Test_newPostgresSearchAccessor(t *testing.T) {
+	_ = newPostgresSearchAccessor(nil)
+}
+
+func Test_buildSearchQuery(t *testing.T) {
+	t.Parallel()
+
+	t.Run("only includes requested entities", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		query := buildSearchQuery([]EntityType{EntityVendor})
+
+		g.Expect(query).To(gomega.ContainSubstring("FROM vendor v, q"))
+		g.Expect(query).ToNot(gomega.ContainSubstring("FROM product p, q"))
+		g.Expect(query).ToNot(gomega.ContainSubstring("UNION ALL"))
+	})
+
+	t.Run("unions every entity", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		query := buildSearchQuery(AllEntityTypes)
+
+		g.Expect(query).To(gomega.ContainSubstring("FROM vendor v, q"))
+		g.Expect(query).To(gomega.ContainSubstring("FROM product p, q"))
+		g.Expect(query).To(gomega.ContainSubstring("FROM product_category pc, q"))
+		g.Expect(regexp.MustCompile("UNION ALL").FindAllString(query, -1)).To(gomega.HaveLen(2))
+	})
+}
+
+func Test_Search(t *testing.T) {
+	t.Parallel()
+
+	spec := SearchSpec{
+		Query: "semen",
+		Types: AllEntityTypes,
+		PaginationSpec: database.PaginationSpec{
+			Limit: 10,
+			Page:  1,
+		},
+	}
+	columns := []string{"type", "id", "title", "snippet", "rank", "total_entries"}
+
+	t.Run("success", func(t *testing.T) {
+		c := setupSearchAccessorTestComponent(t)
+		defer c.db.Close()
+
+		rows := sqlmock.NewRows(columns).
+			AddRow("product", "1", "Semen Gresik", "<mark>Semen</mark> Gresik 50kg", 0.9, 12).
+			AddRow("vendor", "2", "Toko Semen", "Toko <mark>Semen</mark>", 0.5, 12)
+		c.mock.ExpectQuery(regexp.QuoteMeta(buildSearchQuery(spec.Types))).
+			WithArgs("semen", 10, 0).
+			WillReturnRows(rows)
+
+		res, err := c.accessor.Search(context.Background(), spec)
+
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(res.Results).To(gomega.Equal([]SearchResult{
+			{Type: "product", ID: "1", Title: "Semen Gresik", Snippet: "<mark>Semen</mark> Gresik 50kg", Rank: 0.9},
+			{Type: "vendor", ID: "2", Title: "Toko Semen", Snippet: "Toko <mark>Semen</mark>", Rank: 0.5},
+		}))
+		c.g.Expect(res.Metadata).To(gomega.Equal(database.PaginationMetadata{
+			TotalPage:    2,
+			CurrentPage:  1,
+			TotalEntries: 12,
+		}))
+	})
+
+	t.Run("success on empty result", func(t *testing.T) {
+		c := setupSearchAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectQuery(regexp.QuoteMeta(buildSearchQuery(spec.Types))).
+			WithArgs("semen", 10, 0).
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		res, err := c.accessor.Search(context.Background(), spec)
+
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(res.Results).To(gomega.BeEmpty())
+		c.g.Expect(res.Metadata.TotalEntries).To(gomega.Equal(0))
+	})
+
+	t.Run("error on query", func(t *testing.T) {
+		c := setupSearchAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectQuery(regexp.QuoteMeta(buildSearchQuery(spec.Types))).
+			WithArgs("semen", 10, 0).
+			WillReturnError(sql.ErrConnDone)
+
+		res, err := c.accessor.Search(context.Background(), spec)
+
+		c.g.Expect(err).To(gomega.Equal(sql.ErrConnDone))
+		c.g.Expect(res).To(gomega.BeNil())
+	})
+
+	t.Run("error on scan", func(t *testing.T) {
+		c := setupSearchAccessorTestComponent(t)
+		defer c.db.Close()
+
+		rows := sqlmock.NewRows([]string{"unknown"}).AddRow("1")
+		c.mock.ExpectQuery(regexp.QuoteMeta(buildSearchQuery(spec.Types))).
+			WithArgs("semen", 10, 0).
+			WillReturnRows(rows)
+
+		res, err := c.accessor.Search(context.Background(), spec)
+
+		c.g.Expect(err).ToNot(gomega.BeNil())
+		c.g.Expect(res).To(gomega.BeNil())
+	})
+
+	t.Run("error while iterating rows", func(t *testing.T) {
+		c := setupSearchAccessorTestComponent(t)
+		defer c.db.Close()
+
+		rows := sqlmock.NewRows(columns).
+			AddRow("product", "1", "Semen Gresik", "", 0.9, 1).
+			RowError(0, errors.New("row error"))
+		c.mock.ExpectQuery(regexp.QuoteMeta(buildSearchQuery(spec.Types))).
+			WithArgs("semen", 10, 0).
+			WillReturnRows(rows)
+
+		res, err := c.accessor.Search(context.Background(), spec)
+
+		c.g.Expect(err).ToNot(gomega.BeNil())
+		c.g.Expect(res).To(gomega.BeNil())
+	})
+}
+
+type searchAccessorTestComponent struct {
+	g        *gomega.WithT
+	mock     sqlmock.Sqlmock
+	db       *sql.DB
+	accessor *postgresSearchAccessor
+}
+
+func setupSearchAccessorTestComponent(t *testing.T) searchAccessorTestComponent {
+	g := gomega.NewWithT(t)
+	db, sqlMock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	return searchAccessorTestComponent{
+		g:        g,
+		mock:     sqlMock,
+		db:       db,
+		accessor: newPostgresSearchAccessor(sqlxDB),
+	}
+}
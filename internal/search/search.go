@@ -0,0 +1,56 @@
+package search
+
+import (
+	"errors"
+	"kg/procurement/internal/common/database"
+)
+
+type EntityType string
+
+const (
+	EntityVendor          EntityType = "vendor"
+	EntityProduct         EntityType = "product"
+	EntityProductCategory EntityType = "product_category"
+)
+
+func (e EntityType) String() string {
+	return string(e)
+}
+
+// AllEntityTypes is the default set of entities covered by a search
+var AllEntityTypes = []EntityType{EntityVendor, EntityProduct, EntityProductCategory}
+
+func ParseEntityType(entity string) (EntityType, error) {
+	switch EntityType(entity) {
+	case EntityVendor, EntityProduct, EntityProductCategory:
+		return EntityType(entity), nil
+	default:
+		return "", ErrInvalidEntityType
+	}
+}
+
+var (
+	ErrEmptyQuery        = errors.New("search query is required")
+	ErrInvalidEntityType = errors.New("invalid search entity type")
+)
+
+type SearchSpec struct {
+	Query string       `json:"query"`
+	Types []EntityType `json:"types"`
+	database.PaginationSpec
+}
+
+// SearchResult is a single ranked hit, Snippet wraps the matched
+// terms with <mark></mark> so clients can highlight them
+type SearchResult struct {
+	Type    string  `db:"type" json:"type"`
+	ID      string  `db:"id" json:"id"`
+	Title   string  `db:"title" json:"title"`
+	Snippet string  `db:"snippet" json:"snippet"`
+	Rank    float64 `db:"rank" json:"rank"`
+}
+
+type AccessorSearchPaginationData struct {
+	Results  []SearchResult              `json:"results"`
+	Metadata database.PaginationMetadata `json:"metadata"`
+}
@@ -0,0 +1,39 @@
+//go:generate mockgen -typed -source=service.go -destination=service_mock.go -package=search
+package search
+
+import (
+	"context"
+	"kg/procurement/internal/common/database"
+	"strings"
+)
+
+type searchDBAccessor interface {
+	Search(ctx context.Context, spec SearchSpec) (*AccessorSearchPaginationData, error)
+}
+
+type SearchService struct {
+	searchDBAccessor
+}
+
+// Search looks up vendors, products and product categories matching the query,
+// results are ranked by relevance and tolerate typos on names
+func (s *SearchService) Search(ctx context.Context, spec SearchSpec) (*AccessorSearchPaginationData, error) {
+	spec.Query = strings.TrimSpace(spec.Query)
+	if spec.Query == "" {
+		return nil, ErrEmptyQuery
+	}
+
+	if len(spec.Types) == 0 {
+		spec.Types = AllEntityTypes
+	}
+
+	return s.searchDBAccessor.Search(ctx, spec)
+}
+
+func NewSearchService(
+	conn database.DBConnector,
+) *SearchService {
+	return &SearchService{
+		searchDBAccessor: newPostgresSearchAccessor(conn),
+	}
+}
@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+//
+// Generated by this command:
+//
+//	mockgen -typed -source=service.go -destination=service_mock.go -package=search
+//
+
+// Package search is a generated GoMock package.
+package search
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MocksearchDBAccessor is a mock of searchDBAccessor interface.
+type MocksearchDBAccessor struct {
+	ctrl     *gomock.Controller
+	recorder *MocksearchDBAccessorMockRecorder
+}
+
+// MocksearchDBAccessorMockRecorder is the mock recorder for MocksearchDBAccessor.
+type MocksearchDBAccessorMockRecorder struct {
+	mock *MocksearchDBAccessor
+}
+
+// NewMocksearchDBAccessor creates a new mock instance.
+func NewMocksearchDBAccessor(ctrl *gomock.Controller) *MocksearchDBAccessor {
+	mock := &MocksearchDBAccessor{ctrl: ctrl}
+	mock.recorder = &MocksearchDBAccessorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocksearchDBAccessor) EXPECT() *MocksearchDBAccessorMockRecorder {
+	return m.recorder
+}
+
+// Search mocks base method.
+func (m *MocksearchDBAccessor) Search(ctx context.Context, spec SearchSpec) (*AccessorSearchPaginationData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, spec)
+	ret0, _ := ret[0].(*AccessorSearchPaginationData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MocksearchDBAccessorMockRecorder) Search(ctx, spec any) *MocksearchDBAccessorSearchCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MocksearchDBAccessor)(nil).Search), ctx, spec)
+	return &MocksearchDBAccessorSearchCall{Call: call}
+}
+
+// MocksearchDBAccessorSearchCall wrap *gomock.Call
+type MocksearchDBAccessorSearchCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MocksearchDBAccessorSearchCall) Return(arg0 *AccessorSearchPaginationData, arg1 error) *MocksearchDBAccessorSearchCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MocksearchDBAccessorSearchCall) Do(f func(context.Context, SearchSpec) (*AccessorSearchPaginationData, error)) *MocksearchDBAccessorSearchCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MocksearchDBAccessorSearchCall) DoAndReturn(f func(context.Context, SearchSpec) (*AccessorSearchPaginationData, error)) *MocksearchDBAccessorSearchCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -0,0 +1,97 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_NewSearchService(t *testing.T) {
+	_ = NewSearchService(nil)
+}
+
+func TestSearchService_Search(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mockSearchAccessor *MocksearchDBAccessor
+		subject            *SearchService
+	)
+
+	setup := func(t *testing.T) *gomega.GomegaWithT {
+		ctrl := gomock.NewController(t)
+		mockSearchAccessor = NewMocksearchDBAccessor(ctrl)
+		subject = &SearchService{
+			searchDBAccessor: mockSearchAccessor,
+		}
+		return gomega.NewWithT(t)
+	}
+
+	t.Run("success defaults to every entity", func(t *testing.T) {
+		g := setup(t)
+		ctx := context.Background()
+
+		expected := &AccessorSearchPaginationData{
+			Results: []SearchResult{{Type: "vendor", ID: "1"}},
+		}
+		mockSearchAccessor.EXPECT().
+			Search(ctx, SearchSpec{Query: "semen", Types: AllEntityTypes}).
+			Return(expected, nil)
+
+		res, err := subject.Search(ctx, SearchSpec{Query: "  semen "})
+
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(res).To(gomega.Equal(expected))
+	})
+
+	t.Run("success with requested entities", func(t *testing.T) {
+		g := setup(t)
+		ctx := context.Background()
+
+		spec := SearchSpec{Query: "semen", Types: []EntityType{EntityProduct}}
+		mockSearchAccessor.EXPECT().
+			Search(ctx, spec).
+			Return(&AccessorSearchPaginationData{}, nil)
+
+		_, err := subject.Search(ctx, spec)
+
+		g.Expect(err).To(gomega.BeNil())
+	})
+
+	t.Run("error on empty query", func(t *testing.T) {
+		g := setup(t)
+
+		res, err := subject.Search(context.Background(), SearchSpec{Query: "   "})
+
+		g.Expect(err).To(gomega.Equal(ErrEmptyQuery))
+		g.Expect(res).To(gomega.BeNil())
+	})
+
+	t.Run("error from accessor", func(t *testing.T) {
+		g := setup(t)
+		ctx := context.Background()
+
+		mockSearchAccessor.EXPECT().
+			Search(ctx, gomock.Any()).
+			Return(nil, errors.New("db error"))
+
+		res, err := subject.Search(ctx, SearchSpec{Query: "semen"})
+
+		g.Expect(err).ToNot(gomega.BeNil())
+		g.Expect(res).To(gomega.BeNil())
+	})
+}
+
+func TestParseEntityType(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	entity, err := ParseEntityType("product_category")
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(entity).To(gomega.Equal(EntityProductCategory))
+
+	_, err = ParseEntityType("price")
+	g.Expect(err).To(gomega.Equal(ErrInvalidEntityType))
+}
@@ -0,0 +1,151 @@
+package uom
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"kg/procurement/cmd/utils"
+	"kg/procurement/internal/common/database"
+
+	"github.com/benbjohnson/clock"
+	"github.com/lib/pq"
+)
+
+// foreignKeyViolation is the postgres error code of a missing referenced row
+const foreignKeyViolation = "23503"
+
+const (
+	getUnitsQuery = `
+		SELECT id, name, dimension, base_factor
+		FROM uom
+		WHERE deleted_at IS NULL
+		ORDER BY id
+	`
+
+	getProductConversionsQuery = `
+		SELECT id, product_id, from_uom_id, to_uom_id, factor, modified_date, modified_by
+		FROM product_uom_conversion
+		WHERE product_id = ANY($1)
+		ORDER BY product_id, from_uom_id, to_uom_id
+	`
+
+	// upsertProductConversionQuery replaces the factor of a product's pair, the id of
+	// an existing conversion is kept
+	upsertProductConversionQuery = `
+		INSERT INTO product_uom_conversion
+			(id, product_id, from_uom_id, to_uom_id, factor, modified_date, modified_by)
+		VALUES
+			(:id, :product_id, :from_uom_id, :to_uom_id, :factor, :modified_date, :modified_by)
+		ON CONFLICT (product_id, from_uom_id, to_uom_id) DO UPDATE SET
+			factor = EXCLUDED.factor,
+			modified_date = EXCLUDED.modified_date,
+			modified_by = EXCLUDED.modified_by
+		RETURNING id, product_id, from_uom_id, to_uom_id, factor, modified_date, modified_by
+	`
+
+	deleteProductConversionQuery = `DELETE FROM product_uom_conversion WHERE id = $1`
+
+	updateBaseFactorQuery = `
+		UPDATE uom SET
+			base_factor = $2,
+			modified_date = $3,
+			modified_by = $4
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+)
+
+type postgresUOMAccessor struct {
+	db    database.DBConnector
+	clock clock.Clock
+}
+
+func (p *postgresUOMAccessor) GetUnits(ctx context.Context) ([]Unit, error) {
+	res := []Unit{}
+	if err := p.db.SelectContext(ctx, &res, getUnitsQuery); err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+	return res, nil
+}
+
+func (p *postgresUOMAccessor) GetProductConversions(ctx context.Context, productIDs []string) ([]ProductConversion, error) {
+	res := []ProductConversion{}
+	if len(productIDs) == 0 {
+		return res, nil
+	}
+	if err := p.db.SelectContext(ctx, &res, getProductConversionsQuery, pq.Array(productIDs)); err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+	return res, nil
+}
+
+func (p *postgresUOMAccessor) UpsertProductConversion(ctx context.Context, conversion ProductConversion) (*ProductConversion, error) {
+	rows, err := p.db.NamedQueryContext(ctx, upsertProductConversionQuery, conversion)
+	if err != nil {
+		utils.Logger.Error(err.Error())
+		// the UOMs are checked beforehand, a foreign key violation is left to the product
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == foreignKeyViolation {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownProduct, conversion.ProductID)
+		}
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := ProductConversion{}
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			utils.Logger.Error(err.Error())
+			return nil, err
+		}
+		return nil, sql.ErrNoRows
+	}
+	if err := rows.StructScan(&res); err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+	return &res, nil
+}
+
+func (p *postgresUOMAccessor) DeleteProductConversion(ctx context.Context, id string) error {
+	result, err := p.db.ExecContext(ctx, deleteProductConversionQuery, id)
+	if err != nil {
+		utils.Logger.Error(err.Error())
+		return err
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		utils.Logger.Error(err.Error())
+		return err
+	}
+	if deleted == 0 {
+		return ErrConversionNotFound
+	}
+	return nil
+}
+
+func (p *postgresUOMAccessor) UpdateBaseFactor(ctx context.Context, uomID string, baseFactor float64, modifiedBy string) error {
+	result, err := p.db.ExecContext(ctx, updateBaseFactorQuery, uomID, baseFactor, p.clock.Now(), modifiedBy)
+	if err != nil {
+		utils.Logger.Error(err.Error())
+		return err
+	}
+	updated, err := result.RowsAffected()
+	if err != nil {
+		utils.Logger.Error(err.Error())
+		return err
+	}
+	if updated == 0 {
+		return ErrUnknownUOM
+	}
+	return nil
+}
+
+func newPostgresUOMAccessor(db database.DBConnector, clock clock.Clock) *postgresUOMAccessor {
+	return &postgresUOMAccessor{
+		db:    db,
+		clock: clock,
+	}
+}
@@ -0,0 +1,261 @@
+package uom
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/benbjohnson/clock"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/onsi/gomega"
+)
+
+func Test_newPostgresUOMAccessor(t *testing.T) {
+	_ = newPostgresUOMAccessor(nil, nil)
+}
+
+func Test_GetUnits(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		c := setupUOMAccessorTestComponent(t)
+		defer c.db.Close()
+
+		rows := sqlmock.NewRows([]string{"id", "name", "dimension", "base_factor"}).
+			AddRow("KG", "Kilogram", "mass", 1000.0).
+			AddRow("BOX", "Box", "count", nil)
+		c.mock.ExpectQuery(regexp.QuoteMeta(getUnitsQuery)).WillReturnRows(rows)
+
+		res, err := c.accessor.GetUnits(context.Background())
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(res).To(gomega.Equal([]Unit{
+			{ID: "KG", Name: "Kilogram", Dimension: "mass", BaseFactor: factor(1000)},
+			{ID: "BOX", Name: "Box", Dimension: "count"},
+		}))
+	})
+
+	t.Run("error on select", func(t *testing.T) {
+		c := setupUOMAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectQuery(regexp.QuoteMeta(getUnitsQuery)).WillReturnError(errors.New("error"))
+
+		res, err := c.accessor.GetUnits(context.Background())
+		c.g.Expect(err).ToNot(gomega.BeNil())
+		c.g.Expect(res).To(gomega.BeNil())
+	})
+}
+
+func Test_GetProductConversions(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, time.December, 8, 0, 0, 0, 0, time.UTC)
+
+	t.Run("success", func(t *testing.T) {
+		c := setupUOMAccessorTestComponent(t)
+		defer c.db.Close()
+
+		rows := sqlmock.NewRows([]string{"id", "product_id", "from_uom_id", "to_uom_id", "factor", "modified_date", "modified_by"}).
+			AddRow("1", "P1", "BOX", "PCS", 12.0, now, "admin")
+		c.mock.ExpectQuery(regexp.QuoteMeta(getProductConversionsQuery)).
+			WithArgs(pq.Array([]string{"P1", "P2"})).
+			WillReturnRows(rows)
+
+		res, err := c.accessor.GetProductConversions(context.Background(), []string{"P1", "P2"})
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(res).To(gomega.Equal([]ProductConversion{
+			{ID: "1", ProductID: "P1", FromUOMID: "BOX", ToUOMID: "PCS", Factor: 12, ModifiedDate: now, ModifiedBy: "admin"},
+		}))
+	})
+
+	t.Run("skips the query without products", func(t *testing.T) {
+		c := setupUOMAccessorTestComponent(t)
+		defer c.db.Close()
+
+		res, err := c.accessor.GetProductConversions(context.Background(), nil)
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(res).To(gomega.BeEmpty())
+		c.g.Expect(c.mock.ExpectationsWereMet()).To(gomega.BeNil())
+	})
+
+	t.Run("error on select", func(t *testing.T) {
+		c := setupUOMAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectQuery(regexp.QuoteMeta(getProductConversionsQuery)).WillReturnError(errors.New("error"))
+
+		res, err := c.accessor.GetProductConversions(context.Background(), []string{"P1"})
+		c.g.Expect(err).ToNot(gomega.BeNil())
+		c.g.Expect(res).To(gomega.BeNil())
+	})
+}
+
+func Test_UpsertProductConversion(t *testing.T) {
+	t.Parallel()
+
+	var (
+		now        = time.Date(2024, time.December, 8, 0, 0, 0, 0, time.UTC)
+		conversion = ProductConversion{
+			ID:           "1",
+			ProductID:    "P1",
+			FromUOMID:    "BOX",
+			ToUOMID:      "PCS",
+			Factor:       12,
+			ModifiedDate: now,
+			ModifiedBy:   "admin",
+		}
+	)
+
+	t.Run("success", func(t *testing.T) {
+		c := setupUOMAccessorTestComponent(t)
+		defer c.db.Close()
+
+		rows := sqlmock.NewRows([]string{"id", "product_id", "from_uom_id", "to_uom_id", "factor", "modified_date", "modified_by"}).
+			AddRow("0", "P1", "BOX", "PCS", 12.0, now, "admin")
+		c.mock.ExpectQuery(`INSERT INTO product_uom_conversion .* ON CONFLICT \(product_id, from_uom_id, to_uom_id\) DO UPDATE`).
+			WithArgs("1", "P1", "BOX", "PCS", 12.0, now, "admin").
+			WillReturnRows(rows)
+
+		res, err := c.accessor.UpsertProductConversion(context.Background(), conversion)
+		c.g.Expect(err).To(gomega.BeNil())
+
+		expected := conversion
+		expected.ID = "0"
+		c.g.Expect(res).To(gomega.Equal(&expected))
+	})
+
+	t.Run("unknown product", func(t *testing.T) {
+		c := setupUOMAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectQuery(`INSERT INTO product_uom_conversion`).WillReturnError(&pq.Error{Code: foreignKeyViolation})
+
+		res, err := c.accessor.UpsertProductConversion(context.Background(), conversion)
+		c.g.Expect(errors.Is(err, ErrUnknownProduct)).To(gomega.BeTrue())
+		c.g.Expect(res).To(gomega.BeNil())
+	})
+
+	t.Run("error without returned row", func(t *testing.T) {
+		c := setupUOMAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectQuery(`INSERT INTO product_uom_conversion`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		res, err := c.accessor.UpsertProductConversion(context.Background(), conversion)
+		c.g.Expect(errors.Is(err, sql.ErrNoRows)).To(gomega.BeTrue())
+		c.g.Expect(res).To(gomega.BeNil())
+	})
+
+	t.Run("error on query", func(t *testing.T) {
+		c := setupUOMAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectQuery(`INSERT INTO product_uom_conversion`).WillReturnError(errors.New("error"))
+
+		res, err := c.accessor.UpsertProductConversion(context.Background(), conversion)
+		c.g.Expect(err).ToNot(gomega.BeNil())
+		c.g.Expect(errors.Is(err, ErrUnknownProduct)).To(gomega.BeFalse())
+		c.g.Expect(res).To(gomega.BeNil())
+	})
+}
+
+func Test_DeleteProductConversion(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		c := setupUOMAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectExec(regexp.QuoteMeta(deleteProductConversionQuery)).
+			WithArgs("1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := c.accessor.DeleteProductConversion(context.Background(), "1")
+		c.g.Expect(err).To(gomega.BeNil())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		c := setupUOMAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectExec(regexp.QuoteMeta(deleteProductConversionQuery)).
+			WithArgs("1").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := c.accessor.DeleteProductConversion(context.Background(), "1")
+		c.g.Expect(errors.Is(err, ErrConversionNotFound)).To(gomega.BeTrue())
+	})
+
+	t.Run("error on exec", func(t *testing.T) {
+		c := setupUOMAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectExec(regexp.QuoteMeta(deleteProductConversionQuery)).WillReturnError(errors.New("error"))
+
+		err := c.accessor.DeleteProductConversion(context.Background(), "1")
+		c.g.Expect(err).ToNot(gomega.BeNil())
+	})
+}
+
+func Test_UpdateBaseFactor(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		c := setupUOMAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectExec(regexp.QuoteMeta(updateBaseFactorQuery)).
+			WithArgs("KG", 1000.0, c.accessor.clock.Now(), "admin").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := c.accessor.UpdateBaseFactor(context.Background(), "KG", 1000, "admin")
+		c.g.Expect(err).To(gomega.BeNil())
+	})
+
+	t.Run("unknown uom", func(t *testing.T) {
+		c := setupUOMAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectExec(regexp.QuoteMeta(updateBaseFactorQuery)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := c.accessor.UpdateBaseFactor(context.Background(), "KG", 1000, "admin")
+		c.g.Expect(errors.Is(err, ErrUnknownUOM)).To(gomega.BeTrue())
+	})
+
+	t.Run("error on exec", func(t *testing.T) {
+		c := setupUOMAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectExec(regexp.QuoteMeta(updateBaseFactorQuery)).WillReturnError(errors.New("error"))
+
+		err := c.accessor.UpdateBaseFactor(context.Background(), "KG", 1000, "admin")
+		c.g.Expect(err).ToNot(gomega.BeNil())
+	})
+}
+
+type uomAccessorTestComponent struct {
+	g        *gomega.WithT
+	mock     sqlmock.Sqlmock
+	db       *sql.DB
+	accessor *postgresUOMAccessor
+}
+
+func setupUOMAccessorTestComponent(t *testing.T) uomAccessorTestComponent {
+	g := gomega.NewWithT(t)
+	db, sqlMock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	return uomAccessorTestComponent{
+		g:        g,
+		mock:     sqlMock,
+		db:       db,
+		accessor: newPostgresUOMAccessor(sqlxDB, clock.NewMock()),
+	}
+}
@@ -0,0 +1,100 @@
+package uom
+
+import "fmt"
+
+// ConversionTable converts between the UOMs of a product. Units of a dimension
+// convert through their base factors and the conversions of the product add or
+// override a factor, conversions are chained when no single one applies
+type ConversionTable struct {
+	units       map[string]Unit
+	dimensions  map[string][]Unit
+	conversions map[string][]conversionEdge
+}
+
+// conversionEdge is a product conversion from a unit, one unit holds factor to
+type conversionEdge struct {
+	to     string
+	factor float64
+}
+
+// NewConversionTable builds the table of the units with the conversions of a single product,
+// each conversion also applies the other way around
+func NewConversionTable(units []Unit, conversions []ProductConversion) *ConversionTable {
+	t := &ConversionTable{
+		units:       make(map[string]Unit, len(units)),
+		dimensions:  map[string][]Unit{},
+		conversions: map[string][]conversionEdge{},
+	}
+	for _, unit := range units {
+		t.units[unit.ID] = unit
+		if unit.Dimension != "" && unit.BaseFactor != nil && *unit.BaseFactor > 0 {
+			t.dimensions[unit.Dimension] = append(t.dimensions[unit.Dimension], unit)
+		}
+	}
+	for _, c := range conversions {
+		if c.Factor <= 0 {
+			continue
+		}
+		t.conversions[c.FromUOMID] = append(t.conversions[c.FromUOMID], conversionEdge{to: c.ToUOMID, factor: c.Factor})
+		t.conversions[c.ToUOMID] = append(t.conversions[c.ToUOMID], conversionEdge{to: c.FromUOMID, factor: 1 / c.Factor})
+	}
+	return t
+}
+
+// Factor returns the amount of to one from holds. The shortest chain of conversions
+// is used, the conversions of the product are tried before the base factors so they
+// win over the dimension when both convert the same units
+func (t *ConversionTable) Factor(from string, to string) (float64, error) {
+	if from == to {
+		return 1, nil
+	}
+
+	factors := map[string]float64{from: 1}
+	queue := []string{from}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, edge := range t.edges(current) {
+			if _, seen := factors[edge.to]; seen {
+				continue
+			}
+			factors[edge.to] = factors[current] * edge.factor
+			if edge.to == to {
+				return factors[edge.to], nil
+			}
+			queue = append(queue, edge.to)
+		}
+	}
+	return 0, fmt.Errorf("%w: %s to %s", ErrNoConversion, from, to)
+}
+
+// Convert converts quantity of from into to
+func (t *ConversionTable) Convert(quantity float64, from string, to string) (*Conversion, error) {
+	factor, err := t.Factor(from, to)
+	if err != nil {
+		return nil, err
+	}
+	return &Conversion{
+		Quantity:  quantity * factor,
+		FromUOMID: from,
+		ToUOMID:   to,
+		Factor:    factor,
+	}, nil
+}
+
+// edges lists the units a unit converts into directly, product conversions first
+func (t *ConversionTable) edges(id string) []conversionEdge {
+	edges := append([]conversionEdge{}, t.conversions[id]...)
+
+	unit, ok := t.units[id]
+	if !ok || unit.BaseFactor == nil || *unit.BaseFactor <= 0 {
+		return edges
+	}
+	for _, other := range t.dimensions[unit.Dimension] {
+		if other.ID != id {
+			edges = append(edges, conversionEdge{to: other.ID, factor: *unit.BaseFactor / *other.BaseFactor})
+		}
+	}
+	return edges
+}
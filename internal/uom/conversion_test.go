@@ -0,0 +1,120 @@
+package uom
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func factor(f float64) *float64 {
+	return &f
+}
+
+var testUnits = []Unit{
+	{ID: "KG", Name: "Kilogram", Dimension: "mass", BaseFactor: factor(1000)},
+	{ID: "G", Name: "Gram", Dimension: "mass", BaseFactor: factor(1)},
+	{ID: "PCS", Name: "Piece", Dimension: "count", BaseFactor: factor(1)},
+	{ID: "DOZEN", Name: "Dozen", Dimension: "count", BaseFactor: factor(12)},
+	{ID: "BOX", Name: "Box", Dimension: "count"},
+}
+
+func TestConversionTable_Factor(t *testing.T) {
+	t.Parallel()
+
+	t.Run("same unit", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		res, err := NewConversionTable(nil, nil).Factor("BOX", "BOX")
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(res).To(gomega.Equal(1.0))
+	})
+
+	t.Run("converts within a dimension", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		table := NewConversionTable(testUnits, nil)
+
+		res, err := table.Factor("KG", "G")
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(res).To(gomega.Equal(1000.0))
+
+		res, err = table.Factor("G", "KG")
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(res).To(gomega.Equal(0.001))
+	})
+
+	t.Run("converts through a product conversion both ways", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		table := NewConversionTable(testUnits, []ProductConversion{
+			{ProductID: "P1", FromUOMID: "BOX", ToUOMID: "PCS", Factor: 12},
+		})
+
+		res, err := table.Factor("BOX", "PCS")
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(res).To(gomega.Equal(12.0))
+
+		res, err = table.Factor("PCS", "BOX")
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(res).To(gomega.BeNumerically("~", 1.0/12))
+	})
+
+	t.Run("chains a product conversion with a dimension", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		table := NewConversionTable(testUnits, []ProductConversion{
+			{ProductID: "P1", FromUOMID: "BOX", ToUOMID: "PCS", Factor: 24},
+		})
+
+		res, err := table.Factor("BOX", "DOZEN")
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(res).To(gomega.Equal(2.0))
+	})
+
+	t.Run("product conversion overrides the dimension", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		table := NewConversionTable(testUnits, []ProductConversion{
+			{ProductID: "P1", FromUOMID: "DOZEN", ToUOMID: "PCS", Factor: 13},
+		})
+
+		res, err := table.Factor("DOZEN", "PCS")
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(res).To(gomega.Equal(13.0))
+	})
+
+	t.Run("ignores factors that aren't positive", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		table := NewConversionTable(testUnits, []ProductConversion{
+			{ProductID: "P1", FromUOMID: "BOX", ToUOMID: "PCS", Factor: 0},
+		})
+
+		_, err := table.Factor("BOX", "PCS")
+		g.Expect(errors.Is(err, ErrNoConversion)).To(gomega.BeTrue())
+	})
+
+	t.Run("no conversion across dimensions", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		res, err := NewConversionTable(testUnits, nil).Factor("KG", "PCS")
+		g.Expect(errors.Is(err, ErrNoConversion)).To(gomega.BeTrue())
+		g.Expect(res).To(gomega.Equal(0.0))
+	})
+}
+
+func TestConversionTable_Convert(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		res, err := NewConversionTable(testUnits, nil).Convert(2.5, "KG", "G")
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(res).To(gomega.Equal(&Conversion{Quantity: 2500, FromUOMID: "KG", ToUOMID: "G", Factor: 1000}))
+	})
+
+	t.Run("error without conversion", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		res, err := NewConversionTable(testUnits, nil).Convert(1, "BOX", "PCS")
+		g.Expect(errors.Is(err, ErrNoConversion)).To(gomega.BeTrue())
+		g.Expect(res).To(gomega.BeNil())
+	})
+}
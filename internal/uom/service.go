@@ -0,0 +1,122 @@
+//go:generate mockgen -typed -source=service.go -destination=service_mock.go -package=uom
+package uom
+
+import (
+	"context"
+	"fmt"
+	"kg/procurement/cmd/utils"
+	"kg/procurement/internal/common/database"
+	"kg/procurement/internal/common/helper"
+
+	"github.com/benbjohnson/clock"
+)
+
+type uomDBAccessor interface {
+	GetUnits(ctx context.Context) ([]Unit, error)
+	GetProductConversions(ctx context.Context, productIDs []string) ([]ProductConversion, error)
+	UpsertProductConversion(ctx context.Context, conversion ProductConversion) (*ProductConversion, error)
+	DeleteProductConversion(ctx context.Context, id string) error
+	UpdateBaseFactor(ctx context.Context, uomID string, baseFactor float64, modifiedBy string) error
+}
+
+type UOMService struct {
+	uomDBAccessor
+	clock clock.Clock
+}
+
+// ConversionTables returns the conversion table of each product, the units are
+// read once for all of them
+func (u *UOMService) ConversionTables(ctx context.Context, productIDs []string) (map[string]*ConversionTable, error) {
+	units, err := u.uomDBAccessor.GetUnits(ctx)
+	if err != nil {
+		return nil, err
+	}
+	conversions, err := u.uomDBAccessor.GetProductConversions(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	byProduct := map[string][]ProductConversion{}
+	for _, c := range conversions {
+		byProduct[c.ProductID] = append(byProduct[c.ProductID], c)
+	}
+
+	tables := make(map[string]*ConversionTable, len(productIDs))
+	for _, productID := range productIDs {
+		tables[productID] = NewConversionTable(units, byProduct[productID])
+	}
+	return tables, nil
+}
+
+// Convert converts quantity of from into to for a product, without a product
+// only the conversions of the dimensions apply
+func (u *UOMService) Convert(ctx context.Context, productID string, quantity float64, from string, to string) (*Conversion, error) {
+	tables, err := u.ConversionTables(ctx, []string{productID})
+	if err != nil {
+		return nil, err
+	}
+	return tables[productID].Convert(quantity, from, to)
+}
+
+// CreateProductConversion records the conversion of a product's pair of UOMs,
+// replacing the factor of the pair when it was already recorded
+func (u *UOMService) CreateProductConversion(ctx context.Context, spec PostProductConversionSpec) (*ProductConversion, error) {
+	if spec.FromUOMID == spec.ToUOMID {
+		return nil, ErrSameUOM
+	}
+	if spec.Factor <= 0 {
+		return nil, ErrInvalidFactor
+	}
+
+	units, err := u.uomDBAccessor.GetUnits(ctx)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]bool, len(units))
+	for _, unit := range units {
+		known[unit.ID] = true
+	}
+	for _, id := range []string{spec.FromUOMID, spec.ToUOMID} {
+		if !known[id] {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownUOM, id)
+		}
+	}
+
+	id, err := helper.GenerateRandomID()
+	if err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	return u.uomDBAccessor.UpsertProductConversion(ctx, ProductConversion{
+		ID:           id,
+		ProductID:    spec.ProductID,
+		FromUOMID:    spec.FromUOMID,
+		ToUOMID:      spec.ToUOMID,
+		Factor:       spec.Factor,
+		ModifiedDate: u.clock.Now(),
+		ModifiedBy:   spec.ModifiedBy,
+	})
+}
+
+func (u *UOMService) GetProductConversions(ctx context.Context, productID string) ([]ProductConversion, error) {
+	return u.uomDBAccessor.GetProductConversions(ctx, []string{productID})
+}
+
+// UpdateBaseFactor sets the amount of the base unit of its dimension a UOM holds
+func (u *UOMService) UpdateBaseFactor(ctx context.Context, uomID string, spec PutBaseFactorSpec) error {
+	if spec.BaseFactor <= 0 {
+		return ErrInvalidFactor
+	}
+	return u.uomDBAccessor.UpdateBaseFactor(ctx, uomID, spec.BaseFactor, spec.ModifiedBy)
+}
+
+func NewUOMService(
+	conn database.DBConnector,
+	clock clock.Clock,
+) *UOMService {
+	return &UOMService{
+		uomDBAccessor: newPostgresUOMAccessor(conn, clock),
+		clock:         clock,
+	}
+}
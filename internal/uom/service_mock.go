@@ -0,0 +1,233 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+//
+// Generated by this command:
+//
+//	mockgen -typed -source=service.go -destination=service_mock.go -package=uom
+//
+
+// Package uom is a generated GoMock package.
+package uom
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockuomDBAccessor is a mock of uomDBAccessor interface.
+type MockuomDBAccessor struct {
+	ctrl     *gomock.Controller
+	recorder *MockuomDBAccessorMockRecorder
+}
+
+// MockuomDBAccessorMockRecorder is the mock recorder for MockuomDBAccessor.
+type MockuomDBAccessorMockRecorder struct {
+	mock *MockuomDBAccessor
+}
+
+// NewMockuomDBAccessor creates a new mock instance.
+func NewMockuomDBAccessor(ctrl *gomock.Controller) *MockuomDBAccessor {
+	mock := &MockuomDBAccessor{ctrl: ctrl}
+	mock.recorder = &MockuomDBAccessorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockuomDBAccessor) EXPECT() *MockuomDBAccessorMockRecorder {
+	return m.recorder
+}
+
+// DeleteProductConversion mocks base method.
+func (m *MockuomDBAccessor) DeleteProductConversion(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProductConversion", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProductConversion indicates an expected call of DeleteProductConversion.
+func (mr *MockuomDBAccessorMockRecorder) DeleteProductConversion(ctx, id any) *MockuomDBAccessorDeleteProductConversionCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProductConversion", reflect.TypeOf((*MockuomDBAccessor)(nil).DeleteProductConversion), ctx, id)
+	return &MockuomDBAccessorDeleteProductConversionCall{Call: call}
+}
+
+// MockuomDBAccessorDeleteProductConversionCall wrap *gomock.Call
+type MockuomDBAccessorDeleteProductConversionCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockuomDBAccessorDeleteProductConversionCall) Return(arg0 error) *MockuomDBAccessorDeleteProductConversionCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockuomDBAccessorDeleteProductConversionCall) Do(f func(context.Context, string) error) *MockuomDBAccessorDeleteProductConversionCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockuomDBAccessorDeleteProductConversionCall) DoAndReturn(f func(context.Context, string) error) *MockuomDBAccessorDeleteProductConversionCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetProductConversions mocks base method.
+func (m *MockuomDBAccessor) GetProductConversions(ctx context.Context, productIDs []string) ([]ProductConversion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductConversions", ctx, productIDs)
+	ret0, _ := ret[0].([]ProductConversion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductConversions indicates an expected call of GetProductConversions.
+func (mr *MockuomDBAccessorMockRecorder) GetProductConversions(ctx, productIDs any) *MockuomDBAccessorGetProductConversionsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductConversions", reflect.TypeOf((*MockuomDBAccessor)(nil).GetProductConversions), ctx, productIDs)
+	return &MockuomDBAccessorGetProductConversionsCall{Call: call}
+}
+
+// MockuomDBAccessorGetProductConversionsCall wrap *gomock.Call
+type MockuomDBAccessorGetProductConversionsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockuomDBAccessorGetProductConversionsCall) Return(arg0 []ProductConversion, arg1 error) *MockuomDBAccessorGetProductConversionsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockuomDBAccessorGetProductConversionsCall) Do(f func(context.Context, []string) ([]ProductConversion, error)) *MockuomDBAccessorGetProductConversionsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockuomDBAccessorGetProductConversionsCall) DoAndReturn(f func(context.Context, []string) ([]ProductConversion, error)) *MockuomDBAccessorGetProductConversionsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetUnits mocks base method.
+func (m *MockuomDBAccessor) GetUnits(ctx context.Context) ([]Unit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnits", ctx)
+	ret0, _ := ret[0].([]Unit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnits indicates an expected call of GetUnits.
+func (mr *MockuomDBAccessorMockRecorder) GetUnits(ctx any) *MockuomDBAccessorGetUnitsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnits", reflect.TypeOf((*MockuomDBAccessor)(nil).GetUnits), ctx)
+	return &MockuomDBAccessorGetUnitsCall{Call: call}
+}
+
+// MockuomDBAccessorGetUnitsCall wrap *gomock.Call
+type MockuomDBAccessorGetUnitsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockuomDBAccessorGetUnitsCall) Return(arg0 []Unit, arg1 error) *MockuomDBAccessorGetUnitsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockuomDBAccessorGetUnitsCall) Do(f func(context.Context) ([]Unit, error)) *MockuomDBAccessorGetUnitsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockuomDBAccessorGetUnitsCall) DoAndReturn(f func(context.Context) ([]Unit, error)) *MockuomDBAccessorGetUnitsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// UpdateBaseFactor mocks base method.
+func (m *MockuomDBAccessor) UpdateBaseFactor(ctx context.Context, uomID string, baseFactor float64, modifiedBy string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBaseFactor", ctx, uomID, baseFactor, modifiedBy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBaseFactor indicates an expected call of UpdateBaseFactor.
+func (mr *MockuomDBAccessorMockRecorder) UpdateBaseFactor(ctx, uomID, baseFactor, modifiedBy any) *MockuomDBAccessorUpdateBaseFactorCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBaseFactor", reflect.TypeOf((*MockuomDBAccessor)(nil).UpdateBaseFactor), ctx, uomID, baseFactor, modifiedBy)
+	return &MockuomDBAccessorUpdateBaseFactorCall{Call: call}
+}
+
+// MockuomDBAccessorUpdateBaseFactorCall wrap *gomock.Call
+type MockuomDBAccessorUpdateBaseFactorCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockuomDBAccessorUpdateBaseFactorCall) Return(arg0 error) *MockuomDBAccessorUpdateBaseFactorCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockuomDBAccessorUpdateBaseFactorCall) Do(f func(context.Context, string, float64, string) error) *MockuomDBAccessorUpdateBaseFactorCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockuomDBAccessorUpdateBaseFactorCall) DoAndReturn(f func(context.Context, string, float64, string) error) *MockuomDBAccessorUpdateBaseFactorCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// UpsertProductConversion mocks base method.
+func (m *MockuomDBAccessor) UpsertProductConversion(ctx context.Context, conversion ProductConversion) (*ProductConversion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertProductConversion", ctx, conversion)
+	ret0, _ := ret[0].(*ProductConversion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertProductConversion indicates an expected call of UpsertProductConversion.
+func (mr *MockuomDBAccessorMockRecorder) UpsertProductConversion(ctx, conversion any) *MockuomDBAccessorUpsertProductConversionCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertProductConversion", reflect.TypeOf((*MockuomDBAccessor)(nil).UpsertProductConversion), ctx, conversion)
+	return &MockuomDBAccessorUpsertProductConversionCall{Call: call}
+}
+
+// MockuomDBAccessorUpsertProductConversionCall wrap *gomock.Call
+type MockuomDBAccessorUpsertProductConversionCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockuomDBAccessorUpsertProductConversionCall) Return(arg0 *ProductConversion, arg1 error) *MockuomDBAccessorUpsertProductConversionCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockuomDBAccessorUpsertProductConversionCall) Do(f func(context.Context, ProductConversion) (*ProductConversion, error)) *MockuomDBAccessorUpsertProductConversionCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockuomDBAccessorUpsertProductConversionCall) DoAndReturn(f func(context.Context, ProductConversion) (*ProductConversion, error)) *MockuomDBAccessorUpsertProductConversionCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
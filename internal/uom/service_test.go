@@ -0,0 +1,222 @@
+package uom
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/benbjohnson/clock"
+	"github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_NewUOMService(t *testing.T) {
+	_ = NewUOMService(nil, nil)
+}
+
+func TestUOMService_ConversionTables(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mockUOMAccessor *MockuomDBAccessor
+		subject         *UOMService
+	)
+
+	setup := func(t *testing.T) *gomega.GomegaWithT {
+		ctrl := gomock.NewController(t)
+		mockUOMAccessor = NewMockuomDBAccessor(ctrl)
+		subject = &UOMService{
+			uomDBAccessor: mockUOMAccessor,
+			clock:         clock.NewMock(),
+		}
+		return gomega.NewWithT(t)
+	}
+
+	t.Run("groups the conversions by product", func(t *testing.T) {
+		g := setup(t)
+		ctx := context.Background()
+
+		mockUOMAccessor.EXPECT().GetUnits(ctx).Return(testUnits, nil)
+		mockUOMAccessor.EXPECT().GetProductConversions(ctx, []string{"P1", "P2"}).Return([]ProductConversion{
+			{ProductID: "P1", FromUOMID: "BOX", ToUOMID: "PCS", Factor: 12},
+			{ProductID: "P2", FromUOMID: "BOX", ToUOMID: "PCS", Factor: 6},
+		}, nil)
+
+		res, err := subject.ConversionTables(ctx, []string{"P1", "P2"})
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(res).To(gomega.HaveLen(2))
+
+		p1, err := res["P1"].Factor("BOX", "PCS")
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(p1).To(gomega.Equal(12.0))
+
+		p2, err := res["P2"].Factor("BOX", "DOZEN")
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(p2).To(gomega.Equal(0.5))
+	})
+
+	t.Run("converts a quantity of a product", func(t *testing.T) {
+		g := setup(t)
+		ctx := context.Background()
+
+		mockUOMAccessor.EXPECT().GetUnits(ctx).Return(testUnits, nil)
+		mockUOMAccessor.EXPECT().GetProductConversions(ctx, []string{"P1"}).Return([]ProductConversion{
+			{ProductID: "P1", FromUOMID: "BOX", ToUOMID: "PCS", Factor: 12},
+		}, nil)
+
+		res, err := subject.Convert(ctx, "P1", 3, "BOX", "PCS")
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(res).To(gomega.Equal(&Conversion{Quantity: 36, FromUOMID: "BOX", ToUOMID: "PCS", Factor: 12}))
+	})
+
+	t.Run("error on get units", func(t *testing.T) {
+		g := setup(t)
+		ctx := context.Background()
+
+		mockUOMAccessor.EXPECT().GetUnits(ctx).Return(nil, errors.New("error"))
+
+		res, err := subject.ConversionTables(ctx, []string{"P1"})
+		g.Expect(err).ToNot(gomega.BeNil())
+		g.Expect(res).To(gomega.BeNil())
+	})
+
+	t.Run("error on get conversions", func(t *testing.T) {
+		g := setup(t)
+		ctx := context.Background()
+
+		mockUOMAccessor.EXPECT().GetUnits(ctx).Return(testUnits, nil)
+		mockUOMAccessor.EXPECT().GetProductConversions(ctx, []string{"P1"}).Return(nil, errors.New("error"))
+
+		res, err := subject.ConversionTables(ctx, []string{"P1"})
+		g.Expect(err).ToNot(gomega.BeNil())
+		g.Expect(res).To(gomega.BeNil())
+	})
+}
+
+func TestUOMService_CreateProductConversion(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mockUOMAccessor *MockuomDBAccessor
+		subject         *UOMService
+		spec            = PostProductConversionSpec{
+			ProductID:  "P1",
+			FromUOMID:  "BOX",
+			ToUOMID:    "PCS",
+			Factor:     12,
+			ModifiedBy: "admin",
+		}
+	)
+
+	setup := func(t *testing.T) *gomega.GomegaWithT {
+		ctrl := gomock.NewController(t)
+		mockUOMAccessor = NewMockuomDBAccessor(ctrl)
+		subject = &UOMService{
+			uomDBAccessor: mockUOMAccessor,
+			clock:         clock.NewMock(),
+		}
+		return gomega.NewWithT(t)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		g := setup(t)
+		ctx := context.Background()
+
+		mockUOMAccessor.EXPECT().GetUnits(ctx).Return(testUnits, nil)
+		mockUOMAccessor.EXPECT().UpsertProductConversion(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, c ProductConversion) (*ProductConversion, error) {
+				g.Expect(c.ID).ToNot(gomega.BeEmpty())
+				g.Expect(c.ProductID).To(gomega.Equal("P1"))
+				g.Expect(c.Factor).To(gomega.Equal(12.0))
+				g.Expect(c.ModifiedDate).To(gomega.Equal(subject.clock.Now()))
+				return &c, nil
+			})
+
+		res, err := subject.CreateProductConversion(ctx, spec)
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(res.FromUOMID).To(gomega.Equal("BOX"))
+	})
+
+	t.Run("rejects the same uom", func(t *testing.T) {
+		g := setup(t)
+
+		sameUOM := spec
+		sameUOM.ToUOMID = "BOX"
+
+		res, err := subject.CreateProductConversion(context.Background(), sameUOM)
+		g.Expect(errors.Is(err, ErrSameUOM)).To(gomega.BeTrue())
+		g.Expect(res).To(gomega.BeNil())
+	})
+
+	t.Run("rejects a factor that isn't positive", func(t *testing.T) {
+		g := setup(t)
+
+		negative := spec
+		negative.Factor = -1
+
+		res, err := subject.CreateProductConversion(context.Background(), negative)
+		g.Expect(errors.Is(err, ErrInvalidFactor)).To(gomega.BeTrue())
+		g.Expect(res).To(gomega.BeNil())
+	})
+
+	t.Run("rejects an unknown uom", func(t *testing.T) {
+		g := setup(t)
+		ctx := context.Background()
+
+		unknown := spec
+		unknown.ToUOMID = "CRATE"
+		mockUOMAccessor.EXPECT().GetUnits(ctx).Return(testUnits, nil)
+
+		res, err := subject.CreateProductConversion(ctx, unknown)
+		g.Expect(errors.Is(err, ErrUnknownUOM)).To(gomega.BeTrue())
+		g.Expect(err.Error()).To(gomega.ContainSubstring("CRATE"))
+		g.Expect(res).To(gomega.BeNil())
+	})
+
+	t.Run("error on get units", func(t *testing.T) {
+		g := setup(t)
+		ctx := context.Background()
+
+		mockUOMAccessor.EXPECT().GetUnits(ctx).Return(nil, errors.New("error"))
+
+		res, err := subject.CreateProductConversion(ctx, spec)
+		g.Expect(err).ToNot(gomega.BeNil())
+		g.Expect(res).To(gomega.BeNil())
+	})
+}
+
+func TestUOMService_UpdateBaseFactor(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mockUOMAccessor *MockuomDBAccessor
+		subject         *UOMService
+	)
+
+	setup := func(t *testing.T) *gomega.GomegaWithT {
+		ctrl := gomock.NewController(t)
+		mockUOMAccessor = NewMockuomDBAccessor(ctrl)
+		subject = &UOMService{
+			uomDBAccessor: mockUOMAccessor,
+			clock:         clock.NewMock(),
+		}
+		return gomega.NewWithT(t)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		g := setup(t)
+		ctx := context.Background()
+
+		mockUOMAccessor.EXPECT().UpdateBaseFactor(ctx, "KG", 1000.0, "admin").Return(nil)
+
+		err := subject.UpdateBaseFactor(ctx, "KG", PutBaseFactorSpec{BaseFactor: 1000, ModifiedBy: "admin"})
+		g.Expect(err).To(gomega.BeNil())
+	})
+
+	t.Run("rejects a factor that isn't positive", func(t *testing.T) {
+		g := setup(t)
+
+		err := subject.UpdateBaseFactor(context.Background(), "KG", PutBaseFactorSpec{BaseFactor: 0})
+		g.Expect(errors.Is(err, ErrInvalidFactor)).To(gomega.BeTrue())
+	})
+}
@@ -0,0 +1,59 @@
+package uom
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrNoConversion       = errors.New("no uom conversion")
+	ErrUnknownUOM         = errors.New("unknown uom")
+	ErrUnknownProduct     = errors.New("unknown product")
+	ErrSameUOM            = errors.New("conversion uoms must differ")
+	ErrInvalidFactor      = errors.New("conversion factor must be greater than zero")
+	ErrConversionNotFound = errors.New("uom conversion not found")
+)
+
+// Unit is a UOM as far as conversions are concerned, BaseFactor is the amount of
+// the base unit of its dimension one unit holds. Units without a base factor only
+// convert through the conversions of a product
+type Unit struct {
+	ID         string   `db:"id" json:"id"`
+	Name       string   `db:"name" json:"name"`
+	Dimension  string   `db:"dimension" json:"dimension"`
+	BaseFactor *float64 `db:"base_factor" json:"base_factor"`
+}
+
+// ProductConversion converts the UOMs of a single product, one FromUOMID holds
+// Factor ToUOMID. It overrides the conversion of the dimensions and converts
+// between UOMs of different dimensions, such as a box of 12 pieces
+type ProductConversion struct {
+	ID           string    `db:"id" json:"id"`
+	ProductID    string    `db:"product_id" json:"product_id"`
+	FromUOMID    string    `db:"from_uom_id" json:"from_uom_id"`
+	ToUOMID      string    `db:"to_uom_id" json:"to_uom_id"`
+	Factor       float64   `db:"factor" json:"factor"`
+	ModifiedDate time.Time `db:"modified_date" json:"modified_date"`
+	ModifiedBy   string    `db:"modified_by" json:"modified_by"`
+}
+
+type PostProductConversionSpec struct {
+	ProductID  string  `json:"product_id" binding:"required"`
+	FromUOMID  string  `json:"from_uom_id" binding:"required"`
+	ToUOMID    string  `json:"to_uom_id" binding:"required"`
+	Factor     float64 `json:"factor" binding:"required"`
+	ModifiedBy string  `json:"modified_by"`
+}
+
+type PutBaseFactorSpec struct {
+	BaseFactor float64 `json:"base_factor" binding:"required"`
+	ModifiedBy string  `json:"modified_by"`
+}
+
+// Conversion is a quantity converted to another UOM along with the factor used
+type Conversion struct {
+	Quantity  float64 `json:"quantity"`
+	FromUOMID string  `json:"from_uom_id"`
+	ToUOMID   string  `json:"to_uom_id"`
+	Factor    float64 `json:"factor"`
+}
@@ -2,10 +2,14 @@ package vendors
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"kg/procurement/cmd/utils"
+	"kg/procurement/internal/audit"
 	"kg/procurement/internal/common/database"
 	"strings"
+	"time"
 
 	"github.com/benbjohnson/clock"
 	"github.com/jmoiron/sqlx"
@@ -23,8 +27,24 @@ const (
 		VALUES 
 			(:id, :name, :email, :description, :bp_id, :bp_name, :rating, :area_group_id, :area_group_name, :sap_code, :modified_date, :modified_by, :dt)
 	`
-	getBulkByID          = `SELECT * FROM vendor WHERE id IN (?)`
-	getAllLocationsQuery = `SELECT DISTINCT area_group_name FROM vendor`
+	// the seeder upserts by id so fixtures can be loaded again
+	upsertVendor = insertVendor + `
+		ON CONFLICT (id) DO UPDATE SET
+			name = EXCLUDED.name,
+			email = EXCLUDED.email,
+			description = EXCLUDED.description,
+			bp_id = EXCLUDED.bp_id,
+			bp_name = EXCLUDED.bp_name,
+			rating = EXCLUDED.rating,
+			area_group_id = EXCLUDED.area_group_id,
+			area_group_name = EXCLUDED.area_group_name,
+			sap_code = EXCLUDED.sap_code,
+			modified_date = EXCLUDED.modified_date,
+			modified_by = EXCLUDED.modified_by,
+			dt = EXCLUDED.dt
+	`
+	getBulkByID          = `SELECT * FROM vendor WHERE id IN (?) AND deleted_at IS NULL`
+	getAllLocationsQuery = `SELECT DISTINCT area_group_name FROM vendor WHERE deleted_at IS NULL`
 	getBulkByProductName = `
 		SELECT
 			v.*
@@ -38,18 +58,114 @@ const (
 			product p ON p.id = pv.product_id
 		WHERE
 			p.name = :product_name
+			AND v.status = 'active'
+			AND v.deleted_at IS NULL
+			AND pp.deleted_at IS NULL
+			AND pv.deleted_at IS NULL
+			AND p.deleted_at IS NULL
 	`
 	createEvaluationQuery = `
 		INSERT INTO vendor_evaluation
-			(id, vendor_id, kesesuaian_produk, kualitas_produk, ketepatan_waktu_pengiriman, kompetitifitas_harga, responsivitas_kemampuan_komunikasi, kemampuan_dalam_menangani_masalah, kelengkapan_barang, harga, term_of_payment, reputasi, ketersediaan_barang, kualitas_layanan_after_services, modified_date)
+			(id, vendor_id, kesesuaian_produk, kualitas_produk, ketepatan_waktu_pengiriman, kompetitifitas_harga, responsivitas_kemampuan_komunikasi, kemampuan_dalam_menangani_masalah, kelengkapan_barang, harga, term_of_payment, reputasi, ketersediaan_barang, kualitas_layanan_after_services, modified_date, modified_by)
 		VALUES
-			(:id, :vendor_id, :kesesuaian_produk, :kualitas_produk, :ketepatan_waktu_pengiriman, :kompetitifitas_harga, :responsivitas_kemampuan_komunikasi, :kemampuan_dalam_menangani_masalah, :kelengkapan_barang, :harga, :term_of_payment, :reputasi, :ketersediaan_barang, :kualitas_layanan_after_services, :modified_date)
+			(:id, :vendor_id, :kesesuaian_produk, :kualitas_produk, :ketepatan_waktu_pengiriman, :kompetitifitas_harga, :responsivitas_kemampuan_komunikasi, :kemampuan_dalam_menangani_masalah, :kelengkapan_barang, :harga, :term_of_payment, :reputasi, :ketersediaan_barang, :kualitas_layanan_after_services, :modified_date, :modified_by)
+	`
+	upsertEvaluationQuery = createEvaluationQuery + `
+		ON CONFLICT (id) DO UPDATE SET
+			vendor_id = EXCLUDED.vendor_id,
+			kesesuaian_produk = EXCLUDED.kesesuaian_produk,
+			kualitas_produk = EXCLUDED.kualitas_produk,
+			ketepatan_waktu_pengiriman = EXCLUDED.ketepatan_waktu_pengiriman,
+			kompetitifitas_harga = EXCLUDED.kompetitifitas_harga,
+			responsivitas_kemampuan_komunikasi = EXCLUDED.responsivitas_kemampuan_komunikasi,
+			kemampuan_dalam_menangani_masalah = EXCLUDED.kemampuan_dalam_menangani_masalah,
+			kelengkapan_barang = EXCLUDED.kelengkapan_barang,
+			harga = EXCLUDED.harga,
+			term_of_payment = EXCLUDED.term_of_payment,
+			reputasi = EXCLUDED.reputasi,
+			ketersediaan_barang = EXCLUDED.ketersediaan_barang,
+			kualitas_layanan_after_services = EXCLUDED.kualitas_layanan_after_services,
+			modified_date = EXCLUDED.modified_date,
+			modified_by = EXCLUDED.modified_by
+	`
+	updateVendorStatusQuery = `
+		UPDATE vendor
+		SET status = $2, modified_date = $3, modified_by = $4
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+	// lockVendorStatusQuery holds the vendor row until the transaction ends so status
+	// changes of the same vendor are applied one after the other
+	lockVendorStatusQuery = `
+		SELECT status
+		FROM vendor
+		WHERE id = $1 AND deleted_at IS NULL
+		FOR UPDATE
+	`
+	hasPendingStatusChangeQuery = `
+		SELECT EXISTS (
+			SELECT 1 FROM vendor_status_history WHERE vendor_id = $1 AND approval_status = 'pending'
+		)
+	`
+	closeStatusHistoryQuery = `
+		UPDATE vendor_status_history
+		SET effective_to = $2
+		WHERE vendor_id = $1 AND approval_status = 'approved' AND effective_to IS NULL
+	`
+	insertStatusHistoryQuery = `
+		INSERT INTO vendor_status_history
+			(id, vendor_id, previous_status, status, reason, approval_status, effective_from, effective_to, requested_by, reviewed_by, reviewed_date, modified_date)
+		VALUES
+			(:id, :vendor_id, :previous_status, :status, :reason, :approval_status, :effective_from, :effective_to, :requested_by, :reviewed_by, :reviewed_date, :modified_date)
+	`
+	// updateStatusHistoryReviewQuery only reviews a pending change so a review racing
+	// another one leaves no row updated
+	updateStatusHistoryReviewQuery = `
+		UPDATE vendor_status_history
+		SET approval_status = :approval_status, effective_from = :effective_from, reviewed_by = :reviewed_by, reviewed_date = :reviewed_date, modified_date = :modified_date
+		WHERE id = :id AND approval_status = 'pending'
+	`
+	getStatusHistoryByVendorIDQuery = `
+		SELECT id, vendor_id, previous_status, status, reason, approval_status, effective_from, effective_to, requested_by, reviewed_by, reviewed_date, modified_date
+		FROM vendor_status_history
+		WHERE vendor_id = $1
+		ORDER BY modified_date DESC
+	`
+	getStatusHistoryByIDQuery = `
+		SELECT id, vendor_id, previous_status, status, reason, approval_status, effective_from, effective_to, requested_by, reviewed_by, reviewed_date, modified_date
+		FROM vendor_status_history
+		WHERE id = $1
+	`
+	// a vendor is only deleted once none of its prices remain, prices keep
+	// referring to it otherwise
+	softDeleteVendorQuery = `
+		UPDATE vendor SET
+			deleted_at = $2,
+			deleted_by = $3,
+			modified_date = $2,
+			modified_by = $3
+		WHERE id = $1
+			AND deleted_at IS NULL
+			AND NOT EXISTS (SELECT 1 FROM price WHERE vendor_id = $1 AND deleted_at IS NULL)
+	`
+	getVendorReferencesQuery = `
+		SELECT
+			EXISTS (SELECT 1 FROM vendor WHERE id = $1 AND deleted_at IS NULL),
+			(SELECT COUNT(*) FROM price WHERE vendor_id = $1 AND deleted_at IS NULL)
+	`
+	restoreVendorQuery = `
+		UPDATE vendor SET
+			deleted_at = NULL,
+			deleted_by = NULL,
+			modified_date = $2,
+			modified_by = $3
+		WHERE id = $1
+			AND deleted_at IS NOT NULL
 	`
 )
 
 // GetSomeStuff is just an example
 func (p *postgresVendorAccessor) GetSomeStuff(ctx context.Context) ([]string, error) {
-	rows, err := p.db.Query(`SELECT name FROM users WHERE title = (?)`, "test")
+	rows, err := p.db.QueryContext(ctx, `SELECT name FROM users WHERE title = (?)`, "test")
 	if err != nil {
 		return nil, err
 	}
@@ -71,6 +187,18 @@ func (p *postgresVendorAccessor) GetSomeStuff(ctx context.Context) ([]string, er
 	return results, nil
 }
 
+// vendorSortableColumns maps the accepted order_by fields to their column
+var vendorSortableColumns = map[string]string{
+	"id":              "v.id",
+	"name":            "v.name",
+	"bp_name":         "v.bp_name",
+	"rating":          "v.rating",
+	"area_group_name": "v.area_group_name",
+	"sap_code":        "v.sap_code",
+	"modified_date":   "v.modified_date",
+	"dt":              "v.dt",
+}
+
 func (p *postgresVendorAccessor) GetAll(ctx context.Context, spec GetAllVendorSpec) (*AccessorGetAllPaginationData, error) {
 	paginationArgs := database.BuildPaginationArgs(spec.PaginationSpec)
 
@@ -87,6 +215,23 @@ func (p *postgresVendorAccessor) GetAll(ctx context.Context, spec GetAllVendorSp
 		}
 	)
 
+	// Soft deleted vendors are never listed
+	whereClauses = append(whereClauses, "v.deleted_at IS NULL")
+
+	// Build WHERE clause for status, only active vendors are listed by default
+	status := VendorStatusActive
+	if spec.Status != "" {
+		parsed, err := ParseVendorStatus(spec.Status)
+		if err != nil {
+			utils.Logger.Error(err.Error())
+			return nil, err
+		}
+		status = parsed
+	}
+	whereClauses = append(whereClauses, fmt.Sprintf("v.status = $%d", argsIndex))
+	args = append(args, status.String())
+	argsIndex++
+
 	// Build WHERE clause for location
 	if spec.Location != "" {
 		whereClauses = append(whereClauses, fmt.Sprintf("v.area_group_name = $%d", argsIndex))
@@ -94,11 +239,43 @@ func (p *postgresVendorAccessor) GetAll(ctx context.Context, spec GetAllVendorSp
 		argsIndex++
 	}
 
+	// Build WHERE clauses for vendor attributes
+	if spec.AreaGroupID != "" {
+		whereClauses = append(whereClauses, fmt.Sprintf("v.area_group_id = $%d", argsIndex))
+		args = append(args, spec.AreaGroupID)
+		argsIndex++
+	}
+	if spec.SapCode != "" {
+		whereClauses = append(whereClauses, fmt.Sprintf("v.sap_code = $%d", argsIndex))
+		args = append(args, spec.SapCode)
+		argsIndex++
+	}
+	if spec.RatingMin != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("v.rating >= $%d", argsIndex))
+		args = append(args, *spec.RatingMin)
+		argsIndex++
+	}
+	if spec.RatingMax != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("v.rating <= $%d", argsIndex))
+		args = append(args, *spec.RatingMax)
+		argsIndex++
+	}
+	if !spec.ModifiedFrom.IsZero() {
+		whereClauses = append(whereClauses, fmt.Sprintf("v.modified_date >= $%d", argsIndex))
+		args = append(args, spec.ModifiedFrom)
+		argsIndex++
+	}
+	if !spec.ModifiedTo.IsZero() {
+		whereClauses = append(whereClauses, fmt.Sprintf("v.modified_date <= $%d", argsIndex))
+		args = append(args, spec.ModifiedTo)
+		argsIndex++
+	}
+
 	// Build JOIN and WHERE clauses for product
-	if spec.Product != "" {
-		joinClauses = append(joinClauses, "JOIN price pr ON pr.vendor_id = v.id")
-		joinClauses = append(joinClauses, "JOIN product_vendor pv ON pv.id = pr.product_vendor_id")
-		joinClauses = append(joinClauses, "JOIN product p ON p.id = pv.product_id")
+	if spec.Product != "" || spec.ProductCategoryID != "" {
+		joinClauses = append(joinClauses, "JOIN price pr ON pr.vendor_id = v.id AND pr.deleted_at IS NULL")
+		joinClauses = append(joinClauses, "JOIN product_vendor pv ON pv.id = pr.product_vendor_id AND pv.deleted_at IS NULL")
+		joinClauses = append(joinClauses, "JOIN product p ON p.id = pv.product_id AND p.deleted_at IS NULL")
 
 		productNameList := strings.Fields(spec.Product)
 		for _, word := range productNameList {
@@ -106,25 +283,53 @@ func (p *postgresVendorAccessor) GetAll(ctx context.Context, spec GetAllVendorSp
 			args = append(args, "%"+word+"%")
 			argsIndex++
 		}
+
+		if spec.ProductCategoryID != "" {
+			whereClauses = append(whereClauses, fmt.Sprintf("p.product_category_id = $%d", argsIndex))
+			args = append(args, spec.ProductCategoryID)
+			argsIndex++
+		}
+	}
+
+	// Build WHERE clause for vendors having a price valid right now
+	if spec.HasValidPrice != nil {
+		clause := fmt.Sprintf(`EXISTS (
+			SELECT 1 FROM price vp
+			WHERE vp.vendor_id = v.id AND vp.deleted_at IS NULL AND vp.valid_from <= $%d AND (vp.valid_to IS NULL OR vp.valid_to >= $%d)
+		)`, argsIndex, argsIndex)
+		if !*spec.HasValidPrice {
+			clause = "NOT " + clause
+		}
+		whereClauses = append(whereClauses, clause)
+		args = append(args, p.clock.Now())
+		argsIndex++
+	}
+
+	if spec.IsCursor() {
+		return p.getAllByCursor(ctx, spec.PaginationSpec, joinClauses, whereClauses, args, argsIndex)
 	}
 
 	// Set order by default value
-	if paginationArgs.OrderBy == "" {
-		paginationArgs.OrderBy = "v.dt"
-	} else {
-		paginationArgs.OrderBy = "v." + paginationArgs.OrderBy
+	orderBy := paginationArgs.OrderBy
+	if orderBy == "" {
+		orderBy = "dt"
+	}
+	orderByClause, err := database.BuildOrderByClause(orderBy, paginationArgs.Order, vendorSortableColumns)
+	if err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
 	}
 
 	// Populate extra clauses
-	extraClauses = append(
-		extraClauses,
-		fmt.Sprintf("ORDER BY %s %s", paginationArgs.OrderBy, paginationArgs.Order),
-	)
+	extraClauses = append(extraClauses, orderByClause)
 	for _, clause := range extraClausesRaw {
 		extraClauses = append(extraClauses, fmt.Sprintf(clause, argsIndex))
 		argsIndex++
 	}
 
+	// Filters are shared by the count query, only pagination arguments are left out
+	countArgs := args
+
 	// Append pagination arguments to args
 	args = append(args, paginationArgs.Limit, paginationArgs.Offset)
 
@@ -149,7 +354,8 @@ func (p *postgresVendorAccessor) GetAll(ctx context.Context, spec GetAllVendorSp
 			v.sap_code,
 			v.modified_date,
 			v.modified_by,
-			v.dt
+			v.dt,
+			v.status
 		FROM vendor v
 		%s
 		%s
@@ -157,7 +363,7 @@ func (p *postgresVendorAccessor) GetAll(ctx context.Context, spec GetAllVendorSp
 	`, joinClause, whereClause, extraClause)
 
 	// Execute the query
-	rows, err := p.db.Queryx(dataQuery, args...)
+	rows, err := p.db.QueryxContext(ctx, dataQuery, args...)
 	if err != nil {
 		utils.Logger.Error(err.Error())
 		return nil, err
@@ -179,9 +385,14 @@ func (p *postgresVendorAccessor) GetAll(ctx context.Context, spec GetAllVendorSp
 	}
 
 	// Get the total count of entries
-	countQuery := "SELECT COUNT(*) from vendor"
+	countQuery := fmt.Sprintf(`
+		SELECT COUNT(DISTINCT v.id)
+		FROM vendor v
+		%s
+		%s
+	`, joinClause, whereClause)
 	totalEntries := new(int)
-	row := p.db.QueryRow(countQuery)
+	row := p.db.QueryRowContext(ctx, countQuery, countArgs...)
 	if err = row.Scan(&totalEntries); err != nil {
 		utils.Logger.Error(err.Error())
 		return nil, err
@@ -193,6 +404,97 @@ func (p *postgresVendorAccessor) GetAll(ctx context.Context, spec GetAllVendorSp
 	return &AccessorGetAllPaginationData{Vendors: vendors, Metadata: metadata}, nil
 }
 
+// vendorCursorRow carries the sort column value used to build the cursors
+type vendorCursorRow struct {
+	Vendor
+	CursorValue interface{} `db:"cursor_value"`
+}
+
+// getAllByCursor runs the vendor listing in keyset mode on top of the filters
+// built by GetAll, the count query is skipped since cursors don't need it
+func (p *postgresVendorAccessor) getAllByCursor(
+	ctx context.Context,
+	spec database.PaginationSpec,
+	joinClauses []string,
+	whereClauses []string,
+	args []interface{},
+	argsIndex int,
+) (*AccessorGetAllPaginationData, error) {
+	keysetArgs, err := database.BuildKeysetArgs(spec, "dt", "v.id", vendorSortableColumns)
+	if err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	condition := keysetArgs.Condition(fmt.Sprintf("$%d", argsIndex), fmt.Sprintf("$%d", argsIndex+1))
+	if condition != "" {
+		whereClauses = append(whereClauses, condition)
+		args = append(args, keysetArgs.Cursor.Value, keysetArgs.Cursor.ID)
+		argsIndex += 2
+	}
+	args = append(args, keysetArgs.FetchLimit())
+
+	whereClause := ""
+	if len(whereClauses) > 0 {
+		whereClause = "WHERE " + strings.Join(whereClauses, " AND ")
+	}
+
+	dataQuery := fmt.Sprintf(`
+		SELECT DISTINCT
+			v.id,
+			v.name,
+			v.description,
+			v.bp_id,
+			v.bp_name,
+			v.rating,
+			v.area_group_id,
+			v.area_group_name,
+			v.sap_code,
+			v.modified_date,
+			v.modified_by,
+			v.dt,
+			v.status,
+			%s AS %s
+		FROM vendor v
+		%s
+		%s
+		%s
+		LIMIT $%d
+	`, keysetArgs.Column, database.CursorValueColumn,
+		strings.Join(joinClauses, "\n"), whereClause, keysetArgs.OrderByClause(), argsIndex)
+
+	rows, err := p.db.QueryxContext(ctx, dataQuery, args...)
+	if err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	cursorRows := []vendorCursorRow{}
+	for rows.Next() {
+		var row vendorCursorRow
+		if err := rows.StructScan(&row); err != nil {
+			return nil, err
+		}
+		cursorRows = append(cursorRows, row)
+	}
+	if err := rows.Err(); err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	cursorRows, metadata := database.GenerateCursorMetadata(keysetArgs, cursorRows, func(row vendorCursorRow) database.Cursor {
+		return database.Cursor{Value: row.CursorValue, ID: row.ID}
+	})
+
+	vendors := make([]Vendor, 0, len(cursorRows))
+	for _, row := range cursorRows {
+		vendors = append(vendors, row.Vendor)
+	}
+
+	return &AccessorGetAllPaginationData{Vendors: vendors, Metadata: metadata}, nil
+}
+
 func (p *postgresVendorAccessor) GetById(ctx context.Context, id string) (*Vendor, error) {
 	query := `SELECT 
 		"id",
@@ -206,12 +508,13 @@ func (p *postgresVendorAccessor) GetById(ctx context.Context, id string) (*Vendo
         "sap_code",
         "modified_date",
         "modified_by",
-        "dt"
+        "dt",
+        "status"
 		FROM vendor 
-		WHERE id = $1`
+		WHERE id = $1 AND deleted_at IS NULL`
 
 	vendor := Vendor{}
-	row := p.db.QueryRowx(query, id)
+	row := p.db.QueryRowxContext(ctx, query, id)
 	if err := row.StructScan(&vendor); err != nil {
 		utils.Logger.Error(err.Error())
 		return nil, err
@@ -221,6 +524,16 @@ func (p *postgresVendorAccessor) GetById(ctx context.Context, id string) (*Vendo
 }
 
 func (p *postgresVendorAccessor) UpdateDetail(ctx context.Context, vendor Vendor) (*Vendor, error) {
+	var updated *Vendor
+	err := p.audited(ctx, audit.EntityVendor, []string{vendor.ID}, vendor.ModifiedBy, func(tx *postgresVendorAccessor) error {
+		var err error
+		updated, err = tx.updateDetailRow(ctx, vendor)
+		return err
+	})
+	return updated, err
+}
+
+func (p *postgresVendorAccessor) updateDetailRow(ctx context.Context, vendor Vendor) (*Vendor, error) {
 	now := p.clock.Now()
 
 	// Not yet updating modified_by
@@ -236,7 +549,7 @@ func (p *postgresVendorAccessor) UpdateDetail(ctx context.Context, vendor Vendor
 			sap_code = $9,
 			modified_date = $10
 		WHERE 
-			id = $1
+			id = $1 AND deleted_at IS NULL
 		RETURNING 
 			id, 
 			name, 
@@ -249,11 +562,12 @@ func (p *postgresVendorAccessor) UpdateDetail(ctx context.Context, vendor Vendor
 			sap_code, 
 			modified_date, 
 			modified_by, 
-			dt
+			dt,
+			status
 	`
 
 	updatedVendor := &Vendor{}
-	row := p.db.QueryRowx(query,
+	row := p.db.QueryRowxContext(ctx, query,
 		vendor.ID,
 		vendor.Name,
 		vendor.Description,
@@ -274,7 +588,7 @@ func (p *postgresVendorAccessor) UpdateDetail(ctx context.Context, vendor Vendor
 }
 
 func (p *postgresVendorAccessor) GetAllLocations(ctx context.Context) ([]string, error) {
-	rows, err := p.db.Query(getAllLocationsQuery)
+	rows, err := p.db.QueryContext(ctx, getAllLocationsQuery)
 	if err != nil {
 		utils.Logger.Error(err.Error())
 		return nil, err
@@ -299,7 +613,7 @@ func (p *postgresVendorAccessor) GetAllLocations(ctx context.Context) ([]string,
 	return results, nil
 }
 
-func (p *postgresVendorAccessor) BulkGetByIDs(_ context.Context, ids []string) ([]Vendor, error) {
+func (p *postgresVendorAccessor) BulkGetByIDs(ctx context.Context, ids []string) ([]Vendor, error) {
 	query, args, err := sqlx.In(getBulkByID, ids)
 	if err != nil {
 		utils.Logger.Error(err.Error())
@@ -307,7 +621,7 @@ func (p *postgresVendorAccessor) BulkGetByIDs(_ context.Context, ids []string) (
 	}
 
 	query = p.db.Rebind(query)
-	rows, err := p.db.Queryx(query, args...)
+	rows, err := p.db.QueryxContext(ctx, query, args...)
 	if err != nil {
 		utils.Logger.Error(err.Error())
 		return nil, err
@@ -331,17 +645,25 @@ func (p *postgresVendorAccessor) BulkGetByIDs(_ context.Context, ids []string) (
 }
 
 func (p *postgresVendorAccessor) writeVendor(ctx context.Context, vendor Vendor) error {
-	if _, err := p.db.NamedExec(insertVendor, vendor); err != nil {
+	if _, err := p.db.NamedExecContext(ctx, upsertVendor, vendor); err != nil {
 		utils.Logger.Error(err.Error())
 		return err
 	}
 	return nil
 }
 
-func (p *postgresVendorAccessor) BulkGetByProductName(_ context.Context, productName string) ([]Vendor, error) {
+func (p *postgresVendorAccessor) writeEvaluation(ctx context.Context, evaluation VendorEvaluation) error {
+	if _, err := p.db.NamedExecContext(ctx, upsertEvaluationQuery, evaluation); err != nil {
+		utils.Logger.Errorf("failed upserting vendor evaluation: %s", evaluation.ID)
+		return err
+	}
+	return nil
+}
+
+func (p *postgresVendorAccessor) BulkGetByProductName(ctx context.Context, productName string) ([]Vendor, error) {
 	query := getBulkByProductName
 
-	rows, err := p.db.NamedQuery(query, map[string]interface{}{
+	rows, err := p.db.NamedQueryContext(ctx, query, map[string]interface{}{
 		"product_name": productName,
 	})
 
@@ -369,18 +691,200 @@ func (p *postgresVendorAccessor) BulkGetByProductName(_ context.Context, product
 }
 
 func (p *postgresVendorAccessor) CreateEvaluation(ctx context.Context, evaluation *VendorEvaluation) (*VendorEvaluation, error) {
-	if _, err := p.db.NamedExec(createEvaluationQuery, evaluation); err != nil {
-		utils.Logger.Error(err.Error())
+	err := p.audited(ctx, audit.EntityEvaluation, []string{evaluation.ID}, evaluation.ModifiedBy, func(tx *postgresVendorAccessor) error {
+		if _, err := tx.db.NamedExecContext(ctx, createEvaluationQuery, evaluation); err != nil {
+			utils.Logger.Error(err.Error())
+			return err
+		}
+		return nil
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return evaluation, nil
 }
 
+// UpdateStatus sets the status of the vendor from effectiveFrom on, modifiedBy is who
+// changed it, the reviewer when it was requested
+func (p *postgresVendorAccessor) UpdateStatus(ctx context.Context, vendorID string, status VendorStatus, effectiveFrom time.Time, modifiedBy string) error {
+	return p.audited(ctx, audit.EntityVendor, []string{vendorID}, modifiedBy, func(tx *postgresVendorAccessor) error {
+		if _, err := tx.db.ExecContext(ctx, updateVendorStatusQuery, vendorID, status.String(), effectiveFrom, modifiedBy); err != nil {
+			utils.Logger.Error(err.Error())
+			return err
+		}
+
+		// close the window of the status that is being replaced
+		if _, err := tx.db.ExecContext(ctx, closeStatusHistoryQuery, vendorID, effectiveFrom); err != nil {
+			utils.Logger.Error(err.Error())
+			return err
+		}
+
+		return nil
+	})
+}
+
+// LockStatus returns the current status of the vendor, locking it for the rest of the transaction
+func (p *postgresVendorAccessor) LockStatus(ctx context.Context, vendorID string) (string, error) {
+	var status string
+	if err := p.db.QueryRowContext(ctx, lockVendorStatusQuery, vendorID).Scan(&status); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", ErrVendorNotFound
+		}
+		utils.Logger.Error(err.Error())
+		return "", err
+	}
+	return status, nil
+}
+
+func (p *postgresVendorAccessor) HasPendingStatusChange(ctx context.Context, vendorID string) (bool, error) {
+	var pending bool
+	if err := p.db.QueryRowContext(ctx, hasPendingStatusChangeQuery, vendorID).Scan(&pending); err != nil {
+		utils.Logger.Error(err.Error())
+		return false, err
+	}
+	return pending, nil
+}
+
+// SoftDelete marks the vendor as deleted in a single statement that also checks its
+// prices, when nothing was deleted they are counted to tell a missing vendor from one in use
+func (p *postgresVendorAccessor) SoftDelete(ctx context.Context, id string, deletedBy string) error {
+	return p.audited(ctx, audit.EntityVendor, []string{id}, deletedBy, func(tx *postgresVendorAccessor) error {
+		result, err := tx.db.ExecContext(ctx, softDeleteVendorQuery, id, tx.clock.Now(), deletedBy)
+		if err != nil {
+			utils.Logger.Error(err.Error())
+			return err
+		}
+		deleted, err := result.RowsAffected()
+		if err != nil {
+			utils.Logger.Error(err.Error())
+			return err
+		}
+		if deleted > 0 {
+			return nil
+		}
+
+		var (
+			found  bool
+			prices int
+		)
+		if err := tx.db.QueryRowContext(ctx, getVendorReferencesQuery, id).Scan(&found, &prices); err != nil {
+			utils.Logger.Error(err.Error())
+			return err
+		}
+		if !found {
+			return ErrVendorNotFound
+		}
+		return fmt.Errorf("%w: %d prices refer to it", ErrVendorInUse, prices)
+	})
+}
+
+// Restore clears the deletion of a vendor, its prices are restored on their own
+func (p *postgresVendorAccessor) Restore(ctx context.Context, id string, restoredBy string) error {
+	return p.audited(ctx, audit.EntityVendor, []string{id}, restoredBy, func(tx *postgresVendorAccessor) error {
+		result, err := tx.db.ExecContext(ctx, restoreVendorQuery, id, tx.clock.Now(), restoredBy)
+		if err != nil {
+			utils.Logger.Error(err.Error())
+			return err
+		}
+		restored, err := result.RowsAffected()
+		if err != nil {
+			utils.Logger.Error(err.Error())
+			return err
+		}
+		if restored == 0 {
+			return ErrVendorNotFound
+		}
+		return nil
+	})
+}
+
+func (p *postgresVendorAccessor) WriteStatusHistory(ctx context.Context, history VendorStatusHistory) error {
+	if _, err := p.db.NamedExecContext(ctx, insertStatusHistoryQuery, history); err != nil {
+		utils.Logger.Error(err.Error())
+		return err
+	}
+	return nil
+}
+
+// UpdateStatusHistoryReview records the review of a pending status change,
+// ErrStatusChangeNotPending is returned when it was reviewed in the meantime
+func (p *postgresVendorAccessor) UpdateStatusHistoryReview(ctx context.Context, history VendorStatusHistory) error {
+	result, err := p.db.NamedExecContext(ctx, updateStatusHistoryReviewQuery, history)
+	if err != nil {
+		utils.Logger.Error(err.Error())
+		return err
+	}
+	reviewed, err := result.RowsAffected()
+	if err != nil {
+		utils.Logger.Error(err.Error())
+		return err
+	}
+	if reviewed == 0 {
+		return ErrStatusChangeNotPending
+	}
+	return nil
+}
+
+func (p *postgresVendorAccessor) GetStatusHistory(ctx context.Context, vendorID string) ([]VendorStatusHistory, error) {
+	rows, err := p.db.QueryxContext(ctx, getStatusHistoryByVendorIDQuery, vendorID)
+	if err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := []VendorStatusHistory{}
+	for rows.Next() {
+		var history VendorStatusHistory
+		if err := rows.StructScan(&history); err != nil {
+			utils.Logger.Error(err.Error())
+			return nil, err
+		}
+		res = append(res, history)
+	}
+	if err := rows.Err(); err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (p *postgresVendorAccessor) GetStatusHistoryByID(ctx context.Context, id string) (*VendorStatusHistory, error) {
+	history := VendorStatusHistory{}
+	if err := p.db.QueryRowxContext(ctx, getStatusHistoryByIDQuery, id).StructScan(&history); err != nil {
+		utils.Logger.Error(err.Error())
+		return nil, err
+	}
+	return &history, nil
+}
+
 func (p *postgresVendorAccessor) Close() error {
 	return p.db.Close()
 }
 
+// runInTx runs fn as a unit of work, the accessor fn gets writes in its transaction
+func (p *postgresVendorAccessor) runInTx(ctx context.Context, fn func(tx vendorDBAccessor) error) error {
+	return database.RunInTx(ctx, p.db, func(tx database.DBConnector) error {
+		return fn(newPostgresVendorAccessor(tx, p.clock))
+	})
+}
+
+// audited runs write on an accessor of its transaction, recording the changes it
+// makes to the rows of entity among ids on the audit log
+func (p *postgresVendorAccessor) audited(
+	ctx context.Context,
+	entity audit.Entity,
+	ids []string,
+	actor string,
+	write func(tx *postgresVendorAccessor) error,
+) error {
+	return audit.Track(ctx, p.db, p.clock, entity, ids, actor, func(tx database.DBConnector) error {
+		return write(newPostgresVendorAccessor(tx, p.clock))
+	})
+}
+
 // newPostgresVendorAccessor is only accessible by the vendor package
 // entrypoint for other verticals should refer to the interface declared on service
 func newPostgresVendorAccessor(db database.DBConnector, clock clock.Clock) *postgresVendorAccessor {
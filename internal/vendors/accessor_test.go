@@ -6,6 +6,7 @@ import (
 	"database/sql/driver"
 	"errors"
 	"fmt"
+	"kg/procurement/internal/audit"
 	"kg/procurement/internal/common/database"
 	"log"
 	"regexp"
@@ -16,6 +17,7 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/benbjohnson/clock"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/onsi/gomega"
 )
 
@@ -169,14 +171,16 @@ func TestVendorAccessor_GetAll(t *testing.T) {
 			v.sap_code,
 			v.modified_date,
 			v.modified_by,
-			v.dt
+			v.dt,
+			v.status
 		FROM vendor v
+		WHERE v.deleted_at IS NULL AND v.status = $1
 		ORDER BY v.dt DESC
-		LIMIT $1
-		OFFSET $2
+		LIMIT $2
+		OFFSET $3
 	`
 
-	countQuery := "SELECT COUNT(*) from vendor"
+	countQuery := "SELECT COUNT(DISTINCT v.id) FROM vendor v WHERE v.deleted_at IS NULL AND v.status = $1"
 
 	fixedTime := time.Date(2024, time.September, 27, 12, 30, 0, 0, time.UTC)
 
@@ -211,12 +215,12 @@ func TestVendorAccessor_GetAll(t *testing.T) {
 		args := database.BuildPaginationArgs(spec.PaginationSpec)
 
 		mock.ExpectQuery(dataQuery).
-			WithArgs(args.Limit, args.Offset).
+			WithArgs("active", args.Limit, args.Offset).
 			WillReturnRows(rows)
 
 		totalRows := sqlmock.NewRows([]string{"count"}).AddRow(1)
 
-		mock.ExpectQuery(countQuery).WillReturnRows(totalRows)
+		mock.ExpectQuery(countQuery).WithArgs("active").WillReturnRows(totalRows)
 
 		ctx := context.Background()
 		res, err := accessor.GetAll(ctx, spec)
@@ -278,11 +282,13 @@ func TestVendorAccessor_GetAll(t *testing.T) {
 				v.sap_code,
 				v.modified_date,
 				v.modified_by,
-				v.dt
+				v.dt,
+				v.status
 			FROM vendor v
+			WHERE v.deleted_at IS NULL AND v.status = $1
 			ORDER BY v.rating DESC
-			LIMIT $1
-			OFFSET $2
+			LIMIT $2
+			OFFSET $3
 		`
 
 		customSpec := GetAllVendorSpec{
@@ -297,12 +303,12 @@ func TestVendorAccessor_GetAll(t *testing.T) {
 		args := database.BuildPaginationArgs(customSpec.PaginationSpec)
 
 		mock.ExpectQuery(customQuery).
-			WithArgs(args.Limit, args.Offset).
+			WithArgs("active", args.Limit, args.Offset).
 			WillReturnRows(rows)
 
 		totalRows := sqlmock.NewRows([]string{"count"}).AddRow(1)
 
-		mock.ExpectQuery(countQuery).WillReturnRows(totalRows)
+		mock.ExpectQuery(countQuery).WithArgs("active").WillReturnRows(totalRows)
 
 		ctx := context.Background()
 		res, err := accessor.GetAll(ctx, customSpec)
@@ -360,12 +366,12 @@ func TestVendorAccessor_GetAll(t *testing.T) {
 		}
 
 		mock.ExpectQuery(dataQuery).
-			WithArgs(1, 0).
+			WithArgs("active", 1, 0).
 			WillReturnRows(rows)
 
 		totalRows := sqlmock.NewRows([]string{"count"}).AddRow(1)
 
-		mock.ExpectQuery(countQuery).WillReturnRows(totalRows)
+		mock.ExpectQuery(countQuery).WithArgs("active").WillReturnRows(totalRows)
 
 		ctx := context.Background()
 		res, err := accessor.GetAll(ctx, customSpec)
@@ -403,12 +409,13 @@ func TestVendorAccessor_GetAll(t *testing.T) {
 		args := database.BuildPaginationArgs(spec.PaginationSpec)
 
 		mock.ExpectQuery(dataQuery).
-			WithArgs(args.Limit, args.Offset).
+			WithArgs("active", args.Limit, args.Offset).
 			WillReturnRows(rows)
 
 		totalRows := sqlmock.NewRows([]string{"count"}).AddRow(0)
 
 		mock.ExpectQuery(countQuery).
+			WithArgs("active").
 			WillReturnRows(totalRows)
 
 		ctx := context.Background()
@@ -445,12 +452,12 @@ func TestVendorAccessor_GetAll(t *testing.T) {
 		args := database.BuildPaginationArgs(spec.PaginationSpec)
 
 		mock.ExpectQuery(dataQuery).
-			WithArgs(args.Limit, args.Offset).
+			WithArgs("active", args.Limit, args.Offset).
 			WillReturnRows(rows)
 
 		totalRows := sqlmock.NewRows([]string{"count"}).AddRow(nil)
 
-		mock.ExpectQuery(countQuery).WillReturnRows(totalRows)
+		mock.ExpectQuery(countQuery).WithArgs("active").WillReturnRows(totalRows)
 
 		ctx := context.Background()
 		res, err := accessor.GetAll(ctx, spec)
@@ -500,12 +507,12 @@ func TestVendorAccessor_GetAll(t *testing.T) {
 		args := database.BuildPaginationArgs(spec.PaginationSpec)
 
 		mock.ExpectQuery(wrongQuery).
-			WithArgs(args.Limit, args.Offset).
+			WithArgs("active", args.Limit, args.Offset).
 			WillReturnRows(rows)
 
 		totalRows := sqlmock.NewRows([]string{"count"}).AddRow(1)
 
-		mock.ExpectQuery(countQuery).WillReturnRows(totalRows)
+		mock.ExpectQuery(countQuery).WithArgs("active").WillReturnRows(totalRows)
 
 		ctx := context.Background()
 		res, err := accessor.GetAll(ctx, spec)
@@ -550,12 +557,12 @@ func TestVendorAccessor_GetAll(t *testing.T) {
 		args := database.BuildPaginationArgs(spec.PaginationSpec)
 
 		mock.ExpectQuery(dataQuery).
-			WithArgs(args.Limit, args.Offset).
+			WithArgs("active", args.Limit, args.Offset).
 			WillReturnRows(rows)
 
 		totalRows := sqlmock.NewRows([]string{"count"}).AddRow(1)
 
-		mock.ExpectQuery(countQuery).WillReturnRows(totalRows)
+		mock.ExpectQuery(countQuery).WithArgs("active").WillReturnRows(totalRows)
 
 		ctx := context.Background()
 		res, err := accessor.GetAll(ctx, spec)
@@ -587,12 +594,12 @@ func TestVendorAccessor_GetAll(t *testing.T) {
 		args := database.BuildPaginationArgs(spec.PaginationSpec)
 
 		mock.ExpectQuery(dataQuery).
-			WithArgs(args.Limit, args.Offset).
+			WithArgs("active", args.Limit, args.Offset).
 			WillReturnRows(rows)
 
 		totalRows := sqlmock.NewRows([]string{"count"}).RowError(1, fmt.Errorf("row error"))
 
-		mock.ExpectQuery(countQuery).WillReturnRows(totalRows)
+		mock.ExpectQuery(countQuery).WithArgs("active").WillReturnRows(totalRows)
 
 		ctx := context.Background()
 		res, err := accessor.GetAll(ctx, spec)
@@ -654,7 +661,14 @@ func Test_postgresVendorAccessor_GetAll_WithLocationAndProduct(t *testing.T) {
 		"dt",
 	}
 
-	countQuery := "SELECT COUNT(*) from vendor"
+	countQuery := `
+		SELECT COUNT(DISTINCT v.id)
+		FROM vendor v
+		JOIN price pr ON pr.vendor_id = v.id AND pr.deleted_at IS NULL
+		JOIN product_vendor pv ON pv.id = pr.product_vendor_id AND pv.deleted_at IS NULL
+		JOIN product p ON p.id = pv.product_id AND p.deleted_at IS NULL
+		WHERE v.deleted_at IS NULL AND v.status = $1 AND v.area_group_name = $2 AND p.name iLIKE $3 AND p.name iLIKE $4
+	`
 
 	dataQuery := fmt.Sprintf(`
 		SELECT DISTINCT
@@ -669,15 +683,16 @@ func Test_postgresVendorAccessor_GetAll_WithLocationAndProduct(t *testing.T) {
 			v.sap_code,
 			v.modified_date,
 			v.modified_by,
-			v.dt
+			v.dt,
+			v.status
 		FROM vendor v
-		JOIN price pr ON pr.vendor_id = v.id
-		JOIN product_vendor pv ON pv.id = pr.product_vendor_id
-		JOIN product p ON p.id = pv.product_id
-		WHERE v.area_group_name = $1 AND p.name iLIKE $2 AND p.name iLIKE $3
+		JOIN price pr ON pr.vendor_id = v.id AND pr.deleted_at IS NULL
+		JOIN product_vendor pv ON pv.id = pr.product_vendor_id AND pv.deleted_at IS NULL
+		JOIN product p ON p.id = pv.product_id AND p.deleted_at IS NULL
+		WHERE v.deleted_at IS NULL AND v.status = $1 AND v.area_group_name = $2 AND p.name iLIKE $3 AND p.name iLIKE $4
 		ORDER BY v.dt %s
-		LIMIT $4
-		OFFSET $5
+		LIMIT $5
+		OFFSET $6
 	`, spec.PaginationSpec.Order)
 
 	productNameList := strings.Fields(spec.Product)
@@ -706,6 +721,7 @@ func Test_postgresVendorAccessor_GetAll_WithLocationAndProduct(t *testing.T) {
 
 		mock.ExpectQuery(dataQuery).
 			WithArgs(
+				"active",
 				spec.Location,
 				"%"+productNameList[0]+"%",
 				"%"+productNameList[1]+"%",
@@ -716,7 +732,9 @@ func Test_postgresVendorAccessor_GetAll_WithLocationAndProduct(t *testing.T) {
 
 		totalRows := sqlmock.NewRows([]string{"count"}).AddRow(1)
 
-		mock.ExpectQuery(countQuery).WillReturnRows(totalRows)
+		mock.ExpectQuery(countQuery).
+			WithArgs("active", spec.Location, "%"+productNameList[0]+"%", "%"+productNameList[1]+"%").
+			WillReturnRows(totalRows)
 
 		ctx := context.Background()
 		res, err := accessor.GetAll(ctx, spec)
@@ -751,6 +769,7 @@ func Test_postgresVendorAccessor_GetAll_WithLocationAndProduct(t *testing.T) {
 
 		mock.ExpectQuery(dataQuery).
 			WithArgs(
+				"active",
 				spec.Location,
 				"%"+productNameList[0]+"%",
 				"%"+productNameList[1]+"%",
@@ -792,12 +811,13 @@ func Test_postgresVendorAccessor_GetAll_WithLocationAndProduct(t *testing.T) {
 				v.sap_code,
 				v.modified_date,
 				v.modified_by,
-				v.dt
+				v.dt,
+				v.status
 			FROM vendor v
-			WHERE v.area_group_name = $1
+			WHERE v.deleted_at IS NULL AND v.status = $1 AND v.area_group_name = $2
 			ORDER BY v.dt DESC
-			LIMIT $2
-			OFFSET $3
+			LIMIT $3
+			OFFSET $4
 		`
 
 		rows := sqlmock.NewRows(vendorFields).
@@ -819,12 +839,14 @@ func Test_postgresVendorAccessor_GetAll_WithLocationAndProduct(t *testing.T) {
 		args := database.BuildPaginationArgs(spec.PaginationSpec)
 
 		mock.ExpectQuery(dataQuery).
-			WithArgs(spec.Location, args.Limit, args.Offset).
+			WithArgs("active", spec.Location, args.Limit, args.Offset).
 			WillReturnRows(rows)
 
 		totalRows := sqlmock.NewRows([]string{"count"}).AddRow(1)
 
-		mock.ExpectQuery(countQuery).WillReturnRows(totalRows)
+		mock.ExpectQuery("SELECT COUNT(DISTINCT v.id) FROM vendor v WHERE v.deleted_at IS NULL AND v.status = $1 AND v.area_group_name = $2").
+			WithArgs("active", spec.Location).
+			WillReturnRows(totalRows)
 
 		ctx := context.Background()
 		res, err := accessor.GetAll(ctx, spec)
@@ -879,15 +901,16 @@ func Test_postgresVendorAccessor_GetAll_WithLocationAndProduct(t *testing.T) {
 				v.sap_code,
 				v.modified_date,
 				v.modified_by,
-				v.dt
+				v.dt,
+				v.status
 			FROM vendor v
-			JOIN price pr ON pr.vendor_id = v.id
-			JOIN product_vendor pv ON pv.id = pr.product_vendor_id
-			JOIN product p ON p.id = pv.product_id
-			WHERE p.name iLIKE $1 AND p.name iLIKE $2
+			JOIN price pr ON pr.vendor_id = v.id AND pr.deleted_at IS NULL
+			JOIN product_vendor pv ON pv.id = pr.product_vendor_id AND pv.deleted_at IS NULL
+			JOIN product p ON p.id = pv.product_id AND p.deleted_at IS NULL
+			WHERE v.deleted_at IS NULL AND v.status = $1 AND p.name iLIKE $2 AND p.name iLIKE $3
 			ORDER BY v.dt DESC
-			LIMIT $3
-			OFFSET $4
+			LIMIT $4
+			OFFSET $5
 		`
 
 		rows := sqlmock.NewRows(vendorFields).
@@ -910,6 +933,7 @@ func Test_postgresVendorAccessor_GetAll_WithLocationAndProduct(t *testing.T) {
 
 		mock.ExpectQuery(dataQuery).
 			WithArgs(
+				"active",
 				"%"+productNameList[0]+"%",
 				"%"+productNameList[1]+"%",
 				args.Limit,
@@ -919,7 +943,16 @@ func Test_postgresVendorAccessor_GetAll_WithLocationAndProduct(t *testing.T) {
 
 		totalRows := sqlmock.NewRows([]string{"count"}).AddRow(1)
 
-		mock.ExpectQuery(countQuery).WillReturnRows(totalRows)
+		mock.ExpectQuery(`
+			SELECT COUNT(DISTINCT v.id)
+			FROM vendor v
+			JOIN price pr ON pr.vendor_id = v.id AND pr.deleted_at IS NULL
+			JOIN product_vendor pv ON pv.id = pr.product_vendor_id AND pv.deleted_at IS NULL
+			JOIN product p ON p.id = pv.product_id AND p.deleted_at IS NULL
+			WHERE v.deleted_at IS NULL AND v.status = $1 AND p.name iLIKE $2 AND p.name iLIKE $3
+		`).
+			WithArgs("active", "%"+productNameList[0]+"%", "%"+productNameList[1]+"%").
+			WillReturnRows(totalRows)
 
 		ctx := context.Background()
 		res, err := accessor.GetAll(ctx, spec)
@@ -1000,9 +1033,10 @@ func TestVendorAccessor_GetById(t *testing.T) {
 	"sap_code",
 	"modified_date",
 	"modified_by",
-	"dt"
+	"dt",
+	"status"
 	FROM vendor 
-	WHERE id = $1`
+	WHERE id = $1 AND deleted_at IS NULL`
 
 	fixedTime := time.Date(2024, time.September, 27, 12, 30, 0, 0, time.UTC)
 
@@ -1130,7 +1164,7 @@ func TestVendorAccessor_UpdateDetail(t *testing.T) {
 			sap_code = $9,
 			modified_date = $10
 		WHERE 
-			id = $1
+			id = $1 AND deleted_at IS NULL
 		RETURNING 
 			id, 
 			name, 
@@ -1143,7 +1177,8 @@ func TestVendorAccessor_UpdateDetail(t *testing.T) {
 			sap_code, 
 			modified_date, 
 			modified_by, 
-			dt
+			dt,
+			status
 	`
 
 	t.Run("success", func(t *testing.T) {
@@ -1187,18 +1222,20 @@ func TestVendorAccessor_UpdateDetail(t *testing.T) {
 			Date:          fixedTime,
 		}
 
-		mock.ExpectQuery(query).
-			WithArgs("ID",
-				"updated",
-				"updated",
-				"updated",
-				"updated",
-				2,
-				"updated",
-				"updated",
-				"updated",
-				now).
-			WillReturnRows(rows)
+		expectAudited(mock, audit.EntityVendor, "ID", `{"id":"ID","name":"original"}`, `{"id":"ID","name":"updated"}`, func() {
+			mock.ExpectQuery(query).
+				WithArgs("ID",
+					"updated",
+					"updated",
+					"updated",
+					"updated",
+					2,
+					"updated",
+					"updated",
+					"updated",
+					now).
+				WillReturnRows(rows)
+		})
 
 		res, err := accessor.UpdateDetail(ctx, *updatedVendor)
 
@@ -1437,7 +1474,7 @@ func Test_writeProductVendor(t *testing.T) {
 			vendor = Vendor{ID: "123"}
 		)
 
-		transformedQuery, args, _ := sqlx.Named(insertVendor, vendor)
+		transformedQuery, args, _ := sqlx.Named(upsertVendor, vendor)
 		driverArgs := make([]driver.Value, len(args))
 		for i, arg := range args {
 			driverArgs[i] = arg
@@ -1458,7 +1495,7 @@ func Test_writeProductVendor(t *testing.T) {
 			vendor = Vendor{ID: "123"}
 		)
 
-		transformedQuery, args, _ := sqlx.Named(insertVendor, vendor)
+		transformedQuery, args, _ := sqlx.Named(upsertVendor, vendor)
 		driverArgs := make([]driver.Value, len(args))
 		for i, arg := range args {
 			driverArgs[i] = arg
@@ -1473,6 +1510,52 @@ func Test_writeProductVendor(t *testing.T) {
 	})
 }
 
+func Test_writeEvaluation(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		var (
+			ctx        = context.Background()
+			c          = setupVendorAccessorTestComponent(t, WithQueryMatcher(sqlmock.QueryMatcherRegexp))
+			evaluation = VendorEvaluation{ID: "1", VendorID: "123"}
+		)
+
+		transformedQuery, args, _ := sqlx.Named(upsertEvaluationQuery, evaluation)
+		driverArgs := make([]driver.Value, len(args))
+		for i, arg := range args {
+			driverArgs[i] = arg
+		}
+
+		c.mock.ExpectExec(regexp.QuoteMeta(transformedQuery)).WithArgs(
+			driverArgs...,
+		).WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err := c.accessor.writeEvaluation(ctx, evaluation)
+		c.g.Expect(err).Should(gomega.BeNil())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		var (
+			ctx        = context.Background()
+			c          = setupVendorAccessorTestComponent(t)
+			evaluation = VendorEvaluation{ID: "1", VendorID: "123"}
+		)
+
+		transformedQuery, args, _ := sqlx.Named(upsertEvaluationQuery, evaluation)
+		driverArgs := make([]driver.Value, len(args))
+		for i, arg := range args {
+			driverArgs[i] = arg
+		}
+
+		c.mock.ExpectExec(regexp.QuoteMeta(transformedQuery)).WithArgs(
+			driverArgs...,
+		).WillReturnError(errors.New("error"))
+
+		err := c.accessor.writeEvaluation(ctx, evaluation)
+		c.g.Expect(err).ShouldNot(gomega.BeNil())
+	})
+}
+
 func Test_getAllVendorIdByProductName(t *testing.T) {
 	t.Parallel()
 
@@ -1659,6 +1742,7 @@ func Test_createVendorEvaluation(t *testing.T) {
 			Reputasi:                         1,
 			KetersediaanBarang:               1,
 			KualitasLayananAfterServices:     1,
+			ModifiedBy:                       "evaluator",
 		}
 	)
 
@@ -1675,12 +1759,15 @@ func Test_createVendorEvaluation(t *testing.T) {
 			driverArgs[i] = arg
 		}
 
-		c.mock.ExpectExec(transformedQuery).
-			WithArgs(driverArgs...).WillReturnResult(sqlmock.NewResult(1, 1))
+		expectAudited(c.mock, audit.EntityEvaluation, vendorEvaluation.ID, "", `{"id":"Vp5XxWumASFvxD3","vendor_id":"1"}`, func() {
+			c.mock.ExpectExec(transformedQuery).
+				WithArgs(driverArgs...).WillReturnResult(sqlmock.NewResult(1, 1))
+		})
 
 		_, err := c.accessor.CreateEvaluation(ctx, &vendorEvaluation)
 
 		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(c.mock.ExpectationsWereMet()).To(gomega.Succeed())
 	})
 
 	t.Run("error while doing db query", func(t *testing.T) {
@@ -1767,3 +1854,861 @@ func setupVendorAccessorTestComponent(t *testing.T, opts ...Option) vendorAccess
 		cmock:    clockMock,
 	}
 }
+
+// expectSnapshot expects the audit log to read the row as json, an empty row is missing
+func expectSnapshot(mock sqlmock.Sqlmock, entity audit.Entity, id string, row string) {
+	rows := sqlmock.NewRows([]string{"id", "row"})
+	if row != "" {
+		rows.AddRow(id, []byte(row))
+	}
+	mock.ExpectQuery(audit.SnapshotQuery(entity)).WithArgs(pq.Array([]string{id})).WillReturnRows(rows)
+}
+
+// expectAudited expects the writes of an audited mutation between the snapshots the
+// audit log takes of the row, an entry is recorded when before and after differ
+func expectAudited(mock sqlmock.Sqlmock, entity audit.Entity, id string, before string, after string, writes func()) {
+	mock.ExpectBegin()
+	expectSnapshot(mock, entity, id, before)
+	writes()
+	expectSnapshot(mock, entity, id, after)
+	if before != after {
+		insert, _, _ := sqlx.Named(audit.InsertEntryQuery, audit.Entry{})
+		args := make([]driver.Value, 10)
+		for i := range args {
+			args[i] = sqlmock.AnyArg()
+		}
+		mock.ExpectExec(insert).WithArgs(args...).WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	mock.ExpectCommit()
+}
+
+// expectAuditedFailure expects the failing writes of an audited mutation to be rolled back
+func expectAuditedFailure(mock sqlmock.Sqlmock, entity audit.Entity, id string, before string, writes func()) {
+	mock.ExpectBegin()
+	expectSnapshot(mock, entity, id, before)
+	writes()
+	mock.ExpectRollback()
+}
+
+func Test_UpdateStatus(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, time.December, 2, 10, 0, 0, 0, time.UTC)
+
+	t.Run("success", func(t *testing.T) {
+		c := setupVendorAccessorTestComponent(t)
+		defer c.db.Close()
+
+		expectAudited(c.mock, audit.EntityVendor, "1", `{"id":"1","status":"active"}`, `{"id":"1","status":"suspended"}`, func() {
+			c.mock.ExpectExec(updateVendorStatusQuery).
+				WithArgs("1", "suspended", now, "admin").
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			c.mock.ExpectExec(closeStatusHistoryQuery).
+				WithArgs("1", now).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+		})
+
+		err := c.accessor.UpdateStatus(context.Background(), "1", VendorStatusSuspended, now, "admin")
+
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(c.mock.ExpectationsWereMet()).To(gomega.Succeed())
+	})
+
+	t.Run("error on updating vendor", func(t *testing.T) {
+		c := setupVendorAccessorTestComponent(t)
+		defer c.db.Close()
+
+		expectAuditedFailure(c.mock, audit.EntityVendor, "1", `{"id":"1","status":"active"}`, func() {
+			c.mock.ExpectExec(updateVendorStatusQuery).
+				WithArgs("1", "suspended", now, "admin").
+				WillReturnError(sql.ErrConnDone)
+		})
+
+		err := c.accessor.UpdateStatus(context.Background(), "1", VendorStatusSuspended, now, "admin")
+
+		c.g.Expect(err).To(gomega.Equal(sql.ErrConnDone))
+	})
+
+	t.Run("error on closing status history", func(t *testing.T) {
+		c := setupVendorAccessorTestComponent(t)
+		defer c.db.Close()
+
+		expectAuditedFailure(c.mock, audit.EntityVendor, "1", `{"id":"1","status":"active"}`, func() {
+			c.mock.ExpectExec(updateVendorStatusQuery).
+				WithArgs("1", "suspended", now, "admin").
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			c.mock.ExpectExec(closeStatusHistoryQuery).
+				WithArgs("1", now).
+				WillReturnError(sql.ErrConnDone)
+		})
+
+		err := c.accessor.UpdateStatus(context.Background(), "1", VendorStatusSuspended, now, "admin")
+
+		c.g.Expect(err).To(gomega.Equal(sql.ErrConnDone))
+	})
+}
+
+func Test_LockStatus(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		c := setupVendorAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectQuery(lockVendorStatusQuery).
+			WithArgs("1").
+			WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("blacklisted"))
+
+		status, err := c.accessor.LockStatus(context.Background(), "1")
+
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(status).To(gomega.Equal("blacklisted"))
+	})
+
+	t.Run("error on unknown vendor", func(t *testing.T) {
+		c := setupVendorAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectQuery(lockVendorStatusQuery).
+			WithArgs("1").
+			WillReturnError(sql.ErrNoRows)
+
+		_, err := c.accessor.LockStatus(context.Background(), "1")
+
+		c.g.Expect(err).To(gomega.Equal(ErrVendorNotFound))
+	})
+
+	t.Run("error", func(t *testing.T) {
+		c := setupVendorAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectQuery(lockVendorStatusQuery).
+			WithArgs("1").
+			WillReturnError(errors.New("db error"))
+
+		_, err := c.accessor.LockStatus(context.Background(), "1")
+
+		c.g.Expect(err).To(gomega.MatchError("db error"))
+	})
+}
+
+func Test_HasPendingStatusChange(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		c := setupVendorAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectQuery(hasPendingStatusChangeQuery).
+			WithArgs("1").
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+		pending, err := c.accessor.HasPendingStatusChange(context.Background(), "1")
+
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(pending).To(gomega.BeTrue())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		c := setupVendorAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectQuery(hasPendingStatusChangeQuery).
+			WithArgs("1").
+			WillReturnError(errors.New("db error"))
+
+		pending, err := c.accessor.HasPendingStatusChange(context.Background(), "1")
+
+		c.g.Expect(err).ToNot(gomega.BeNil())
+		c.g.Expect(pending).To(gomega.BeFalse())
+	})
+}
+
+func Test_SoftDelete(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		c := setupVendorAccessorTestComponent(t)
+		defer c.db.Close()
+
+		expectAudited(c.mock, audit.EntityVendor, "1", `{"id":"1","deleted_at":null}`, `{"id":"1","deleted_at":"2024-12-12T00:00:00"}`, func() {
+			c.mock.ExpectExec(softDeleteVendorQuery).
+				WithArgs("1", c.cmock.Now(), "admin").
+				WillReturnResult(sqlmock.NewResult(0, 1))
+		})
+
+		err := c.accessor.SoftDelete(context.Background(), "1", "admin")
+
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(c.mock.ExpectationsWereMet()).To(gomega.Succeed())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		c := setupVendorAccessorTestComponent(t)
+		defer c.db.Close()
+
+		expectAuditedFailure(c.mock, audit.EntityVendor, "1", "", func() {
+			c.mock.ExpectExec(softDeleteVendorQuery).
+				WithArgs("1", c.cmock.Now(), "admin").
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			c.mock.ExpectQuery(getVendorReferencesQuery).
+				WithArgs("1").
+				WillReturnRows(sqlmock.NewRows([]string{"exists", "prices"}).AddRow(false, 0))
+		})
+
+		err := c.accessor.SoftDelete(context.Background(), "1", "admin")
+
+		c.g.Expect(err).To(gomega.Equal(ErrVendorNotFound))
+	})
+
+	t.Run("in use while prices refer to it", func(t *testing.T) {
+		c := setupVendorAccessorTestComponent(t)
+		defer c.db.Close()
+
+		expectAuditedFailure(c.mock, audit.EntityVendor, "1", `{"id":"1","deleted_at":null}`, func() {
+			c.mock.ExpectExec(softDeleteVendorQuery).
+				WithArgs("1", c.cmock.Now(), "admin").
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			c.mock.ExpectQuery(getVendorReferencesQuery).
+				WithArgs("1").
+				WillReturnRows(sqlmock.NewRows([]string{"exists", "prices"}).AddRow(true, 3))
+		})
+
+		err := c.accessor.SoftDelete(context.Background(), "1", "admin")
+
+		c.g.Expect(errors.Is(err, ErrVendorInUse)).To(gomega.BeTrue())
+		c.g.Expect(err.Error()).To(gomega.Equal("vendor still has prices: 3 prices refer to it"))
+	})
+}
+
+func Test_Restore(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		c := setupVendorAccessorTestComponent(t)
+		defer c.db.Close()
+
+		expectAudited(c.mock, audit.EntityVendor, "1", `{"id":"1","deleted_at":"2024-12-12T00:00:00"}`, `{"id":"1","deleted_at":null}`, func() {
+			c.mock.ExpectExec(restoreVendorQuery).
+				WithArgs("1", c.cmock.Now(), "admin").
+				WillReturnResult(sqlmock.NewResult(0, 1))
+		})
+
+		err := c.accessor.Restore(context.Background(), "1", "admin")
+
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(c.mock.ExpectationsWereMet()).To(gomega.Succeed())
+	})
+
+	t.Run("not found when the vendor isn't deleted", func(t *testing.T) {
+		c := setupVendorAccessorTestComponent(t)
+		defer c.db.Close()
+
+		expectAuditedFailure(c.mock, audit.EntityVendor, "1", `{"id":"1","deleted_at":null}`, func() {
+			c.mock.ExpectExec(restoreVendorQuery).
+				WithArgs("1", c.cmock.Now(), "admin").
+				WillReturnResult(sqlmock.NewResult(0, 0))
+		})
+
+		err := c.accessor.Restore(context.Background(), "1", "admin")
+
+		c.g.Expect(err).To(gomega.Equal(ErrVendorNotFound))
+	})
+}
+
+func Test_WriteStatusHistory(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, time.December, 2, 10, 0, 0, 0, time.UTC)
+	history := VendorStatusHistory{
+		ID:             "history",
+		VendorID:       "1",
+		PreviousStatus: "active",
+		Status:         "blacklisted",
+		Reason:         "fraud",
+		ApprovalStatus: "approved",
+		EffectiveFrom:  &now,
+		ModifiedDate:   now,
+	}
+
+	expect := func(c vendorAccessorTestComponent) *sqlmock.ExpectedExec {
+		transformedQuery, args, _ := sqlx.Named(insertStatusHistoryQuery, history)
+		driverArgs := make([]driver.Value, len(args))
+		for i, arg := range args {
+			driverArgs[i] = arg
+		}
+		return c.mock.ExpectExec(transformedQuery).WithArgs(driverArgs...)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		c := setupVendorAccessorTestComponent(t)
+		defer c.db.Close()
+
+		expect(c).WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err := c.accessor.WriteStatusHistory(context.Background(), history)
+
+		c.g.Expect(err).To(gomega.BeNil())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		c := setupVendorAccessorTestComponent(t)
+		defer c.db.Close()
+
+		expect(c).WillReturnError(sql.ErrConnDone)
+
+		err := c.accessor.WriteStatusHistory(context.Background(), history)
+
+		c.g.Expect(err).To(gomega.Equal(sql.ErrConnDone))
+	})
+}
+
+func Test_UpdateStatusHistoryReview(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, time.December, 2, 10, 0, 0, 0, time.UTC)
+	history := VendorStatusHistory{
+		ID:             "history",
+		ApprovalStatus: "approved",
+		EffectiveFrom:  &now,
+		ReviewedBy:     "reviewer",
+		ReviewedDate:   &now,
+		ModifiedDate:   now,
+	}
+
+	expect := func(c vendorAccessorTestComponent) *sqlmock.ExpectedExec {
+		transformedQuery, args, _ := sqlx.Named(updateStatusHistoryReviewQuery, history)
+		driverArgs := make([]driver.Value, len(args))
+		for i, arg := range args {
+			driverArgs[i] = arg
+		}
+		return c.mock.ExpectExec(transformedQuery).WithArgs(driverArgs...)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		c := setupVendorAccessorTestComponent(t)
+		defer c.db.Close()
+
+		expect(c).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := c.accessor.UpdateStatusHistoryReview(context.Background(), history)
+
+		c.g.Expect(err).To(gomega.BeNil())
+	})
+
+	t.Run("error when the change was reviewed in the meantime", func(t *testing.T) {
+		c := setupVendorAccessorTestComponent(t)
+		defer c.db.Close()
+
+		expect(c).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := c.accessor.UpdateStatusHistoryReview(context.Background(), history)
+
+		c.g.Expect(err).To(gomega.Equal(ErrStatusChangeNotPending))
+	})
+
+	t.Run("error", func(t *testing.T) {
+		c := setupVendorAccessorTestComponent(t)
+		defer c.db.Close()
+
+		expect(c).WillReturnError(sql.ErrConnDone)
+
+		err := c.accessor.UpdateStatusHistoryReview(context.Background(), history)
+
+		c.g.Expect(err).To(gomega.Equal(sql.ErrConnDone))
+	})
+}
+
+func Test_GetStatusHistory(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, time.December, 2, 10, 0, 0, 0, time.UTC)
+	columns := []string{
+		"id", "vendor_id", "previous_status", "status", "reason", "approval_status",
+		"effective_from", "effective_to", "requested_by", "reviewed_by", "reviewed_date", "modified_date",
+	}
+
+	t.Run("success", func(t *testing.T) {
+		c := setupVendorAccessorTestComponent(t)
+		defer c.db.Close()
+
+		rows := sqlmock.NewRows(columns).
+			AddRow("2", "1", "blacklisted", "active", "cleared", "pending", nil, nil, "requester", "", nil, now).
+			AddRow("1", "1", "active", "blacklisted", "fraud", "approved", now, nil, "requester", "", nil, now)
+		c.mock.ExpectQuery(getStatusHistoryByVendorIDQuery).
+			WithArgs("1").
+			WillReturnRows(rows)
+
+		res, err := c.accessor.GetStatusHistory(context.Background(), "1")
+
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(res).To(gomega.HaveLen(2))
+		c.g.Expect(res[0].EffectiveFrom).To(gomega.BeNil())
+		c.g.Expect(*res[1].EffectiveFrom).To(gomega.Equal(now))
+	})
+
+	t.Run("error on query", func(t *testing.T) {
+		c := setupVendorAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectQuery(getStatusHistoryByVendorIDQuery).
+			WithArgs("1").
+			WillReturnError(sql.ErrConnDone)
+
+		res, err := c.accessor.GetStatusHistory(context.Background(), "1")
+
+		c.g.Expect(err).To(gomega.Equal(sql.ErrConnDone))
+		c.g.Expect(res).To(gomega.BeNil())
+	})
+
+	t.Run("error on scan", func(t *testing.T) {
+		c := setupVendorAccessorTestComponent(t)
+		defer c.db.Close()
+
+		rows := sqlmock.NewRows([]string{"unknown"}).AddRow("1")
+		c.mock.ExpectQuery(getStatusHistoryByVendorIDQuery).
+			WithArgs("1").
+			WillReturnRows(rows)
+
+		res, err := c.accessor.GetStatusHistory(context.Background(), "1")
+
+		c.g.Expect(err).ToNot(gomega.BeNil())
+		c.g.Expect(res).To(gomega.BeNil())
+	})
+
+	t.Run("error while iterating rows", func(t *testing.T) {
+		c := setupVendorAccessorTestComponent(t)
+		defer c.db.Close()
+
+		rows := sqlmock.NewRows(columns).
+			AddRow("1", "1", "active", "blacklisted", "fraud", "approved", now, nil, "requester", "", nil, now).
+			RowError(0, errors.New("row error"))
+		c.mock.ExpectQuery(getStatusHistoryByVendorIDQuery).
+			WithArgs("1").
+			WillReturnRows(rows)
+
+		res, err := c.accessor.GetStatusHistory(context.Background(), "1")
+
+		c.g.Expect(err).ToNot(gomega.BeNil())
+		c.g.Expect(res).To(gomega.BeNil())
+	})
+}
+
+func Test_GetStatusHistoryByID(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, time.December, 2, 10, 0, 0, 0, time.UTC)
+	columns := []string{
+		"id", "vendor_id", "previous_status", "status", "reason", "approval_status",
+		"effective_from", "effective_to", "requested_by", "reviewed_by", "reviewed_date", "modified_date",
+	}
+
+	t.Run("success", func(t *testing.T) {
+		c := setupVendorAccessorTestComponent(t)
+		defer c.db.Close()
+
+		rows := sqlmock.NewRows(columns).
+			AddRow("2", "1", "blacklisted", "active", "cleared", "pending", nil, nil, "requester", "", nil, now)
+		c.mock.ExpectQuery(getStatusHistoryByIDQuery).
+			WithArgs("2").
+			WillReturnRows(rows)
+
+		res, err := c.accessor.GetStatusHistoryByID(context.Background(), "2")
+
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(res.ApprovalStatus).To(gomega.Equal("pending"))
+	})
+
+	t.Run("error", func(t *testing.T) {
+		c := setupVendorAccessorTestComponent(t)
+		defer c.db.Close()
+
+		c.mock.ExpectQuery(getStatusHistoryByIDQuery).
+			WithArgs("2").
+			WillReturnError(sql.ErrNoRows)
+
+		res, err := c.accessor.GetStatusHistoryByID(context.Background(), "2")
+
+		c.g.Expect(err).To(gomega.Equal(sql.ErrNoRows))
+		c.g.Expect(res).To(gomega.BeNil())
+	})
+}
+
+func Test_postgresVendorAccessor_GetAll_WithStatus(t *testing.T) {
+	t.Parallel()
+
+	c := setupVendorAccessorTestComponent(t)
+	defer c.db.Close()
+
+	spec := GetAllVendorSpec{
+		Status: "blacklisted",
+		PaginationSpec: database.PaginationSpec{
+			Order: "DESC",
+			Limit: 10,
+			Page:  1,
+		},
+	}
+
+	dataQuery := `
+		SELECT DISTINCT
+			v.id,
+			v.name,
+			v.description,
+			v.bp_id,
+			v.bp_name,
+			v.rating,
+			v.area_group_id,
+			v.area_group_name,
+			v.sap_code,
+			v.modified_date,
+			v.modified_by,
+			v.dt,
+			v.status
+		FROM vendor v
+		WHERE v.deleted_at IS NULL AND v.status = $1
+		ORDER BY v.dt DESC
+		LIMIT $2
+		OFFSET $3
+	`
+
+	rows := sqlmock.NewRows([]string{"id", "status"}).AddRow("1", "blacklisted")
+	c.mock.ExpectQuery(dataQuery).
+		WithArgs("blacklisted", 10, 0).
+		WillReturnRows(rows)
+	c.mock.ExpectQuery("SELECT COUNT(DISTINCT v.id) FROM vendor v WHERE v.deleted_at IS NULL AND v.status = $1").
+		WithArgs("blacklisted").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	res, err := c.accessor.GetAll(context.Background(), spec)
+
+	c.g.Expect(err).To(gomega.BeNil())
+	c.g.Expect(res.Vendors).To(gomega.Equal([]Vendor{{ID: "1", Status: "blacklisted"}}))
+	c.g.Expect(res.Metadata.TotalEntries).To(gomega.Equal(1))
+}
+
+func Test_postgresVendorAccessor_GetAll_WithInvalidStatus(t *testing.T) {
+	t.Parallel()
+
+	c := setupVendorAccessorTestComponent(t)
+	defer c.db.Close()
+
+	res, err := c.accessor.GetAll(context.Background(), GetAllVendorSpec{
+		Status:         "deleted",
+		PaginationSpec: database.PaginationSpec{Limit: 10, Page: 1},
+	})
+
+	c.g.Expect(err).To(gomega.Equal(ErrInvalidVendorStatus))
+	c.g.Expect(res).To(gomega.BeNil())
+	c.g.Expect(c.mock.ExpectationsWereMet()).To(gomega.BeNil())
+}
+
+func Test_postgresVendorAccessor_GetAll_WithFilters(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ratingMin     = 3
+		ratingMax     = 5
+		hasValidPrice = true
+		modifiedFrom  = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+		modifiedTo    = time.Date(2024, time.December, 31, 0, 0, 0, 0, time.UTC)
+		now           = time.Date(2024, time.December, 3, 0, 0, 0, 0, time.UTC)
+	)
+
+	spec := GetAllVendorSpec{
+		AreaGroupID:       "AG1",
+		SapCode:           "SAP1",
+		RatingMin:         &ratingMin,
+		RatingMax:         &ratingMax,
+		ModifiedFrom:      modifiedFrom,
+		ModifiedTo:        modifiedTo,
+		ProductCategoryID: "PC1",
+		HasValidPrice:     &hasValidPrice,
+		PaginationSpec: database.PaginationSpec{
+			Order:   "DESC",
+			OrderBy: "rating,name:asc",
+			Limit:   10,
+			Page:    1,
+		},
+	}
+
+	joinAndWhere := `
+		JOIN price pr ON pr.vendor_id = v.id AND pr.deleted_at IS NULL
+		JOIN product_vendor pv ON pv.id = pr.product_vendor_id AND pv.deleted_at IS NULL
+		JOIN product p ON p.id = pv.product_id AND p.deleted_at IS NULL
+		WHERE v.deleted_at IS NULL AND v.status = $1 AND v.area_group_id = $2 AND v.sap_code = $3 AND v.rating >= $4 AND v.rating <= $5
+			AND v.modified_date >= $6 AND v.modified_date <= $7 AND p.product_category_id = $8
+			AND EXISTS (
+			SELECT 1 FROM price vp
+			WHERE vp.vendor_id = v.id AND vp.deleted_at IS NULL AND vp.valid_from <= $9 AND (vp.valid_to IS NULL OR vp.valid_to >= $9)
+		)
+	`
+	filterArgs := []driver.Value{"active", "AG1", "SAP1", 3, 5, modifiedFrom, modifiedTo, "PC1", now}
+
+	t.Run("success", func(t *testing.T) {
+		c := setupVendorAccessorTestComponent(t)
+		defer c.db.Close()
+		c.cmock.Set(now)
+
+		dataQuery := `
+			SELECT DISTINCT
+				v.id,
+				v.name,
+				v.description,
+				v.bp_id,
+				v.bp_name,
+				v.rating,
+				v.area_group_id,
+				v.area_group_name,
+				v.sap_code,
+				v.modified_date,
+				v.modified_by,
+				v.dt,
+				v.status
+			FROM vendor v
+		` + joinAndWhere + `
+			ORDER BY v.rating DESC, v.name ASC
+			LIMIT $10
+			OFFSET $11
+		`
+
+		c.mock.ExpectQuery(dataQuery).
+			WithArgs(append(filterArgs, 10, 0)...).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1"))
+		c.mock.ExpectQuery("SELECT COUNT(DISTINCT v.id) FROM vendor v " + joinAndWhere).
+			WithArgs(filterArgs...).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+		res, err := c.accessor.GetAll(context.Background(), spec)
+
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(res.Vendors).To(gomega.Equal([]Vendor{{ID: "1"}}))
+		c.g.Expect(res.Metadata.TotalEntries).To(gomega.Equal(1))
+	})
+
+	t.Run("vendors without a valid price", func(t *testing.T) {
+		c := setupVendorAccessorTestComponent(t)
+		defer c.db.Close()
+		c.cmock.Set(now)
+
+		noValidPrice := false
+		where := `
+			WHERE v.deleted_at IS NULL AND v.status = $1 AND NOT EXISTS (
+				SELECT 1 FROM price vp
+				WHERE vp.vendor_id = v.id AND vp.deleted_at IS NULL AND vp.valid_from <= $2 AND (vp.valid_to IS NULL OR vp.valid_to >= $2)
+			)
+		`
+		dataQuery := `
+			SELECT DISTINCT
+				v.id,
+				v.name,
+				v.description,
+				v.bp_id,
+				v.bp_name,
+				v.rating,
+				v.area_group_id,
+				v.area_group_name,
+				v.sap_code,
+				v.modified_date,
+				v.modified_by,
+				v.dt,
+				v.status
+			FROM vendor v
+		` + where + `
+			ORDER BY v.dt ASC
+			LIMIT $3
+			OFFSET $4
+		`
+
+		c.mock.ExpectQuery(dataQuery).
+			WithArgs("active", now, 10, 0).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1"))
+		c.mock.ExpectQuery("SELECT COUNT(DISTINCT v.id) FROM vendor v "+where).
+			WithArgs("active", now).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+		res, err := c.accessor.GetAll(context.Background(), GetAllVendorSpec{
+			HasValidPrice:  &noValidPrice,
+			PaginationSpec: database.PaginationSpec{Limit: 10, Page: 1},
+		})
+
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(res.Vendors).To(gomega.HaveLen(1))
+	})
+
+	t.Run("error on unknown sort column", func(t *testing.T) {
+		c := setupVendorAccessorTestComponent(t)
+		defer c.db.Close()
+
+		res, err := c.accessor.GetAll(context.Background(), GetAllVendorSpec{
+			PaginationSpec: database.PaginationSpec{
+				OrderBy: "rating; DROP TABLE vendor",
+				Limit:   10,
+				Page:    1,
+			},
+		})
+
+		c.g.Expect(errors.Is(err, database.ErrInvalidSortColumn)).To(gomega.BeTrue())
+		c.g.Expect(res).To(gomega.BeNil())
+	})
+}
+
+func Test_postgresVendorAccessor_GetAll_WithCursor(t *testing.T) {
+	t.Parallel()
+
+	var (
+		columns = []string{"id", "name", "dt", "cursor_value"}
+		dt1     = time.Date(2024, time.December, 2, 0, 0, 0, 0, time.UTC)
+		dt2     = time.Date(2024, time.December, 1, 0, 0, 0, 0, time.UTC)
+	)
+
+	selectClause := `
+		SELECT DISTINCT
+			v.id,
+			v.name,
+			v.description,
+			v.bp_id,
+			v.bp_name,
+			v.rating,
+			v.area_group_id,
+			v.area_group_name,
+			v.sap_code,
+			v.modified_date,
+			v.modified_by,
+			v.dt,
+			v.status,
+			v.dt AS cursor_value
+		FROM vendor v
+	`
+
+	t.Run("first page", func(t *testing.T) {
+		c := setupVendorAccessorTestComponent(t)
+		defer c.db.Close()
+
+		query := selectClause + `
+			WHERE v.deleted_at IS NULL AND v.status = $1
+			ORDER BY v.dt DESC, v.id DESC
+			LIMIT $2
+		`
+		rows := sqlmock.NewRows(columns).
+			AddRow("1", "Vendor 1", dt1, dt1).
+			AddRow("2", "Vendor 2", dt2, dt2)
+		c.mock.ExpectQuery(query).WithArgs("active", 2).WillReturnRows(rows)
+
+		res, err := c.accessor.GetAll(context.Background(), GetAllVendorSpec{
+			PaginationSpec: database.PaginationSpec{
+				Mode:  database.PaginationModeCursor,
+				Order: "DESC",
+				Limit: 1,
+			},
+		})
+
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(res.Vendors).To(gomega.Equal([]Vendor{{ID: "1", Name: "Vendor 1", Date: dt1}}))
+		c.g.Expect(res.Metadata.NextCursor).To(gomega.Equal(
+			database.EncodeCursor(database.Cursor{Value: dt1, ID: "1"}),
+		))
+		c.g.Expect(res.Metadata.PrevCursor).To(gomega.BeEmpty())
+		c.g.Expect(c.mock.ExpectationsWereMet()).To(gomega.Succeed())
+	})
+
+	t.Run("next page", func(t *testing.T) {
+		c := setupVendorAccessorTestComponent(t)
+		defer c.db.Close()
+
+		query := selectClause + `
+			WHERE v.deleted_at IS NULL AND v.status = $1 AND v.area_group_name = $2 AND (v.dt < $3 OR (v.dt IS NOT DISTINCT FROM $3 AND v.id < $4) OR (v.dt IS NOT NULL AND $3 IS NULL))
+			ORDER BY v.dt DESC, v.id DESC
+			LIMIT $5
+		`
+		rows := sqlmock.NewRows(columns).AddRow("2", "Vendor 2", dt2, dt2)
+		c.mock.ExpectQuery(query).
+			WithArgs("active", "Jakarta", "2024-12-02T00:00:00Z", "1", 2).
+			WillReturnRows(rows)
+
+		res, err := c.accessor.GetAll(context.Background(), GetAllVendorSpec{
+			Location: "Jakarta",
+			PaginationSpec: database.PaginationSpec{
+				Order:  "DESC",
+				Limit:  1,
+				Cursor: database.EncodeCursor(database.Cursor{Value: dt1, ID: "1"}),
+			},
+		})
+
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(res.Vendors).To(gomega.Equal([]Vendor{{ID: "2", Name: "Vendor 2", Date: dt2}}))
+		c.g.Expect(res.Metadata.NextCursor).To(gomega.BeEmpty())
+		c.g.Expect(res.Metadata.PrevCursor).To(gomega.Equal(
+			database.EncodeCursor(database.Cursor{Value: dt2, ID: "2", Backward: true}),
+		))
+	})
+
+	t.Run("rows with a NULL sort value span the page boundary", func(t *testing.T) {
+		c := setupVendorAccessorTestComponent(t)
+		defer c.db.Close()
+
+		ratingSelect := strings.Replace(selectClause, "v.dt AS cursor_value", "v.rating AS cursor_value", 1)
+
+		firstQuery := ratingSelect + `
+			WHERE v.deleted_at IS NULL AND v.status = $1
+			ORDER BY v.rating DESC, v.id DESC
+			LIMIT $2
+		`
+		c.mock.ExpectQuery(firstQuery).WithArgs("active", 2).WillReturnRows(
+			sqlmock.NewRows(columns).
+				AddRow("3", "Vendor 3", dt1, nil).
+				AddRow("2", "Vendor 2", dt1, nil),
+		)
+
+		first, err := c.accessor.GetAll(context.Background(), GetAllVendorSpec{
+			PaginationSpec: database.PaginationSpec{
+				Mode:    database.PaginationModeCursor,
+				OrderBy: "rating:desc",
+				Limit:   1,
+			},
+		})
+
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(first.Vendors).To(gomega.Equal([]Vendor{{ID: "3", Name: "Vendor 3", Date: dt1}}))
+		c.g.Expect(first.Metadata.NextCursor).To(gomega.Equal(
+			database.EncodeCursor(database.Cursor{Value: nil, ID: "3"}),
+		))
+
+		nextQuery := ratingSelect + `
+			WHERE v.deleted_at IS NULL AND v.status = $1 AND (v.rating < $2 OR (v.rating IS NOT DISTINCT FROM $2 AND v.id < $3) OR (v.rating IS NOT NULL AND $2 IS NULL))
+			ORDER BY v.rating DESC, v.id DESC
+			LIMIT $4
+		`
+		c.mock.ExpectQuery(nextQuery).WithArgs("active", nil, "3", 2).WillReturnRows(
+			sqlmock.NewRows(columns).
+				AddRow("2", "Vendor 2", dt1, nil).
+				AddRow("1", "Vendor 1", dt2, 4),
+		)
+
+		next, err := c.accessor.GetAll(context.Background(), GetAllVendorSpec{
+			PaginationSpec: database.PaginationSpec{
+				OrderBy: "rating:desc",
+				Limit:   1,
+				Cursor:  first.Metadata.NextCursor,
+			},
+		})
+
+		c.g.Expect(err).To(gomega.BeNil())
+		c.g.Expect(next.Vendors).To(gomega.Equal([]Vendor{{ID: "2", Name: "Vendor 2", Date: dt1}}))
+		c.g.Expect(next.Metadata.NextCursor).To(gomega.Equal(
+			database.EncodeCursor(database.Cursor{Value: nil, ID: "2"}),
+		))
+		c.g.Expect(c.mock.ExpectationsWereMet()).To(gomega.Succeed())
+	})
+
+	t.Run("error on invalid cursor", func(t *testing.T) {
+		c := setupVendorAccessorTestComponent(t)
+		defer c.db.Close()
+
+		res, err := c.accessor.GetAll(context.Background(), GetAllVendorSpec{
+			PaginationSpec: database.PaginationSpec{Cursor: "invalid"},
+		})
+
+		c.g.Expect(err).To(gomega.Equal(database.ErrInvalidCursor))
+		c.g.Expect(res).To(gomega.BeNil())
+	})
+}
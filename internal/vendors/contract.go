@@ -9,4 +9,5 @@ type EmailBlastContract struct {
 	Subject     string                  `form:"subject"`
 	Body        string                  `form:"body"`
 	Attachments []*multipart.FileHeader `form:"attachments"`
+	SentBy      string                  `form:"sent_by"`
 }
@@ -0,0 +1,27 @@
+package vendors
+
+import (
+	"kg/procurement/internal/mailer"
+)
+
+var vendorExportHeader = []interface{}{
+	"id", "name", "email", "description", "bp_id", "bp_name", "rating",
+	"area_group_id", "area_group_name", "sap_code", "status", "modified_date", "modified_by",
+}
+
+func vendorExportRecord(v Vendor) []interface{} {
+	return []interface{}{
+		v.ID, v.Name, v.Email, v.Description, v.BpID, v.BpName, v.Rating,
+		v.AreaGroupID, v.AreaGroupName, v.SapCode, v.Status, v.ModifiedDate, v.ModifiedBy,
+	}
+}
+
+var emailStatusExportHeader = []interface{}{
+	"id", "email_to", "status", "vendor_id", "vendor_name", "vendor_rating", "date_sent", "modified_date",
+}
+
+func emailStatusExportRecord(es mailer.EmailStatusResponse) []interface{} {
+	return []interface{}{
+		es.ID, es.EmailTo, es.Status, es.VendorID, es.VendorName, es.VendorRating, es.DateSent, es.ModifiedDate,
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 type seederDataWriter interface {
 	writeVendor(ctx context.Context, vendor Vendor) error
+	writeEvaluation(ctx context.Context, evaluation VendorEvaluation) error
 	Close() error
 }
 
@@ -26,6 +27,15 @@ func (s *Seeder) SetupVendors(ctx context.Context, vendors []Vendor) error {
 	return nil
 }
 
+func (s *Seeder) SetupEvaluations(ctx context.Context, evaluations []VendorEvaluation) error {
+	for _, evaluation := range evaluations {
+		if err := s.seederDataWriter.writeEvaluation(ctx, evaluation); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *Seeder) Close() error {
 	return s.seederDataWriter.Close()
 }
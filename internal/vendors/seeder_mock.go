@@ -77,6 +77,44 @@ func (c *MockseederDataWriterCloseCall) DoAndReturn(f func() error) *MockseederD
 	return c
 }
 
+// writeEvaluation mocks base method.
+func (m *MockseederDataWriter) writeEvaluation(ctx context.Context, evaluation VendorEvaluation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "writeEvaluation", ctx, evaluation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// writeEvaluation indicates an expected call of writeEvaluation.
+func (mr *MockseederDataWriterMockRecorder) writeEvaluation(ctx, evaluation any) *MockseederDataWriterwriteEvaluationCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "writeEvaluation", reflect.TypeOf((*MockseederDataWriter)(nil).writeEvaluation), ctx, evaluation)
+	return &MockseederDataWriterwriteEvaluationCall{Call: call}
+}
+
+// MockseederDataWriterwriteEvaluationCall wrap *gomock.Call
+type MockseederDataWriterwriteEvaluationCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockseederDataWriterwriteEvaluationCall) Return(arg0 error) *MockseederDataWriterwriteEvaluationCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockseederDataWriterwriteEvaluationCall) Do(f func(context.Context, VendorEvaluation) error) *MockseederDataWriterwriteEvaluationCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockseederDataWriterwriteEvaluationCall) DoAndReturn(f func(context.Context, VendorEvaluation) error) *MockseederDataWriterwriteEvaluationCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // writeVendor mocks base method.
 func (m *MockseederDataWriter) writeVendor(ctx context.Context, vendor Vendor) error {
 	m.ctrl.T.Helper()
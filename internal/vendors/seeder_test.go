@@ -57,6 +57,29 @@ func Test_Seeder(t *testing.T) {
 		})
 	})
 
+	t.Run("setup evaluations", func(t *testing.T) {
+		evaluations := []VendorEvaluation{{ID: "1"}, {ID: "2"}}
+
+		t.Run("success", func(t *testing.T) {
+			g := setup(t)
+
+			mockWriter.EXPECT().writeEvaluation(ctx, VendorEvaluation{ID: "1"})
+			mockWriter.EXPECT().writeEvaluation(ctx, VendorEvaluation{ID: "2"})
+
+			err := subject.SetupEvaluations(ctx, evaluations)
+			g.Expect(err).ShouldNot(gomega.HaveOccurred())
+		})
+
+		t.Run("error", func(t *testing.T) {
+			g := setup(t)
+
+			mockWriter.EXPECT().writeEvaluation(ctx, VendorEvaluation{ID: "1"}).Return(errors.New("error"))
+
+			err := subject.SetupEvaluations(ctx, evaluations)
+			g.Expect(err).Should(gomega.HaveOccurred())
+		})
+	})
+
 	t.Run("Close", func(t *testing.T) {
 
 		t.Run("success", func(t *testing.T) {
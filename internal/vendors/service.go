@@ -8,6 +8,7 @@ import (
 	"kg/procurement/cmd/utils"
 	"kg/procurement/internal/common/database"
 	"kg/procurement/internal/common/helper"
+	"kg/procurement/internal/common/spreadsheet"
 	"kg/procurement/internal/mailer"
 	"strings"
 	"sync"
@@ -17,6 +18,7 @@ import (
 )
 
 type vendorDBAccessor interface {
+	runInTx(ctx context.Context, fn func(tx vendorDBAccessor) error) error
 	GetSomeStuff(ctx context.Context) ([]string, error)
 	GetAll(ctx context.Context, spec GetAllVendorSpec) (*AccessorGetAllPaginationData, error)
 	GetById(ctx context.Context, id string) (*Vendor, error)
@@ -25,10 +27,19 @@ type vendorDBAccessor interface {
 	BulkGetByIDs(_ context.Context, ids []string) ([]Vendor, error)
 	BulkGetByProductName(_ context.Context, productName string) ([]Vendor, error)
 	CreateEvaluation(ctx context.Context, evaluation *VendorEvaluation) (*VendorEvaluation, error)
+	UpdateStatus(ctx context.Context, vendorID string, status VendorStatus, effectiveFrom time.Time, modifiedBy string) error
+	LockStatus(ctx context.Context, vendorID string) (string, error)
+	HasPendingStatusChange(ctx context.Context, vendorID string) (bool, error)
+	SoftDelete(ctx context.Context, id string, deletedBy string) error
+	Restore(ctx context.Context, id string, restoredBy string) error
+	WriteStatusHistory(ctx context.Context, history VendorStatusHistory) error
+	UpdateStatusHistoryReview(ctx context.Context, history VendorStatusHistory) error
+	GetStatusHistory(ctx context.Context, vendorID string) ([]VendorStatusHistory, error)
+	GetStatusHistoryByID(ctx context.Context, id string) (*VendorStatusHistory, error)
 }
 
 type emailStatusSvc interface {
-	WriteEmailStatus(ctx context.Context, status mailer.EmailStatus) error
+	WriteEmailStatuses(ctx context.Context, statuses []mailer.EmailStatus) error
 	GetAllEmailStatus(ctx context.Context, spec mailer.GetAllEmailStatusSpec) (*mailer.AccessorGetEmailStatusPaginationData, error)
 }
 
@@ -37,6 +48,7 @@ type VendorService struct {
 	vendorDBAccessor
 	smtpProvider   mailer.EmailProvider
 	emailStatusSvc emailStatusSvc
+	clock          clock.Clock
 }
 
 func (v *VendorService) GetById(ctx context.Context, id string) (*Vendor, error) {
@@ -47,15 +59,50 @@ func (v *VendorService) GetAll(ctx context.Context, spec GetAllVendorSpec) (*Acc
 	return v.vendorDBAccessor.GetAll(ctx, spec)
 }
 
+// ExportVendors writes every vendor matching the filters of spec to w, the
+// listing is read in batches so large exports are never held in memory
+func (v *VendorService) ExportVendors(ctx context.Context, spec GetAllVendorSpec, w spreadsheet.Writer) error {
+	if err := w.Write(vendorExportHeader); err != nil {
+		return err
+	}
+
+	return database.WalkCursor(spec.PaginationSpec, func(page database.PaginationSpec) (database.PaginationMetadata, error) {
+		spec.PaginationSpec = page
+		res, err := v.vendorDBAccessor.GetAll(ctx, spec)
+		if err != nil {
+			utils.Logger.Error(err.Error())
+			return database.PaginationMetadata{}, err
+		}
+
+		for _, vendor := range res.Vendors {
+			if err := w.Write(vendorExportRecord(vendor)); err != nil {
+				return database.PaginationMetadata{}, err
+			}
+		}
+		return res.Metadata, nil
+	})
+}
+
 func (v *VendorService) UpdateDetail(ctx context.Context, vendor Vendor) (*Vendor, error) {
 	return v.vendorDBAccessor.UpdateDetail(ctx, vendor)
 }
 
+// DeleteVendor soft deletes a vendor, ErrVendorInUse is returned while prices refer to it
+func (v *VendorService) DeleteVendor(ctx context.Context, id string, deletedBy string) error {
+	return v.vendorDBAccessor.SoftDelete(ctx, id, deletedBy)
+}
+
+// RestoreVendor restores a deleted vendor, ErrVendorNotFound is returned when it isn't deleted
+func (v *VendorService) RestoreVendor(ctx context.Context, id string, restoredBy string) error {
+	return v.vendorDBAccessor.Restore(ctx, id, restoredBy)
+}
+
 func (v *VendorService) GetLocations(ctx context.Context) ([]string, error) {
 	return v.vendorDBAccessor.GetAllLocations(ctx)
 }
 
-func (v *VendorService) BlastEmail(ctx context.Context, vendorIDs []string, email mailer.Email) ([]string, error) {
+// BlastEmail emails the active vendors among vendorIDs, sentBy is recorded on the statuses
+func (v *VendorService) BlastEmail(ctx context.Context, vendorIDs []string, email mailer.Email, sentBy string) ([]string, error) {
 	vendors, err := v.vendorDBAccessor.BulkGetByIDs(ctx, vendorIDs)
 	if err != nil {
 		return nil, err
@@ -63,10 +110,10 @@ func (v *VendorService) BlastEmail(ctx context.Context, vendorIDs []string, emai
 
 	v.applyDefaultEmailTemplate(&email)
 
-	return v.executeBlastEmail(ctx, vendors, email)
+	return v.executeBlastEmail(ctx, filterActiveVendors(vendors), email, sentBy)
 }
 
-func (v *VendorService) AutomatedEmailBlast(ctx context.Context, productName string) ([]string, error) {
+func (v *VendorService) AutomatedEmailBlast(ctx context.Context, productName string, sentBy string) ([]string, error) {
 	vendors, err := v.vendorDBAccessor.BulkGetByProductName(ctx, productName)
 
 	if err != nil {
@@ -81,7 +128,7 @@ func (v *VendorService) AutomatedEmailBlast(ctx context.Context, productName str
 
 	email.Body = v.replacePlaceholder(email.Body, replacements)
 
-	return v.executeBlastEmail(ctx, vendors, *email)
+	return v.executeBlastEmail(ctx, vendors, *email, sentBy)
 }
 
 func (*VendorService) applyDefaultEmailTemplate(email *mailer.Email) {
@@ -102,7 +149,145 @@ func (v *VendorService) CreateEvaluation(ctx context.Context, evaluation *Vendor
 	return v.vendorDBAccessor.CreateEvaluation(ctx, evaluation)
 }
 
-func (v *VendorService) executeBlastEmail(ctx context.Context, vendors []Vendor, email mailer.Email) ([]string, error) {
+// UpdateStatus changes the status of a vendor and records it on the status history.
+// Lifting a blacklist is only recorded as a pending request, see ReviewStatusChange,
+// a vendor has at most one pending request at a time
+func (v *VendorService) UpdateStatus(ctx context.Context, vendorID string, spec PutVendorStatusSpec) (*VendorStatusHistory, error) {
+	status, err := ParseVendorStatus(spec.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != VendorStatusActive && strings.TrimSpace(spec.Reason) == "" {
+		return nil, ErrStatusReasonRequired
+	}
+
+	id, err := helper.GenerateRandomID()
+	if err != nil {
+		utils.Logger.Errorf("failed to generate random ID: %v", err)
+		return nil, err
+	}
+
+	now := v.clock.Now()
+	var history VendorStatusHistory
+	err = v.vendorDBAccessor.runInTx(ctx, func(tx vendorDBAccessor) error {
+		current, err := tx.LockStatus(ctx, vendorID)
+		if err != nil {
+			return err
+		}
+		if current == status.String() {
+			return ErrVendorStatusUnchanged
+		}
+
+		history = VendorStatusHistory{
+			ID:             id,
+			VendorID:       vendorID,
+			PreviousStatus: current,
+			Status:         status.String(),
+			Reason:         spec.Reason,
+			RequestedBy:    spec.RequestedBy,
+			ModifiedDate:   now,
+		}
+
+		if current == VendorStatusBlacklisted.String() {
+			// the requester can't review the request, an anonymous one could
+			if strings.TrimSpace(spec.RequestedBy) == "" {
+				return ErrStatusRequesterRequired
+			}
+			pending, err := tx.HasPendingStatusChange(ctx, vendorID)
+			if err != nil {
+				return err
+			}
+			if pending {
+				return ErrStatusChangePending
+			}
+			history.ApprovalStatus = StatusApprovalPending.String()
+			return tx.WriteStatusHistory(ctx, history)
+		}
+
+		history.ApprovalStatus = StatusApprovalApproved.String()
+		history.EffectiveFrom = &now
+		if err := tx.UpdateStatus(ctx, vendorID, status, now, spec.RequestedBy); err != nil {
+			return err
+		}
+		return tx.WriteStatusHistory(ctx, history)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &history, nil
+}
+
+// ReviewStatusChange approves or rejects a pending status change,
+// the vendor status is only updated when the change is approved and
+// the vendor still has the status the change was requested from.
+// A change reviewed concurrently returns ErrStatusChangeNotPending
+func (v *VendorService) ReviewStatusChange(ctx context.Context, historyID string, spec ReviewVendorStatusSpec) (*VendorStatusHistory, error) {
+	var history *VendorStatusHistory
+	err := v.vendorDBAccessor.runInTx(ctx, func(tx vendorDBAccessor) error {
+		var err error
+		if history, err = tx.GetStatusHistoryByID(ctx, historyID); err != nil {
+			return err
+		}
+
+		if history.ApprovalStatus != StatusApprovalPending.String() {
+			return ErrStatusChangeNotPending
+		}
+
+		if history.RequestedBy != "" && history.RequestedBy == spec.ReviewedBy {
+			return ErrStatusChangeSelfApproval
+		}
+
+		now := v.clock.Now()
+		history.ReviewedBy = spec.ReviewedBy
+		history.ReviewedDate = &now
+		history.ModifiedDate = now
+
+		if !spec.Approved {
+			history.ApprovalStatus = StatusApprovalRejected.String()
+			return tx.UpdateStatusHistoryReview(ctx, *history)
+		}
+
+		history.ApprovalStatus = StatusApprovalApproved.String()
+		history.EffectiveFrom = &now
+		current, err := tx.LockStatus(ctx, history.VendorID)
+		if err != nil {
+			return err
+		}
+		if current != history.PreviousStatus {
+			return ErrVendorStatusChanged
+		}
+		if err := tx.UpdateStatus(ctx, history.VendorID, VendorStatus(history.Status), now, spec.ReviewedBy); err != nil {
+			return err
+		}
+		return tx.UpdateStatusHistoryReview(ctx, *history)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return history, nil
+}
+
+func (v *VendorService) GetStatusHistory(ctx context.Context, vendorID string) ([]VendorStatusHistory, error) {
+	return v.vendorDBAccessor.GetStatusHistory(ctx, vendorID)
+}
+
+// filterActiveVendors drops suspended and blacklisted vendors so they are never invited
+func filterActiveVendors(vendors []Vendor) []Vendor {
+	res := []Vendor{}
+	for _, vendor := range vendors {
+		if !vendor.IsActive() {
+			utils.Logger.Infof("Skipping %s vendor: %s", vendor.Status, vendor.ID)
+			continue
+		}
+		res = append(res, vendor)
+	}
+	return res
+}
+
+func (v *VendorService) executeBlastEmail(ctx context.Context, vendors []Vendor, email mailer.Email, sentBy string) ([]string, error) {
 	errCh := make(chan error, len(vendors))
 	statusCh := make(chan mailer.EmailStatus, len(vendors))
 
@@ -145,6 +330,7 @@ func (v *VendorService) executeBlastEmail(ctx context.Context, vendors []Vendor,
 				VendorID:     vendor.ID,
 				DateSent:     dateSent,
 				ModifiedDate: dateSent,
+				ModifiedBy:   sentBy,
 			}
 
 			if sendErr != nil {
@@ -172,11 +358,12 @@ func (v *VendorService) executeBlastEmail(ctx context.Context, vendors []Vendor,
 	}
 
 	// write email statuses to the database so we can track the status
+	statuses := make([]mailer.EmailStatus, 0, len(vendors))
 	for status := range statusCh {
-		writeErr := v.emailStatusSvc.WriteEmailStatus(ctx, status)
-		if writeErr != nil {
-			utils.Logger.Errorf("failed to write email status: %v", writeErr)
-		}
+		statuses = append(statuses, status)
+	}
+	if writeErr := v.emailStatusSvc.WriteEmailStatuses(ctx, statuses); writeErr != nil {
+		utils.Logger.Errorf("failed to write email statuses: %v", writeErr)
 	}
 
 	if len(errList) > 0 {
@@ -259,6 +446,29 @@ func (v *VendorService) GetPopulatedEmailStatus(
 	return &res, nil
 }
 
+// ExportEmailStatus writes every email status matching spec to w along with
+// the name and rating of its vendor
+func (v *VendorService) ExportEmailStatus(ctx context.Context, spec mailer.GetAllEmailStatusSpec, w spreadsheet.Writer) error {
+	if err := w.Write(emailStatusExportHeader); err != nil {
+		return err
+	}
+
+	return database.WalkCursor(spec.PaginationSpec, func(page database.PaginationSpec) (database.PaginationMetadata, error) {
+		spec.PaginationSpec = page
+		res, err := v.GetPopulatedEmailStatus(ctx, spec)
+		if err != nil {
+			return database.PaginationMetadata{}, err
+		}
+
+		for _, es := range res.EmailStatus {
+			if err := w.Write(emailStatusExportRecord(es)); err != nil {
+				return database.PaginationMetadata{}, err
+			}
+		}
+		return res.Metadata, nil
+	})
+}
+
 func NewVendorService(
 	cfg config.Application,
 	conn database.DBConnector,
@@ -271,5 +481,6 @@ func NewVendorService(
 		vendorDBAccessor: newPostgresVendorAccessor(conn, clock),
 		smtpProvider:     smtpProvider,
 		emailStatusSvc:   emailStatusSvc,
+		clock:            clock,
 	}
 }
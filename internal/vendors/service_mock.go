@@ -13,6 +13,7 @@ import (
 	context "context"
 	mailer "kg/procurement/internal/mailer"
 	reflect "reflect"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -313,6 +314,238 @@ func (c *MockvendorDBAccessorGetSomeStuffCall) DoAndReturn(f func(context.Contex
 	return c
 }
 
+// GetStatusHistory mocks base method.
+func (m *MockvendorDBAccessor) GetStatusHistory(ctx context.Context, vendorID string) ([]VendorStatusHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatusHistory", ctx, vendorID)
+	ret0, _ := ret[0].([]VendorStatusHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatusHistory indicates an expected call of GetStatusHistory.
+func (mr *MockvendorDBAccessorMockRecorder) GetStatusHistory(ctx, vendorID any) *MockvendorDBAccessorGetStatusHistoryCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatusHistory", reflect.TypeOf((*MockvendorDBAccessor)(nil).GetStatusHistory), ctx, vendorID)
+	return &MockvendorDBAccessorGetStatusHistoryCall{Call: call}
+}
+
+// MockvendorDBAccessorGetStatusHistoryCall wrap *gomock.Call
+type MockvendorDBAccessorGetStatusHistoryCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockvendorDBAccessorGetStatusHistoryCall) Return(arg0 []VendorStatusHistory, arg1 error) *MockvendorDBAccessorGetStatusHistoryCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockvendorDBAccessorGetStatusHistoryCall) Do(f func(context.Context, string) ([]VendorStatusHistory, error)) *MockvendorDBAccessorGetStatusHistoryCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockvendorDBAccessorGetStatusHistoryCall) DoAndReturn(f func(context.Context, string) ([]VendorStatusHistory, error)) *MockvendorDBAccessorGetStatusHistoryCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetStatusHistoryByID mocks base method.
+func (m *MockvendorDBAccessor) GetStatusHistoryByID(ctx context.Context, id string) (*VendorStatusHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatusHistoryByID", ctx, id)
+	ret0, _ := ret[0].(*VendorStatusHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatusHistoryByID indicates an expected call of GetStatusHistoryByID.
+func (mr *MockvendorDBAccessorMockRecorder) GetStatusHistoryByID(ctx, id any) *MockvendorDBAccessorGetStatusHistoryByIDCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatusHistoryByID", reflect.TypeOf((*MockvendorDBAccessor)(nil).GetStatusHistoryByID), ctx, id)
+	return &MockvendorDBAccessorGetStatusHistoryByIDCall{Call: call}
+}
+
+// MockvendorDBAccessorGetStatusHistoryByIDCall wrap *gomock.Call
+type MockvendorDBAccessorGetStatusHistoryByIDCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockvendorDBAccessorGetStatusHistoryByIDCall) Return(arg0 *VendorStatusHistory, arg1 error) *MockvendorDBAccessorGetStatusHistoryByIDCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockvendorDBAccessorGetStatusHistoryByIDCall) Do(f func(context.Context, string) (*VendorStatusHistory, error)) *MockvendorDBAccessorGetStatusHistoryByIDCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockvendorDBAccessorGetStatusHistoryByIDCall) DoAndReturn(f func(context.Context, string) (*VendorStatusHistory, error)) *MockvendorDBAccessorGetStatusHistoryByIDCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// HasPendingStatusChange mocks base method.
+func (m *MockvendorDBAccessor) HasPendingStatusChange(ctx context.Context, vendorID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasPendingStatusChange", ctx, vendorID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasPendingStatusChange indicates an expected call of HasPendingStatusChange.
+func (mr *MockvendorDBAccessorMockRecorder) HasPendingStatusChange(ctx, vendorID any) *MockvendorDBAccessorHasPendingStatusChangeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasPendingStatusChange", reflect.TypeOf((*MockvendorDBAccessor)(nil).HasPendingStatusChange), ctx, vendorID)
+	return &MockvendorDBAccessorHasPendingStatusChangeCall{Call: call}
+}
+
+// MockvendorDBAccessorHasPendingStatusChangeCall wrap *gomock.Call
+type MockvendorDBAccessorHasPendingStatusChangeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockvendorDBAccessorHasPendingStatusChangeCall) Return(arg0 bool, arg1 error) *MockvendorDBAccessorHasPendingStatusChangeCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockvendorDBAccessorHasPendingStatusChangeCall) Do(f func(context.Context, string) (bool, error)) *MockvendorDBAccessorHasPendingStatusChangeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockvendorDBAccessorHasPendingStatusChangeCall) DoAndReturn(f func(context.Context, string) (bool, error)) *MockvendorDBAccessorHasPendingStatusChangeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// LockStatus mocks base method.
+func (m *MockvendorDBAccessor) LockStatus(ctx context.Context, vendorID string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockStatus", ctx, vendorID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockStatus indicates an expected call of LockStatus.
+func (mr *MockvendorDBAccessorMockRecorder) LockStatus(ctx, vendorID any) *MockvendorDBAccessorLockStatusCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockStatus", reflect.TypeOf((*MockvendorDBAccessor)(nil).LockStatus), ctx, vendorID)
+	return &MockvendorDBAccessorLockStatusCall{Call: call}
+}
+
+// MockvendorDBAccessorLockStatusCall wrap *gomock.Call
+type MockvendorDBAccessorLockStatusCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockvendorDBAccessorLockStatusCall) Return(arg0 string, arg1 error) *MockvendorDBAccessorLockStatusCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockvendorDBAccessorLockStatusCall) Do(f func(context.Context, string) (string, error)) *MockvendorDBAccessorLockStatusCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockvendorDBAccessorLockStatusCall) DoAndReturn(f func(context.Context, string) (string, error)) *MockvendorDBAccessorLockStatusCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Restore mocks base method.
+func (m *MockvendorDBAccessor) Restore(ctx context.Context, id, restoredBy string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id, restoredBy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockvendorDBAccessorMockRecorder) Restore(ctx, id, restoredBy any) *MockvendorDBAccessorRestoreCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockvendorDBAccessor)(nil).Restore), ctx, id, restoredBy)
+	return &MockvendorDBAccessorRestoreCall{Call: call}
+}
+
+// MockvendorDBAccessorRestoreCall wrap *gomock.Call
+type MockvendorDBAccessorRestoreCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockvendorDBAccessorRestoreCall) Return(arg0 error) *MockvendorDBAccessorRestoreCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockvendorDBAccessorRestoreCall) Do(f func(context.Context, string, string) error) *MockvendorDBAccessorRestoreCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockvendorDBAccessorRestoreCall) DoAndReturn(f func(context.Context, string, string) error) *MockvendorDBAccessorRestoreCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// SoftDelete mocks base method.
+func (m *MockvendorDBAccessor) SoftDelete(ctx context.Context, id, deletedBy string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SoftDelete", ctx, id, deletedBy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SoftDelete indicates an expected call of SoftDelete.
+func (mr *MockvendorDBAccessorMockRecorder) SoftDelete(ctx, id, deletedBy any) *MockvendorDBAccessorSoftDeleteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SoftDelete", reflect.TypeOf((*MockvendorDBAccessor)(nil).SoftDelete), ctx, id, deletedBy)
+	return &MockvendorDBAccessorSoftDeleteCall{Call: call}
+}
+
+// MockvendorDBAccessorSoftDeleteCall wrap *gomock.Call
+type MockvendorDBAccessorSoftDeleteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockvendorDBAccessorSoftDeleteCall) Return(arg0 error) *MockvendorDBAccessorSoftDeleteCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockvendorDBAccessorSoftDeleteCall) Do(f func(context.Context, string, string) error) *MockvendorDBAccessorSoftDeleteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockvendorDBAccessorSoftDeleteCall) DoAndReturn(f func(context.Context, string, string) error) *MockvendorDBAccessorSoftDeleteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // UpdateDetail mocks base method.
 func (m *MockvendorDBAccessor) UpdateDetail(ctx context.Context, spec Vendor) (*Vendor, error) {
 	m.ctrl.T.Helper()
@@ -352,6 +585,158 @@ func (c *MockvendorDBAccessorUpdateDetailCall) DoAndReturn(f func(context.Contex
 	return c
 }
 
+// UpdateStatus mocks base method.
+func (m *MockvendorDBAccessor) UpdateStatus(ctx context.Context, vendorID string, status VendorStatus, effectiveFrom time.Time, modifiedBy string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, vendorID, status, effectiveFrom, modifiedBy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockvendorDBAccessorMockRecorder) UpdateStatus(ctx, vendorID, status, effectiveFrom, modifiedBy any) *MockvendorDBAccessorUpdateStatusCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockvendorDBAccessor)(nil).UpdateStatus), ctx, vendorID, status, effectiveFrom, modifiedBy)
+	return &MockvendorDBAccessorUpdateStatusCall{Call: call}
+}
+
+// MockvendorDBAccessorUpdateStatusCall wrap *gomock.Call
+type MockvendorDBAccessorUpdateStatusCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockvendorDBAccessorUpdateStatusCall) Return(arg0 error) *MockvendorDBAccessorUpdateStatusCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockvendorDBAccessorUpdateStatusCall) Do(f func(context.Context, string, VendorStatus, time.Time, string) error) *MockvendorDBAccessorUpdateStatusCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockvendorDBAccessorUpdateStatusCall) DoAndReturn(f func(context.Context, string, VendorStatus, time.Time, string) error) *MockvendorDBAccessorUpdateStatusCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// UpdateStatusHistoryReview mocks base method.
+func (m *MockvendorDBAccessor) UpdateStatusHistoryReview(ctx context.Context, history VendorStatusHistory) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatusHistoryReview", ctx, history)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatusHistoryReview indicates an expected call of UpdateStatusHistoryReview.
+func (mr *MockvendorDBAccessorMockRecorder) UpdateStatusHistoryReview(ctx, history any) *MockvendorDBAccessorUpdateStatusHistoryReviewCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatusHistoryReview", reflect.TypeOf((*MockvendorDBAccessor)(nil).UpdateStatusHistoryReview), ctx, history)
+	return &MockvendorDBAccessorUpdateStatusHistoryReviewCall{Call: call}
+}
+
+// MockvendorDBAccessorUpdateStatusHistoryReviewCall wrap *gomock.Call
+type MockvendorDBAccessorUpdateStatusHistoryReviewCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockvendorDBAccessorUpdateStatusHistoryReviewCall) Return(arg0 error) *MockvendorDBAccessorUpdateStatusHistoryReviewCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockvendorDBAccessorUpdateStatusHistoryReviewCall) Do(f func(context.Context, VendorStatusHistory) error) *MockvendorDBAccessorUpdateStatusHistoryReviewCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockvendorDBAccessorUpdateStatusHistoryReviewCall) DoAndReturn(f func(context.Context, VendorStatusHistory) error) *MockvendorDBAccessorUpdateStatusHistoryReviewCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// WriteStatusHistory mocks base method.
+func (m *MockvendorDBAccessor) WriteStatusHistory(ctx context.Context, history VendorStatusHistory) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteStatusHistory", ctx, history)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WriteStatusHistory indicates an expected call of WriteStatusHistory.
+func (mr *MockvendorDBAccessorMockRecorder) WriteStatusHistory(ctx, history any) *MockvendorDBAccessorWriteStatusHistoryCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteStatusHistory", reflect.TypeOf((*MockvendorDBAccessor)(nil).WriteStatusHistory), ctx, history)
+	return &MockvendorDBAccessorWriteStatusHistoryCall{Call: call}
+}
+
+// MockvendorDBAccessorWriteStatusHistoryCall wrap *gomock.Call
+type MockvendorDBAccessorWriteStatusHistoryCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockvendorDBAccessorWriteStatusHistoryCall) Return(arg0 error) *MockvendorDBAccessorWriteStatusHistoryCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockvendorDBAccessorWriteStatusHistoryCall) Do(f func(context.Context, VendorStatusHistory) error) *MockvendorDBAccessorWriteStatusHistoryCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockvendorDBAccessorWriteStatusHistoryCall) DoAndReturn(f func(context.Context, VendorStatusHistory) error) *MockvendorDBAccessorWriteStatusHistoryCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// runInTx mocks base method.
+func (m *MockvendorDBAccessor) runInTx(ctx context.Context, fn func(vendorDBAccessor) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "runInTx", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// runInTx indicates an expected call of runInTx.
+func (mr *MockvendorDBAccessorMockRecorder) runInTx(ctx, fn any) *MockvendorDBAccessorrunInTxCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "runInTx", reflect.TypeOf((*MockvendorDBAccessor)(nil).runInTx), ctx, fn)
+	return &MockvendorDBAccessorrunInTxCall{Call: call}
+}
+
+// MockvendorDBAccessorrunInTxCall wrap *gomock.Call
+type MockvendorDBAccessorrunInTxCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockvendorDBAccessorrunInTxCall) Return(arg0 error) *MockvendorDBAccessorrunInTxCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockvendorDBAccessorrunInTxCall) Do(f func(context.Context, func(vendorDBAccessor) error) error) *MockvendorDBAccessorrunInTxCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockvendorDBAccessorrunInTxCall) DoAndReturn(f func(context.Context, func(vendorDBAccessor) error) error) *MockvendorDBAccessorrunInTxCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // MockemailStatusSvc is a mock of emailStatusSvc interface.
 type MockemailStatusSvc struct {
 	ctrl     *gomock.Controller
@@ -414,40 +799,40 @@ func (c *MockemailStatusSvcGetAllEmailStatusCall) DoAndReturn(f func(context.Con
 	return c
 }
 
-// WriteEmailStatus mocks base method.
-func (m *MockemailStatusSvc) WriteEmailStatus(ctx context.Context, status mailer.EmailStatus) error {
+// WriteEmailStatuses mocks base method.
+func (m *MockemailStatusSvc) WriteEmailStatuses(ctx context.Context, statuses []mailer.EmailStatus) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WriteEmailStatus", ctx, status)
+	ret := m.ctrl.Call(m, "WriteEmailStatuses", ctx, statuses)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// WriteEmailStatus indicates an expected call of WriteEmailStatus.
-func (mr *MockemailStatusSvcMockRecorder) WriteEmailStatus(ctx, status any) *MockemailStatusSvcWriteEmailStatusCall {
+// WriteEmailStatuses indicates an expected call of WriteEmailStatuses.
+func (mr *MockemailStatusSvcMockRecorder) WriteEmailStatuses(ctx, statuses any) *MockemailStatusSvcWriteEmailStatusesCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteEmailStatus", reflect.TypeOf((*MockemailStatusSvc)(nil).WriteEmailStatus), ctx, status)
-	return &MockemailStatusSvcWriteEmailStatusCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteEmailStatuses", reflect.TypeOf((*MockemailStatusSvc)(nil).WriteEmailStatuses), ctx, statuses)
+	return &MockemailStatusSvcWriteEmailStatusesCall{Call: call}
 }
 
-// MockemailStatusSvcWriteEmailStatusCall wrap *gomock.Call
-type MockemailStatusSvcWriteEmailStatusCall struct {
+// MockemailStatusSvcWriteEmailStatusesCall wrap *gomock.Call
+type MockemailStatusSvcWriteEmailStatusesCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *MockemailStatusSvcWriteEmailStatusCall) Return(arg0 error) *MockemailStatusSvcWriteEmailStatusCall {
+func (c *MockemailStatusSvcWriteEmailStatusesCall) Return(arg0 error) *MockemailStatusSvcWriteEmailStatusesCall {
 	c.Call = c.Call.Return(arg0)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *MockemailStatusSvcWriteEmailStatusCall) Do(f func(context.Context, mailer.EmailStatus) error) *MockemailStatusSvcWriteEmailStatusCall {
+func (c *MockemailStatusSvcWriteEmailStatusesCall) Do(f func(context.Context, []mailer.EmailStatus) error) *MockemailStatusSvcWriteEmailStatusesCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *MockemailStatusSvcWriteEmailStatusCall) DoAndReturn(f func(context.Context, mailer.EmailStatus) error) *MockemailStatusSvcWriteEmailStatusCall {
+func (c *MockemailStatusSvcWriteEmailStatusesCall) DoAndReturn(f func(context.Context, []mailer.EmailStatus) error) *MockemailStatusSvcWriteEmailStatusesCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
@@ -1,14 +1,17 @@
 package vendors
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"kg/procurement/cmd/config"
 	"kg/procurement/internal/common/database"
+	"kg/procurement/internal/common/spreadsheet"
 	"kg/procurement/internal/mailer"
 	"testing"
 	"time"
 
+	"github.com/benbjohnson/clock"
 	"github.com/onsi/gomega"
 	"go.uber.org/mock/gomock"
 )
@@ -206,6 +209,42 @@ func TestVendorService_GetById(t *testing.T) {
 	}
 }
 
+func TestVendorService_DeleteVendor(t *testing.T) {
+	t.Parallel()
+
+	t.Run("vendors are only deleted without prices", func(t *testing.T) {
+		var (
+			g        = gomega.NewWithT(t)
+			ctx      = context.Background()
+			ctrl     = gomock.NewController(t)
+			accessor = NewMockvendorDBAccessor(ctrl)
+		)
+
+		v := &VendorService{vendorDBAccessor: accessor}
+
+		accessor.EXPECT().SoftDelete(ctx, "1", "admin").Return(ErrVendorInUse)
+
+		err := v.DeleteVendor(ctx, "1", "admin")
+		g.Expect(errors.Is(err, ErrVendorInUse)).To(gomega.BeTrue())
+	})
+
+	t.Run("restores a deleted vendor", func(t *testing.T) {
+		var (
+			g        = gomega.NewWithT(t)
+			ctx      = context.Background()
+			ctrl     = gomock.NewController(t)
+			accessor = NewMockvendorDBAccessor(ctrl)
+		)
+
+		v := &VendorService{vendorDBAccessor: accessor}
+
+		accessor.EXPECT().Restore(ctx, "1", "admin").Return(nil)
+
+		err := v.RestoreVendor(ctx, "1", "admin")
+		g.Expect(err).To(gomega.BeNil())
+	})
+}
+
 func TestVendorService_UpdateDetail(t *testing.T) {
 	fixedTime := time.Date(2024, time.September, 27, 12, 30, 0, 0, time.UTC)
 	updatedFixedTime := time.Date(2024, time.September, 27, 12, 30, 0, 1, time.UTC)
@@ -390,12 +429,14 @@ func TestVendorService_BlastEmail(t *testing.T) {
 	var (
 		vendors = []Vendor{
 			{
-				ID:    "1111",
-				Email: "valenganteng@gmail.com",
+				ID:     "1111",
+				Email:  "valenganteng@gmail.com",
+				Status: VendorStatusActive.String(),
 			},
 			{
-				ID:    "2222",
-				Email: "ferryganteng@gmail.com",
+				ID:     "2222",
+				Email:  "ferryganteng@gmail.com",
+				Status: VendorStatusActive.String(),
 			},
 		}
 	)
@@ -415,14 +456,18 @@ func TestVendorService_BlastEmail(t *testing.T) {
 			Times(2)
 
 		mockEmailStatusSvc.EXPECT().
-			WriteEmailStatus(ctx, gomock.Any()).
-			Return(nil).
-			Times(2)
+			WriteEmailStatuses(ctx, gomock.Len(2)).
+			DoAndReturn(func(_ context.Context, statuses []mailer.EmailStatus) error {
+				for _, status := range statuses {
+					g.Expect(status.ModifiedBy).To(gomega.Equal("admin"))
+				}
+				return nil
+			})
 
 		errList, err := subject.BlastEmail(ctx, vendorIDs, mailer.Email{
 			Subject: "test",
 			Body:    "email body here uwaa",
-		})
+		}, "admin")
 		g.Expect(err).To(gomega.BeNil())
 		g.Expect(errList).To(gomega.BeNil())
 	})
@@ -439,7 +484,7 @@ func TestVendorService_BlastEmail(t *testing.T) {
 		errList, err := subject.BlastEmail(ctx, vendorIDs, mailer.Email{
 			Subject: "test",
 			Body:    "email body here uwaa",
-		})
+		}, "admin")
 		g.Expect(err).ToNot(gomega.BeNil())
 		g.Expect(errList).To(gomega.BeNil())
 	})
@@ -462,14 +507,13 @@ func TestVendorService_BlastEmail(t *testing.T) {
 			Return(nil)
 
 		mockEmailStatusSvc.EXPECT().
-			WriteEmailStatus(ctx, gomock.Any()).
-			Return(nil).
-			Times(2)
+			WriteEmailStatuses(ctx, gomock.Len(2)).
+			Return(nil)
 
 		errList, err := subject.BlastEmail(ctx, vendorIDs, mailer.Email{
 			Subject: "test",
 			Body:    "email body here uwaa",
-		})
+		}, "admin")
 		g.Expect(err).ToNot(gomega.BeNil())
 		g.Expect(errList).To(gomega.HaveLen(1))
 	})
@@ -490,18 +534,47 @@ func TestVendorService_BlastEmail(t *testing.T) {
 
 		// simulate error when inserting to email_status
 		mockEmailStatusSvc.EXPECT().
-			WriteEmailStatus(ctx, gomock.Any()).
-			Return(errors.New("write error")).
-			Times(2)
+			WriteEmailStatuses(ctx, gomock.Len(2)).
+			Return(errors.New("write error"))
 
 		errList, err := subject.BlastEmail(ctx, vendorIDs, mailer.Email{
 			Subject: "Test Subject",
 			Body:    "Test Body",
-		})
+		}, "admin")
 
 		g.Expect(err).To(gomega.BeNil())
 		g.Expect(errList).To(gomega.BeNil())
 	})
+
+	t.Run("skips vendors that are not active", func(t *testing.T) {
+		g := setup(t)
+		ctx := context.Background()
+
+		vendorIDs := []string{"1111", "2222", "3333"}
+		mockVendorAccessor.EXPECT().
+			BulkGetByIDs(ctx, vendorIDs).
+			Return(append(vendors, Vendor{
+				ID:     "3333",
+				Email:  "blacklisted@gmail.com",
+				Status: VendorStatusBlacklisted.String(),
+			}), nil)
+
+		mockEmailProvider.EXPECT().
+			SendEmail(gomock.Any()).
+			Return(nil).
+			Times(2)
+
+		mockEmailStatusSvc.EXPECT().
+			WriteEmailStatuses(ctx, gomock.Len(2)).
+			Return(nil)
+
+		errList, err := subject.BlastEmail(ctx, vendorIDs, mailer.Email{
+			Subject: "test",
+			Body:    "email body here uwaa",
+		}, "admin")
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(errList).To(gomega.BeNil())
+	})
 }
 
 func TestVendorService_AutomatedBlastEmail(t *testing.T) {
@@ -558,11 +631,10 @@ func TestVendorService_AutomatedBlastEmail(t *testing.T) {
 			Times(2)
 
 		mockEmailStatusSvc.EXPECT().
-			WriteEmailStatus(ctx, gomock.Any()).
-			Return(nil).
-			Times(2)
+			WriteEmailStatuses(ctx, gomock.Len(2)).
+			Return(nil)
 
-		errList, err := service.AutomatedEmailBlast(ctx, product_name)
+		errList, err := service.AutomatedEmailBlast(ctx, product_name, "admin")
 		g.Expect(err).To(gomega.BeNil())
 		g.Expect(errList).To(gomega.BeNil())
 	})
@@ -575,7 +647,7 @@ func TestVendorService_AutomatedBlastEmail(t *testing.T) {
 			BulkGetByProductName(ctx, product_name).
 			Return(nil, errors.New("error"))
 
-		result, err := service.AutomatedEmailBlast(ctx, product_name)
+		result, err := service.AutomatedEmailBlast(ctx, product_name, "admin")
 		g.Expect(err).ToNot(gomega.BeNil())
 		g.Expect(result).To(gomega.BeNil())
 	})
@@ -597,11 +669,10 @@ func TestVendorService_AutomatedBlastEmail(t *testing.T) {
 			Return(nil)
 
 		mockEmailStatusSvc.EXPECT().
-			WriteEmailStatus(ctx, gomock.Any()).
-			Return(nil).
-			Times(2)
+			WriteEmailStatuses(ctx, gomock.Len(2)).
+			Return(nil)
 
-		errList, err := service.AutomatedEmailBlast(ctx, product_name)
+		errList, err := service.AutomatedEmailBlast(ctx, product_name, "admin")
 		g.Expect(err).ToNot(gomega.BeNil())
 		g.Expect(errList).To(gomega.HaveLen(1))
 	})
@@ -928,3 +999,515 @@ func TestVendorService_GetPopulatedEmailStatus(t *testing.T) {
 		g.Expect(result).To(gomega.BeNil())
 	})
 }
+
+func TestVendorService_UpdateStatus(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mockVendorAccessor *MockvendorDBAccessor
+		clockMock          *clock.Mock
+		subject            *VendorService
+	)
+
+	setup := func(t *testing.T) *gomega.GomegaWithT {
+		ctrl := gomock.NewController(t)
+		mockVendorAccessor = NewMockvendorDBAccessor(ctrl)
+		clockMock = clock.NewMock()
+		clockMock.Set(time.Date(2024, time.December, 2, 10, 0, 0, 0, time.UTC))
+
+		subject = &VendorService{
+			vendorDBAccessor: mockVendorAccessor,
+			clock:            clockMock,
+		}
+
+		expectUnitOfWork(mockVendorAccessor)
+		return gomega.NewWithT(t)
+	}
+
+	t.Run("success suspending an active vendor", func(t *testing.T) {
+		g := setup(t)
+		ctx := context.Background()
+		now := clockMock.Now()
+
+		mockVendorAccessor.EXPECT().
+			LockStatus(ctx, "1").
+			Return("active", nil)
+		mockVendorAccessor.EXPECT().
+			UpdateStatus(ctx, "1", VendorStatusSuspended, now, "requester").
+			Return(nil)
+		mockVendorAccessor.EXPECT().
+			WriteStatusHistory(ctx, gomock.Any()).
+			Return(nil)
+
+		res, err := subject.UpdateStatus(ctx, "1", PutVendorStatusSpec{
+			Status:      "suspended",
+			Reason:      "late deliveries",
+			RequestedBy: "requester",
+		})
+
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(res.PreviousStatus).To(gomega.Equal("active"))
+		g.Expect(res.Status).To(gomega.Equal("suspended"))
+		g.Expect(res.ApprovalStatus).To(gomega.Equal("approved"))
+		g.Expect(*res.EffectiveFrom).To(gomega.Equal(now))
+	})
+
+	t.Run("lifting a blacklist waits for approval", func(t *testing.T) {
+		g := setup(t)
+		ctx := context.Background()
+
+		mockVendorAccessor.EXPECT().
+			LockStatus(ctx, "1").
+			Return("blacklisted", nil)
+		mockVendorAccessor.EXPECT().
+			HasPendingStatusChange(ctx, "1").
+			Return(false, nil)
+		mockVendorAccessor.EXPECT().
+			WriteStatusHistory(ctx, gomock.Any()).
+			Return(nil)
+
+		res, err := subject.UpdateStatus(ctx, "1", PutVendorStatusSpec{
+			Status:      "active",
+			RequestedBy: "requester",
+		})
+
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(res.ApprovalStatus).To(gomega.Equal("pending"))
+		g.Expect(res.EffectiveFrom).To(gomega.BeNil())
+	})
+
+	t.Run("error when lifting a blacklist without a requester", func(t *testing.T) {
+		g := setup(t)
+		ctx := context.Background()
+
+		mockVendorAccessor.EXPECT().
+			LockStatus(ctx, "1").
+			Return("blacklisted", nil)
+
+		res, err := subject.UpdateStatus(ctx, "1", PutVendorStatusSpec{Status: "active"})
+
+		g.Expect(err).To(gomega.Equal(ErrStatusRequesterRequired))
+		g.Expect(res).To(gomega.BeNil())
+	})
+
+	t.Run("error when a change is already pending", func(t *testing.T) {
+		g := setup(t)
+		ctx := context.Background()
+
+		mockVendorAccessor.EXPECT().
+			LockStatus(ctx, "1").
+			Return("blacklisted", nil)
+		mockVendorAccessor.EXPECT().
+			HasPendingStatusChange(ctx, "1").
+			Return(true, nil)
+
+		res, err := subject.UpdateStatus(ctx, "1", PutVendorStatusSpec{
+			Status:      "active",
+			RequestedBy: "requester",
+		})
+
+		g.Expect(err).To(gomega.Equal(ErrStatusChangePending))
+		g.Expect(res).To(gomega.BeNil())
+	})
+
+	t.Run("error on unknown vendor", func(t *testing.T) {
+		g := setup(t)
+		ctx := context.Background()
+
+		mockVendorAccessor.EXPECT().
+			LockStatus(ctx, "1").
+			Return("", ErrVendorNotFound)
+
+		res, err := subject.UpdateStatus(ctx, "1", PutVendorStatusSpec{Status: "active"})
+
+		g.Expect(err).To(gomega.Equal(ErrVendorNotFound))
+		g.Expect(res).To(gomega.BeNil())
+	})
+
+	t.Run("error on invalid status", func(t *testing.T) {
+		g := setup(t)
+
+		res, err := subject.UpdateStatus(context.Background(), "1", PutVendorStatusSpec{Status: "deleted"})
+
+		g.Expect(err).To(gomega.Equal(ErrInvalidVendorStatus))
+		g.Expect(res).To(gomega.BeNil())
+	})
+
+	t.Run("error on missing reason", func(t *testing.T) {
+		g := setup(t)
+
+		res, err := subject.UpdateStatus(context.Background(), "1", PutVendorStatusSpec{Status: "blacklisted"})
+
+		g.Expect(err).To(gomega.Equal(ErrStatusReasonRequired))
+		g.Expect(res).To(gomega.BeNil())
+	})
+
+	t.Run("error on unchanged status", func(t *testing.T) {
+		g := setup(t)
+		ctx := context.Background()
+
+		mockVendorAccessor.EXPECT().
+			LockStatus(ctx, "1").
+			Return("active", nil)
+
+		res, err := subject.UpdateStatus(ctx, "1", PutVendorStatusSpec{Status: "active"})
+
+		g.Expect(err).To(gomega.Equal(ErrVendorStatusUnchanged))
+		g.Expect(res).To(gomega.BeNil())
+	})
+
+	t.Run("error on fetching vendor", func(t *testing.T) {
+		g := setup(t)
+		ctx := context.Background()
+
+		mockVendorAccessor.EXPECT().
+			LockStatus(ctx, "1").
+			Return("", errors.New("db error"))
+
+		res, err := subject.UpdateStatus(ctx, "1", PutVendorStatusSpec{Status: "active"})
+
+		g.Expect(err).ToNot(gomega.BeNil())
+		g.Expect(res).To(gomega.BeNil())
+	})
+
+	t.Run("error on updating status", func(t *testing.T) {
+		g := setup(t)
+		ctx := context.Background()
+
+		mockVendorAccessor.EXPECT().
+			LockStatus(ctx, "1").
+			Return("suspended", nil)
+		mockVendorAccessor.EXPECT().
+			UpdateStatus(ctx, "1", VendorStatusActive, clockMock.Now(), "").
+			Return(errors.New("db error"))
+
+		res, err := subject.UpdateStatus(ctx, "1", PutVendorStatusSpec{Status: "active"})
+
+		g.Expect(err).ToNot(gomega.BeNil())
+		g.Expect(res).To(gomega.BeNil())
+	})
+}
+
+func TestVendorService_ReviewStatusChange(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mockVendorAccessor *MockvendorDBAccessor
+		clockMock          *clock.Mock
+		subject            *VendorService
+	)
+
+	setup := func(t *testing.T) *gomega.GomegaWithT {
+		ctrl := gomock.NewController(t)
+		mockVendorAccessor = NewMockvendorDBAccessor(ctrl)
+		clockMock = clock.NewMock()
+		clockMock.Set(time.Date(2024, time.December, 2, 10, 0, 0, 0, time.UTC))
+
+		subject = &VendorService{
+			vendorDBAccessor: mockVendorAccessor,
+			clock:            clockMock,
+		}
+
+		expectUnitOfWork(mockVendorAccessor)
+		return gomega.NewWithT(t)
+	}
+
+	pending := func() *VendorStatusHistory {
+		return &VendorStatusHistory{
+			ID:             "history",
+			VendorID:       "1",
+			PreviousStatus: "blacklisted",
+			Status:         "active",
+			ApprovalStatus: "pending",
+			RequestedBy:    "requester",
+		}
+	}
+
+	t.Run("success approving", func(t *testing.T) {
+		g := setup(t)
+		ctx := context.Background()
+		now := clockMock.Now()
+
+		mockVendorAccessor.EXPECT().
+			GetStatusHistoryByID(ctx, "history").
+			Return(pending(), nil)
+		mockVendorAccessor.EXPECT().
+			LockStatus(ctx, "1").
+			Return("blacklisted", nil)
+		mockVendorAccessor.EXPECT().
+			UpdateStatus(ctx, "1", VendorStatusActive, now, "approver").
+			Return(nil)
+		mockVendorAccessor.EXPECT().
+			UpdateStatusHistoryReview(ctx, gomock.Any()).
+			Return(nil)
+
+		res, err := subject.ReviewStatusChange(ctx, "history", ReviewVendorStatusSpec{
+			Approved:   true,
+			ReviewedBy: "approver",
+		})
+
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(res.ApprovalStatus).To(gomega.Equal("approved"))
+		g.Expect(res.ReviewedBy).To(gomega.Equal("approver"))
+		g.Expect(*res.EffectiveFrom).To(gomega.Equal(now))
+	})
+
+	t.Run("success rejecting", func(t *testing.T) {
+		g := setup(t)
+		ctx := context.Background()
+
+		mockVendorAccessor.EXPECT().
+			GetStatusHistoryByID(ctx, "history").
+			Return(pending(), nil)
+		mockVendorAccessor.EXPECT().
+			UpdateStatusHistoryReview(ctx, gomock.Any()).
+			Return(nil)
+
+		res, err := subject.ReviewStatusChange(ctx, "history", ReviewVendorStatusSpec{
+			Approved:   false,
+			ReviewedBy: "approver",
+		})
+
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(res.ApprovalStatus).To(gomega.Equal("rejected"))
+		g.Expect(res.EffectiveFrom).To(gomega.BeNil())
+	})
+
+	t.Run("error when change is not pending", func(t *testing.T) {
+		g := setup(t)
+		ctx := context.Background()
+
+		history := pending()
+		history.ApprovalStatus = "approved"
+		mockVendorAccessor.EXPECT().
+			GetStatusHistoryByID(ctx, "history").
+			Return(history, nil)
+
+		res, err := subject.ReviewStatusChange(ctx, "history", ReviewVendorStatusSpec{
+			Approved:   true,
+			ReviewedBy: "approver",
+		})
+
+		g.Expect(err).To(gomega.Equal(ErrStatusChangeNotPending))
+		g.Expect(res).To(gomega.BeNil())
+	})
+
+	t.Run("error when the change is reviewed concurrently", func(t *testing.T) {
+		g := setup(t)
+		ctx := context.Background()
+
+		mockVendorAccessor.EXPECT().
+			GetStatusHistoryByID(ctx, "history").
+			Return(pending(), nil)
+		mockVendorAccessor.EXPECT().
+			UpdateStatusHistoryReview(ctx, gomock.Any()).
+			Return(ErrStatusChangeNotPending)
+
+		res, err := subject.ReviewStatusChange(ctx, "history", ReviewVendorStatusSpec{
+			Approved:   false,
+			ReviewedBy: "approver",
+		})
+
+		g.Expect(err).To(gomega.Equal(ErrStatusChangeNotPending))
+		g.Expect(res).To(gomega.BeNil())
+	})
+
+	t.Run("error when requester reviews own change", func(t *testing.T) {
+		g := setup(t)
+		ctx := context.Background()
+
+		mockVendorAccessor.EXPECT().
+			GetStatusHistoryByID(ctx, "history").
+			Return(pending(), nil)
+
+		res, err := subject.ReviewStatusChange(ctx, "history", ReviewVendorStatusSpec{
+			Approved:   true,
+			ReviewedBy: "requester",
+		})
+
+		g.Expect(err).To(gomega.Equal(ErrStatusChangeSelfApproval))
+		g.Expect(res).To(gomega.BeNil())
+	})
+
+	t.Run("error when the vendor status changed since the request", func(t *testing.T) {
+		g := setup(t)
+		ctx := context.Background()
+
+		mockVendorAccessor.EXPECT().
+			GetStatusHistoryByID(ctx, "history").
+			Return(pending(), nil)
+		mockVendorAccessor.EXPECT().
+			LockStatus(ctx, "1").
+			Return("suspended", nil)
+
+		res, err := subject.ReviewStatusChange(ctx, "history", ReviewVendorStatusSpec{
+			Approved:   true,
+			ReviewedBy: "approver",
+		})
+
+		g.Expect(err).To(gomega.Equal(ErrVendorStatusChanged))
+		g.Expect(res).To(gomega.BeNil())
+	})
+
+	t.Run("error on updating vendor status", func(t *testing.T) {
+		g := setup(t)
+		ctx := context.Background()
+
+		mockVendorAccessor.EXPECT().
+			GetStatusHistoryByID(ctx, "history").
+			Return(pending(), nil)
+		mockVendorAccessor.EXPECT().
+			LockStatus(ctx, "1").
+			Return("blacklisted", nil)
+		mockVendorAccessor.EXPECT().
+			UpdateStatus(ctx, "1", VendorStatusActive, clockMock.Now(), "approver").
+			Return(errors.New("db error"))
+
+		res, err := subject.ReviewStatusChange(ctx, "history", ReviewVendorStatusSpec{
+			Approved:   true,
+			ReviewedBy: "approver",
+		})
+
+		g.Expect(err).ToNot(gomega.BeNil())
+		g.Expect(res).To(gomega.BeNil())
+	})
+}
+
+func TestVendorService_ExportVendors(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mockVendorAccessor *MockvendorDBAccessor
+		subject            *VendorService
+		modifiedDate       = time.Date(2024, time.December, 7, 0, 0, 0, 0, time.UTC)
+	)
+
+	setup := func(t *testing.T) *gomega.GomegaWithT {
+		ctrl := gomock.NewController(t)
+		mockVendorAccessor = NewMockvendorDBAccessor(ctrl)
+		subject = &VendorService{
+			vendorDBAccessor: mockVendorAccessor,
+		}
+		return gomega.NewWithT(t)
+	}
+
+	t.Run("writes every batch of the listing", func(t *testing.T) {
+		g := setup(t)
+		ctx := context.Background()
+
+		spec := GetAllVendorSpec{
+			Location:       "Jakarta",
+			PaginationSpec: database.PaginationSpec{Limit: 10, OrderBy: "name"},
+		}
+		firstPage := spec
+		firstPage.PaginationSpec = database.PaginationSpec{
+			Limit: database.ExportBatchSize, Page: 1, OrderBy: "name", Mode: database.PaginationModeCursor,
+		}
+		secondPage := firstPage
+		secondPage.Cursor = "next"
+
+		gomock.InOrder(
+			mockVendorAccessor.EXPECT().GetAll(ctx, firstPage).Return(&AccessorGetAllPaginationData{
+				Vendors:  []Vendor{{ID: "1", Name: "Vendor 1", Rating: 4, Status: "active", ModifiedDate: modifiedDate}},
+				Metadata: database.PaginationMetadata{NextCursor: "next"},
+			}, nil),
+			mockVendorAccessor.EXPECT().GetAll(ctx, secondPage).Return(&AccessorGetAllPaginationData{
+				Vendors: []Vendor{{ID: "2", Name: "Vendor 2", Status: "inactive"}},
+			}, nil),
+		)
+
+		var buffer bytes.Buffer
+		writer, err := spreadsheet.NewWriter(&buffer, spreadsheet.FormatCSV, "Vendors")
+		g.Expect(err).To(gomega.BeNil())
+
+		err = subject.ExportVendors(ctx, spec, writer)
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(writer.Close()).To(gomega.Succeed())
+		g.Expect(buffer.String()).To(gomega.Equal(
+			"id,name,email,description,bp_id,bp_name,rating,area_group_id,area_group_name,sap_code,status,modified_date,modified_by\n" +
+				"1,Vendor 1,,,,,4,,,,active,2024-12-07T00:00:00Z,\n" +
+				"2,Vendor 2,,,,,0,,,,inactive,,\n"))
+	})
+
+	t.Run("returns error on accessor failure", func(t *testing.T) {
+		g := setup(t)
+		ctx := context.Background()
+
+		mockVendorAccessor.EXPECT().GetAll(ctx, gomock.Any()).Return(nil, errors.New("error"))
+
+		writer, _ := spreadsheet.NewWriter(&bytes.Buffer{}, spreadsheet.FormatCSV, "Vendors")
+		err := subject.ExportVendors(ctx, GetAllVendorSpec{}, writer)
+		g.Expect(err).ToNot(gomega.BeNil())
+	})
+}
+
+func TestVendorService_ExportEmailStatus(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mockVendorAccessor *MockvendorDBAccessor
+		mockEmailStatusSvc *MockemailStatusSvc
+		subject            *VendorService
+		dateSent           = time.Date(2024, time.December, 7, 0, 0, 0, 0, time.UTC)
+	)
+
+	setup := func(t *testing.T) *gomega.GomegaWithT {
+		ctrl := gomock.NewController(t)
+		mockVendorAccessor = NewMockvendorDBAccessor(ctrl)
+		mockEmailStatusSvc = NewMockemailStatusSvc(ctrl)
+		subject = &VendorService{
+			vendorDBAccessor: mockVendorAccessor,
+			emailStatusSvc:   mockEmailStatusSvc,
+		}
+		return gomega.NewWithT(t)
+	}
+
+	t.Run("writes the email status with their vendor", func(t *testing.T) {
+		g := setup(t)
+		ctx := context.Background()
+
+		mockEmailStatusSvc.EXPECT().GetAllEmailStatus(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, spec mailer.GetAllEmailStatusSpec) (*mailer.AccessorGetEmailStatusPaginationData, error) {
+				g.Expect(spec.EmailTo).To(gomega.Equal("vendor1@example.com"))
+				g.Expect(spec.IsCursor()).To(gomega.BeTrue())
+				return &mailer.AccessorGetEmailStatusPaginationData{
+					EmailStatus: []mailer.EmailStatus{
+						{ID: "1", EmailTo: "vendor1@example.com", Status: "Success", VendorID: "vendor1", DateSent: dateSent},
+					},
+				}, nil
+			})
+		mockVendorAccessor.EXPECT().BulkGetByIDs(ctx, []string{"vendor1"}).
+			Return([]Vendor{{ID: "vendor1", Name: "Vendor 1", Rating: 5}}, nil)
+
+		var buffer bytes.Buffer
+		writer, err := spreadsheet.NewWriter(&buffer, spreadsheet.FormatCSV, "Email status")
+		g.Expect(err).To(gomega.BeNil())
+
+		err = subject.ExportEmailStatus(ctx, mailer.GetAllEmailStatusSpec{EmailTo: "vendor1@example.com"}, writer)
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(writer.Close()).To(gomega.Succeed())
+		g.Expect(buffer.String()).To(gomega.Equal(
+			"id,email_to,status,vendor_id,vendor_name,vendor_rating,date_sent,modified_date\n" +
+				"1,vendor1@example.com,Success,vendor1,Vendor 1,5,2024-12-07T00:00:00Z,\n"))
+	})
+
+	t.Run("returns error on email status failure", func(t *testing.T) {
+		g := setup(t)
+		ctx := context.Background()
+
+		mockEmailStatusSvc.EXPECT().GetAllEmailStatus(ctx, gomock.Any()).Return(nil, errors.New("error"))
+
+		writer, _ := spreadsheet.NewWriter(&bytes.Buffer{}, spreadsheet.FormatCSV, "Email status")
+		err := subject.ExportEmailStatus(ctx, mailer.GetAllEmailStatusSpec{}, writer)
+		g.Expect(err).ToNot(gomega.BeNil())
+	})
+}
+
+// expectUnitOfWork runs the units of work of the service on the accessor mock itself
+func expectUnitOfWork(m *MockvendorDBAccessor) {
+	m.EXPECT().runInTx(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, fn func(tx vendorDBAccessor) error) error {
+			return fn(m)
+		}).
+		AnyTimes()
+}
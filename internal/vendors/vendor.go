@@ -1,6 +1,7 @@
 package vendors
 
 import (
+	"errors"
 	"kg/procurement/internal/common/database"
 	"time"
 )
@@ -8,19 +9,92 @@ import (
 // Vendor defines the metadata related to a vendor
 // i.e. name, etc
 type Vendor struct {
-	ID            string    `db:"id" json:"id"`
-	Email         string    `db:"email" json:"email"`
-	Name          string    `db:"name" json:"name"`
-	Description   string    `db:"description" json:"description"`
-	BpID          string    `db:"bp_id" json:"bp_id"`
-	BpName        string    `db:"bp_name" json:"bp_name"`
-	Rating        int       `db:"rating" json:"rating"`
-	AreaGroupID   string    `db:"area_group_id" json:"area_group_id"`
-	AreaGroupName string    `db:"area_group_name" json:"area_group_name"`
-	SapCode       string    `db:"sap_code" json:"sap_code"`
-	ModifiedDate  time.Time `db:"modified_date" json:"modified_date"`
-	ModifiedBy    string    `db:"modified_by" json:"modified_by"`
-	Date          time.Time `db:"dt" json:"dt"`
+	ID            string     `db:"id" json:"id"`
+	Email         string     `db:"email" json:"email"`
+	Name          string     `db:"name" json:"name"`
+	Description   string     `db:"description" json:"description"`
+	BpID          string     `db:"bp_id" json:"bp_id"`
+	BpName        string     `db:"bp_name" json:"bp_name"`
+	Rating        int        `db:"rating" json:"rating"`
+	AreaGroupID   string     `db:"area_group_id" json:"area_group_id"`
+	AreaGroupName string     `db:"area_group_name" json:"area_group_name"`
+	SapCode       string     `db:"sap_code" json:"sap_code"`
+	ModifiedDate  time.Time  `db:"modified_date" json:"modified_date"`
+	ModifiedBy    string     `db:"modified_by" json:"modified_by"`
+	Date          time.Time  `db:"dt" json:"dt"`
+	Status        string     `db:"status" json:"status"`
+	DeletedAt     *time.Time `db:"deleted_at" json:"deleted_at,omitempty"`
+	DeletedBy     *string    `db:"deleted_by" json:"deleted_by,omitempty"`
+}
+
+// IsActive reports whether the vendor can be invited to RFQs
+// and shown on the default listing
+func (v Vendor) IsActive() bool {
+	return v.Status == VendorStatusActive.String()
+}
+
+type VendorStatus string
+
+const (
+	VendorStatusActive      VendorStatus = "active"
+	VendorStatusSuspended   VendorStatus = "suspended"
+	VendorStatusBlacklisted VendorStatus = "blacklisted"
+)
+
+func (s VendorStatus) String() string {
+	return string(s)
+}
+
+func ParseVendorStatus(status string) (VendorStatus, error) {
+	switch VendorStatus(status) {
+	case VendorStatusActive, VendorStatusSuspended, VendorStatusBlacklisted:
+		return VendorStatus(status), nil
+	default:
+		return "", ErrInvalidVendorStatus
+	}
+}
+
+type StatusApproval string
+
+const (
+	StatusApprovalApproved StatusApproval = "approved"
+	StatusApprovalPending  StatusApproval = "pending"
+	StatusApprovalRejected StatusApproval = "rejected"
+)
+
+func (s StatusApproval) String() string {
+	return string(s)
+}
+
+var (
+	ErrInvalidVendorStatus      = errors.New("invalid vendor status")
+	ErrStatusReasonRequired     = errors.New("reason is required when deactivating a vendor")
+	ErrVendorStatusUnchanged    = errors.New("vendor already has the requested status")
+	ErrStatusChangeNotPending   = errors.New("status change is not pending approval")
+	ErrStatusChangeSelfApproval = errors.New("status change cannot be reviewed by its requester")
+	ErrStatusChangePending      = errors.New("vendor already has a pending status change")
+	ErrStatusRequesterRequired  = errors.New("requested_by is required when lifting a blacklist")
+	ErrVendorStatusChanged      = errors.New("vendor status changed since the change was requested")
+	ErrVendorNotFound           = errors.New("vendor not found")
+	ErrVendorInUse              = errors.New("vendor still has prices")
+)
+
+// VendorStatusHistory records every status transition of a vendor.
+// Lifting a blacklist is stored as a pending entry and only takes
+// effect once it is approved
+type VendorStatusHistory struct {
+	ID             string     `db:"id" json:"id"`
+	VendorID       string     `db:"vendor_id" json:"vendor_id"`
+	PreviousStatus string     `db:"previous_status" json:"previous_status"`
+	Status         string     `db:"status" json:"status"`
+	Reason         string     `db:"reason" json:"reason"`
+	ApprovalStatus string     `db:"approval_status" json:"approval_status"`
+	EffectiveFrom  *time.Time `db:"effective_from" json:"effective_from"`
+	EffectiveTo    *time.Time `db:"effective_to" json:"effective_to"`
+	RequestedBy    string     `db:"requested_by" json:"requested_by"`
+	ReviewedBy     string     `db:"reviewed_by" json:"reviewed_by"`
+	ReviewedDate   *time.Time `db:"reviewed_date" json:"reviewed_date"`
+	ModifiedDate   time.Time  `db:"modified_date" json:"modified_date"`
 }
 
 type VendorEvaluation struct {
@@ -39,6 +113,7 @@ type VendorEvaluation struct {
 	KetersediaanBarang               int       `db:"ketersediaan_barang" json:"ketersediaan_barang"`
 	KualitasLayananAfterServices     int       `db:"kualitas_layanan_after_services" json:"kualitas_layanan_after_services"`
 	ModifiedDate                     time.Time `db:"modified_date" json:"modified_date"`
+	ModifiedBy                       string    `db:"modified_by" json:"modified_by"`
 }
 
 type PutVendorSpec struct {
@@ -52,8 +127,30 @@ type PutVendorSpec struct {
 	SapCode       string `json:"sap_code"`
 }
 
+type PutVendorStatusSpec struct {
+	Status      string `json:"status" binding:"required"`
+	Reason      string `json:"reason"`
+	RequestedBy string `json:"requested_by"`
+}
+
+type ReviewVendorStatusSpec struct {
+	Approved   bool   `json:"approved"`
+	ReviewedBy string `json:"reviewed_by" binding:"required"`
+}
+
 type GetAllVendorSpec struct {
 	Location string `json:"location"`
 	Product  string `json:"product"`
+	// Status defaults to active vendors only when left empty
+	Status            string    `json:"status"`
+	AreaGroupID       string    `json:"area_group_id"`
+	SapCode           string    `json:"sap_code"`
+	RatingMin         *int      `json:"rating_min"`
+	RatingMax         *int      `json:"rating_max"`
+	ModifiedFrom      time.Time `json:"modified_from"`
+	ModifiedTo        time.Time `json:"modified_to"`
+	ProductCategoryID string    `json:"product_category_id"`
+	// HasValidPrice keeps vendors with (or without) a price valid right now
+	HasValidPrice *bool `json:"has_valid_price"`
 	database.PaginationSpec
 }
@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE vendor
+    ADD status VARCHAR(15) NOT NULL DEFAULT 'active';
+
+CREATE TABLE vendor_status_history
+(
+    id              VARCHAR(15) PRIMARY KEY,
+    vendor_id       VARCHAR(15) NOT NULL,
+    previous_status VARCHAR(15) NOT NULL,
+    status          VARCHAR(15) NOT NULL,
+    reason          VARCHAR(255),
+    approval_status VARCHAR(15) NOT NULL,
+    effective_from  TIMESTAMP,
+    effective_to    TIMESTAMP,
+    requested_by    VARCHAR(127),
+    reviewed_by     VARCHAR(127),
+    reviewed_date   TIMESTAMP,
+    modified_date   TIMESTAMP,
+    FOREIGN KEY (vendor_id) REFERENCES vendor (id)
+);
+
+CREATE INDEX idx_vendor_status_history_vendor_id ON vendor_status_history (vendor_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE vendor_status_history;
+
+ALTER TABLE vendor
+    DROP COLUMN status;
+-- +goose StatementEnd
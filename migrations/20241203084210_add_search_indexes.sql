@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX idx_vendor_search_document ON vendor
+    USING GIN (to_tsvector('simple', coalesce(name, '') || ' ' || coalesce(description, '') || ' ' || coalesce(bp_name, '')));
+CREATE INDEX idx_vendor_name_trgm ON vendor USING GIN (name gin_trgm_ops);
+CREATE INDEX idx_vendor_bp_name_trgm ON vendor USING GIN (bp_name gin_trgm_ops);
+
+CREATE INDEX idx_product_search_document ON product
+    USING GIN (to_tsvector('simple', coalesce(name, '') || ' ' || coalesce(description, '')));
+CREATE INDEX idx_product_name_trgm ON product USING GIN (name gin_trgm_ops);
+
+CREATE INDEX idx_product_category_search_document ON product_category
+    USING GIN (to_tsvector('simple', coalesce(name, '') || ' ' || coalesce(description, '')));
+CREATE INDEX idx_product_category_name_trgm ON product_category USING GIN (name gin_trgm_ops);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_vendor_search_document;
+DROP INDEX IF EXISTS idx_vendor_name_trgm;
+DROP INDEX IF EXISTS idx_vendor_bp_name_trgm;
+DROP INDEX IF EXISTS idx_product_search_document;
+DROP INDEX IF EXISTS idx_product_name_trgm;
+DROP INDEX IF EXISTS idx_product_category_search_document;
+DROP INDEX IF EXISTS idx_product_category_name_trgm;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE INDEX idx_email_status_vendor_id_date_sent ON email_status (vendor_id, date_sent);
+CREATE INDEX idx_price_vendor_id_validity ON price (vendor_id, valid_from, valid_to);
+CREATE INDEX idx_vendor_evaluation_vendor_id ON vendor_evaluation (vendor_id, modified_date);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_vendor_evaluation_vendor_id;
+DROP INDEX IF EXISTS idx_price_vendor_id_validity;
+DROP INDEX IF EXISTS idx_email_status_vendor_id_date_sent;
+-- +goose StatementEnd
@@ -0,0 +1,68 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE price_history
+(
+    id                VARCHAR(15) PRIMARY KEY,
+    price_id          VARCHAR(15)    NOT NULL,
+    product_vendor_id VARCHAR(15)    NOT NULL DEFAULT '',
+    vendor_id         VARCHAR(15)    NOT NULL DEFAULT '',
+    old_price         NUMERIC(15, 2),
+    new_price         NUMERIC(15, 2),
+    currency_id       VARCHAR(15)    NOT NULL DEFAULT '',
+    price_quantity    INT            NOT NULL DEFAULT 0,
+    price_uom_id      VARCHAR(15)    NOT NULL DEFAULT '',
+    valid_from        TIMESTAMP,
+    valid_to          TIMESTAMP,
+    document_type_id  VARCHAR(15)    NOT NULL DEFAULT '',
+    document_id       VARCHAR(15)    NOT NULL DEFAULT '',
+    reference_number  VARCHAR(127)   NOT NULL DEFAULT '',
+    changed_by        VARCHAR(255)   NOT NULL DEFAULT '',
+    reason            VARCHAR(255)   NOT NULL DEFAULT '',
+    modified_date     TIMESTAMP      NOT NULL,
+
+    CONSTRAINT fk_price FOREIGN KEY (price_id) REFERENCES price (id)
+);
+
+CREATE INDEX idx_price_history_price_id ON price_history (price_id);
+CREATE INDEX idx_price_history_product_vendor_id ON price_history (product_vendor_id, modified_date);
+
+-- history entries are immutable, corrections are recorded as new entries
+CREATE FUNCTION reject_price_history_change() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'price_history entries are immutable';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER price_history_immutable
+    BEFORE UPDATE OR DELETE ON price_history
+    FOR EACH ROW EXECUTE FUNCTION reject_price_history_change();
+
+-- every new price row gets a baseline entry so the history covers its first version,
+-- later versions are recorded by the application together with who changed it and why
+CREATE FUNCTION record_price_baseline() RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO price_history
+        (id, price_id, product_vendor_id, vendor_id, old_price, new_price, currency_id, price_quantity, price_uom_id, valid_from, valid_to, document_type_id, document_id, reference_number, changed_by, reason, modified_date)
+    VALUES
+        (left(md5(random()::text || clock_timestamp()::text), 15), NEW.id, COALESCE(NEW.product_vendor_id, ''), COALESCE(NEW.vendor_id, ''), NULL, NEW.price, COALESCE(NEW.currency_id, ''), COALESCE(NEW.price_quantity, 0), COALESCE(NEW.price_uom_id, ''), NEW.valid_from, NEW.valid_to, COALESCE(NEW.document_type_id, ''), COALESCE(NEW.document_id, ''), COALESCE(NEW.reference_number, ''), COALESCE(NEW.modified_by, ''), 'created', COALESCE(NEW.modified_date, now()));
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER price_record_baseline
+    AFTER INSERT ON price
+    FOR EACH ROW EXECUTE FUNCTION record_price_baseline();
+
+INSERT INTO price_history
+    (id, price_id, product_vendor_id, vendor_id, old_price, new_price, currency_id, price_quantity, price_uom_id, valid_from, valid_to, document_type_id, document_id, reference_number, changed_by, reason, modified_date)
+SELECT left(md5(pr.id || 'baseline'), 15), pr.id, COALESCE(pr.product_vendor_id, ''), COALESCE(pr.vendor_id, ''), NULL, pr.price, COALESCE(pr.currency_id, ''), COALESCE(pr.price_quantity, 0), COALESCE(pr.price_uom_id, ''), pr.valid_from, pr.valid_to, COALESCE(pr.document_type_id, ''), COALESCE(pr.document_id, ''), COALESCE(pr.reference_number, ''), COALESCE(pr.modified_by, ''), 'baseline', COALESCE(pr.modified_date, now())
+FROM price pr;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS price_record_baseline ON price;
+DROP FUNCTION IF EXISTS record_price_baseline();
+DROP TABLE price_history;
+DROP FUNCTION IF EXISTS reject_price_history_change();
+-- +goose StatementEnd
@@ -0,0 +1,58 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE currency
+(
+    id            VARCHAR(15) PRIMARY KEY,
+    code          VARCHAR(15)  NOT NULL UNIQUE,
+    name          VARCHAR(255) NOT NULL DEFAULT '',
+    modified_date TIMESTAMP    NOT NULL,
+    modified_by   VARCHAR(255) NOT NULL DEFAULT ''
+);
+
+-- rates are stored per day, a rate applies until a more recent rate of the same pair
+CREATE TABLE exchange_rate
+(
+    id            VARCHAR(15) PRIMARY KEY,
+    from_currency VARCHAR(15)    NOT NULL,
+    to_currency   VARCHAR(15)    NOT NULL,
+    rate          NUMERIC(24, 10) NOT NULL,
+    rate_date     DATE           NOT NULL,
+    source        VARCHAR(15)    NOT NULL DEFAULT 'manual',
+    modified_date TIMESTAMP      NOT NULL,
+    modified_by   VARCHAR(255)   NOT NULL DEFAULT '',
+
+    CONSTRAINT fk_from_currency FOREIGN KEY (from_currency) REFERENCES currency (code),
+    CONSTRAINT fk_to_currency FOREIGN KEY (to_currency) REFERENCES currency (code),
+    CONSTRAINT chk_exchange_rate_positive CHECK (rate > 0),
+    CONSTRAINT chk_exchange_rate_pair CHECK (from_currency <> to_currency),
+    CONSTRAINT uq_exchange_rate_pair_date UNIQUE (from_currency, to_currency, rate_date)
+);
+
+CREATE INDEX idx_exchange_rate_to_pair_date ON exchange_rate (to_currency, from_currency, rate_date);
+
+-- every currency already used by a price becomes a known currency
+INSERT INTO currency (id, code, name, modified_date, modified_by)
+SELECT DISTINCT ON (pr.currency_code)
+    COALESCE(NULLIF(pr.currency_id, ''), left(md5(pr.currency_code), 15)),
+    pr.currency_code,
+    COALESCE(pr.currency_name, ''),
+    now(),
+    'migration'
+FROM price pr
+WHERE pr.currency_code IS NOT NULL
+    AND pr.currency_code <> ''
+ORDER BY pr.currency_code, pr.modified_date DESC
+ON CONFLICT DO NOTHING;
+
+INSERT INTO currency (id, code, name, modified_date, modified_by)
+VALUES
+    ('IDR', 'IDR', 'Indonesian Rupiah', now(), 'migration'),
+    ('USD', 'USD', 'US Dollar', now(), 'migration')
+ON CONFLICT DO NOTHING;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS exchange_rate;
+DROP TABLE IF EXISTS currency;
+-- +goose StatementEnd
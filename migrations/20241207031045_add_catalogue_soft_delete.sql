@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+-- catalogue entries are soft deleted so the prices and history referencing them stay readable
+ALTER TABLE product
+    ADD deleted_at TIMESTAMP;
+ALTER TABLE product_category
+    ADD deleted_at TIMESTAMP;
+ALTER TABLE product_type
+    ADD deleted_at TIMESTAMP;
+ALTER TABLE uom
+    ADD deleted_at TIMESTAMP;
+
+-- used by the referential checks run before an entry is deleted
+CREATE INDEX idx_product_product_category_id ON product (product_category_id) WHERE deleted_at IS NULL;
+CREATE INDEX idx_product_product_type_id ON product (product_type_id) WHERE deleted_at IS NULL;
+CREATE INDEX idx_product_uom_id ON product (uom_id) WHERE deleted_at IS NULL;
+CREATE INDEX idx_product_category_parent_id ON product_category (parent_id) WHERE deleted_at IS NULL;
+CREATE INDEX idx_product_vendor_product_id ON product_vendor (product_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_product_product_category_id;
+DROP INDEX IF EXISTS idx_product_product_type_id;
+DROP INDEX IF EXISTS idx_product_uom_id;
+DROP INDEX IF EXISTS idx_product_category_parent_id;
+DROP INDEX IF EXISTS idx_product_vendor_product_id;
+
+ALTER TABLE product
+    DROP COLUMN deleted_at;
+ALTER TABLE product_category
+    DROP COLUMN deleted_at;
+ALTER TABLE product_type
+    DROP COLUMN deleted_at;
+ALTER TABLE uom
+    DROP COLUMN deleted_at;
+-- +goose StatementEnd
@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+-- the amount of the base unit of its dimension one unit holds, e.g. 1000 for kg when g is the base,
+-- units of the same dimension convert through it
+ALTER TABLE uom
+    ADD base_factor NUMERIC(24, 10);
+ALTER TABLE uom
+    ADD CONSTRAINT chk_uom_base_factor_positive CHECK (base_factor > 0);
+
+-- conversions of a single product override the global ones and convert across dimensions,
+-- one from_uom_id holds factor to_uom_id, e.g. a box of 12 pieces
+CREATE TABLE product_uom_conversion
+(
+    id            VARCHAR(15) PRIMARY KEY,
+    product_id    VARCHAR(15)     NOT NULL,
+    from_uom_id   VARCHAR(15)     NOT NULL,
+    to_uom_id     VARCHAR(15)     NOT NULL,
+    factor        NUMERIC(24, 10) NOT NULL,
+    modified_date TIMESTAMP       NOT NULL,
+    modified_by   VARCHAR(255)    NOT NULL DEFAULT '',
+
+    CONSTRAINT fk_product_uom_conversion_product FOREIGN KEY (product_id) REFERENCES product (id),
+    CONSTRAINT fk_product_uom_conversion_from_uom FOREIGN KEY (from_uom_id) REFERENCES uom (id),
+    CONSTRAINT fk_product_uom_conversion_to_uom FOREIGN KEY (to_uom_id) REFERENCES uom (id),
+    CONSTRAINT chk_product_uom_conversion_factor_positive CHECK (factor > 0),
+    CONSTRAINT chk_product_uom_conversion_pair CHECK (from_uom_id <> to_uom_id),
+    CONSTRAINT uq_product_uom_conversion_pair UNIQUE (product_id, from_uom_id, to_uom_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS product_uom_conversion;
+
+ALTER TABLE uom
+    DROP CONSTRAINT IF EXISTS chk_uom_base_factor_positive;
+ALTER TABLE uom
+    DROP COLUMN base_factor;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+-- product vendors and prices are soft deleted so the price history referencing them stays readable
+ALTER TABLE product_vendor
+    ADD deleted_at TIMESTAMP;
+ALTER TABLE price
+    ADD deleted_at TIMESTAMP;
+
+-- used by the tier checks run before a price is created and by the product vendor delete
+CREATE INDEX idx_price_product_vendor_id ON price (product_vendor_id) WHERE deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_price_product_vendor_id;
+
+ALTER TABLE product_vendor
+    DROP COLUMN deleted_at;
+ALTER TABLE price
+    DROP COLUMN deleted_at;
+-- +goose StatementEnd
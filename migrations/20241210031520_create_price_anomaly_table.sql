@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE price_anomaly
+(
+    id                VARCHAR(15) PRIMARY KEY,
+    price_id          VARCHAR(15)    NOT NULL,
+    product_vendor_id VARCHAR(15)    NOT NULL DEFAULT '',
+    vendor_id         VARCHAR(15)    NOT NULL DEFAULT '',
+    source            VARCHAR(15)    NOT NULL,
+    old_price         NUMERIC(15, 2),
+    new_price         NUMERIC(15, 2) NOT NULL,
+    currency_id       VARCHAR(15)    NOT NULL DEFAULT '',
+    history_ratio     NUMERIC(15, 4),
+    peer_median       NUMERIC(15, 2),
+    peer_ratio        NUMERIC(15, 4),
+    peer_count        INT            NOT NULL DEFAULT 0,
+    status            VARCHAR(15)    NOT NULL,
+    -- the price held for approval, it is applied once the anomaly is approved
+    proposed          JSONB,
+    requested_by      VARCHAR(255)   NOT NULL DEFAULT '',
+    reason            VARCHAR(255)   NOT NULL DEFAULT '',
+    reviewed_by       VARCHAR(255)   NOT NULL DEFAULT '',
+    reviewed_date     TIMESTAMP,
+    modified_date     TIMESTAMP      NOT NULL,
+
+    CONSTRAINT fk_price FOREIGN KEY (price_id) REFERENCES price (id)
+);
+
+CREATE INDEX idx_price_anomaly_status ON price_anomaly (status, modified_date);
+CREATE INDEX idx_price_anomaly_product_vendor_id ON price_anomaly (product_vendor_id, modified_date);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE price_anomaly;
+-- +goose StatementEnd
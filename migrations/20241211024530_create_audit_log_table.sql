@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE audit_log
+(
+    id         VARCHAR(15)  PRIMARY KEY,
+    entity     VARCHAR(63)  NOT NULL,
+    entity_id  VARCHAR(15)  NOT NULL,
+    action     VARCHAR(15)  NOT NULL,
+    actor      VARCHAR(255) NOT NULL DEFAULT '',
+    request_id VARCHAR(127) NOT NULL DEFAULT '',
+    -- the row as stored before and after the change, before is NULL on creation
+    before     JSONB,
+    after      JSONB,
+    changes    JSONB        NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP    NOT NULL
+);
+
+CREATE INDEX idx_audit_log_entity ON audit_log (entity, entity_id, created_at);
+CREATE INDEX idx_audit_log_actor ON audit_log (actor, created_at);
+CREATE INDEX idx_audit_log_request_id ON audit_log (request_id);
+CREATE INDEX idx_audit_log_created_at ON audit_log (created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE audit_log;
+-- +goose StatementEnd
@@ -0,0 +1,78 @@
+-- +goose Up
+-- +goose StatementBegin
+-- vendors and accounts are soft deleted like the catalogue, every soft deleted row records who deleted it
+ALTER TABLE vendor
+    ADD deleted_at TIMESTAMP,
+    ADD deleted_by VARCHAR(255);
+ALTER TABLE account
+    ADD deleted_at TIMESTAMP,
+    ADD deleted_by VARCHAR(255);
+ALTER TABLE product
+    ADD deleted_by VARCHAR(255);
+ALTER TABLE product_category
+    ADD deleted_by VARCHAR(255);
+ALTER TABLE product_type
+    ADD deleted_by VARCHAR(255);
+ALTER TABLE uom
+    ADD deleted_by VARCHAR(255);
+ALTER TABLE product_vendor
+    ADD deleted_by VARCHAR(255);
+ALTER TABLE price
+    ADD deleted_by VARCHAR(255);
+
+-- deletes so far recorded their actor as the last modifier
+UPDATE product SET deleted_by = modified_by WHERE deleted_at IS NOT NULL;
+UPDATE product_category SET deleted_by = modified_by WHERE deleted_at IS NOT NULL;
+UPDATE product_type SET deleted_by = modified_by WHERE deleted_at IS NOT NULL;
+UPDATE uom SET deleted_by = modified_by WHERE deleted_at IS NOT NULL;
+UPDATE product_vendor SET deleted_by = modified_by WHERE deleted_at IS NOT NULL;
+UPDATE price SET deleted_by = modified_by WHERE deleted_at IS NOT NULL;
+
+-- a deleted account frees its email, restoring it fails while another account holds it
+ALTER TABLE account
+    DROP CONSTRAINT account_email_key;
+CREATE UNIQUE INDEX idx_account_email ON account (email) WHERE deleted_at IS NULL;
+
+-- used by the delete guard of vendors and by the purge of rows past retention
+CREATE INDEX idx_price_vendor_id ON price (vendor_id) WHERE deleted_at IS NULL;
+CREATE INDEX idx_vendor_deleted_at ON vendor (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_account_deleted_at ON account (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_product_deleted_at ON product (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_product_vendor_deleted_at ON product_vendor (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_price_deleted_at ON price (deleted_at) WHERE deleted_at IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_price_vendor_id;
+DROP INDEX IF EXISTS idx_vendor_deleted_at;
+DROP INDEX IF EXISTS idx_account_deleted_at;
+DROP INDEX IF EXISTS idx_product_deleted_at;
+DROP INDEX IF EXISTS idx_product_vendor_deleted_at;
+DROP INDEX IF EXISTS idx_price_deleted_at;
+
+-- accounts deleted in the meantime would hold a duplicate email
+DELETE FROM account WHERE deleted_at IS NOT NULL;
+DROP INDEX IF EXISTS idx_account_email;
+ALTER TABLE account
+    ADD CONSTRAINT account_email_key UNIQUE (email);
+
+ALTER TABLE vendor
+    DROP COLUMN deleted_at,
+    DROP COLUMN deleted_by;
+ALTER TABLE account
+    DROP COLUMN deleted_at,
+    DROP COLUMN deleted_by;
+ALTER TABLE product
+    DROP COLUMN deleted_by;
+ALTER TABLE product_category
+    DROP COLUMN deleted_by;
+ALTER TABLE product_type
+    DROP COLUMN deleted_by;
+ALTER TABLE uom
+    DROP COLUMN deleted_by;
+ALTER TABLE product_vendor
+    DROP COLUMN deleted_by;
+ALTER TABLE price
+    DROP COLUMN deleted_by;
+-- +goose StatementEnd
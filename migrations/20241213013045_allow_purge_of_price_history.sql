@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+-- history entries stay immutable, only the retention purge may remove those of a purged
+-- price and it says so with a setting local to its transaction
+CREATE OR REPLACE FUNCTION reject_price_history_change() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'DELETE' AND current_setting('procurement.purge', true) = 'on' THEN
+        RETURN OLD;
+    END IF;
+    RAISE EXCEPTION 'price_history entries are immutable';
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION reject_price_history_change() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'price_history entries are immutable';
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- evaluations and email statuses record who wrote them like the other entities, so the
+-- audit log has an actor for their changes
+ALTER TABLE vendor_evaluation
+    ADD modified_by VARCHAR(127);
+ALTER TABLE email_status
+    ADD modified_by VARCHAR(127);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE email_status
+    DROP COLUMN modified_by;
+ALTER TABLE vendor_evaluation
+    DROP COLUMN modified_by;
+-- +goose StatementEnd
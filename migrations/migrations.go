@@ -0,0 +1,50 @@
+// Package migrations embeds the goose migrations so the binary knows the schema it expects
+package migrations
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"strconv"
+	"strings"
+)
+
+//go:embed *.sql
+var FS embed.FS
+
+// Versions lists the versions of the embedded migrations in ascending order, the version
+// is the timestamp prefixing each file name
+func Versions() ([]int64, error) {
+	files, err := fs.Glob(FS, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]int64, 0, len(files))
+	for _, file := range files {
+		prefix, _, found := strings.Cut(path.Base(file), "_")
+		if !found {
+			return nil, fmt.Errorf("migration %s has no version prefix", file)
+		}
+		version, err := strconv.ParseInt(prefix, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s has an invalid version: %w", file, err)
+		}
+		versions = append(versions, version)
+	}
+	// file names sort by their fixed width timestamp already
+	return versions, nil
+}
+
+// LatestVersion is the version of the newest embedded migration
+func LatestVersion() (int64, error) {
+	versions, err := Versions()
+	if err != nil {
+		return 0, err
+	}
+	if len(versions) == 0 {
+		return 0, nil
+	}
+	return versions[len(versions)-1], nil
+}
@@ -0,0 +1,23 @@
+package migrations
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestVersions(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	versions, err := Versions()
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(versions).ToNot(gomega.BeEmpty())
+	g.Expect(sort.SliceIsSorted(versions, func(i, j int) bool { return versions[i] < versions[j] })).To(gomega.BeTrue())
+
+	latest, err := LatestVersion()
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(latest).To(gomega.Equal(versions[len(versions)-1]))
+	g.Expect(latest).To(gomega.BeNumerically(">=", int64(20241210031520)))
+}
@@ -0,0 +1,83 @@
+//go:generate mockgen -typed -source=runner.go -destination=runner_mock.go -package=migrations
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/pressly/goose/v3"
+)
+
+// ErrSchemaBehind is returned when the database misses migrations the binary expects
+var ErrSchemaBehind = errors.New("schema version is behind the migrations")
+
+// provider is the part of goose.Provider the runner relies on
+type provider interface {
+	Up(ctx context.Context) ([]*goose.MigrationResult, error)
+	UpTo(ctx context.Context, version int64) ([]*goose.MigrationResult, error)
+	Down(ctx context.Context) (*goose.MigrationResult, error)
+	DownTo(ctx context.Context, version int64) ([]*goose.MigrationResult, error)
+	Status(ctx context.Context) ([]*goose.MigrationStatus, error)
+	GetVersions(ctx context.Context) (current, target int64, err error)
+}
+
+// Runner applies the embedded migrations, keeping track of them in the goose_db_version
+// table like the goose CLI does
+type Runner struct {
+	provider
+}
+
+// Up applies every pending migration
+func (r *Runner) Up(ctx context.Context) ([]*goose.MigrationResult, error) {
+	return r.provider.Up(ctx)
+}
+
+// Down rolls back the latest applied migration
+func (r *Runner) Down(ctx context.Context) ([]*goose.MigrationResult, error) {
+	res, err := r.provider.Down(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return []*goose.MigrationResult{res}, nil
+}
+
+// To migrates up or down until version is the latest migration applied
+func (r *Runner) To(ctx context.Context, version int64) ([]*goose.MigrationResult, error) {
+	current, _, err := r.provider.GetVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if version < current {
+		return r.provider.DownTo(ctx, version)
+	}
+	return r.provider.UpTo(ctx, version)
+}
+
+// Status lists the embedded migrations and whether they were applied
+func (r *Runner) Status(ctx context.Context) ([]*goose.MigrationStatus, error) {
+	return r.provider.Status(ctx)
+}
+
+// CheckSchema fails when the database misses migrations, a schema ahead of the binary is
+// fine as migrations are applied before a deployment rolls out
+func (r *Runner) CheckSchema(ctx context.Context) error {
+	current, target, err := r.provider.GetVersions(ctx)
+	if err != nil {
+		return err
+	}
+	if current < target {
+		return fmt.Errorf("%w: %d, expected %d", ErrSchemaBehind, current, target)
+	}
+	return nil
+}
+
+// NewRunner runs the embedded migrations on db
+func NewRunner(db *sql.DB) (*Runner, error) {
+	p, err := goose.NewProvider(goose.DialectPostgres, db, FS)
+	if err != nil {
+		return nil, err
+	}
+	return &Runner{provider: p}, nil
+}
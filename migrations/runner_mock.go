@@ -0,0 +1,276 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: runner.go
+//
+// Generated by this command:
+//
+//	mockgen -typed -source=runner.go -destination=runner_mock.go -package=migrations
+//
+
+// Package migrations is a generated GoMock package.
+package migrations
+
+import (
+	context "context"
+	reflect "reflect"
+
+	goose "github.com/pressly/goose/v3"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// Mockprovider is a mock of provider interface.
+type Mockprovider struct {
+	ctrl     *gomock.Controller
+	recorder *MockproviderMockRecorder
+}
+
+// MockproviderMockRecorder is the mock recorder for Mockprovider.
+type MockproviderMockRecorder struct {
+	mock *Mockprovider
+}
+
+// NewMockprovider creates a new mock instance.
+func NewMockprovider(ctrl *gomock.Controller) *Mockprovider {
+	mock := &Mockprovider{ctrl: ctrl}
+	mock.recorder = &MockproviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockprovider) EXPECT() *MockproviderMockRecorder {
+	return m.recorder
+}
+
+// Down mocks base method.
+func (m *Mockprovider) Down(ctx context.Context) (*goose.MigrationResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Down", ctx)
+	ret0, _ := ret[0].(*goose.MigrationResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Down indicates an expected call of Down.
+func (mr *MockproviderMockRecorder) Down(ctx any) *MockproviderDownCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Down", reflect.TypeOf((*Mockprovider)(nil).Down), ctx)
+	return &MockproviderDownCall{Call: call}
+}
+
+// MockproviderDownCall wrap *gomock.Call
+type MockproviderDownCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockproviderDownCall) Return(arg0 *goose.MigrationResult, arg1 error) *MockproviderDownCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockproviderDownCall) Do(f func(context.Context) (*goose.MigrationResult, error)) *MockproviderDownCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockproviderDownCall) DoAndReturn(f func(context.Context) (*goose.MigrationResult, error)) *MockproviderDownCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DownTo mocks base method.
+func (m *Mockprovider) DownTo(ctx context.Context, version int64) ([]*goose.MigrationResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DownTo", ctx, version)
+	ret0, _ := ret[0].([]*goose.MigrationResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DownTo indicates an expected call of DownTo.
+func (mr *MockproviderMockRecorder) DownTo(ctx, version any) *MockproviderDownToCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownTo", reflect.TypeOf((*Mockprovider)(nil).DownTo), ctx, version)
+	return &MockproviderDownToCall{Call: call}
+}
+
+// MockproviderDownToCall wrap *gomock.Call
+type MockproviderDownToCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockproviderDownToCall) Return(arg0 []*goose.MigrationResult, arg1 error) *MockproviderDownToCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockproviderDownToCall) Do(f func(context.Context, int64) ([]*goose.MigrationResult, error)) *MockproviderDownToCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockproviderDownToCall) DoAndReturn(f func(context.Context, int64) ([]*goose.MigrationResult, error)) *MockproviderDownToCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetVersions mocks base method.
+func (m *Mockprovider) GetVersions(ctx context.Context) (int64, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVersions", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetVersions indicates an expected call of GetVersions.
+func (mr *MockproviderMockRecorder) GetVersions(ctx any) *MockproviderGetVersionsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVersions", reflect.TypeOf((*Mockprovider)(nil).GetVersions), ctx)
+	return &MockproviderGetVersionsCall{Call: call}
+}
+
+// MockproviderGetVersionsCall wrap *gomock.Call
+type MockproviderGetVersionsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockproviderGetVersionsCall) Return(current, target int64, err error) *MockproviderGetVersionsCall {
+	c.Call = c.Call.Return(current, target, err)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockproviderGetVersionsCall) Do(f func(context.Context) (int64, int64, error)) *MockproviderGetVersionsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockproviderGetVersionsCall) DoAndReturn(f func(context.Context) (int64, int64, error)) *MockproviderGetVersionsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Status mocks base method.
+func (m *Mockprovider) Status(ctx context.Context) ([]*goose.MigrationStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Status", ctx)
+	ret0, _ := ret[0].([]*goose.MigrationStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Status indicates an expected call of Status.
+func (mr *MockproviderMockRecorder) Status(ctx any) *MockproviderStatusCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*Mockprovider)(nil).Status), ctx)
+	return &MockproviderStatusCall{Call: call}
+}
+
+// MockproviderStatusCall wrap *gomock.Call
+type MockproviderStatusCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockproviderStatusCall) Return(arg0 []*goose.MigrationStatus, arg1 error) *MockproviderStatusCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockproviderStatusCall) Do(f func(context.Context) ([]*goose.MigrationStatus, error)) *MockproviderStatusCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockproviderStatusCall) DoAndReturn(f func(context.Context) ([]*goose.MigrationStatus, error)) *MockproviderStatusCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Up mocks base method.
+func (m *Mockprovider) Up(ctx context.Context) ([]*goose.MigrationResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Up", ctx)
+	ret0, _ := ret[0].([]*goose.MigrationResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Up indicates an expected call of Up.
+func (mr *MockproviderMockRecorder) Up(ctx any) *MockproviderUpCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Up", reflect.TypeOf((*Mockprovider)(nil).Up), ctx)
+	return &MockproviderUpCall{Call: call}
+}
+
+// MockproviderUpCall wrap *gomock.Call
+type MockproviderUpCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockproviderUpCall) Return(arg0 []*goose.MigrationResult, arg1 error) *MockproviderUpCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockproviderUpCall) Do(f func(context.Context) ([]*goose.MigrationResult, error)) *MockproviderUpCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockproviderUpCall) DoAndReturn(f func(context.Context) ([]*goose.MigrationResult, error)) *MockproviderUpCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// UpTo mocks base method.
+func (m *Mockprovider) UpTo(ctx context.Context, version int64) ([]*goose.MigrationResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpTo", ctx, version)
+	ret0, _ := ret[0].([]*goose.MigrationResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpTo indicates an expected call of UpTo.
+func (mr *MockproviderMockRecorder) UpTo(ctx, version any) *MockproviderUpToCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpTo", reflect.TypeOf((*Mockprovider)(nil).UpTo), ctx, version)
+	return &MockproviderUpToCall{Call: call}
+}
+
+// MockproviderUpToCall wrap *gomock.Call
+type MockproviderUpToCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockproviderUpToCall) Return(arg0 []*goose.MigrationResult, arg1 error) *MockproviderUpToCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockproviderUpToCall) Do(f func(context.Context, int64) ([]*goose.MigrationResult, error)) *MockproviderUpToCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockproviderUpToCall) DoAndReturn(f func(context.Context, int64) ([]*goose.MigrationResult, error)) *MockproviderUpToCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
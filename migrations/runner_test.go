@@ -0,0 +1,110 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/onsi/gomega"
+	"github.com/pressly/goose/v3"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_NewRunner(t *testing.T) {
+	g := gomega.NewWithT(t)
+	db, _, err := sqlmock.New()
+	g.Expect(err).To(gomega.BeNil())
+	defer db.Close()
+
+	// goose reads the embedded migrations without reaching the database
+	_, err = NewRunner(db)
+	g.Expect(err).To(gomega.BeNil())
+}
+
+func TestRunner(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mockProvider *Mockprovider
+		subject      *Runner
+	)
+
+	setup := func(t *testing.T) *gomega.GomegaWithT {
+		ctrl := gomock.NewController(t)
+		mockProvider = NewMockprovider(ctrl)
+		subject = &Runner{provider: mockProvider}
+		return gomega.NewWithT(t)
+	}
+
+	t.Run("Down rolls back a single migration", func(t *testing.T) {
+		g := setup(t)
+		ctx := context.Background()
+
+		result := &goose.MigrationResult{Source: &goose.Source{Version: 3}, Direction: "down"}
+		mockProvider.EXPECT().Down(ctx).Return(result, nil)
+
+		res, err := subject.Down(ctx)
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(res).To(gomega.Equal([]*goose.MigrationResult{result}))
+	})
+
+	t.Run("Down returns the provider error", func(t *testing.T) {
+		g := setup(t)
+		ctx := context.Background()
+
+		mockProvider.EXPECT().Down(ctx).Return(nil, goose.ErrNoNextVersion)
+
+		_, err := subject.Down(ctx)
+		g.Expect(err).To(gomega.MatchError(goose.ErrNoNextVersion))
+	})
+
+	t.Run("To migrates up to a newer version", func(t *testing.T) {
+		g := setup(t)
+		ctx := context.Background()
+
+		mockProvider.EXPECT().GetVersions(ctx).Return(int64(2), int64(5), nil)
+		mockProvider.EXPECT().UpTo(ctx, int64(4)).Return(nil, nil)
+
+		_, err := subject.To(ctx, 4)
+		g.Expect(err).To(gomega.BeNil())
+	})
+
+	t.Run("To migrates down to an older version", func(t *testing.T) {
+		g := setup(t)
+		ctx := context.Background()
+
+		mockProvider.EXPECT().GetVersions(ctx).Return(int64(5), int64(5), nil)
+		mockProvider.EXPECT().DownTo(ctx, int64(3)).Return(nil, nil)
+
+		_, err := subject.To(ctx, 3)
+		g.Expect(err).To(gomega.BeNil())
+	})
+
+	t.Run("To returns the version error", func(t *testing.T) {
+		g := setup(t)
+		ctx := context.Background()
+
+		mockProvider.EXPECT().GetVersions(ctx).Return(int64(0), int64(0), errors.New("error"))
+
+		_, err := subject.To(ctx, 3)
+		g.Expect(err).To(gomega.MatchError("error"))
+	})
+
+	t.Run("CheckSchema", func(t *testing.T) {
+		g := setup(t)
+		ctx := context.Background()
+
+		mockProvider.EXPECT().GetVersions(ctx).Return(int64(5), int64(5), nil)
+		g.Expect(subject.CheckSchema(ctx)).To(gomega.Succeed())
+
+		mockProvider.EXPECT().GetVersions(ctx).Return(int64(6), int64(5), nil)
+		g.Expect(subject.CheckSchema(ctx)).To(gomega.Succeed())
+
+		mockProvider.EXPECT().GetVersions(ctx).Return(int64(4), int64(5), nil)
+		g.Expect(subject.CheckSchema(ctx)).To(gomega.MatchError(ErrSchemaBehind))
+
+		mockProvider.EXPECT().GetVersions(ctx).Return(int64(0), int64(0), errors.New("error"))
+		g.Expect(subject.CheckSchema(ctx)).To(gomega.MatchError("error"))
+	})
+}
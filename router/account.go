@@ -1,6 +1,7 @@
 package router
 
 import (
+	"errors"
 	"kg/procurement/cmd/config"
 	"kg/procurement/cmd/utils"
 	"kg/procurement/internal/account"
@@ -99,4 +100,46 @@ func NewAccountEngine(
 			"modifiedAt": account.ModifiedDate,
 		})
 	})
+
+	r.DELETE(cfg.DeleteAccount, func(ctx *gin.Context) {
+		utils.Logger.Info("Received deleteAccount request")
+
+		deletedBy := getModifiedBy(ctx, ctx.Query("modified_by"))
+		if err := accountSvc.DeleteAccount(ctx, ctx.Param("id"), deletedBy); err != nil {
+			ctx.JSON(accountErrorCode(err), gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		utils.Logger.Info("Completed deleteAccount request process")
+
+		ctx.Status(http.StatusNoContent)
+	})
+
+	r.POST(cfg.RestoreAccount, func(ctx *gin.Context) {
+		utils.Logger.Info("Received restoreAccount request")
+
+		if err := accountSvc.RestoreAccount(ctx, ctx.Param("id")); err != nil {
+			ctx.JSON(accountErrorCode(err), gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		utils.Logger.Info("Completed restoreAccount request process")
+
+		ctx.Status(http.StatusNoContent)
+	})
+}
+
+func accountErrorCode(err error) int {
+	switch {
+	case errors.Is(err, account.ErrAccountNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, account.ErrAccountEmailTaken):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
 }
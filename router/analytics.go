@@ -0,0 +1,96 @@
+package router
+
+import (
+	"errors"
+	"kg/procurement/cmd/config"
+	"kg/procurement/cmd/utils"
+	"kg/procurement/internal/analytics"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func NewAnalyticsEngine(
+	r *gin.Engine,
+	cfg config.AnalyticsRoutes,
+	analyticsSvc *analytics.AnalyticsService,
+) {
+	r.GET(cfg.GetVendorPerformances, func(ctx *gin.Context) {
+		utils.Logger.Info("Received getVendorPerformances request")
+
+		spec, err := getVendorPerformanceSpec(ctx.Request)
+		if err != nil {
+			utils.Logger.Error(err.Error())
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		spec.PaginationSpec = GetPaginationSpec(ctx.Request)
+
+		res, err := analyticsSvc.GetVendorPerformances(ctx, spec)
+		if err != nil {
+			statusCode := paginationErrorCode(err)
+			if errors.Is(err, analytics.ErrInvalidPeriod) {
+				statusCode = http.StatusBadRequest
+			}
+			ctx.JSON(statusCode, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		utils.Logger.Info("Completed getVendorPerformances request process")
+
+		ctx.JSON(http.StatusOK, res)
+	})
+
+	r.GET(cfg.GetVendorPerformance, func(ctx *gin.Context) {
+		utils.Logger.Info("Received getVendorPerformance request")
+
+		spec, err := getVendorPerformanceSpec(ctx.Request)
+		if err != nil {
+			utils.Logger.Error(err.Error())
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		res, err := analyticsSvc.GetVendorPerformance(ctx, ctx.Param("id"), spec)
+		if err != nil {
+			statusCode := http.StatusInternalServerError
+			switch {
+			case errors.Is(err, analytics.ErrInvalidPeriod):
+				statusCode = http.StatusBadRequest
+			case errors.Is(err, analytics.ErrVendorNotFound):
+				statusCode = http.StatusNotFound
+			}
+			ctx.JSON(statusCode, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		utils.Logger.Info("Completed getVendorPerformance request process")
+
+		ctx.JSON(http.StatusOK, res)
+	})
+}
+
+func getVendorPerformanceSpec(r *http.Request) (analytics.VendorPerformanceSpec, error) {
+	from, err := GetOptionalTimeQuery(r, "from")
+	if err != nil {
+		return analytics.VendorPerformanceSpec{}, err
+	}
+	to, err := GetOptionalTimeQuery(r, "to")
+	if err != nil {
+		return analytics.VendorPerformanceSpec{}, err
+	}
+
+	return analytics.VendorPerformanceSpec{
+		AreaGroupID: r.URL.Query().Get("area_group_id"),
+		From:        from,
+		To:          to,
+	}, nil
+}
@@ -0,0 +1,76 @@
+package router
+
+import (
+	"errors"
+	"kg/procurement/cmd/config"
+	"kg/procurement/cmd/utils"
+	"kg/procurement/internal/audit"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func NewAuditEngine(
+	r *gin.Engine,
+	cfg config.AuditRoutes,
+	auditSvc *audit.AuditService,
+) {
+	r.GET(cfg.GetAll, func(ctx *gin.Context) {
+		utils.Logger.Info("Received getAuditLog request")
+
+		spec, err := getAuditLogSpec(ctx.Request)
+		if err != nil {
+			utils.Logger.Error(err.Error())
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		spec.PaginationSpec = GetPaginationSpec(ctx.Request)
+
+		res, err := auditSvc.GetAll(ctx, spec)
+		if err != nil {
+			statusCode := paginationErrorCode(err)
+			if errors.Is(err, audit.ErrInvalidPeriod) {
+				statusCode = http.StatusBadRequest
+			}
+			ctx.JSON(statusCode, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		utils.Logger.Info("Completed getAuditLog request process")
+
+		ctx.JSON(http.StatusOK, res)
+	})
+}
+
+func getAuditLogSpec(r *http.Request) (audit.GetAuditLogSpec, error) {
+	query := r.URL.Query()
+
+	var entity audit.Entity
+	if s := query.Get("entity"); s != "" {
+		var err error
+		if entity, err = audit.ParseEntity(s); err != nil {
+			return audit.GetAuditLogSpec{}, err
+		}
+	}
+	from, err := GetOptionalTimeQuery(r, "from")
+	if err != nil {
+		return audit.GetAuditLogSpec{}, err
+	}
+	to, err := GetOptionalTimeQuery(r, "to")
+	if err != nil {
+		return audit.GetAuditLogSpec{}, err
+	}
+
+	return audit.GetAuditLogSpec{
+		Entity:    entity,
+		EntityID:  query.Get("entity_id"),
+		Actor:     query.Get("actor"),
+		RequestID: query.Get("request_id"),
+		From:      from,
+		To:        to,
+	}, nil
+}
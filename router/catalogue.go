@@ -0,0 +1,407 @@
+package router
+
+import (
+	"errors"
+	"kg/procurement/cmd/config"
+	"kg/procurement/cmd/utils"
+	"kg/procurement/internal/product"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func NewCatalogueEngine(
+	r *gin.Engine,
+	cfg config.CatalogueRoutes,
+	productSvc *product.ProductService,
+) {
+	r.POST(cfg.CreateProduct, func(ctx *gin.Context) {
+		utils.Logger.Info("Received createProduct request")
+
+		spec := product.PostProductSpec{}
+		if err := ctx.ShouldBindJSON(&spec); err != nil {
+			utils.Logger.Error(err.Error())
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		res, err := productSvc.CreateProduct(ctx, spec)
+		if err != nil {
+			ctx.JSON(catalogueErrorCode(err), gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		utils.Logger.Info("Completed createProduct request process")
+
+		ctx.JSON(http.StatusCreated, res)
+	})
+
+	r.GET(cfg.GetProducts, func(ctx *gin.Context) {
+		spec := product.GetProductsSpec{
+			Name:              ctx.Query("name"),
+			ProductCategoryID: ctx.Query("product_category_id"),
+			ProductTypeID:     ctx.Query("product_type_id"),
+			PaginationSpec:    GetPaginationSpec(ctx.Request),
+		}
+		includeSubcategories, err := GetOptionalBoolQuery(ctx.Request, "include_subcategories")
+		if err != nil {
+			utils.Logger.Error(err.Error())
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		if includeSubcategories != nil {
+			spec.IncludeSubcategories = *includeSubcategories
+		}
+
+		res, err := productSvc.GetProducts(ctx, spec)
+		if err != nil {
+			ctx.JSON(paginationErrorCode(err), gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		ctx.JSON(http.StatusOK, res)
+	})
+
+	r.GET(cfg.GetProduct, func(ctx *gin.Context) {
+		res, err := productSvc.GetProduct(ctx, ctx.Param("id"))
+		if err != nil {
+			ctx.JSON(catalogueErrorCode(err), gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		ctx.JSON(http.StatusOK, res)
+	})
+
+	r.DELETE(cfg.DeleteProduct, func(ctx *gin.Context) {
+		utils.Logger.Info("Received deleteProduct request")
+
+		if err := productSvc.DeleteProduct(ctx, ctx.Param("id"), ctx.Query("modified_by")); err != nil {
+			ctx.JSON(catalogueErrorCode(err), gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		utils.Logger.Info("Completed deleteProduct request process")
+
+		ctx.Status(http.StatusNoContent)
+	})
+
+	r.POST(cfg.RestoreProduct, func(ctx *gin.Context) {
+		utils.Logger.Info("Received restoreProduct request")
+
+		restoredBy := getModifiedBy(ctx, ctx.Query("modified_by"))
+		if err := productSvc.RestoreProduct(ctx, ctx.Param("id"), restoredBy); err != nil {
+			ctx.JSON(catalogueErrorCode(err), gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		utils.Logger.Info("Completed restoreProduct request process")
+
+		ctx.Status(http.StatusNoContent)
+	})
+
+	r.POST(cfg.CreateProductCategory, func(ctx *gin.Context) {
+		utils.Logger.Info("Received createProductCategory request")
+
+		spec := product.PostProductCategorySpec{}
+		if err := ctx.ShouldBindJSON(&spec); err != nil {
+			utils.Logger.Error(err.Error())
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		res, err := productSvc.CreateProductCategory(ctx, spec)
+		if err != nil {
+			ctx.JSON(catalogueErrorCode(err), gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		utils.Logger.Info("Completed createProductCategory request process")
+
+		ctx.JSON(http.StatusCreated, res)
+	})
+
+	r.GET(cfg.GetProductCategories, func(ctx *gin.Context) {
+		spec := product.GetProductCategoriesSpec{
+			Name:           ctx.Query("name"),
+			ParentID:       ctx.Query("parent_id"),
+			PaginationSpec: GetPaginationSpec(ctx.Request),
+		}
+
+		res, err := productSvc.GetProductCategories(ctx, spec)
+		if err != nil {
+			ctx.JSON(paginationErrorCode(err), gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		ctx.JSON(http.StatusOK, res)
+	})
+
+	r.GET(cfg.GetProductCategory, func(ctx *gin.Context) {
+		res, err := productSvc.GetProductCategory(ctx, ctx.Param("id"))
+		if err != nil {
+			ctx.JSON(catalogueErrorCode(err), gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		ctx.JSON(http.StatusOK, res)
+	})
+
+	r.DELETE(cfg.DeleteProductCategory, func(ctx *gin.Context) {
+		utils.Logger.Info("Received deleteProductCategory request")
+
+		if err := productSvc.DeleteProductCategory(ctx, ctx.Param("id"), ctx.Query("modified_by")); err != nil {
+			ctx.JSON(catalogueErrorCode(err), gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		utils.Logger.Info("Completed deleteProductCategory request process")
+
+		ctx.Status(http.StatusNoContent)
+	})
+
+	r.GET(cfg.GetCategoryTree, func(ctx *gin.Context) {
+		res, err := productSvc.GetCategoryTree(ctx)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{
+			"product_categories": res,
+		})
+	})
+
+	r.GET(cfg.GetCategorySubtree, func(ctx *gin.Context) {
+		res, err := productSvc.GetCategorySubtree(ctx, ctx.Param("id"))
+		if err != nil {
+			ctx.JSON(catalogueErrorCode(err), gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		ctx.JSON(http.StatusOK, res)
+	})
+
+	r.GET(cfg.GetCategoryAncestors, func(ctx *gin.Context) {
+		res, err := productSvc.GetCategoryAncestors(ctx, ctx.Param("id"))
+		if err != nil {
+			ctx.JSON(catalogueErrorCode(err), gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{
+			"ancestors": res,
+		})
+	})
+
+	r.PUT(cfg.MoveProductCategory, func(ctx *gin.Context) {
+		utils.Logger.Info("Received moveProductCategory request")
+
+		spec := product.MoveProductCategorySpec{}
+		if err := ctx.ShouldBindJSON(&spec); err != nil {
+			utils.Logger.Error(err.Error())
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		res, err := productSvc.MoveProductCategory(ctx, ctx.Param("id"), spec)
+		if err != nil {
+			ctx.JSON(catalogueErrorCode(err), gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		utils.Logger.Info("Completed moveProductCategory request process")
+
+		ctx.JSON(http.StatusOK, res)
+	})
+
+	r.POST(cfg.CreateProductType, func(ctx *gin.Context) {
+		utils.Logger.Info("Received createProductType request")
+
+		spec := product.PostProductTypeSpec{}
+		if err := ctx.ShouldBindJSON(&spec); err != nil {
+			utils.Logger.Error(err.Error())
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		res, err := productSvc.CreateProductType(ctx, spec)
+		if err != nil {
+			ctx.JSON(catalogueErrorCode(err), gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		utils.Logger.Info("Completed createProductType request process")
+
+		ctx.JSON(http.StatusCreated, res)
+	})
+
+	r.GET(cfg.GetProductTypes, func(ctx *gin.Context) {
+		spec := product.GetCatalogueSpec{
+			Name:           ctx.Query("name"),
+			PaginationSpec: GetPaginationSpec(ctx.Request),
+		}
+
+		res, err := productSvc.GetProductTypes(ctx, spec)
+		if err != nil {
+			ctx.JSON(paginationErrorCode(err), gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		ctx.JSON(http.StatusOK, res)
+	})
+
+	r.GET(cfg.GetProductType, func(ctx *gin.Context) {
+		res, err := productSvc.GetProductType(ctx, ctx.Param("id"))
+		if err != nil {
+			ctx.JSON(catalogueErrorCode(err), gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		ctx.JSON(http.StatusOK, res)
+	})
+
+	r.DELETE(cfg.DeleteProductType, func(ctx *gin.Context) {
+		utils.Logger.Info("Received deleteProductType request")
+
+		if err := productSvc.DeleteProductType(ctx, ctx.Param("id"), ctx.Query("modified_by")); err != nil {
+			ctx.JSON(catalogueErrorCode(err), gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		utils.Logger.Info("Completed deleteProductType request process")
+
+		ctx.Status(http.StatusNoContent)
+	})
+
+	r.POST(cfg.CreateUOM, func(ctx *gin.Context) {
+		utils.Logger.Info("Received createUOM request")
+
+		spec := product.PostUOMSpec{}
+		if err := ctx.ShouldBindJSON(&spec); err != nil {
+			utils.Logger.Error(err.Error())
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		res, err := productSvc.CreateUOM(ctx, spec)
+		if err != nil {
+			ctx.JSON(catalogueErrorCode(err), gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		utils.Logger.Info("Completed createUOM request process")
+
+		ctx.JSON(http.StatusCreated, res)
+	})
+
+	r.GET(cfg.GetUOMs, func(ctx *gin.Context) {
+		spec := product.GetCatalogueSpec{
+			Name:           ctx.Query("name"),
+			PaginationSpec: GetPaginationSpec(ctx.Request),
+		}
+
+		res, err := productSvc.GetUOMs(ctx, spec)
+		if err != nil {
+			ctx.JSON(paginationErrorCode(err), gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		ctx.JSON(http.StatusOK, res)
+	})
+
+	r.GET(cfg.GetUOM, func(ctx *gin.Context) {
+		res, err := productSvc.GetUOM(ctx, ctx.Param("id"))
+		if err != nil {
+			ctx.JSON(catalogueErrorCode(err), gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		ctx.JSON(http.StatusOK, res)
+	})
+
+	r.DELETE(cfg.DeleteUOM, func(ctx *gin.Context) {
+		utils.Logger.Info("Received deleteUOM request")
+
+		if err := productSvc.DeleteUOM(ctx, ctx.Param("id"), ctx.Query("modified_by")); err != nil {
+			ctx.JSON(catalogueErrorCode(err), gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		utils.Logger.Info("Completed deleteUOM request process")
+
+		ctx.Status(http.StatusNoContent)
+	})
+}
+
+// catalogueErrorCode maps missing entries to not found, references to missing
+// entries and cyclic moves to a bad request and entries still referenced to a conflict
+func catalogueErrorCode(err error) int {
+	switch {
+	case errors.Is(err, product.ErrInvalidReference),
+		errors.Is(err, product.ErrCategoryCycle):
+		return http.StatusBadRequest
+	case errors.Is(err, product.ErrProductNotFound),
+		errors.Is(err, product.ErrProductCategoryNotFound),
+		errors.Is(err, product.ErrProductTypeNotFound),
+		errors.Is(err, product.ErrUOMNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, product.ErrCatalogueInUse),
+		errors.Is(err, product.ErrCatalogueDeletedReference):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,160 @@
+package router
+
+import (
+	"errors"
+	"kg/procurement/cmd/config"
+	"kg/procurement/cmd/utils"
+	"kg/procurement/internal/currency"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func NewCurrencyEngine(
+	r *gin.Engine,
+	cfg config.CurrencyRoutes,
+	currencySvc *currency.CurrencyService,
+) {
+	r.GET(cfg.GetCurrencies, func(ctx *gin.Context) {
+		utils.Logger.Info("Received getCurrencies request")
+
+		res, err := currencySvc.GetCurrencies(ctx)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		utils.Logger.Info("Completed getCurrencies request process")
+
+		ctx.JSON(http.StatusOK, gin.H{
+			"currencies":    res,
+			"base_currency": currencySvc.BaseCurrency(),
+		})
+	})
+
+	r.GET(cfg.GetExchangeRates, func(ctx *gin.Context) {
+		utils.Logger.Info("Received getExchangeRates request")
+
+		dateFrom, err := GetOptionalTimeQuery(ctx.Request, "date_from")
+		if err != nil {
+			utils.Logger.Error(err.Error())
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		dateTo, err := GetOptionalTimeQuery(ctx.Request, "date_to")
+		if err != nil {
+			utils.Logger.Error(err.Error())
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		spec := currency.GetExchangeRatesSpec{
+			FromCurrency:   ctx.Query("from_currency"),
+			ToCurrency:     ctx.Query("to_currency"),
+			DateFrom:       dateFrom,
+			DateTo:         dateTo,
+			PaginationSpec: GetPaginationSpec(ctx.Request),
+		}
+
+		res, err := currencySvc.GetExchangeRates(ctx, spec)
+		if err != nil {
+			ctx.JSON(paginationErrorCode(err), gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		utils.Logger.Info("Completed getExchangeRates request process")
+
+		ctx.JSON(http.StatusOK, res)
+	})
+
+	r.POST(cfg.CreateExchangeRate, func(ctx *gin.Context) {
+		utils.Logger.Info("Received createExchangeRate request")
+
+		spec := currency.PostExchangeRateSpec{}
+		if err := ctx.ShouldBindJSON(&spec); err != nil {
+			utils.Logger.Error(err.Error())
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		res, err := currencySvc.CreateExchangeRate(ctx, spec)
+		if err != nil {
+			ctx.JSON(exchangeRateErrorCode(err), gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		utils.Logger.Info("Completed createExchangeRate request process")
+
+		ctx.JSON(http.StatusCreated, res)
+	})
+
+	r.POST(cfg.ImportExchangeRates, func(ctx *gin.Context) {
+		utils.Logger.Info("Received importExchangeRates request")
+
+		fileHeader, err := ctx.FormFile("file")
+		if err != nil {
+			utils.Logger.Error(err.Error())
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error": "file is required",
+			})
+			return
+		}
+
+		file, err := fileHeader.Open()
+		if err != nil {
+			utils.Logger.Error(err.Error())
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		defer file.Close()
+
+		res, err := currencySvc.ImportExchangeRates(ctx, file, ctx.PostForm("modified_by"))
+		if err != nil {
+			var importErr *currency.ImportError
+			if errors.As(err, &importErr) {
+				ctx.JSON(http.StatusBadRequest, gin.H{
+					"error": "invalid import file",
+					"rows":  importErr.Rows,
+				})
+				return
+			}
+			ctx.JSON(exchangeRateErrorCode(err), gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		utils.Logger.Info("Completed importExchangeRates request process")
+
+		ctx.JSON(http.StatusOK, res)
+	})
+}
+
+// exchangeRateErrorCode maps the validation errors of exchange rates to a bad request
+func exchangeRateErrorCode(err error) int {
+	switch {
+	case errors.Is(err, currency.ErrUnknownCurrency),
+		errors.Is(err, currency.ErrSameCurrency),
+		errors.Is(err, currency.ErrInvalidRate),
+		errors.Is(err, currency.ErrMissingRateDate),
+		errors.Is(err, currency.ErrEmptyImport),
+		errors.Is(err, currency.ErrTooManyImportRows),
+		errors.Is(err, currency.ErrInvalidImportHeader):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
@@ -25,7 +25,7 @@ func NewEmailStatusEngine(
 
 		res, err := emailStatusSvc.GetAll(ctx, spec)
 		if err != nil {
-			ctx.JSON(http.StatusInternalServerError, gin.H{
+			ctx.JSON(paginationErrorCode(err), gin.H{
 				"error": err.Error(),
 			})
 			return
@@ -62,6 +62,7 @@ func NewEmailStatusEngine(
 		}
 
 		payload.ID = id
+		payload.ModifiedBy = getModifiedBy(ctx, payload.ModifiedBy)
 
 		updatedStatus, err := emailStatusSvc.UpdateEmailStatus(ctx, payload)
 		if err != nil {
@@ -0,0 +1,28 @@
+package router
+
+import (
+	"kg/procurement/cmd/config"
+	"kg/procurement/internal/health"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func NewHealthEngine(
+	r *gin.Engine,
+	cfg config.HealthRoutes,
+	healthSvc *health.HealthService,
+) {
+	r.GET(cfg.Liveness, func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, healthSvc.Liveness())
+	})
+
+	r.GET(cfg.Readiness, func(ctx *gin.Context) {
+		res := healthSvc.Readiness(ctx)
+		if res.Status != health.StatusUp {
+			ctx.JSON(http.StatusServiceUnavailable, res)
+			return
+		}
+		ctx.JSON(http.StatusOK, res)
+	})
+}
@@ -1,9 +1,20 @@
 package router
 
 import (
+	"errors"
+	"fmt"
+	"kg/procurement/cmd/utils"
 	"kg/procurement/internal/common/database"
+	"kg/procurement/internal/common/middleware"
+	"kg/procurement/internal/common/spreadsheet"
+	"kg/procurement/internal/token"
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
 )
 
 func GetPaginationSpec(r *http.Request) database.PaginationSpec {
@@ -32,7 +43,130 @@ func GetPaginationSpec(r *http.Request) database.PaginationSpec {
 		Order:   order,
 		Page:    page,
 		OrderBy: orderBy,
+		Mode:    queryParam.Get("pagination"),
+		Cursor:  queryParam.Get("cursor"),
 	}
 
 	return spec
 }
+
+// getModifiedBy returns the user making the request when it is authenticated,
+// otherwise the modified_by given along with the request
+func getModifiedBy(ctx *gin.Context, given string) string {
+	if payload, ok := ctx.Get(middleware.AuthPayloadKey); ok {
+		if claims, ok := payload.(token.ClaimSpec); ok && claims.UserID != "" {
+			return claims.UserID
+		}
+	}
+	return given
+}
+
+// paginationErrorCode maps invalid sort or cursor input to a bad request
+func paginationErrorCode(err error) int {
+	if errors.Is(err, database.ErrInvalidSortColumn) ||
+		errors.Is(err, database.ErrInvalidCursor) ||
+		errors.Is(err, database.ErrCursorMultiColumn) {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// GetOptionalIntQuery parses an optional integer query parameter.
+// A missing parameter returns nil without an error.
+func GetOptionalIntQuery(r *http.Request, key string) (*int, error) {
+	value := r.URL.Query().Get(key)
+	if value == "" {
+		return nil, nil
+	}
+
+	res, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %s", key, value)
+	}
+
+	return &res, nil
+}
+
+// GetOptionalBoolQuery parses an optional boolean query parameter.
+// A missing parameter returns nil without an error.
+func GetOptionalBoolQuery(r *http.Request, key string) (*bool, error) {
+	value := r.URL.Query().Get(key)
+	if value == "" {
+		return nil, nil
+	}
+
+	res, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %s", key, value)
+	}
+
+	return &res, nil
+}
+
+// GetOptionalTimeQuery parses an optional time query parameter formatted
+// either as RFC3339 or as a plain date (YYYY-MM-DD).
+// A missing parameter returns the zero time without an error.
+func GetOptionalTimeQuery(r *http.Request, key string) (time.Time, error) {
+	value := r.URL.Query().Get(key)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if res, err := time.Parse(layout, value); err == nil {
+			return res, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid %s: %s", key, value)
+}
+
+// getExportFormat reads the format query parameter of an export,
+// the first allowed format is used when it is missing
+func getExportFormat(r *http.Request, allowed ...string) (string, error) {
+	format := strings.ToLower(r.URL.Query().Get("format"))
+	if format == "" {
+		return allowed[0], nil
+	}
+	if !slices.Contains(allowed, format) {
+		return "", fmt.Errorf("%w: %s", spreadsheet.ErrUnsupportedFormat, format)
+	}
+	return format, nil
+}
+
+// streamExport sends the file written by export as an attachment named filename.
+// Errors raised before anything is sent are returned as JSON, once the file is
+// partly sent the response can only be cut short
+func streamExport(ctx *gin.Context, filename string, format string, title string, export func(w spreadsheet.Writer) error) {
+	writer, err := spreadsheet.NewWriter(ctx.Writer, format, title)
+	if err != nil {
+		utils.Logger.Error(err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	ctx.Header("Content-Type", spreadsheet.ContentType(format))
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, filename, format))
+
+	if err := export(writer); err != nil {
+		writer.Abort()
+		utils.Logger.Error(err.Error())
+		if ctx.Writer.Written() {
+			ctx.Abort()
+			return
+		}
+		ctx.Writer.Header().Del("Content-Type")
+		ctx.Writer.Header().Del("Content-Disposition")
+		ctx.JSON(paginationErrorCode(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if err := writer.Close(); err != nil {
+		utils.Logger.Error(err.Error())
+		ctx.Abort()
+	}
+}
@@ -1,10 +1,13 @@
 package router
 
 import (
+	"errors"
 	"kg/procurement/cmd/config"
 	"kg/procurement/cmd/utils"
+	"kg/procurement/internal/common/spreadsheet"
 	"kg/procurement/internal/product"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
@@ -27,10 +30,20 @@ func NewProductEngine(
 			return
 		}
 
+		priceContext, err := getPriceContext(ctx.Request, "price_date")
+		if err != nil {
+			utils.Logger.Error(err.Error())
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
 		paginationSpec := GetPaginationSpec(ctx.Request)
 
 		spec := product.GetProductVendorByVendorSpec{
 			Name:           ctx.Query("name"),
+			PriceContext:   priceContext,
 			PaginationSpec: paginationSpec,
 		}
 
@@ -48,16 +61,18 @@ func NewProductEngine(
 	})
 
 	r.GET(cfg.GetProductVendors, func(ctx *gin.Context) {
-		paginationSpec := GetPaginationSpec(ctx.Request)
-
-		spec := product.GetProductVendorsSpec{
-			Name:           ctx.Query("name"),
-			PaginationSpec: paginationSpec,
+		spec, err := getProductVendorsSpec(ctx.Request)
+		if err != nil {
+			utils.Logger.Error(err.Error())
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
 		}
 
 		res, err := productSvc.GetProductVendors(ctx, spec)
 		if err != nil {
-			ctx.JSON(http.StatusInternalServerError, gin.H{
+			ctx.JSON(paginationErrorCode(err), gin.H{
 				"error": err.Error(),
 			})
 			return
@@ -66,6 +81,62 @@ func NewProductEngine(
 		ctx.JSON(http.StatusOK, res)
 	})
 
+	r.GET(cfg.ExportProductVendors, func(ctx *gin.Context) {
+		utils.Logger.Info("Received exportProductVendors request")
+
+		format, err := getExportFormat(ctx.Request, spreadsheet.FormatCSV, spreadsheet.FormatXLSX)
+		if err != nil {
+			utils.Logger.Error(err.Error())
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		spec, err := getProductVendorsSpec(ctx.Request)
+		if err != nil {
+			utils.Logger.Error(err.Error())
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		streamExport(ctx, "product-vendors", format, "Product vendors", func(w spreadsheet.Writer) error {
+			return productSvc.ExportProductVendors(ctx, spec, w)
+		})
+
+		utils.Logger.Info("Completed exportProductVendors request process")
+	})
+
+	r.GET(cfg.ExportPriceComparison, func(ctx *gin.Context) {
+		utils.Logger.Info("Received exportPriceComparison request")
+
+		format, err := getExportFormat(ctx.Request, spreadsheet.FormatPDF, spreadsheet.FormatCSV, spreadsheet.FormatXLSX)
+		if err != nil {
+			utils.Logger.Error(err.Error())
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		spec, err := getProductVendorsSpec(ctx.Request)
+		if err != nil {
+			utils.Logger.Error(err.Error())
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		streamExport(ctx, "price-comparison", format, "Price comparison", func(w spreadsheet.Writer) error {
+			return productSvc.ExportPriceComparison(ctx, spec, w)
+		})
+
+		utils.Logger.Info("Completed exportPriceComparison request process")
+	})
+
 	r.PUT(cfg.UpdateProduct, func(ctx *gin.Context) {
 		utils.Logger.Info("Received updateProductDetail request")
 
@@ -139,7 +210,197 @@ func NewProductEngine(
 			InvocationOrder: spec.InvocationOrder,
 		}
 
-		res, err := productSvc.UpdatePrice(ctx, newPrice)
+		change := product.PriceChange{
+			ChangedBy: spec.ChangedBy,
+			Reason:    spec.Reason,
+			Confirmed: spec.ConfirmAnomaly,
+		}
+
+		res, err := productSvc.UpdatePrice(ctx, newPrice, change)
+		if err != nil {
+			var anomalyErr *product.PriceAnomalyError
+			switch {
+			case errors.As(err, &anomalyErr) && anomalyErr.Anomaly.Status == product.PriceAnomalyStatusPending.String():
+				// the price is held until someone else approves it
+				ctx.JSON(http.StatusAccepted, anomalyErr.Anomaly)
+			case errors.As(err, &anomalyErr):
+				ctx.JSON(http.StatusUnprocessableEntity, gin.H{
+					"error":   err.Error(),
+					"anomaly": anomalyErr.Anomaly,
+				})
+			case errors.Is(err, product.ErrPriceNotFound):
+				ctx.JSON(http.StatusNotFound, gin.H{
+					"error": err.Error(),
+				})
+			case errors.Is(err, product.ErrOverlappingPrice):
+				ctx.JSON(http.StatusConflict, gin.H{
+					"error": err.Error(),
+				})
+			default:
+				ctx.JSON(http.StatusInternalServerError, gin.H{
+					"error": err.Error(),
+				})
+			}
+			return
+		}
+
+		utils.Logger.Info("Completed updateProductPrice request process")
+
+		ctx.JSON(http.StatusOK, res)
+	})
+
+	r.POST(cfg.CreateProductVendor, func(ctx *gin.Context) {
+		utils.Logger.Info("Received createProductVendor request")
+
+		spec := product.PostProductVendorSpec{}
+		if err := ctx.ShouldBindJSON(&spec); err != nil {
+			utils.Logger.Error(err.Error())
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		spec.ModifiedBy = getModifiedBy(ctx, spec.ModifiedBy)
+
+		res, err := productSvc.CreateProductVendor(ctx, spec)
+		if err != nil {
+			ctx.JSON(offeringErrorCode(err), gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		utils.Logger.Info("Completed createProductVendor request process")
+
+		ctx.JSON(http.StatusCreated, res)
+	})
+
+	r.DELETE(cfg.DeleteProductVendor, func(ctx *gin.Context) {
+		utils.Logger.Info("Received deleteProductVendor request")
+
+		deletedBy := getModifiedBy(ctx, ctx.Query("modified_by"))
+		if err := productSvc.DeleteProductVendor(ctx, ctx.Param("id"), deletedBy); err != nil {
+			ctx.JSON(offeringErrorCode(err), gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		utils.Logger.Info("Completed deleteProductVendor request process")
+
+		ctx.Status(http.StatusNoContent)
+	})
+
+	r.POST(cfg.RestoreProductVendor, func(ctx *gin.Context) {
+		utils.Logger.Info("Received restoreProductVendor request")
+
+		restoredBy := getModifiedBy(ctx, ctx.Query("modified_by"))
+		if err := productSvc.RestoreProductVendor(ctx, ctx.Param("id"), restoredBy); err != nil {
+			ctx.JSON(offeringErrorCode(err), gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		utils.Logger.Info("Completed restoreProductVendor request process")
+
+		ctx.Status(http.StatusNoContent)
+	})
+
+	r.POST(cfg.CreatePrice, func(ctx *gin.Context) {
+		utils.Logger.Info("Received createPrice request")
+
+		spec := product.PostPriceSpec{}
+		if err := ctx.ShouldBindJSON(&spec); err != nil {
+			utils.Logger.Error(err.Error())
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		spec.ModifiedBy = getModifiedBy(ctx, spec.ModifiedBy)
+
+		res, err := productSvc.CreatePrice(ctx, spec)
+		if err != nil {
+			ctx.JSON(offeringErrorCode(err), gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		utils.Logger.Info("Completed createPrice request process")
+
+		ctx.JSON(http.StatusCreated, res)
+	})
+
+	r.DELETE(cfg.DeletePrice, func(ctx *gin.Context) {
+		utils.Logger.Info("Received deletePrice request")
+
+		deletedBy := getModifiedBy(ctx, ctx.Query("modified_by"))
+		if err := productSvc.DeletePrice(ctx, ctx.Param("id"), deletedBy); err != nil {
+			ctx.JSON(offeringErrorCode(err), gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		utils.Logger.Info("Completed deletePrice request process")
+
+		ctx.Status(http.StatusNoContent)
+	})
+
+	r.POST(cfg.RestorePrice, func(ctx *gin.Context) {
+		utils.Logger.Info("Received restorePrice request")
+
+		restoredBy := getModifiedBy(ctx, ctx.Query("modified_by"))
+		if err := productSvc.RestorePrice(ctx, ctx.Param("id"), restoredBy); err != nil {
+			ctx.JSON(offeringErrorCode(err), gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		utils.Logger.Info("Completed restorePrice request process")
+
+		ctx.Status(http.StatusNoContent)
+	})
+
+	r.GET(cfg.GetPriceHistory, func(ctx *gin.Context) {
+		utils.Logger.Info("Received getPriceHistory request")
+
+		productVendorID := ctx.Param("product_vendor_id")
+
+		// with a date the prices in effect at that date are returned instead of the full history
+		at, err := GetOptionalTimeQuery(ctx.Request, "at")
+		if err != nil {
+			utils.Logger.Error(err.Error())
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		if !at.IsZero() {
+			res, err := productSvc.GetEffectivePrices(ctx, productVendorID, at)
+			if err != nil {
+				ctx.JSON(http.StatusInternalServerError, gin.H{
+					"error": err.Error(),
+				})
+				return
+			}
+
+			utils.Logger.Info("Completed getPriceHistory request process")
+
+			ctx.JSON(http.StatusOK, gin.H{
+				"effective_prices": res,
+			})
+			return
+		}
+
+		spec := product.GetPriceHistorySpec{
+			PaginationSpec: GetPaginationSpec(ctx.Request),
+		}
+
+		res, err := productSvc.GetPriceHistory(ctx, productVendorID, spec)
 		if err != nil {
 			ctx.JSON(http.StatusInternalServerError, gin.H{
 				"error": err.Error(),
@@ -147,8 +408,246 @@ func NewProductEngine(
 			return
 		}
 
-		utils.Logger.Info("Completed updateProductPrice request process")
+		utils.Logger.Info("Completed getPriceHistory request process")
+
+		ctx.JSON(http.StatusOK, res)
+	})
+
+	r.GET(cfg.ResolvePrice, func(ctx *gin.Context) {
+		utils.Logger.Info("Received resolvePrice request")
+
+		productVendorID := ctx.Query("product_vendor_id")
+		if productVendorID == "" {
+			utils.Logger.Error("product_vendor_id is required")
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error": "product_vendor_id is required",
+			})
+			return
+		}
+
+		priceContext, err := getPriceContext(ctx.Request, "date")
+		if err != nil {
+			utils.Logger.Error(err.Error())
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		res, err := productSvc.ResolvePrice(ctx, productVendorID, priceContext)
+		if err != nil {
+			statusCode := http.StatusInternalServerError
+			if errors.Is(err, product.ErrNoApplicablePrice) {
+				statusCode = http.StatusNotFound
+			}
+			ctx.JSON(statusCode, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		utils.Logger.Info("Completed resolvePrice request process")
+
+		ctx.JSON(http.StatusOK, res)
+	})
+
+	r.POST(cfg.ImportPrices, func(ctx *gin.Context) {
+		utils.Logger.Info("Received importPrices request")
+
+		fileHeader, err := ctx.FormFile("file")
+		if err != nil {
+			utils.Logger.Error(err.Error())
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error": "file is required",
+			})
+			return
+		}
+
+		format, err := spreadsheet.FormatFromFilename(fileHeader.Filename)
+		if err != nil {
+			utils.Logger.Error(err.Error())
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		dryRun, err := strconv.ParseBool(ctx.DefaultPostForm("dry_run", "false"))
+		if err != nil {
+			utils.Logger.Error(err.Error())
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error": "invalid dry_run",
+			})
+			return
+		}
+
+		confirmAnomalies, err := strconv.ParseBool(ctx.DefaultPostForm("confirm_anomalies", "false"))
+		if err != nil {
+			utils.Logger.Error(err.Error())
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error": "invalid confirm_anomalies",
+			})
+			return
+		}
+
+		file, err := fileHeader.Open()
+		if err != nil {
+			utils.Logger.Error(err.Error())
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		defer file.Close()
+
+		res, err := productSvc.ImportPrices(ctx, file, format, product.PriceImportSpec{
+			DryRun:           dryRun,
+			ConfirmAnomalies: confirmAnomalies,
+			ModifiedBy:       ctx.PostForm("modified_by"),
+		})
+		if err != nil {
+			var importErr *product.PriceImportError
+			switch {
+			case errors.As(err, &importErr):
+				ctx.JSON(http.StatusBadRequest, gin.H{
+					"error": "invalid import file",
+					"rows":  importErr.Rows,
+				})
+			case errors.Is(err, product.ErrEmptyPriceImport),
+				errors.Is(err, product.ErrTooManyPriceImportRows),
+				errors.Is(err, spreadsheet.ErrMissingHeader):
+				ctx.JSON(http.StatusBadRequest, gin.H{
+					"error": err.Error(),
+				})
+			default:
+				ctx.JSON(http.StatusInternalServerError, gin.H{
+					"error": err.Error(),
+				})
+			}
+			return
+		}
+
+		utils.Logger.Info("Completed importPrices request process")
+
+		ctx.JSON(http.StatusOK, res)
+	})
+
+	r.GET(cfg.GetPriceAnomalies, func(ctx *gin.Context) {
+		utils.Logger.Info("Received getPriceAnomalies request")
+
+		spec := product.GetPriceAnomaliesSpec{
+			Status:          ctx.Query("status"),
+			ProductVendorID: ctx.Query("product_vendor_id"),
+			PaginationSpec:  GetPaginationSpec(ctx.Request),
+		}
+
+		res, err := productSvc.GetPriceAnomalies(ctx, spec)
+		if err != nil {
+			ctx.JSON(priceAnomalyErrorCode(err), gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		utils.Logger.Info("Completed getPriceAnomalies request process")
+
+		ctx.JSON(http.StatusOK, res)
+	})
+
+	r.PUT(cfg.ReviewPriceAnomaly, func(ctx *gin.Context) {
+		utils.Logger.Info("Received reviewPriceAnomaly request")
+
+		spec := product.ReviewPriceAnomalySpec{}
+		if err := ctx.ShouldBindJSON(&spec); err != nil {
+			utils.Logger.Error(err.Error())
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		spec.ReviewedBy = getModifiedBy(ctx, spec.ReviewedBy)
+
+		res, err := productSvc.ReviewPriceAnomaly(ctx, ctx.Param("id"), spec)
+		if err != nil {
+			ctx.JSON(priceAnomalyErrorCode(err), gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		utils.Logger.Info("Completed reviewPriceAnomaly request process")
 
 		ctx.JSON(http.StatusOK, res)
 	})
 }
+
+func priceAnomalyErrorCode(err error) int {
+	switch {
+	case errors.Is(err, product.ErrInvalidPriceAnomalyStatus),
+		errors.Is(err, product.ErrPriceAnomalyNotPending),
+		errors.Is(err, product.ErrPriceAnomalySelfApproval):
+		return http.StatusBadRequest
+	case errors.Is(err, product.ErrPriceAnomalyNotFound),
+		errors.Is(err, product.ErrPriceNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, product.ErrOverlappingPrice),
+		errors.Is(err, product.ErrPriceAnomalyStale):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
+
+// offeringErrorCode maps invalid prices and references to missing entries to a bad
+// request, overlapping prices and product vendors still priced to a conflict
+func offeringErrorCode(err error) int {
+	switch {
+	case errors.Is(err, product.ErrInvalidPrice),
+		errors.Is(err, product.ErrInvalidReference):
+		return http.StatusBadRequest
+	case errors.Is(err, product.ErrProductVendorNotFound),
+		errors.Is(err, product.ErrPriceNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, product.ErrOverlappingPrice),
+		errors.Is(err, product.ErrCatalogueInUse),
+		errors.Is(err, product.ErrCatalogueDeletedReference):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
+
+// getProductVendorsSpec reads the filters and price context of the product vendor listing
+func getProductVendorsSpec(r *http.Request) (product.GetProductVendorsSpec, error) {
+	priceContext, err := getPriceContext(r, "price_date")
+	if err != nil {
+		return product.GetProductVendorsSpec{}, err
+	}
+
+	return product.GetProductVendorsSpec{
+		Name:           r.URL.Query().Get("name"),
+		PriceContext:   priceContext,
+		PaginationSpec: GetPaginationSpec(r),
+	}, nil
+}
+
+// getPriceContext reads the purchase a price is resolved for, dateKey is the
+// query param holding the date the price must be valid at
+func getPriceContext(r *http.Request, dateKey string) (product.PriceContext, error) {
+	quantity, err := GetOptionalIntQuery(r, "quantity")
+	if err != nil {
+		return product.PriceContext{}, err
+	}
+	date, err := GetOptionalTimeQuery(r, dateKey)
+	if err != nil {
+		return product.PriceContext{}, err
+	}
+
+	pc := product.PriceContext{
+		AreaGroupID:     r.URL.Query().Get("area_group_id"),
+		PurchasingOrgID: r.URL.Query().Get("purchasing_org_id"),
+		Date:            date,
+	}
+	if quantity != nil {
+		pc.Quantity = *quantity
+	}
+	return pc, nil
+}
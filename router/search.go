@@ -0,0 +1,78 @@
+package router
+
+import (
+	"errors"
+	"kg/procurement/cmd/config"
+	"kg/procurement/cmd/utils"
+	"kg/procurement/internal/search"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+func NewSearchEngine(
+	r *gin.Engine,
+	cfg config.SearchRoutes,
+	searchSvc *search.SearchService,
+) {
+	r.GET(cfg.Search, func(ctx *gin.Context) {
+		utils.Logger.Info("Received search request")
+
+		types, err := parseSearchTypes(ctx.Query("types"))
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		spec := search.SearchSpec{
+			Query:          ctx.Query("q"),
+			Types:          types,
+			PaginationSpec: GetPaginationSpec(ctx.Request),
+		}
+
+		res, err := searchSvc.Search(ctx, spec)
+		if err != nil {
+			if errors.Is(err, search.ErrEmptyQuery) {
+				ctx.JSON(http.StatusBadRequest, gin.H{
+					"error": err.Error(),
+				})
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		utils.Logger.Info("Completed search request process")
+
+		ctx.JSON(http.StatusOK, res)
+	})
+}
+
+// parseSearchTypes reads a comma separated list of entity types, i.e. vendor,product
+func parseSearchTypes(raw string) ([]search.EntityType, error) {
+	var (
+		types []search.EntityType
+		seen  = map[search.EntityType]bool{}
+	)
+	for _, t := range strings.Split(raw, ",") {
+		t = strings.TrimSpace(t)
+		if t == "" {
+			continue
+		}
+		entity, err := search.ParseEntityType(t)
+		if err != nil {
+			return nil, err
+		}
+		if seen[entity] {
+			continue
+		}
+		seen[entity] = true
+		types = append(types, entity)
+	}
+	return types, nil
+}
@@ -0,0 +1,141 @@
+package router
+
+import (
+	"errors"
+	"kg/procurement/cmd/config"
+	"kg/procurement/cmd/utils"
+	"kg/procurement/internal/uom"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func NewUOMEngine(
+	r *gin.Engine,
+	cfg config.UOMRoutes,
+	uomSvc *uom.UOMService,
+) {
+	r.GET(cfg.Convert, func(ctx *gin.Context) {
+		from, to := ctx.Query("from_uom_id"), ctx.Query("to_uom_id")
+		if from == "" || to == "" {
+			utils.Logger.Error("from_uom_id and to_uom_id are required")
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error": "from_uom_id and to_uom_id are required",
+			})
+			return
+		}
+
+		quantity, err := strconv.ParseFloat(ctx.DefaultQuery("quantity", "1"), 64)
+		if err != nil {
+			utils.Logger.Error(err.Error())
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error": "invalid quantity",
+			})
+			return
+		}
+
+		res, err := uomSvc.Convert(ctx, ctx.Query("product_id"), quantity, from, to)
+		if err != nil {
+			ctx.JSON(uomErrorCode(err), gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		ctx.JSON(http.StatusOK, res)
+	})
+
+	r.GET(cfg.GetProductConversions, func(ctx *gin.Context) {
+		res, err := uomSvc.GetProductConversions(ctx, ctx.Param("product_id"))
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{
+			"conversions": res,
+		})
+	})
+
+	r.POST(cfg.CreateProductConversion, func(ctx *gin.Context) {
+		utils.Logger.Info("Received createProductConversion request")
+
+		spec := uom.PostProductConversionSpec{}
+		if err := ctx.ShouldBindJSON(&spec); err != nil {
+			utils.Logger.Error(err.Error())
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		res, err := uomSvc.CreateProductConversion(ctx, spec)
+		if err != nil {
+			ctx.JSON(uomErrorCode(err), gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		utils.Logger.Info("Completed createProductConversion request process")
+
+		ctx.JSON(http.StatusCreated, res)
+	})
+
+	r.DELETE(cfg.DeleteProductConversion, func(ctx *gin.Context) {
+		utils.Logger.Info("Received deleteProductConversion request")
+
+		if err := uomSvc.DeleteProductConversion(ctx, ctx.Param("id")); err != nil {
+			ctx.JSON(uomErrorCode(err), gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		utils.Logger.Info("Completed deleteProductConversion request process")
+
+		ctx.Status(http.StatusNoContent)
+	})
+
+	r.PUT(cfg.UpdateBaseFactor, func(ctx *gin.Context) {
+		utils.Logger.Info("Received updateBaseFactor request")
+
+		spec := uom.PutBaseFactorSpec{}
+		if err := ctx.ShouldBindJSON(&spec); err != nil {
+			utils.Logger.Error(err.Error())
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		if err := uomSvc.UpdateBaseFactor(ctx, ctx.Param("id"), spec); err != nil {
+			ctx.JSON(uomErrorCode(err), gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		utils.Logger.Info("Completed updateBaseFactor request process")
+
+		ctx.Status(http.StatusNoContent)
+	})
+}
+
+// uomErrorCode maps invalid conversions to a bad request and missing entries to not found
+func uomErrorCode(err error) int {
+	switch {
+	case errors.Is(err, uom.ErrSameUOM),
+		errors.Is(err, uom.ErrInvalidFactor),
+		errors.Is(err, uom.ErrUnknownProduct),
+		errors.Is(err, uom.ErrNoConversion):
+		return http.StatusBadRequest
+	case errors.Is(err, uom.ErrUnknownUOM),
+		errors.Is(err, uom.ErrConversionNotFound):
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
@@ -2,8 +2,10 @@ package router
 
 import (
 	"encoding/json"
+	"errors"
 	"kg/procurement/cmd/config"
 	"kg/procurement/cmd/utils"
+	"kg/procurement/internal/common/spreadsheet"
 	"kg/procurement/internal/mailer"
 	"kg/procurement/internal/vendors"
 	"net/http"
@@ -19,16 +21,18 @@ func NewVendorEngine(
 	r.GET(cfg.GetAll, func(ctx *gin.Context) {
 		utils.Logger.Info("Received getAllVendor request")
 
-		paginationSpec := GetPaginationSpec(ctx.Request)
-		spec := vendors.GetAllVendorSpec{
-			Location:       ctx.Query("location"),
-			Product:        ctx.Query("product"),
-			PaginationSpec: paginationSpec,
+		spec, err := getAllVendorSpec(ctx.Request)
+		if err != nil {
+			utils.Logger.Error(err.Error())
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
 		}
 
 		res, err := vendorSvc.GetAll(ctx, spec)
 		if err != nil {
-			ctx.JSON(http.StatusInternalServerError, gin.H{
+			ctx.JSON(vendorListErrorCode(err), gin.H{
 				"error": err.Error(),
 			})
 			return
@@ -39,6 +43,34 @@ func NewVendorEngine(
 		ctx.JSON(http.StatusOK, res)
 	})
 
+	r.GET(cfg.ExportVendors, func(ctx *gin.Context) {
+		utils.Logger.Info("Received exportVendors request")
+
+		format, err := getExportFormat(ctx.Request, spreadsheet.FormatCSV, spreadsheet.FormatXLSX)
+		if err != nil {
+			utils.Logger.Error(err.Error())
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		spec, err := getAllVendorSpec(ctx.Request)
+		if err != nil {
+			utils.Logger.Error(err.Error())
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		streamExport(ctx, "vendors", format, "Vendors", func(w spreadsheet.Writer) error {
+			return vendorSvc.ExportVendors(ctx, spec, w)
+		})
+
+		utils.Logger.Info("Completed exportVendors request process")
+	})
+
 	r.PUT(cfg.UpdateDetail, func(ctx *gin.Context) {
 		utils.Logger.Info("Received updateVendorDetail request")
 
@@ -162,7 +194,7 @@ func NewVendorEngine(
 			Attachments: attachments,
 		}
 
-		errList, err := vendorSvc.BlastEmail(ctx, vendorIDs, email)
+		errList, err := vendorSvc.BlastEmail(ctx, vendorIDs, email, getModifiedBy(ctx, payload.SentBy))
 		if err != nil {
 			if len(errList) > 0 {
 				ctx.JSON(http.StatusMultiStatus, gin.H{
@@ -194,7 +226,7 @@ func NewVendorEngine(
 			return
 		}
 
-		errList, err := vendorSvc.AutomatedEmailBlast(ctx, productName)
+		errList, err := vendorSvc.AutomatedEmailBlast(ctx, productName, getModifiedBy(ctx, ctx.Query("sent_by")))
 		if err != nil {
 			if len(errList) > 0 {
 				ctx.JSON(http.StatusMultiStatus, gin.H{
@@ -223,7 +255,7 @@ func NewVendorEngine(
 
 		res, err := vendorSvc.GetPopulatedEmailStatus(ctx, spec)
 		if err != nil {
-			ctx.JSON(http.StatusInternalServerError, gin.H{
+			ctx.JSON(paginationErrorCode(err), gin.H{
 				"error": err.Error(),
 			})
 			return
@@ -234,6 +266,30 @@ func NewVendorEngine(
 		ctx.JSON(http.StatusOK, res)
 	})
 
+	r.GET(cfg.ExportEmailStatus, func(ctx *gin.Context) {
+		utils.Logger.Info("Received exportEmailStatus request")
+
+		format, err := getExportFormat(ctx.Request, spreadsheet.FormatCSV, spreadsheet.FormatXLSX)
+		if err != nil {
+			utils.Logger.Error(err.Error())
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		spec := mailer.GetAllEmailStatusSpec{
+			EmailTo:        ctx.Query("email_to"),
+			PaginationSpec: GetPaginationSpec(ctx.Request),
+		}
+
+		streamExport(ctx, "email-status", format, "Email status", func(w spreadsheet.Writer) error {
+			return vendorSvc.ExportEmailStatus(ctx, spec, w)
+		})
+
+		utils.Logger.Info("Completed exportEmailStatus request process")
+	})
+
 	r.POST(cfg.Evaluation, func(ctx *gin.Context) {
 		utils.Logger.Info("Received vendor evaluation request")
 
@@ -248,6 +304,7 @@ func NewVendorEngine(
 			return
 		}
 
+		vendorEvaluation.ModifiedBy = getModifiedBy(ctx, vendorEvaluation.ModifiedBy)
 		_, err = vendorSvc.CreateEvaluation(ctx, vendorEvaluation)
 		if err != nil {
 			ctx.JSON(http.StatusInternalServerError, gin.H{
@@ -260,4 +317,201 @@ func NewVendorEngine(
 			"vendor_evaluation": vendorEvaluation,
 		})
 	})
+
+	r.PUT(cfg.UpdateStatus, func(ctx *gin.Context) {
+		utils.Logger.Info("Received updateVendorStatus request")
+
+		id := ctx.Param("id")
+
+		spec := vendors.PutVendorStatusSpec{}
+		if err := ctx.ShouldBindJSON(&spec); err != nil {
+			utils.Logger.Error(err.Error())
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		spec.RequestedBy = getModifiedBy(ctx, spec.RequestedBy)
+		res, err := vendorSvc.UpdateStatus(ctx, id, spec)
+		if err != nil {
+			ctx.JSON(vendorStatusErrorCode(err), gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		utils.Logger.Info("Completed updateVendorStatus request process")
+
+		// lifting a blacklist waits for approval
+		if res.ApprovalStatus == vendors.StatusApprovalPending.String() {
+			ctx.JSON(http.StatusAccepted, res)
+			return
+		}
+		ctx.JSON(http.StatusOK, res)
+	})
+
+	r.GET(cfg.GetStatusHistory, func(ctx *gin.Context) {
+		utils.Logger.Info("Received getVendorStatusHistory request")
+
+		id := ctx.Param("id")
+
+		res, err := vendorSvc.GetStatusHistory(ctx, id)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		utils.Logger.Info("Completed getVendorStatusHistory request process")
+
+		ctx.JSON(http.StatusOK, gin.H{
+			"status_history": res,
+		})
+	})
+
+	r.PUT(cfg.ReviewStatus, func(ctx *gin.Context) {
+		utils.Logger.Info("Received reviewVendorStatus request")
+
+		id := ctx.Param("id")
+
+		spec := vendors.ReviewVendorStatusSpec{}
+		if err := ctx.ShouldBindJSON(&spec); err != nil {
+			utils.Logger.Error(err.Error())
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		spec.ReviewedBy = getModifiedBy(ctx, spec.ReviewedBy)
+		res, err := vendorSvc.ReviewStatusChange(ctx, id, spec)
+		if err != nil {
+			ctx.JSON(vendorStatusErrorCode(err), gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		utils.Logger.Info("Completed reviewVendorStatus request process")
+
+		ctx.JSON(http.StatusOK, res)
+	})
+
+	r.DELETE(cfg.DeleteVendor, func(ctx *gin.Context) {
+		utils.Logger.Info("Received deleteVendor request")
+
+		deletedBy := getModifiedBy(ctx, ctx.Query("modified_by"))
+		if err := vendorSvc.DeleteVendor(ctx, ctx.Param("id"), deletedBy); err != nil {
+			ctx.JSON(vendorErrorCode(err), gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		utils.Logger.Info("Completed deleteVendor request process")
+
+		ctx.Status(http.StatusNoContent)
+	})
+
+	r.POST(cfg.RestoreVendor, func(ctx *gin.Context) {
+		utils.Logger.Info("Received restoreVendor request")
+
+		restoredBy := getModifiedBy(ctx, ctx.Query("modified_by"))
+		if err := vendorSvc.RestoreVendor(ctx, ctx.Param("id"), restoredBy); err != nil {
+			ctx.JSON(vendorErrorCode(err), gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		utils.Logger.Info("Completed restoreVendor request process")
+
+		ctx.Status(http.StatusNoContent)
+	})
+}
+
+func vendorErrorCode(err error) int {
+	switch {
+	case errors.Is(err, vendors.ErrVendorNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, vendors.ErrVendorInUse):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func vendorListErrorCode(err error) int {
+	if errors.Is(err, vendors.ErrInvalidVendorStatus) {
+		return http.StatusBadRequest
+	}
+	return paginationErrorCode(err)
+}
+
+func vendorStatusErrorCode(err error) int {
+	switch {
+	case errors.Is(err, vendors.ErrInvalidVendorStatus),
+		errors.Is(err, vendors.ErrStatusReasonRequired),
+		errors.Is(err, vendors.ErrStatusRequesterRequired),
+		errors.Is(err, vendors.ErrVendorStatusUnchanged),
+		errors.Is(err, vendors.ErrStatusChangeNotPending),
+		errors.Is(err, vendors.ErrStatusChangeSelfApproval):
+		return http.StatusBadRequest
+	case errors.Is(err, vendors.ErrVendorNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, vendors.ErrStatusChangePending),
+		errors.Is(err, vendors.ErrVendorStatusChanged):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// getAllVendorSpec reads the filters of the vendor listing
+func getAllVendorSpec(r *http.Request) (vendors.GetAllVendorSpec, error) {
+	query := r.URL.Query()
+	spec := vendors.GetAllVendorSpec{
+		Location:          query.Get("location"),
+		Product:           query.Get("product"),
+		Status:            query.Get("status"),
+		AreaGroupID:       query.Get("area_group_id"),
+		SapCode:           query.Get("sap_code"),
+		ProductCategoryID: query.Get("product_category_id"),
+		PaginationSpec:    GetPaginationSpec(r),
+	}
+
+	if err := parseVendorFilters(r, &spec); err != nil {
+		return vendors.GetAllVendorSpec{}, err
+	}
+	return spec, nil
+}
+
+func parseVendorFilters(r *http.Request, spec *vendors.GetAllVendorSpec) error {
+	var err error
+
+	if spec.Status != "" {
+		if _, err = vendors.ParseVendorStatus(spec.Status); err != nil {
+			return err
+		}
+	}
+
+	if spec.RatingMin, err = GetOptionalIntQuery(r, "rating_min"); err != nil {
+		return err
+	}
+	if spec.RatingMax, err = GetOptionalIntQuery(r, "rating_max"); err != nil {
+		return err
+	}
+	if spec.ModifiedFrom, err = GetOptionalTimeQuery(r, "modified_from"); err != nil {
+		return err
+	}
+	if spec.ModifiedTo, err = GetOptionalTimeQuery(r, "modified_to"); err != nil {
+		return err
+	}
+	if spec.HasValidPrice, err = GetOptionalBoolQuery(r, "has_valid_price"); err != nil {
+		return err
+	}
+
+	return nil
 }
@@ -0,0 +1,366 @@
+package main
+
+import (
+	"fmt"
+	"kg/procurement/internal/mailer"
+	"kg/procurement/internal/product"
+	"kg/procurement/internal/vendors"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// generatedBy is the modified_by of every generated row, it tells them apart from real data
+const generatedBy = "generator"
+
+// scale is how many rows are generated, offers, windows, evaluations and emails are
+// counted per product, offer and vendor respectively
+type scale struct {
+	vendors     int
+	categories  int
+	products    int
+	offers      int
+	windows     int
+	evaluations int
+	emails      int
+}
+
+// dataset holds referentially consistent rows of every seeded table
+type dataset struct {
+	uoms           []product.UOM
+	categories     []product.ProductCategory
+	productTypes   []product.ProductType
+	products       []product.Product
+	vendors        []vendors.Vendor
+	productVendors []product.ProductVendor
+	prices         []product.Price
+	evaluations    []vendors.VendorEvaluation
+	emailStatuses  []mailer.EmailStatus
+}
+
+type reference struct {
+	id   string
+	name string
+}
+
+// tier is a quantity range priced at a discount off the base price, a zero max is unbounded
+type tier struct {
+	min      int
+	max      int
+	discount float64
+}
+
+var (
+	uomReferences = []struct {
+		reference
+		dimension string
+	}{
+		{reference{"gu000000001", "PCS"}, "pieces"},
+		{reference{"gu000000002", "Box"}, "boxes"},
+		{reference{"gu000000003", "Rim"}, "reams"},
+		{reference{"gu000000004", "Kg"}, "kilograms"},
+		{reference{"gu000000005", "Ltr"}, "litres"},
+		{reference{"gu000000006", "Set"}, "sets"},
+	}
+	productTypeReferences = []product.ProductType{
+		{ID: "gt000000001", Name: "Goods - Stockable", Goods: true, Stock: true},
+		{ID: "gt000000002", Name: "Goods - Consumable", Goods: true},
+		{ID: "gt000000003", Name: "Asset", Goods: true, Asset: true},
+	}
+	categoryNames = []string{
+		"Alat Tulis Kantor", "Peralatan Komputer", "Jaringan", "Perabot Kantor",
+		"Peralatan Kebersihan", "Konsumsi", "Kemasan", "Peralatan Listrik",
+		"Suku Cadang", "Keselamatan Kerja", "Percetakan", "Peralatan Dapur",
+	}
+	productNames = []string{
+		"Kertas A4", "Pulpen", "Map Plastik", "Tinta Printer", "Mouse", "Keyboard",
+		"Kabel LAN", "Router", "Kursi Kerja", "Meja Lipat", "Sabun Cair", "Tisu",
+		"Air Mineral", "Kopi Bubuk", "Kardus", "Lakban", "Lampu LED", "Stop Kontak",
+		"Baterai", "Helm Proyek", "Sarung Tangan", "Brosur", "Gelas Kertas", "Dispenser",
+	}
+	productVariants = []string{"Standar", "Premium", "Ekonomis", "Heavy Duty", "Mini", "Jumbo"}
+	vendorPrefixes  = []string{"Sinar", "Maju", "Karya", "Mitra", "Berkah", "Cahaya", "Surya", "Putra"}
+	vendorSuffixes  = []string{"Abadi", "Sentosa", "Jaya", "Makmur", "Mandiri", "Sejahtera", "Utama", "Lestari"}
+	areaGroups      = []reference{{"1", "Indonesia"}, {"2", "Jabodetabek"}, {"3", "Jawa Timur"}, {"4", "Sumatera"}}
+	purchasingOrgs  = []reference{{"1005", "UP14 - Prima Infosarana Media"}, {"1009", "UP18 - Gramedia Asri Media"}}
+	tiers           = []tier{{min: 1, max: 9}, {min: 10, max: 99, discount: 0.05}, {min: 100, discount: 0.1}}
+	windowDays      = []int{30, 90, 180}
+	emailStatuses   = []mailer.EmailStatusEnum{
+		mailer.Success, mailer.Success, mailer.Success, mailer.Completed,
+		mailer.Completed, mailer.Failed, mailer.InProgress,
+	}
+)
+
+// generator builds a dataset from a seeded source, the same seed, scale and anchor always
+// produce the same rows
+type generator struct {
+	rand   *rand.Rand
+	anchor time.Time
+	data   dataset
+}
+
+func id(prefix string, n int) string {
+	return fmt.Sprintf("g%s%09d", prefix, n)
+}
+
+// generate builds the dataset, dates are spread around anchor so that some prices are
+// expired, one is valid at the anchor and some are scheduled
+func generate(seed uint64, sc scale, anchor time.Time) dataset {
+	g := &generator{
+		rand:   rand.New(rand.NewPCG(seed, seed)),
+		anchor: anchor.UTC().Truncate(24 * time.Hour),
+	}
+	g.references()
+	g.generateCategories(sc.categories)
+	g.generateProducts(sc.products)
+	g.generateVendors(sc.vendors)
+	g.generateOffers(sc.offers, sc.windows)
+	g.generateEvaluations(sc.evaluations)
+	g.generateEmailStatuses(sc.emails)
+	return g.data
+}
+
+func (g *generator) pick(n int) int {
+	return g.rand.IntN(n)
+}
+
+// daysAgo is a moment within the last days before the anchor
+func (g *generator) daysAgo(days int) time.Time {
+	return g.anchor.Add(-time.Duration(g.rand.Int64N(int64(days) * int64(24*time.Hour))))
+}
+
+func (g *generator) references() {
+	for _, ref := range uomReferences {
+		g.data.uoms = append(g.data.uoms, product.UOM{
+			ID:           ref.id,
+			Name:         ref.name,
+			Description:  ref.dimension,
+			Dimension:    ref.dimension,
+			ModifiedDate: g.anchor,
+			ModifiedBy:   generatedBy,
+		})
+	}
+	for _, productType := range productTypeReferences {
+		productType.ModifiedDate = g.anchor
+		productType.ModifiedBy = generatedBy
+		g.data.productTypes = append(g.data.productTypes, productType)
+	}
+}
+
+func (g *generator) generateCategories(n int) {
+	for i := 1; i <= n; i++ {
+		name := categoryNames[(i-1)%len(categoryNames)]
+		if i > len(categoryNames) {
+			name = fmt.Sprintf("%s %d", name, (i-1)/len(categoryNames)+1)
+		}
+		g.data.categories = append(g.data.categories, product.ProductCategory{
+			ID:           id("c", i),
+			Name:         name,
+			Code:         fmt.Sprintf("CAT%04d", i),
+			Description:  name,
+			ModifiedDate: g.daysAgo(365),
+			ModifiedBy:   generatedBy,
+		})
+	}
+}
+
+func (g *generator) generateProducts(n int) {
+	if len(g.data.categories) == 0 {
+		return
+	}
+	for i := 1; i <= n; i++ {
+		name := fmt.Sprintf("%s %s", productNames[g.pick(len(productNames))], productVariants[g.pick(len(productVariants))])
+		g.data.products = append(g.data.products, product.Product{
+			ID:                product.ProductID(id("p", i)),
+			ProductCategoryID: g.data.categories[g.pick(len(g.data.categories))].ID,
+			UOMID:             g.data.uoms[g.pick(len(g.data.uoms))].ID,
+			IncomeTaxID:       "0",
+			ProductTypeID:     g.data.productTypes[g.pick(len(g.data.productTypes))].ID,
+			Name:              name,
+			Description:       name,
+			ModifiedDate:      g.daysAgo(365),
+			ModifiedBy:        generatedBy,
+		})
+	}
+}
+
+func (g *generator) generateVendors(n int) {
+	for i := 1; i <= n; i++ {
+		name := fmt.Sprintf("%s %s, PT", vendorPrefixes[g.pick(len(vendorPrefixes))], vendorSuffixes[g.pick(len(vendorSuffixes))])
+		area := areaGroups[g.pick(len(areaGroups))]
+		status := vendors.VendorStatusActive
+		if g.rand.Float64() < 0.1 {
+			status = vendors.VendorStatusSuspended
+		}
+		g.data.vendors = append(g.data.vendors, vendors.Vendor{
+			ID:            id("v", i),
+			Email:         fmt.Sprintf("vendor%d@example.com", i),
+			Name:          name,
+			Description:   name,
+			BpID:          fmt.Sprintf("%d", 10000+i),
+			BpName:        name,
+			Rating:        g.pick(6),
+			AreaGroupID:   area.id,
+			AreaGroupName: area.name,
+			SapCode:       fmt.Sprintf("SAP%06d", i),
+			ModifiedDate:  g.daysAgo(365),
+			ModifiedBy:    generatedBy,
+			Date:          g.daysAgo(3 * 365),
+			Status:        status.String(),
+		})
+	}
+}
+
+// generateOffers has offers vendors sell every product, each offer is priced in every
+// tier over consecutive validity windows
+func (g *generator) generateOffers(offers int, windows int) {
+	if len(g.data.vendors) == 0 || windows <= 0 {
+		return
+	}
+	for _, p := range g.data.products {
+		base := float64(500 * (10 + g.pick(4000)))
+		for o := 0; o < offers; o++ {
+			vendor := g.data.vendors[g.pick(len(g.data.vendors))]
+			pv := product.ProductVendor{
+				ID:                  id("o", len(g.data.productVendors)+1),
+				ProductID:           string(p.ID),
+				Code:                fmt.Sprintf("OF%06d", len(g.data.productVendors)+1),
+				Name:                p.Name,
+				IncomeTaxID:         "0",
+				IncomeTaxPercentage: "0",
+				Description:         p.Description,
+				UOMID:               p.UOMID,
+				ModifiedDate:        g.daysAgo(365),
+				ModifiedBy:          generatedBy,
+			}
+			g.data.productVendors = append(g.data.productVendors, pv)
+
+			// each vendor prices the product slightly off its base and raises it over time
+			price := base * (0.85 + 0.3*g.rand.Float64())
+			org := purchasingOrgs[g.pick(len(purchasingOrgs))]
+			leadTimeMin := 1 + g.pick(14)
+			leadTimeMax := leadTimeMin + g.pick(7)
+
+			for _, w := range g.validityWindows(windows) {
+				validTo, referenceDate := w.to, w.from
+				for _, t := range tiers {
+					g.data.prices = append(g.data.prices, product.Price{
+						ID:                id("r", len(g.data.prices)+1),
+						PurchasingOrgID:   org.id,
+						PurchasingOrgName: org.name,
+						VendorID:          vendor.ID,
+						ProductVendorID:   pv.ID,
+						QuantityMin:       t.min,
+						QuantityMax:       t.max,
+						QuantityUOMID:     p.UOMID,
+						LeadTimeMin:       leadTimeMin,
+						LeadTimeMax:       leadTimeMax,
+						CurrencyID:        "IDR",
+						CurrencyName:      "Indonesian Rupiah",
+						CurrencyCode:      "IDR",
+						Price:             math.Round(price*(1-t.discount)/100) * 100,
+						PriceQuantity:     1,
+						PriceUOMID:        p.UOMID,
+						ValidFrom:         w.from,
+						ValidTo:           &validTo,
+						ValidPatternID:    "1",
+						ValidPatternName:  "Everyday",
+						AreaGroupID:       vendor.AreaGroupID,
+						AreaGroupName:     vendor.AreaGroupName,
+						ReferenceNumber:   fmt.Sprintf("Q-%s.%07d", w.from.Format("06.01"), len(g.data.prices)+1),
+						ReferenceDate:     &referenceDate,
+						DocumentTypeID:    "7",
+						DocumentTypeName:  "Quotation",
+						TermOfPaymentID:   "7",
+						TermOfPaymentDays: 30,
+						TermOfPaymentText: "Due in 30 days after invoice receipt",
+						InvocationOrder:   100,
+						ModifiedDate:      w.from,
+						ModifiedBy:        generatedBy,
+					})
+				}
+				price *= 1 + 0.05*g.rand.Float64()
+			}
+		}
+	}
+}
+
+type window struct {
+	from time.Time
+	to   time.Time
+}
+
+// validityWindows lists n consecutive windows oldest first, the last one holds the anchor
+// and a quarter of the time a scheduled window follows it. A window ends a second before
+// the next one starts so that they never overlap
+func (g *generator) validityWindows(n int) []window {
+	days := func() int { return windowDays[g.pick(len(windowDays))] }
+
+	length := days()
+	from := g.anchor.AddDate(0, 0, -g.pick(length))
+	windows := []window{{from: from, to: from.AddDate(0, 0, length)}}
+	for i := 1; i < n; i++ {
+		to := windows[0].from
+		windows = append([]window{{from: to.AddDate(0, 0, -days()), to: to}}, windows...)
+	}
+	if g.rand.Float64() < 0.25 {
+		from := windows[len(windows)-1].to
+		windows = append(windows, window{from: from, to: from.AddDate(0, 0, days())})
+	}
+
+	for i := range windows {
+		windows[i].to = windows[i].to.Add(-time.Second)
+	}
+	return windows
+}
+
+// evaluationScore is a score from 1 to 5 around the quality of a vendor
+func (g *generator) evaluationScore(quality int) int {
+	return min(5, max(1, quality+g.pick(3)-1))
+}
+
+func (g *generator) generateEvaluations(n int) {
+	for _, vendor := range g.data.vendors {
+		quality := 2 + g.pick(3)
+		for i := 0; i < n; i++ {
+			g.data.evaluations = append(g.data.evaluations, vendors.VendorEvaluation{
+				ID:                               id("e", len(g.data.evaluations)+1),
+				VendorID:                         vendor.ID,
+				KesesuaianProduk:                 g.evaluationScore(quality),
+				KualitasProduk:                   g.evaluationScore(quality),
+				KetepatanWaktuPengiriman:         g.evaluationScore(quality),
+				KompetitifitasHarga:              g.evaluationScore(quality),
+				ResponsivitasKemampuanKomunikasi: g.evaluationScore(quality),
+				KemampuanDalamMenanganiMasalah:   g.evaluationScore(quality),
+				KelengkapanBarang:                g.evaluationScore(quality),
+				Harga:                            g.evaluationScore(quality),
+				TermOfPayment:                    g.evaluationScore(quality),
+				Reputasi:                         g.evaluationScore(quality),
+				KetersediaanBarang:               g.evaluationScore(quality),
+				KualitasLayananAfterServices:     g.evaluationScore(quality),
+				ModifiedDate:                     g.daysAgo(365),
+			})
+		}
+	}
+}
+
+func (g *generator) generateEmailStatuses(n int) {
+	for _, vendor := range g.data.vendors {
+		for i := 0; i < n; i++ {
+			sent := g.daysAgo(180)
+			status := emailStatuses[g.pick(len(emailStatuses))]
+			modified := sent
+			if status == mailer.Completed {
+				modified = sent.Add(time.Duration(1+g.pick(72)) * time.Hour)
+			}
+			g.data.emailStatuses = append(g.data.emailStatuses, mailer.EmailStatus{
+				ID:           id("m", len(g.data.emailStatuses)+1),
+				EmailTo:      vendor.Email,
+				Status:       status.String(),
+				VendorID:     vendor.ID,
+				DateSent:     sent,
+				ModifiedDate: modified,
+			})
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+)
+
+var (
+	testAnchor = time.Date(2024, time.December, 10, 0, 0, 0, 0, time.UTC)
+	testScale  = scale{vendors: 5, categories: 3, products: 10, offers: 2, windows: 3, evaluations: 2, emails: 3}
+)
+
+func Test_generate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("is deterministic for a seed", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		g.Expect(generate(7, testScale, testAnchor)).To(gomega.Equal(generate(7, testScale, testAnchor)))
+		g.Expect(generate(7, testScale, testAnchor)).ToNot(gomega.Equal(generate(8, testScale, testAnchor)))
+	})
+
+	t.Run("generates the requested scale", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		d := generate(1, testScale, testAnchor)
+
+		g.Expect(d.vendors).To(gomega.HaveLen(5))
+		g.Expect(d.categories).To(gomega.HaveLen(3))
+		g.Expect(d.products).To(gomega.HaveLen(10))
+		g.Expect(d.productVendors).To(gomega.HaveLen(20))
+		g.Expect(len(d.prices)).To(gomega.BeNumerically(">=", 20*3*len(tiers)))
+		g.Expect(d.evaluations).To(gomega.HaveLen(10))
+		g.Expect(d.emailStatuses).To(gomega.HaveLen(15))
+	})
+
+	t.Run("refers to generated rows only", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		d := generate(1, testScale, testAnchor)
+
+		ids := map[string]bool{}
+		for _, u := range d.uoms {
+			ids[u.ID] = true
+		}
+		for _, c := range d.categories {
+			ids[c.ID] = true
+		}
+		for _, pt := range d.productTypes {
+			ids[pt.ID] = true
+		}
+		for _, p := range d.products {
+			g.Expect(ids).To(gomega.HaveKey(p.ProductCategoryID))
+			g.Expect(ids).To(gomega.HaveKey(p.UOMID))
+			g.Expect(ids).To(gomega.HaveKey(p.ProductTypeID))
+			ids[string(p.ID)] = true
+		}
+		for _, v := range d.vendors {
+			ids[v.ID] = true
+		}
+		for _, pv := range d.productVendors {
+			g.Expect(ids).To(gomega.HaveKey(pv.ProductID))
+			g.Expect(ids).To(gomega.HaveKey(pv.UOMID))
+			ids[pv.ID] = true
+		}
+		for _, p := range d.prices {
+			g.Expect(ids).To(gomega.HaveKey(p.VendorID))
+			g.Expect(ids).To(gomega.HaveKey(p.ProductVendorID))
+			g.Expect(ids).To(gomega.HaveKey(p.QuantityUOMID))
+			g.Expect(ids).To(gomega.HaveKey(p.PriceUOMID))
+			g.Expect(len(p.ID)).To(gomega.BeNumerically("<=", 15))
+		}
+		for _, e := range d.evaluations {
+			g.Expect(ids).To(gomega.HaveKey(e.VendorID))
+		}
+		for _, es := range d.emailStatuses {
+			g.Expect(ids).To(gomega.HaveKey(es.VendorID))
+		}
+	})
+
+	t.Run("prices an offer once per tier and moment", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		d := generate(1, testScale, testAnchor)
+
+		byOffer := map[string][]int{}
+		for i, p := range d.prices {
+			g.Expect(p.ValidFrom.Before(*p.ValidTo)).To(gomega.BeTrue())
+			g.Expect(p.Price).To(gomega.BeNumerically(">", 0))
+			byOffer[p.ProductVendorID] = append(byOffer[p.ProductVendorID], i)
+		}
+
+		for _, indexes := range byOffer {
+			current := 0
+			for i, a := range indexes {
+				pa := d.prices[a]
+				if !pa.ValidFrom.After(testAnchor) && !pa.ValidTo.Before(testAnchor) {
+					current++
+				}
+				for _, b := range indexes[i+1:] {
+					pb := d.prices[b]
+					tiersOverlap := (pa.QuantityMax == 0 || pa.QuantityMax >= pb.QuantityMin) &&
+						(pb.QuantityMax == 0 || pb.QuantityMax >= pa.QuantityMin)
+					windowsOverlap := !pa.ValidTo.Before(pb.ValidFrom) && !pb.ValidTo.Before(pa.ValidFrom)
+					g.Expect(tiersOverlap && windowsOverlap).To(gomega.BeFalse(), "%s overlaps %s", pa.ID, pb.ID)
+				}
+			}
+			g.Expect(current).To(gomega.Equal(len(tiers)))
+		}
+	})
+
+	t.Run("discounts larger quantities", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		d := generate(1, testScale, testAnchor)
+
+		for i := 0; i < len(d.prices); i += len(tiers) {
+			for j := 1; j < len(tiers); j++ {
+				g.Expect(d.prices[i+j].Price).To(gomega.BeNumerically("<=", d.prices[i+j-1].Price))
+			}
+		}
+	})
+
+	t.Run("scores evaluations from 1 to 5", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		d := generate(1, testScale, testAnchor)
+
+		for _, e := range d.evaluations {
+			for _, score := range []int{e.KesesuaianProduk, e.KualitasProduk, e.Harga, e.Reputasi} {
+				g.Expect(score).To(gomega.BeNumerically(">=", 1))
+				g.Expect(score).To(gomega.BeNumerically("<=", 5))
+			}
+		}
+	})
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"kg/procurement/cmd/config"
+	"kg/procurement/cmd/dependency"
+	"kg/procurement/cmd/utils"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/benbjohnson/clock"
+)
+
+func main() {
+	var (
+		sc     scale
+		seed   uint64
+		anchor string
+		out    string
+	)
+	flag.IntVar(&sc.vendors, "vendors", 50, "number of vendors")
+	flag.IntVar(&sc.categories, "categories", 10, "number of product categories")
+	flag.IntVar(&sc.products, "products", 200, "number of products")
+	flag.IntVar(&sc.offers, "offers", 3, "number of vendors offering each product")
+	flag.IntVar(&sc.windows, "windows", 3, "number of validity windows priced per offer, the last one holds the anchor")
+	flag.IntVar(&sc.evaluations, "evaluations", 2, "number of evaluations per vendor")
+	flag.IntVar(&sc.emails, "emails", 5, "number of email statuses per vendor")
+	flag.Uint64Var(&seed, "seed", 1, "seed of the generated data, the same seed and anchor generate the same rows")
+	flag.StringVar(&anchor, "anchor", "", "date the generated prices are valid at as YYYY-MM-DD, today by default")
+	flag.StringVar(&out, "out", "", "write fixtures into this directory instead of the database")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: go run ./scripts/generator [flags]\n\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "Generates synthetic vendors, products and prices, rows are upserted by id so a run can be repeated.\n\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	anchorDate := time.Now()
+	if anchor != "" {
+		var err error
+		if anchorDate, err = time.Parse(time.DateOnly, anchor); err != nil {
+			utils.Logger.Fatalf("invalid anchor %s: %v", anchor, err)
+		}
+	}
+
+	d := generate(seed, sc, anchorDate)
+	if out != "" {
+		if err := writeFixtures(out, d); err != nil {
+			utils.Logger.Fatal(err.Error())
+		}
+		printCounts(d, fmt.Sprintf("Fixtures written to %s", out))
+		return
+	}
+
+	cfg := config.Load()
+	conn := dependency.NewPostgreSQL(cfg.Common.Postgres)
+	defer conn.Close()
+
+	if err := writeDatabase(context.Background(), conn, clock.New(), d); err != nil {
+		utils.Logger.Fatal(err.Error())
+	}
+	printCounts(d, "Rows upserted into the database")
+}
+
+func printCounts(d dataset, title string) {
+	fmt.Println(title)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TABLE\tROWS")
+	for _, t := range d.tables() {
+		fmt.Fprintf(w, "%s\t%d\n", t.name, t.count)
+	}
+	_ = w.Flush()
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"kg/procurement/internal/common/database"
+	"kg/procurement/internal/mailer"
+	"kg/procurement/internal/product"
+	"kg/procurement/internal/vendors"
+	"os"
+	"path/filepath"
+
+	"github.com/benbjohnson/clock"
+)
+
+// generatedTable is the rows of a table, tables are listed after the tables they refer to
+type generatedTable struct {
+	name  string
+	rows  any
+	count int
+}
+
+func (d dataset) tables() []generatedTable {
+	return []generatedTable{
+		{"uom", d.uoms, len(d.uoms)},
+		{"product_category", d.categories, len(d.categories)},
+		{"product_type", d.productTypes, len(d.productTypes)},
+		{"product", d.products, len(d.products)},
+		{"vendor", d.vendors, len(d.vendors)},
+		{"product_vendor", d.productVendors, len(d.productVendors)},
+		{"price", d.prices, len(d.prices)},
+		{"vendor_evaluation", d.evaluations, len(d.evaluations)},
+		{"email_status", d.emailStatuses, len(d.emailStatuses)},
+	}
+}
+
+// writeFixtures writes a <table>.json fixture per table into dir, the seeder loads them
+// back with go run ./scripts/seeder dir
+func writeFixtures(dir string, d dataset) error {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return err
+	}
+	for _, t := range d.tables() {
+		raw, err := json.MarshalIndent(t.rows, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(dir, t.name+".json"), raw, 0o600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeDatabase upserts the dataset through the seeders as a single unit of work
+func writeDatabase(ctx context.Context, conn database.DBConnector, clock clock.Clock, d dataset) error {
+	return database.RunInTx(ctx, conn, func(tx database.DBConnector) error {
+		productSeeder := product.NewSeeder(product.NewDBSeederWriter(tx, clock))
+		vendorSeeder := vendors.NewSeeder(vendors.NewDBSeederWriter(tx, clock))
+		mailerSeeder := mailer.NewSeeder(mailer.NewDBSeederWriter(tx, clock))
+
+		steps := []func() error{
+			func() error { return productSeeder.SetupUOM(ctx, d.uoms) },
+			func() error { return productSeeder.SetupProductCategory(ctx, d.categories) },
+			func() error { return productSeeder.SetupProductType(ctx, d.productTypes) },
+			func() error { return productSeeder.SetupProducts(ctx, d.products) },
+			func() error { return vendorSeeder.SetupVendors(ctx, d.vendors) },
+			func() error { return productSeeder.SetupProductVendor(ctx, d.productVendors) },
+			func() error { return productSeeder.SetupPrice(ctx, d.prices) },
+			func() error { return vendorSeeder.SetupEvaluations(ctx, d.evaluations) },
+			func() error { return mailerSeeder.SetupEmailStatuses(ctx, d.emailStatuses) },
+		}
+		for _, step := range steps {
+			if err := step(); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
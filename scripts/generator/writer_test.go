@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"kg/procurement/internal/product"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/benbjohnson/clock"
+	"github.com/jmoiron/sqlx"
+	"github.com/onsi/gomega"
+)
+
+func Test_writeFixtures(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+	d := generate(1, testScale, testAnchor)
+	dir := filepath.Join(t.TempDir(), "generated")
+
+	g.Expect(writeFixtures(dir, d)).To(gomega.Succeed())
+
+	for _, table := range d.tables() {
+		g.Expect(filepath.Join(dir, table.name+".json")).To(gomega.BeAnExistingFile())
+	}
+
+	raw, err := os.ReadFile(filepath.Join(dir, "price.json"))
+	g.Expect(err).To(gomega.BeNil())
+	var prices []product.Price
+	g.Expect(json.Unmarshal(raw, &prices)).To(gomega.Succeed())
+	g.Expect(prices).To(gomega.HaveLen(len(d.prices)))
+	g.Expect(prices[0].ValidFrom.Equal(d.prices[0].ValidFrom)).To(gomega.BeTrue())
+}
+
+func Test_writeDatabase(t *testing.T) {
+	t.Parallel()
+
+	small := scale{vendors: 1, categories: 1, products: 1, offers: 1, windows: 1, evaluations: 1, emails: 1}
+	setup := func(t *testing.T) (*sqlx.DB, sqlmock.Sqlmock) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { db.Close() })
+		return sqlx.NewDb(db, "sqlmock"), mock
+	}
+
+	t.Run("upserts every row in a transaction", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		db, mock := setup(t)
+		d := generate(1, small, testAnchor)
+
+		mock.ExpectBegin()
+		for _, table := range d.tables() {
+			for i := 0; i < table.count; i++ {
+				mock.ExpectExec("INSERT INTO " + table.name + " ").WillReturnResult(sqlmock.NewResult(0, 1))
+			}
+		}
+		mock.ExpectCommit()
+
+		g.Expect(writeDatabase(context.Background(), db, clock.NewMock(), d)).To(gomega.Succeed())
+		g.Expect(mock.ExpectationsWereMet()).To(gomega.BeNil())
+	})
+
+	t.Run("rolls back when a row fails", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		db, mock := setup(t)
+		d := generate(1, small, testAnchor)
+
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO uom").WillReturnError(errors.New("error"))
+		mock.ExpectRollback()
+
+		g.Expect(writeDatabase(context.Background(), db, clock.NewMock(), d)).ToNot(gomega.Succeed())
+		g.Expect(mock.ExpectationsWereMet()).To(gomega.BeNil())
+	})
+}
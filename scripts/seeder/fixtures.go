@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/tidwall/jsonc"
+)
+
+// findFixtures lists the fixture files under paths by the table they seed, a fixture is
+// a .json or .jsonc file named after its table. paths may mix files and directories,
+// directories are walked recursively
+func findFixtures(paths []string) (map[string][]string, error) {
+	fixtures := map[string][]string{}
+	add := func(path string) {
+		ext := filepath.Ext(path)
+		table := strings.TrimSuffix(filepath.Base(path), ext)
+		fixtures[table] = append(fixtures[table], path)
+	}
+
+	for _, root := range paths {
+		info, err := os.Stat(root)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			if !isFixture(root) {
+				return nil, fmt.Errorf("%s is not a .json or .jsonc fixture", root)
+			}
+			add(filepath.Clean(root))
+			continue
+		}
+
+		err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.IsDir() && isFixture(path) {
+				add(path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, files := range fixtures {
+		sort.Strings(files)
+	}
+	return fixtures, nil
+}
+
+func isFixture(path string) bool {
+	ext := filepath.Ext(path)
+	return ext == ".json" || ext == ".jsonc"
+}
+
+func readFixture(path string) ([]byte, error) {
+	raw, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+
+	// enable jsonc support, lots of editor already support it
+	// for easier to maintain the fixtures
+	// https://code.visualstudio.com/docs/languages/json#_json-with-comments
+	// https://changelog.com/news/jsonc-is-a-superset-of-json-which-supports-comments-6LwR
+	if strings.HasSuffix(path, ".jsonc") {
+		return jsonc.ToJSON(raw), nil
+	}
+	return raw, nil
+}
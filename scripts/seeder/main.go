@@ -2,279 +2,161 @@ package main
 
 import (
 	"context"
-	"encoding/json"
-	"io"
+	"errors"
+	"flag"
+	"fmt"
 	"kg/procurement/cmd/config"
 	"kg/procurement/cmd/dependency"
 	"kg/procurement/cmd/utils"
+	"kg/procurement/internal/common/database"
+	"kg/procurement/internal/mailer"
 	"kg/procurement/internal/product"
 	"kg/procurement/internal/vendors"
 	"os"
-	"path/filepath"
 	"strings"
-	"time"
+	"text/tabwriter"
 
 	"github.com/benbjohnson/clock"
-	"github.com/tidwall/jsonc"
 )
 
-const (
-	productFixtureFile         = "./fixtures/product/product.jsonc"
-	productCategoryFixtureFile = "./fixtures/product/product_category.jsonc"
-	productTypeFixtureFile     = "./fixtures/product/product_type.jsonc"
-	uomFixtureFile             = "./fixtures/product/uom.jsonc"
-	vendorFixtureFile          = "./fixtures/vendors/vendor.jsonc"
-	productVendorFixtureFile   = "./fixtures/product/product_vendor.jsonc"
-	priceFixtureFile           = "./fixtures/product/price.jsonc"
-)
-
-var (
-	productSeeder *product.Seeder
-	vendorSeeder  *vendors.Seeder
-)
-
-func main() {
-	if len(os.Args) < 2 {
-		utils.Logger.Info("Usage: go run scripts/seeder/main.go [product|product_category|product_type|uom|vendor|product_vendor].")
-		return
-	}
-
-	ctx := context.Background()
+const defaultFixturePath = "./fixtures"
 
-	bootstrapSeeder()
-	defer productSeeder.Close()
+// errDryRun rolls back the transaction of a dry run
+var errDryRun = errors.New("dry run")
 
-	switch os.Args[1] {
-	case "product":
-		seedProduct(ctx)
-	case "product_category":
-		seedProductCategory(ctx)
-	case "product_type":
-		seedProductType(ctx)
-	case "uom":
-		seedUOM(ctx)
-	case "vendor":
-		seedVendor(ctx)
-	case "product_vendor":
-		seedProductVendor(ctx)
-	case "price":
-		seedPrice(ctx)
-	default:
-		utils.Logger.Info("Usage: go run scripts/seeder/main.go [product|product_category|product_type|uom|vendor|product_vendor].")
-	}
+type seedOptions struct {
+	dryRun   bool
+	truncate bool
 }
 
-func bootstrapSeeder() {
-	cfg := config.Load()
-
-	clock := clock.New()
-	db := dependency.NewPostgreSQL(cfg.Common.Postgres)
-
-	productSeeder = product.NewSeeder(
-		product.NewDBSeederWriter(db, clock),
-	)
-
-	vendorSeeder = vendors.NewSeeder(
-		vendors.NewDBSeederWriter(db, clock),
-	)
+type tableCount struct {
+	table string
+	rows  int
 }
 
-func seedProduct(ctx context.Context) {
-	var products []product.Product
-
-	// parsing issue from data given not conforming to RFC3339 format
-	var temp []struct {
-		product.Product
-		ModifiedDate string `json:"modified_date"`
-	}
-	byteValue := readBytesFromFixture(productFixtureFile)
-	if err := json.Unmarshal(byteValue, &temp); err != nil {
-		utils.Logger.Fatalf("Error unmarshalling")
-	}
-
-	for _, tProduct := range temp {
-		product := tProduct.Product
-		product.ModifiedDate, _ = time.Parse(time.DateTime, tProduct.ModifiedDate)
-		products = append(products, product)
-	}
-
-	if err := productSeeder.SetupProducts(ctx, products); err != nil {
-		utils.Logger.Fatal(err.Error())
-	}
-}
-
-func seedProductCategory(ctx context.Context) {
-	var productCategory []product.ProductCategory
-
-	// parsing issue from data given not conforming to RFC3339 format
-	var temp []struct {
-		product.ProductCategory
-		ModifiedDate string `json:"modified_date"`
-	}
-	byteValue := readBytesFromFixture(productCategoryFixtureFile)
-	if err := json.Unmarshal(byteValue, &temp); err != nil {
-		utils.Logger.Fatal("Error unmarshalling")
-	}
-
-	for _, tCategory := range temp {
-		category := tCategory.ProductCategory
-		category.ModifiedDate, _ = time.Parse(time.DateTime, tCategory.ModifiedDate)
-		productCategory = append(productCategory, category)
-	}
-
-	if err := productSeeder.SetupProductCategory(ctx, productCategory); err != nil {
-		utils.Logger.Fatal(err.Error())
-	}
-}
-
-func seedProductType(ctx context.Context) {
-	var productType []product.ProductType
-
-	// parsing issue from data given not conforming to RFC3339 format
-	var temp []struct {
-		product.ProductType
-		ModifiedDate string `json:"modified_date"`
-	}
-	byteValue := readBytesFromFixture(productTypeFixtureFile)
-	if err := json.Unmarshal(byteValue, &temp); err != nil {
-		utils.Logger.Fatal("Error unmarshalling")
+func main() {
+	var (
+		opts   seedOptions
+		filter string
+	)
+	flag.BoolVar(&opts.dryRun, "dry-run", false, "seed in a transaction that is rolled back, reporting what would be written")
+	flag.BoolVar(&opts.truncate, "truncate", false, "empty the seeded tables first, along with the tables referring to them")
+	flag.StringVar(&filter, "tables", "", "comma separated tables to seed, every table with a fixture by default")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: go run ./scripts/seeder [flags] [fixture file or directory...]\n\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "Fixtures are named after their table, %s is seeded by default.\n\n", defaultFixturePath)
+		flag.PrintDefaults()
 	}
+	flag.Parse()
 
-	for _, tType := range temp {
-		prodType := tType.ProductType
-		prodType.ModifiedDate, _ = time.Parse(time.DateTime, tType.ModifiedDate)
-		productType = append(productType, prodType)
+	paths := flag.Args()
+	if len(paths) == 0 {
+		paths = []string{defaultFixturePath}
 	}
 
-	if err := productSeeder.SetupProductType(ctx, productType); err != nil {
+	fixtures, err := findFixtures(paths)
+	if err != nil {
 		utils.Logger.Fatal(err.Error())
 	}
-}
-
-func seedUOM(ctx context.Context) {
-	var uoms []product.UOM
-
-	// parsing issue from data given not conforming to RFC3339 format
-	var temp []struct {
-		product.UOM
-		ModifiedDate string `json:"modified_date"`
-	}
-	byteValue := readBytesFromFixture(uomFixtureFile)
-	if err := json.Unmarshal(byteValue, &temp); err != nil {
-		utils.Logger.Fatal("Error unmarshalling")
-	}
-
-	for _, tUOM := range temp {
-		uom := tUOM.UOM
-		uom.ModifiedDate, _ = time.Parse(time.DateTime, tUOM.ModifiedDate)
-		uoms = append(uoms, uom)
-	}
-
-	if err := productSeeder.SetupUOM(ctx, uoms); err != nil {
+	order, err := seedOrder(selectTables(fixtures, filter))
+	if err != nil {
 		utils.Logger.Fatal(err.Error())
 	}
-}
-
-func seedVendor(ctx context.Context) {
-	var listOfVendor []vendors.Vendor
-
-	var temp []struct {
-		vendors.Vendor
-		ModifiedDate string `json:"modified_date"`
-		Date         string `json:"dt"`
-	}
-	byteValue := readBytesFromFixture(vendorFixtureFile)
-	if err := json.Unmarshal(byteValue, &temp); err != nil {
-		utils.Logger.Fatal("Error unmarshalling")
+	if len(order) == 0 {
+		utils.Logger.Fatal("no fixtures to seed")
 	}
 
-	for _, tempVendor := range temp {
-		theVendor := tempVendor.Vendor
-		theVendor.ModifiedDate, _ = time.Parse(time.DateTime, tempVendor.ModifiedDate)
-		theVendor.Date, _ = time.Parse(time.DateOnly, tempVendor.Date)
-		listOfVendor = append(listOfVendor, theVendor)
-	}
+	cfg := config.Load()
+	conn := dependency.NewPostgreSQL(cfg.Common.Postgres)
+	defer conn.Close()
 
-	if err := vendorSeeder.SetupVendors(ctx, listOfVendor); err != nil {
+	counts, err := seed(context.Background(), conn, clock.New(), order, fixtures, opts)
+	if err != nil {
 		utils.Logger.Fatal(err.Error())
 	}
+	printCounts(counts, opts)
 }
 
-func seedProductVendor(ctx context.Context) {
-	var listOfProductVendor []product.ProductVendor
-
-	// parsing issue from data given not conforming to RFC3339 format
-	var temp []struct {
-		product.ProductVendor
-		ModifiedDate string `json:"modified_date"`
+// selectTables lists the tables with a fixture, restricted to filter when set
+func selectTables(fixtures map[string][]string, filter string) []string {
+	var names []string
+	if filter == "" {
+		for name := range fixtures {
+			names = append(names, name)
+		}
+		return names
 	}
-	byteValue := readBytesFromFixture(productVendorFixtureFile)
-	if err := json.Unmarshal(byteValue, &temp); err != nil {
-		utils.Logger.Fatal("Error unmarshalling")
-	}
-
-	for _, tProductVendor := range temp {
-		pv := tProductVendor.ProductVendor
-		pv.ModifiedDate, _ = time.Parse(time.DateTime, tProductVendor.ModifiedDate)
-		listOfProductVendor = append(listOfProductVendor, pv)
-	}
-
-	if err := productSeeder.SetupProductVendor(ctx, listOfProductVendor); err != nil {
-		utils.Logger.Fatal(err.Error())
+	for _, name := range strings.Split(filter, ",") {
+		names = append(names, strings.TrimSpace(name))
 	}
+	return names
 }
 
-func seedPrice(ctx context.Context) {
-	var listOfPrice []product.Price
-
-	// parsing issue from data given not conforming to RFC3339 format
-	var temp []struct {
-		product.Price
-		ValidFrom     string `json:"valid_from"`
-		ValidTo       string `json:"valid_to"`
-		ReferenceDate string `json:"reference_date"`
-		ModifiedDate  string `json:"modified_date"`
-	}
-	byteValue := readBytesFromFixture(priceFixtureFile)
-	if err := json.Unmarshal(byteValue, &temp); err != nil {
-		utils.Logger.Fatal("Error unmarshalling")
-		panic(err)
-	}
-
-	for _, tPrice := range temp {
-		p := tPrice.Price
-		p.ValidFrom, _ = time.Parse(time.DateTime, tPrice.ValidFrom)
-		p.ValidTo, _ = time.Parse(time.DateTime, tPrice.ValidTo)
-		p.ReferenceDate, _ = time.Parse(time.DateOnly, tPrice.ReferenceDate)
-		p.ModifiedDate, _ = time.Parse(time.DateTime, tPrice.ModifiedDate)
-		listOfPrice = append(listOfPrice, p)
-	}
-
-	if err := productSeeder.SetupPrice(ctx, listOfPrice); err != nil {
-		panic(err)
-	}
+// seed writes the fixtures of every table in order as a single unit of work, a failing
+// fixture leaves the database untouched
+func seed(
+	ctx context.Context,
+	conn database.DBConnector,
+	clock clock.Clock,
+	order []string,
+	fixtures map[string][]string,
+	opts seedOptions,
+) ([]tableCount, error) {
+	var counts []tableCount
+	err := database.RunInTx(ctx, conn, func(tx database.DBConnector) error {
+		s := seeders{
+			product: product.NewSeeder(product.NewDBSeederWriter(tx, clock)),
+			vendor:  vendors.NewSeeder(vendors.NewDBSeederWriter(tx, clock)),
+			mailer:  mailer.NewSeeder(mailer.NewDBSeederWriter(tx, clock)),
+		}
+
+		if opts.truncate {
+			if _, err := tx.ExecContext(ctx, truncateQuery(order)); err != nil {
+				return err
+			}
+		}
+
+		for _, name := range order {
+			t, _ := lookupTable(name)
+			if len(fixtures[name]) == 0 {
+				return fmt.Errorf("no fixture for table %s", name)
+			}
+
+			count := tableCount{table: name}
+			for _, path := range fixtures[name] {
+				raw, err := readFixture(path)
+				if err != nil {
+					return err
+				}
+				rows, err := t.seed(ctx, s, raw)
+				if err != nil {
+					return fmt.Errorf("seeding %s from %s: %w", name, path, err)
+				}
+				count.rows += rows
+			}
+			counts = append(counts, count)
+		}
+
+		if opts.dryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if errors.Is(err, errDryRun) {
+		return counts, nil
+	}
+	return counts, err
 }
 
-func readBytesFromFixture(filePath string) []byte {
-	file, err := os.Open(filepath.Clean(filePath))
-	if err != nil {
-		utils.Logger.Fatal(err.Error())
-	}
-	defer func() {
-		_ = file.Close()
-	}()
-
-	byteValue, err := io.ReadAll(file)
-	if err != nil {
-		utils.Logger.Fatal(err.Error())
+func printCounts(counts []tableCount, opts seedOptions) {
+	if opts.dryRun {
+		fmt.Println("Dry run, nothing was written")
 	}
 
-	// enable jsonc support, lots of editor already support it
-	// for easier to maintain the fixtures
-	// https://code.visualstudio.com/docs/languages/json#_json-with-comments
-	// https://changelog.com/news/jsonc-is-a-superset-of-json-which-supports-comments-6LwR
-	if strings.HasSuffix(filePath, ".jsonc") {
-		return jsonc.ToJSON(byteValue)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TABLE\tROWS UPSERTED")
+	for _, count := range counts {
+		fmt.Fprintf(w, "%s\t%d\n", count.table, count.rows)
 	}
-	return byteValue
+	_ = w.Flush()
 }
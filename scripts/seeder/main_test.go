@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/benbjohnson/clock"
+	"github.com/jmoiron/sqlx"
+	"github.com/onsi/gomega"
+)
+
+func Test_seedOrder(t *testing.T) {
+	t.Parallel()
+
+	t.Run("orders tables after their dependencies", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		order, err := seedOrder([]string{"price", "vendor", "product_vendor", "product", "uom", "product_type", "product_category"})
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(order).To(gomega.Equal([]string{"uom", "product_category", "product_type", "product", "vendor", "product_vendor", "price"}))
+	})
+
+	t.Run("leaves out the tables that aren't seeded", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		order, err := seedOrder([]string{"price", "product_vendor"})
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(order).To(gomega.Equal([]string{"product_vendor", "price"}))
+	})
+
+	t.Run("seeds evaluations and email statuses after vendors", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		order, err := seedOrder([]string{"email_status", "vendor_evaluation", "vendor"})
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(order).To(gomega.Equal([]string{"vendor", "vendor_evaluation", "email_status"}))
+	})
+
+	t.Run("rejects unknown tables", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		_, err := seedOrder([]string{"invoice"})
+		g.Expect(err).To(gomega.MatchError("no seeder for table invoice"))
+	})
+}
+
+func Test_parseFixtureTime(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+
+	g.Expect(parseFixtureTime("2024-12-01 10:00:00")).To(gomega.Equal(time.Date(2024, time.December, 1, 10, 0, 0, 0, time.UTC)))
+	g.Expect(parseFixtureTime("2024-12-01")).To(gomega.Equal(time.Date(2024, time.December, 1, 0, 0, 0, 0, time.UTC)))
+	g.Expect(parseFixtureTime("2024-12-01T10:00:00Z")).To(gomega.Equal(time.Date(2024, time.December, 1, 10, 0, 0, 0, time.UTC)))
+	g.Expect(parseFixtureTime("")).To(gomega.BeZero())
+}
+
+func Test_findFixtures(t *testing.T) {
+	t.Parallel()
+
+	t.Run("walks a directory", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		fixtures, err := findFixtures([]string{"../../fixtures"})
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(fixtures).To(gomega.HaveLen(len(tables)))
+		g.Expect(fixtures["vendor"]).To(gomega.Equal([]string{"../../fixtures/vendors/vendor.jsonc"}))
+	})
+
+	t.Run("takes a single file", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		fixtures, err := findFixtures([]string{"../../fixtures/product/uom.jsonc"})
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(fixtures).To(gomega.Equal(map[string][]string{"uom": {"../../fixtures/product/uom.jsonc"}}))
+	})
+
+	t.Run("rejects other files", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		_, err := findFixtures([]string{"main.go"})
+		g.Expect(err).ToNot(gomega.BeNil())
+	})
+}
+
+func Test_selectTables(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewWithT(t)
+	fixtures := map[string][]string{"uom": {"uom.json"}, "price": {"price.json"}}
+
+	g.Expect(selectTables(fixtures, "")).To(gomega.ConsistOf("uom", "price"))
+	g.Expect(selectTables(fixtures, "price, vendor")).To(gomega.Equal([]string{"price", "vendor"}))
+}
+
+func Test_seed(t *testing.T) {
+	t.Parallel()
+
+	setup := func(t *testing.T) (*sqlx.DB, sqlmock.Sqlmock, map[string][]string) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { db.Close() })
+
+		dir := t.TempDir()
+		path := filepath.Join(dir, "uom.jsonc")
+		fixture := `[
+			// comments are allowed
+			{"id": "1", "name": "PCS", "modified_date": "2024-12-01 10:00:00"},
+			{"id": "2", "name": "BOX", "modified_date": "2024-12-01 10:00:00"}
+		]`
+		if err := os.WriteFile(path, []byte(fixture), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return sqlx.NewDb(db, "sqlmock"), mock, map[string][]string{"uom": {path}}
+	}
+	upsertUOM := regexp.QuoteMeta("INSERT INTO uom") + "(.|\n)*" + regexp.QuoteMeta("ON CONFLICT (id) DO UPDATE SET")
+
+	t.Run("upserts the fixtures in a transaction", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		db, mock, fixtures := setup(t)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("TRUNCATE TABLE uom CASCADE")).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(upsertUOM).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(upsertUOM).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		counts, err := seed(context.Background(), db, clock.NewMock(), []string{"uom"}, fixtures, seedOptions{truncate: true})
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(counts).To(gomega.Equal([]tableCount{{table: "uom", rows: 2}}))
+		g.Expect(mock.ExpectationsWereMet()).To(gomega.BeNil())
+	})
+
+	t.Run("rolls back a dry run", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		db, mock, fixtures := setup(t)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(upsertUOM).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(upsertUOM).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectRollback()
+
+		counts, err := seed(context.Background(), db, clock.NewMock(), []string{"uom"}, fixtures, seedOptions{dryRun: true})
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(counts).To(gomega.Equal([]tableCount{{table: "uom", rows: 2}}))
+		g.Expect(mock.ExpectationsWereMet()).To(gomega.BeNil())
+	})
+
+	t.Run("rolls back when a row fails", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		db, mock, fixtures := setup(t)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(upsertUOM).WillReturnError(errors.New("error"))
+		mock.ExpectRollback()
+
+		_, err := seed(context.Background(), db, clock.NewMock(), []string{"uom"}, fixtures, seedOptions{})
+		g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("seeding uom")))
+		g.Expect(mock.ExpectationsWereMet()).To(gomega.BeNil())
+	})
+
+	t.Run("needs a fixture for every table", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		db, mock, fixtures := setup(t)
+
+		mock.ExpectBegin()
+		mock.ExpectRollback()
+
+		_, err := seed(context.Background(), db, clock.NewMock(), []string{"vendor"}, fixtures, seedOptions{})
+		g.Expect(err).To(gomega.MatchError("no fixture for table vendor"))
+	})
+}
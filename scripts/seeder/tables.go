@@ -0,0 +1,287 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"kg/procurement/internal/mailer"
+	"kg/procurement/internal/product"
+	"kg/procurement/internal/vendors"
+	"strings"
+	"time"
+)
+
+// seeders write the rows of a fixture
+type seeders struct {
+	product *product.Seeder
+	vendor  *vendors.Seeder
+	mailer  *mailer.Seeder
+}
+
+// table is a table fixtures are seeded into, dependsOn lists the tables its foreign keys
+// refer to. seed decodes a fixture and returns the number of rows it wrote
+type table struct {
+	name      string
+	dependsOn []string
+	seed      func(ctx context.Context, s seeders, raw []byte) (int, error)
+}
+
+var tables = []table{
+	{name: "uom", seed: seedUOM},
+	{name: "product_category", seed: seedProductCategory},
+	{name: "product_type", seed: seedProductType},
+	{name: "product", dependsOn: []string{"product_category", "uom", "product_type"}, seed: seedProduct},
+	{name: "vendor", seed: seedVendor},
+	{name: "product_vendor", dependsOn: []string{"product", "uom"}, seed: seedProductVendor},
+	{name: "price", dependsOn: []string{"vendor", "product_vendor", "uom"}, seed: seedPrice},
+	{name: "vendor_evaluation", dependsOn: []string{"vendor"}, seed: seedVendorEvaluation},
+	{name: "email_status", dependsOn: []string{"vendor"}, seed: seedEmailStatus},
+}
+
+func lookupTable(name string) (table, bool) {
+	for _, t := range tables {
+		if t.name == name {
+			return t, true
+		}
+	}
+	return table{}, false
+}
+
+// seedOrder sorts names so every table comes after the tables it depends on, ties keep
+// the order of tables. A dependency left out of names is expected to be seeded already
+func seedOrder(names []string) ([]string, error) {
+	pending := map[string]bool{}
+	for _, name := range names {
+		if _, ok := lookupTable(name); !ok {
+			return nil, fmt.Errorf("no seeder for table %s", name)
+		}
+		pending[name] = true
+	}
+
+	var order []string
+	for len(pending) > 0 {
+		progressed := false
+		for _, t := range tables {
+			if !pending[t.name] || dependsOnAny(t, pending) {
+				continue
+			}
+			order = append(order, t.name)
+			delete(pending, t.name)
+			progressed = true
+		}
+		if !progressed {
+			return nil, fmt.Errorf("tables depend on each other: %v", pending)
+		}
+	}
+	return order, nil
+}
+
+func dependsOnAny(t table, names map[string]bool) bool {
+	for _, dependency := range t.dependsOn {
+		if names[dependency] {
+			return true
+		}
+	}
+	return false
+}
+
+// truncateQuery empties tables at once, CASCADE also empties the tables referring to
+// them such as price history and anomalies
+func truncateQuery(names []string) string {
+	return fmt.Sprintf("TRUNCATE TABLE %s CASCADE", strings.Join(names, ", "))
+}
+
+// fixtureTimeLayouts are the date formats of the fixtures, the hand written ones don't
+// follow RFC3339 while the generated ones do
+var fixtureTimeLayouts = []string{time.DateTime, time.DateOnly, time.RFC3339}
+
+// parseFixtureTime parses a fixture date, an empty or unknown one is the zero time
+func parseFixtureTime(value string) time.Time {
+	for _, layout := range fixtureTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// parseOptionalFixtureTime parses a fixture date that may be left empty, it is nil then
+func parseOptionalFixtureTime(value string) *time.Time {
+	t := parseFixtureTime(value)
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+func seedProduct(ctx context.Context, s seeders, raw []byte) (int, error) {
+	var temp []struct {
+		product.Product
+		ModifiedDate string `json:"modified_date"`
+	}
+	if err := json.Unmarshal(raw, &temp); err != nil {
+		return 0, err
+	}
+
+	products := make([]product.Product, 0, len(temp))
+	for _, tProduct := range temp {
+		p := tProduct.Product
+		p.ModifiedDate = parseFixtureTime(tProduct.ModifiedDate)
+		products = append(products, p)
+	}
+	return len(products), s.product.SetupProducts(ctx, products)
+}
+
+func seedProductCategory(ctx context.Context, s seeders, raw []byte) (int, error) {
+	var temp []struct {
+		product.ProductCategory
+		ModifiedDate string `json:"modified_date"`
+	}
+	if err := json.Unmarshal(raw, &temp); err != nil {
+		return 0, err
+	}
+
+	categories := make([]product.ProductCategory, 0, len(temp))
+	for _, tCategory := range temp {
+		category := tCategory.ProductCategory
+		category.ModifiedDate = parseFixtureTime(tCategory.ModifiedDate)
+		categories = append(categories, category)
+	}
+	return len(categories), s.product.SetupProductCategory(ctx, categories)
+}
+
+func seedProductType(ctx context.Context, s seeders, raw []byte) (int, error) {
+	var temp []struct {
+		product.ProductType
+		ModifiedDate string `json:"modified_date"`
+	}
+	if err := json.Unmarshal(raw, &temp); err != nil {
+		return 0, err
+	}
+
+	productTypes := make([]product.ProductType, 0, len(temp))
+	for _, tType := range temp {
+		prodType := tType.ProductType
+		prodType.ModifiedDate = parseFixtureTime(tType.ModifiedDate)
+		productTypes = append(productTypes, prodType)
+	}
+	return len(productTypes), s.product.SetupProductType(ctx, productTypes)
+}
+
+func seedUOM(ctx context.Context, s seeders, raw []byte) (int, error) {
+	var temp []struct {
+		product.UOM
+		ModifiedDate string `json:"modified_date"`
+	}
+	if err := json.Unmarshal(raw, &temp); err != nil {
+		return 0, err
+	}
+
+	uoms := make([]product.UOM, 0, len(temp))
+	for _, tUOM := range temp {
+		uom := tUOM.UOM
+		uom.ModifiedDate = parseFixtureTime(tUOM.ModifiedDate)
+		uoms = append(uoms, uom)
+	}
+	return len(uoms), s.product.SetupUOM(ctx, uoms)
+}
+
+func seedVendor(ctx context.Context, s seeders, raw []byte) (int, error) {
+	var temp []struct {
+		vendors.Vendor
+		ModifiedDate string `json:"modified_date"`
+		Date         string `json:"dt"`
+	}
+	if err := json.Unmarshal(raw, &temp); err != nil {
+		return 0, err
+	}
+
+	listOfVendor := make([]vendors.Vendor, 0, len(temp))
+	for _, tempVendor := range temp {
+		theVendor := tempVendor.Vendor
+		theVendor.ModifiedDate = parseFixtureTime(tempVendor.ModifiedDate)
+		theVendor.Date = parseFixtureTime(tempVendor.Date)
+		listOfVendor = append(listOfVendor, theVendor)
+	}
+	return len(listOfVendor), s.vendor.SetupVendors(ctx, listOfVendor)
+}
+
+func seedProductVendor(ctx context.Context, s seeders, raw []byte) (int, error) {
+	var temp []struct {
+		product.ProductVendor
+		ModifiedDate string `json:"modified_date"`
+	}
+	if err := json.Unmarshal(raw, &temp); err != nil {
+		return 0, err
+	}
+
+	listOfProductVendor := make([]product.ProductVendor, 0, len(temp))
+	for _, tProductVendor := range temp {
+		pv := tProductVendor.ProductVendor
+		pv.ModifiedDate = parseFixtureTime(tProductVendor.ModifiedDate)
+		listOfProductVendor = append(listOfProductVendor, pv)
+	}
+	return len(listOfProductVendor), s.product.SetupProductVendor(ctx, listOfProductVendor)
+}
+
+func seedPrice(ctx context.Context, s seeders, raw []byte) (int, error) {
+	var temp []struct {
+		product.Price
+		ValidFrom     string `json:"valid_from"`
+		ValidTo       string `json:"valid_to"`
+		ReferenceDate string `json:"reference_date"`
+		ModifiedDate  string `json:"modified_date"`
+	}
+	if err := json.Unmarshal(raw, &temp); err != nil {
+		return 0, err
+	}
+
+	listOfPrice := make([]product.Price, 0, len(temp))
+	for _, tPrice := range temp {
+		p := tPrice.Price
+		p.ValidFrom = parseFixtureTime(tPrice.ValidFrom)
+		p.ValidTo = parseOptionalFixtureTime(tPrice.ValidTo)
+		p.ReferenceDate = parseOptionalFixtureTime(tPrice.ReferenceDate)
+		p.ModifiedDate = parseFixtureTime(tPrice.ModifiedDate)
+		listOfPrice = append(listOfPrice, p)
+	}
+	return len(listOfPrice), s.product.SetupPrice(ctx, listOfPrice)
+}
+
+func seedVendorEvaluation(ctx context.Context, s seeders, raw []byte) (int, error) {
+	var temp []struct {
+		vendors.VendorEvaluation
+		ModifiedDate string `json:"modified_date"`
+	}
+	if err := json.Unmarshal(raw, &temp); err != nil {
+		return 0, err
+	}
+
+	evaluations := make([]vendors.VendorEvaluation, 0, len(temp))
+	for _, tEvaluation := range temp {
+		evaluation := tEvaluation.VendorEvaluation
+		evaluation.ModifiedDate = parseFixtureTime(tEvaluation.ModifiedDate)
+		evaluations = append(evaluations, evaluation)
+	}
+	return len(evaluations), s.vendor.SetupEvaluations(ctx, evaluations)
+}
+
+func seedEmailStatus(ctx context.Context, s seeders, raw []byte) (int, error) {
+	var temp []struct {
+		mailer.EmailStatus
+		DateSent     string `json:"date_sent"`
+		ModifiedDate string `json:"modified_date"`
+	}
+	if err := json.Unmarshal(raw, &temp); err != nil {
+		return 0, err
+	}
+
+	statuses := make([]mailer.EmailStatus, 0, len(temp))
+	for _, tStatus := range temp {
+		status := tStatus.EmailStatus
+		status.DateSent = parseFixtureTime(tStatus.DateSent)
+		status.ModifiedDate = parseFixtureTime(tStatus.ModifiedDate)
+		statuses = append(statuses, status)
+	}
+	return len(statuses), s.mailer.SetupEmailStatuses(ctx, statuses)
+}